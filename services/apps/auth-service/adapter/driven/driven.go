@@ -35,6 +35,9 @@ var Set = wire.NewSet(
 	gorm.NewIndicatorDimensionalRuleInterfaceRepository,
 	gorm.NewTTechnicalIndicatorRepo,
 	gorm.NewDataApplicationFormRepo,
+	gorm.NewAuthRecertRepo,
+	gorm.NewAuthAttributePolicyRepo,
+	gorm.NewAuthBreakGlassRepo,
 	util.NewHTTPClient,
 	mqHandlers,
 	gorm.NewConsumeAuthRequestRepo,
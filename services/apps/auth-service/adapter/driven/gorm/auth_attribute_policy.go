@@ -0,0 +1,116 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+)
+
+// AuthAttributePolicyRepo 属性策略的仓储接口
+type AuthAttributePolicyRepo interface {
+	// Create 创建属性策略
+	Create(ctx context.Context, policy *model.TAuthAttributePolicy) error
+	// Update 更新属性策略
+	Update(ctx context.Context, policy *model.TAuthAttributePolicy) error
+	// Delete 删除属性策略
+	Delete(ctx context.Context, id string) error
+	// Get 获取属性策略
+	Get(ctx context.Context, id string) (*model.TAuthAttributePolicy, error)
+	// List 获取属性策略列表
+	List(ctx context.Context, req *dto.AttributePolicyListArgs) (int, []*model.TAuthAttributePolicy, error)
+	// ListEnabled 获取所有启用的属性策略
+	ListEnabled(ctx context.Context) ([]*model.TAuthAttributePolicy, error)
+}
+
+type authAttributePolicyRepo struct {
+	db *gorm.DB
+}
+
+func NewAuthAttributePolicyRepo(db *gorm.DB) AuthAttributePolicyRepo {
+	return &authAttributePolicyRepo{db: db}
+}
+
+// Create 创建属性策略
+func (r *authAttributePolicyRepo) Create(ctx context.Context, policy *model.TAuthAttributePolicy) error {
+	if err := r.db.WithContext(ctx).Create(policy).Error; err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// Update 更新属性策略，不更新创建人和创建时间
+func (r *authAttributePolicyRepo) Update(ctx context.Context, policy *model.TAuthAttributePolicy) error {
+	tx := r.db.WithContext(ctx).Model(new(model.TAuthAttributePolicy)).
+		Where("id = ?", policy.ID).
+		Select("name", "description", "object_types", "label_ids", "type", "column_action", "row_operator", "row_value",
+			"exempt_csf_level", "exempt_department_ids", "enabled", "updated_by", "updated_by_name", "updated_at").
+		Updates(policy)
+	if tx.Error != nil {
+		return errorcode.PublicDatabaseErr.Detail(tx.Error.Error())
+	}
+	if tx.RowsAffected == 0 {
+		return errorcode.PublicResourceNotExistErr.Err()
+	}
+	return nil
+}
+
+// Delete 删除属性策略
+func (r *authAttributePolicyRepo) Delete(ctx context.Context, id string) error {
+	tx := r.db.WithContext(ctx).Where("id = ?", id).Delete(new(model.TAuthAttributePolicy))
+	if tx.Error != nil {
+		return errorcode.PublicDatabaseErr.Detail(tx.Error.Error())
+	}
+	if tx.RowsAffected == 0 {
+		return errorcode.PublicResourceNotExistErr.Err()
+	}
+	return nil
+}
+
+// Get 获取属性策略
+func (r *authAttributePolicyRepo) Get(ctx context.Context, id string) (*model.TAuthAttributePolicy, error) {
+	policy := &model.TAuthAttributePolicy{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.PublicResourceNotExistErr.Err()
+		}
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return policy, nil
+}
+
+// List 获取属性策略列表
+func (r *authAttributePolicyRepo) List(ctx context.Context, req *dto.AttributePolicyListArgs) (int, []*model.TAuthAttributePolicy, error) {
+	db := r.db.WithContext(ctx).Model(new(model.TAuthAttributePolicy))
+	if req.Keyword != "" {
+		db = db.Where("name like ?", "%"+req.Keyword+"%")
+	}
+	if req.ObjectType != "" {
+		db = db.Where("(object_types = '' OR CONCAT(',', object_types, ',') like ?)", "%,"+req.ObjectType+",%")
+	}
+	if req.LabelID != "" {
+		db = db.Where("CONCAT(',', label_ids, ',') like ?", "%,"+req.LabelID+",%")
+	}
+	total := int64(0)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	var policies []*model.TAuthAttributePolicy
+	if err := Paginate(req.Offset, req.Limit)(db).Order("updated_at DESC").Find(&policies).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return int(total), policies, nil
+}
+
+// ListEnabled 获取所有启用的属性策略，按创建时间排序
+func (r *authAttributePolicyRepo) ListEnabled(ctx context.Context) ([]*model.TAuthAttributePolicy, error) {
+	var policies []*model.TAuthAttributePolicy
+	if err := r.db.WithContext(ctx).Where("enabled = ?", true).Order("created_at").Find(&policies).Error; err != nil {
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return policies, nil
+}
@@ -0,0 +1,185 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+)
+
+// AuthBreakGlassRepo 紧急访问的仓储接口
+type AuthBreakGlassRepo interface {
+	// Create 创建紧急访问，用户在资源上已有生效中的紧急访问时返回 BreakGlassGrantExistErr。并发申请时只有一个成功
+	Create(ctx context.Context, grant *model.TAuthBreakGlassGrant) error
+	// Delete 删除紧急访问，只用于创建授权失败时撤销申请
+	Delete(ctx context.Context, id string) error
+	// Get 获取紧急访问
+	Get(ctx context.Context, id string) (*model.TAuthBreakGlassGrant, error)
+	// UpdatePolicyIDs 记录紧急访问创建的策略ID
+	UpdatePolicyIDs(ctx context.Context, grant *model.TAuthBreakGlassGrant) error
+	// List 获取紧急访问列表
+	List(ctx context.Context, req *dto.BreakGlassGrantListArgs) (int, []*model.TAuthBreakGlassGrant, error)
+	// ListDue 获取已到过期时间需要回收的紧急访问
+	ListDue(ctx context.Context, now time.Time) ([]*model.TAuthBreakGlassGrant, error)
+	// Revoke 紧急访问生效中时记录回收结果，返回是否记录成功。多实例同时回收时只有一个成功
+	Revoke(ctx context.Context, grant *model.TAuthBreakGlassGrant) (bool, error)
+	// UpdateAudit 更新事后复核的审核信息
+	UpdateAudit(ctx context.Context, grant *model.TAuthBreakGlassGrant) error
+	// ResetAuditByProcDefKeys 审核流程被删除时，审核中的事后复核恢复为未发起审核
+	ResetAuditByProcDefKeys(ctx context.Context, procDefKeys []string, message string) error
+}
+
+type authBreakGlassRepo struct {
+	db *gorm.DB
+}
+
+func NewAuthBreakGlassRepo(db *gorm.DB) AuthBreakGlassRepo {
+	return &authBreakGlassRepo{db: db}
+}
+
+// Create 创建紧急访问。生效中的紧急访问占用 object_id:subject_id 唯一键，回收时释放
+func (r *authBreakGlassRepo) Create(ctx context.Context, grant *model.TAuthBreakGlassGrant) error {
+	if grant.Status == dto.BreakGlassActive {
+		grant.ActiveKey = lo.ToPtr(grant.ObjectID + ":" + grant.SubjectID)
+	}
+	if err := r.db.WithContext(ctx).Create(grant).Error; err != nil {
+		if isDuplicateEntry(err) {
+			return errorcode.BreakGlassGrantExistErr.Err()
+		}
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// Delete 删除紧急访问
+func (r *authBreakGlassRepo) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(new(model.TAuthBreakGlassGrant)).Error; err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// Get 获取紧急访问
+func (r *authBreakGlassRepo) Get(ctx context.Context, id string) (*model.TAuthBreakGlassGrant, error) {
+	grant := &model.TAuthBreakGlassGrant{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(grant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.PublicResourceNotExistErr.Err()
+		}
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return grant, nil
+}
+
+// UpdatePolicyIDs 记录紧急访问创建的策略ID
+func (r *authBreakGlassRepo) UpdatePolicyIDs(ctx context.Context, grant *model.TAuthBreakGlassGrant) error {
+	err := r.db.WithContext(ctx).Model(new(model.TAuthBreakGlassGrant)).
+		Where("id = ?", grant.ID).
+		Update("policy_ids", grant.PolicyIDs).Error
+	if err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// List 获取紧急访问列表
+func (r *authBreakGlassRepo) List(ctx context.Context, req *dto.BreakGlassGrantListArgs) (int, []*model.TAuthBreakGlassGrant, error) {
+	db := r.db.WithContext(ctx).Model(new(model.TAuthBreakGlassGrant))
+	if req.ObjectId != "" {
+		db = db.Where("object_id = ?", req.ObjectId)
+	}
+	if req.SubjectId != "" {
+		db = db.Where("subject_id = ?", req.SubjectId)
+	}
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	// 只返回查看人申请的，或者查看人是数据 Owner 的紧急访问
+	if req.ViewerId != "" {
+		db = db.Where("(subject_id = ? OR CONCAT(',', owner_ids, ',') LIKE ?)", req.ViewerId, "%,"+req.ViewerId+",%")
+	}
+	total := int64(0)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	var grants []*model.TAuthBreakGlassGrant
+	if err := Paginate(req.Offset, req.Limit)(db).Order("created_at DESC").Find(&grants).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return int(total), grants, nil
+}
+
+// ListDue 获取已到过期时间需要回收的紧急访问
+func (r *authBreakGlassRepo) ListDue(ctx context.Context, now time.Time) ([]*model.TAuthBreakGlassGrant, error) {
+	var grants []*model.TAuthBreakGlassGrant
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expired_at <= ?", dto.BreakGlassActive, now).
+		Order("expired_at").
+		Find(&grants).Error
+	if err != nil {
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return grants, nil
+}
+
+// Revoke 紧急访问生效中时记录回收结果
+func (r *authBreakGlassRepo) Revoke(ctx context.Context, grant *model.TAuthBreakGlassGrant) (bool, error) {
+	tx := r.db.WithContext(ctx).Model(new(model.TAuthBreakGlassGrant)).
+		Where("id = ? AND status = ?", grant.ID, dto.BreakGlassActive).
+		Updates(map[string]any{
+			"status":          grant.Status,
+			"active_key":      nil,
+			"revoked_at":      grant.RevokedAt,
+			"revoked_by":      grant.RevokedBy,
+			"revoked_by_name": grant.RevokedByName,
+			"revoke_reason":   grant.RevokeReason,
+		})
+	if tx.Error != nil {
+		return false, errorcode.PublicDatabaseErr.Detail(tx.Error.Error())
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// UpdateAudit 更新事后复核的审核信息
+func (r *authBreakGlassRepo) UpdateAudit(ctx context.Context, grant *model.TAuthBreakGlassGrant) error {
+	err := r.db.WithContext(ctx).Model(new(model.TAuthBreakGlassGrant)).
+		Where("id = ?", grant.ID).
+		Updates(map[string]any{
+			"apply_id":      grant.ApplyID,
+			"proc_def_key":  grant.ProcDefKey,
+			"audit_phase":   grant.AuditPhase,
+			"audit_message": grant.AuditMessage,
+		}).Error
+	if err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// ResetAuditByProcDefKeys 审核流程被删除时，审核中的事后复核恢复为未发起审核
+func (r *authBreakGlassRepo) ResetAuditByProcDefKeys(ctx context.Context, procDefKeys []string, message string) error {
+	err := r.db.WithContext(ctx).Model(new(model.TAuthBreakGlassGrant)).
+		Where("proc_def_key in ? AND audit_phase = ?", procDefKeys, constant.AUDIT_AUDITING).
+		Updates(map[string]any{
+			"audit_phase":   constant.AUDIT_PENDING,
+			"audit_message": message,
+		}).Error
+	if err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// isDuplicateEntry 是否违反唯一索引
+func isDuplicateEntry(err error) bool {
+	myErr := new(mysql.MySQLError)
+	return errors.As(err, &myErr) && myErr.Number == 1062
+}
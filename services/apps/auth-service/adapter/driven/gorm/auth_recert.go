@@ -0,0 +1,251 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+)
+
+// AuthRecertRepo 权限复核活动、复核项的仓储接口
+type AuthRecertRepo interface {
+	// CreateCampaign 创建复核活动及其复核项
+	CreateCampaign(ctx context.Context, campaign *model.TAuthRecertCampaign, items []*model.TAuthRecertItem) error
+	// GetCampaign 获取复核活动
+	GetCampaign(ctx context.Context, id string) (*model.TAuthRecertCampaign, error)
+	// ListCampaigns 获取复核活动列表
+	ListCampaigns(ctx context.Context, req *dto.RecertCampaignListArgs) (int, []*model.TAuthRecertCampaign, error)
+	// ListDueCampaigns 获取已到截止时间需要回收未复核授权的复核活动
+	ListDueCampaigns(ctx context.Context, now time.Time) ([]*model.TAuthRecertCampaign, error)
+	// TransitCampaign 复核活动的状态为 from 时更新为 to，返回是否更新成功。多实例同时更新时只有一个成功
+	TransitCampaign(ctx context.Context, id, from, to string) (bool, error)
+	// CountItems 按复核人、复核结果统计复核项的数量
+	CountItems(ctx context.Context, campaignIDs ...string) (map[string][]*model.TAuthRecertItemCount, error)
+	// HasReviewer 复核活动中是否有需要该用户复核的复核项
+	HasReviewer(ctx context.Context, campaignID, reviewerID string) (bool, error)
+	// ListItems 获取复核项列表，Limit 为 0 时返回所有
+	ListItems(ctx context.Context, req *dto.RecertItemListArgs) (int, []*model.TAuthRecertItem, error)
+	// ListPendingItems 按ID顺序获取复核活动中ID大于 afterID 的待复核项，用于跳过回收失败的复核项逐批处理
+	ListPendingItems(ctx context.Context, campaignID, afterID string, limit int) ([]*model.TAuthRecertItem, error)
+	// GetItems 获取复核活动的指定复核项
+	GetItems(ctx context.Context, campaignID string, ids []string) ([]*model.TAuthRecertItem, error)
+	// ClaimItem 复核项为待复核时记录复核结果，返回是否记录成功。用于避免同一个复核项被重复复核
+	ClaimItem(ctx context.Context, item *model.TAuthRecertItem) (bool, error)
+	// ReleaseItem 执行复核结果失败时，复核项恢复为待复核并记录失败原因
+	ReleaseItem(ctx context.Context, id, message string) error
+}
+
+type authRecertRepo struct {
+	db *gorm.DB
+}
+
+func NewAuthRecertRepo(db *gorm.DB) AuthRecertRepo {
+	return &authRecertRepo{db: db}
+}
+
+// CreateCampaign 创建复核活动及其复核项
+func (r *authRecertRepo) CreateCampaign(ctx context.Context, campaign *model.TAuthRecertCampaign, items []*model.TAuthRecertItem) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(items, 500).Error
+	})
+	if err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
+
+// GetCampaign 获取复核活动
+func (r *authRecertRepo) GetCampaign(ctx context.Context, id string) (*model.TAuthRecertCampaign, error) {
+	campaign := &model.TAuthRecertCampaign{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(campaign).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorcode.PublicResourceNotExistErr.Err()
+		}
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return campaign, nil
+}
+
+// ListCampaigns 获取复核活动列表
+func (r *authRecertRepo) ListCampaigns(ctx context.Context, req *dto.RecertCampaignListArgs) (int, []*model.TAuthRecertCampaign, error) {
+	db := r.db.WithContext(ctx).Model(new(model.TAuthRecertCampaign))
+	if req.Keyword != "" {
+		db = db.Where("name like ?", "%"+req.Keyword+"%")
+	}
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	if req.ViewerID != "" {
+		db = db.Where("(created_by = ? OR id IN (?))", req.ViewerID,
+			r.db.Model(new(model.TAuthRecertItem)).Select("campaign_id").Where("reviewer_id = ?", req.ViewerID))
+	}
+	total := int64(0)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	var campaigns []*model.TAuthRecertCampaign
+	if err := Paginate(req.Offset, req.Limit)(db).Order("created_at DESC").Find(&campaigns).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return int(total), campaigns, nil
+}
+
+// ListDueCampaigns 获取已到截止时间需要回收未复核授权的复核活动，包括上次没有回收完的
+func (r *authRecertRepo) ListDueCampaigns(ctx context.Context, now time.Time) ([]*model.TAuthRecertCampaign, error) {
+	var campaigns []*model.TAuthRecertCampaign
+	err := r.db.WithContext(ctx).
+		Where("(status = ? AND deadline <= ?) OR status = ?", dto.RecertCampaignActive, now, dto.RecertCampaignClosing).
+		Order("deadline").
+		Find(&campaigns).Error
+	if err != nil {
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return campaigns, nil
+}
+
+// TransitCampaign 复核活动的状态为 from 时更新为 to
+func (r *authRecertRepo) TransitCampaign(ctx context.Context, id, from, to string) (bool, error) {
+	updates := map[string]any{"status": to}
+	if to == dto.RecertCampaignCompleted || to == dto.RecertCampaignCanceled {
+		updates["completed_at"] = time.Now()
+	}
+	tx := r.db.WithContext(ctx).Model(new(model.TAuthRecertCampaign)).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if tx.Error != nil {
+		return false, errorcode.PublicDatabaseErr.Detail(tx.Error.Error())
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// CountItems 按复核人、复核结果统计复核项的数量，返回复核活动ID到统计结果的映射
+func (r *authRecertRepo) CountItems(ctx context.Context, campaignIDs ...string) (map[string][]*model.TAuthRecertItemCount, error) {
+	result := make(map[string][]*model.TAuthRecertItemCount, len(campaignIDs))
+	if len(campaignIDs) == 0 {
+		return result, nil
+	}
+	var rows []struct {
+		CampaignID string `gorm:"column:campaign_id"`
+		model.TAuthRecertItemCount
+	}
+	err := r.db.WithContext(ctx).Model(new(model.TAuthRecertItem)).
+		Select("campaign_id, reviewer_id, reviewer_name, decision, count(*) as count").
+		Where("campaign_id in ?", campaignIDs).
+		Group("campaign_id, reviewer_id, reviewer_name, decision").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	for i := range rows {
+		result[rows[i].CampaignID] = append(result[rows[i].CampaignID], &rows[i].TAuthRecertItemCount)
+	}
+	return result, nil
+}
+
+// HasReviewer 复核活动中是否有需要该用户复核的复核项
+func (r *authRecertRepo) HasReviewer(ctx context.Context, campaignID, reviewerID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(new(model.TAuthRecertItem)).
+		Where("campaign_id = ? AND reviewer_id = ?", campaignID, reviewerID).
+		Count(&count).Error
+	if err != nil {
+		return false, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return count > 0, nil
+}
+
+// ListItems 获取复核项列表
+func (r *authRecertRepo) ListItems(ctx context.Context, req *dto.RecertItemListArgs) (int, []*model.TAuthRecertItem, error) {
+	db := r.db.WithContext(ctx).Model(new(model.TAuthRecertItem)).Where("campaign_id = ?", req.CampaignID)
+	if req.Decision != "" {
+		db = db.Where("decision = ?", req.Decision)
+	}
+	if req.ReviewerID != "" {
+		db = db.Where("reviewer_id = ?", req.ReviewerID)
+	}
+	if req.Keyword != "" {
+		nameLike := "%" + req.Keyword + "%"
+		db = db.Where("object_name like ? or subject_name like ?", nameLike, nameLike)
+	}
+	total := int64(0)
+	if err := db.Count(&total).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	if req.Limit > 0 {
+		db = Paginate(req.Offset, req.Limit)(db)
+	}
+	var items []*model.TAuthRecertItem
+	if err := db.Order("object_id, subject_type, subject_id").Find(&items).Error; err != nil {
+		return 0, nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return int(total), items, nil
+}
+
+// ListPendingItems 按ID顺序获取ID大于 afterID 的待复核项
+func (r *authRecertRepo) ListPendingItems(ctx context.Context, campaignID, afterID string, limit int) ([]*model.TAuthRecertItem, error) {
+	var items []*model.TAuthRecertItem
+	err := r.db.WithContext(ctx).
+		Where("campaign_id = ? AND decision = ? AND id > ?", campaignID, dto.RecertPending, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return items, nil
+}
+
+// GetItems 获取复核活动的指定复核项
+func (r *authRecertRepo) GetItems(ctx context.Context, campaignID string, ids []string) ([]*model.TAuthRecertItem, error) {
+	var items []*model.TAuthRecertItem
+	if err := r.db.WithContext(ctx).Where("campaign_id = ? AND id in ?", campaignID, ids).Find(&items).Error; err != nil {
+		return nil, errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return items, nil
+}
+
+// ClaimItem 复核项为待复核时记录复核结果
+func (r *authRecertRepo) ClaimItem(ctx context.Context, item *model.TAuthRecertItem) (bool, error) {
+	tx := r.db.WithContext(ctx).Model(new(model.TAuthRecertItem)).
+		Where("id = ? AND decision = ?", item.ID, dto.RecertPending).
+		Updates(map[string]any{
+			"decision":        item.Decision,
+			"new_expired_at":  item.NewExpiredAt,
+			"comment":         item.Comment,
+			"decided_by":      item.DecidedBy,
+			"decided_by_name": item.DecidedByName,
+			"decided_at":      item.DecidedAt,
+			"message":         "",
+		})
+	if tx.Error != nil {
+		return false, errorcode.PublicDatabaseErr.Detail(tx.Error.Error())
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// ReleaseItem 复核项恢复为待复核并记录失败原因
+func (r *authRecertRepo) ReleaseItem(ctx context.Context, id, message string) error {
+	err := r.db.WithContext(ctx).Model(new(model.TAuthRecertItem)).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"decision":        dto.RecertPending,
+			"new_expired_at":  nil,
+			"comment":         "",
+			"decided_by":      "",
+			"decided_by_name": "",
+			"decided_at":      nil,
+			"message":         message,
+		}).Error
+	if err != nil {
+		return errorcode.PublicDatabaseErr.Detail(err.Error())
+	}
+	return nil
+}
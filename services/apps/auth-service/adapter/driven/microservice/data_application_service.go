@@ -29,9 +29,24 @@ type DataApplicationServiceOwner struct {
 	OwnerName string `json:"owner_name"`
 }
 
+// DataApplicationServiceParamGetRes 接口详情中的参数配置，只包含用到的字段
+type DataApplicationServiceParamGetRes struct {
+	ServiceParam struct {
+		// 数据视图Id
+		DataViewId string `json:"data_view_id"`
+		// 返回参数
+		DataTableResponseParams []struct {
+			// 英文名称
+			EnName string `json:"en_name"`
+		} `json:"data_table_response_params"`
+	} `json:"service_param"`
+}
+
 type DataApplicationServiceRepo interface {
 	// DataApplicationServiceGet 接口详情
 	DataApplicationServiceGet(ctx context.Context, id string) (res *DataApplicationServiceGetRes, err error)
+	// DataApplicationServiceParamGet 接口的参数配置，包括接口所属的逻辑视图和返回的字段
+	DataApplicationServiceParamGet(ctx context.Context, id string) (res *DataApplicationServiceParamGetRes, err error)
 }
 
 type dataApplicationServiceRepo struct{}
@@ -63,3 +78,26 @@ func (u *dataApplicationServiceRepo) DataApplicationServiceGet(ctx context.Conte
 
 	return
 }
+
+func (u *dataApplicationServiceRepo) DataApplicationServiceParamGet(ctx context.Context, id string) (res *DataApplicationServiceParamGetRes, err error) {
+	// 策略验证的调用方不一定携带用户令牌，使用内部接口
+	resp, err := req.SetContext(ctx).
+		Get(settings.Instance.Services.DataApplicationService + "/api/internal/data-application-service/v1/services/" + id)
+	if err != nil {
+		log.WithContext(ctx).Error("DataApplicationServiceParamGet", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.InternalError, err.Error())
+	}
+	if resp.StatusCode != 200 {
+		log.WithContext(ctx).Error("DataApplicationServiceParamGet", zap.Error(errors.New(resp.String())))
+		return nil, errorcode.Detail(errorcode.InternalError, resp.String())
+	}
+
+	res = &DataApplicationServiceParamGetRes{}
+	err = resp.UnmarshalJson(&res)
+	if err != nil {
+		log.WithContext(ctx).Error("DataApplicationServiceParamGet", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.InternalError, err.Error())
+	}
+
+	return
+}
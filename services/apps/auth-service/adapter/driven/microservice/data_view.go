@@ -70,6 +70,10 @@ type DataViewRepo interface {
 	UpdateSubViewInternally(ctx context.Context, id string, spec *dto.SubViewSpec) (*dto.SubView, error)
 	// GetSubView 获取指定 ID 的行列规则（子视图）
 	GetSubView(ctx context.Context, id string) (*dto.SubView, error)
+	// ListDataViewIDsByDepartment 返回属于指定部门的逻辑视图 ID 列表
+	ListDataViewIDsByDepartment(ctx context.Context, departmentID string, includeSubDepartment bool) ([]string, error)
+	// GetFieldLabels 返回逻辑视图 ID 到字段技术名称、分级标签 ID 映射的映射
+	GetFieldLabels(ctx context.Context, ids []string) (map[string]map[string]string, error)
 }
 
 type dataViewRepo struct{}
@@ -554,3 +558,138 @@ func (u *dataViewRepo) GetViewByKey(ctx context.Context, logicViewName string) (
 	}
 	return &result, nil
 }
+
+// ListDataViewIDsByDepartment implements DataViewRepo.
+func (u *dataViewRepo) ListDataViewIDsByDepartment(ctx context.Context, departmentID string, includeSubDepartment bool) ([]string, error) {
+	// limit 1000 records per page.
+	const limit = 1000
+
+	var ids []string
+	for offset, totalCount := 1, 0; offset == 1 || (offset-1)*limit < totalCount; offset++ {
+		// API Endpoint
+		base, err := url.Parse(settings.Instance.Services.DataView)
+		if err != nil {
+			log.WithContext(ctx).Error("parse data-view address fail", zap.Error(err), zap.String("address", settings.Instance.Services.DataView))
+			return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("parse data-view address %q fail: %v", settings.Instance.Services.DataView, err))
+		}
+		base.Path = path.Join(base.Path, "/api/internal/data-view/v1/form-view")
+
+		// Query parameters
+		var query = make(url.Values)
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("department_id", departmentID)
+		query.Set("include_sub_department", strconv.FormatBool(includeSubDepartment))
+		base.RawQuery = query.Encode()
+
+		// create http request
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.String(), http.NoBody)
+		if err != nil {
+			log.WithContext(ctx).Error("create http request fail", zap.Error(err), zap.String("method", http.MethodGet), zap.Stringer("url", base))
+			return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("create http request fail: %v", err))
+		}
+		// Set authorization
+		req.Header.Set("authorization", util.GetToken(ctx))
+
+		// send http request
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			log.WithContext(ctx).Error("send http request fail", zap.Error(err), zap.String("method", http.MethodGet), zap.Stringer("url", base))
+			return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("send http request fail: %v", err))
+		}
+
+		// status code other than 200 is considered a failure
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			log.WithContext(ctx).Error("invoke API ListDataView fail", zap.String("method", http.MethodGet), zap.Stringer("url", base), zap.ByteString("response.body", body))
+			return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("%s %s, status: %s, body: %s", req.Method, req.URL, resp.Status, body))
+		}
+
+		var result listDataViewResponse
+		// decode response body as json
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			log.WithContext(ctx).Error("decode response body of API ListDataView fail", zap.Error(err))
+			return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("decode response body of API ListDataView fail: %v", err))
+		}
+
+		totalCount = result.TotalCount
+		for _, e := range result.Entries {
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Only contains necessary fields.
+type dataViewFieldsResponseEntry struct {
+	FormViewID string `json:"form_view_id"`
+	Fields     []struct {
+		TechnicalName string `json:"technical_name"`
+		LabelID       string `json:"label_id"`
+	} `json:"fields"`
+}
+
+// GetFieldLabels implements DataViewRepo.
+func (u *dataViewRepo) GetFieldLabels(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	labels := make(map[string]map[string]string, len(ids))
+	if len(ids) == 0 {
+		return labels, nil
+	}
+	// API Endpoint
+	base, err := url.Parse(settings.Instance.Services.DataView)
+	if err != nil {
+		log.WithContext(ctx).Error("parse data-view address fail", zap.Error(err), zap.String("address", settings.Instance.Services.DataView))
+		return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("parse data-view address %q fail: %v", settings.Instance.Services.DataView, err))
+	}
+	base.Path = path.Join(base.Path, "/api/internal/data-view/v1/form-view/fields")
+
+	// Query parameters
+	var query = make(url.Values)
+	for _, id := range ids {
+		query.Add("id", id)
+	}
+	base.RawQuery = query.Encode()
+
+	// create http request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.String(), http.NoBody)
+	if err != nil {
+		log.WithContext(ctx).Error("create http request fail", zap.Error(err), zap.String("method", http.MethodGet), zap.Stringer("url", base))
+		return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("create http request fail: %v", err))
+	}
+
+	// send http request
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.WithContext(ctx).Error("send http request fail", zap.Error(err), zap.String("method", http.MethodGet), zap.Stringer("url", base))
+		return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("send http request fail: %v", err))
+	}
+	defer resp.Body.Close()
+
+	// status code other than 200 is considered a failure
+	if resp.StatusCode != http.StatusOK {
+		var body []byte
+		if body, err = io.ReadAll(resp.Body); err != nil {
+			log.WithContext(ctx).Error("read response body fail", zap.Error(err))
+		}
+		log.WithContext(ctx).Error("invoke API BatchViewsFields fail", zap.Error(err), zap.String("method", http.MethodGet), zap.Stringer("url", base), zap.ByteString("response.body", body))
+		return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("%s %s, status: %s, body: %s", req.Method, req.URL, resp.Status, body))
+	}
+
+	var result []dataViewFieldsResponseEntry
+	// decode response body as json
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		log.WithContext(ctx).Error("decode response body of API BatchViewsFields fail", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.InternalError, fmt.Sprintf("decode response body of API BatchViewsFields fail: %v", err))
+	}
+	for _, view := range result {
+		fieldLabels := make(map[string]string, len(view.Fields))
+		for _, field := range view.Fields {
+			fieldLabels[field.TechnicalName] = field.LabelID
+		}
+		labels[view.FormViewID] = fieldLabels
+	}
+	return labels, nil
+}
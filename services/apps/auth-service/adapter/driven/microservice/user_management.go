@@ -47,7 +47,7 @@ func (u *userManagementRepo) GetUserById(ctx context.Context, userId string) (re
 
 	params := map[string]string{
 		"userId": userId,
-		"fields": "name,roles,parent_deps",
+		"fields": "name,roles,parent_deps,csf_level",
 	}
 
 	//req.DevMode()
@@ -93,7 +93,7 @@ func (u *userManagementRepo) GetUserByIdV1(ctx context.Context, userId string) (
 
 	params := map[string]string{
 		"userId": userId,
-		"fields": "name,roles,parent_deps",
+		"fields": "name,roles,parent_deps,csf_level",
 	}
 
 	resp, err := req.SetContext(ctx).
@@ -3,6 +3,7 @@ package custom
 import (
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/dwh_data_auth_request"
 	"github.com/kweaver-ai/idrm-go-common/workflow"
 )
@@ -11,17 +12,20 @@ type WFConsumerRegister struct {
 	wf                        workflow.WorkflowInterface
 	authRequestRepo           gorm.ConsumeAuthRequestRepo
 	dwhDataApplicationUseCase dwh_data_auth_request.UseCase
+	breakGlassUseCase         break_glass.UseCase
 }
 
 func NewWFConsumerRegister(
 	wf workflow.WorkflowInterface,
 	authRequestRepo gorm.ConsumeAuthRequestRepo,
 	dwhDataApplicationUseCase dwh_data_auth_request.UseCase,
+	breakGlassUseCase break_glass.UseCase,
 ) (*WFConsumerRegister, error) {
 	r := &WFConsumerRegister{
 		wf:                        wf,
 		authRequestRepo:           authRequestRepo,
 		dwhDataApplicationUseCase: dwhDataApplicationUseCase,
+		breakGlassUseCase:         breakGlassUseCase,
 	}
 	err := r.registerConsumeHandlers()
 	if err != nil {
@@ -44,5 +48,12 @@ func (r *WFConsumerRegister) registerConsumeHandlers() error {
 		r.dwhDataApplicationUseCase.ConsumerWorkflowAuditResultRequest,
 		r.dwhDataApplicationUseCase.ConsumerWorkflowAuditProcDeleteRequest,
 	)
+	// 紧急访问的事后复核
+	r.wf.RegistConusmeHandlers(
+		constant.BreakGlassReview,
+		r.breakGlassUseCase.ConsumerWorkflowAuditMsg,
+		r.breakGlassUseCase.ConsumerWorkflowAuditResultRequest,
+		r.breakGlassUseCase.ConsumerWorkflowAuditProcDeleteRequest,
+	)
 	return r.wf.Start()
 }
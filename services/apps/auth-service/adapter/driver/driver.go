@@ -5,7 +5,10 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/resources"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/dwh_auth_request_form"
 
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/attribute_policy"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/break_glass"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/indicator_dimensional_rule"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/recertification"
 	auth_v2 "github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v2/auth"
 )
 
@@ -16,5 +19,8 @@ var ProviderSet = wire.NewSet(
 	auth_v2.NewController,
 	indicator_dimensional_rule.New,
 	dwh_auth_request_form.NewAuthController,
+	recertification.NewController,
+	attribute_policy.NewController,
+	break_glass.NewController,
 	resources.NewRegisterClient,
 )
@@ -9,7 +9,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/attribute_policy"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/break_glass"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/indicator_dimensional_rule"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/recertification"
 	auth_v2 "github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v2/auth"
 	"github.com/kweaver-ai/idrm-go-common/interception"
 	"github.com/kweaver-ai/idrm-go-common/middleware"
@@ -53,6 +56,9 @@ type Router struct {
 	IndicatorDimensionalRuleController *indicator_dimensional_rule.Controller //指标维度规则
 	AuthV2Controller                   *auth_v2.Controller
 	DWHController                      *dwh_auth_request_form.Controller // 数仓数据授权申请
+	RecertificationController          *recertification.Controller       // 权限复核
+	AttributePolicyController          *attribute_policy.Controller      // 属性策略
+	BreakGlassController               *break_glass.Controller           // 紧急访问
 }
 
 func (r *Router) Register(engine *gin.Engine) error {
@@ -86,15 +92,17 @@ func (r *Router) RegisterApi(engine *gin.Engine) {
 
 	{
 		policyRouter := router.Group("/policy")
-		policyRouter.POST("", r.AuthV2Controller.Create)   // 策略创建
-		policyRouter.GET("", r.AuthV2Controller.Get)       // 策略详情
-		policyRouter.PUT("", r.AuthV2Controller.Update)    // 策略更新
-		policyRouter.DELETE("", r.AuthV2Controller.Delete) // 策略删除
+		policyRouter.POST("", r.AuthV2Controller.Create)         // 策略创建
+		policyRouter.GET("", r.AuthV2Controller.Get)             // 策略详情
+		policyRouter.PUT("", r.AuthV2Controller.Update)          // 策略更新
+		policyRouter.DELETE("", r.AuthV2Controller.Delete)       // 策略删除
+		policyRouter.POST("/what-if", r.AuthV2Controller.WhatIf) // 策略模拟
 
 		//资源接口
 		router.GET("/subject/objects", r.AuthV2Controller.GetObjectsBySubjectId)     // 访问者拥有的资源
 		router.GET("/sub-views", r.AuthV2Controller.ListSubViews)                    // 获取拥有指定动作权限的子视图列表
 		router.GET("/menu-resource/actions", r.AuthV2Controller.MenuResourceActions) //查询菜单资源的允许的操作
+		router.POST("/enforce/explain", r.AuthV2Controller.Explain)                  //策略验证的解释
 		//策略验证
 		rawRouter := engine.Group("/api/auth-service/v1")
 		rawRouter.POST("/enforce", setContextWithToken, r.AuthV2Controller.Enforce) //策略验证
@@ -102,6 +110,7 @@ func (r *Router) RegisterApi(engine *gin.Engine) {
 		//routerInternal.GET("policies", r.AuthV2Controller.ListPolicies)                                            //获取策略列表
 		routerInternal.GET("/objects/policy/expired", r.AuthV2Controller.QueryPolicyExpiredObjects)                //查询某个资源有没有过期的
 		routerInternal.POST("/enforce", setContextWithToken, r.AuthV2Controller.Enforce)                           //数据权限验证
+		routerInternal.GET("/enforce/cache-stats", r.AuthV2Controller.DecisionCacheStats)                          //策略决策缓存的命中统计
 		routerInternal.POST("/rule/enforce", setContextWithToken, r.AuthV2Controller.RuleEnforce)                  //数据策略验证
 		routerInternal.POST("/menu-resource/enforce", setContextWithToken, r.AuthV2Controller.MenuResourceEnforce) //权限资源验证
 		routerInternal.GET("/menu-resource/actions", setContextWithToken, r.AuthV2Controller.MenuResourceActions)  //查询菜单资源的允许的操作
@@ -137,6 +146,37 @@ func (r *Router) RegisterApi(engine *gin.Engine) {
 		dwhDataAuthReqInternalRouter.GET("", r.DWHController.QueryApplicantDWHAuthReqFormInfo) //查询用户的申请单状态
 		dwhDataAuthReqInternalRouter.POST("/test", r.DWHController.TestAuditMsg)               //查询用户的申请单状态
 	}
+	//权限复核
+	{
+		recertRouter := router.Group("recertification-campaigns")
+		recertRouter.POST("", r.RecertificationController.Create)              //创建复核活动
+		recertRouter.GET("", r.RecertificationController.List)                 //获取复核活动列表
+		recertRouter.GET(":id", r.RecertificationController.Get)               //获取复核活动详情
+		recertRouter.POST(":id/cancel", r.RecertificationController.Cancel)    //取消复核活动
+		recertRouter.GET(":id/report", r.RecertificationController.Report)     //获取复核活动的报告
+		recertRouter.GET(":id/items", r.RecertificationController.ListItems)   //获取复核项列表
+		recertRouter.POST(":id/decisions", r.RecertificationController.Decide) //批量复核
+	}
+	//属性策略
+	{
+		attributePolicyRouter := router.Group("attribute-policies")
+		attributePolicyRouter.POST("", r.AttributePolicyController.Create)           //创建属性策略
+		attributePolicyRouter.GET("", r.AttributePolicyController.List)              //获取属性策略列表
+		attributePolicyRouter.GET(":id", r.AttributePolicyController.Get)            //获取属性策略详情
+		attributePolicyRouter.PUT(":id", r.AttributePolicyController.Update)         //更新属性策略
+		attributePolicyRouter.DELETE(":id", r.AttributePolicyController.Delete)      //删除属性策略
+		attributePolicyRouter.POST("evaluate", r.AttributePolicyController.Evaluate) //计算生效的属性策略
+		//内部接口
+		routerInternal.POST("/attribute-policies/evaluate", setContextWithToken, r.AttributePolicyController.Evaluate) //计算生效的属性策略
+	}
+	//紧急访问
+	{
+		breakGlassRouter := router.Group("break-glass-grants")
+		breakGlassRouter.POST("", r.BreakGlassController.Create)           //申请紧急访问
+		breakGlassRouter.GET("", r.BreakGlassController.List)              //获取紧急访问列表
+		breakGlassRouter.GET(":id", r.BreakGlassController.Get)            //获取紧急访问详情
+		breakGlassRouter.POST(":id/revoke", r.BreakGlassController.Revoke) //提前结束紧急访问
+	}
 
 }
 
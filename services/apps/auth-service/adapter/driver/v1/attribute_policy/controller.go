@@ -0,0 +1,174 @@
+package attribute_policy
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/form_validator"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/attribute_policy"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest/ginx"
+)
+
+type Controller struct {
+	service attribute_policy.UseCase
+}
+
+func NewController(service attribute_policy.UseCase) *Controller {
+	return &Controller{service: service}
+}
+
+// Create 创建属性策略
+//
+//	@Description	创建属性策略，按字段的分级标签和访问者的部门、密级脱敏、隐藏字段或过滤行
+//	@Tags			属性策略
+//	@Summary		创建属性策略
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.AttributePolicySpec	true	"请求参数"
+//	@Success		200	{object}	dto.IDResp				"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError			"失败响应参数"
+//	@Router			/api/auth-service/v1/attribute-policies [post]
+func (ctrl *Controller) Create(c *gin.Context) {
+	req := &dto.AttributePolicySpec{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	id, err := ctrl.service.Create(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, dto.NewIDResp(id))
+}
+
+// List 获取属性策略列表
+//
+//	@Description	获取属性策略列表
+//	@Tags			属性策略
+//	@Summary		获取属性策略列表
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			_	query		dto.AttributePolicyListArgs			true	"请求参数"
+//	@Success		200	{object}	dto.PageResult[dto.AttributePolicy]	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError						"失败响应参数"
+//	@Router			/api/auth-service/v1/attribute-policies [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	req := &dto.AttributePolicyListArgs{}
+	if _, err := form_validator.BindQueryAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.List(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Get 获取属性策略详情
+//
+//	@Description	获取属性策略详情
+//	@Tags			属性策略
+//	@Summary		获取属性策略详情
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			id	path		string				true	"策略ID"	Format(uuid)
+//	@Success		200	{object}	dto.AttributePolicy	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError		"失败响应参数"
+//	@Router			/api/auth-service/v1/attribute-policies/{id} [get]
+func (ctrl *Controller) Get(c *gin.Context) {
+	req := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.Get(c, req.ID)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Update 更新属性策略
+//
+//	@Description	更新属性策略
+//	@Tags			属性策略
+//	@Summary		更新属性策略
+//	@Accept			json
+//	@Produce		text/plain
+//	@Param			id	path		string					true	"策略ID"	Format(uuid)
+//	@Param			_	body		dto.AttributePolicySpec	true	"请求参数"
+//	@Success		200	{object}	string					"成功响应参数:OK"
+//	@Failure		400	{object}	rest.HttpError			"失败响应参数"
+//	@Router			/api/auth-service/v1/attribute-policies/{id} [put]
+func (ctrl *Controller) Update(c *gin.Context) {
+	uri := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, uri); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	req := &dto.AttributePolicySpec{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	if err := ctrl.service.Update(c, uri.ID, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, nil)
+}
+
+// Delete 删除属性策略
+//
+//	@Description	删除属性策略
+//	@Tags			属性策略
+//	@Summary		删除属性策略
+//	@Accept			text/plain
+//	@Produce		text/plain
+//	@Param			id	path		string			true	"策略ID"	Format(uuid)
+//	@Success		200	{object}	string			"成功响应参数:OK"
+//	@Failure		400	{object}	rest.HttpError	"失败响应参数"
+//	@Router			/api/auth-service/v1/attribute-policies/{id} [delete]
+func (ctrl *Controller) Delete(c *gin.Context) {
+	req := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	if err := ctrl.service.Delete(c, req.ID); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, nil)
+}
+
+// Evaluate 计算生效的属性策略
+//
+//	@Description	计算访问者访问逻辑视图、接口时生效的属性策略，返回需要脱敏、隐藏的字段和行过滤条件
+//	@Tags			属性策略
+//	@Summary		计算生效的属性策略
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.AttributePolicyEvaluateReq	true	"请求参数"
+//	@Success		200	{object}	dto.AttributeObligations		"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError					"失败响应参数"
+//	@Router			/api/auth-service/v1/attribute-policies/evaluate [post]
+func (ctrl *Controller) Evaluate(c *gin.Context) {
+	req := &dto.AttributePolicyEvaluateReq{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.Evaluate(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
@@ -0,0 +1,125 @@
+package break_glass
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/form_validator"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest/ginx"
+)
+
+type Controller struct {
+	service break_glass.UseCase
+}
+
+func NewController(service break_glass.UseCase) *Controller {
+	return &Controller{service: service}
+}
+
+// Create 申请紧急访问
+//
+//	@Description	申请紧急访问，不需要审批立即获得逻辑视图的读取权限，到期自动回收。申请后通知数据 Owner 和安全团队，并发起事后复核
+//	@Tags			紧急访问
+//	@Summary		申请紧急访问
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.BreakGlassGrantCreateReq	true	"请求参数"
+//	@Success		200	{object}	dto.IDResp						"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError					"失败响应参数"
+//	@Router			/api/auth-service/v1/break-glass-grants [post]
+func (ctrl *Controller) Create(c *gin.Context) {
+	req := &dto.BreakGlassGrantCreateReq{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	id, err := ctrl.service.Create(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, dto.NewIDResp(id))
+}
+
+// List 获取紧急访问列表
+//
+//	@Description	获取紧急访问列表。安全团队可以查看全部，其他用户只能查看自己申请的和自己是数据 Owner 的紧急访问
+//	@Tags			紧急访问
+//	@Summary		获取紧急访问列表
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			_	query		dto.BreakGlassGrantListArgs				true	"请求参数"
+//	@Success		200	{object}	dto.PageResult[dto.BreakGlassGrant]	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError							"失败响应参数"
+//	@Router			/api/auth-service/v1/break-glass-grants [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	req := &dto.BreakGlassGrantListArgs{}
+	if _, err := form_validator.BindQueryAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.List(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Get 获取紧急访问详情
+//
+//	@Description	获取紧急访问详情，包括事后复核的审核状态。只有申请人、数据 Owner 和安全团队可以查看
+//	@Tags			紧急访问
+//	@Summary		获取紧急访问详情
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			id	path		string				true	"紧急访问ID"	Format(uuid)
+//	@Success		200	{object}	dto.BreakGlassGrant	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError		"失败响应参数"
+//	@Router			/api/auth-service/v1/break-glass-grants/{id} [get]
+func (ctrl *Controller) Get(c *gin.Context) {
+	req := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.Get(c, req.ID)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Revoke 提前结束紧急访问
+//
+//	@Description	提前结束紧急访问，立即回收读取权限。只有申请人和安全团队可以结束
+//	@Tags			紧急访问
+//	@Summary		提前结束紧急访问
+//	@Accept			json
+//	@Produce		text/plain
+//	@Param			id	path		string							true	"紧急访问ID"	Format(uuid)
+//	@Param			_	body		dto.BreakGlassGrantRevokeReq	true	"请求参数"
+//	@Success		200	{object}	string							"成功响应参数:OK"
+//	@Failure		400	{object}	rest.HttpError					"失败响应参数"
+//	@Router			/api/auth-service/v1/break-glass-grants/{id}/revoke [post]
+func (ctrl *Controller) Revoke(c *gin.Context) {
+	uriReq := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, uriReq); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	req := &dto.BreakGlassGrantRevokeReq{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	if err := ctrl.service.Revoke(c, uriReq.ID, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, nil)
+}
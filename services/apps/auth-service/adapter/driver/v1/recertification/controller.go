@@ -0,0 +1,208 @@
+package recertification
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/form_validator"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/recertification"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest/ginx"
+)
+
+type Controller struct {
+	service recertification.UseCase
+}
+
+func NewController(service recertification.UseCase) *Controller {
+	return &Controller{service: service}
+}
+
+// Create 创建权限复核活动
+//
+//	@Description	创建权限复核活动，为复核范围内资源上的每个授权生成复核项，复核人为资源的 Owner
+//	@Tags			权限复核
+//	@Summary		创建权限复核活动
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.RecertCampaignCreateReq	true	"请求参数"
+//	@Success		200	{object}	dto.IDResp					"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError				"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns [post]
+func (ctrl *Controller) Create(c *gin.Context) {
+	req := &dto.RecertCampaignCreateReq{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	id, err := ctrl.service.Create(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, dto.NewIDResp(id))
+}
+
+// List 获取权限复核活动列表
+//
+//	@Description	获取权限复核活动列表，只返回当前用户创建或者需要当前用户复核的复核活动
+//	@Tags			权限复核
+//	@Summary		获取权限复核活动列表
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			_	query		dto.RecertCampaignListArgs			true	"请求参数"
+//	@Success		200	{object}	dto.PageResult[dto.RecertCampaign]	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError						"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns [get]
+func (ctrl *Controller) List(c *gin.Context) {
+	req := &dto.RecertCampaignListArgs{}
+	if _, err := form_validator.BindQueryAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.List(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Get 获取权限复核活动详情
+//
+//	@Description	获取权限复核活动详情，只有复核活动的创建人或复核人可以查看
+//	@Tags			权限复核
+//	@Summary		获取权限复核活动详情
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			id	path		string				true	"复核活动ID"	Format(uuid)
+//	@Success		200	{object}	dto.RecertCampaign	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError		"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns/{id} [get]
+func (ctrl *Controller) Get(c *gin.Context) {
+	req := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.Get(c, req.ID)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Cancel 取消权限复核活动
+//
+//	@Description	取消权限复核活动，未复核的授权保持不变
+//	@Tags			权限复核
+//	@Summary		取消权限复核活动
+//	@Accept			text/plain
+//	@Produce		text/plain
+//	@Param			id	path		string			true	"复核活动ID"	Format(uuid)
+//	@Success		200	{object}	string			"成功响应参数:OK"
+//	@Failure		400	{object}	rest.HttpError	"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns/{id}/cancel [post]
+func (ctrl *Controller) Cancel(c *gin.Context) {
+	req := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	if err := ctrl.service.Cancel(c, req.ID); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, nil)
+}
+
+// Report 获取权限复核活动的报告
+//
+//	@Description	获取权限复核活动的报告，包括按复核人的统计和每个复核项的复核记录，只有复核活动的创建人或复核人可以查看
+//	@Tags			权限复核
+//	@Summary		获取权限复核活动的报告
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			id	path		string						true	"复核活动ID"	Format(uuid)
+//	@Success		200	{object}	dto.RecertCampaignReport	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError				"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns/{id}/report [get]
+func (ctrl *Controller) Report(c *gin.Context) {
+	req := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	result, err := ctrl.service.Report(c, req.ID)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// ListItems 获取权限复核项列表
+//
+//	@Description	获取权限复核项列表，只有复核活动的创建人或复核人可以查看
+//	@Tags			权限复核
+//	@Summary		获取权限复核项列表
+//	@Accept			text/plain
+//	@Produce		json
+//	@Param			id	path		string							true	"复核活动ID"	Format(uuid)
+//	@Param			_	query		dto.RecertItemListArgs			true	"请求参数"
+//	@Success		200	{object}	dto.PageResult[dto.RecertItem]	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError					"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns/{id}/items [get]
+func (ctrl *Controller) ListItems(c *gin.Context) {
+	idReq := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, idReq); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	req := &dto.RecertItemListArgs{}
+	if _, err := form_validator.BindQueryAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	req.CampaignID = idReq.ID
+	result, err := ctrl.service.ListItems(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
+
+// Decide 批量复核
+//
+//	@Description	批量复核：保留、回收或者缩短授权的有效期，复核结果立即生效
+//	@Tags			权限复核
+//	@Summary		批量复核
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"复核活动ID"	Format(uuid)
+//	@Param			_	body		dto.RecertDecideReq		true	"请求参数"
+//	@Success		200	{object}	dto.RecertDecideRes		"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError			"失败响应参数"
+//	@Router			/api/auth-service/v1/recertification-campaigns/{id}/decisions [post]
+func (ctrl *Controller) Decide(c *gin.Context) {
+	idReq := &dto.IDReq{}
+	if _, err := form_validator.BindUriAndValid(c, idReq); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterErr.Detail(err))
+		return
+	}
+	req := &dto.RecertDecideReq{}
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusBadRequest, errorcode.PublicInvalidParameterJsonErr.Detail(err))
+		return
+	}
+	req.CampaignID = idReq.ID
+	result, err := ctrl.service.Decide(c, req)
+	if err != nil {
+		ginx.AbortResponseWithCode(c, http.StatusInternalServerError, err)
+		return
+	}
+	ginx.ResOKJson(c, result)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/attribute_policy"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/common_auth"
 
 	"github.com/gin-gonic/gin"
@@ -14,11 +15,12 @@ import (
 )
 
 type Controller struct {
-	authDomain common_auth.Auth
+	authDomain      common_auth.Auth
+	attributePolicy attribute_policy.Evaluator
 }
 
-func NewController(authDomain common_auth.Auth) *Controller {
-	return &Controller{authDomain: authDomain}
+func NewController(authDomain common_auth.Auth, attributePolicy attribute_policy.UseCase) *Controller {
+	return &Controller{authDomain: authDomain, attributePolicy: attributePolicy}
 }
 
 // Create 策略创建
@@ -180,11 +182,18 @@ func (s *Controller) Delete(c *gin.Context) {
 //	@Summary		策略验证
 //	@Accept			json
 //	@Produce		json
-//	@Param			_	body		dto.PolicyEnforceReq	true	"请求参数"
-//	@Success		200	{object}	dto.PolicyEnforceRes	"成功响应参数"
-//	@Failure		400	{object}	rest.HttpError			"失败响应参数"
+//	@Param			_	query		dto.PolicyEnforceOptions	false	"策略验证的选项"
+//	@Param			_	body		dto.PolicyEnforceReq		true	"请求参数"
+//	@Success		200	{object}	dto.PolicyEnforceRes		"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError				"失败响应参数"
 //	@Router			/api/auth-service/v1/enforce [post]
 func (s *Controller) Enforce(c *gin.Context) {
+	opts := &dto.PolicyEnforceOptions{}
+	if _, err := form_validator.BindQueryAndValid(c, opts); err != nil {
+		ginx.ResErrJsonWithCode(c, http.StatusBadRequest, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+		return
+	}
+
 	req := &dto.PolicyEnforceReq{}
 
 	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
@@ -204,6 +213,11 @@ func (s *Controller) Enforce(c *gin.Context) {
 		return
 	}
 
+	// 属性策略计算失败时只在对应的验证结果中返回原因，不影响允许、拒绝的结果
+	if opts.WithObligations {
+		s.attributePolicy.AttachObligations(c.Request.Context(), res)
+	}
+
 	ginx.ResOKJson(c, res)
 }
 
@@ -277,6 +291,86 @@ func (s *Controller) GetObjectsBySubjectId(c *gin.Context) {
 	ginx.ResOKJson(c, res)
 }
 
+// Explain 策略验证的解释
+//
+//	@Description	解释访问者对资源的动作的策略结果，返回参与决策的身份、策略和行列规则
+//	@Tags			策略
+//	@Summary		策略验证的解释
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.PolicyEnforce		true	"请求参数"
+//	@Success		200	{object}	dto.PolicyExplainRes	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError			"失败响应参数"
+//	@Router			/api/auth-service/v1/enforce/explain [post]
+func (s *Controller) Explain(c *gin.Context) {
+	req := &dto.PolicyEnforce{}
+
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		switch err.(type) {
+		case form_validator.ValidErrors:
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameterJson, err))
+		default:
+			ginx.ResErrJsonWithCode(c, http.StatusBadRequest, errorcode.Desc(errorcode.PublicInvalidParameterJson))
+		}
+		return
+	}
+
+	res, err := s.authDomain.Explain(c.Request.Context(), req)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, res)
+}
+
+// WhatIf 策略模拟
+//
+//	@Description	模拟保存策略后访问者的策略结果，策略不会被保存
+//	@Tags			策略
+//	@Summary		策略模拟
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.PolicyWhatIfReq	true	"请求参数"
+//	@Success		200	{object}	dto.PolicyWhatIfRes	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError		"失败响应参数"
+//	@Router			/api/auth-service/v1/policy/what-if [post]
+func (s *Controller) WhatIf(c *gin.Context) {
+	req := &dto.PolicyWhatIfReq{}
+
+	if _, err := form_validator.BindJsonAndValid(c, req); err != nil {
+		switch err.(type) {
+		case form_validator.ValidErrors:
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameterJson, err))
+		default:
+			ginx.ResErrJsonWithCode(c, http.StatusBadRequest, errorcode.Desc(errorcode.PublicInvalidParameterJson))
+		}
+		return
+	}
+
+	res, err := s.authDomain.WhatIf(c.Request.Context(), req)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, res)
+}
+
+// DecisionCacheStats 策略决策缓存的命中统计
+//
+//	@Description	策略决策缓存的命中统计
+//	@Tags			策略
+//	@Summary		策略决策缓存的命中统计
+//	@Produce		json
+//	@Success		200	{object}	dto.DecisionCacheStats	"成功响应参数"
+//	@Router			/api/internal/auth-service/v1/enforce/cache-stats [get]
+func (s *Controller) DecisionCacheStats(c *gin.Context) {
+	ginx.ResOKJson(c, s.authDomain.DecisionCacheStats(c.Request.Context()))
+}
+
 // QueryPolicyExpiredObjects 查询包含策略过期的object
 func (s *Controller) QueryPolicyExpiredObjects(c *gin.Context) {
 	query := &dto.QueryPolicyExpiredObjectsArgs{}
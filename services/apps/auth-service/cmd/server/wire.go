@@ -13,6 +13,8 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/settings"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain"
+	break_glass_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass/impl"
+	recertification_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/recertification/impl"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure"
 	af_go_frame "github.com/kweaver-ai/idrm-go-frame"
 	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest"
@@ -20,10 +22,10 @@ import (
 
 var appRunnerSet = wire.NewSet(wire.Struct(new(AppRunner), "*"))
 
-func newApp(hs *rest.Server, consumer *mq.KafkaConsumer) *af_go_frame.App {
+func newApp(hs *rest.Server, consumer *mq.KafkaConsumer, recert *recertification_impl.Server, breakGlass *break_glass_impl.Server) *af_go_frame.App {
 	return af_go_frame.New(
 		af_go_frame.Name(Name),
-		af_go_frame.Server(hs, consumer),
+		af_go_frame.Server(hs, consumer, recert, breakGlass),
 	)
 }
 
@@ -16,13 +16,19 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/resources"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/workflow/custom"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/attribute_policy"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/break_glass"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/dwh_auth_request_form"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/indicator_dimensional_rule"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v1/recertification"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driver/v2/auth"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/settings"
+	impl12 "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/attribute_policy/impl"
+	impl13 "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass/impl"
 	impl7 "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/common_auth/impl"
 	impl10 "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/dwh_data_auth_request/impl"
 	impl8 "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/indicator_dimensional_rule/impl"
+	impl11 "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/recertification/impl"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/mq/kafka"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/redis"
@@ -71,10 +77,16 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 		return nil, nil, err
 	}
 	databaseClient := database.New(gormDBWithoutDatabase)
-	common_authAuth := impl7.NewAuth(authorizationDriven, redisClient, indicatorDimensionalRuleInterface, driven, data_application_serviceDriven, data_viewDriven, indicator_managementDriven, databaseClient, drivenUserMgnt)
+	userManagementRepo := microservice.NewUserManagementRepo()
+	authSubViewRepo := gorm.NewAuthSubViewRepo(gormDB)
+	common_authAuth := impl7.NewAuth(authorizationDriven, redisClient, indicatorDimensionalRuleInterface, driven, data_application_serviceDriven, data_viewDriven, indicator_managementDriven, databaseClient, drivenUserMgnt, userManagementRepo, authSubViewRepo)
 	useCase := impl8.NewIndicatorDimensionalRuleInterface(indicatorDimensionalRuleInterface, common_authAuth)
 	controller := indicator_dimensional_rule.New(useCase)
-	authController := auth.NewController(common_authAuth)
+	authAttributePolicyRepo := gorm.NewAuthAttributePolicyRepo(gormDB)
+	dataViewRepo := microservice.NewDataViewRepo()
+	dataApplicationServiceRepo := microservice.NewDataApplicationServiceRepo()
+	attribute_policyUseCase := impl12.NewUseCase(authAttributePolicyRepo, userManagementRepo, dataViewRepo, dataApplicationServiceRepo)
+	authController := auth.NewController(common_authAuth, attribute_policyUseCase)
 	dataAuthRequestFormRepo := gorm.NewDataApplicationFormRepo(gormDB)
 	mqConf, err := settings.WorkflowMQConfFor(s)
 	if err != nil {
@@ -84,24 +96,38 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	dataViewRepo := microservice.NewDataViewRepo()
 	workflowDriven := impl9.NewWorkflowDriven(client)
 	dwh_data_auth_requestUseCase := impl10.NewUseCase(dataAuthRequestFormRepo, workflowInterface, driven, data_viewDriven, dataViewRepo, workflowDriven, common_authAuth)
 	dwh_auth_request_formController := dwh_auth_request_form.NewAuthController(dwh_data_auth_requestUseCase)
+	authRecertRepo := gorm.NewAuthRecertRepo(gormDB)
+	recertificationUseCase := impl11.NewUseCase(authRecertRepo, authSubViewRepo, dataViewRepo, common_authAuth)
+	recertificationController := recertification.NewController(recertificationUseCase)
+	attribute_policyController := attribute_policy.NewController(attribute_policyUseCase)
+	authBreakGlassRepo := gorm.NewAuthBreakGlassRepo(gormDB)
+	producer, err := kafka.NewSyncProducer()
+	if err != nil {
+		return nil, nil, err
+	}
+	break_glassUseCase := impl13.NewUseCase(authBreakGlassRepo, common_authAuth, dataViewRepo, workflowInterface, driven, producer)
+	break_glassController := break_glass.NewController(break_glassUseCase)
 	router := &driver.Router{
 		Middleware:                         middleware,
 		IndicatorDimensionalRuleController: controller,
 		AuthV2Controller:                   authController,
 		DWHController:                      dwh_auth_request_formController,
+		RecertificationController:          recertificationController,
+		AttributePolicyController:          attribute_policyController,
+		BreakGlassController:               break_glassController,
 	}
 	server := driver.NewHttpServer(s, router)
 	consumer := kafka.NewConsumer()
-	authSubViewRepo := gorm.NewAuthSubViewRepo(gormDB)
 	subViewHandler := views.NewSubViewHandler(authSubViewRepo)
 	kafkaConsumer := mq.NewKafkaConsumer(consumer, subViewHandler)
-	app := newApp(server, kafkaConsumer)
+	implServer := impl11.NewServer(recertificationUseCase)
+	server2 := impl13.NewServer(break_glassUseCase)
+	app := newApp(server, kafkaConsumer, implServer, server2)
 	consumeAuthRequestRepo := gorm.NewConsumeAuthRequestRepo(gormDB, client)
-	wfConsumerRegister, err := custom.NewWFConsumerRegister(workflowInterface, consumeAuthRequestRepo, dwh_data_auth_requestUseCase)
+	wfConsumerRegister, err := custom.NewWFConsumerRegister(workflowInterface, consumeAuthRequestRepo, dwh_data_auth_requestUseCase, break_glassUseCase)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -119,6 +145,6 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 
 var appRunnerSet = wire.NewSet(wire.Struct(new(AppRunner), "*"))
 
-func newApp(hs *rest.Server, consumer *mq.KafkaConsumer) *idrm_go_frame.App {
-	return idrm_go_frame.New(idrm_go_frame.Name(Name), idrm_go_frame.Server(hs, consumer))
+func newApp(hs *rest.Server, consumer *mq.KafkaConsumer, recert *impl11.Server, breakGlass *impl13.Server) *idrm_go_frame.App {
+	return idrm_go_frame.New(idrm_go_frame.Name(Name), idrm_go_frame.Server(hs, consumer, recert, breakGlass))
 }
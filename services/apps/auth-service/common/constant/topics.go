@@ -0,0 +1,7 @@
+package constant
+
+// 发送的消息
+const (
+	// 紧急访问，通知数据 Owner 和安全团队
+	BreakGlassTopic = "af.auth-service.break_glass"
+)
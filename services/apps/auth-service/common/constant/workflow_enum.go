@@ -6,6 +6,7 @@ import "github.com/kweaver-ai/idrm-go-common/workflow/common"
 const (
 	DataPermissionRequest  = "af-data-permission-request" //数据权限申请
 	DWHDataAuthRequestForm = "af-dwh-data-auth-request"   //数仓数据权限申请
+	BreakGlassReview       = "af-auth-break-glass-review" //紧急访问的事后复核
 )
 
 const (
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+// 属性策略的类型
+const (
+	AttributePolicyColumn = "column" // 列策略，脱敏或隐藏字段
+	AttributePolicyRow    = "row"    // 行策略，按字段过滤行
+)
+
+// 列策略对字段的处理，隐藏优先于脱敏
+const (
+	AttributeColumnMask = "mask" // 脱敏
+	AttributeColumnHide = "hide" // 隐藏
+)
+
+// 行策略的过滤值中可以引用的访问者属性
+const (
+	AttributeVarUserID        = "user.id"             // 用户ID
+	AttributeVarDepartmentIDs = "user.department_ids" // 用户所属部门及其上级部门的ID，逗号分隔
+	AttributeVarCsfLevel      = "user.csf_level"      // 用户密级
+)
+
+// AttributePolicySpec 属性策略的定义：资源中分级标签为 LabelIDs 的字段，对不满足豁免条件的访问者，
+// 列策略脱敏或隐藏这些字段，行策略按这些字段过滤行
+type AttributePolicySpec struct {
+	Name                string   `json:"name" binding:"required,min=1,max=128"`                                                                // 策略名称
+	Description         string   `json:"description" binding:"omitempty,max=1024"`                                                             // 策略描述
+	ObjectTypes         []string `json:"object_types" binding:"omitempty,unique,dive,oneof=data_view api"`                                     // 生效的资源类型，为空表示逻辑视图、接口都生效
+	LabelIDs            []string `json:"label_ids" binding:"required,min=1,max=100,unique,dive,max=64"`                                        // 字段的分级标签，由逻辑视图的分级规则、分类规则产生
+	Type                string   `json:"type" binding:"required,oneof=column row"`                                                             // 策略类型 column 列策略 row 行策略
+	ColumnAction        string   `json:"column_action" binding:"required_if=Type column,omitempty,oneof=mask hide"`                            // 列策略对字段的处理 mask 脱敏 hide 隐藏
+	RowOperator         string   `json:"row_operator" binding:"required_if=Type row,omitempty,oneof='=' '!=' 'in' 'not in' 'null' 'not null'"` // 行策略的运算逻辑
+	RowValue            string   `json:"row_value" binding:"omitempty,max=255"`                                                                // 行策略的过滤值，支持引用访问者属性 ${user.id} ${user.department_ids} ${user.csf_level}
+	ExemptCsfLevel      int      `json:"exempt_csf_level" binding:"omitempty,min=0,max=100"`                                                   // 豁免条件：密级大于等于该值的用户不受策略限制，0 表示不按密级豁免
+	ExemptDepartmentIDs []string `json:"exempt_department_ids" binding:"omitempty,max=100,unique,dive,uuid"`                                   // 豁免条件：属于这些部门及其子部门的用户不受策略限制
+	Enabled             bool     `json:"enabled"`                                                                                              // 是否启用
+}
+
+// AttributePolicyListArgs 属性策略列表参数
+type AttributePolicyListArgs struct {
+	Keyword    string `json:"keyword" form:"keyword" binding:"omitempty,max=128"`                        // 策略名称
+	ObjectType string `json:"object_type" form:"object_type" binding:"omitempty,oneof=data_view api"`    // 生效的资源类型
+	LabelID    string `json:"label_id" form:"label_id" binding:"omitempty,max=64"`                       // 分级标签
+	Offset     int    `json:"offset" form:"offset,default=1" binding:"number,min=1" default:"1"`         // 页码 默认 1
+	Limit      int    `json:"limit" form:"limit,default=10" binding:"number,min=1,max=100" default:"10"` // 每页大小 默认 10
+}
+
+// AttributePolicy 属性策略
+type AttributePolicy struct {
+	ID string `json:"id"` // 策略ID
+	AttributePolicySpec
+	CreatedBy     string       `json:"created_by"`      // 创建人
+	CreatedByName string       `json:"created_by_name"` // 创建人名称
+	CreatedAt     meta_v1.Time `json:"created_at"`      // 创建时间
+	UpdatedBy     string       `json:"updated_by"`      // 更新人
+	UpdatedByName string       `json:"updated_by_name"` // 更新人名称
+	UpdatedAt     meta_v1.Time `json:"updated_at"`      // 更新时间
+}
+
+// AttributePolicyEvaluateReq 计算访问者访问资源时生效的属性策略
+type AttributePolicyEvaluateReq struct {
+	ObjectId    string              `json:"object_id" binding:"required,VerifyNameEn,max=128"`                       // 资源id
+	ObjectType  string              `json:"object_type" binding:"required,oneof=data_view api"`                      // 资源类型 data_view 逻辑视图 api 接口
+	SubjectId   string              `json:"subject_id" binding:"omitempty,VerifyNameEn,max=128"`                     // 访问者id，为空时是当前用户
+	SubjectType string              `json:"subject_type" binding:"required_with=SubjectId,omitempty,oneof=app user"` // 访问者类型 app 应用 user 用户
+	Fields      []AttributeFieldRef `json:"fields" binding:"omitempty,max=1000,dive"`                                // 访问的字段，为空时是资源的所有字段
+}
+
+// AttributeFieldRef 资源访问的字段，对应逻辑视图的字段
+type AttributeFieldRef struct {
+	DataViewID string `json:"data_view_id" binding:"required,uuid"`  // 字段所属逻辑视图的ID
+	Name       string `json:"name" binding:"required,min=1,max=255"` // 字段的技术名称
+}
+
+// AttributeField 资源的字段及其分级标签
+type AttributeField struct {
+	AttributeFieldRef
+	LabelID string `json:"label_id"` // 分级标签ID
+}
+
+// AttributeSubject 计算属性策略用到的访问者属性
+type AttributeSubject struct {
+	ID            string   // 访问者ID
+	Type          string   // 访问者类型
+	CsfLevel      int      // 密级，应用为 0
+	DepartmentIDs []string // 所属部门及其上级部门的ID
+}
+
+// AttributeObligations 访问者访问资源时必须执行的属性策略
+type AttributeObligations struct {
+	PolicyIDs  []string                     `json:"policy_ids"`  // 生效的属性策略ID
+	Columns    []*AttributeColumnObligation `json:"columns"`     // 需要脱敏或隐藏的字段
+	RowFilters []*AttributeRowFilter        `json:"row_filters"` // 行过滤条件，条件之间是且的关系
+}
+
+// AttributeColumnObligation 需要脱敏或隐藏的字段
+type AttributeColumnObligation struct {
+	AttributeField
+	Action    string   `json:"action"`     // mask 脱敏 hide 隐藏
+	PolicyIDs []string `json:"policy_ids"` // 要求处理该字段的属性策略ID
+}
+
+// AttributeRowFilter 行过滤条件
+type AttributeRowFilter struct {
+	AttributeField
+	Operator string `json:"operator"`  // 运算逻辑 = != in not in null not null
+	Value    string `json:"value"`     // 过滤值，已替换访问者属性
+	PolicyID string `json:"policy_id"` // 属性策略ID
+}
+
+// PolicyEnforceOptions 策略验证的选项
+type PolicyEnforceOptions struct {
+	WithObligations bool `json:"with_obligations" form:"with_obligations" binding:"omitempty"` // 是否返回允许读取、下载的逻辑视图、接口上生效的属性策略
+}
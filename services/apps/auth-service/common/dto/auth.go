@@ -313,6 +313,10 @@ type PolicyEnforceEffect struct {
 	SubjectType string `json:"subject_type" binding:"required,oneof=app user department role"`                                                      //访问者类型 app 应用 user 用户 department 部门 role 角色
 	Action      string `json:"action" binding:"required,oneof=view read download auth allocate apply"`                                              //请求动作 view 查看 read 读取 download 下载
 	Effect      string `json:"effect"`                                                                                                              //策略结果 allow 允许 deny 拒绝
+	// 生效的属性策略，指定 with_obligations 且允许读取、下载逻辑视图、接口时返回
+	Obligations *AttributeObligations `json:"obligations,omitempty"`
+	// 计算属性策略失败的原因，此时不返回 Obligations，调用方不应按没有属性策略处理
+	ObligationError string `json:"obligation_error,omitempty"`
 }
 
 type PolicyEnforceCheck struct {
@@ -357,6 +361,16 @@ type MenuResourceActionsResp struct {
 
 type PolicyEnforceRes []PolicyEnforceEffect
 
+// DecisionCacheStats 策略决策缓存的命中统计
+type DecisionCacheStats struct {
+	Size          int     `json:"size"`          //缓存的决策数量
+	Hits          uint64  `json:"hits"`          //命中次数
+	Misses        uint64  `json:"misses"`        //未命中次数
+	HitRatio      float64 `json:"hit_ratio"`     //命中率
+	Invalidations uint64  `json:"invalidations"` //因策略变更失效的次数
+	TTLSeconds    int     `json:"ttl_seconds"`   //缓存过期时间，单位秒
+}
+
 type GetObjectsBySubjectIdReq struct {
 	SubjectId   string `json:"subject_id" form:"subject_id" binding:"required,VerifyNameEn,max=128"`               //访问者id
 	SubjectType string `json:"subject_type" form:"subject_type" binding:"required,oneof=app user department role"` //访问者类型 app 应用 user 用户 department 部门 role 角色
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+// 紧急访问的状态
+const (
+	BreakGlassActive  = "active"  // 生效中
+	BreakGlassExpired = "expired" // 到期自动回收
+	BreakGlassRevoked = "revoked" // 被申请人、安全团队提前结束，或者事后复核不通过被回收
+)
+
+// 紧急访问的有效期，单位分钟
+const (
+	BreakGlassDefaultDuration = 120 // 默认有效期 2 小时
+	BreakGlassMaxDuration     = 240 // 最长有效期 4 小时
+)
+
+// BreakGlassGrantCreateReq 申请紧急访问，不需要审批立即获得逻辑视图的读取权限
+type BreakGlassGrantCreateReq struct {
+	ObjectId      string `json:"object_id" binding:"required,uuid"`                        // 逻辑视图ID
+	Justification string `json:"justification" binding:"required,min=10,max=1024"`         // 紧急访问的理由，例如故障单号、故障现象
+	Duration      int    `json:"duration" binding:"omitempty,min=1,max=240" example:"120"` // 有效期，单位分钟，默认 120 分钟
+}
+
+// BreakGlassGrantRevokeReq 提前结束紧急访问
+type BreakGlassGrantRevokeReq struct {
+	Reason string `json:"reason" binding:"omitempty,max=1024"` // 提前结束的原因
+}
+
+// BreakGlassGrantListArgs 紧急访问列表参数
+type BreakGlassGrantListArgs struct {
+	ObjectId  string `json:"object_id" form:"object_id" binding:"omitempty,uuid"`                       // 逻辑视图ID
+	SubjectId string `json:"subject_id" form:"subject_id" binding:"omitempty,uuid"`                     // 申请人ID
+	Status    string `json:"status" form:"status" binding:"omitempty,oneof=active expired revoked"`     // 状态
+	Offset    int    `json:"offset" form:"offset,default=1" binding:"number,min=1" default:"1"`         // 页码 默认 1
+	Limit     int    `json:"limit" form:"limit,default=10" binding:"number,min=1,max=100" default:"10"` // 每页大小 默认 10
+	ViewerId  string `json:"-" form:"-"`                                                                // 查看人ID，不为空时只返回查看人申请的，或者查看人是数据 Owner 的紧急访问
+}
+
+// BreakGlassGrant 紧急访问
+type BreakGlassGrant struct {
+	ID            string           `json:"id"`                        // 紧急访问ID
+	ObjectId      string           `json:"object_id"`                 // 逻辑视图ID
+	ObjectType    string           `json:"object_type"`               // 资源类型，目前只有 data_view
+	ObjectName    string           `json:"object_name"`               // 逻辑视图名称
+	SubjectId     string           `json:"subject_id"`                // 申请人ID
+	SubjectName   string           `json:"subject_name"`              // 申请人名称
+	Justification string           `json:"justification"`             // 紧急访问的理由
+	Duration      int              `json:"duration"`                  // 有效期，单位分钟
+	ExpiredAt     meta_v1.Time     `json:"expired_at"`                // 过期时间
+	Status        string           `json:"status"`                    // 状态 active 生效中 expired 到期自动回收 revoked 提前结束
+	RevokedAt     *meta_v1.Time    `json:"revoked_at,omitempty"`      // 回收时间
+	RevokedBy     string           `json:"revoked_by,omitempty"`      // 提前结束的用户，到期自动回收或者复核不通过时为空
+	RevokedByName string           `json:"revoked_by_name,omitempty"` // 提前结束的用户名称
+	RevokeReason  string           `json:"revoke_reason,omitempty"`   // 提前结束的原因
+	Review        BreakGlassReview `json:"review"`                    // 事后复核
+	CreatedAt     meta_v1.Time     `json:"created_at"`                // 申请时间
+}
+
+// BreakGlassReview 紧急访问的事后复核，通过审核流程进行
+type BreakGlassReview struct {
+	ApplyID string `json:"apply_id,omitempty"` // 审核申请ID
+	Phase   string `json:"phase"`              // 审核状态 pending 未发起审核 auditing 审核中 pass 通过 reject 不通过
+	Message string `json:"message,omitempty"`  // 审核意见，或者未发起审核的原因
+}
+
+// BreakGlassMessage 紧急访问的通知消息，由任务中心通知数据 Owner 和安全团队
+type BreakGlassMessage struct {
+	GrantID       string   `json:"grant_id"`      // 紧急访问ID
+	ObjectId      string   `json:"object_id"`     // 逻辑视图ID
+	ObjectName    string   `json:"object_name"`   // 逻辑视图名称
+	SubjectId     string   `json:"subject_id"`    // 申请人ID
+	SubjectName   string   `json:"subject_name"`  // 申请人名称
+	Justification string   `json:"justification"` // 紧急访问的理由
+	ExpiredAt     int64    `json:"expired_at"`    // 过期时间，毫秒时间戳
+	RecipientIDs  []string `json:"recipient_ids"` // 通知接收人ID
+	CreatedAt     int64    `json:"created_at"`    // 申请时间，毫秒时间戳
+}
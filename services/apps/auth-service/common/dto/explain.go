@@ -0,0 +1,86 @@
+package dto
+
+import (
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+// 访问者获得身份的途径
+const (
+	ViaSelf       = "self"       // 访问者自身
+	ViaDepartment = "department" // 所属部门或上级部门
+	ViaRole       = "role"       // 角色
+)
+
+// PolicyExplainRes 策略验证的解释
+type PolicyExplainRes struct {
+	PolicyEnforceEffect
+	Reason      string              `json:"reason"`      //决策原因
+	IsOwner     bool                `json:"is_owner"`    //访问者是否为资源的 Owner，Owner 允许所有操作
+	Memberships []ExplainMembership `json:"memberships"` //参与决策的访问者身份：自身、所属部门及上级部门、角色
+	Policies    []ExplainPolicy     `json:"policies"`    //与访问者身份相关的策略，包括上级资源的策略
+	Rules       []ExplainRule       `json:"rules"`       //资源相关的行列规则（子视图）
+}
+
+// ExplainMembership 参与决策的访问者身份
+type ExplainMembership struct {
+	SubjectId   string `json:"subject_id"`   //访问者id
+	SubjectType string `json:"subject_type"` //访问者类型 app 应用 user 用户 department 部门 role 角色
+	SubjectName string `json:"subject_name"` //访问者名称
+	Via         string `json:"via"`          //获得身份的途径 self 自身 department 所属部门 role 角色
+}
+
+// ExplainPolicy 参与决策的策略
+type ExplainPolicy struct {
+	PolicyID    string        `json:"policy_id"`            //策略ID，拟新增的策略为空
+	ObjectId    string        `json:"object_id"`            //策略所在的资源id
+	ObjectType  string        `json:"object_type"`          //策略所在的资源类型
+	Inherited   bool          `json:"inherited"`            //是否为上级资源的策略，例如子视图所属的逻辑视图
+	SubjectId   string        `json:"subject_id"`           //策略的访问者id
+	SubjectType string        `json:"subject_type"`         //策略的访问者类型
+	SubjectName string        `json:"subject_name"`         //策略的访问者名称
+	Via         string        `json:"via"`                  //访问者通过哪种身份匹配该策略 self 自身 department 所属部门 role 角色
+	Actions     []string      `json:"actions"`              //策略允许的动作
+	ExpiredAt   *meta_v1.Time `json:"expired_at,omitempty"` //过期时间，为空表示永久有效
+	Expired     bool          `json:"expired"`              //是否已过期
+	Matched     bool          `json:"matched"`              //是否包含请求的动作且未过期
+	Proposed    bool          `json:"proposed"`             //是否为模拟中拟新增的策略
+}
+
+// ExplainRule 资源相关的行列规则（子视图）
+type ExplainRule struct {
+	SubViewId       string `json:"sub_view_id"`       //子视图id
+	Name            string `json:"name"`              //子视图名称
+	LogicViewId     string `json:"logic_view_id"`     //所属逻辑视图id
+	Columns         string `json:"columns"`           //子视图的列名称，逗号分隔
+	RowFilterClause string `json:"row_filter_clause"` //行过滤条件
+	Effect          string `json:"effect"`            //访问者对该子视图请求动作的策略结果 allow 允许 deny 拒绝
+}
+
+// PolicyWhatIfReq 模拟保存策略后访问者的策略结果
+type PolicyWhatIfReq struct {
+	Policy   PolicyCreateReq `json:"policy" binding:"required"`                                                     //拟保存的策略
+	Subjects []WhatIfSubject `json:"subjects" binding:"required,min=1,max=100,dive"`                                //需要评估的访问者
+	Actions  []string        `json:"actions" binding:"omitempty,dive,oneof=view read download auth allocate apply"` //需要评估的动作，为空时评估拟保存策略中的所有动作
+}
+
+// WhatIfSubject 模拟中需要评估的访问者
+type WhatIfSubject struct {
+	SubjectId   string `json:"subject_id" binding:"required,VerifyNameEn,max=128"`             //访问者id
+	SubjectType string `json:"subject_type" binding:"required,oneof=app user department role"` //访问者类型 app 应用 user 用户 department 部门 role 角色
+}
+
+// PolicyWhatIfRes 策略模拟的结果
+type PolicyWhatIfRes struct {
+	Entries []PolicyWhatIfEntry `json:"entries"`
+}
+
+// PolicyWhatIfEntry 一个访问者对一个动作的模拟结果
+type PolicyWhatIfEntry struct {
+	SubjectId   string          `json:"subject_id"`   //访问者id
+	SubjectType string          `json:"subject_type"` //访问者类型
+	Action      string          `json:"action"`       //请求动作
+	Current     string          `json:"current"`      //当前的策略结果 allow 允许 deny 拒绝
+	Proposed    string          `json:"proposed"`     //保存策略后的策略结果 allow 允许 deny 拒绝
+	Changed     bool            `json:"changed"`      //策略结果是否变化
+	Policies    []ExplainPolicy `json:"policies"`     //拟保存的策略中与该访问者相关的策略
+}
@@ -0,0 +1,161 @@
+package dto
+
+import (
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+// 权限复核活动的状态
+const (
+	RecertCampaignActive    = "active"    // 进行中
+	RecertCampaignClosing   = "closing"   // 已截止，正在回收未复核的授权
+	RecertCampaignCompleted = "completed" // 已完成
+	RecertCampaignCanceled  = "canceled"  // 已取消
+)
+
+// 权限复核项的复核结果
+const (
+	RecertPending     = "pending"      // 待复核
+	RecertKeep        = "keep"         // 保留
+	RecertRevoke      = "revoke"       // 回收
+	RecertShorten     = "shorten"      // 缩短有效期
+	RecertAutoRevoked = "auto_revoked" // 截止时仍未复核，自动回收
+)
+
+// RecertCampaignCreateReq 创建权限复核活动
+type RecertCampaignCreateReq struct {
+	Name                 string        `json:"name" binding:"required,min=1,max=128"`                                                                               //复核活动名称
+	Description          string        `json:"description" binding:"omitempty,max=1024"`                                                                            //复核活动描述
+	ObjectType           string        `json:"object_type" binding:"required,oneof=domain data_view api sub_service sub_view indicator indicator_dimensional_rule"` //复核范围：资源类型
+	DepartmentID         string        `json:"department_id" binding:"omitempty,uuid"`                                                                              //复核范围：资源所属部门，仅支持 data_view 和 sub_view
+	IncludeSubDepartment bool          `json:"include_sub_department"`                                                                                              //复核范围：是否包含子部门
+	LabelID              string        `json:"label_id" binding:"omitempty,max=64"`                                                                                 //复核范围：字段的分级标签，仅支持 data_view 和 sub_view，资源包含该分级的字段时参与复核
+	ObjectIDs            []string      `json:"object_ids" binding:"omitempty,max=1000,dive,VerifyNameEn,max=128"`                                                   //复核范围：指定的资源ID
+	Deadline             *meta_v1.Time `json:"deadline" binding:"required"`                                                                                         //截止时间，未复核的授权在截止时间后自动回收
+}
+
+// RecertCampaignListArgs 权限复核活动列表参数
+type RecertCampaignListArgs struct {
+	Keyword string `json:"keyword" form:"keyword" binding:"omitempty,max=128"`                               // 复核活动名称
+	Status  string `json:"status" form:"status" binding:"omitempty,oneof=active closing completed canceled"` // 状态
+	Offset  int    `json:"offset" form:"offset,default=1" binding:"number,min=1" default:"1"`                // 页码 默认 1
+	Limit   int    `json:"limit" form:"limit,default=10" binding:"number,min=1,max=100" default:"10"`        // 每页大小 默认 10
+	// ViewerID 不为空时只返回该用户创建或者需要该用户复核的复核活动
+	ViewerID string `json:"-" form:"-"`
+}
+
+// RecertCampaign 权限复核活动
+type RecertCampaign struct {
+	ID                   string        `json:"id"`                     // 复核活动ID
+	Name                 string        `json:"name"`                   // 复核活动名称
+	Description          string        `json:"description"`            // 复核活动描述
+	ObjectType           string        `json:"object_type"`            // 复核范围：资源类型
+	DepartmentID         string        `json:"department_id"`          // 复核范围：资源所属部门
+	IncludeSubDepartment bool          `json:"include_sub_department"` // 复核范围：是否包含子部门
+	LabelID              string        `json:"label_id"`               // 复核范围：字段的分级标签
+	ObjectIDs            []string      `json:"object_ids"`             // 复核范围：指定的资源ID
+	Deadline             meta_v1.Time  `json:"deadline"`               // 截止时间
+	Status               string        `json:"status"`                 // 状态 active 进行中 closing 回收中 completed 已完成 canceled 已取消
+	CreatedBy            string        `json:"created_by"`             // 创建人
+	CreatedByName        string        `json:"created_by_name"`        // 创建人名称
+	CreatedAt            meta_v1.Time  `json:"created_at"`             // 创建时间
+	CompletedAt          *meta_v1.Time `json:"completed_at,omitempty"` // 完成时间
+	Summary              RecertSummary `json:"summary"`                // 复核项的统计
+}
+
+// RecertSummary 复核项按复核结果的统计
+type RecertSummary struct {
+	Total       int `json:"total"`        // 复核项总数
+	Pending     int `json:"pending"`      // 待复核
+	Keep        int `json:"keep"`         // 保留
+	Revoke      int `json:"revoke"`       // 回收
+	Shorten     int `json:"shorten"`      // 缩短有效期
+	AutoRevoked int `json:"auto_revoked"` // 自动回收
+}
+
+// Add 统计 count 个复核结果为 decision 的复核项
+func (s *RecertSummary) Add(decision string, count int) {
+	s.Total += count
+	switch decision {
+	case RecertPending:
+		s.Pending += count
+	case RecertKeep:
+		s.Keep += count
+	case RecertRevoke:
+		s.Revoke += count
+	case RecertShorten:
+		s.Shorten += count
+	case RecertAutoRevoked:
+		s.AutoRevoked += count
+	}
+}
+
+// RecertItemListArgs 权限复核项列表参数
+type RecertItemListArgs struct {
+	CampaignID string `json:"-"`                                                                                           // 复核活动ID
+	Decision   string `json:"decision" form:"decision" binding:"omitempty,oneof=pending keep revoke shorten auto_revoked"` // 复核结果
+	ReviewerID string `json:"reviewer_id" form:"reviewer_id" binding:"omitempty,uuid"`                                     // 复核人
+	Mine       bool   `json:"mine" form:"mine"`                                                                            // 只返回当前用户需要复核的
+	Keyword    string `json:"keyword" form:"keyword" binding:"omitempty,max=128"`                                          // 资源名称或访问者名称
+	Offset     int    `json:"offset" form:"offset,default=1" binding:"number,min=1" default:"1"`                           // 页码 默认 1
+	Limit      int    `json:"limit" form:"limit,default=10" binding:"number,min=1,max=1000" default:"10"`                  // 每页大小 默认 10
+}
+
+// RecertItem 权限复核项，对应复核活动开始时资源上的一个授权
+type RecertItem struct {
+	ID            string        `json:"id"`                       // 复核项ID
+	CampaignID    string        `json:"campaign_id"`              // 复核活动ID
+	PolicyID      string        `json:"policy_id"`                // 策略ID
+	ObjectId      string        `json:"object_id"`                // 资源ID
+	ObjectType    string        `json:"object_type"`              // 资源类型
+	ObjectName    string        `json:"object_name"`              // 资源名称
+	SubjectId     string        `json:"subject_id"`               // 访问者ID
+	SubjectType   string        `json:"subject_type"`             // 访问者类型
+	SubjectName   string        `json:"subject_name"`             // 访问者名称
+	Actions       []string      `json:"actions"`                  // 授权的动作
+	ExpiredAt     *meta_v1.Time `json:"expired_at,omitempty"`     // 复核前的过期时间，为空表示永久有效
+	ReviewerID    string        `json:"reviewer_id"`              // 复核人
+	ReviewerName  string        `json:"reviewer_name"`            // 复核人名称
+	Decision      string        `json:"decision"`                 // 复核结果 pending 待复核 keep 保留 revoke 回收 shorten 缩短有效期 auto_revoked 自动回收
+	NewExpiredAt  *meta_v1.Time `json:"new_expired_at,omitempty"` // 缩短后的过期时间
+	Comment       string        `json:"comment"`                  // 复核意见
+	DecidedBy     string        `json:"decided_by"`               // 做出复核决定的用户，自动回收时为空
+	DecidedByName string        `json:"decided_by_name"`          // 做出复核决定的用户名称
+	DecidedAt     *meta_v1.Time `json:"decided_at,omitempty"`     // 复核时间
+	Message       string        `json:"message,omitempty"`        // 最近一次执行复核决定失败的原因
+}
+
+// RecertDecideReq 批量复核
+type RecertDecideReq struct {
+	CampaignID string        `json:"-"`                                                     // 复核活动ID
+	ItemIDs    []string      `json:"item_ids" binding:"required,min=1,max=1000,dive,uuid"`  // 复核项ID
+	Decision   string        `json:"decision" binding:"required,oneof=keep revoke shorten"` // 复核结果 keep 保留 revoke 回收 shorten 缩短有效期
+	ExpiredAt  *meta_v1.Time `json:"expired_at" binding:"required_if=Decision shorten"`     // 缩短后的过期时间，复核结果为 shorten 时必填
+	Comment    string        `json:"comment" binding:"omitempty,max=1024"`                  // 复核意见
+}
+
+// RecertDecideRes 批量复核的结果
+type RecertDecideRes struct {
+	Succeeded []string             `json:"succeeded"` // 复核成功的复核项ID
+	Failed    []RecertDecideFailed `json:"failed"`    // 复核失败的复核项
+}
+
+// RecertDecideFailed 复核失败的复核项
+type RecertDecideFailed struct {
+	ItemID string `json:"item_id"` // 复核项ID
+	Reason string `json:"reason"`  // 失败原因
+}
+
+// RecertReviewerSummary 一个复核人的复核统计
+type RecertReviewerSummary struct {
+	ReviewerID    string `json:"reviewer_id"`   // 复核人
+	ReviewerName  string `json:"reviewer_name"` // 复核人名称
+	RecertSummary        // 复核项的统计
+}
+
+// RecertCampaignReport 权限复核活动的报告
+type RecertCampaignReport struct {
+	Campaign    RecertCampaign          `json:"campaign"`     // 复核活动
+	Reviewers   []RecertReviewerSummary `json:"reviewers"`    // 按复核人的统计
+	Items       []*RecertItem           `json:"items"`        // 所有复核项及其复核记录
+	GeneratedAt meta_v1.Time            `json:"generated_at"` // 报告生成时间
+}
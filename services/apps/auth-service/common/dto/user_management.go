@@ -11,6 +11,7 @@ type UserInfo struct {
 	Roles      []string             `json:"roles"`
 	ParentDeps [][]ParentDepartment `json:"parent_deps"`
 	Id         string               `json:"id"`
+	CsfLevel   int                  `json:"csf_level"` // 密级
 }
 
 type ParentDepartment struct {
@@ -9,11 +9,14 @@ const (
 )
 
 var (
-	publicModule   = errorx.New(ServiceName + ".Public.")
-	authModule     = errorx.New(ServiceName + ".Auth.")
-	DWHDataModule  = errorx.New(ServiceName + ".DataApplicationForm.")
-	workflowModule = errorx.New(ServiceName + ".Workflow.")
-	UserModule     = errorx.New(ServiceName + ".UserModule.")
+	publicModule          = errorx.New(ServiceName + ".Public.")
+	authModule            = errorx.New(ServiceName + ".Auth.")
+	DWHDataModule         = errorx.New(ServiceName + ".DataApplicationForm.")
+	workflowModule        = errorx.New(ServiceName + ".Workflow.")
+	UserModule            = errorx.New(ServiceName + ".UserModule.")
+	recertModule          = errorx.New(ServiceName + ".Recertification.")
+	attributePolicyModule = errorx.New(ServiceName + ".AttributePolicy.")
+	breakGlassModule      = errorx.New(ServiceName + ".BreakGlass.")
 )
 
 var (
@@ -52,3 +55,30 @@ var (
 var (
 	UserNotExistErr = UserModule.Description("UserNotExistError", "用户不存在")
 )
+
+var (
+	RecertScopeInvalidErr      = recertModule.Description("ScopeInvalidErr", "复核范围不合法，部门和分级标签仅支持逻辑视图和子视图")
+	RecertScopeEmptyErr        = recertModule.Description("ScopeEmptyErr", "复核范围内没有可复核的授权")
+	RecertDeadlineInvalidErr   = recertModule.Description("DeadlineInvalidErr", "截止时间必须晚于当前时间")
+	RecertCampaignNotActiveErr = recertModule.Description("CampaignNotActiveErr", "复核活动已截止或已取消")
+	RecertItemNotPendingErr    = recertModule.Description("ItemNotPendingErr", "复核项已经复核")
+	RecertNotReviewerErr       = recertModule.Description("NotReviewerErr", "只有复核人或复核活动的创建人可以复核")
+	RecertExpiredAtInvalidErr  = recertModule.Description("ExpiredAtInvalidErr", "缩短后的过期时间必须晚于当前时间且早于原过期时间")
+	RecertViewForbiddenErr     = recertModule.Description("ViewForbiddenErr", "只有复核活动的创建人或复核人可以查看")
+	RecertPolicyNotExistErr    = recertModule.Description("PolicyNotExistErr", "复核项对应的授权已不存在")
+)
+
+var (
+	AttributePolicyRowValueInvalidErr = attributePolicyModule.Description("RowValueInvalidErr", "行策略的过滤值只能引用 ${user.id} ${user.department_ids} ${user.csf_level}")
+	AttributePolicyRowValueEmptyErr   = attributePolicyModule.Description("RowValueEmptyErr", "行策略的运算逻辑不是 null 或 not null 时，过滤值不能为空")
+	AttributePolicySubjectEmptyErr    = attributePolicyModule.Description("SubjectEmptyErr", "未指定访问者且没有当前用户")
+)
+
+var (
+	BreakGlassAlreadyAllowedErr  = breakGlassModule.Description("AlreadyAllowedErr", "已经拥有该逻辑视图的读取权限，不需要紧急访问")
+	BreakGlassGrantExistErr      = breakGlassModule.Description("GrantExistErr", "该逻辑视图上已有生效中的紧急访问")
+	BreakGlassNotActiveErr       = breakGlassModule.Description("NotActiveErr", "紧急访问已结束")
+	BreakGlassRevokeForbiddenErr = breakGlassModule.Description("RevokeForbiddenErr", "只有申请人或安全团队可以结束紧急访问")
+	BreakGlassViewForbiddenErr   = breakGlassModule.Description("ViewForbiddenErr", "只有申请人、数据 Owner 或安全团队可以查看紧急访问")
+	BreakGlassPolicyMissingErr   = breakGlassModule.Description("PolicyMissingErr", "创建紧急访问授权未返回策略ID")
+)
@@ -22,6 +22,8 @@ type Settings struct {
 	Workflow Workflow
 	// 审计相关配置
 	Audit Audit `yaml:"audit"`
+	// 紧急访问相关配置
+	BreakGlass BreakGlass `yaml:"break_glass" json:"break_glass"`
 }
 
 type Server struct {
@@ -107,3 +109,9 @@ type Audit struct {
 	// 是否启用审计
 	Enabled bool `json:"enabled,omitempty"`
 }
+
+// 紧急访问相关配置
+type BreakGlass struct {
+	// 安全团队成员的用户ID，逗号分隔。紧急访问时通知安全团队，安全团队可以提前结束紧急访问
+	SecurityTeam string `json:"security_team,omitempty"`
+}
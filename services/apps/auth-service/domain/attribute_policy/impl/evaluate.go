@@ -0,0 +1,310 @@
+package impl
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-common/util"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// Evaluate 计算访问者访问资源时生效的属性策略
+func (u *useCaseImpl) Evaluate(ctx context.Context, req *dto.AttributePolicyEvaluateReq) (*dto.AttributeObligations, error) {
+	policies, err := u.repo.ListEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subjectID, subjectType := req.SubjectId, req.SubjectType
+	if subjectID == "" {
+		userInfo, err := util.GetUserInfo(ctx)
+		if err != nil || userInfo.ID == "" {
+			return nil, errorcode.AttributePolicySubjectEmptyErr.Err()
+		}
+		subjectID, subjectType = userInfo.ID, dto.SubjectUser.Str()
+	}
+	subject, err := u.resolveSubject(ctx, subjectID, subjectType)
+	if err != nil {
+		return nil, err
+	}
+	return u.evaluate(ctx, policies, subject, req.ObjectType, req.ObjectId, req.Fields)
+}
+
+// AttachObligations 为策略验证结果中允许读取、下载的逻辑视图、接口附加生效的属性策略，
+// 计算失败时在对应的验证结果中记录失败原因
+func (u *useCaseImpl) AttachObligations(ctx context.Context, res *dto.PolicyEnforceRes) {
+	if res == nil {
+		return
+	}
+	var indexes []int
+	for i := range *res {
+		if needObligations(&(*res)[i]) {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return
+	}
+	policies, err := u.repo.ListEnabled(ctx)
+	if err != nil {
+		log.WithContext(ctx).Error("list enabled attribute policies fail", zap.Error(err))
+		for _, i := range indexes {
+			(*res)[i].ObligationError = err.Error()
+		}
+		return
+	}
+	// 同一访问者、资源的读取和下载共用计算结果，失败的结果也复用
+	type subjectResult struct {
+		subject *dto.AttributeSubject
+		err     error
+	}
+	type obligationsResult struct {
+		obligations *dto.AttributeObligations
+		err         error
+	}
+	subjects := make(map[string]*subjectResult)
+	results := make(map[string]*obligationsResult)
+	for _, i := range indexes {
+		effect := &(*res)[i]
+		subjectKey := effect.SubjectType + "/" + effect.SubjectId
+		s, ok := subjects[subjectKey]
+		if !ok {
+			s = &subjectResult{}
+			s.subject, s.err = u.resolveSubject(ctx, effect.SubjectId, effect.SubjectType)
+			subjects[subjectKey] = s
+		}
+		if s.err != nil {
+			effect.ObligationError = s.err.Error()
+			continue
+		}
+		resultKey := subjectKey + "/" + effect.ObjectType + "/" + effect.ObjectId
+		r, ok := results[resultKey]
+		if !ok {
+			r = &obligationsResult{}
+			r.obligations, r.err = u.evaluate(ctx, policies, s.subject, effect.ObjectType, effect.ObjectId, nil)
+			if r.err != nil {
+				log.WithContext(ctx).Error("evaluate attribute policies fail", zap.String("subject", subjectKey), zap.String("object", effect.ObjectType+"/"+effect.ObjectId), zap.Error(r.err))
+			}
+			results[resultKey] = r
+		}
+		if r.err != nil {
+			effect.ObligationError = r.err.Error()
+			continue
+		}
+		effect.Obligations = r.obligations
+	}
+}
+
+// needObligations 返回策略验证结果是否需要附加属性策略
+func needObligations(effect *dto.PolicyEnforceEffect) bool {
+	if effect.Effect != dto.EftAllow {
+		return false
+	}
+	if effect.Action != dto.ActionRead.Str() && effect.Action != dto.ActionDownload.Str() {
+		return false
+	}
+	return lo.Contains(attributeObjectTypes, effect.ObjectType)
+}
+
+// attributeObjectTypes 属性策略支持的资源类型
+var attributeObjectTypes = []string{dto.ObjectDataView.Str(), dto.ObjectAPI.Str()}
+
+func (u *useCaseImpl) evaluate(ctx context.Context, policies []*model.TAuthAttributePolicy, subject *dto.AttributeSubject, objectType, objectID string, refs []dto.AttributeFieldRef) (*dto.AttributeObligations, error) {
+	policies = applicablePolicies(policies, subject, objectType)
+	// 没有生效的策略时不需要获取资源的字段
+	if len(policies) == 0 {
+		return newAttributeObligations(), nil
+	}
+	fields, err := u.resolveFields(ctx, objectType, objectID, refs)
+	if err != nil {
+		return nil, err
+	}
+	return evaluatePolicies(policies, subject, fields), nil
+}
+
+// resolveSubject 获取访问者的属性，应用和角色没有部门和密级
+func (u *useCaseImpl) resolveSubject(ctx context.Context, id, subjectType string) (*dto.AttributeSubject, error) {
+	subject := &dto.AttributeSubject{ID: id, Type: subjectType, DepartmentIDs: make([]string, 0)}
+	switch subjectType {
+	case dto.SubjectUser.Str():
+		userInfo, err := u.userRepo.GetUserById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		subject.CsfLevel = userInfo.CsfLevel
+		subject.DepartmentIDs = userDepartmentIDs(userInfo)
+	case dto.SubjectDepartment.Str():
+		subject.DepartmentIDs = []string{id}
+	}
+	return subject, nil
+}
+
+// userDepartmentIDs 返回用户所属部门及其上级部门的ID
+func userDepartmentIDs(userInfo *dto.UserInfo) []string {
+	ids := make([]string, 0)
+	for _, path := range userInfo.ParentDeps {
+		for _, dep := range path {
+			ids = append(ids, dep.Id)
+		}
+	}
+	return lo.Uniq(lo.Filter(ids, func(id string, _ int) bool { return id != "" }))
+}
+
+// resolveFields 获取资源访问的字段及其分级标签，没有指定字段时使用资源的所有字段：
+// 逻辑视图的所有字段、接口的返回参数
+func (u *useCaseImpl) resolveFields(ctx context.Context, objectType, objectID string, refs []dto.AttributeFieldRef) ([]dto.AttributeField, error) {
+	if len(refs) == 0 {
+		switch objectType {
+		case dto.ObjectDataView.Str():
+			labels, err := u.dataViewLocal.GetFieldLabels(ctx, []string{objectID})
+			if err != nil {
+				return nil, err
+			}
+			return viewFields(objectID, labels[objectID]), nil
+		case dto.ObjectAPI.Str():
+			param, err := u.serviceRepo.DataApplicationServiceParamGet(ctx, objectID)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range param.ServiceParam.DataTableResponseParams {
+				refs = append(refs, dto.AttributeFieldRef{DataViewID: param.ServiceParam.DataViewId, Name: p.EnName})
+			}
+		}
+	}
+	viewIDs := lo.Uniq(lo.FilterMap(refs, func(ref dto.AttributeFieldRef, _ int) (string, bool) { return ref.DataViewID, ref.DataViewID != "" }))
+	if len(viewIDs) == 0 {
+		return make([]dto.AttributeField, 0), nil
+	}
+	labels, err := u.dataViewLocal.GetFieldLabels(ctx, viewIDs)
+	if err != nil {
+		return nil, err
+	}
+	return labelFields(refs, labels), nil
+}
+
+// viewFields 返回逻辑视图中有分级标签的字段，按字段名称排序
+func viewFields(viewID string, labels map[string]string) []dto.AttributeField {
+	fields := make([]dto.AttributeField, 0, len(labels))
+	for name, labelID := range labels {
+		if labelID == "" {
+			continue
+		}
+		fields = append(fields, dto.AttributeField{AttributeFieldRef: dto.AttributeFieldRef{DataViewID: viewID, Name: name}, LabelID: labelID})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+// labelFields 返回字段中有分级标签的字段，去掉重复的字段
+func labelFields(refs []dto.AttributeFieldRef, labels map[string]map[string]string) []dto.AttributeField {
+	fields := make([]dto.AttributeField, 0, len(refs))
+	for _, ref := range lo.Uniq(refs) {
+		if labelID := labels[ref.DataViewID][ref.Name]; labelID != "" {
+			fields = append(fields, dto.AttributeField{AttributeFieldRef: ref, LabelID: labelID})
+		}
+	}
+	return fields
+}
+
+// applicablePolicies 返回对资源类型生效且访问者不满足豁免条件的策略
+func applicablePolicies(policies []*model.TAuthAttributePolicy, subject *dto.AttributeSubject, objectType string) []*model.TAuthAttributePolicy {
+	return lo.Filter(policies, func(policy *model.TAuthAttributePolicy, _ int) bool {
+		if objectTypes := splitComma(policy.ObjectTypes); len(objectTypes) > 0 && !lo.Contains(objectTypes, objectType) {
+			return false
+		}
+		return !isExempt(policy, subject)
+	})
+}
+
+// isExempt 返回访问者是否满足策略的豁免条件：密级足够，或者属于豁免的部门及其子部门
+func isExempt(policy *model.TAuthAttributePolicy, subject *dto.AttributeSubject) bool {
+	if policy.ExemptCsfLevel > 0 && subject.CsfLevel >= policy.ExemptCsfLevel {
+		return true
+	}
+	return lo.Some(subject.DepartmentIDs, splitComma(policy.ExemptDepartmentIDs))
+}
+
+// evaluatePolicies 把生效的策略应用到带分级标签的字段上，同一字段上隐藏优先于脱敏
+func evaluatePolicies(policies []*model.TAuthAttributePolicy, subject *dto.AttributeSubject, fields []dto.AttributeField) *dto.AttributeObligations {
+	result := newAttributeObligations()
+	columns := make(map[dto.AttributeFieldRef]*dto.AttributeColumnObligation)
+	for _, policy := range policies {
+		labelIDs := splitComma(policy.LabelIDs)
+		matched := false
+		for _, field := range fields {
+			if !lo.Contains(labelIDs, field.LabelID) {
+				continue
+			}
+			matched = true
+			switch policy.Type {
+			case dto.AttributePolicyColumn:
+				column, ok := columns[field.AttributeFieldRef]
+				if !ok {
+					column = &dto.AttributeColumnObligation{AttributeField: field, Action: policy.ColumnAction, PolicyIDs: make([]string, 0)}
+					columns[field.AttributeFieldRef] = column
+					result.Columns = append(result.Columns, column)
+				}
+				if policy.ColumnAction == dto.AttributeColumnHide {
+					column.Action = dto.AttributeColumnHide
+				}
+				column.PolicyIDs = append(column.PolicyIDs, policy.ID)
+			case dto.AttributePolicyRow:
+				result.RowFilters = append(result.RowFilters, &dto.AttributeRowFilter{
+					AttributeField: field,
+					Operator:       policy.RowOperator,
+					Value:          renderRowValue(policy.RowValue, subject),
+					PolicyID:       policy.ID,
+				})
+			}
+		}
+		if matched {
+			result.PolicyIDs = append(result.PolicyIDs, policy.ID)
+		}
+	}
+	return result
+}
+
+func newAttributeObligations() *dto.AttributeObligations {
+	return &dto.AttributeObligations{
+		PolicyIDs:  make([]string, 0),
+		Columns:    make([]*dto.AttributeColumnObligation, 0),
+		RowFilters: make([]*dto.AttributeRowFilter, 0),
+	}
+}
+
+// attributeVarPattern 匹配行策略过滤值中引用的访问者属性 ${name}
+var attributeVarPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// validRowValue 返回过滤值引用的访问者属性是否都存在
+func validRowValue(value string) bool {
+	for _, match := range attributeVarPattern.FindAllStringSubmatch(value, -1) {
+		switch match[1] {
+		case dto.AttributeVarUserID, dto.AttributeVarDepartmentIDs, dto.AttributeVarCsfLevel:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// renderRowValue 把过滤值中引用的访问者属性替换成访问者的属性值，多个部门用逗号分隔
+func renderRowValue(value string, subject *dto.AttributeSubject) string {
+	return attributeVarPattern.ReplaceAllStringFunc(value, func(s string) string {
+		switch attributeVarPattern.FindStringSubmatch(s)[1] {
+		case dto.AttributeVarUserID:
+			return subject.ID
+		case dto.AttributeVarDepartmentIDs:
+			return strings.Join(subject.DepartmentIDs, ",")
+		case dto.AttributeVarCsfLevel:
+			return strconv.Itoa(subject.CsfLevel)
+		}
+		return s
+	})
+}
@@ -0,0 +1,79 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicablePolicies(t *testing.T) {
+	policies := []*model.TAuthAttributePolicy{
+		{ID: "all"},
+		{ID: "api", ObjectTypes: "api"},
+		{ID: "csf", ExemptCsfLevel: 3},
+		{ID: "dep", ExemptDepartmentIDs: "d1,d2"},
+	}
+	ids := func(policies []*model.TAuthAttributePolicy) (ids []string) {
+		for _, p := range policies {
+			ids = append(ids, p.ID)
+		}
+		return
+	}
+
+	user := &dto.AttributeSubject{ID: "u1", Type: "user", CsfLevel: 3, DepartmentIDs: []string{"d0", "d2"}}
+	assert.Equal(t, []string{"all"}, ids(applicablePolicies(policies, user, "data_view")))
+	assert.Equal(t, []string{"all", "api"}, ids(applicablePolicies(policies, user, "api")))
+
+	app := &dto.AttributeSubject{ID: "a1", Type: "app"}
+	assert.Equal(t, []string{"all", "csf", "dep"}, ids(applicablePolicies(policies, app, "data_view")))
+}
+
+func TestEvaluatePolicies(t *testing.T) {
+	policies := []*model.TAuthAttributePolicy{
+		{ID: "p1", LabelIDs: "l1", Type: dto.AttributePolicyColumn, ColumnAction: dto.AttributeColumnMask},
+		{ID: "p2", LabelIDs: "l1,l2", Type: dto.AttributePolicyColumn, ColumnAction: dto.AttributeColumnHide},
+		{ID: "p3", LabelIDs: "l3", Type: dto.AttributePolicyRow, RowOperator: "in", RowValue: "${user.department_ids}"},
+		{ID: "p4", LabelIDs: "l9", Type: dto.AttributePolicyColumn, ColumnAction: dto.AttributeColumnMask},
+	}
+	subject := &dto.AttributeSubject{ID: "u1", DepartmentIDs: []string{"d1", "d2"}}
+	fields := []dto.AttributeField{
+		{AttributeFieldRef: dto.AttributeFieldRef{DataViewID: "v1", Name: "a"}, LabelID: "l1"},
+		{AttributeFieldRef: dto.AttributeFieldRef{DataViewID: "v1", Name: "b"}, LabelID: "l2"},
+		{AttributeFieldRef: dto.AttributeFieldRef{DataViewID: "v1", Name: "c"}, LabelID: "l3"},
+	}
+
+	result := evaluatePolicies(policies, subject, fields)
+	assert.Equal(t, []string{"p1", "p2", "p3"}, result.PolicyIDs)
+	if assert.Len(t, result.Columns, 2) {
+		assert.Equal(t, "a", result.Columns[0].Name)
+		assert.Equal(t, dto.AttributeColumnHide, result.Columns[0].Action)
+		assert.Equal(t, []string{"p1", "p2"}, result.Columns[0].PolicyIDs)
+		assert.Equal(t, "b", result.Columns[1].Name)
+		assert.Equal(t, dto.AttributeColumnHide, result.Columns[1].Action)
+	}
+	if assert.Len(t, result.RowFilters, 1) {
+		assert.Equal(t, "c", result.RowFilters[0].Name)
+		assert.Equal(t, "d1,d2", result.RowFilters[0].Value)
+	}
+}
+
+func TestRowValue(t *testing.T) {
+	assert.True(t, validRowValue("${user.id}"))
+	assert.True(t, validRowValue("level-${user.csf_level}"))
+	assert.True(t, validRowValue("fixed"))
+	assert.False(t, validRowValue("${user.name}"))
+
+	subject := &dto.AttributeSubject{ID: "u1", CsfLevel: 2, DepartmentIDs: []string{}}
+	assert.Equal(t, "u1/2/", renderRowValue("${user.id}/${user.csf_level}/${user.department_ids}", subject))
+}
+
+func TestLabelFields(t *testing.T) {
+	labels := map[string]map[string]string{"v1": {"a": "l1", "b": ""}}
+	refs := []dto.AttributeFieldRef{{DataViewID: "v1", Name: "a"}, {DataViewID: "v1", Name: "a"}, {DataViewID: "v1", Name: "b"}, {DataViewID: "v2", Name: "a"}}
+	assert.Equal(t, []dto.AttributeField{{AttributeFieldRef: refs[0], LabelID: "l1"}}, labelFields(refs, labels))
+
+	fields := viewFields("v1", map[string]string{"z": "l2", "a": "l1", "m": ""})
+	assert.Equal(t, []string{"a", "z"}, []string{fields[0].Name, fields[1].Name})
+}
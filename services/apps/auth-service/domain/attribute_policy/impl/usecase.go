@@ -0,0 +1,169 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/attribute_policy"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+	"github.com/kweaver-ai/idrm-go-common/util"
+	"github.com/samber/lo"
+)
+
+type useCaseImpl struct {
+	repo          gorm.AuthAttributePolicyRepo
+	userRepo      microservice.UserManagementRepo
+	dataViewLocal microservice.DataViewRepo
+	serviceRepo   microservice.DataApplicationServiceRepo
+}
+
+func NewUseCase(
+	repo gorm.AuthAttributePolicyRepo,
+	userRepo microservice.UserManagementRepo,
+	dataViewLocal microservice.DataViewRepo,
+	serviceRepo microservice.DataApplicationServiceRepo,
+) attribute_policy.UseCase {
+	return &useCaseImpl{
+		repo:          repo,
+		userRepo:      userRepo,
+		dataViewLocal: dataViewLocal,
+		serviceRepo:   serviceRepo,
+	}
+}
+
+// Create 创建属性策略
+func (u *useCaseImpl) Create(ctx context.Context, spec *dto.AttributePolicySpec) (string, error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err = validateSpec(spec); err != nil {
+		return "", err
+	}
+	policy := newAttributePolicyModel(spec)
+	policy.ID = uuid.Must(uuid.NewV7()).String()
+	policy.CreatedBy, policy.CreatedByName = userInfo.ID, userInfo.Name
+	policy.UpdatedBy, policy.UpdatedByName = userInfo.ID, userInfo.Name
+	policy.CreatedAt = time.Now()
+	if err = u.repo.Create(ctx, policy); err != nil {
+		return "", err
+	}
+	return policy.ID, nil
+}
+
+// Update 更新属性策略
+func (u *useCaseImpl) Update(ctx context.Context, id string, spec *dto.AttributePolicySpec) error {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if err = validateSpec(spec); err != nil {
+		return err
+	}
+	policy := newAttributePolicyModel(spec)
+	policy.ID = id
+	policy.UpdatedBy, policy.UpdatedByName = userInfo.ID, userInfo.Name
+	return u.repo.Update(ctx, policy)
+}
+
+// Delete 删除属性策略
+func (u *useCaseImpl) Delete(ctx context.Context, id string) error {
+	return u.repo.Delete(ctx, id)
+}
+
+// Get 获取属性策略详情
+func (u *useCaseImpl) Get(ctx context.Context, id string) (*dto.AttributePolicy, error) {
+	policy, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newAttributePolicy(policy), nil
+}
+
+// List 获取属性策略列表
+func (u *useCaseImpl) List(ctx context.Context, args *dto.AttributePolicyListArgs) (*dto.PageResult[dto.AttributePolicy], error) {
+	total, policies, err := u.repo.List(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PageResult[dto.AttributePolicy]{
+		TotalCount: total,
+		Entries: lo.Map(policies, func(item *model.TAuthAttributePolicy, index int) *dto.AttributePolicy {
+			return newAttributePolicy(item)
+		}),
+	}, nil
+}
+
+// validateSpec 检查行策略的过滤值，binding 无法检查
+func validateSpec(spec *dto.AttributePolicySpec) error {
+	if spec.Type != dto.AttributePolicyRow {
+		return nil
+	}
+	if spec.RowValue == "" && spec.RowOperator != "null" && spec.RowOperator != "not null" {
+		return errorcode.AttributePolicyRowValueEmptyErr.Err()
+	}
+	if !validRowValue(spec.RowValue) {
+		return errorcode.AttributePolicyRowValueInvalidErr.Err()
+	}
+	return nil
+}
+
+// newAttributePolicyModel 只保留策略类型对应的字段
+func newAttributePolicyModel(spec *dto.AttributePolicySpec) *model.TAuthAttributePolicy {
+	policy := &model.TAuthAttributePolicy{
+		Name:                spec.Name,
+		Description:         spec.Description,
+		ObjectTypes:         strings.Join(spec.ObjectTypes, ","),
+		LabelIDs:            strings.Join(spec.LabelIDs, ","),
+		Type:                spec.Type,
+		ExemptCsfLevel:      spec.ExemptCsfLevel,
+		ExemptDepartmentIDs: strings.Join(spec.ExemptDepartmentIDs, ","),
+		Enabled:             spec.Enabled,
+	}
+	if spec.Type == dto.AttributePolicyColumn {
+		policy.ColumnAction = spec.ColumnAction
+	} else {
+		policy.RowOperator, policy.RowValue = spec.RowOperator, spec.RowValue
+	}
+	return policy
+}
+
+func newAttributePolicy(policy *model.TAuthAttributePolicy) *dto.AttributePolicy {
+	return &dto.AttributePolicy{
+		ID: policy.ID,
+		AttributePolicySpec: dto.AttributePolicySpec{
+			Name:                policy.Name,
+			Description:         policy.Description,
+			ObjectTypes:         splitComma(policy.ObjectTypes),
+			LabelIDs:            splitComma(policy.LabelIDs),
+			Type:                policy.Type,
+			ColumnAction:        policy.ColumnAction,
+			RowOperator:         policy.RowOperator,
+			RowValue:            policy.RowValue,
+			ExemptCsfLevel:      policy.ExemptCsfLevel,
+			ExemptDepartmentIDs: splitComma(policy.ExemptDepartmentIDs),
+			Enabled:             policy.Enabled,
+		},
+		CreatedBy:     policy.CreatedBy,
+		CreatedByName: policy.CreatedByName,
+		CreatedAt:     meta_v1.NewTime(policy.CreatedAt),
+		UpdatedBy:     policy.UpdatedBy,
+		UpdatedByName: policy.UpdatedByName,
+		UpdatedAt:     meta_v1.NewTime(policy.UpdatedAt),
+	}
+}
+
+// splitComma 拆分逗号分隔的字符串，空字符串返回空列表
+func splitComma(s string) []string {
+	if s == "" {
+		return make([]string, 0)
+	}
+	return strings.Split(s, ",")
+}
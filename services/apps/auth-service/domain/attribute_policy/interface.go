@@ -0,0 +1,35 @@
+package attribute_policy
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+)
+
+// UseCase 属性策略：按字段的分级标签和访问者的属性（部门、密级）统一定义行列策略，
+// 不需要为每个逻辑视图、接口单独配置行列规则
+type UseCase interface {
+	Manager
+	Evaluator
+}
+
+type Manager interface {
+	// Create 创建属性策略
+	Create(ctx context.Context, spec *dto.AttributePolicySpec) (string, error)
+	// Update 更新属性策略
+	Update(ctx context.Context, id string, spec *dto.AttributePolicySpec) error
+	// Delete 删除属性策略
+	Delete(ctx context.Context, id string) error
+	// Get 获取属性策略详情
+	Get(ctx context.Context, id string) (*dto.AttributePolicy, error)
+	// List 获取属性策略列表
+	List(ctx context.Context, args *dto.AttributePolicyListArgs) (*dto.PageResult[dto.AttributePolicy], error)
+}
+
+type Evaluator interface {
+	// Evaluate 计算访问者访问资源时生效的属性策略
+	Evaluate(ctx context.Context, req *dto.AttributePolicyEvaluateReq) (*dto.AttributeObligations, error)
+	// AttachObligations 为策略验证结果中允许读取、下载的逻辑视图、接口附加生效的属性策略，
+	// 计算失败时记录在对应的验证结果中，不影响其他验证结果
+	AttachObligations(ctx context.Context, res *dto.PolicyEnforceRes)
+}
@@ -0,0 +1,78 @@
+package impl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+	"github.com/samber/lo"
+)
+
+func genApplyID(id string) string {
+	return fmt.Sprintf("%s-%d", id, time.Now().Unix())
+}
+
+func parseApplyID(id string) string {
+	ps := strings.Split(id, "-")
+	return strings.Join(ps[:len(ps)-1], "-")
+}
+
+// grantDuration 紧急访问的有效期，未指定时使用默认有效期，最长不超过最长有效期
+func grantDuration(duration int) int {
+	if duration <= 0 {
+		return dto.BreakGlassDefaultDuration
+	}
+	return min(duration, dto.BreakGlassMaxDuration)
+}
+
+// notifyRecipients 紧急访问的通知接收人：数据 Owner 和安全团队，不包括申请人自己
+func notifyRecipients(owners, team []string, requester string) []string {
+	return lo.Uniq(lo.Filter(append(append([]string{}, owners...), team...), func(id string, index int) bool {
+		return id != "" && id != requester
+	}))
+}
+
+// viewable 用户是否可以查看紧急访问：申请人、申请时的数据 Owner 和安全团队
+func viewable(grant *model.TAuthBreakGlassGrant, team []string, userID string) bool {
+	return grant.SubjectID == userID || lo.Contains(splitUserIDs(grant.OwnerIDs), userID) || lo.Contains(team, userID)
+}
+
+// splitUserIDs 解析逗号分隔的用户ID
+func splitUserIDs(s string) []string {
+	return lo.FilterMap(strings.Split(s, ","), func(id string, index int) (string, bool) {
+		id = strings.TrimSpace(id)
+		return id, id != ""
+	})
+}
+
+func newBreakGlassGrant(grant *model.TAuthBreakGlassGrant) *dto.BreakGlassGrant {
+	result := &dto.BreakGlassGrant{
+		ID:            grant.ID,
+		ObjectId:      grant.ObjectID,
+		ObjectType:    grant.ObjectType,
+		ObjectName:    grant.ObjectName,
+		SubjectId:     grant.SubjectID,
+		SubjectName:   grant.SubjectName,
+		Justification: grant.Justification,
+		Duration:      grant.Duration,
+		ExpiredAt:     meta_v1.NewTime(grant.ExpiredAt),
+		Status:        grant.Status,
+		RevokedBy:     grant.RevokedBy,
+		RevokedByName: grant.RevokedByName,
+		RevokeReason:  grant.RevokeReason,
+		Review: dto.BreakGlassReview{
+			ApplyID: grant.ApplyID,
+			Phase:   grant.AuditPhase,
+			Message: grant.AuditMessage,
+		},
+		CreatedAt: meta_v1.NewTime(grant.CreatedAt),
+	}
+	if grant.RevokedAt != nil {
+		revokedAt := meta_v1.NewTime(*grant.RevokedAt)
+		result.RevokedAt = &revokedAt
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+)
+
+func TestGrantDuration(t *testing.T) {
+	for _, tt := range []struct{ in, want int }{
+		{0, dto.BreakGlassDefaultDuration},
+		{30, 30},
+		{1000, dto.BreakGlassMaxDuration},
+	} {
+		if got := grantDuration(tt.in); got != tt.want {
+			t.Errorf("grantDuration(%d) = %d, 期望 %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNotifyRecipients(t *testing.T) {
+	got := notifyRecipients([]string{"o1", "", "u1"}, splitUserIDs(" s1, o1 ,,s2"), "u1")
+	if !reflect.DeepEqual(got, []string{"o1", "s1", "s2"}) {
+		t.Errorf("期望通知数据 Owner 和安全团队并去重，不通知申请人，但得到: %v", got)
+	}
+}
+
+func TestViewable(t *testing.T) {
+	grant := &model.TAuthBreakGlassGrant{SubjectID: "u1", OwnerIDs: "o1,o2"}
+	team := []string{"s1"}
+	for _, tt := range []struct {
+		userID string
+		want   bool
+	}{
+		{"u1", true},
+		{"o2", true},
+		{"s1", true},
+		{"u2", false},
+		{"o", false},
+		{"", false},
+	} {
+		if got := viewable(grant, team, tt.userID); got != tt.want {
+			t.Errorf("viewable(%q) = %v, 期望 %v", tt.userID, got, tt.want)
+		}
+	}
+}
+
+func TestParseApplyID(t *testing.T) {
+	id := "01940795-d488-77ea-ab48-689d2683a623"
+	if got := parseApplyID(genApplyID(id)); got != id {
+		t.Errorf("parseApplyID(genApplyID(%s)) = %s", id, got)
+	}
+}
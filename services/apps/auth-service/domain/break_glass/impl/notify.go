@@ -0,0 +1,40 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"go.uber.org/zap"
+)
+
+// notify 通知数据 Owner 和安全团队，由任务中心发送消息。通知失败只记录日志
+func (u *useCaseImpl) notify(ctx context.Context, grant *model.TAuthBreakGlassGrant) {
+	recipients := notifyRecipients(strings.Split(grant.OwnerIDs, ","), securityTeam(), grant.SubjectID)
+	if len(recipients) == 0 {
+		log.WithContext(ctx).Warn("break-glass grant has no recipient to notify", zap.String("grant", grant.ID))
+		return
+	}
+	bytes, err := json.Marshal(&dto.BreakGlassMessage{
+		GrantID:       grant.ID,
+		ObjectId:      grant.ObjectID,
+		ObjectName:    grant.ObjectName,
+		SubjectId:     grant.SubjectID,
+		SubjectName:   grant.SubjectName,
+		Justification: grant.Justification,
+		ExpiredAt:     grant.ExpiredAt.UnixMilli(),
+		RecipientIDs:  recipients,
+		CreatedAt:     grant.CreatedAt.UnixMilli(),
+	})
+	if err != nil {
+		log.WithContext(ctx).Error("marshal break-glass message fail", zap.String("grant", grant.ID), zap.Error(err))
+		return
+	}
+	if err = u.producer.Send(constant.BreakGlassTopic, bytes); err != nil {
+		log.WithContext(ctx).Error("send break-glass message fail", zap.String("grant", grant.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,129 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-common/rest/configuration_center"
+	"github.com/kweaver-ai/idrm-go-common/workflow/common"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// applyReview 发起事后复核。没有绑定审核流程或者发送失败时，事后复核保持未发起审核，并记录原因
+func (u *useCaseImpl) applyReview(ctx context.Context, grant *model.TAuthBreakGlassGrant) {
+	grant.AuditPhase = constant.AUDIT_PENDING
+	result, err := u.ccDriven.GetProcessBindByAuditType(ctx, &configuration_center.GetProcessBindByAuditTypeReq{AuditType: constant.BreakGlassReview})
+	switch {
+	case err != nil:
+		grant.AuditMessage = "查询事后复核的审核流程失败：" + err.Error()
+	case result.ProcDefKey == "":
+		grant.AuditMessage = "没有绑定事后复核的审核流程"
+	default:
+		msg := reviewMsgGenerator(grant)
+		msg.Process.ProcDefKey = result.ProcDefKey
+		if err = u.workflow.AuditApply(msg); err != nil {
+			grant.AuditMessage = "发送事后复核失败：" + err.Error()
+			break
+		}
+		grant.ApplyID = msg.Process.ApplyID
+		grant.ProcDefKey = result.ProcDefKey
+		grant.AuditPhase = constant.AUDIT_AUDITING
+	}
+	if grant.AuditPhase == constant.AUDIT_PENDING {
+		log.WithContext(ctx).Warn("break-glass review not applied", zap.String("grant", grant.ID), zap.String("reason", grant.AuditMessage))
+	}
+	if err = u.repo.UpdateAudit(ctx, grant); err != nil {
+		log.WithContext(ctx).Error("update break-glass review fail", zap.String("grant", grant.ID), zap.Error(err))
+	}
+}
+
+func reviewMsgGenerator(grant *model.TAuthBreakGlassGrant) *common.AuditApplyMsg {
+	return &common.AuditApplyMsg{
+		Process: common.AuditApplyProcessInfo{
+			AuditType:  constant.BreakGlassReview,
+			ApplyID:    genApplyID(grant.ID),
+			UserID:     grant.SubjectID,
+			UserName:   grant.SubjectName,
+			ProcDefKey: "", //外面赋值
+		},
+		Data: map[string]any{
+			"id":            grant.ID,
+			"object_id":     grant.ObjectID,
+			"object_name":   grant.ObjectName,
+			"justification": grant.Justification,
+			"duration":      grant.Duration,
+			"expired_at":    grant.ExpiredAt.Format(time.DateTime),
+			"apply_time":    grant.CreatedAt.Format(time.DateTime),
+		},
+		Workflow: common.AuditApplyWorkflowInfo{
+			TopCsf: 5,
+			AbstractInfo: common.AuditApplyAbstractInfo{
+				Icon: constant.AuditIconBase64,
+				Text: "紧急访问：" + grant.ObjectName,
+			},
+			Webhooks: []common.Webhook{},
+		},
+	}
+}
+
+// ConsumerWorkflowAuditMsg 处理审核中的消息，记录复核不通过的审核意见
+func (u *useCaseImpl) ConsumerWorkflowAuditMsg(ctx context.Context, msg *common.AuditProcessMsg) error {
+	log.Info("consumer break-glass review process msg", zap.Any("msg", fmt.Sprintf("%#v", msg)))
+	if msg.CurrentActivity == nil || msg.ProcessInputModel.Fields.AuditIdea {
+		return nil
+	}
+	grant, err := u.repo.Get(ctx, parseApplyID(msg.ProcessInputModel.Fields.ApplyID))
+	if err != nil {
+		log.Error("get break-glass grant error: ", zap.Error(err))
+		return nil
+	}
+	grant.AuditPhase = constant.AUDIT_REJECT
+	grant.AuditMessage = lo.FromPtr(msg.GetAuditMsg())
+	if err = u.repo.UpdateAudit(ctx, grant); err != nil {
+		log.Error("update break-glass review error: ", zap.Error(err))
+	}
+	return nil
+}
+
+// ConsumerWorkflowAuditResultRequest 处理复核结果，复核不通过时立即回收生效中的紧急访问
+func (u *useCaseImpl) ConsumerWorkflowAuditResultRequest(ctx context.Context, msg *common.AuditResultMsg) error {
+	log.Info("consumer break-glass review result msg", zap.Any("msg", fmt.Sprintf("%#v", msg)))
+	grant, err := u.repo.Get(ctx, parseApplyID(msg.ApplyID))
+	if err != nil {
+		log.Error("get break-glass grant error: ", zap.Error(err))
+		return err
+	}
+	grant.AuditPhase = msg.Result
+	if err = u.repo.UpdateAudit(ctx, grant); err != nil {
+		log.Error("update break-glass review error: ", zap.Error(err))
+		return err
+	}
+	if grant.AuditPhase != constant.AUDIT_REJECT || grant.Status != dto.BreakGlassActive {
+		return nil
+	}
+	now := time.Now()
+	grant.Status = dto.BreakGlassRevoked
+	grant.RevokedAt = &now
+	grant.RevokeReason = "事后复核不通过"
+	return u.revoke(ctx, grant)
+}
+
+// ConsumerWorkflowAuditProcDeleteRequest 审核流程被删除，审核中的事后复核恢复为未发起审核，并解绑审核流程
+func (u *useCaseImpl) ConsumerWorkflowAuditProcDeleteRequest(ctx context.Context, msg *common.AuditProcDefDelMsg) error {
+	log.Info("consumer break-glass review proc delete msg", zap.String("msg", string(lo.T2(json.Marshal(msg)).A)))
+	if len(msg.ProcDefKeys) == 0 {
+		return nil
+	}
+	if err := u.repo.ResetAuditByProcDefKeys(ctx, msg.ProcDefKeys, "审核流程被删除，事后复核未完成"); err != nil {
+		log.Error("reset break-glass review error: ", zap.Error(err))
+		return err
+	}
+	return u.ccDriven.DeleteProcessBindByAuditType(ctx, &configuration_center.DeleteProcessBindByAuditTypeReq{AuditType: constant.BreakGlassReview})
+}
@@ -0,0 +1,57 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"go.uber.org/zap"
+)
+
+// 检查紧急访问是否到期的间隔
+const expireDueGrantsInterval = time.Minute
+
+// Server 定期回收已到期的紧急访问
+type Server struct {
+	uc     break_glass.UseCase
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+}
+
+func NewServer(uc break_glass.UseCase) *Server {
+	return &Server{uc: uc}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mtx.Lock()
+	s.cancel = cancel
+	s.mtx.Unlock()
+
+	ticker := time.NewTicker(expireDueGrantsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.uc.ExpireDueGrants(ctx); err != nil {
+				log.WithContext(ctx).Error("expire due break-glass grants fail", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Server) Stop(_ context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/common_auth"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+	"github.com/kweaver-ai/idrm-go-common/rest/configuration_center"
+	"github.com/kweaver-ai/idrm-go-common/util"
+	"github.com/kweaver-ai/idrm-go-common/workflow"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/mq/kafkax"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+type useCaseImpl struct {
+	repo          gorm.AuthBreakGlassRepo
+	auth          common_auth.Auth
+	dataViewLocal microservice.DataViewRepo
+	workflow      workflow.WorkflowInterface
+	ccDriven      configuration_center.Driven
+	producer      kafkax.Producer
+}
+
+func NewUseCase(
+	repo gorm.AuthBreakGlassRepo,
+	auth common_auth.Auth,
+	dataViewLocal microservice.DataViewRepo,
+	wf workflow.WorkflowInterface,
+	ccDriven configuration_center.Driven,
+	producer kafkax.Producer,
+) break_glass.UseCase {
+	return &useCaseImpl{
+		repo:          repo,
+		auth:          auth,
+		dataViewLocal: dataViewLocal,
+		workflow:      wf,
+		ccDriven:      ccDriven,
+		producer:      producer,
+	}
+}
+
+// Create 申请紧急访问。先保存紧急访问，用户在逻辑视图上只能有一个生效中的紧急访问，由唯一索引保证；
+// 再创建授权，授权立即生效，授权的过期时间即紧急访问的过期时间。
+// 之后发起事后复核并通知数据 Owner 和安全团队，这两步失败不影响紧急访问
+func (u *useCaseImpl) Create(ctx context.Context, req *dto.BreakGlassGrantCreateReq) (string, error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	view, err := u.dataViewLocal.DataViewGet(ctx, req.ObjectId)
+	if err != nil {
+		return "", err
+	}
+	allowed, err := u.readAllowed(ctx, req.ObjectId, userInfo.ID)
+	if err != nil {
+		return "", err
+	}
+	if allowed {
+		return "", errorcode.BreakGlassAlreadyAllowedErr.Err()
+	}
+
+	now := time.Now()
+	duration := grantDuration(req.Duration)
+	grant := &model.TAuthBreakGlassGrant{
+		ID:            uuid.Must(uuid.NewV7()).String(),
+		ObjectID:      req.ObjectId,
+		ObjectType:    dto.ObjectDataView.Str(),
+		ObjectName:    view.BusinessName,
+		SubjectID:     userInfo.ID,
+		SubjectName:   userInfo.Name,
+		Justification: req.Justification,
+		Duration:      duration,
+		ExpiredAt:     now.Add(time.Duration(duration) * time.Minute),
+		OwnerIDs: strings.Join(lo.Map(view.Owners, func(item microservice.DataViewOwner, index int) string {
+			return item.OwnerID
+		}), ","),
+		Status:    dto.BreakGlassActive,
+		CreatedAt: now,
+	}
+	if err = u.repo.Create(ctx, grant); err != nil {
+		return "", err
+	}
+	policyIDs, err := u.grantRead(ctx, grant)
+	if err != nil {
+		u.discard(ctx, grant)
+		return "", err
+	}
+	grant.PolicyIDs = strings.Join(policyIDs, ",")
+	if err = u.repo.UpdatePolicyIDs(ctx, grant); err != nil {
+		// 授权已经生效，保存失败时回收授权，避免出现无法追踪的紧急访问
+		if deleteErr := u.deletePolicies(ctx, grant); deleteErr != nil {
+			log.WithContext(ctx).Error("delete break-glass policies fail", zap.String("policy_ids", grant.PolicyIDs), zap.Error(deleteErr))
+		}
+		u.discard(ctx, grant)
+		return "", err
+	}
+
+	u.applyReview(ctx, grant)
+	u.notify(ctx, grant)
+	return grant.ID, nil
+}
+
+// discard 紧急访问没有生效时删除，释放唯一键，申请人可以重新申请
+func (u *useCaseImpl) discard(ctx context.Context, grant *model.TAuthBreakGlassGrant) {
+	if err := u.repo.Delete(ctx, grant.ID); err != nil {
+		log.WithContext(ctx).Error("delete break-glass grant fail", zap.String("grant", grant.ID), zap.Error(err))
+	}
+}
+
+// List 获取紧急访问列表，安全团队可以查看全部，其他用户只能查看自己申请的和自己是数据 Owner 的
+func (u *useCaseImpl) List(ctx context.Context, args *dto.BreakGlassGrantListArgs) (*dto.PageResult[dto.BreakGlassGrant], error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args.ViewerId = ""
+	if !lo.Contains(securityTeam(), userInfo.ID) {
+		args.ViewerId = userInfo.ID
+	}
+	total, grants, err := u.repo.List(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PageResult[dto.BreakGlassGrant]{
+		TotalCount: total,
+		Entries: lo.Map(grants, func(item *model.TAuthBreakGlassGrant, index int) *dto.BreakGlassGrant {
+			return newBreakGlassGrant(item)
+		}),
+	}, nil
+}
+
+// Get 获取紧急访问详情，只有申请人、数据 Owner 和安全团队可以查看
+func (u *useCaseImpl) Get(ctx context.Context, id string) (*dto.BreakGlassGrant, error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grant, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !viewable(grant, securityTeam(), userInfo.ID) {
+		return nil, errorcode.BreakGlassViewForbiddenErr.Err()
+	}
+	return newBreakGlassGrant(grant), nil
+}
+
+// Revoke 提前结束紧急访问，只有申请人和安全团队可以结束
+func (u *useCaseImpl) Revoke(ctx context.Context, id string, req *dto.BreakGlassGrantRevokeReq) error {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return err
+	}
+	grant, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if grant.SubjectID != userInfo.ID && !lo.Contains(securityTeam(), userInfo.ID) {
+		return errorcode.BreakGlassRevokeForbiddenErr.Err()
+	}
+	if grant.Status != dto.BreakGlassActive {
+		return errorcode.BreakGlassNotActiveErr.Err()
+	}
+	now := time.Now()
+	grant.Status = dto.BreakGlassRevoked
+	grant.RevokedAt = &now
+	grant.RevokedBy = userInfo.ID
+	grant.RevokedByName = userInfo.Name
+	grant.RevokeReason = req.Reason
+	return u.revoke(ctx, grant)
+}
+
+// ExpireDueGrants 回收已到期的紧急访问。授权本身带有过期时间，这里删除授权并记录状态，
+// 回收失败的紧急访问保持生效中，下次继续回收
+func (u *useCaseImpl) ExpireDueGrants(ctx context.Context) error {
+	now := time.Now()
+	grants, err := u.repo.ListDue(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, grant := range grants {
+		grant.Status = dto.BreakGlassExpired
+		grant.RevokedAt = &now
+		if err := u.revoke(ctx, grant); err != nil {
+			log.WithContext(ctx).Error("expire break-glass grant fail", zap.String("grant", grant.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// readAllowed 用户是否已经拥有逻辑视图的读取权限
+func (u *useCaseImpl) readAllowed(ctx context.Context, objectID, subjectID string) (bool, error) {
+	res, err := u.auth.Enforce(ctx, &dto.PolicyEnforceReq{{
+		ObjectId:    objectID,
+		ObjectType:  dto.ObjectDataView.Str(),
+		SubjectId:   subjectID,
+		SubjectType: dto.SubjectUser.Str(),
+		Action:      dto.ActionRead.Str(),
+	}}, nil)
+	if err != nil {
+		return false, err
+	}
+	return lo.ContainsBy(*res, func(item dto.PolicyEnforceEffect) bool { return item.Effect == dto.EftAllow }), nil
+}
+
+// grantRead 为申请人创建带过期时间的读取授权，返回新创建的策略ID。
+// 回收时只删除这些策略，不影响申请人在该逻辑视图上的其他授权
+func (u *useCaseImpl) grantRead(ctx context.Context, grant *model.TAuthBreakGlassGrant) ([]string, error) {
+	expiredAt := meta_v1.NewTime(grant.ExpiredAt)
+	resp, err := u.auth.Create(ctx, &dto.PolicyCreateReq{Policy: dto.Policy{
+		Object: dto.Object{ObjectId: grant.ObjectID, ObjectType: grant.ObjectType, ObjectName: grant.ObjectName},
+		Subjects: []dto.Subject{{
+			SubjectId:   grant.SubjectID,
+			SubjectType: dto.SubjectUser.Str(),
+			SubjectName: grant.SubjectName,
+			Permissions: []dto.Permission{{Action: dto.ActionRead.Str(), Effect: dto.EftAllow}},
+			ExpiredAt:   &expiredAt,
+		}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	// 没有策略ID时无法回收授权，紧急访问不能生效
+	var policyIDs []string
+	if resp != nil {
+		policyIDs = lo.Compact(resp.IDs)
+	}
+	if len(policyIDs) == 0 {
+		return nil, errorcode.BreakGlassPolicyMissingErr.Err()
+	}
+	return policyIDs, nil
+}
+
+// revoke 删除紧急访问创建的授权并记录回收结果
+func (u *useCaseImpl) revoke(ctx context.Context, grant *model.TAuthBreakGlassGrant) error {
+	if err := u.deletePolicies(ctx, grant); err != nil {
+		return err
+	}
+	revoked, err := u.repo.Revoke(ctx, grant)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errorcode.BreakGlassNotActiveErr.Err()
+	}
+	return nil
+}
+
+// deletePolicies 删除紧急访问创建的策略，策略已经不存在时忽略
+func (u *useCaseImpl) deletePolicies(ctx context.Context, grant *model.TAuthBreakGlassGrant) error {
+	// 没有记录策略ID时不能按访问者删除，否则会删除申请人在该逻辑视图上的其他授权
+	if grant.PolicyIDs == "" {
+		return nil
+	}
+	return u.auth.Delete(ctx, &dto.PolicyDeleteReq{
+		PolicyID:    grant.PolicyIDs,
+		ObjectId:    grant.ObjectID,
+		ObjectType:  grant.ObjectType,
+		SubjectId:   grant.SubjectID,
+		SubjectType: dto.SubjectUser.Str(),
+	})
+}
+
+// securityTeam 安全团队成员的用户ID
+func securityTeam() []string {
+	return splitUserIDs(settings.Instance.BreakGlass.SecurityTeam)
+}
@@ -0,0 +1,35 @@
+package break_glass
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	workflow_common "github.com/kweaver-ai/idrm-go-common/workflow/common"
+)
+
+// UseCase 紧急访问：值班人员在故障时自助获得逻辑视图的临时读取权限，
+// 不需要审批，到期自动回收，并通过审核流程进行事后复核
+type UseCase interface {
+	Manager
+	ConsumeAuditHandler
+	// ExpireDueGrants 回收已到期的紧急访问
+	ExpireDueGrants(ctx context.Context) error
+}
+
+type Manager interface {
+	// Create 申请紧急访问，立即获得逻辑视图的读取权限
+	Create(ctx context.Context, req *dto.BreakGlassGrantCreateReq) (string, error)
+	// List 获取紧急访问列表，安全团队可以查看全部，其他用户只能查看自己申请的和自己是数据 Owner 的
+	List(ctx context.Context, args *dto.BreakGlassGrantListArgs) (*dto.PageResult[dto.BreakGlassGrant], error)
+	// Get 获取紧急访问详情，只有申请人、数据 Owner 和安全团队可以查看
+	Get(ctx context.Context, id string) (*dto.BreakGlassGrant, error)
+	// Revoke 提前结束紧急访问，回收读取权限
+	Revoke(ctx context.Context, id string, req *dto.BreakGlassGrantRevokeReq) error
+}
+
+// ConsumeAuditHandler 处理事后复核的审核消息
+type ConsumeAuditHandler interface {
+	ConsumerWorkflowAuditMsg(ctx context.Context, msg *workflow_common.AuditProcessMsg) error
+	ConsumerWorkflowAuditResultRequest(ctx context.Context, msg *workflow_common.AuditResultMsg) error
+	ConsumerWorkflowAuditProcDeleteRequest(ctx context.Context, msg *workflow_common.AuditProcDefDelMsg) error
+}
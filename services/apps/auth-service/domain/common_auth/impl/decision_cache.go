@@ -0,0 +1,94 @@
+package impl
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+)
+
+// 策略决策缓存配置
+const (
+	// 缓存大小
+	decisionCacheSize = 1 << 16
+	// 缓存过期时间，策略变更由其他实例写入时最多延迟这么久生效
+	decisionCacheTTL = 5 * time.Second
+)
+
+// decisionKey 策略决策缓存的键
+type decisionKey struct {
+	SubjectType string
+	SubjectId   string
+	ObjectType  string
+	ObjectId    string
+	Action      string
+}
+
+func newDecisionKey(req *dto.PolicyEnforce) decisionKey {
+	return decisionKey{
+		SubjectType: req.SubjectType,
+		SubjectId:   req.SubjectId,
+		ObjectType:  req.ObjectType,
+		ObjectId:    req.ObjectId,
+		Action:      req.Action,
+	}
+}
+
+// decisionCache 本地的策略决策缓存，记录访问者对资源的某个动作是否允许
+type decisionCache struct {
+	cache *expirable.LRU[decisionKey, bool]
+	// 命中次数
+	hits atomic.Uint64
+	// 未命中次数
+	misses atomic.Uint64
+	// 因策略变更失效的次数
+	invalidations atomic.Uint64
+}
+
+func newDecisionCache() *decisionCache {
+	return &decisionCache{cache: expirable.NewLRU[decisionKey, bool](decisionCacheSize, nil, decisionCacheTTL)}
+}
+
+// Get 查询缓存的决策结果
+func (c *decisionCache) Get(key decisionKey) (allow bool, ok bool) {
+	if allow, ok = c.cache.Get(key); ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return
+}
+
+// Add 缓存决策结果
+func (c *decisionCache) Add(key decisionKey, allow bool) {
+	c.cache.Add(key, allow)
+}
+
+// InvalidateObject 资源的策略变更后，删除所有访问者对该资源的决策结果。
+// 访问者可能通过部门、角色间接获得权限，所以不能只删除策略中的访问者
+func (c *decisionCache) InvalidateObject(objectType, objectId string) {
+	c.invalidations.Add(1)
+	for _, key := range c.cache.Keys() {
+		if key.ObjectType == objectType && key.ObjectId == objectId {
+			c.cache.Remove(key)
+		}
+	}
+}
+
+// Stats 缓存的命中统计
+func (c *decisionCache) Stats() *dto.DecisionCacheStats {
+	hits, misses := c.hits.Load(), c.misses.Load()
+	stats := &dto.DecisionCacheStats{
+		Size:          c.cache.Len(),
+		Hits:          hits,
+		Misses:        misses,
+		Invalidations: c.invalidations.Load(),
+		TTLSeconds:    int(decisionCacheTTL / time.Second),
+	}
+	if total := hits + misses; total > 0 {
+		stats.HitRatio = float64(hits) / float64(total)
+	}
+	return stats
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/database"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/database/af_configuration"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/enum"
 	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/util"
@@ -31,6 +32,12 @@ type auth struct {
 	driven authorization.Driven
 	helper *AuthHelper
 	ccDB   af_configuration.Interface
+	// 策略决策缓存
+	decisions *decisionCache
+	// 用户所属部门和角色，用于解释策略结果
+	userRepo microservice.UserManagementRepo
+	// 行列规则（子视图）
+	subViewRepo gorm.AuthSubViewRepo
 }
 
 func NewAuth(
@@ -43,6 +50,8 @@ func NewAuth(
 	indicatorDriven indicator_management.Driven,
 	database database.Interface,
 	userManagementDriven user_management.DrivenUserMgnt,
+	userRepo microservice.UserManagementRepo,
+	subViewRepo gorm.AuthSubViewRepo,
 ) domain.Auth {
 	return &auth{
 		driven: driven,
@@ -55,73 +64,120 @@ func NewAuth(
 			indicatorDriven,
 			userManagementDriven,
 		),
-		ccDB: database.AFConfiguration(),
+		ccDB:        database.AFConfiguration(),
+		decisions:   newDecisionCache(),
+		userRepo:    userRepo,
+		subViewRepo: subViewRepo,
 	}
 }
 
-// Enforce 策略验证
+// Enforce 策略验证，优先使用本地缓存的决策结果，未命中的按访问者和资源类型分组批量验证
 func (a *auth) Enforce(ctx context.Context, reqs *dto.PolicyEnforceReq, check *dto.PolicyEnforceCheck) (*dto.PolicyEnforceRes, error) {
-	enforcerResults := make(dto.PolicyEnforceRes, 0, len(*reqs))
-	for _, req := range *reqs {
-		if req.ObjectType != dto.ObjectAPI.Str() && req.ObjectType != dto.ObjectSubService.Str() && req.ObjectType != dto.ObjectSubView.Str() && req.ObjectType != dto.ObjectSubIndicator.Str() && req.ObjectType != dto.ObjectIndicator.Str() {
-			// CAUTION: original logic returned true/allow for unknown types?
-			// Previous code: if != API && != SubService { append true; continue }
-			// Now we adding SubView etc based on my understanding, or simply stick to original Logic?
-			// Original logic:
-			/*
-				            if req.ObjectType != dto.ObjectAPI.Str() && req.ObjectType != dto.ObjectSubService.Str() {
-							    enforcerResults = append(enforcerResults, true)
-							    continue
-						    }
-			*/
-			// But domain code calls it for ObjectSubView and ObjectIndicatorDimensionalRule
-			// If I keep original logic, checking SubView would always return TRUE (Allow) without checking driven?
-			// That seems WRONG if domain code expects actual check.
-			// However, removing that check enforces strict driven check.
-			// I will strictly implement driven check for all, OR trust the driven/policy engine.
-			// But to be safe and match "new auth" intent, I should probably check AccessorPolicy for everything.
-			// I'll assume driven can handle all defined types in dto.ObjectType.
-			// However, to replicate original behavior (skipping non-API/SubService), I might break things if I don't check.
-			// But clearly usage in 'indicator_dimensional_rule' implies it WANTS a check.
-			// So I will REMOVE the early return for other types, assuming 'driven' handles them.
-			// Or better, only skip if driven doesn't support it?
+	enforcerResults := make(dto.PolicyEnforceRes, len(*reqs))
+	misses := make([]int, 0, len(*reqs))
+	for i, req := range *reqs {
+		enforcerResults[i] = dto.PolicyEnforceEffect{
+			ObjectId:    req.ObjectId,
+			ObjectType:  req.ObjectType,
+			SubjectId:   req.SubjectId,
+			SubjectType: req.SubjectType,
+			Action:      req.Action,
+		}
+		if allow, ok := a.decisions.Get(newDecisionKey(&req)); ok {
+			enforcerResults[i].Effect = lo.Ternary(allow, dto.EftAllow, dto.EftDeny)
+			continue
+		}
+		misses = append(misses, i)
+	}
 
-			// Let's rely on standard logic: everything goes to driven check.
+	for _, group := range groupEnforceRequests(*reqs, misses) {
+		results, err := a.batchOperationCheck(ctx, *reqs, group)
+		if err != nil {
+			log.Errorf("Enforce Error %v", err.Error())
+			return nil, err
 		}
+		for j, i := range group {
+			a.decisions.Add(newDecisionKey(&(*reqs)[i]), results[j])
+			enforcerResults[i].Effect = lo.Ternary(results[j], dto.EftAllow, dto.EftDeny)
+		}
+	}
+	return &enforcerResults, nil
+}
 
-		arg := &authorization.OperationCheckArgs{
-			Accessor: authorization.Accessor{
-				ID:   req.SubjectId,
-				Type: req.SubjectType,
-			},
+// 一次批量验证最多包含的资源数量
+const enforceBatchSize = 200
+
+// groupEnforceRequests 将需要远程验证的请求按访问者和资源类型分组，保持请求的先后顺序，每组最多 enforceBatchSize 个
+func groupEnforceRequests(reqs []dto.PolicyEnforce, indexes []int) [][]int {
+	type groupKey struct {
+		SubjectType string
+		SubjectId   string
+		ObjectType  string
+	}
+	groups := make([][]int, 0)
+	current := make(map[groupKey]int)
+	for _, i := range indexes {
+		key := groupKey{SubjectType: reqs[i].SubjectType, SubjectId: reqs[i].SubjectId, ObjectType: reqs[i].ObjectType}
+		g, ok := current[key]
+		if !ok || len(groups[g]) >= enforceBatchSize {
+			g = len(groups)
+			current[key] = g
+			groups = append(groups, make([]int, 0, 1))
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// batchOperationCheck 验证同一访问者对同一类型资源的一组请求，返回每个请求是否允许。
+// 只有一个请求时直接验证动作，多个请求时一次查询所有资源允许的操作
+func (a *auth) batchOperationCheck(ctx context.Context, reqs []dto.PolicyEnforce, group []int) ([]bool, error) {
+	first := reqs[group[0]]
+	accessor := authorization.Accessor{
+		ID:   first.SubjectId,
+		Type: first.SubjectType,
+	}
+	resourceType := dto.ObjectToResourceType(first.ObjectType)
+	if len(group) == 1 {
+		result, err := a.driven.OperationCheck(ctx, &authorization.OperationCheckArgs{
+			Accessor: accessor,
 			Resource: authorization.ResourceObject{
-				ID:   req.ObjectId,
-				Type: dto.ObjectToResourceType(req.ObjectType),
+				ID:   first.ObjectId,
+				Type: resourceType,
 			},
-			Operation: []string{req.Action},
+			Operation: []string{first.Action},
 			Method:    "GET",
 			Include:   []string{authorization.INCLUDE_OPERATION_OBLIGATIONS},
-		}
-		result, err := a.driven.OperationCheck(ctx, arg)
+		})
 		if err != nil {
-			log.Errorf("Enforce Error %v", err.Error())
 			return nil, err
 		}
+		return []bool{result.Result}, nil
+	}
 
-		effect := dto.EftDeny
-		if result.Result {
-			effect = dto.EftAllow
-		}
-		enforcerResults = append(enforcerResults, dto.PolicyEnforceEffect{
-			ObjectId:    req.ObjectId,
-			ObjectType:  req.ObjectType,
-			SubjectId:   req.SubjectId,
-			SubjectType: req.SubjectType,
-			Action:      req.Action,
-			Effect:      effect,
-		})
+	objectIds := lo.Uniq(lo.Map(group, func(i int, _ int) string { return reqs[i].ObjectId }))
+	resourceOperations, err := a.driven.GetResourceOperations(ctx, &authorization.GetResourceOperationsArgs{
+		Method:   "GET",
+		Accessor: accessor,
+		Resources: lo.Map(objectIds, func(id string, _ int) authorization.ResourceObject {
+			return authorization.ResourceObject{ID: id, Type: resourceType}
+		}),
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &enforcerResults, nil
+	operations := make(map[string][]string, len(objectIds))
+	for _, obj := range resourceOperations {
+		operations[obj.ID] = append(operations[obj.ID], obj.Operation...)
+	}
+	return lo.Map(group, func(i int, _ int) bool {
+		return lo.Contains(operations[reqs[i].ObjectId], reqs[i].Action)
+	}), nil
+}
+
+// DecisionCacheStats 策略决策缓存的命中统计
+func (a *auth) DecisionCacheStats(ctx context.Context) *dto.DecisionCacheStats {
+	return a.decisions.Stats()
 }
 
 // CurrentUserEnforce 当前用户的策略验证
@@ -130,6 +186,16 @@ func (a *auth) CurrentUserEnforce(ctx context.Context, req *dto.CurrentUserEnfor
 	if err != nil {
 		return false, err
 	}
+	key := newDecisionKey(&dto.PolicyEnforce{
+		ObjectId:    req.ObjectId,
+		ObjectType:  req.ObjectType,
+		SubjectId:   userInfo.ID,
+		SubjectType: dto.SubjectUser.String(),
+		Action:      req.Action,
+	})
+	if allow, ok := a.decisions.Get(key); ok {
+		return allow, nil
+	}
 	arg := &authorization.OperationCheckArgs{
 		Accessor: authorization.Accessor{
 			ID:   userInfo.ID,
@@ -147,6 +213,7 @@ func (a *auth) CurrentUserEnforce(ctx context.Context, req *dto.CurrentUserEnfor
 		log.Errorf("CheckUserPermission Error %v", err.Error())
 		return false, err
 	}
+	a.decisions.Add(key, result.Result)
 	return result.Result, nil
 }
 
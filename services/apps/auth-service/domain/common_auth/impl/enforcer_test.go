@@ -0,0 +1,47 @@
+package impl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+)
+
+func TestGroupEnforceRequests(t *testing.T) {
+	reqs := []dto.PolicyEnforce{
+		{SubjectType: "user", SubjectId: "u1", ObjectType: "data_view", ObjectId: "v1", Action: "read"},
+		{SubjectType: "user", SubjectId: "u1", ObjectType: "sub_view", ObjectId: "s1", Action: "read"},
+		{SubjectType: "user", SubjectId: "u1", ObjectType: "data_view", ObjectId: "v2", Action: "read"},
+		{SubjectType: "app", SubjectId: "u1", ObjectType: "data_view", ObjectId: "v1", Action: "read"},
+		{SubjectType: "user", SubjectId: "u1", ObjectType: "data_view", ObjectId: "v1", Action: "download"},
+	}
+
+	t.Run("按访问者和资源类型分组", func(t *testing.T) {
+		got := groupEnforceRequests(reqs, []int{0, 1, 2, 3, 4})
+		want := [][]int{{0, 2, 4}, {1}, {3}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("期望 %v，但得到: %v", want, got)
+		}
+	})
+
+	t.Run("只分组未命中缓存的请求", func(t *testing.T) {
+		got := groupEnforceRequests(reqs, []int{2, 3})
+		want := [][]int{{2}, {3}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("期望 %v，但得到: %v", want, got)
+		}
+	})
+
+	t.Run("超过批量大小时拆分", func(t *testing.T) {
+		many := make([]dto.PolicyEnforce, enforceBatchSize+1)
+		indexes := make([]int, len(many))
+		for i := range many {
+			many[i] = reqs[0]
+			indexes[i] = i
+		}
+		got := groupEnforceRequests(many, indexes)
+		if len(got) != 2 || len(got[0]) != enforceBatchSize || got[1][0] != enforceBatchSize {
+			t.Errorf("期望拆分为 %d 和 1 个，但得到 %d 组", enforceBatchSize, len(got))
+		}
+	})
+}
@@ -0,0 +1,368 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/enum"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-common/rest/authorization"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/samber/lo"
+)
+
+// 上级资源的类型，子视图继承所属逻辑视图的权限，接口行列规则继承所属接口的权限
+var parentObjectTypes = map[string]string{
+	dto.ObjectSubView.Str():    dto.ObjectDataView.Str(),
+	dto.ObjectSubService.Str(): dto.ObjectAPI.Str(),
+}
+
+// Explain 解释访问者对资源的动作的策略结果，返回参与决策的身份、策略和行列规则
+func (a *auth) Explain(ctx context.Context, req *dto.PolicyEnforce) (*dto.PolicyExplainRes, error) {
+	res := &dto.PolicyExplainRes{
+		PolicyEnforceEffect: dto.PolicyEnforceEffect{
+			ObjectId:    req.ObjectId,
+			ObjectType:  req.ObjectType,
+			SubjectId:   req.SubjectId,
+			SubjectType: req.SubjectType,
+			Action:      req.Action,
+		},
+	}
+	memberships, err := a.explainMemberships(ctx, req.SubjectType, req.SubjectId)
+	if err != nil {
+		return nil, err
+	}
+	res.Memberships = memberships
+
+	if res.IsOwner, err = a.checkSubjectIsObjectOwner(ctx, req.SubjectType, req.SubjectId, req.ObjectType, req.ObjectId); err != nil {
+		return nil, err
+	}
+
+	policies, err := a.explainObjectPolicies(ctx, req.ObjectType, req.ObjectId, false)
+	if err != nil {
+		return nil, err
+	}
+	if parentType, ok := parentObjectTypes[req.ObjectType]; ok {
+		objectInfo, err := a.helper.getObjectInfo(ctx, req.ObjectType, req.ObjectId)
+		if err != nil {
+			return nil, err
+		}
+		if objectInfo.SourceObjectID != "" {
+			parentPolicies, err := a.explainObjectPolicies(ctx, parentType, objectInfo.SourceObjectID, true)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, parentPolicies...)
+		}
+	}
+	res.Policies = evaluateExplainPolicies(policies, memberships, req.Action, time.Now())
+
+	// 策略结果以策略引擎为准，Owner 允许所有操作
+	allow := res.IsOwner
+	if !allow {
+		result, err := a.driven.OperationCheck(ctx, &authorization.OperationCheckArgs{
+			Accessor: authorization.Accessor{
+				ID:   req.SubjectId,
+				Type: req.SubjectType,
+			},
+			Resource: authorization.ResourceObject{
+				ID:   req.ObjectId,
+				Type: dto.ObjectToResourceType(req.ObjectType),
+			},
+			Operation: []string{req.Action},
+			Method:    "GET",
+		})
+		if err != nil {
+			log.Errorf("Explain OperationCheck Error %v", err.Error())
+			return nil, err
+		}
+		allow = result.Result
+	}
+	res.Effect = lo.Ternary(allow, dto.EftAllow, dto.EftDeny)
+	res.Reason = explainReason(res)
+
+	if res.Rules, err = a.explainRules(ctx, req); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WhatIf 模拟保存策略后访问者的策略结果，策略不会被保存
+func (a *auth) WhatIf(ctx context.Context, req *dto.PolicyWhatIfReq) (*dto.PolicyWhatIfRes, error) {
+	proposed := proposedExplainPolicies(&req.Policy)
+	actions := req.Actions
+	if len(actions) == 0 {
+		actions = lo.Uniq(lo.FlatMap(proposed, func(item dto.ExplainPolicy, index int) []string {
+			return item.Actions
+		}))
+	}
+
+	// 当前的策略结果
+	enforceReq := make(dto.PolicyEnforceReq, 0, len(req.Subjects)*len(actions))
+	for _, subject := range req.Subjects {
+		for _, action := range actions {
+			enforceReq = append(enforceReq, dto.PolicyEnforce{
+				ObjectId:    req.Policy.ObjectId,
+				ObjectType:  req.Policy.ObjectType,
+				SubjectId:   subject.SubjectId,
+				SubjectType: subject.SubjectType,
+				Action:      action,
+			})
+		}
+	}
+	current, err := a.Enforce(ctx, &enforceReq, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := &dto.PolicyWhatIfRes{Entries: make([]dto.PolicyWhatIfEntry, 0, len(enforceReq))}
+	for i, subject := range req.Subjects {
+		memberships, err := a.explainMemberships(ctx, subject.SubjectType, subject.SubjectId)
+		if err != nil {
+			return nil, err
+		}
+		for j, action := range actions {
+			effect := (*current)[i*len(actions)+j].Effect
+			policies := evaluateExplainPolicies(proposed, memberships, action, now)
+			// 新建策略只增加权限，当前允许的保存后仍然允许
+			proposedEffect := effect
+			if lo.ContainsBy(policies, func(item dto.ExplainPolicy) bool { return item.Matched }) {
+				proposedEffect = dto.EftAllow
+			}
+			res.Entries = append(res.Entries, dto.PolicyWhatIfEntry{
+				SubjectId:   subject.SubjectId,
+				SubjectType: subject.SubjectType,
+				Action:      action,
+				Current:     effect,
+				Proposed:    proposedEffect,
+				Changed:     proposedEffect != effect,
+				Policies:    policies,
+			})
+		}
+	}
+	return res, nil
+}
+
+// explainMemberships 访问者参与决策的身份：自身、所属部门及上级部门、角色
+func (a *auth) explainMemberships(ctx context.Context, subjectType, subjectId string) ([]dto.ExplainMembership, error) {
+	memberships := []dto.ExplainMembership{{
+		SubjectId:   subjectId,
+		SubjectType: subjectType,
+		Via:         dto.ViaSelf,
+	}}
+	switch subjectType {
+	case enum.SubjectTypeUser:
+		userInfo, err := a.userRepo.GetUserById(ctx, subjectId)
+		if err != nil {
+			return nil, err
+		}
+		memberships[0].SubjectName = userInfo.Name
+		for _, departments := range userInfo.ParentDeps {
+			for _, department := range departments {
+				memberships = append(memberships, dto.ExplainMembership{
+					SubjectId:   department.Id,
+					SubjectType: enum.SubjectTypeDepartment,
+					SubjectName: department.Name,
+					Via:         dto.ViaDepartment,
+				})
+			}
+		}
+		for _, role := range userInfo.Roles {
+			memberships = append(memberships, dto.ExplainMembership{
+				SubjectId:   role,
+				SubjectType: enum.SubjectTypeRole,
+				SubjectName: role,
+				Via:         dto.ViaRole,
+			})
+		}
+	case enum.SubjectTypeDepartment:
+		departments, err := a.helper.ccDriven.GetDepartmentsByIds(ctx, []string{subjectId})
+		if err != nil {
+			return nil, errorcode.PublicConfigurationCenterError.Detail(err.Error())
+		}
+		for _, department := range departments {
+			memberships[0].SubjectName = department.Name
+			// 上级部门
+			ids, names := strings.Split(department.PathID, "/"), strings.Split(department.Path, "/")
+			for i, id := range ids {
+				if id == "" || id == subjectId {
+					continue
+				}
+				membership := dto.ExplainMembership{
+					SubjectId:   id,
+					SubjectType: enum.SubjectTypeDepartment,
+					Via:         dto.ViaDepartment,
+				}
+				if i < len(names) {
+					membership.SubjectName = names[i]
+				}
+				memberships = append(memberships, membership)
+			}
+		}
+	}
+	return lo.UniqBy(memberships, func(item dto.ExplainMembership) string {
+		return util.JoinField(item.SubjectType, item.SubjectId)
+	}), nil
+}
+
+// explainObjectPolicies 查询资源上的所有策略
+func (a *auth) explainObjectPolicies(ctx context.Context, objectType, objectId string, inherited bool) ([]dto.ExplainPolicy, error) {
+	resourcePolicies, err := a.driven.GetResourcePolicy(ctx, &authorization.GetResourcePolicyReq{
+		ResourceID:   objectId,
+		ResourceType: dto.ObjectToResourceType(objectType),
+		Offset:       0,
+		Limit:        1000,
+	})
+	if err != nil {
+		log.Errorf("Explain GetResourcePolicy Error %v", err.Error())
+		return nil, err
+	}
+	policies := make([]dto.ExplainPolicy, 0, len(resourcePolicies.Entries))
+	for _, resourcePolicy := range resourcePolicies.Entries {
+		policies = append(policies, dto.ExplainPolicy{
+			PolicyID:    resourcePolicy.ID,
+			ObjectId:    objectId,
+			ObjectType:  objectType,
+			Inherited:   inherited,
+			SubjectId:   resourcePolicy.Accessor.ID,
+			SubjectType: resourcePolicy.Accessor.Type,
+			SubjectName: resourcePolicy.Accessor.Name,
+			Actions: lo.Map(resourcePolicy.Operation.Allow, func(item *authorization.OperationObject, index int) string {
+				return item.ID
+			}),
+			ExpiredAt: convertExpireTime(resourcePolicy.ExpiresAt),
+		})
+	}
+	return policies, nil
+}
+
+// explainRules 资源相关的行列规则（子视图），以及访问者对它们请求动作的策略结果
+func (a *auth) explainRules(ctx context.Context, req *dto.PolicyEnforce) ([]dto.ExplainRule, error) {
+	var opts gorm.ListOptions
+	switch req.ObjectType {
+	case dto.ObjectDataView.Str():
+		opts.LogicViewID = req.ObjectId
+	case dto.ObjectSubView.Str():
+		opts.IDs = []string{req.ObjectId}
+	default:
+		return make([]dto.ExplainRule, 0), nil
+	}
+	subViews, err := a.subViewRepo.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	enforceReq := dto.PolicyEnforceReq(lo.Map(subViews, func(item model.AuthSubView, index int) dto.PolicyEnforce {
+		return dto.PolicyEnforce{
+			ObjectId:    item.ID,
+			ObjectType:  dto.ObjectSubView.Str(),
+			SubjectId:   req.SubjectId,
+			SubjectType: req.SubjectType,
+			Action:      req.Action,
+		}
+	}))
+	effects, err := a.Enforce(ctx, &enforceReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Times(len(subViews), func(index int) dto.ExplainRule {
+		return dto.ExplainRule{
+			SubViewId:       subViews[index].ID,
+			Name:            subViews[index].Name,
+			LogicViewId:     subViews[index].LogicViewID,
+			Columns:         subViews[index].Columns,
+			RowFilterClause: subViews[index].RowFilterClause,
+			Effect:          (*effects)[index].Effect,
+		}
+	}), nil
+}
+
+// proposedExplainPolicies 将拟保存的策略转换为参与决策的策略，与保存时一样不区分权限的 effect
+func proposedExplainPolicies(policy *dto.PolicyCreateReq) []dto.ExplainPolicy {
+	return lo.Map(policy.Subjects, func(subject dto.Subject, index int) dto.ExplainPolicy {
+		return dto.ExplainPolicy{
+			ObjectId:    policy.ObjectId,
+			ObjectType:  policy.ObjectType,
+			SubjectId:   subject.SubjectId,
+			SubjectType: subject.SubjectType,
+			SubjectName: subject.SubjectName,
+			Actions: lo.Uniq(lo.Map(subject.Permissions, func(item dto.Permission, index int) string {
+				return item.Action
+			})),
+			ExpiredAt: subject.ExpiredAt,
+			Proposed:  true,
+		}
+	})
+}
+
+// evaluateExplainPolicies 筛选与访问者身份相关的策略，标记匹配的身份、是否过期以及是否允许请求的动作
+func evaluateExplainPolicies(policies []dto.ExplainPolicy, memberships []dto.ExplainMembership, action string, now time.Time) []dto.ExplainPolicy {
+	via := make(map[string]string, len(memberships))
+	for _, membership := range memberships {
+		key := util.JoinField(membership.SubjectType, membership.SubjectId)
+		if _, ok := via[key]; !ok {
+			via[key] = membership.Via
+		}
+	}
+	result := make([]dto.ExplainPolicy, 0)
+	for _, policy := range policies {
+		v, ok := via[util.JoinField(policy.SubjectType, policy.SubjectId)]
+		if !ok {
+			continue
+		}
+		policy.Via = v
+		policy.Expired = policy.ExpiredAt != nil && !policy.ExpiredAt.After(now)
+		policy.Matched = !policy.Expired && lo.Contains(policy.Actions, action)
+		result = append(result, policy)
+	}
+	return result
+}
+
+// explainReason 根据参与决策的策略生成决策原因
+func explainReason(res *dto.PolicyExplainRes) string {
+	if res.IsOwner {
+		return "访问者是资源的 Owner，允许所有操作"
+	}
+	matched := lo.Filter(res.Policies, func(item dto.ExplainPolicy, index int) bool { return item.Matched })
+	if res.Effect == dto.EftAllow {
+		if len(matched) == 0 {
+			return "策略引擎允许，但没有找到与访问者身份直接相关的策略，权限可能来自内置角色"
+		}
+		return "通过" + strings.Join(lo.Uniq(lo.Map(matched, func(item dto.ExplainPolicy, index int) string {
+			return describeExplainPolicy(&item)
+		})), "、") + "获得权限"
+	}
+	switch {
+	case len(matched) > 0:
+		return "存在允许该动作的策略，但策略引擎拒绝，可能被上级资源的限制覆盖"
+	case lo.ContainsBy(res.Policies, func(item dto.ExplainPolicy) bool { return item.Expired && lo.Contains(item.Actions, res.Action) }):
+		return "允许该动作的策略已过期"
+	case len(res.Policies) > 0:
+		return "与访问者身份相关的策略不包含该动作"
+	}
+	return "没有授予访问者及其所属部门、角色的策略"
+}
+
+// describeExplainPolicy 描述策略的来源，例如：所属部门 研发部 在上级资源上的策略
+func describeExplainPolicy(policy *dto.ExplainPolicy) string {
+	name := lo.Ternary(policy.SubjectName != "", policy.SubjectName, policy.SubjectId)
+	var source string
+	switch policy.Via {
+	case dto.ViaDepartment:
+		source = fmt.Sprintf("所属部门 %s ", name)
+	case dto.ViaRole:
+		source = fmt.Sprintf("角色 %s ", name)
+	default:
+		source = "访问者自身"
+	}
+	if policy.Inherited {
+		return source + "在上级资源上的策略"
+	}
+	return source + "的策略"
+}
@@ -0,0 +1,57 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+func TestEvaluateExplainPolicies(t *testing.T) {
+	now := time.Now()
+	memberships := []dto.ExplainMembership{
+		{SubjectId: "u1", SubjectType: "user", Via: dto.ViaSelf},
+		{SubjectId: "d1", SubjectType: "department", Via: dto.ViaDepartment},
+	}
+	expired := meta_v1.NewTime(now.Add(-time.Hour))
+	policies := []dto.ExplainPolicy{
+		{PolicyID: "p1", SubjectId: "u1", SubjectType: "user", Actions: []string{"view"}},
+		{PolicyID: "p2", SubjectId: "d1", SubjectType: "department", Actions: []string{"read"}},
+		{PolicyID: "p3", SubjectId: "u1", SubjectType: "user", Actions: []string{"read"}, ExpiredAt: &expired},
+		{PolicyID: "p4", SubjectId: "u2", SubjectType: "user", Actions: []string{"read"}},
+	}
+
+	got := evaluateExplainPolicies(policies, memberships, "read", now)
+	if len(got) != 3 {
+		t.Fatalf("期望筛选出3条与访问者相关的策略，但得到: %+v", got)
+	}
+	if got[0].Matched || got[0].Via != dto.ViaSelf {
+		t.Errorf("不包含请求动作的策略不应匹配: %+v", got[0])
+	}
+	if !got[1].Matched || got[1].Via != dto.ViaDepartment {
+		t.Errorf("期望通过所属部门匹配: %+v", got[1])
+	}
+	if got[2].Matched || !got[2].Expired {
+		t.Errorf("已过期的策略不应匹配: %+v", got[2])
+	}
+}
+
+func TestExplainReason(t *testing.T) {
+	res := &dto.PolicyExplainRes{PolicyEnforceEffect: dto.PolicyEnforceEffect{Action: "read", Effect: dto.EftAllow}}
+	res.Policies = []dto.ExplainPolicy{{SubjectName: "研发部", Via: dto.ViaDepartment, Inherited: true, Matched: true, Actions: []string{"read"}}}
+	if got := explainReason(res); got != "通过所属部门 研发部 在上级资源上的策略获得权限" {
+		t.Errorf("期望说明通过上级资源获得权限，但得到: %s", got)
+	}
+
+	res.Effect = dto.EftDeny
+	res.Policies = []dto.ExplainPolicy{{Via: dto.ViaSelf, Expired: true, Actions: []string{"read"}}}
+	if got := explainReason(res); got != "允许该动作的策略已过期" {
+		t.Errorf("期望说明策略已过期，但得到: %s", got)
+	}
+
+	res.Policies = nil
+	if got := explainReason(res); got != "没有授予访问者及其所属部门、角色的策略" {
+		t.Errorf("期望说明没有策略，但得到: %s", got)
+	}
+}
@@ -34,11 +34,14 @@ func (a *auth) Create(ctx context.Context, req *dto.PolicyCreateReq) (*authoriza
 		log.Errorf("CreatePolicy Error %v", err.Error())
 		return nil, err
 	}
+	a.decisions.InvalidateObject(req.Object.ObjectType, req.Object.ObjectId)
 	return resp, nil
 }
 
 // Update  更新策略
 func (a *auth) Update(ctx context.Context, req *dto.PolicyUpdateReq) error {
+	// 中途失败时部分策略可能已经变更，所以无论成功与否都使缓存的决策失效
+	defer a.decisions.InvalidateObject(req.Object.ObjectType, req.Object.ObjectId)
 	//删除已有的
 	policyDetail, err := a.Get(ctx, req.PolicyGetReq())
 	if err != nil {
@@ -115,6 +118,22 @@ func (a *auth) Update(ctx context.Context, req *dto.PolicyUpdateReq) error {
 	return nil
 }
 
+// UpdateSubjectExpiredAt 只更新资源上一个访问者的策略的过期时间
+func (a *auth) UpdateSubjectExpiredAt(ctx context.Context, object *dto.PolicyGetReq, subject *dto.Subject) error {
+	args := []*authorization.UpdatePolicyReq{
+		{
+			Operation: subject.Operations(),
+			ExpiresAt: formatExpireTime(subject.ExpiredAt),
+		},
+	}
+	if err := a.driven.UpdatePolicy(ctx, subject.PolicyID, args); err != nil {
+		log.Errorf("UpdatePolicy Error %v", err.Error())
+		return err
+	}
+	a.decisions.InvalidateObject(object.ObjectType, object.ObjectId)
+	return nil
+}
+
 // Delete 删除策略
 func (a *auth) Delete(ctx context.Context, req *dto.PolicyDeleteReq) error {
 	//查询所有的策略配置
@@ -127,6 +146,14 @@ func (a *auth) Delete(ctx context.Context, req *dto.PolicyDeleteReq) error {
 	}
 	ids := make([]string, 0)
 	switch {
+	case req.PolicyID != "": //删除指定的策略，只删除该资源上的策略
+		policyIDs := strings.Split(req.PolicyID, ",")
+		ids = lo.FlatMap(policyDetail.Subjects, func(item dto.Subject, index int) []string {
+			if lo.Contains(policyIDs, item.PolicyID) {
+				return []string{item.PolicyID}
+			}
+			return nil
+		})
 	case req.SubjectId == "": //删除所有
 		ids = lo.Times(len(policyDetail.Subjects), func(index int) string {
 			return policyDetail.Subjects[index].PolicyID
@@ -153,6 +180,7 @@ func (a *auth) Delete(ctx context.Context, req *dto.PolicyDeleteReq) error {
 		log.Errorf("DeletePolicy Error %v", err.Error())
 		return err
 	}
+	a.decisions.InvalidateObject(req.ObjectType, req.ObjectId)
 	return nil
 }
 
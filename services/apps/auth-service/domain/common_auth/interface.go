@@ -21,6 +21,8 @@ type Auth interface {
 	PolicyWrite(ctx context.Context, policy dto.Policy) error
 	PolicyUpdateInternal(ctx context.Context, req *dto.PolicyUpdateReq) error
 	RemovePolicies(ctx context.Context, policies []dto.PolicyEnforce) error
+	// UpdateSubjectExpiredAt 只更新资源上一个访问者的策略的过期时间，不影响其他访问者
+	UpdateSubjectExpiredAt(ctx context.Context, object *dto.PolicyGetReq, subject *dto.Subject) error
 }
 type AuthManagement interface {
 	//Create 创建规则
@@ -42,4 +44,9 @@ type Enforcer interface {
 	MenuResourceEnforce(ctx context.Context, r *dto.MenuResourceEnforceArg) (policyEnforceEffect *dto.MenuResourceEnforceEffect, err error)
 	MenuResourceActions(ctx context.Context, req *dto.MenuResourceActionsArg) (resp *dto.MenuResourceActionsResp, err error)
 	ListSubViews(ctx context.Context, request *dto.ListSubViewsReq) (*dto.ListSubViewsRes, error)
+	DecisionCacheStats(ctx context.Context) *dto.DecisionCacheStats
+	// Explain 解释访问者对资源的动作的策略结果
+	Explain(ctx context.Context, req *dto.PolicyEnforce) (*dto.PolicyExplainRes, error)
+	// WhatIf 模拟保存策略后访问者的策略结果
+	WhatIf(ctx context.Context, req *dto.PolicyWhatIfReq) (*dto.PolicyWhatIfRes, error)
 }
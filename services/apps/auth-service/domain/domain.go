@@ -2,9 +2,12 @@ package domain
 
 import (
 	"github.com/google/wire"
+	attribute_policy_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/attribute_policy/impl"
+	break_glass_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/break_glass/impl"
 	common_auth_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/common_auth/impl"
 	dwh_data_application_form_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/dwh_data_auth_request/impl"
 	indicator_dimensional_rule_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/indicator_dimensional_rule/impl"
+	recertification_impl "github.com/kweaver-ai/dsg/services/apps/auth-service/domain/recertification/impl"
 )
 
 // ProviderSet is biz providers.
@@ -14,4 +17,12 @@ var ProviderSet = wire.NewSet(
 	dwh_data_application_form_impl.NewUseCase,
 	//新的auth
 	common_auth_impl.NewAuth,
+	// 权限复核
+	recertification_impl.NewUseCase,
+	recertification_impl.NewServer,
+	// 属性策略
+	attribute_policy_impl.NewUseCase,
+	// 紧急访问
+	break_glass_impl.NewUseCase,
+	break_glass_impl.NewServer,
 )
@@ -0,0 +1,272 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+	"github.com/kweaver-ai/idrm-go-common/util"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// 自动回收时每批处理的复核项数量
+const autoRevokeBatchSize = 200
+
+// Decide 批量复核。每个复核项先记录复核结果再执行，执行失败时恢复为待复核，可以重新复核
+func (u *useCaseImpl) Decide(ctx context.Context, req *dto.RecertDecideReq) (*dto.RecertDecideRes, error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	campaign, err := u.repo.GetCampaign(ctx, req.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if campaign.Status != dto.RecertCampaignActive || !campaign.Deadline.After(now) {
+		return nil, errorcode.RecertCampaignNotActiveErr.Err()
+	}
+	items, err := u.repo.GetItems(ctx, req.CampaignID, lo.Uniq(req.ItemIDs))
+	if err != nil {
+		return nil, err
+	}
+	itemDict := lo.SliceToMap(items, func(item *model.TAuthRecertItem) (string, *model.TAuthRecertItem) {
+		return item.ID, item
+	})
+
+	res := &dto.RecertDecideRes{Succeeded: make([]string, 0), Failed: make([]dto.RecertDecideFailed, 0)}
+	for _, id := range lo.Uniq(req.ItemIDs) {
+		item, ok := itemDict[id]
+		if !ok {
+			res.Failed = append(res.Failed, dto.RecertDecideFailed{ItemID: id, Reason: errorcode.PublicResourceNotExistErr.Err().Error()})
+			continue
+		}
+		if item.ReviewerID != userInfo.ID && campaign.CreatedBy != userInfo.ID {
+			res.Failed = append(res.Failed, dto.RecertDecideFailed{ItemID: id, Reason: errorcode.RecertNotReviewerErr.Err().Error()})
+			continue
+		}
+		if req.Decision == dto.RecertShorten && !validShortenExpiredAt(item, req.ExpiredAt, now) {
+			res.Failed = append(res.Failed, dto.RecertDecideFailed{ItemID: id, Reason: errorcode.RecertExpiredAtInvalidErr.Err().Error()})
+			continue
+		}
+		item.Decision = req.Decision
+		item.Comment = req.Comment
+		item.DecidedBy = userInfo.ID
+		item.DecidedByName = userInfo.Name
+		item.DecidedAt = &now
+		if req.Decision == dto.RecertShorten {
+			item.NewExpiredAt = &req.ExpiredAt.Time
+		}
+		if err := u.decide(ctx, item); err != nil {
+			res.Failed = append(res.Failed, dto.RecertDecideFailed{ItemID: id, Reason: err.Error()})
+			continue
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+	return res, nil
+}
+
+// decide 记录并执行复核项的复核结果
+func (u *useCaseImpl) decide(ctx context.Context, item *model.TAuthRecertItem) error {
+	claimed, err := u.repo.ClaimItem(ctx, item)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errorcode.RecertItemNotPendingErr.Err()
+	}
+	if err = u.execute(ctx, item); err != nil {
+		log.WithContext(ctx).Error("execute recertification decision fail", zap.String("item", item.ID), zap.String("decision", item.Decision), zap.Error(err))
+		if releaseErr := u.repo.ReleaseItem(ctx, item.ID, err.Error()); releaseErr != nil {
+			log.WithContext(ctx).Error("release recertification item fail", zap.String("item", item.ID), zap.Error(releaseErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// execute 执行复核结果：回收授权或者缩短授权的有效期，保留不需要执行。
+// 回收时只删除复核项对应的策略，同一访问者在该资源上的其它授权不受影响
+func (u *useCaseImpl) execute(ctx context.Context, item *model.TAuthRecertItem) error {
+	switch item.Decision {
+	case dto.RecertRevoke, dto.RecertAutoRevoked:
+		return u.auth.Delete(ctx, &dto.PolicyDeleteReq{
+			PolicyID:    item.PolicyID,
+			ObjectId:    item.ObjectID,
+			ObjectType:  item.ObjectType,
+			SubjectId:   item.SubjectID,
+			SubjectType: item.SubjectType,
+		})
+	case dto.RecertShorten:
+		return u.shorten(ctx, item)
+	}
+	return nil
+}
+
+// shorten 缩短授权的有效期。复核期间授权可能被修改过，按当前的策略更新，
+// 只修改过期时间，保留当前的权限
+func (u *useCaseImpl) shorten(ctx context.Context, item *model.TAuthRecertItem) error {
+	object := &dto.PolicyGetReq{ObjectId: item.ObjectID, ObjectType: item.ObjectType}
+	policy, err := u.auth.Get(ctx, object)
+	if err != nil {
+		return err
+	}
+	subject, ok := lo.Find(policy.Subjects, func(subject dto.Subject) bool {
+		return subject.PolicyID == item.PolicyID
+	})
+	if !ok {
+		return errorcode.RecertPolicyNotExistErr.Err()
+	}
+	// 当前的过期时间已经不晚于缩短后的过期时间时不能再延长
+	if subject.ExpiredAt != nil && !item.NewExpiredAt.Before(subject.ExpiredAt.Time) {
+		return errorcode.RecertExpiredAtInvalidErr.Err()
+	}
+	expiredAt := meta_v1.NewTime(*item.NewExpiredAt)
+	subject.ExpiredAt = &expiredAt
+	return u.auth.UpdateSubjectExpiredAt(ctx, object, &subject)
+}
+
+// CloseDueCampaigns 回收已截止的复核活动中未复核的授权。复核活动先变为回收中，
+// 所有复核项都回收后变为已完成；回收失败的复核项保持待复核，下次继续回收
+func (u *useCaseImpl) CloseDueCampaigns(ctx context.Context) error {
+	campaigns, err := u.repo.ListDueCampaigns(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, campaign := range campaigns {
+		if campaign.Status == dto.RecertCampaignActive {
+			if _, err := u.repo.TransitCampaign(ctx, campaign.ID, dto.RecertCampaignActive, dto.RecertCampaignClosing); err != nil {
+				return err
+			}
+		}
+		if err := u.autoRevoke(ctx, campaign); err != nil {
+			log.WithContext(ctx).Error("auto revoke recertification campaign fail", zap.String("campaign", campaign.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// autoRevoke 回收复核活动中所有待复核的授权，全部回收后复核活动变为已完成。
+// 按ID顺序逐批处理，回收失败的复核项恢复为待复核后不会阻塞后面的复核项
+func (u *useCaseImpl) autoRevoke(ctx context.Context, campaign *model.TAuthRecertCampaign) error {
+	var (
+		lastID string
+		failed int
+	)
+	for {
+		items, err := u.repo.ListPendingItems(ctx, campaign.ID, lastID, autoRevokeBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			now := time.Now()
+			item.Decision = dto.RecertAutoRevoked
+			item.DecidedAt = &now
+			// 其他实例同时回收时复核项可能已经不是待复核，同样等待下次确认
+			if err := u.decide(ctx, item); err != nil {
+				failed++
+			}
+		}
+		if len(items) < autoRevokeBatchSize {
+			break
+		}
+		lastID = items[len(items)-1].ID
+	}
+	// 还有回收失败的复核项时等待下次回收
+	if failed > 0 {
+		return nil
+	}
+	_, err := u.repo.TransitCampaign(ctx, campaign.ID, dto.RecertCampaignClosing, dto.RecertCampaignCompleted)
+	return err
+}
+
+// validShortenExpiredAt 缩短后的过期时间必须晚于当前时间，且早于原过期时间
+func validShortenExpiredAt(item *model.TAuthRecertItem, expiredAt *meta_v1.Time, now time.Time) bool {
+	if expiredAt == nil || !expiredAt.After(now) {
+		return false
+	}
+	return item.ExpiredAt == nil || expiredAt.Before(*item.ExpiredAt)
+}
+
+// summarizeItems 统计复核项，返回总的统计和按复核人的统计
+func summarizeItems(counts []*model.TAuthRecertItemCount) (dto.RecertSummary, []dto.RecertReviewerSummary) {
+	var (
+		summary   dto.RecertSummary
+		reviewers = make([]dto.RecertReviewerSummary, 0)
+		indexes   = make(map[string]int)
+	)
+	for _, count := range counts {
+		summary.Add(count.Decision, count.Count)
+		index, ok := indexes[count.ReviewerID]
+		if !ok {
+			reviewers = append(reviewers, dto.RecertReviewerSummary{ReviewerID: count.ReviewerID, ReviewerName: count.ReviewerName})
+			index = len(reviewers) - 1
+			indexes[count.ReviewerID] = index
+		}
+		reviewers[index].Add(count.Decision, count.Count)
+	}
+	return summary, reviewers
+}
+
+func newRecertCampaign(campaign *model.TAuthRecertCampaign, summary dto.RecertSummary) *dto.RecertCampaign {
+	result := &dto.RecertCampaign{
+		ID:                   campaign.ID,
+		Name:                 campaign.Name,
+		Description:          campaign.Description,
+		ObjectType:           campaign.ObjectType,
+		DepartmentID:         campaign.DepartmentID,
+		IncludeSubDepartment: campaign.IncludeSubDepartment,
+		LabelID:              campaign.LabelID,
+		ObjectIDs:            make([]string, 0),
+		Deadline:             meta_v1.NewTime(campaign.Deadline),
+		Status:               campaign.Status,
+		CreatedBy:            campaign.CreatedBy,
+		CreatedByName:        campaign.CreatedByName,
+		CreatedAt:            meta_v1.NewTime(campaign.CreatedAt),
+		CompletedAt:          newMetaTime(campaign.CompletedAt),
+		Summary:              summary,
+	}
+	if campaign.ObjectIDs != "" {
+		result.ObjectIDs = strings.Split(campaign.ObjectIDs, ",")
+	}
+	return result
+}
+
+func newRecertItem(item *model.TAuthRecertItem) *dto.RecertItem {
+	return &dto.RecertItem{
+		ID:            item.ID,
+		CampaignID:    item.CampaignID,
+		PolicyID:      item.PolicyID,
+		ObjectId:      item.ObjectID,
+		ObjectType:    item.ObjectType,
+		ObjectName:    item.ObjectName,
+		SubjectId:     item.SubjectID,
+		SubjectType:   item.SubjectType,
+		SubjectName:   item.SubjectName,
+		Actions:       strings.Split(item.Actions, ","),
+		ExpiredAt:     newMetaTime(item.ExpiredAt),
+		ReviewerID:    item.ReviewerID,
+		ReviewerName:  item.ReviewerName,
+		Decision:      item.Decision,
+		NewExpiredAt:  newMetaTime(item.NewExpiredAt),
+		Comment:       item.Comment,
+		DecidedBy:     item.DecidedBy,
+		DecidedByName: item.DecidedByName,
+		DecidedAt:     newMetaTime(item.DecidedAt),
+		Message:       item.Message,
+	}
+}
+
+func newMetaTime(t *time.Time) *meta_v1.Time {
+	if t == nil {
+		return nil
+	}
+	result := meta_v1.NewTime(*t)
+	return &result
+}
@@ -0,0 +1,188 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/common_auth"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+// fakeRecertRepo 内存中的复核项，只实现自动回收用到的方法
+type fakeRecertRepo struct {
+	gorm.AuthRecertRepo
+	items     []*model.TAuthRecertItem
+	completed bool
+}
+
+func (r *fakeRecertRepo) ListPendingItems(ctx context.Context, campaignID, afterID string, limit int) ([]*model.TAuthRecertItem, error) {
+	result := make([]*model.TAuthRecertItem, 0)
+	for _, item := range r.items {
+		if item.CampaignID == campaignID && item.Decision == dto.RecertPending && item.ID > afterID && len(result) < limit {
+			copied := *item
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRecertRepo) ClaimItem(ctx context.Context, item *model.TAuthRecertItem) (bool, error) {
+	for _, stored := range r.items {
+		if stored.ID == item.ID && stored.Decision == dto.RecertPending {
+			stored.Decision = item.Decision
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRecertRepo) ReleaseItem(ctx context.Context, id, message string) error {
+	for _, stored := range r.items {
+		if stored.ID == id {
+			stored.Decision = dto.RecertPending
+			stored.Message = message
+		}
+	}
+	return nil
+}
+
+func (r *fakeRecertRepo) TransitCampaign(ctx context.Context, id, from, to string) (bool, error) {
+	r.completed = to == dto.RecertCampaignCompleted
+	return true, nil
+}
+
+// fakeAuth 回收 failing 中的策略时返回错误，获取策略时返回 policy，记录更新过期时间的访问者
+type fakeAuth struct {
+	common_auth.Auth
+	failing map[string]bool
+	deleted map[string]int
+	policy  *dto.Policy
+	updated *dto.Subject
+}
+
+func (a *fakeAuth) Get(ctx context.Context, req *dto.PolicyGetReq) (*dto.Policy, error) {
+	return a.policy, nil
+}
+
+func (a *fakeAuth) UpdateSubjectExpiredAt(ctx context.Context, object *dto.PolicyGetReq, subject *dto.Subject) error {
+	a.updated = subject
+	return nil
+}
+
+func (a *fakeAuth) Delete(ctx context.Context, req *dto.PolicyDeleteReq) error {
+	a.deleted[req.PolicyID]++
+	if a.failing[req.PolicyID] {
+		return errors.New("delete policy fail")
+	}
+	return nil
+}
+
+func TestAutoRevokeSkipsFailedItems(t *testing.T) {
+	repo := &fakeRecertRepo{}
+	auth := &fakeAuth{failing: make(map[string]bool), deleted: make(map[string]int)}
+	// 前 250 个复核项一直回收失败，超过一批的数量
+	for i := 0; i < 300; i++ {
+		item := &model.TAuthRecertItem{
+			ID:         fmt.Sprintf("item-%04d", i),
+			CampaignID: "c1",
+			PolicyID:   fmt.Sprintf("p-%04d", i),
+			Decision:   dto.RecertPending,
+		}
+		repo.items = append(repo.items, item)
+		if i < 250 {
+			auth.failing[item.PolicyID] = true
+		}
+	}
+	u := &useCaseImpl{repo: repo, auth: auth}
+	campaign := &model.TAuthRecertCampaign{ID: "c1", Status: dto.RecertCampaignClosing}
+
+	if err := u.autoRevoke(context.Background(), campaign); err != nil {
+		t.Fatalf("自动回收返回错误: %v", err)
+	}
+	for _, item := range repo.items {
+		if auth.deleted[item.PolicyID] != 1 {
+			t.Fatalf("期望每个复核项回收一次，%v 回收了 %d 次", item.ID, auth.deleted[item.PolicyID])
+		}
+		want := dto.RecertAutoRevoked
+		if auth.failing[item.PolicyID] {
+			want = dto.RecertPending
+		}
+		if item.Decision != want {
+			t.Fatalf("期望 %v 的复核结果为 %v，但得到: %v", item.ID, want, item.Decision)
+		}
+	}
+	if repo.completed {
+		t.Fatal("还有回收失败的复核项时复核活动不应该变为已完成")
+	}
+
+	// 策略删除恢复后，下次回收完成复核活动
+	auth.failing = make(map[string]bool)
+	if err := u.autoRevoke(context.Background(), campaign); err != nil {
+		t.Fatalf("自动回收返回错误: %v", err)
+	}
+	if !repo.completed {
+		t.Fatal("所有复核项回收后复核活动应该变为已完成")
+	}
+}
+
+func TestShortenUsesCurrentPolicy(t *testing.T) {
+	now := time.Now()
+	current := meta_v1.NewTime(now.Add(30 * 24 * time.Hour))
+	newExpiredAt := now.Add(7 * 24 * time.Hour)
+	// 复核项记录的是创建复核活动时的权限，复核期间授权被改为只能下载
+	item := &model.TAuthRecertItem{
+		PolicyID:     "p1",
+		ObjectID:     "o1",
+		ObjectType:   "data_view",
+		SubjectID:    "s1",
+		SubjectType:  "user",
+		Actions:      "view,read",
+		Decision:     dto.RecertShorten,
+		NewExpiredAt: &newExpiredAt,
+	}
+	permissions := []dto.Permission{{Action: "download", Effect: dto.EftAllow}}
+	auth := &fakeAuth{policy: &dto.Policy{Subjects: []dto.Subject{
+		{PolicyID: "p0", SubjectId: "s0", SubjectType: "user", Permissions: []dto.Permission{{Action: "view", Effect: dto.EftAllow}}},
+		{PolicyID: "p1", SubjectId: "s1", SubjectType: "user", Permissions: permissions, ExpiredAt: &current},
+	}}}
+	u := &useCaseImpl{auth: auth}
+
+	if err := u.execute(context.Background(), item); err != nil {
+		t.Fatalf("缩短有效期返回错误: %v", err)
+	}
+	if auth.updated == nil || auth.updated.PolicyID != "p1" {
+		t.Fatalf("期望更新策略 p1，但得到: %+v", auth.updated)
+	}
+	if len(auth.updated.Permissions) != 1 || auth.updated.Permissions[0] != permissions[0] {
+		t.Fatalf("期望保留当前的权限 %v，但得到: %v", permissions, auth.updated.Permissions)
+	}
+	if auth.updated.ExpiredAt == nil || !auth.updated.ExpiredAt.Equal(newExpiredAt) {
+		t.Fatalf("期望过期时间为 %v，但得到: %v", newExpiredAt, auth.updated.ExpiredAt)
+	}
+
+	// 复核期间授权的过期时间已经被改得更早，不能再延长
+	earlier := meta_v1.NewTime(now.Add(24 * time.Hour))
+	auth.policy.Subjects[1].ExpiredAt = &earlier
+	auth.updated = nil
+	if err := u.execute(context.Background(), item); err == nil {
+		t.Fatal("当前过期时间早于缩短后的过期时间时应该返回错误")
+	}
+	if auth.updated != nil {
+		t.Fatal("当前过期时间早于缩短后的过期时间时不应该更新策略")
+	}
+
+	// 复核期间授权已被删除
+	auth.policy.Subjects = auth.policy.Subjects[:1]
+	if err := u.execute(context.Background(), item); err == nil {
+		t.Fatal("授权已不存在时应该返回错误")
+	}
+	if auth.updated != nil {
+		t.Fatal("授权已不存在时不应该更新策略")
+	}
+}
@@ -0,0 +1,227 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	"github.com/samber/lo"
+)
+
+// 批量查询逻辑视图字段时每批的数量
+const fieldLabelBatchSize = 100
+
+// resolveScope 返回复核范围内的资源 ID。部门、分级标签只支持逻辑视图和子视图，
+// 其他类型的资源需要指定资源 ID
+func (u *useCaseImpl) resolveScope(ctx context.Context, req *dto.RecertCampaignCreateReq) ([]string, error) {
+	objectIDs := lo.Uniq(req.ObjectIDs)
+	if req.ObjectType != dto.ObjectDataView.Str() && req.ObjectType != dto.ObjectSubView.Str() {
+		if req.DepartmentID != "" || req.LabelID != "" || len(objectIDs) == 0 {
+			return nil, errorcode.RecertScopeInvalidErr.Err()
+		}
+		return objectIDs, nil
+	}
+	// 属于部门的逻辑视图，未指定资源 ID 和部门时为所有逻辑视图
+	var departmentViewIDs []string
+	if req.DepartmentID != "" || len(objectIDs) == 0 {
+		var err error
+		if departmentViewIDs, err = u.dataViewLocal.ListDataViewIDsByDepartment(ctx, req.DepartmentID, req.IncludeSubDepartment); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		viewIDs  []string
+		subViews []model.AuthSubView
+	)
+	switch {
+	case req.ObjectType == dto.ObjectSubView.Str():
+		var err error
+		if subViews, err = u.listSubViews(ctx, objectIDs, departmentViewIDs, req.DepartmentID != ""); err != nil {
+			return nil, err
+		}
+		viewIDs = lo.Uniq(lo.Map(subViews, func(item model.AuthSubView, index int) string {
+			return item.LogicViewID
+		}))
+	case len(objectIDs) == 0:
+		viewIDs = departmentViewIDs
+	case req.DepartmentID == "":
+		viewIDs = objectIDs
+	default:
+		viewIDs = lo.Intersect(objectIDs, departmentViewIDs)
+	}
+	if req.LabelID != "" {
+		labels, err := u.getFieldLabels(ctx, viewIDs)
+		if err != nil {
+			return nil, err
+		}
+		viewIDs = filterViewsByLabel(viewIDs, labels, req.LabelID)
+		subViews = filterSubViewsByLabel(subViews, labels, req.LabelID)
+	}
+	if req.ObjectType == dto.ObjectSubView.Str() {
+		return lo.Map(subViews, func(item model.AuthSubView, index int) string {
+			return item.ID
+		}), nil
+	}
+	return viewIDs, nil
+}
+
+// listSubViews 返回指定的子视图，未指定时返回属于逻辑视图的子视图。byDepartment 为 true 时只保留属于逻辑视图的子视图
+func (u *useCaseImpl) listSubViews(ctx context.Context, ids, viewIDs []string, byDepartment bool) ([]model.AuthSubView, error) {
+	if len(ids) > 0 {
+		subViews, err := u.subViewRepo.List(ctx, gorm.ListOptions{IDs: ids})
+		if err != nil || !byDepartment {
+			return subViews, err
+		}
+		return lo.Filter(subViews, func(item model.AuthSubView, index int) bool {
+			return lo.Contains(viewIDs, item.LogicViewID)
+		}), nil
+	}
+	var subViews []model.AuthSubView
+	for _, viewID := range viewIDs {
+		list, err := u.subViewRepo.List(ctx, gorm.ListOptions{LogicViewID: viewID})
+		if err != nil {
+			return nil, err
+		}
+		subViews = append(subViews, list...)
+	}
+	return subViews, nil
+}
+
+// getFieldLabels 分批查询逻辑视图字段的分级标签
+func (u *useCaseImpl) getFieldLabels(ctx context.Context, viewIDs []string) (map[string]map[string]string, error) {
+	labels := make(map[string]map[string]string, len(viewIDs))
+	for _, chunk := range lo.Chunk(viewIDs, fieldLabelBatchSize) {
+		chunkLabels, err := u.dataViewLocal.GetFieldLabels(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for viewID, fieldLabels := range chunkLabels {
+			labels[viewID] = fieldLabels
+		}
+	}
+	return labels, nil
+}
+
+// newItems 为每个资源上未过期的授权生成复核项。创建人只能复核有授权权限或者是 Owner 的资源，其他资源被忽略
+func (u *useCaseImpl) newItems(ctx context.Context, campaign *model.TAuthRecertCampaign, objectIDs []string, now time.Time) ([]*model.TAuthRecertItem, error) {
+	policies := make([]*dto.Policy, 0, len(objectIDs))
+	for _, objectID := range objectIDs {
+		policy, err := u.auth.Get(ctx, &dto.PolicyGetReq{ObjectId: objectID, ObjectType: campaign.ObjectType})
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	policies, err := u.filterAuthorizable(ctx, campaign.CreatedBy, policies)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*model.TAuthRecertItem, 0)
+	for _, policy := range policies {
+		items = append(items, newRecertItems(campaign, policy, now)...)
+	}
+	return items, nil
+}
+
+// filterAuthorizable 返回用户是 Owner 或者拥有授权权限的资源的策略
+func (u *useCaseImpl) filterAuthorizable(ctx context.Context, userID string, policies []*dto.Policy) ([]*dto.Policy, error) {
+	var (
+		result     = make([]*dto.Policy, 0, len(policies))
+		notOwned   = make([]*dto.Policy, 0, len(policies))
+		enforceReq = make(dto.PolicyEnforceReq, 0, len(policies))
+	)
+	for _, policy := range policies {
+		if lo.Contains(policy.Object.OwnerIDSlice(), userID) {
+			result = append(result, policy)
+			continue
+		}
+		notOwned = append(notOwned, policy)
+		enforceReq = append(enforceReq, dto.PolicyEnforce{
+			ObjectId:    policy.Object.ObjectId,
+			ObjectType:  policy.Object.ObjectType,
+			SubjectId:   userID,
+			SubjectType: dto.SubjectUser.Str(),
+			Action:      dto.ActionAuth.Str(),
+		})
+	}
+	if len(enforceReq) == 0 {
+		return result, nil
+	}
+	effects, err := u.auth.Enforce(ctx, &enforceReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, effect := range *effects {
+		if effect.Effect == dto.EftAllow {
+			result = append(result, notOwned[i])
+		}
+	}
+	return result, nil
+}
+
+// filterViewsByLabel 返回包含指定分级标签字段的逻辑视图
+func filterViewsByLabel(viewIDs []string, labels map[string]map[string]string, labelID string) []string {
+	return lo.Filter(viewIDs, func(viewID string, index int) bool {
+		for _, fieldLabel := range labels[viewID] {
+			if fieldLabel == labelID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filterSubViewsByLabel 返回列中包含指定分级标签字段的子视图
+func filterSubViewsByLabel(subViews []model.AuthSubView, labels map[string]map[string]string, labelID string) []model.AuthSubView {
+	return lo.Filter(subViews, func(subView model.AuthSubView, index int) bool {
+		for _, column := range strings.Split(subView.Columns, ",") {
+			if labels[subView.LogicViewID][strings.TrimSpace(column)] == labelID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// newRecertItems 为资源上未过期的授权生成复核项，复核人是资源的第一个 Owner，没有 Owner 时为复核活动的创建人
+func newRecertItems(campaign *model.TAuthRecertCampaign, policy *dto.Policy, now time.Time) []*model.TAuthRecertItem {
+	reviewerID, reviewerName := campaign.CreatedBy, campaign.CreatedByName
+	if len(policy.Object.Owners) > 0 {
+		reviewerID, reviewerName = policy.Object.Owners[0].OwnerID, policy.Object.Owners[0].OwnerName
+	}
+	items := make([]*model.TAuthRecertItem, 0, len(policy.Subjects))
+	for _, subject := range policy.Subjects {
+		if subject.ExpiredAt != nil && !subject.ExpiredAt.After(now) {
+			continue
+		}
+		item := &model.TAuthRecertItem{
+			ID:          uuid.Must(uuid.NewV7()).String(),
+			CampaignID:  campaign.ID,
+			PolicyID:    subject.PolicyID,
+			ObjectID:    policy.Object.ObjectId,
+			ObjectType:  campaign.ObjectType,
+			ObjectName:  policy.Object.ObjectName,
+			SubjectID:   subject.SubjectId,
+			SubjectType: subject.SubjectType,
+			SubjectName: subject.SubjectName,
+			Actions: strings.Join(lo.Uniq(lo.Map(subject.Permissions, func(item dto.Permission, index int) string {
+				return item.Action
+			})), ","),
+			ReviewerID:   reviewerID,
+			ReviewerName: reviewerName,
+			Decision:     dto.RecertPending,
+			CreatedAt:    now,
+		}
+		if subject.ExpiredAt != nil {
+			expiredAt := subject.ExpiredAt.Time
+			item.ExpiredAt = &expiredAt
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+)
+
+func TestFilterByLabel(t *testing.T) {
+	labels := map[string]map[string]string{
+		"v1": {"id_card": "l1", "name": "l2"},
+		"v2": {"name": "l2"},
+	}
+
+	views := filterViewsByLabel([]string{"v1", "v2", "v3"}, labels, "l1")
+	if len(views) != 1 || views[0] != "v1" {
+		t.Errorf("期望只保留包含分级标签 l1 的视图 v1，但得到: %v", views)
+	}
+
+	subViews := filterSubViewsByLabel([]model.AuthSubView{
+		{ID: "s1", LogicViewID: "v1", Columns: "name, id_card"},
+		{ID: "s2", LogicViewID: "v1", Columns: "name"},
+		{ID: "s3", LogicViewID: "v2", Columns: "name"},
+	}, labels, "l1")
+	if len(subViews) != 1 || subViews[0].ID != "s1" {
+		t.Errorf("期望只保留列中包含分级标签 l1 的子视图 s1，但得到: %+v", subViews)
+	}
+}
+
+func TestNewRecertItems(t *testing.T) {
+	now := time.Now()
+	expired := meta_v1.NewTime(now.Add(-time.Hour))
+	future := meta_v1.NewTime(now.Add(time.Hour))
+	campaign := &model.TAuthRecertCampaign{ID: "c1", ObjectType: "data_view", CreatedBy: "u0", CreatedByName: "创建人"}
+	policy := &dto.Policy{
+		Object: dto.Object{ObjectId: "v1", ObjectName: "视图"},
+		Subjects: []dto.Subject{
+			{PolicyID: "p1", SubjectId: "u1", SubjectType: "user", Permissions: []dto.Permission{
+				{Action: "view", Effect: dto.EftAllow}, {Action: "read", Effect: dto.EftAllow}, {Action: "read", Effect: dto.EftDeny},
+			}},
+			{PolicyID: "p2", SubjectId: "u2", SubjectType: "user", ExpiredAt: &expired},
+			{PolicyID: "p3", SubjectId: "u3", SubjectType: "user", ExpiredAt: &future},
+		},
+	}
+
+	items := newRecertItems(campaign, policy, now)
+	if len(items) != 2 {
+		t.Fatalf("期望跳过已过期的授权生成2个复核项，但得到: %d", len(items))
+	}
+	if items[0].Actions != "view,read" || items[0].ExpiredAt != nil {
+		t.Errorf("复核项的动作或过期时间不符合预期: %+v", items[0])
+	}
+	if items[0].ReviewerID != "u0" || items[0].Decision != dto.RecertPending {
+		t.Errorf("资源没有 Owner 时复核人应为创建人: %+v", items[0])
+	}
+	if items[1].ExpiredAt == nil || !items[1].ExpiredAt.Equal(future.Time) {
+		t.Errorf("复核项应记录授权的过期时间: %+v", items[1])
+	}
+
+	policy.Owners = []dto.ObjectOwner{{OwnerID: "o1", OwnerName: "Owner"}}
+	items = newRecertItems(campaign, policy, now)
+	if items[0].ReviewerID != "o1" || items[0].ReviewerName != "Owner" {
+		t.Errorf("资源有 Owner 时复核人应为第一个 Owner: %+v", items[0])
+	}
+}
+
+func TestValidShortenExpiredAt(t *testing.T) {
+	now := time.Now()
+	original := now.Add(48 * time.Hour)
+	past := meta_v1.NewTime(now.Add(-time.Hour))
+	earlier := meta_v1.NewTime(now.Add(24 * time.Hour))
+	later := meta_v1.NewTime(now.Add(72 * time.Hour))
+
+	tests := []struct {
+		name      string
+		item      *model.TAuthRecertItem
+		expiredAt *meta_v1.Time
+		want      bool
+	}{
+		{"未指定过期时间", &model.TAuthRecertItem{}, nil, false},
+		{"早于当前时间", &model.TAuthRecertItem{}, &past, false},
+		{"原授权永久有效", &model.TAuthRecertItem{}, &later, true},
+		{"早于原过期时间", &model.TAuthRecertItem{ExpiredAt: &original}, &earlier, true},
+		{"晚于原过期时间", &model.TAuthRecertItem{ExpiredAt: &original}, &later, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validShortenExpiredAt(tt.item, tt.expiredAt, now); got != tt.want {
+				t.Errorf("validShortenExpiredAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeItems(t *testing.T) {
+	summary, reviewers := summarizeItems([]*model.TAuthRecertItemCount{
+		{ReviewerID: "o1", ReviewerName: "A", Decision: dto.RecertPending, Count: 2},
+		{ReviewerID: "o2", ReviewerName: "B", Decision: dto.RecertKeep, Count: 1},
+		{ReviewerID: "o1", ReviewerName: "A", Decision: dto.RecertRevoke, Count: 3},
+	})
+	if summary.Total != 6 || summary.Pending != 2 || summary.Keep != 1 || summary.Revoke != 3 {
+		t.Errorf("总的统计不符合预期: %+v", summary)
+	}
+	if len(reviewers) != 2 || reviewers[0].ReviewerID != "o1" || reviewers[0].Total != 5 || reviewers[1].Keep != 1 {
+		t.Errorf("按复核人的统计不符合预期: %+v", reviewers)
+	}
+}
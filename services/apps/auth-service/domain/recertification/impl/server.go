@@ -0,0 +1,57 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/recertification"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"go.uber.org/zap"
+)
+
+// 检查复核活动是否截止的间隔
+const closeDueCampaignsInterval = time.Minute
+
+// Server 定期回收已截止的复核活动中未复核的授权
+type Server struct {
+	uc     recertification.UseCase
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+}
+
+func NewServer(uc recertification.UseCase) *Server {
+	return &Server{uc: uc}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mtx.Lock()
+	s.cancel = cancel
+	s.mtx.Unlock()
+
+	ticker := time.NewTicker(closeDueCampaignsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.uc.CloseDueCampaigns(ctx); err != nil {
+				log.WithContext(ctx).Error("close due recertification campaigns fail", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Server) Stop(_ context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
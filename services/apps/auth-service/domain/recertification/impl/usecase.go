@@ -0,0 +1,211 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/common_auth"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/domain/recertification"
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/infrastructure/repository/db/model"
+	meta_v1 "github.com/kweaver-ai/idrm-go-common/api/meta/v1"
+	"github.com/kweaver-ai/idrm-go-common/util"
+	"github.com/samber/lo"
+)
+
+type useCaseImpl struct {
+	repo          gorm.AuthRecertRepo
+	subViewRepo   gorm.AuthSubViewRepo
+	dataViewLocal microservice.DataViewRepo
+	auth          common_auth.Auth
+}
+
+func NewUseCase(
+	repo gorm.AuthRecertRepo,
+	subViewRepo gorm.AuthSubViewRepo,
+	dataViewLocal microservice.DataViewRepo,
+	auth common_auth.Auth,
+) recertification.UseCase {
+	return &useCaseImpl{
+		repo:          repo,
+		subViewRepo:   subViewRepo,
+		dataViewLocal: dataViewLocal,
+		auth:          auth,
+	}
+}
+
+// Create 创建复核活动
+func (u *useCaseImpl) Create(ctx context.Context, req *dto.RecertCampaignCreateReq) (string, error) {
+	creator, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	if !req.Deadline.After(now) {
+		return "", errorcode.RecertDeadlineInvalidErr.Err()
+	}
+	objectIDs, err := u.resolveScope(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	campaign := &model.TAuthRecertCampaign{
+		ID:                   uuid.Must(uuid.NewV7()).String(),
+		Name:                 req.Name,
+		Description:          req.Description,
+		ObjectType:           req.ObjectType,
+		DepartmentID:         req.DepartmentID,
+		IncludeSubDepartment: req.IncludeSubDepartment,
+		LabelID:              req.LabelID,
+		ObjectIDs:            strings.Join(req.ObjectIDs, ","),
+		Deadline:             req.Deadline.Time,
+		Status:               dto.RecertCampaignActive,
+		CreatedBy:            creator.ID,
+		CreatedByName:        creator.Name,
+		CreatedAt:            now,
+	}
+	items, err := u.newItems(ctx, campaign, objectIDs, now)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", errorcode.RecertScopeEmptyErr.Err()
+	}
+	if err = u.repo.CreateCampaign(ctx, campaign, items); err != nil {
+		return "", err
+	}
+	return campaign.ID, nil
+}
+
+// List 获取复核活动列表，只返回当前用户创建或者需要当前用户复核的复核活动
+func (u *useCaseImpl) List(ctx context.Context, args *dto.RecertCampaignListArgs) (*dto.PageResult[dto.RecertCampaign], error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args.ViewerID = userInfo.ID
+	total, campaigns, err := u.repo.ListCampaigns(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := u.repo.CountItems(ctx, lo.Map(campaigns, func(item *model.TAuthRecertCampaign, index int) string {
+		return item.ID
+	})...)
+	if err != nil {
+		return nil, err
+	}
+	entries := lo.Map(campaigns, func(item *model.TAuthRecertCampaign, index int) *dto.RecertCampaign {
+		summary, _ := summarizeItems(counts[item.ID])
+		return newRecertCampaign(item, summary)
+	})
+	return &dto.PageResult[dto.RecertCampaign]{TotalCount: total, Entries: entries}, nil
+}
+
+// Get 获取复核活动详情
+func (u *useCaseImpl) Get(ctx context.Context, id string) (*dto.RecertCampaign, error) {
+	campaign, err := u.getViewableCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := u.repo.CountItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	summary, _ := summarizeItems(counts[id])
+	return newRecertCampaign(campaign, summary), nil
+}
+
+// Cancel 取消复核活动，只有创建人可以取消
+func (u *useCaseImpl) Cancel(ctx context.Context, id string) error {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return err
+	}
+	campaign, err := u.repo.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+	if campaign.CreatedBy != userInfo.ID {
+		return errorcode.NoAuthError.Err()
+	}
+	canceled, err := u.repo.TransitCampaign(ctx, id, dto.RecertCampaignActive, dto.RecertCampaignCanceled)
+	if err != nil {
+		return err
+	}
+	if !canceled {
+		return errorcode.RecertCampaignNotActiveErr.Err()
+	}
+	return nil
+}
+
+// Report 获取复核活动的报告，包括每个复核项的复核记录
+func (u *useCaseImpl) Report(ctx context.Context, id string) (*dto.RecertCampaignReport, error) {
+	campaign, err := u.getViewableCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := u.repo.CountItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	_, items, err := u.repo.ListItems(ctx, &dto.RecertItemListArgs{CampaignID: id})
+	if err != nil {
+		return nil, err
+	}
+	summary, reviewers := summarizeItems(counts[id])
+	return &dto.RecertCampaignReport{
+		Campaign:    *newRecertCampaign(campaign, summary),
+		Reviewers:   reviewers,
+		Items:       lo.Map(items, func(item *model.TAuthRecertItem, index int) *dto.RecertItem { return newRecertItem(item) }),
+		GeneratedAt: meta_v1.NewTime(time.Now()),
+	}, nil
+}
+
+// ListItems 获取复核项列表
+func (u *useCaseImpl) ListItems(ctx context.Context, args *dto.RecertItemListArgs) (*dto.PageResult[dto.RecertItem], error) {
+	if _, err := u.getViewableCampaign(ctx, args.CampaignID); err != nil {
+		return nil, err
+	}
+	if args.Mine {
+		userInfo, err := util.GetUserInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args.ReviewerID = userInfo.ID
+	}
+	total, items, err := u.repo.ListItems(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PageResult[dto.RecertItem]{
+		TotalCount: total,
+		Entries:    lo.Map(items, func(item *model.TAuthRecertItem, index int) *dto.RecertItem { return newRecertItem(item) }),
+	}, nil
+}
+
+// getViewableCampaign 获取复核活动，只有复核活动的创建人或复核人可以查看
+func (u *useCaseImpl) getViewableCampaign(ctx context.Context, id string) (*model.TAuthRecertCampaign, error) {
+	userInfo, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	campaign, err := u.repo.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.CreatedBy == userInfo.ID {
+		return campaign, nil
+	}
+	reviewer, err := u.repo.HasReviewer(ctx, id, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer {
+		return nil, errorcode.RecertViewForbiddenErr.Err()
+	}
+	return campaign, nil
+}
@@ -0,0 +1,35 @@
+package recertification
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/dto"
+)
+
+// UseCase 权限复核活动：定期复核资源上的授权，未复核的授权在截止时间后自动回收
+type UseCase interface {
+	Manager
+	Reviewer
+	// CloseDueCampaigns 回收已截止的复核活动中未复核的授权
+	CloseDueCampaigns(ctx context.Context) error
+}
+
+type Manager interface {
+	// Create 创建复核活动，为复核范围内的每个授权生成复核项
+	Create(ctx context.Context, req *dto.RecertCampaignCreateReq) (string, error)
+	// List 获取复核活动列表，只返回当前用户创建或者需要当前用户复核的复核活动
+	List(ctx context.Context, args *dto.RecertCampaignListArgs) (*dto.PageResult[dto.RecertCampaign], error)
+	// Get 获取复核活动详情，只有复核活动的创建人或复核人可以查看
+	Get(ctx context.Context, id string) (*dto.RecertCampaign, error)
+	// Cancel 取消复核活动，未复核的授权保持不变
+	Cancel(ctx context.Context, id string) error
+	// Report 获取复核活动的报告，只有复核活动的创建人或复核人可以查看
+	Report(ctx context.Context, id string) (*dto.RecertCampaignReport, error)
+}
+
+type Reviewer interface {
+	// ListItems 获取复核项列表，只有复核活动的创建人或复核人可以查看
+	ListItems(ctx context.Context, args *dto.RecertItemListArgs) (*dto.PageResult[dto.RecertItem], error)
+	// Decide 批量复核，复核结果立即生效
+	Decide(ctx context.Context, req *dto.RecertDecideReq) (*dto.RecertDecideRes, error)
+}
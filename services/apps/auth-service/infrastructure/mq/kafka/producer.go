@@ -0,0 +1,22 @@
+package kafka
+
+import (
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/settings"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/mq/kafkax"
+	"go.uber.org/zap"
+)
+
+func NewSyncProducer() (kafkax.Producer, error) {
+	s := settings.Instance
+	producer, err := kafkax.NewSyncProducer(&kafkax.ProducerConfig{
+		Addr:      s.Kafka.URI,
+		UserName:  s.Kafka.Username,
+		Password:  s.Kafka.Password,
+		Mechanism: s.Kafka.Mechanism,
+	})
+	if err != nil {
+		log.Error("NewSyncProducer ", zap.Error(err))
+	}
+	return producer, err
+}
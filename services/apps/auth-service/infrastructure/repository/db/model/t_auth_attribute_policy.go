@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/util"
+	"gorm.io/gorm"
+)
+
+const TableNameTAuthAttributePolicy = "t_auth_attribute_policy"
+
+// TAuthAttributePolicy 基于分级标签和访问者属性的行列策略
+type TAuthAttributePolicy struct {
+	Sid                 uint64    `gorm:"column:sid;primaryKey;comment:雪花ID" json:"sid"`                                             // 雪花ID
+	ID                  string    `gorm:"column:id;not null;comment:策略ID" json:"id"`                                                 // 策略ID
+	Name                string    `gorm:"column:name;not null;comment:策略名称" json:"name"`                                             // 策略名称
+	Description         string    `gorm:"column:description;not null;comment:策略描述" json:"description"`                               // 策略描述
+	ObjectTypes         string    `gorm:"column:object_types;not null;comment:生效的资源类型，逗号分隔" json:"object_types"`                     // 生效的资源类型，逗号分隔，为空表示都生效
+	LabelIDs            string    `gorm:"column:label_ids;not null;comment:字段的分级标签ID，逗号分隔" json:"label_ids"`                         // 字段的分级标签ID，逗号分隔
+	Type                string    `gorm:"column:type;not null;comment:策略类型" json:"type"`                                             // 策略类型 column 列策略 row 行策略
+	ColumnAction        string    `gorm:"column:column_action;not null;comment:列策略对字段的处理" json:"column_action"`                      // 列策略对字段的处理 mask 脱敏 hide 隐藏
+	RowOperator         string    `gorm:"column:row_operator;not null;comment:行策略的运算逻辑" json:"row_operator"`                         // 行策略的运算逻辑
+	RowValue            string    `gorm:"column:row_value;not null;comment:行策略的过滤值" json:"row_value"`                                // 行策略的过滤值，支持引用访问者属性
+	ExemptCsfLevel      int       `gorm:"column:exempt_csf_level;not null;comment:豁免条件：密级" json:"exempt_csf_level"`                  // 豁免条件：密级大于等于该值的用户不受策略限制
+	ExemptDepartmentIDs string    `gorm:"column:exempt_department_ids;not null;comment:豁免条件：部门ID，逗号分隔" json:"exempt_department_ids"` // 豁免条件：部门ID，逗号分隔
+	Enabled             bool      `gorm:"column:enabled;not null;comment:是否启用" json:"enabled"`                                       // 是否启用
+	CreatedBy           string    `gorm:"column:created_by;not null;comment:创建人" json:"created_by"`                                  // 创建人
+	CreatedByName       string    `gorm:"column:created_by_name;not null;comment:创建人名称" json:"created_by_name"`                      // 创建人名称
+	UpdatedBy           string    `gorm:"column:updated_by;not null;comment:更新人" json:"updated_by"`                                  // 更新人
+	UpdatedByName       string    `gorm:"column:updated_by_name;not null;comment:更新人名称" json:"updated_by_name"`                      // 更新人名称
+	CreatedAt           time.Time `gorm:"column:created_at;not null;comment:创建时间" json:"created_at"`                                 // 创建时间
+	UpdatedAt           time.Time `gorm:"column:updated_at;not null;autoUpdateTime;comment:更新时间" json:"updated_at"`                  // 更新时间
+}
+
+func (m *TAuthAttributePolicy) BeforeCreate(_ *gorm.DB) error {
+	if m == nil {
+		return nil
+	}
+
+	if m.Sid == 0 {
+		m.Sid = uint64(util.GetUniqueID())
+	}
+
+	return nil
+}
+
+// TableName TAuthAttributePolicy's table name
+func (*TAuthAttributePolicy) TableName() string {
+	return TableNameTAuthAttributePolicy
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/util"
+	"gorm.io/gorm"
+)
+
+const TableNameTAuthBreakGlassGrant = "t_auth_break_glass_grant"
+
+// TAuthBreakGlassGrant 紧急访问，申请后立即获得逻辑视图的读取权限，到期自动回收，事后复核
+type TAuthBreakGlassGrant struct {
+	Sid           uint64     `gorm:"column:sid;primaryKey;comment:雪花ID" json:"sid"`                            // 雪花ID
+	ID            string     `gorm:"column:id;not null;comment:紧急访问ID" json:"id"`                              // 紧急访问ID
+	ObjectID      string     `gorm:"column:object_id;not null;comment:资源ID" json:"object_id"`                  // 资源ID
+	ObjectType    string     `gorm:"column:object_type;not null;comment:资源类型" json:"object_type"`              // 资源类型
+	ObjectName    string     `gorm:"column:object_name;not null;comment:资源名称" json:"object_name"`              // 资源名称
+	SubjectID     string     `gorm:"column:subject_id;not null;comment:申请人ID" json:"subject_id"`               // 申请人ID
+	SubjectName   string     `gorm:"column:subject_name;not null;comment:申请人名称" json:"subject_name"`           // 申请人名称
+	Justification string     `gorm:"column:justification;not null;comment:紧急访问的理由" json:"justification"`       // 紧急访问的理由
+	Duration      int        `gorm:"column:duration;not null;comment:有效期，单位分钟" json:"duration"`                // 有效期，单位分钟
+	ExpiredAt     time.Time  `gorm:"column:expired_at;not null;comment:过期时间" json:"expired_at"`                // 过期时间
+	PolicyIDs     string     `gorm:"column:policy_ids;not null;comment:紧急访问创建的策略ID，逗号分隔" json:"policy_ids"`    // 紧急访问创建的策略ID，逗号分隔，回收时只删除这些策略
+	OwnerIDs      string     `gorm:"column:owner_ids;not null;comment:资源的Owner，逗号分隔" json:"owner_ids"`         // 申请时资源的 Owner，逗号分隔
+	Status        string     `gorm:"column:status;not null;comment:状态" json:"status"`                          // 状态 active 生效中 expired 到期自动回收 revoked 提前结束
+	ActiveKey     *string    `gorm:"column:active_key;comment:生效中的唯一键" json:"-"`                               // 生效中时为 object_id:subject_id，回收后置空，唯一索引保证用户在资源上只有一个生效中的紧急访问
+	RevokedAt     *time.Time `gorm:"column:revoked_at;comment:回收时间" json:"revoked_at"`                         // 回收时间
+	RevokedBy     string     `gorm:"column:revoked_by;not null;comment:提前结束的用户" json:"revoked_by"`             // 提前结束的用户
+	RevokedByName string     `gorm:"column:revoked_by_name;not null;comment:提前结束的用户名称" json:"revoked_by_name"` // 提前结束的用户名称
+	RevokeReason  string     `gorm:"column:revoke_reason;not null;comment:提前结束的原因" json:"revoke_reason"`       // 提前结束的原因
+	ApplyID       string     `gorm:"column:apply_id;not null;comment:事后复核的审核申请ID" json:"apply_id"`             // 事后复核的审核申请ID
+	ProcDefKey    string     `gorm:"column:proc_def_key;not null;comment:事后复核的审核流程key" json:"proc_def_key"`    // 事后复核的审核流程key
+	AuditPhase    string     `gorm:"column:audit_phase;not null;comment:事后复核的审核状态" json:"audit_phase"`         // 事后复核的审核状态
+	AuditMessage  string     `gorm:"column:audit_message;not null;comment:事后复核的审核意见" json:"audit_message"`     // 事后复核的审核意见，或者未发起审核的原因
+	CreatedAt     time.Time  `gorm:"column:created_at;not null;comment:创建时间" json:"created_at"`                // 创建时间
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;autoUpdateTime;comment:更新时间" json:"updated_at"` // 更新时间
+}
+
+func (m *TAuthBreakGlassGrant) BeforeCreate(_ *gorm.DB) error {
+	if m == nil {
+		return nil
+	}
+
+	if m.Sid == 0 {
+		m.Sid = uint64(util.GetUniqueID())
+	}
+
+	return nil
+}
+
+// TableName TAuthBreakGlassGrant's table name
+func (*TAuthBreakGlassGrant) TableName() string {
+	return TableNameTAuthBreakGlassGrant
+}
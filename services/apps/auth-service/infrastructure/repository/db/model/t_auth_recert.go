@@ -0,0 +1,102 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/auth-service/common/util"
+	"gorm.io/gorm"
+)
+
+const (
+	TableNameTAuthRecertCampaign = "t_auth_recert_campaign"
+	TableNameTAuthRecertItem     = "t_auth_recert_item"
+)
+
+// TAuthRecertCampaign 权限复核活动
+type TAuthRecertCampaign struct {
+	Sid                  uint64     `gorm:"column:sid;primaryKey;comment:雪花ID" json:"sid"`                                             // 雪花ID
+	ID                   string     `gorm:"column:id;not null;comment:复核活动ID" json:"id"`                                               // 复核活动ID
+	Name                 string     `gorm:"column:name;not null;comment:复核活动名称" json:"name"`                                           // 复核活动名称
+	Description          string     `gorm:"column:description;not null;comment:复核活动描述" json:"description"`                             // 复核活动描述
+	ObjectType           string     `gorm:"column:object_type;not null;comment:复核范围：资源类型" json:"object_type"`                          // 复核范围：资源类型
+	DepartmentID         string     `gorm:"column:department_id;not null;comment:复核范围：资源所属部门ID" json:"department_id"`                  // 复核范围：资源所属部门ID
+	IncludeSubDepartment bool       `gorm:"column:include_sub_department;not null;comment:复核范围：是否包含子部门" json:"include_sub_department"` // 复核范围：是否包含子部门
+	LabelID              string     `gorm:"column:label_id;not null;comment:复核范围：字段的分级标签ID" json:"label_id"`                           // 复核范围：字段的分级标签ID
+	ObjectIDs            string     `gorm:"column:object_ids;comment:复核范围：指定的资源ID，逗号分隔" json:"object_ids"`                             // 复核范围：指定的资源ID，逗号分隔
+	Deadline             time.Time  `gorm:"column:deadline;not null;comment:截止时间" json:"deadline"`                                     // 截止时间，未复核的授权在截止时间后自动回收
+	Status               string     `gorm:"column:status;not null;comment:状态" json:"status"`                                           // 状态 active 进行中 closing 回收中 completed 已完成 canceled 已取消
+	CreatedBy            string     `gorm:"column:created_by;not null;comment:创建人" json:"created_by"`                                  // 创建人
+	CreatedByName        string     `gorm:"column:created_by_name;not null;comment:创建人名称" json:"created_by_name"`                      // 创建人名称
+	CompletedAt          *time.Time `gorm:"column:completed_at;comment:完成时间" json:"completed_at"`                                      // 完成时间
+	CreatedAt            time.Time  `gorm:"column:created_at;not null;comment:创建时间" json:"created_at"`                                 // 创建时间
+	UpdatedAt            time.Time  `gorm:"column:updated_at;not null;autoUpdateTime;comment:更新时间" json:"updated_at"`                  // 更新时间
+}
+
+func (m *TAuthRecertCampaign) BeforeCreate(_ *gorm.DB) error {
+	if m == nil {
+		return nil
+	}
+
+	if m.Sid == 0 {
+		m.Sid = uint64(util.GetUniqueID())
+	}
+
+	return nil
+}
+
+// TableName TAuthRecertCampaign's table name
+func (*TAuthRecertCampaign) TableName() string {
+	return TableNameTAuthRecertCampaign
+}
+
+// TAuthRecertItem 权限复核项，每个授权一项
+type TAuthRecertItem struct {
+	Sid           uint64     `gorm:"column:sid;primaryKey;comment:雪花ID" json:"sid"`                              // 雪花ID
+	ID            string     `gorm:"column:id;not null;comment:复核项ID" json:"id"`                                 // 复核项ID
+	CampaignID    string     `gorm:"column:campaign_id;not null;comment:复核活动ID" json:"campaign_id"`              // 复核活动ID
+	PolicyID      string     `gorm:"column:policy_id;not null;comment:策略ID" json:"policy_id"`                    // 策略ID
+	ObjectID      string     `gorm:"column:object_id;not null;comment:资源ID" json:"object_id"`                    // 资源ID
+	ObjectType    string     `gorm:"column:object_type;not null;comment:资源类型" json:"object_type"`                // 资源类型
+	ObjectName    string     `gorm:"column:object_name;not null;comment:资源名称" json:"object_name"`                // 资源名称
+	SubjectID     string     `gorm:"column:subject_id;not null;comment:访问者ID" json:"subject_id"`                 // 访问者ID
+	SubjectType   string     `gorm:"column:subject_type;not null;comment:访问者类型" json:"subject_type"`             // 访问者类型
+	SubjectName   string     `gorm:"column:subject_name;not null;comment:访问者名称" json:"subject_name"`             // 访问者名称
+	Actions       string     `gorm:"column:actions;not null;comment:授权的动作，逗号分隔" json:"actions"`                  // 授权的动作，逗号分隔
+	ExpiredAt     *time.Time `gorm:"column:expired_at;comment:复核前的过期时间" json:"expired_at"`                       // 复核前的过期时间，为空表示永久有效
+	ReviewerID    string     `gorm:"column:reviewer_id;not null;comment:复核人" json:"reviewer_id"`                 // 复核人，资源的 Owner，没有 Owner 时为复核活动的创建人
+	ReviewerName  string     `gorm:"column:reviewer_name;not null;comment:复核人名称" json:"reviewer_name"`           // 复核人名称
+	Decision      string     `gorm:"column:decision;not null;comment:复核结果" json:"decision"`                      // 复核结果 pending 待复核 keep 保留 revoke 回收 shorten 缩短有效期 auto_revoked 到期自动回收
+	NewExpiredAt  *time.Time `gorm:"column:new_expired_at;comment:缩短后的过期时间" json:"new_expired_at"`               // 缩短后的过期时间
+	Comment       string     `gorm:"column:comment;not null;comment:复核意见" json:"comment"`                        // 复核意见
+	DecidedBy     string     `gorm:"column:decided_by;not null;comment:做出复核决定的用户" json:"decided_by"`             // 做出复核决定的用户，自动回收时为空
+	DecidedByName string     `gorm:"column:decided_by_name;not null;comment:做出复核决定的用户名称" json:"decided_by_name"` // 做出复核决定的用户名称
+	DecidedAt     *time.Time `gorm:"column:decided_at;comment:复核时间" json:"decided_at"`                           // 复核时间
+	Message       string     `gorm:"column:message;comment:最近一次执行复核决定失败的原因" json:"message"`                      // 最近一次执行复核决定失败的原因
+	CreatedAt     time.Time  `gorm:"column:created_at;not null;comment:创建时间" json:"created_at"`                  // 创建时间
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null;autoUpdateTime;comment:更新时间" json:"updated_at"`   // 更新时间
+}
+
+func (m *TAuthRecertItem) BeforeCreate(_ *gorm.DB) error {
+	if m == nil {
+		return nil
+	}
+
+	if m.Sid == 0 {
+		m.Sid = uint64(util.GetUniqueID())
+	}
+
+	return nil
+}
+
+// TableName TAuthRecertItem's table name
+func (*TAuthRecertItem) TableName() string {
+	return TableNameTAuthRecertItem
+}
+
+// TAuthRecertItemCount 按复核人和复核结果统计的复核项数量
+type TAuthRecertItemCount struct {
+	ReviewerID   string `gorm:"column:reviewer_id"`
+	ReviewerName string `gorm:"column:reviewer_name"`
+	Decision     string `gorm:"column:decision"`
+	Count        int    `gorm:"column:count"`
+}
@@ -10,6 +10,7 @@ import (
 var Set = wire.NewSet(
 	repositorySet,
 	kafka.NewConsumer,
+	kafka.NewSyncProducer,
 )
 var repositorySet = wire.NewSet(
 	db.NewMariaDB,
@@ -165,3 +165,117 @@ CREATE TABLE IF NOT EXISTS "t_dwh_auth_request_spec" (
   CLUSTER PRIMARY KEY ("sid")
   );
 CREATE UNIQUE INDEX IF NOT EXISTS uidx_dwh_auth_request_spec_request_form_id ON t_dwh_auth_request_spec("request_form_id");
+
+-- 权限复核活动
+CREATE TABLE IF NOT EXISTS "t_auth_recert_campaign" (
+  "sid" BIGINT NOT NULL,
+  "id"  VARCHAR(36 char) NOT NULL,
+  "name" VARCHAR(255 char) NOT NULL,
+  "description" VARCHAR(1024 char) NOT NULL DEFAULT '',
+  "object_type" VARCHAR(64 char) NOT NULL,
+  "department_id" VARCHAR(36 char) NOT NULL DEFAULT '',
+  "include_sub_department" TINYINT NOT NULL DEFAULT 0,
+  "label_id" VARCHAR(64 char) NOT NULL DEFAULT '',
+  "object_ids" text ,
+  "deadline" datetime(3) NOT NULL,
+  "status" VARCHAR(32 char) NOT NULL,
+  "created_by" VARCHAR(36 char) NOT NULL,
+  "created_by_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "completed_at" datetime(3) DEFAULT NULL,
+  "created_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  "updated_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  CLUSTER PRIMARY KEY ("sid")
+  );
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_auth_recert_campaign_id ON t_auth_recert_campaign("id");
+CREATE INDEX IF NOT EXISTS idx_auth_recert_campaign_status_deadline ON t_auth_recert_campaign("status", "deadline");
+
+-- 权限复核项，每个授权一项
+CREATE TABLE IF NOT EXISTS "t_auth_recert_item" (
+  "sid" BIGINT NOT NULL,
+  "id"  VARCHAR(36 char) NOT NULL,
+  "campaign_id" VARCHAR(36 char) NOT NULL,
+  "policy_id" VARCHAR(128 char) NOT NULL,
+  "object_id" VARCHAR(128 char) NOT NULL,
+  "object_type" VARCHAR(64 char) NOT NULL,
+  "object_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "subject_id" VARCHAR(128 char) NOT NULL,
+  "subject_type" VARCHAR(32 char) NOT NULL,
+  "subject_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "actions" VARCHAR(255 char) NOT NULL,
+  "expired_at" datetime(3) DEFAULT NULL,
+  "reviewer_id" VARCHAR(36 char) NOT NULL,
+  "reviewer_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "decision" VARCHAR(32 char) NOT NULL,
+  "new_expired_at" datetime(3) DEFAULT NULL,
+  "comment" VARCHAR(1024 char) NOT NULL DEFAULT '',
+  "decided_by" VARCHAR(36 char) NOT NULL DEFAULT '',
+  "decided_by_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "decided_at" datetime(3) DEFAULT NULL,
+  "message" text ,
+  "created_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  "updated_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  CLUSTER PRIMARY KEY ("sid")
+  );
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_auth_recert_item_id ON t_auth_recert_item("id");
+CREATE INDEX IF NOT EXISTS idx_auth_recert_item_campaign_id_decision ON t_auth_recert_item("campaign_id", "decision");
+CREATE INDEX IF NOT EXISTS idx_auth_recert_item_reviewer_id ON t_auth_recert_item("reviewer_id");
+
+-- 属性策略
+CREATE TABLE IF NOT EXISTS "t_auth_attribute_policy" (
+  "sid" BIGINT NOT NULL,
+  "id"  VARCHAR(36 char) NOT NULL,
+  "name" VARCHAR(128 char) NOT NULL,
+  "description" VARCHAR(1024 char) NOT NULL DEFAULT '',
+  "object_types" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "label_ids" text NOT NULL,
+  "type" VARCHAR(32 char) NOT NULL,
+  "column_action" VARCHAR(32 char) NOT NULL DEFAULT '',
+  "row_operator" VARCHAR(32 char) NOT NULL DEFAULT '',
+  "row_value" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "exempt_csf_level" INT NOT NULL DEFAULT 0,
+  "exempt_department_ids" text NOT NULL,
+  "enabled" TINYINT NOT NULL DEFAULT 0,
+  "created_by" VARCHAR(36 char) NOT NULL,
+  "created_by_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "updated_by" VARCHAR(36 char) NOT NULL,
+  "updated_by_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "created_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  "updated_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  CLUSTER PRIMARY KEY ("sid")
+  );
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_auth_attribute_policy_id ON t_auth_attribute_policy("id");
+CREATE INDEX IF NOT EXISTS idx_auth_attribute_policy_enabled ON t_auth_attribute_policy("enabled");
+
+-- 紧急访问
+CREATE TABLE IF NOT EXISTS "t_auth_break_glass_grant" (
+  "sid" BIGINT NOT NULL,
+  "id"  VARCHAR(36 char) NOT NULL,
+  "object_id" VARCHAR(128 char) NOT NULL,
+  "object_type" VARCHAR(64 char) NOT NULL,
+  "object_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "subject_id" VARCHAR(128 char) NOT NULL,
+  "subject_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "justification" VARCHAR(1024 char) NOT NULL,
+  "duration" INT NOT NULL,
+  "expired_at" datetime(3) NOT NULL,
+  "policy_ids" VARCHAR(1024 char) NOT NULL DEFAULT '',
+  "owner_ids" text NOT NULL,
+  "status" VARCHAR(32 char) NOT NULL,
+  "active_key" VARCHAR(257 char) DEFAULT NULL,
+  "revoked_at" datetime(3) DEFAULT NULL,
+  "revoked_by" VARCHAR(36 char) NOT NULL DEFAULT '',
+  "revoked_by_name" VARCHAR(255 char) NOT NULL DEFAULT '',
+  "revoke_reason" VARCHAR(1024 char) NOT NULL DEFAULT '',
+  "apply_id" VARCHAR(64 char) NOT NULL DEFAULT '',
+  "proc_def_key" VARCHAR(128 char) NOT NULL DEFAULT '',
+  "audit_phase" VARCHAR(32 char) NOT NULL DEFAULT '',
+  "audit_message" text ,
+  "created_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  "updated_at" datetime(3) NOT NULL DEFAULT current_timestamp(3),
+  CLUSTER PRIMARY KEY ("sid")
+  );
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_auth_break_glass_grant_id ON t_auth_break_glass_grant("id");
+CREATE UNIQUE INDEX IF NOT EXISTS uidx_auth_break_glass_grant_active_key ON t_auth_break_glass_grant("active_key");
+CREATE INDEX IF NOT EXISTS idx_auth_break_glass_grant_status_expired_at ON t_auth_break_glass_grant("status", "expired_at");
+CREATE INDEX IF NOT EXISTS idx_auth_break_glass_grant_object_id_subject_id ON t_auth_break_glass_grant("object_id", "subject_id");
+CREATE INDEX IF NOT EXISTS idx_auth_break_glass_grant_proc_def_key_audit_phase ON t_auth_break_glass_grant("proc_def_key", "audit_phase");
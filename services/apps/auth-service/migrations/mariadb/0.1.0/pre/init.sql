@@ -164,4 +164,118 @@ CREATE TABLE IF NOT EXISTS `t_dwh_auth_request_spec` (
     draft_request_type varchar(32)  DEFAULT NULL COMMENT '草稿申请类型，check数据核验，query数据查询',
     PRIMARY KEY (`sid`) USING BTREE,
     KEY  `idx_apply_id` (`request_form_id`)
-)  COMMENT='数仓数据申请单的子视图的内容';
\ No newline at end of file
+)  COMMENT='数仓数据申请单的子视图的内容';
+
+-- 权限复核活动
+CREATE TABLE IF NOT EXISTS `t_auth_recert_campaign` (
+    sid bigint(20) NOT NULL COMMENT '雪花ID',
+    id  char(36) NOT NULL COMMENT '复核活动ID',
+    name VARCHAR(255) NOT NULL COMMENT '复核活动名称',
+    description VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '复核活动描述',
+    object_type varchar(64) NOT NULL COMMENT '复核范围：资源类型',
+    department_id varchar(36) NOT NULL DEFAULT '' COMMENT '复核范围：资源所属部门ID',
+    include_sub_department tinyint(1) NOT NULL DEFAULT 0 COMMENT '复核范围：是否包含子部门',
+    label_id varchar(64) NOT NULL DEFAULT '' COMMENT '复核范围：字段的分级标签ID',
+    object_ids text DEFAULT NULL COMMENT '复核范围：指定的资源ID，逗号分隔',
+    deadline datetime(3) NOT NULL COMMENT '截止时间，未复核的授权在截止时间后自动回收',
+    status varchar(32) NOT NULL COMMENT '状态 active 进行中 closing 回收中 completed 已完成 canceled 已取消',
+    created_by char(36) NOT NULL COMMENT '创建人',
+    created_by_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '创建人名称',
+    completed_at datetime(3) DEFAULT NULL COMMENT '完成时间',
+    created_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '创建时间',
+    updated_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '更新时间',
+    PRIMARY KEY (`sid`) USING BTREE,
+    UNIQUE KEY `idx_id` (`id`),
+    KEY `idx_status_deadline` (`status`, `deadline`)
+)  COMMENT='权限复核活动';
+
+-- 权限复核项，每个授权一项
+CREATE TABLE IF NOT EXISTS `t_auth_recert_item` (
+    sid bigint(20) NOT NULL COMMENT '雪花ID',
+    id  char(36) NOT NULL COMMENT '复核项ID',
+    campaign_id char(36) NOT NULL COMMENT '复核活动ID',
+    policy_id varchar(128) NOT NULL COMMENT '策略ID',
+    object_id varchar(128) NOT NULL COMMENT '资源ID',
+    object_type varchar(64) NOT NULL COMMENT '资源类型',
+    object_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '资源名称',
+    subject_id varchar(128) NOT NULL COMMENT '访问者ID',
+    subject_type varchar(32) NOT NULL COMMENT '访问者类型',
+    subject_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '访问者名称',
+    actions varchar(255) NOT NULL COMMENT '授权的动作，逗号分隔',
+    expired_at datetime(3) DEFAULT NULL COMMENT '复核前的过期时间，为空表示永久有效',
+    reviewer_id char(36) NOT NULL COMMENT '复核人，资源的 Owner，没有 Owner 时为复核活动的创建人',
+    reviewer_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '复核人名称',
+    decision varchar(32) NOT NULL COMMENT '复核结果 pending 待复核 keep 保留 revoke 回收 shorten 缩短有效期 auto_revoked 到期自动回收',
+    new_expired_at datetime(3) DEFAULT NULL COMMENT '缩短后的过期时间',
+    comment VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '复核意见',
+    decided_by varchar(36) NOT NULL DEFAULT '' COMMENT '做出复核决定的用户，自动回收时为空',
+    decided_by_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '做出复核决定的用户名称',
+    decided_at datetime(3) DEFAULT NULL COMMENT '复核时间',
+    message text DEFAULT NULL COMMENT '最近一次执行复核决定失败的原因',
+    created_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '创建时间',
+    updated_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '更新时间',
+    PRIMARY KEY (`sid`) USING BTREE,
+    UNIQUE KEY `idx_id` (`id`),
+    KEY `idx_campaign_id_decision` (`campaign_id`, `decision`),
+    KEY `idx_reviewer_id` (`reviewer_id`)
+)  COMMENT='权限复核项';
+
+-- 属性策略
+CREATE TABLE IF NOT EXISTS `t_auth_attribute_policy` (
+    sid bigint(20) NOT NULL COMMENT '雪花ID',
+    id  char(36) NOT NULL COMMENT '策略ID',
+    name VARCHAR(128) NOT NULL COMMENT '策略名称',
+    description VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '策略描述',
+    object_types varchar(255) NOT NULL DEFAULT '' COMMENT '生效的资源类型，逗号分隔，为空表示都生效',
+    label_ids text NOT NULL COMMENT '字段的分级标签ID，逗号分隔',
+    type varchar(32) NOT NULL COMMENT '策略类型 column 列策略 row 行策略',
+    column_action varchar(32) NOT NULL DEFAULT '' COMMENT '列策略对字段的处理 mask 脱敏 hide 隐藏',
+    row_operator varchar(32) NOT NULL DEFAULT '' COMMENT '行策略的运算逻辑',
+    row_value VARCHAR(255) NOT NULL DEFAULT '' COMMENT '行策略的过滤值，支持引用访问者属性',
+    exempt_csf_level int(11) NOT NULL DEFAULT 0 COMMENT '豁免条件：密级大于等于该值的用户不受策略限制，0 表示不按密级豁免',
+    exempt_department_ids text NOT NULL COMMENT '豁免条件：部门ID，逗号分隔',
+    enabled tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否启用',
+    created_by varchar(36) NOT NULL COMMENT '创建人',
+    created_by_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '创建人名称',
+    updated_by varchar(36) NOT NULL COMMENT '更新人',
+    updated_by_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '更新人名称',
+    created_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '创建时间',
+    updated_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '更新时间',
+    PRIMARY KEY (`sid`) USING BTREE,
+    UNIQUE KEY `idx_id` (`id`),
+    KEY `idx_enabled` (`enabled`)
+)  COMMENT='属性策略';
+
+-- 紧急访问
+CREATE TABLE IF NOT EXISTS `t_auth_break_glass_grant` (
+    sid bigint(20) NOT NULL COMMENT '雪花ID',
+    id  char(36) NOT NULL COMMENT '紧急访问ID',
+    object_id varchar(128) NOT NULL COMMENT '资源ID',
+    object_type varchar(64) NOT NULL COMMENT '资源类型',
+    object_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '资源名称',
+    subject_id varchar(128) NOT NULL COMMENT '申请人ID',
+    subject_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '申请人名称',
+    justification VARCHAR(1024) NOT NULL COMMENT '紧急访问的理由',
+    duration int(11) NOT NULL COMMENT '有效期，单位分钟',
+    expired_at datetime(3) NOT NULL COMMENT '过期时间',
+    policy_ids varchar(1024) NOT NULL DEFAULT '' COMMENT '紧急访问创建的策略ID，逗号分隔，回收时只删除这些策略',
+    owner_ids text NOT NULL COMMENT '申请时资源的 Owner，逗号分隔',
+    status varchar(32) NOT NULL COMMENT '状态 active 生效中 expired 到期自动回收 revoked 提前结束',
+    active_key varchar(257) DEFAULT NULL COMMENT '生效中时为 object_id:subject_id，回收后置空，保证用户在资源上只有一个生效中的紧急访问',
+    revoked_at datetime(3) DEFAULT NULL COMMENT '回收时间',
+    revoked_by varchar(36) NOT NULL DEFAULT '' COMMENT '提前结束的用户，到期自动回收或者复核不通过时为空',
+    revoked_by_name VARCHAR(255) NOT NULL DEFAULT '' COMMENT '提前结束的用户名称',
+    revoke_reason VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '提前结束的原因',
+    apply_id varchar(64) NOT NULL DEFAULT '' COMMENT '事后复核的审核申请ID',
+    proc_def_key varchar(128) NOT NULL DEFAULT '' COMMENT '事后复核的审核流程key',
+    audit_phase varchar(32) NOT NULL DEFAULT '' COMMENT '事后复核的审核状态',
+    audit_message text DEFAULT NULL COMMENT '事后复核的审核意见，或者未发起审核的原因',
+    created_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '创建时间',
+    updated_at datetime(3) NOT NULL DEFAULT current_timestamp(3) COMMENT '更新时间',
+    PRIMARY KEY (`sid`) USING BTREE,
+    UNIQUE KEY `idx_id` (`id`),
+    UNIQUE KEY `uk_active_key` (`active_key`),
+    KEY `idx_status_expired_at` (`status`, `expired_at`),
+    KEY `idx_object_id_subject_id` (`object_id`, `subject_id`),
+    KEY `idx_proc_def_key_audit_phase` (`proc_def_key`, `audit_phase`)
+)  COMMENT='紧急访问';
@@ -0,0 +1,179 @@
+package call_record_spill
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+)
+
+const (
+	fileSuffix   = ".jsonl"
+	tmpSuffix    = ".tmp"
+	failedSuffix = ".failed"
+
+	// maxReplayFailures 其他文件能够补写时，同一文件补写失败的次数达到该值后隔离，不再补写
+	maxReplayFailures = 3
+)
+
+// ErrDisabled 未配置暂存目录
+var ErrDisabled = errors.New("call record spill dir is not configured")
+
+// CallRecordSpill 数据库不可用时暂存调用记录的本地文件，每批记录一个文件，每条记录一行
+type CallRecordSpill interface {
+	// Write 将一批记录写入新的暂存文件
+	Write(lines [][]byte) error
+	// Replay 按写入顺序读取暂存文件交给 fn，fn 成功后删除文件。fn 返回错误时文件保留到下次补写，继续补写其他文件，
+	// 返回第一个错误。其他文件补写成功说明数据库可用，此时反复失败的文件重命名为 .failed 隔离，不再阻塞补写
+	Replay(fn func(lines [][]byte) error) error
+}
+
+func NewCallRecordSpill() CallRecordSpill {
+	return newCallRecordSpill(settings.Instance.CallRecord.SpillDir)
+}
+
+func newCallRecordSpill(dir string) *callRecordSpill {
+	return &callRecordSpill{dir: dir, failures: make(map[string]int)}
+}
+
+type callRecordSpill struct {
+	dir string
+
+	mu  sync.Mutex
+	seq uint64
+	// 暂存文件补写失败的次数，只由 Replay 访问
+	failures map[string]int
+}
+
+func (s *callRecordSpill) Write(lines [][]byte) error {
+	if s.dir == "" {
+		return ErrDisabled
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.seq++
+	// 文件名按写入时间排序，补写时保持写入顺序
+	name := strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatUint(s.seq, 10) + fileSuffix
+	s.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	// 先写临时文件再重命名，补写时不会读到写了一半的文件
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path+tmpSuffix, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+tmpSuffix, path)
+}
+
+func (s *callRecordSpill) Replay(fn func(lines [][]byte) error) error {
+	if s.dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), fileSuffix) {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return spillFileLess(names[i], names[j]) })
+
+	var firstErr error
+	var failed []string
+	var replayed bool
+	for _, name := range names {
+		path := filepath.Join(s.dir, name)
+		lines, err := readLines(path)
+		if err == nil {
+			err = fn(lines)
+		}
+		if err != nil {
+			firstErr = cmp.Or(firstErr, err)
+			failed = append(failed, name)
+			continue
+		}
+		replayed = true
+		delete(s.failures, name)
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	// 全部失败时可能是数据库不可用，不计入文件的失败次数
+	if !replayed {
+		return firstErr
+	}
+	for _, name := range failed {
+		s.failures[name]++
+		if s.failures[name] < maxReplayFailures {
+			continue
+		}
+		path := filepath.Join(s.dir, name)
+		if err := os.Rename(path, path+failedSuffix); err != nil {
+			return err
+		}
+		delete(s.failures, name)
+	}
+	return firstErr
+}
+
+// spillFileLess 按文件名中的写入时间和序号排序
+func spillFileLess(a, b string) bool {
+	ta, sa := spillFileOrder(a)
+	tb, sb := spillFileOrder(b)
+	if ta != tb {
+		return ta < tb
+	}
+	return sa < sb
+}
+
+func spillFileOrder(name string) (int64, uint64) {
+	ts, seq, _ := strings.Cut(strings.TrimSuffix(name, fileSuffix), "-")
+	t, _ := strconv.ParseInt(ts, 10, 64)
+	n, _ := strconv.ParseUint(seq, 10, 64)
+	return t, n
+}
+
+func readLines(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		lines = append(lines, bytes.Clone(scanner.Bytes()))
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,78 @@
+package call_record_spill
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_callRecordSpill(t *testing.T) {
+	s := newCallRecordSpill(t.TempDir())
+	assert.NoError(t, s.Write([][]byte{[]byte(`{"a":1}`), []byte(`{"a":2}`)}))
+	assert.NoError(t, s.Write([][]byte{[]byte(`{"a":3}`)}))
+
+	// 补写失败时保留文件
+	err := s.Replay(func(lines [][]byte) error { return errors.New("db down") })
+	assert.Error(t, err)
+
+	var got []string
+	assert.NoError(t, s.Replay(func(lines [][]byte) error {
+		for _, l := range lines {
+			got = append(got, string(l))
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{`{"a":1}`, `{"a":2}`, `{"a":3}`}, got)
+
+	// 补写成功后文件已删除
+	var n int
+	assert.NoError(t, s.Replay(func(lines [][]byte) error { n += len(lines); return nil }))
+	assert.Zero(t, n)
+}
+
+func Test_callRecordSpill_quarantine(t *testing.T) {
+	dir := t.TempDir()
+	s := newCallRecordSpill(dir)
+	assert.NoError(t, s.Write([][]byte{[]byte(`{"bad":1}`)}))
+	assert.NoError(t, s.Write([][]byte{[]byte(`{"a":1}`)}))
+
+	// 数据库不可用时全部失败，不计入失败次数
+	for i := 0; i < maxReplayFailures; i++ {
+		assert.Error(t, s.Replay(func(lines [][]byte) error { return errors.New("db down") }))
+	}
+
+	// 失败的文件不阻塞之后的文件
+	var got []string
+	fn := func(lines [][]byte) error {
+		if strings.Contains(string(lines[0]), "bad") {
+			return errors.New("duplicate entry")
+		}
+		got = append(got, string(lines[0]))
+		return nil
+	}
+	assert.Error(t, s.Replay(fn))
+	assert.Equal(t, []string{`{"a":1}`}, got)
+
+	// 其他文件可以补写时，反复失败的文件被隔离
+	for i := 1; i < maxReplayFailures; i++ {
+		assert.NoError(t, s.Write([][]byte{[]byte(`{"a":2}`)}))
+		assert.Error(t, s.Replay(fn))
+	}
+	assert.NoError(t, s.Replay(fn))
+	failed, err := filepath.Glob(filepath.Join(dir, "*"+fileSuffix+failedSuffix))
+	assert.NoError(t, err)
+	assert.Len(t, failed, 1)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_callRecordSpill_disabled(t *testing.T) {
+	s := newCallRecordSpill("")
+	assert.ErrorIs(t, s.Write([][]byte{[]byte(`{}`)}), ErrDisabled)
+	assert.NoError(t, s.Replay(func(lines [][]byte) error { return nil }))
+}
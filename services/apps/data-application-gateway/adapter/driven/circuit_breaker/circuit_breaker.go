@@ -0,0 +1,279 @@
+package circuit_breaker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+const (
+	// StateKeyPrefix 熔断状态的 key 前缀，hash 的 field 为网关实例，data-application-service 读取后在接口详情中展示
+	StateKeyPrefix = "data-application-gateway-breaker:"
+	// 熔断状态在 redis 中的保留时间
+	stateKeyTTL = 7 * 24 * time.Hour
+	// 定时重新写入本实例所有熔断器状态的间隔，读取方据此识别已下线实例留下的状态
+	heartbeatInterval = 30 * time.Second
+	// 半开状态探测名额用完时建议的重试等待时间
+	halfOpenRetryAfter = time.Second
+)
+
+// State 熔断器状态
+type State string
+
+const (
+	StateClosed   State = "closed"    // 正常放行
+	StateOpen     State = "open"      // 熔断，拒绝请求
+	StateHalfOpen State = "half_open" // 半开，放行少量探测请求
+)
+
+// Config 熔断规则
+type Config struct {
+	FailureRatio   float64       // 统计窗口内失败比例达到该值时熔断
+	MinRequests    int64         // 统计窗口内请求数达到该值后才计算失败比例
+	Window         time.Duration // 统计窗口
+	OpenInterval   time.Duration // 熔断持续时间
+	HalfOpenProbes int           // 半开状态放行的探测请求数
+}
+
+// Snapshot 熔断器状态快照
+type Snapshot struct {
+	ServiceID string `json:"service_id"`
+	// 网关实例
+	Instance string `json:"instance"`
+	State    State  `json:"state"`
+	// 当前统计窗口内的请求数和失败数
+	Requests int64 `json:"requests"`
+	Failures int64 `json:"failures"`
+	// 熔断开始时间，未熔断时为空
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+	// 状态变化时间
+	ChangedAt time.Time `json:"changed_at"`
+	// 写入 redis 的时间，实例存活时每个心跳间隔刷新一次
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// CircuitBreakerRepo 接口注册后端服务的熔断器，按接口区分，状态保存在网关实例内存中
+type CircuitBreakerRepo interface {
+	// Allow 判断是否允许调用后端服务。允许时返回 done，调用结束后必须上报是否成功；拒绝时返回建议的重试等待时间
+	Allow(serviceID string, conf Config) (done func(success bool), retryAfter time.Duration, ok bool)
+	// Snapshot 接口熔断器的状态，接口没有调用过后端服务时返回 nil
+	Snapshot(serviceID string) *Snapshot
+	// Snapshots 本实例所有熔断器的状态
+	Snapshots() []*Snapshot
+}
+
+func NewCircuitBreakerRepo(r *repository.Redis) CircuitBreakerRepo {
+	instance, _ := os.Hostname()
+	repo := &circuitBreakerRepo{client: r.Client, instance: instance, now: time.Now}
+	go repo.heartbeat()
+	return repo
+}
+
+type circuitBreakerRepo struct {
+	client   redis.UniversalClient
+	instance string
+	now      func() time.Time
+
+	breakers sync.Map // serviceID -> *breaker
+}
+
+func (r *circuitBreakerRepo) Allow(serviceID string, conf Config) (done func(success bool), retryAfter time.Duration, ok bool) {
+	v, _ := r.breakers.LoadOrStore(serviceID, newBreaker(r.now()))
+	b := v.(*breaker)
+
+	generation, retryAfter, ok, changed := b.allow(r.now(), conf)
+	if changed {
+		r.publish(serviceID, b)
+	}
+	if !ok {
+		return nil, retryAfter, false
+	}
+
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() {
+			if b.report(r.now(), conf, generation, success) {
+				r.publish(serviceID, b)
+			}
+		})
+	}, 0, true
+}
+
+func (r *circuitBreakerRepo) Snapshot(serviceID string) *Snapshot {
+	v, ok := r.breakers.Load(serviceID)
+	if !ok {
+		return nil
+	}
+	return v.(*breaker).snapshot(serviceID, r.instance)
+}
+
+func (r *circuitBreakerRepo) Snapshots() []*Snapshot {
+	var res []*Snapshot
+	r.breakers.Range(func(key, value any) bool {
+		res = append(res, value.(*breaker).snapshot(key.(string), r.instance))
+		return true
+	})
+	sort.Slice(res, func(i, j int) bool { return res[i].ServiceID < res[j].ServiceID })
+	return res
+}
+
+// publish 异步将状态变化写入 redis，写入失败不影响熔断判断
+func (r *circuitBreakerRepo) publish(serviceID string, b *breaker) {
+	snapshot := b.snapshot(serviceID, r.instance)
+	go r.write(snapshot)
+}
+
+// heartbeat 定时重新写入本实例所有熔断器的状态，实例下线后状态不再刷新，读取方按写入时间忽略
+func (r *circuitBreakerRepo) heartbeat() {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if snapshots := r.Snapshots(); len(snapshots) > 0 {
+			r.write(snapshots...)
+		}
+	}
+}
+
+// write 将状态快照写入 redis，并刷新写入时间和 key 的过期时间
+func (r *circuitBreakerRepo) write(snapshots ...*Snapshot) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	reportedAt := r.now()
+	pipe := r.client.Pipeline()
+	for _, snapshot := range snapshots {
+		snapshot.ReportedAt = reportedAt
+		val, _ := json.Marshal(snapshot)
+		key := StateKeyPrefix + snapshot.ServiceID
+		pipe.HSet(ctx, key, r.instance, val)
+		pipe.Expire(ctx, key, stateKeyTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.WithContext(ctx).Warn("circuitBreakerRepo write", zap.Int("snapshots", len(snapshots)), zap.Error(err))
+	}
+}
+
+// breaker 单个接口的熔断器
+type breaker struct {
+	mu sync.Mutex
+
+	state State
+	// 状态变化时递增，旧状态下发出的请求结果不再计入
+	generation uint64
+	changedAt  time.Time
+
+	// 关闭状态的统计窗口
+	windowStart        time.Time
+	requests, failures int64
+
+	// 熔断开始时间
+	openedAt time.Time
+
+	// 半开状态已放行和已成功的探测请求数
+	probes, probeSuccesses int
+}
+
+func newBreaker(now time.Time) *breaker {
+	return &breaker{state: StateClosed, changedAt: now, windowStart: now}
+}
+
+// allow 判断是否放行请求，返回放行时的状态代数和状态是否变化
+func (b *breaker) allow(now time.Time, conf Config) (generation uint64, retryAfter time.Duration, ok, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen {
+		if wait := b.openedAt.Add(conf.OpenInterval).Sub(now); wait > 0 {
+			return 0, wait, false, false
+		}
+		b.setState(now, StateHalfOpen)
+		changed = true
+	}
+
+	if b.state == StateHalfOpen {
+		if b.probes >= max(conf.HalfOpenProbes, 1) {
+			return 0, halfOpenRetryAfter, false, changed
+		}
+		b.probes++
+	}
+	return b.generation, 0, true, changed
+}
+
+// report 上报请求结果，返回状态是否变化
+func (b *breaker) report(now time.Time, conf Config, generation uint64, success bool) (changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return false
+	}
+
+	switch b.state {
+	case StateClosed:
+		if conf.Window > 0 && now.Sub(b.windowStart) >= conf.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+		b.requests++
+		if !success {
+			b.failures++
+		}
+		if conf.FailureRatio > 0 && b.requests >= max(conf.MinRequests, 1) &&
+			float64(b.failures) >= conf.FailureRatio*float64(b.requests) {
+			b.setState(now, StateOpen)
+			return true
+		}
+	case StateHalfOpen:
+		if !success {
+			b.setState(now, StateOpen)
+			return true
+		}
+		b.probeSuccesses++
+		if b.probeSuccesses >= max(conf.HalfOpenProbes, 1) {
+			b.setState(now, StateClosed)
+			return true
+		}
+	}
+	return false
+}
+
+func (b *breaker) setState(now time.Time, state State) {
+	b.state = state
+	b.generation++
+	b.changedAt = now
+	b.probes, b.probeSuccesses = 0, 0
+	switch state {
+	case StateOpen:
+		b.openedAt = now
+	case StateClosed:
+		b.windowStart, b.requests, b.failures = now, 0, 0
+	}
+}
+
+func (b *breaker) snapshot(serviceID, instance string) *Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := &Snapshot{
+		ServiceID: serviceID,
+		Instance:  instance,
+		State:     b.state,
+		Requests:  b.requests,
+		Failures:  b.failures,
+		ChangedAt: b.changedAt,
+	}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		s.OpenedAt = &openedAt
+	}
+	return s
+}
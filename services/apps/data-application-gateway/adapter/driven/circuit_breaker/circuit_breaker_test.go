@@ -0,0 +1,72 @@
+package circuit_breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_breaker(t *testing.T) {
+	conf := Config{FailureRatio: 0.5, MinRequests: 4, Window: time.Minute, OpenInterval: 10 * time.Second, HalfOpenProbes: 2}
+	now := time.Unix(0, 0)
+	b := newBreaker(now)
+
+	call := func(success bool) {
+		t.Helper()
+		generation, _, ok, _ := b.allow(now, conf)
+		assert.True(t, ok)
+		b.report(now, conf, generation, success)
+	}
+
+	// 请求数不足时不熔断
+	call(false)
+	call(false)
+	call(true)
+	assert.Equal(t, StateClosed, b.state)
+
+	// 失败比例达到阈值后熔断
+	call(false)
+	assert.Equal(t, StateOpen, b.state)
+	_, retryAfter, ok, _ := b.allow(now.Add(4*time.Second), conf)
+	assert.False(t, ok)
+	assert.Equal(t, 6*time.Second, retryAfter)
+
+	// 熔断结束后进入半开状态，只放行探测请求
+	now = now.Add(10 * time.Second)
+	g1, _, ok, changed := b.allow(now, conf)
+	assert.True(t, ok)
+	assert.True(t, changed)
+	assert.Equal(t, StateHalfOpen, b.state)
+	g2, _, ok, _ := b.allow(now, conf)
+	assert.True(t, ok)
+	_, _, ok, _ = b.allow(now, conf)
+	assert.False(t, ok)
+
+	// 探测请求全部成功后恢复
+	b.report(now, conf, g1, true)
+	assert.Equal(t, StateHalfOpen, b.state)
+	b.report(now, conf, g2, true)
+	assert.Equal(t, StateClosed, b.state)
+	assert.Zero(t, b.requests)
+}
+
+func Test_breaker_halfOpenFailure(t *testing.T) {
+	conf := Config{FailureRatio: 1, MinRequests: 1, OpenInterval: time.Second, HalfOpenProbes: 1}
+	now := time.Unix(0, 0)
+	b := newBreaker(now)
+
+	g, _, _, _ := b.allow(now, conf)
+	// 熔断前发出的请求结果不再计入
+	stale, _, _, _ := b.allow(now, conf)
+	b.report(now, conf, g, false)
+	assert.Equal(t, StateOpen, b.state)
+	assert.False(t, b.report(now, conf, stale, true))
+
+	now = now.Add(time.Second)
+	g, _, ok, _ := b.allow(now, conf)
+	assert.True(t, ok)
+	b.report(now, conf, g, false)
+	assert.Equal(t, StateOpen, b.state)
+	assert.Equal(t, now, b.openedAt)
+}
@@ -6,10 +6,15 @@ import (
 	auth_service "github.com/kweaver-ai/idrm-go-common/rest/auth-service"
 
 	"github.com/google/wire"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/call_record_spill"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/gorm"
 	hydra "github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/hydra/v6"
 	mdl_uniquery "github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/mdl-uniquery"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/nonce_store"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/rate_limiter"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/result_cache"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/reverse_proxy"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/virtual_engine"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/util"
@@ -58,4 +63,9 @@ var Set = wire.NewSet(
 	NewUserManagementService,
 	NewAuthServiceInternalV1Interface,
 	mdl_uniquery.NewMDLUniQuery,
+	rate_limiter.NewRateLimiterRepo,
+	nonce_store.NewNonceStore,
+	result_cache.NewResultCacheRepo,
+	call_record_spill.NewCallRecordSpill,
+	circuit_breaker.NewCircuitBreakerRepo,
 )
@@ -27,6 +27,8 @@ import (
 
 type ServiceRepo interface {
 	ServiceGet(ctx context.Context, servicePath string) (res *model.ServiceAssociations, err error)
+	// ServiceVersionGet 获取版本组内指定版本的接口
+	ServiceVersionGet(ctx context.Context, versionGroupID, version string) (res *model.ServiceAssociations, err error)
 	GetSubServices(ctx context.Context, serviceID string) (subServices []*model.SubService, err error)
 	ServiceGetFields(ctx context.Context, httpMethod string, servicePath string, fields []string) (service *model.Service, err error)
 	IsServicePathExist(ctx context.Context, servicePath, serviceID string) (exist bool, err error)
@@ -81,7 +83,7 @@ func (r *serviceRepo) ServiceGet(ctx context.Context, servicePath string) (res *
 		return nil, errorcode.Desc(errorcode.ServicePathNotExist)
 	} else if tx.Error != nil {
 		log.WithContext(ctx).Error("ServiceGet", zap.Error(tx.Error))
-		return nil, err
+		return nil, tx.Error
 	}
 
 	r.cache.Add(servicePath, res)
@@ -89,6 +91,38 @@ func (r *serviceRepo) ServiceGet(ctx context.Context, servicePath string) (res *
 	return
 }
 
+func (r *serviceRepo) ServiceVersionGet(ctx context.Context, versionGroupID, version string) (res *model.ServiceAssociations, err error) {
+	key := versionGroupID + "@" + version
+	if res, ok := r.cache.Get(key); ok {
+		r.hit.Add(1)
+		return res, nil
+	}
+	r.miss.Add(1)
+
+	// 排除变更产生的草稿，草稿与原接口的版本号相同
+	tx := r.data.DB.WithContext(ctx).Model(&model.Service{}).Scopes(Undeleted()).
+		Preload("ServiceDataSource", "delete_time = 0").
+		Preload("ServiceParams", "delete_time = 0").
+		Preload("ServiceResponseFilters", "delete_time = 0").
+		Preload("ServiceScriptModel", "delete_time = 0").
+		Preload("SubServices", "deleted_at = 0").
+		Where(&model.Service{VersionGroupID: versionGroupID, Version: version}).
+		Where("(changed_service_id IS NULL OR changed_service_id IN ('', 'NULL'))").
+		Limit(1).
+		Find(&res)
+	if tx.Error != nil {
+		log.WithContext(ctx).Error("ServiceVersionGet", zap.Error(tx.Error))
+		return nil, tx.Error
+	}
+	if res == nil || res.ServiceID == "" {
+		return nil, errorcode.Desc(errorcode.ServiceVersionNotExist)
+	}
+
+	r.cache.Add(key, res)
+
+	return
+}
+
 func (r *serviceRepo) GetSubServices(ctx context.Context, serviceID string) (subServices []*model.SubService, err error) {
 	if err = r.data.DB.WithContext(ctx).Where("service_id=? and deleted_at=0 ", serviceID).Find(&subServices).Error; err != nil {
 		return nil, err
@@ -176,11 +210,12 @@ func (r *serviceRepo) WizardModelScript(ctx context.Context, requestParams map[s
 					quote = fmt.Sprintf(`'*' AS %s`, quote)
 				}
 				selects = append(selects, quote)
+				// NULL 固定排在最后，与分页游标的过滤条件一致
 				switch p.Sort {
 				case "asc":
-					tx = tx.Order(quote + " asc")
+					tx = tx.Order(quote + " asc nulls last")
 				case "desc":
-					tx = tx.Order(quote + " desc")
+					tx = tx.Order(quote + " desc nulls last")
 				}
 			}
 		}
@@ -3,12 +3,17 @@ package gorm
 import (
 	"context"
 
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
 )
 
 type ServiceCallRecordRepo interface {
 	Create(ctx context.Context, record *model.ServiceCallRecord) error
+	// CreateInBatches 批量插入调用记录，主键已存在的记录跳过，重复补写同一批记录不报错。返回本次新插入的记录
+	CreateInBatches(ctx context.Context, records []*model.ServiceCallRecord) ([]*model.ServiceCallRecord, error)
 }
 
 type serviceCallRecordRepo struct {
@@ -30,3 +35,44 @@ func (r *serviceCallRecordRepo) Create(ctx context.Context, record *model.Servic
 
 	return r.data.DB.WithContext(ctx).Create(record).Error
 }
+
+func (r *serviceCallRecordRepo) CreateInBatches(ctx context.Context, records []*model.ServiceCallRecord) ([]*model.ServiceCallRecord, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// 写入失败的记录已分配主键后暂存，补写时可能已有部分写入成功，需要跳过已写入的记录
+	var ids []int64
+	for _, record := range records {
+		if record.ID != 0 {
+			ids = append(ids, record.ID)
+		}
+	}
+	var inserted []*model.ServiceCallRecord
+	err := r.data.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing := make(map[int64]bool)
+		if len(ids) > 0 {
+			var existingIDs []int64
+			if err := tx.Model(&model.ServiceCallRecord{}).Where("id IN ?", ids).Pluck("id", &existingIDs).Error; err != nil {
+				return err
+			}
+			for _, id := range existingIDs {
+				existing[id] = true
+			}
+		}
+		inserted = make([]*model.ServiceCallRecord, 0, len(records))
+		for _, record := range records {
+			if !existing[record.ID] {
+				inserted = append(inserted, record)
+			}
+		}
+		if len(inserted) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(inserted, len(inserted)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
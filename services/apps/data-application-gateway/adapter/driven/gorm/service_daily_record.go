@@ -14,6 +14,8 @@ import (
 type DataApplicationServiceRepo interface {
 	IncrementSuccessCount(ctx context.Context, serviceID string) error
 	IncrementFailCount(ctx context.Context, serviceID string) error
+	// IncrementCounts 同时增加成功和失败次数，用于批量写入调用记录后统一计数
+	IncrementCounts(ctx context.Context, serviceID string, success, fail int) error
 	EnsureTodayRecordExists(ctx context.Context, serviceID string) error
 	// 辅助方法
 	Exists(ctx context.Context, serviceID string, recordDate time.Time) (bool, error)
@@ -74,6 +76,34 @@ func (r *dataApplicationServiceRepo) IncrementFailCount(ctx context.Context, ser
 	return nil
 }
 
+func (r *dataApplicationServiceRepo) IncrementCounts(ctx context.Context, serviceID string, success, fail int) error {
+	if success == 0 && fail == 0 {
+		return nil
+	}
+	today := time.Now().Format("2006-01-02")
+
+	// 确保今日记录存在
+	if err := r.EnsureTodayRecordExists(ctx, serviceID); err != nil {
+		log.WithContext(ctx).Error("dataApplicationServiceRepo IncrementCounts ensureRecordExists", zap.Error(err))
+		return err
+	}
+
+	result := r.data.DB.WithContext(ctx).
+		Model(&model.ServiceDailyRecord{}).
+		Where("service_id = ? AND record_date = ?", serviceID, today).
+		UpdateColumns(map[string]interface{}{
+			"success_count": gorm.Expr("success_count + ?", success),
+			"fail_count":    gorm.Expr("fail_count + ?", fail),
+		})
+
+	if result.Error != nil {
+		log.WithContext(ctx).Error("dataApplicationServiceRepo IncrementCounts", zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
 // EnsureTodayRecordExists 确保今日记录存在，不存在则插入
 func (r *dataApplicationServiceRepo) EnsureTodayRecordExists(ctx context.Context, serviceID string) error {
 	today := time.Now()
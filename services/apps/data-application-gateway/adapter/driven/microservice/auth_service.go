@@ -21,6 +21,49 @@ type Enforce struct {
 	SubjectType string `json:"subject_type"`
 }
 
+// EnforceEffect 鉴权结果，Obligations 是 allow 时需要执行的属性策略
+type EnforceEffect struct {
+	Enforce
+	Obligations *AttributeObligations `json:"obligations,omitempty"`
+	// 属性策略计算失败的原因，不为空时不能按没有属性策略处理
+	ObligationError string `json:"obligation_error,omitempty"`
+}
+
+// 属性策略对字段的处理方式
+const (
+	AttributeColumnMask = "mask"
+	AttributeColumnHide = "hide"
+)
+
+// AttributeObligations 属性策略要求对查询结果的处理
+type AttributeObligations struct {
+	Columns    []*AttributeColumnObligation `json:"columns,omitempty"`
+	RowFilters []*AttributeRowFilter        `json:"row_filters,omitempty"`
+}
+
+// AttributeColumnObligation 字段的处理，Name 是逻辑视图字段的英文名称
+type AttributeColumnObligation struct {
+	DataViewID string `json:"data_view_id"`
+	Name       string `json:"name"`
+	Action     string `json:"action"`
+}
+
+// AttributeRowFilter 行过滤条件，in、not in 的多个值使用英文逗号分隔
+type AttributeRowFilter struct {
+	DataViewID string `json:"data_view_id"`
+	Name       string `json:"name"`
+	Operator   string `json:"operator"`
+	Value      string `json:"value"`
+}
+
+// AttributePolicyEvaluateReq 计算属性策略的请求
+type AttributePolicyEvaluateReq struct {
+	ObjectId    string `json:"object_id"`
+	ObjectType  string `json:"object_type"`
+	SubjectId   string `json:"subject_id"`
+	SubjectType string `json:"subject_type"`
+}
+
 type SubjectObjectsRes struct {
 	TotalCount int `json:"total_count"`
 	Entries    []struct {
@@ -35,6 +78,10 @@ type SubjectObjectsRes struct {
 
 type AuthServiceRepo interface {
 	Enforce(ctx context.Context, enforcesReq []Enforce) (enforcesRes []bool, err error)
+	// EnforceWithObligations 鉴权并返回生效的属性策略
+	EnforceWithObligations(ctx context.Context, enforcesReq []Enforce) (enforcesRes []EnforceEffect, err error)
+	// EvaluateAttributePolicies 计算访问者访问资源时生效的属性策略，与访问者是否拥有资源的权限无关
+	EvaluateAttributePolicies(ctx context.Context, evaluateReq *AttributePolicyEvaluateReq) (res *AttributeObligations, err error)
 	SubjectObjects(ctx context.Context, objectType, subjectId, subjectType string) (res *SubjectObjectsRes, err error)
 }
 
@@ -66,6 +113,51 @@ func (b *authServiceRepo) Enforce(ctx context.Context, enforcesReq []Enforce) (e
 	return
 }
 
+func (b *authServiceRepo) EnforceWithObligations(ctx context.Context, enforcesReq []Enforce) (enforcesRes []EnforceEffect, err error) {
+	url := settings.Instance.Services.AuthService + "/api/auth-service/v1/enforce"
+	resp, err := req.SetQueryParam("with_obligations", "true").SetBodyJsonMarshal(enforcesReq).Post(url)
+	if err != nil {
+		log.WithContext(ctx).Error("authServiceRepo EnforceWithObligations", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	if resp.StatusCode != 200 {
+		log.WithContext(ctx).Error("authServiceRepo EnforceWithObligations", zap.Error(errors.New(resp.String())))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, resp.String())
+	}
+
+	err = resp.UnmarshalJson(&enforcesRes)
+	if err != nil {
+		log.WithContext(ctx).Error("authServiceRepo EnforceWithObligations", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	return
+}
+
+func (b *authServiceRepo) EvaluateAttributePolicies(ctx context.Context, evaluateReq *AttributePolicyEvaluateReq) (res *AttributeObligations, err error) {
+	url := settings.Instance.Services.AuthService + "/api/internal/auth-service/v1/attribute-policies/evaluate"
+	resp, err := req.SetBodyJsonMarshal(evaluateReq).Post(url)
+	if err != nil {
+		log.WithContext(ctx).Error("authServiceRepo EvaluateAttributePolicies", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	if resp.StatusCode != 200 {
+		log.WithContext(ctx).Error("authServiceRepo EvaluateAttributePolicies", zap.Error(errors.New(resp.String())))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, resp.String())
+	}
+
+	res = &AttributeObligations{}
+	err = resp.UnmarshalJson(res)
+	if err != nil {
+		log.WithContext(ctx).Error("authServiceRepo EvaluateAttributePolicies", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	return
+}
+
 func (b *authServiceRepo) SubjectObjects(ctx context.Context, objectType, subjectId, subjectType string) (res *SubjectObjectsRes, err error) {
 	params := map[string]string{
 		"object_type":  objectType,
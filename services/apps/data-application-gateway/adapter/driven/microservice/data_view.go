@@ -59,11 +59,27 @@ type DataViewListRes struct {
 	LastScanTime int `json:"last_scan_time"`
 }
 
+// DesensitizationRule 脱敏规则，Method 为 all 全部脱敏、middle 中间脱敏、head-tail 首尾脱敏
+type DesensitizationRule struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Method    string `json:"method"`
+	MiddleBit int32  `json:"middle_bit"`
+	HeadBit   int32  `json:"head_bit"`
+	TailBit   int32  `json:"tail_bit"`
+}
+
+type DesensitizationRulesRes struct {
+	Data []*DesensitizationRule `json:"data"`
+}
+
 type DataViewRepo interface {
 	// DataViewGet 数据视图详情
 	DataViewGet(ctx context.Context, id string) (res *DataViewGetRes, err error)
 	// DataViewList 数据视图列表
 	DataViewList(ctx context.Context, ids []string) (res *DataViewListRes, err error)
+	// DesensitizationRulesGet 根据 ID 批量查询脱敏规则
+	DesensitizationRulesGet(ctx context.Context, ids []string) (res []*DesensitizationRule, err error)
 	// ParseViewSourceCatalogName 解析 catalog 名称
 	ParseViewSourceCatalogName(viewSourceCatalogName string) (catalogName, schemaName string)
 }
@@ -125,6 +141,31 @@ func (u *dataViewRepo) DataViewList(ctx context.Context, ids []string) (res *Dat
 	return res, nil
 }
 
+func (u *dataViewRepo) DesensitizationRulesGet(ctx context.Context, ids []string) (res []*DesensitizationRule, err error) {
+	resp, err := req.SetContext(ctx).
+		SetBearerAuthToken(util.GetToken(ctx)).
+		SetBody(map[string][]string{"ids": ids}).
+		Post(settings.Instance.Services.DataView + "/api/internal/data-view/v1/desensitization-rule/ids")
+	if err != nil {
+		log.WithContext(ctx).Error("DesensitizationRulesGet", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	if resp.StatusCode != 200 {
+		log.WithContext(ctx).Error("DesensitizationRulesGet", zap.Error(errors.New(resp.String())))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, resp.String())
+	}
+
+	rules := &DesensitizationRulesRes{}
+	err = resp.UnmarshalJson(rules)
+	if err != nil {
+		log.WithContext(ctx).Error("DesensitizationRulesGet", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	return rules.Data, nil
+}
+
 func (u *dataViewRepo) ParseViewSourceCatalogName(viewSourceCatalogName string) (catalogName, schemaName string) {
 	if viewSourceCatalogName == "" {
 		return
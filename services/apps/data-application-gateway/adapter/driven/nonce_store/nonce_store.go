@@ -0,0 +1,59 @@
+package nonce_store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository"
+)
+
+const keyPrefix = "data-application-gateway-nonce:"
+
+// NonceStore 记录已使用的随机串，用于防止签名请求被重放
+type NonceStore interface {
+	// Remember 记录随机串并在 ttl 后过期，随机串在有效期内已存在时返回 false
+	Remember(ctx context.Context, nonce string, ttl time.Duration) (fresh bool, err error)
+}
+
+func NewNonceStore(r *repository.Redis) NonceStore {
+	return &redisNonceStore{client: r.Client}
+}
+
+type redisNonceStore struct {
+	client redis.UniversalClient
+}
+
+func (s *redisNonceStore) Remember(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, keyPrefix+nonce, 1, ttl).Result()
+}
+
+// NewMemoryNonceStore 返回进程内的 NonceStore，用于测试或未部署 redis 的场景
+func NewMemoryNonceStore() NonceStore {
+	return &memoryNonceStore{expireAt: make(map[string]time.Time)}
+}
+
+type memoryNonceStore struct {
+	mu       sync.Mutex
+	expireAt map[string]time.Time
+}
+
+func (s *memoryNonceStore) Remember(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	// 顺带清理已过期的随机串
+	for k, t := range s.expireAt {
+		if !now.Before(t) {
+			delete(s.expireAt, k)
+		}
+	}
+	if _, ok := s.expireAt[nonce]; ok {
+		return false, nil
+	}
+	s.expireAt[nonce] = now.Add(ttl)
+	return true, nil
+}
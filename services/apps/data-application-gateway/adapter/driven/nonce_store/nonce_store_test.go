@@ -0,0 +1,34 @@
+package nonce_store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryNonceStore()
+
+	fresh, err := s.Remember(ctx, "app:nonce", 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, fresh)
+
+	// 有效期内重复使用
+	fresh, err = s.Remember(ctx, "app:nonce", 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.False(t, fresh)
+
+	// 其他随机串不受影响
+	fresh, err = s.Remember(ctx, "app:other", 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, fresh)
+
+	// 过期后可再次使用
+	time.Sleep(60 * time.Millisecond)
+	fresh, err = s.Remember(ctx, "app:nonce", 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, fresh)
+}
@@ -0,0 +1,149 @@
+package rate_limiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository"
+)
+
+const keyPrefix = "data-application-gateway-rate-limit:"
+
+// Result 限流检查结果
+type Result struct {
+	// 是否允许本次调用
+	Allowed bool
+	// 剩余额度：令牌桶剩余令牌数、当日剩余配额或剩余并发数
+	Remaining int64
+	// 被拒绝时建议的重试等待时间
+	RetryAfter time.Duration
+}
+
+type RateLimiterRepo interface {
+	// TokenBucket 令牌桶限流，每次调用消耗一个令牌
+	TokenBucket(ctx context.Context, key string, rate, burst int) (*Result, error)
+	// DailyQuota 每日调用配额，每次调用消耗一次配额，零点重置
+	DailyQuota(ctx context.Context, key string, quota int64) (*Result, error)
+	// AcquireConcurrency 占用一个并发名额，ttl 用于实例异常退出时自动释放
+	AcquireConcurrency(ctx context.Context, key string, max int, ttl time.Duration) (*Result, error)
+	// ReleaseConcurrency 释放一个并发名额
+	ReleaseConcurrency(ctx context.Context, key string) error
+}
+
+func NewRateLimiterRepo(r *repository.Redis) RateLimiterRepo {
+	return &rateLimiterRepo{client: r.Client}
+}
+
+type rateLimiterRepo struct {
+	client redis.UniversalClient
+}
+
+// tokenBucketScript 令牌桶，令牌数和上次填充时间保存在 hash 中
+//
+//	KEYS[1] 令牌桶 key
+//	ARGV[1] 速率 个/秒
+//	ARGV[2] 容量
+//	ARGV[3] 当前时间 毫秒
+//
+// 返回 {是否允许, 剩余令牌数, 重试等待毫秒数}
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, math.floor(tokens), retry}
+`)
+
+// counterScript 带上限的计数器，超过上限时回退
+//
+//	KEYS[1] 计数器 key
+//	ARGV[1] 上限
+//	ARGV[2] 过期时间 秒，只在创建计数器时设置，之后的计数不延长过期时间
+//
+// 返回 {是否允许, 剩余额度}
+var counterScript = redis.NewScript(`
+local max = tonumber(ARGV[1])
+local current = redis.call('INCR', KEYS[1])
+if current == 1 or redis.call('TTL', KEYS[1]) == -1 then
+  redis.call('EXPIRE', KEYS[1], tonumber(ARGV[2]))
+end
+if current > max then
+  redis.call('DECR', KEYS[1])
+  return {0, 0}
+end
+return {1, max - current}
+`)
+
+func (r *rateLimiterRepo) TokenBucket(ctx context.Context, key string, rate, burst int) (*Result, error) {
+	if burst < rate {
+		burst = rate
+	}
+	values, err := tokenBucketScript.Run(ctx, r.client, []string{keyPrefix + "bucket:" + key}, rate, burst, time.Now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Allowed:    values[0] == 1,
+		Remaining:  values[1],
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+func (r *rateLimiterRepo) DailyQuota(ctx context.Context, key string, quota int64) (*Result, error) {
+	now := time.Now()
+	// 到次日零点的时间，配额在零点重置
+	untilReset := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Sub(now)
+	values, err := counterScript.Run(ctx, r.client, []string{keyPrefix + "quota:" + now.Format("20060102") + ":" + key}, quota, int64(untilReset/time.Second)+60).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Allowed: values[0] == 1, Remaining: values[1]}
+	if !res.Allowed {
+		res.RetryAfter = untilReset
+	}
+	return res, nil
+}
+
+func (r *rateLimiterRepo) AcquireConcurrency(ctx context.Context, key string, max int, ttl time.Duration) (*Result, error) {
+	values, err := counterScript.Run(ctx, r.client, []string{keyPrefix + "concurrency:" + key}, max, int64(ttl/time.Second)).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Allowed: values[0] == 1, Remaining: values[1]}
+	if !res.Allowed {
+		res.RetryAfter = time.Second
+	}
+	return res, nil
+}
+
+// releaseConcurrencyScript 释放并发名额，计数不小于 0
+var releaseConcurrencyScript = redis.NewScript(`
+local current = redis.call('DECR', KEYS[1])
+if current < 0 then
+  redis.call('SET', KEYS[1], 0, 'KEEPTTL')
+end
+return current
+`)
+
+func (r *rateLimiterRepo) ReleaseConcurrency(ctx context.Context, key string) error {
+	return releaseConcurrencyScript.Run(ctx, r.client, []string{keyPrefix + "concurrency:" + key}).Err()
+}
@@ -0,0 +1,58 @@
+package result_cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository"
+)
+
+const (
+	keyPrefix = "data-application-gateway-cache:"
+	// GenerationKeyPrefix 接口缓存代数的 key 前缀，data-application-service 在接口或逻辑视图重新发布时递增代数使缓存失效
+	GenerationKeyPrefix = "data-application-gateway-cache-gen:"
+)
+
+// ResultCacheRepo 接口生成查询结果缓存
+type ResultCacheRepo interface {
+	// Get 读取缓存，不存在时返回 false
+	Get(ctx context.Context, key string) (val []byte, ok bool, err error)
+	// Set 写入缓存并在 ttl 后过期
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+	// Generation 接口当前的缓存代数，代数变化后旧缓存不再命中
+	Generation(ctx context.Context, serviceID string) (int64, error)
+}
+
+func NewResultCacheRepo(r *repository.Redis) ResultCacheRepo {
+	return &resultCacheRepo{client: r.Client}
+}
+
+type resultCacheRepo struct {
+	client redis.UniversalClient
+}
+
+func (r *resultCacheRepo) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	val, err := r.client.Get(ctx, keyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return val, true, nil
+}
+
+func (r *resultCacheRepo) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, keyPrefix+key, val, ttl).Err()
+}
+
+func (r *resultCacheRepo) Generation(ctx context.Context, serviceID string) (int64, error) {
+	gen, err := r.client.Get(ctx, GenerationKeyPrefix+serviceID).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return gen, err
+}
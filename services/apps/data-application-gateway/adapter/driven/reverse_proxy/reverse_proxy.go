@@ -70,9 +70,15 @@ func (r reverseProxyRepo) Serve(ctx context.Context, params map[string]*dto.Para
 
 	url := backendServiceHost + backendServicePath
 	resp, err := request.Send(strings.ToUpper(HTTPMethod), url)
+	// 连接失败、超时和服务端错误返回 BackendUnavailable，由调用方计入熔断并决定是否重试
 	if err != nil {
 		log.WithContext(ctx).Error("Serve", zap.Error(err))
-		return 0, nil, errorcode.Detail(errorcode.QueryError, err.Error())
+		return 0, nil, errorcode.Detail(errorcode.BackendUnavailable, err.Error())
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		log.WithContext(ctx).Error("Serve", zap.Int("status", resp.StatusCode), zap.String("body", resp.String()))
+		return 0, nil, errorcode.Detail(errorcode.BackendUnavailable, resp.String())
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -0,0 +1,199 @@
+package virtual_engine
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2/ar_trace"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	goframetrace "github.com/kweaver-ai/idrm-go-frame/core/telemetry/trace"
+)
+
+// streamClient 流式查询不设置整体超时，避免大结果集在传输过程中被中断
+var streamClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// RowIterator 逐行读取查询结果，不在内存中缓存整个结果集
+type RowIterator interface {
+	// Columns 结果集的列
+	Columns() []Column
+	// Next 返回下一行经过结果过滤后的数据，列顺序与 Columns 一致，读取完毕时返回 io.EOF
+	Next() ([]interface{}, error)
+	Close() error
+}
+
+// FetchStream 执行查询并返回逐行读取的迭代器。timeout 仅限制等待虚拟化引擎开始返回结果的时间。
+func (v *virtualEngineRepo) FetchStream(ctx context.Context, script string, timeout uint32, serviceResponseFilters []model.ServiceResponseFilter) (it RowIterator, err error) {
+	ctx, span := ar_trace.Tracer.Start(ctx, "virtualEngine", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { goframetrace.TelemetrySpanEnd(span, err) }()
+
+	jsonBody, err := json.Marshal(struct {
+		SQL  string `json:"sql"`
+		TYPE int    `json:"type"`
+	}{
+		SQL:  script,
+		TYPE: 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, settings.Instance.Services.VirtualEngine+"/api/data-connection/v1/gateway/fetch", strings.NewReader(string(jsonBody)))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+util.GetToken(ctx))
+
+	// 超时前未收到响应头则取消请求，读取结果的过程不受 timeout 限制，由 ctx 控制
+	ctx, cancel := context.WithCancel(ctx)
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(time.Duration(timeout)*time.Second, cancel)
+	}
+	response, err := streamClient.Do(httpReq.WithContext(ctx))
+	if timer != nil && !timer.Stop() && err == nil {
+		// 超时与响应同时发生，请求已被取消
+		response.Body.Close()
+		err = context.DeadlineExceeded
+	}
+	if err != nil {
+		cancel()
+		log.WithContext(ctx).Error("FetchStream", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.QueryError, err.Error())
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer cancel()
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		log.WithContext(ctx).Error("FetchStream", zap.ByteString("body", body), zap.String("script", script))
+		fetchError := &FetchError{}
+		if err := json.Unmarshal(body, fetchError); err != nil {
+			return nil, err
+		}
+		var e string
+		if fetchError.Solution != "" {
+			e = fetchError.Solution
+		} else if fetchError.Detail != "" {
+			e = fetchError.Detail
+		}
+		return nil, errorcode.Detail(errorcode.QueryError, e)
+	}
+
+	var filters = make(map[string]model.ServiceResponseFilter)
+	for _, filter := range serviceResponseFilters {
+		filters[filter.Param] = filter
+	}
+	r := &rowIterator{repo: v, body: response.Body, cancel: cancel, filters: filters}
+	r.decoder = json.NewDecoder(response.Body)
+	r.decoder.UseNumber()
+	if err = r.seekData(); err != nil {
+		r.Close()
+		log.WithContext(ctx).Error("FetchStream decode response fail", zap.Error(err))
+		return nil, err
+	}
+	return r, nil
+}
+
+type rowIterator struct {
+	repo    *virtualEngineRepo
+	body    io.ReadCloser
+	cancel  context.CancelFunc
+	decoder *json.Decoder
+	filters map[string]model.ServiceResponseFilter
+	columns []Column
+	// 已读完 data 数组
+	done bool
+}
+
+// seekData 读取 columns，并定位到 data 数组的第一个元素。虚拟化引擎的响应中 columns 在 data 之前。
+func (r *rowIterator) seekData() error {
+	if err := r.expectDelim('{'); err != nil {
+		return err
+	}
+	for r.decoder.More() {
+		t, err := r.decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case "columns":
+			if err := r.decoder.Decode(&r.columns); err != nil {
+				return err
+			}
+		case "data":
+			if r.columns == nil {
+				return fmt.Errorf("virtual engine response: data before columns")
+			}
+			return r.expectDelim('[')
+		default:
+			var skip json.RawMessage
+			if err := r.decoder.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	// 没有 data 字段，结果为空
+	r.done = true
+	return nil
+}
+
+func (r *rowIterator) expectDelim(delim json.Delim) error {
+	t, err := r.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("virtual engine response: expect %v, got %v", delim, t)
+	}
+	return nil
+}
+
+func (r *rowIterator) Columns() []Column {
+	return r.columns
+}
+
+func (r *rowIterator) Next() ([]interface{}, error) {
+	for !r.done && r.decoder.More() {
+		var datum []interface{}
+		if err := r.decoder.Decode(&datum); err != nil {
+			return nil, err
+		}
+		columns := make([]Column, len(r.columns))
+		for i, column := range r.columns {
+			columns[i] = Column{Name: column.Name, Type: column.Type}
+			if i < len(datum) {
+				columns[i].Value = datum[i]
+			}
+		}
+		if !r.repo.fetchResFilter(columns, r.filters) {
+			continue
+		}
+		return datum, nil
+	}
+	r.done = true
+	return nil, io.EOF
+}
+
+func (r *rowIterator) Close() error {
+	defer r.cancel()
+	return r.body.Close()
+}
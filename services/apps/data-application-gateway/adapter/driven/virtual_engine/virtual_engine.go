@@ -36,6 +36,10 @@ type FetchRawResTransform struct {
 type FetchRes struct {
 	TotalCount int                      `json:"total_count"`
 	Data       []map[string]interface{} `json:"data"`
+	// total_count 的准确性 exact 准确 estimated 估计 omitted 未查询
+	TotalCountType string `json:"total_count_type,omitempty"`
+	// 下一页的分页游标，没有下一页时为空
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type Column struct {
@@ -58,6 +62,8 @@ type T struct {
 type VirtualEngineRepo interface {
 	Fetch(ctx context.Context, script string, timeout uint32, serviceResponseFilters []model.ServiceResponseFilter) (fetchRes *FetchRes, err error)
 	FetchCount(ctx context.Context, script string, timeout uint32) (totalCount int64, err error)
+	// FetchStream 逐行读取查询结果，用于流式返回大结果集
+	FetchStream(ctx context.Context, script string, timeout uint32, serviceResponseFilters []model.ServiceResponseFilter) (it RowIterator, err error)
 }
 
 func NewVirtualEngineRepo() VirtualEngineRepo {
@@ -2,6 +2,7 @@ package driver
 
 import (
 	"github.com/google/wire"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver/v1/circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver/v1/query"
 	"github.com/kweaver-ai/idrm-go-common"
 	"github.com/kweaver-ai/idrm-go-common/audit"
@@ -14,6 +15,7 @@ var HttpProviderSet = wire.NewSet(NewHttpServer)
 
 var ProviderSet = wire.NewSet(
 	query.NewQueryController,
+	circuit_breaker.NewCircuitBreakerController,
 	httpclient.NewMiddlewareHTTPClient,
 	GoCommon.Middleware,
 	audit.Discard,
@@ -7,6 +7,7 @@ import (
 	"github.com/google/wire"
 	"go.uber.org/zap"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver/v1/circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver/v1/query"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
 	"github.com/kweaver-ai/idrm-go-common/middleware"
@@ -76,8 +77,9 @@ type IRouter interface {
 }
 
 type Router struct {
-	Middleware      middleware.Middleware
-	QueryController *query.QueryController
+	Middleware               middleware.Middleware
+	QueryController          *query.QueryController
+	CircuitBreakerController *circuit_breaker.CircuitBreakerController
 }
 
 func (r *Router) Register(s *settings.Settings, engine *gin.Engine) error {
@@ -97,4 +99,7 @@ func (r *Router) RegisterApi(s *settings.Settings, engine *gin.Engine) {
 	engine.Any("/data-application-gateway/*service_path", r.Middleware.ShouldTokenInterception(), r.QueryController.Query)
 	//数据查询测试
 	engine.Any("/api/data-application-gateway/v1/query-test", r.Middleware.TokenInterception(), r.QueryController.QueryTest)
+	//熔断器状态
+	engine.GET("/api/data-application-gateway/v1/circuit-breakers", r.Middleware.TokenInterception(), r.CircuitBreakerController.List)
+	engine.GET("/api/data-application-gateway/v1/circuit-breakers/:service_id", r.Middleware.TokenInterception(), r.CircuitBreakerController.Get)
 }
@@ -0,0 +1,53 @@
+package circuit_breaker
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/circuit_breaker"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest/ginx"
+)
+
+type CircuitBreakerController struct {
+	circuitBreakerRepo circuit_breaker.CircuitBreakerRepo
+}
+
+func NewCircuitBreakerController(circuitBreakerRepo circuit_breaker.CircuitBreakerRepo) *CircuitBreakerController {
+	return &CircuitBreakerController{circuitBreakerRepo: circuitBreakerRepo}
+}
+
+// ListRes 熔断器状态列表
+type ListRes struct {
+	Entries []*circuit_breaker.Snapshot `json:"entries"`
+}
+
+// List 本网关实例的熔断器状态
+//
+//	@Summary	熔断器状态列表
+//	@Tags		熔断
+//	@Produce	json
+//	@Success	200	{object}	ListRes
+//	@Router		/api/data-application-gateway/v1/circuit-breakers [get]
+func (s *CircuitBreakerController) List(c *gin.Context) {
+	entries := s.circuitBreakerRepo.Snapshots()
+	if entries == nil {
+		entries = []*circuit_breaker.Snapshot{}
+	}
+	ginx.ResOKJson(c, &ListRes{Entries: entries})
+}
+
+// Get 本网关实例中接口的熔断器状态，接口没有调用过后端服务时为关闭状态
+//
+//	@Summary	接口熔断器状态
+//	@Tags		熔断
+//	@Produce	json
+//	@Param		service_id	path		string	true	"接口ID"
+//	@Success	200			{object}	circuit_breaker.Snapshot
+//	@Router		/api/data-application-gateway/v1/circuit-breakers/{service_id} [get]
+func (s *CircuitBreakerController) Get(c *gin.Context) {
+	serviceID := c.Param("service_id")
+	snapshot := s.circuitBreakerRepo.Snapshot(serviceID)
+	if snapshot == nil {
+		snapshot = &circuit_breaker.Snapshot{ServiceID: serviceID, State: circuit_breaker.StateClosed}
+	}
+	ginx.ResOKJson(c, snapshot)
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +21,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/form_validator"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/util"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/domain"
+	"github.com/kweaver-ai/idrm-go-frame/core/errorx/agerrors"
 	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
 	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest/ginx"
 )
@@ -56,7 +60,9 @@ func (s *QueryController) Query(c *gin.Context) {
 
 	log.WithContext(c).Info("Query")
 	req := &dto.QueryReq{
-		Params: make(map[string]*dto.Param),
+		Params:  make(map[string]*dto.Param),
+		Format:  resultFormat(c.GetHeader("Accept")),
+		Version: c.GetHeader(dto.ServiceVersionHeader),
 	}
 
 	_, err = form_validator.BindUriAndValid(c, req)
@@ -65,13 +71,13 @@ func (s *QueryController) Query(c *gin.Context) {
 		if errors.As(err, &form_validator.ValidErrors{}) {
 			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
 			// 记录失败的调用
-			s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, 0, err.Error(), cssjj)
+			s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, err)
 			return
 		}
 
 		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
 		// 记录失败的调用
-		s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, 0, "请求参数错误", cssjj)
+		s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, errorcode.Desc(errorcode.PublicRequestParameterError))
 		return
 	}
 
@@ -91,7 +97,7 @@ func (s *QueryController) Query(c *gin.Context) {
 		c.Writer.WriteHeader(http.StatusBadRequest)
 		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
 		// 记录失败的调用
-		s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, 0, err.Error(), cssjj)
+		s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, err)
 		return
 	}
 
@@ -105,7 +111,7 @@ func (s *QueryController) Query(c *gin.Context) {
 			c.Writer.WriteHeader(http.StatusBadRequest)
 			ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
 			// 记录失败的调用
-			s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, 0, err.Error(), cssjj)
+			s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, err)
 			return
 		}
 		for k, v := range body {
@@ -121,24 +127,41 @@ func (s *QueryController) Query(c *gin.Context) {
 	}
 
 	length, res, err := s.domain.Query(c, req, cssjj)
+	setRateLimitHeaders(c, req.RateLimit)
+	setVersionHeaders(c, req.VersionInfo)
+	if req.CacheStatus != "" {
+		c.Header("X-Cache", req.CacheStatus)
+	}
 	if err != nil {
+		if errorcode.IsErrorCode(err) && domain.IsRateLimitError(agerrors.Code(err).GetErrorCode()) {
+			ginx.ResErrJsonWithCode(c, http.StatusTooManyRequests, err)
+			// 记录失败的调用
+			s.recordServiceCall(c, req, callStartTime, http.StatusTooManyRequests, err)
+			return
+		}
+		if errorcode.IsErrorCode(err) && domain.IsCircuitOpenError(agerrors.Code(err).GetErrorCode()) {
+			if seconds, ok := domain.CircuitRetryAfter(err); ok {
+				c.Header("Retry-After", strconv.FormatInt(seconds, 10))
+			}
+			ginx.ResErrJsonWithCode(c, http.StatusServiceUnavailable, err)
+			// 记录失败的调用
+			s.recordServiceCall(c, req, callStartTime, http.StatusServiceUnavailable, err)
+			return
+		}
+
 		c.Writer.WriteHeader(http.StatusBadRequest)
 		if errors.As(err, &form_validator.ValidErrors{}) {
 			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
 			// 记录失败的调用
-			s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, 0, err.Error(), cssjj)
+			s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, err)
 			return
 		}
 
 		ginx.ResErrJson(c, err)
 		// 记录失败的调用
-		s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, 0, err.Error(), cssjj)
+		s.recordServiceCall(c, req, callStartTime, http.StatusBadRequest, err)
 		return
 	}
-	defer res.Close()
-
-	// 记录成功的调用
-	s.recordServiceCall(c, req, callStartTime, http.StatusOK, 1, "", cssjj)
 
 	for _, k := range []string{"x-tif-signature", "x-tif-timestamp", "x-tif-nonce"} {
 		if param, ok := req.Params[k]; ok && param.Position == dto.ParamPositionHeader {
@@ -148,7 +171,87 @@ func (s *QueryController) Query(c *gin.Context) {
 		}
 	}
 
-	c.DataFromReader(http.StatusOK, length, "application/json", res, nil)
+	contentType := "application/json"
+	switch req.Format {
+	case dto.ResultFormatNDJSON:
+		contentType = "application/x-ndjson"
+	case dto.ResultFormatCSV:
+		contentType = "text/csv; charset=utf-8"
+	}
+	// 流式返回时 length 为 -1，使用分块传输，下一页游标和中断原因在数据之后通过响应尾部返回
+	if req.Format.IsStream() {
+		c.Header("Trailer", dto.NextCursorTrailer+", "+dto.StreamErrorTrailer)
+	}
+	c.DataFromReader(http.StatusOK, length, contentType, res, nil)
+	res.Close()
+
+	// 响应头已经发送，流式返回中断时只能通过响应尾部告知调用方，调用记录按服务端错误记录
+	if req.StreamErr != nil {
+		c.Writer.Header().Set(dto.StreamErrorTrailer, req.StreamErr.Error())
+		s.recordServiceCall(c, req, callStartTime, http.StatusInternalServerError, req.StreamErr)
+		return
+	}
+	if req.NextCursor != "" {
+		c.Writer.Header().Set(dto.NextCursorTrailer, req.NextCursor)
+	}
+
+	// 结果写出后记录成功的调用，耗时和字节数包含返回数据的时间
+	s.recordServiceCall(c, req, callStartTime, http.StatusOK, nil)
+}
+
+// resultFormat 根据请求头 Accept 选择查询结果的返回格式，按出现顺序取第一个支持的格式
+func resultFormat(accept string) dto.ResultFormat {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/x-ndjson", "application/ndjson", "application/jsonl":
+			return dto.ResultFormatNDJSON
+		case "text/csv":
+			return dto.ResultFormatCSV
+		case "application/json", "*/*":
+			return dto.ResultFormatJSON
+		}
+	}
+	return dto.ResultFormatJSON
+}
+
+// setRateLimitHeaders 在响应头中返回限流和配额状态
+func setRateLimitHeaders(c *gin.Context, info *dto.RateLimitInfo) {
+	if info == nil {
+		return
+	}
+	if info.Limit > 0 {
+		c.Header("X-RateLimit-Limit", strconv.Itoa(info.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(info.Remaining, 10))
+	}
+	if info.QuotaLimit > 0 {
+		c.Header("X-Quota-Limit", strconv.FormatInt(info.QuotaLimit, 10))
+		c.Header("X-Quota-Remaining", strconv.FormatInt(info.QuotaRemaining, 10))
+	}
+	if info.RetryAfter > 0 {
+		// Retry-After 以秒为单位，向上取整
+		c.Header("Retry-After", strconv.FormatInt(int64((info.RetryAfter+time.Second-1)/time.Second), 10))
+	}
+}
+
+// setVersionHeaders 返回实际调用的接口版本。废弃的版本按 RFC 9745 返回 Deprecation，
+// 设置了计划下线时间时按 RFC 8594 返回 Sunset，提示调用方迁移到新版本
+func setVersionHeaders(c *gin.Context, info *dto.ServiceVersionInfo) {
+	if info == nil {
+		return
+	}
+	if info.Version != "" {
+		c.Header(dto.ServiceVersionHeader, info.Version)
+	}
+	if !info.DeprecatedAt.IsZero() {
+		c.Header("Deprecation", "@"+strconv.FormatInt(info.DeprecatedAt.Unix(), 10))
+	}
+	if !info.SunsetAt.IsZero() {
+		c.Header("Sunset", info.SunsetAt.UTC().Format(http.TimeFormat))
+	}
 }
 
 // QueryTest 数据查询测试接口
@@ -308,63 +411,36 @@ func (s *QueryController) queryTestReqCheck(req *dto.QueryTestReq) (err error) {
 	return validErrors
 }
 
-// recordServiceCall 记录服务调用信息
-func (s *QueryController) recordServiceCall(c *gin.Context, req *dto.QueryReq, callStartTime time.Time, httpCode int, callStatus int, errorMessage, cssjj string) {
-	// xx环境由里约网关记录
-	if cssjj == "true" {
-		return
-	}
-	// 异步记录，避免影响主流程性能
-	go func() {
-		callEndTime := time.Now()
-
-		// // 从请求中提取用户信息
-		// userIdentification := c.GetHeader("User-Identification")
-		// if userIdentification == "" {
-		// 	userIdentification = c.GetHeader("X-User-ID")
-		// }
-
-		// // 从请求中提取部门信息
-		// callDepartmentID := c.GetHeader("Call-Department-ID")
-		// if callDepartmentID == "" {
-		// 	callDepartmentID = c.GetHeader("X-Department-ID")
-		// }
-
-		// // 从请求中提取系统信息
-		// callInfoSystemID := c.GetHeader("Call-Info-System-ID")
-		// if callInfoSystemID == "" {
-		// 	callInfoSystemID = c.GetHeader("X-System-ID")
-		// }
-
-		// // 从请求中提取应用信息
-		// callAppID := c.GetHeader("Call-App-ID")
-		// if callAppID == "" {
-		// 	callAppID = c.GetHeader("X-App-ID")
-		// }
-
-		// 构建记录请求
-		recordReq := &domain.RecordServiceCallReq{
-			ServiceID:           req.ServicePath,
-			ServiceDepartmentID: "", // 需要从服务信息中获取
-			ServiceSystemID:     "", // 需要从服务信息中获取
-			ServiceAppID:        "", // 需要从服务信息中获取
-			RemoteAddress:       c.RemoteIP(),
-			ForwardFor:          c.GetHeader("X-Forwarded-For"),
-			UserIdentification:  "", // 需要从外部接口中获取
-			CallDepartmentID:    "", // 需要从外部接口中获取
-			CallInfoSystemID:    "", // 需要从外部接口中获取
-			CallAppID:           "", // 需要从外部接口中获取
-			CallStartTime:       callStartTime,
-			CallEndTime:         &callEndTime,
-			CallHTTPCode:        &httpCode,
-			CallStatus:          callStatus,
-			ErrorMessage:        errorMessage,
-			CallOtherMessage:    "", // 可以记录其他相关信息
-		}
+// recordServiceCall 记录服务调用信息，err 为空表示调用成功。调用记录异步批量写入，不阻塞请求。
+func (s *QueryController) recordServiceCall(c *gin.Context, req *dto.QueryReq, callStartTime time.Time, httpCode int, err error) {
+	callEndTime := time.Now()
 
-		// 记录服务调用
-		if err := s.serviceCallRecordDomain.RecordServiceCall(c, recordReq); err != nil {
-			log.WithContext(c).Error("记录服务调用失败", zap.Error(err))
+	callStatus := 1
+	var errorMessage, errorCode string
+	if err != nil {
+		callStatus = 0
+		errorMessage = err.Error()
+		if errorcode.IsErrorCode(err) {
+			errorCode = agerrors.Code(err).GetErrorCode()
 		}
-	}()
+	}
+	var responseBytes int64
+	if size := c.Writer.Size(); size > 0 {
+		responseBytes = int64(size)
+	}
+
+	s.serviceCallRecordDomain.RecordServiceCall(c, &domain.RecordServiceCallReq{
+		ServiceID:     req.ServicePath,
+		RemoteAddress: c.RemoteIP(),
+		ForwardFor:    c.GetHeader("X-Forwarded-For"),
+		CallAppID:     req.CallAppID,
+		CallStartTime: callStartTime,
+		CallEndTime:   &callEndTime,
+		CallHTTPCode:  &httpCode,
+		CallStatus:    callStatus,
+		ErrorMessage:  errorMessage,
+		RowsReturned:  atomic.LoadInt64(&req.RowsReturned),
+		ResponseBytes: responseBytes,
+		ErrorCode:     errorCode,
+	})
 }
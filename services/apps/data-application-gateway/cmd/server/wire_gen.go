@@ -15,12 +15,18 @@ import (
 	"github.com/kweaver-ai/idrm-go-common/rest/hydra/impl"
 	"github.com/kweaver-ai/idrm-go-common/trace"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/call_record_spill"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/gorm"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/mdl-uniquery"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/nonce_store"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/result_cache"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/rate_limiter"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/reverse_proxy"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/virtual_engine"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver"
+	circuit_breaker2 "github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver/v1/circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driver/v1/query"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/util"
@@ -64,13 +70,20 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 	applicationService := driven.NewConfigurationCenterApplicationService(client)
 	dataApplicationServiceRepo := gorm.NewDataApplicationServiceRepo(data)
 	drivenMDLUniQuery := mdl_uniquery.NewMDLUniQuery()
-	queryDomain := domain.NewQueryDomain(appRepo, serviceRepo, serviceApplyRepo, configurationRepo, virtualEngineRepo, reverseProxyRepo, redis, dataViewRepo, configurationCenterRepo, authServiceRepo, data_viewDriven, labelService, applicationService, dataApplicationServiceRepo, drivenMDLUniQuery)
+	rateLimiterRepo := rate_limiter.NewRateLimiterRepo(redis)
+	nonceStore := nonce_store.NewNonceStore(redis)
+	resultCacheRepo := result_cache.NewResultCacheRepo(redis)
+	circuitBreakerRepo := circuit_breaker.NewCircuitBreakerRepo(redis)
+	queryDomain := domain.NewQueryDomain(appRepo, serviceRepo, serviceApplyRepo, configurationRepo, virtualEngineRepo, reverseProxyRepo, redis, dataViewRepo, configurationCenterRepo, authServiceRepo, data_viewDriven, labelService, applicationService, dataApplicationServiceRepo, drivenMDLUniQuery, rateLimiterRepo, nonceStore, resultCacheRepo, circuitBreakerRepo)
 	serviceCallRecordRepo := gorm.NewServiceCallRecordRepo(data)
-	serviceCallRecordDomain := domain.NewServiceCallRecordDomain(serviceCallRecordRepo, serviceRepo, configurationCenterRepo, dataApplicationServiceRepo)
+	callRecordSpill := call_record_spill.NewCallRecordSpill()
+	serviceCallRecordDomain, cleanup2 := domain.NewServiceCallRecordDomain(serviceCallRecordRepo, serviceRepo, configurationCenterRepo, dataApplicationServiceRepo, callRecordSpill)
 	queryController := query.NewQueryController(queryDomain, serviceCallRecordDomain, configurationRepo)
+	circuitBreakerController := circuit_breaker2.NewCircuitBreakerController(circuitBreakerRepo)
 	router := &driver.Router{
-		Middleware:      middleware,
-		QueryController: queryController,
+		Middleware:               middleware,
+		QueryController:          queryController,
+		CircuitBreakerController: circuitBreakerController,
 	}
 	server := driver.NewHttpServer(s, router)
 	app := newApp(server)
@@ -78,6 +91,7 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 		App: app,
 	}
 	return appRunner, func() {
+		cleanup2()
 		cleanup()
 	}, nil
 }
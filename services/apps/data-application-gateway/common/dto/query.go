@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type ParamPosition string
 
 const (
@@ -23,11 +25,91 @@ const (
 const (
 	Offset = "offset"
 	Limit  = "limit"
+	// Cursor 分页游标，由上一页结果中的 next_cursor 返回，传入后忽略 offset
+	Cursor = "cursor"
+	// WithTotal 是否返回总数，为 false 时不执行计数查询
+	WithTotal = "with_total"
+)
+
+// TotalCountType 查询结果中 total_count 的准确性
+type TotalCountType string
+
+const (
+	// TotalCountExact 本次查询得到的准确总数
+	TotalCountExact TotalCountType = "exact"
+	// TotalCountEstimated 缓存的总数，可能与当前数据不一致
+	TotalCountEstimated TotalCountType = "estimated"
+	// TotalCountOmitted 未查询总数，total_count 为 -1
+	TotalCountOmitted TotalCountType = "omitted"
 )
 
+// ResultFormat 查询结果的返回格式，由请求头 Accept 决定
+type ResultFormat string
+
+const (
+	ResultFormatJSON   ResultFormat = "json"
+	ResultFormatNDJSON ResultFormat = "ndjson"
+	ResultFormatCSV    ResultFormat = "csv"
+)
+
+// IsStream 是否逐行流式返回
+func (f ResultFormat) IsStream() bool {
+	return f == ResultFormatNDJSON || f == ResultFormatCSV
+}
+
 type QueryReq struct {
 	ServicePath string `json:"service_path" uri:"service_path" binding:"required,URL"`
 	Params      map[string]*Param
+	// 限流与配额状态，由查询过程填充，用于设置响应头
+	RateLimit *RateLimitInfo `json:"-"`
+	// 结果缓存状态 HIT 命中 MISS 未命中，未开启缓存时为空
+	CacheStatus string `json:"-"`
+	// 查询结果的返回格式
+	Format ResultFormat `json:"-"`
+	// 返回的数据行数，由查询过程填充，用于调用记录。流式返回时在写出过程中累加
+	RowsReturned int64 `json:"-"`
+	// 流式返回的下一页游标，关闭查询结果后可用，通过响应尾部 X-Next-Cursor 返回
+	NextCursor string `json:"-"`
+	// 流式返回过程中断的原因，关闭查询结果后可用，通过响应尾部 X-Stream-Error 返回
+	StreamErr error `json:"-"`
+	// 请求头 X-Service-Version 指定的接口版本，为空时调用接口路径对应的版本
+	Version string `json:"-"`
+	// 实际调用的接口版本，由查询过程填充，用于设置响应头
+	VersionInfo *ServiceVersionInfo `json:"-"`
+	// 签名鉴权时调用的应用 ID，由查询过程填充，用于调用记录
+	CallAppID string `json:"-"`
+}
+
+// 流式返回时已经发送了响应头，下一页游标和中断原因通过响应尾部返回
+const (
+	NextCursorTrailer  = "X-Next-Cursor"
+	StreamErrorTrailer = "X-Stream-Error"
+)
+
+// ServiceVersionHeader 调用方通过该请求头在同一版本组内选择接口版本
+const ServiceVersionHeader = "X-Service-Version"
+
+// ServiceVersionInfo 接口版本与废弃状态
+type ServiceVersionInfo struct {
+	Version string
+	// 废弃时间，零值表示未废弃
+	DeprecatedAt time.Time
+	// 计划下线时间，零值表示未设置
+	SunsetAt time.Time
+}
+
+// RateLimitInfo 限流与配额状态
+type RateLimitInfo struct {
+	Limit          int           // 每秒请求数上限，0 表示不限制
+	Remaining      int64         // 令牌桶剩余令牌数
+	QuotaLimit     int64         // 每日调用配额，0 表示不限制
+	QuotaRemaining int64         // 当日剩余配额
+	RetryAfter     time.Duration // 被限流时建议的重试等待时间
+}
+
+// CircuitOpenDetail 熔断错误的详情
+type CircuitOpenDetail struct {
+	RetryAfter int64 `json:"retry_after"` // 熔断恢复前建议的重试等待秒数
 }
 
 type Param struct {
@@ -0,0 +1,38 @@
+package dto
+
+// TransformRules 接口注册类接口的请求与返回结果转换规则，由 data-application-service 配置
+type TransformRules struct {
+	Request  *RequestTransform  `json:"request,omitempty"`
+	Response *ResponseTransform `json:"response,omitempty"`
+}
+
+// RequestTransform 请求转换规则，按转发到后台服务的参数位置分别配置
+type RequestTransform struct {
+	Headers []ParamMapping `json:"headers,omitempty"`
+	Query   []ParamMapping `json:"query,omitempty"`
+	// 请求体，From、To 支持以 . 分隔的嵌套字段路径
+	Body []ParamMapping `json:"body,omitempty"`
+}
+
+// ParamMapping 参数映射，把调用方传入的参数 From 以 To 为参数名转发，From 为空时注入常量 Value
+type ParamMapping struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+}
+
+// ResponseTransform 返回结果转换规则
+type ResponseTransform struct {
+	// 记录所在位置，以 . 分隔的字段路径，为空表示整个返回结果
+	Root string `json:"root"`
+	// 为 true 时记录只保留 Fields 中配置的字段
+	Project bool           `json:"project"`
+	Fields  []FieldMapping `json:"fields,omitempty"`
+}
+
+// FieldMapping 返回字段映射，To 为空时不重命名，DesensitizationRuleID 不为空时按数据视图的脱敏规则脱敏
+type FieldMapping struct {
+	From                  string `json:"from"`
+	To                    string `json:"to"`
+	DesensitizationRuleID string `json:"desensitization_rule_id"`
+}
@@ -1,20 +1,17 @@
 package errorcode
 
+import "github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+
 func init() {
 	registerErrorCode(authErrorMap)
 }
 
 const (
-	authModelName = "Auth"
-)
-
-const (
-	authPreCoder              = ServiceName + "." + authModelName + "."
-	TokenAuditFailed          = authPreCoder + "TokenAuditFailed"
-	UserNotActive             = authPreCoder + "UserNotActive"
-	GetUserInfoFailed         = authPreCoder + "GetUserInfoFailed"
-	GetUserInfoFailedInterior = authPreCoder + "GetUserInfoFailedInterior"
-	GetTokenEmpty             = authPreCoder + "GetTokenEmpty"
+	TokenAuditFailed          = gateway_errorcode.TokenAuditFailed
+	UserNotActive             = gateway_errorcode.UserNotActive
+	GetUserInfoFailed         = gateway_errorcode.GetUserInfoFailed
+	GetUserInfoFailedInterior = gateway_errorcode.GetUserInfoFailedInterior
+	GetTokenEmpty             = gateway_errorcode.GetTokenEmpty
 )
 
 var authErrorMap = errorCode{
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
 	"github.com/kweaver-ai/idrm-go-frame/core/errorx/agcodes"
 	"github.com/kweaver-ai/idrm-go-frame/core/errorx/agerrors"
 )
 
 // Model Name
 const (
-	ServiceName     = "DataApplicationGateway"
-	publicModelName = "Public"
+	ServiceName = gateway_errorcode.ServiceName
 )
 
 var Success = map[string]string{
@@ -22,12 +22,11 @@ var Success = map[string]string{
 
 // Public error
 const (
-	publicPreCoder              = ServiceName + "." + publicModelName + "."
-	PublicInternalError         = publicPreCoder + "InternalError"
-	PublicInvalidParameter      = publicPreCoder + "InvalidParameter"
-	PublicInvalidParameterJson  = publicPreCoder + "InvalidParameterJson"
-	PublicDatabaseError         = publicPreCoder + "DatabaseError"
-	PublicRequestParameterError = publicPreCoder + "RequestParameterError"
+	PublicInternalError         = gateway_errorcode.PublicInternalError
+	PublicInvalidParameter      = gateway_errorcode.PublicInvalidParameter
+	PublicInvalidParameterJson  = gateway_errorcode.PublicInvalidParameterJson
+	PublicDatabaseError         = gateway_errorcode.PublicDatabaseError
+	PublicRequestParameterError = gateway_errorcode.PublicRequestParameterError
 )
 
 var publicErrorMap = errorCode{
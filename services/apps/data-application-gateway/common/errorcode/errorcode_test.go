@@ -0,0 +1,26 @@
+package errorcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+)
+
+// 网关注册的错误码与共享的错误码定义一致，导出的接口文档才能列出全部错误码
+func TestRegisteredCodes(t *testing.T) {
+	shared := make(map[string]bool)
+	for _, code := range gateway_errorcode.Codes() {
+		shared[code] = true
+		// 令牌校验中间件返回的错误码不由网关注册
+		if code == gateway_errorcode.PublicAuthenticationFailure {
+			continue
+		}
+		_, ok := errorCodeMap[code]
+		assert.True(t, ok, "错误码没有注册: %s", code)
+	}
+	for code := range errorCodeMap {
+		assert.True(t, shared[code], "错误码没有加入共享的错误码定义: %s", code)
+	}
+}
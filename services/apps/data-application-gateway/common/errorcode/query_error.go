@@ -1,21 +1,33 @@
 package errorcode
 
+import "github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+
 func init() {
 	registerErrorCode(queryErrorMap)
 }
 
-const (
-	queryModelName = "Query"
-)
-
 // Demo error
 const (
-	queryPreCoder = ServiceName + "." + queryModelName + "."
-
-	QueryError     = queryPreCoder + "QueryError"
-	RateLimitError = queryPreCoder + "RateLimitError"
+	QueryError     = gateway_errorcode.QueryError
+	RateLimitError = gateway_errorcode.RateLimitError
+	// 超出每日调用配额
+	QuotaExceededError = gateway_errorcode.QuotaExceededError
+	// 超出最大并发查询数
+	ConcurrencyLimitError = gateway_errorcode.ConcurrencyLimitError
+	// 分页游标无效
+	InvalidCursor = gateway_errorcode.InvalidCursor
 	// 接口服务的后端返回不支持的 content-type
-	BackendUnsupportedContentType = queryPreCoder + "UnsupportedContentType"
+	BackendUnsupportedContentType = gateway_errorcode.BackendUnsupportedContentType
+	// 后端服务连接失败、超时或返回服务端错误
+	BackendUnavailable = gateway_errorcode.BackendUnavailable
+	// 后端服务熔断中
+	CircuitOpenError = gateway_errorcode.CircuitOpenError
+	// 后端服务返回结果转换失败
+	ResponseTransformError = gateway_errorcode.ResponseTransformError
+	// 属性策略计算失败
+	ObligationUnavailable = gateway_errorcode.ObligationUnavailable
+	// 属性策略无法在接口上执行
+	ObligationNotSupported = gateway_errorcode.ObligationNotSupported
 )
 
 var queryErrorMap = errorCode{
@@ -29,7 +41,47 @@ var queryErrorMap = errorCode{
 		cause:       "",
 		solution:    "请稍后再试",
 	},
+	QuotaExceededError: {
+		description: "超出每日调用配额",
+		cause:       "",
+		solution:    "请次日再试或联系管理员调整配额",
+	},
+	ConcurrencyLimitError: {
+		description: "超出最大并发查询数",
+		cause:       "",
+		solution:    "请稍后再试",
+	},
+	InvalidCursor: {
+		description: "分页游标无效",
+		cause:       "游标已损坏或与本次请求的查询参数不一致",
+		solution:    "请使用上一页返回的 next_cursor，或去掉 cursor 参数从第一页开始查询",
+	},
 	BackendUnsupportedContentType: {
 		description: "后端服务返回不支持的 Content-Type[%s]",
 	},
+	BackendUnavailable: {
+		description: "后端服务不可用",
+		cause:       "后端服务连接失败、超时或返回服务端错误",
+		solution:    "请稍后再试或联系接口服务的提供方",
+	},
+	CircuitOpenError: {
+		description: "后端服务熔断中",
+		cause:       "后端服务近期调用失败比例过高，暂停调用",
+		solution:    "请稍后再试",
+	},
+	ResponseTransformError: {
+		description: "后端服务返回结果转换失败",
+		cause:       "后端服务返回的结果不是 JSON 或与接口配置的转换规则不匹配",
+		solution:    "请检查接口的返回结果转换规则",
+	},
+	ObligationUnavailable: {
+		description: "属性策略计算失败",
+		cause:       "",
+		solution:    "请稍后再试或联系管理员",
+	},
+	ObligationNotSupported: {
+		description: "接口无法执行生效的属性策略",
+		cause:       "注册接口的结果不能脱敏、隐藏字段或过滤行，或行过滤条件不受支持",
+		solution:    "请联系管理员调整属性策略",
+	},
 }
@@ -1,19 +1,15 @@
 package errorcode
 
+import "github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+
 func init() {
 	registerErrorCode(serviceApplyErrorMap)
 }
 
 const (
-	serviceApplyModelName = "ServiceApply"
-)
-
-const (
-	serviceApplyPreCoder = ServiceName + "." + serviceApplyModelName + "."
-
-	ServiceApplyNotPass       = serviceApplyPreCoder + "ServiceApplyNotPass"
-	ServiceApplyNotPassCssjj  = serviceApplyPreCoder + "ServiceApplyNotPassCssjj"
-	ServiceStatusNotAvailable = serviceApplyPreCoder + "ServiceStatusNotAvailable"
+	ServiceApplyNotPass       = gateway_errorcode.ServiceApplyNotPass
+	ServiceApplyNotPassCssjj  = gateway_errorcode.ServiceApplyNotPassCssjj
+	ServiceStatusNotAvailable = gateway_errorcode.ServiceStatusNotAvailable
 )
 
 var serviceApplyErrorMap = errorCode{
@@ -1,22 +1,19 @@
 package errorcode
 
+import "github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+
 func init() {
 	registerErrorCode(signErrorMap)
 }
 
 const (
-	signModelName = "Sign"
-)
-
-const (
-	signPreCoder = ServiceName + "." + signModelName + "."
-
-	SignValidateError = signPreCoder + "SignValidateError"
-	TimestampRequired = signPreCoder + "TimestampRequired"
-	TimestampError    = signPreCoder + "TimestampError"
-	TimestampExpired  = signPreCoder + "TimestampExpired"
-	AppIdRequired     = signPreCoder + "AppIdRequired"
-	AppIdNotExist     = signPreCoder + "AppIdNotExist"
+	SignValidateError = gateway_errorcode.SignValidateError
+	TimestampRequired = gateway_errorcode.TimestampRequired
+	TimestampError    = gateway_errorcode.TimestampError
+	TimestampExpired  = gateway_errorcode.TimestampExpired
+	AppIdRequired     = gateway_errorcode.AppIdRequired
+	AppIdNotExist     = gateway_errorcode.AppIdNotExist
+	NonceReplayed     = gateway_errorcode.NonceReplayed
 )
 
 var signErrorMap = errorCode{
@@ -50,4 +47,9 @@ var signErrorMap = errorCode{
 		cause:       "",
 		solution:    "请重新输入 AppId",
 	},
+	NonceReplayed: {
+		description: "请求随机串已被使用",
+		cause:       "请求可能被重放",
+		solution:    "请为每个请求生成新的随机串",
+	},
 }
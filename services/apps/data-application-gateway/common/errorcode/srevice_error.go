@@ -1,28 +1,25 @@
 package errorcode
 
+import "github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+
 func init() {
 	registerErrorCode(serviceErrorMap)
 }
 
-const (
-	serviceModelName = "Service"
-)
-
 // Demo error
 const (
-	servicePreCoder = ServiceName + "." + serviceModelName + "."
-
-	ServiceNameExist         = servicePreCoder + "ServiceNameExist"
-	ServicePathExist         = servicePreCoder + "ServicePathExist"
-	ServicePathNotExist      = servicePreCoder + "ServicePathNotExist"
-	ServiceIDNotExist        = servicePreCoder + "ServiceIDNotExist"
-	ServiceSQLSyntaxError    = servicePreCoder + "ServiceSQLSyntaxError"
-	ServiceSQLSchemaError    = servicePreCoder + "ServiceSQLSchemaError"
-	ServiceSQLTableError     = servicePreCoder + "ServiceSQLTableError"
-	ServiceQueryPublishError = servicePreCoder + "ServiceQueryOnlineError"
-	DataViewIdNotExist       = servicePreCoder + "DataViewIdNotExist"
-	DataViewIdNotPublish     = servicePreCoder + "DataViewIdNotPublish"
-	DatasourceIdNotExist     = servicePreCoder + "DatasourceIdNotExist"
+	ServiceNameExist         = gateway_errorcode.ServiceNameExist
+	ServicePathExist         = gateway_errorcode.ServicePathExist
+	ServicePathNotExist      = gateway_errorcode.ServicePathNotExist
+	ServiceIDNotExist        = gateway_errorcode.ServiceIDNotExist
+	ServiceSQLSyntaxError    = gateway_errorcode.ServiceSQLSyntaxError
+	ServiceSQLSchemaError    = gateway_errorcode.ServiceSQLSchemaError
+	ServiceSQLTableError     = gateway_errorcode.ServiceSQLTableError
+	ServiceQueryPublishError = gateway_errorcode.ServiceQueryPublishError
+	DataViewIdNotExist       = gateway_errorcode.DataViewIdNotExist
+	DataViewIdNotPublish     = gateway_errorcode.DataViewIdNotPublish
+	DatasourceIdNotExist     = gateway_errorcode.DatasourceIdNotExist
+	ServiceVersionNotExist   = gateway_errorcode.ServiceVersionNotExist
 )
 
 var serviceErrorMap = errorCode{
@@ -46,6 +43,11 @@ var serviceErrorMap = errorCode{
 		cause:       "",
 		solution:    "请重新输入接口ID",
 	},
+	ServiceVersionNotExist: {
+		description: "接口版本不存在",
+		cause:       "",
+		solution:    "请检查请求头 X-Service-Version 中的版本号",
+	},
 	ServiceSQLSyntaxError: {
 		description: "脚本格式错误",
 		cause:       "",
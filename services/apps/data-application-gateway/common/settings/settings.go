@@ -16,6 +16,12 @@ type Settings struct {
 	Database        options.DBOptions `yaml:"database"`
 	Redis           Redis             `yaml:"redis"`
 	Services        Services          `yaml:"services"`
+	RateLimit       RateLimit         `json:"rate_limit"`
+	Signature       Signature         `json:"signature"`
+	Stream          Stream            `json:"stream"`
+	Cursor          Cursor            `json:"cursor"`
+	CallRecord      CallRecord        `json:"call_record"`
+	CircuitBreaker  CircuitBreaker    `json:"circuit_breaker"`
 	zapx.LogConfigs `yaml:"logs"`
 	Telemetry       telemetry.Config `json:"telemetry"`
 }
@@ -72,3 +78,80 @@ type Services struct {
 	DataSubject            string `json:"data_subject"`             //主题域管理服务
 	AuthService            string `json:"auth_service"`             //权限服务
 }
+
+// Signature x-tif 签名校验配置
+type Signature struct {
+	ClockSkew int `json:"clock_skew"` // 允许的请求时间戳误差 秒，为 0 时使用默认值 180
+}
+
+// RateLimit 接口调用限流配置
+type RateLimit struct {
+	Enabled bool `json:"enabled"` // 是否启用限流
+	// 接口默认限流规则，接口自身配置的调用频次 rate_limiting 优先于此处的 requests_per_second
+	ServiceDefault RateLimitRule `json:"service_default"`
+	// 调用应用默认限流规则
+	AppDefault RateLimitRule `json:"app_default"`
+	// 按接口 ID 覆盖的限流规则
+	Services map[string]RateLimitRule `json:"services"`
+	// 按应用 ID 覆盖的限流规则
+	Apps map[string]RateLimitRule `json:"apps"`
+}
+
+// RateLimitRule 限流规则，值为 0 表示不限制
+type RateLimitRule struct {
+	RequestsPerSecond int   `json:"requests_per_second"` // 令牌桶速率 次/秒
+	Burst             int   `json:"burst"`               // 令牌桶容量，为 0 时等于 requests_per_second
+	DailyQuota        int64 `json:"daily_quota"`         // 每日调用配额
+	MaxConcurrent     int   `json:"max_concurrent"`      // 最大并发查询数
+}
+
+// Stream 流式返回查询结果
+type Stream struct {
+	// 流式返回时单次请求的最大行数
+	MaxRows int `json:"max_rows"`
+}
+
+// Cursor 分页游标配置
+type Cursor struct {
+	// 游标的 HMAC 签名密钥，多实例部署时必须相同。为空时使用随机密钥，游标只在当前实例有效
+	Secret string `json:"secret"`
+}
+
+// CallRecord 接口调用记录异步写入配置
+type CallRecord struct {
+	// 内存缓冲区可容纳的调用记录数，缓冲区满时丢弃新的记录
+	BufferSize int `json:"buffer_size"`
+	// 单次批量插入的最大记录数
+	BatchSize int `json:"batch_size"`
+	// 缓冲区未满一批时的写入间隔 秒
+	FlushInterval int `json:"flush_interval"`
+	// 数据库不可用时调用记录的暂存目录，为空时不暂存
+	SpillDir string `json:"spill_dir"`
+}
+
+// CircuitBreaker 接口注册后端服务的熔断与重试配置
+type CircuitBreaker struct {
+	Enabled bool `json:"enabled"` // 是否启用熔断与重试
+	// 接口默认规则
+	Default CircuitBreakerRule `json:"default"`
+	// 按接口 ID 覆盖的规则
+	Services map[string]CircuitBreakerRule `json:"services"`
+}
+
+// CircuitBreakerRule 熔断与重试规则
+type CircuitBreakerRule struct {
+	FailureRatio   float64 `json:"failure_ratio"`    // 统计窗口内失败比例达到该值时熔断
+	MinRequests    int64   `json:"min_requests"`     // 统计窗口内请求数达到该值后才计算失败比例
+	Window         int     `json:"window"`           // 统计窗口 秒
+	OpenInterval   int     `json:"open_interval"`    // 熔断持续时间 秒，之后进入半开状态
+	HalfOpenProbes int     `json:"half_open_probes"` // 半开状态放行的探测请求数，全部成功后恢复
+	Retry          Retry   `json:"retry"`
+}
+
+// Retry 后端服务调用失败时的重试规则，只重试幂等的 HTTP 方法
+type Retry struct {
+	MaxAttempts int      `json:"max_attempts"` // 最大尝试次数，包含首次调用，小于等于 1 时不重试
+	Backoff     int      `json:"backoff"`      // 首次重试前的等待时间 毫秒，之后每次翻倍
+	MaxBackoff  int      `json:"max_backoff"`  // 重试等待时间上限 毫秒
+	Methods     []string `json:"methods"`      // 允许重试的 HTTP 方法，为空时为 GET、HEAD、OPTIONS
+}
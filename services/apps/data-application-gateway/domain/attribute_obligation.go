@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/virtual_engine"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"go.uber.org/zap"
+)
+
+// obligationMaskValue 脱敏字段返回的值，与查询保护字段一致
+const obligationMaskValue = "*"
+
+// enforceApp 验证应用读取接口的权限，允许时返回生效的属性策略。属性策略计算失败时不能按没有属性策略处理
+func (u *QueryDomain) enforceApp(c context.Context, appID, serviceID string) (allowed bool, obligations *microservice.AttributeObligations, err error) {
+	resp, err := u.authService.EnforceWithObligations(c, []microservice.Enforce{{
+		SubjectType: "app",
+		SubjectId:   appID,
+		ObjectType:  "api",
+		ObjectId:    serviceID,
+		Action:      "read",
+	}})
+	if err != nil {
+		return false, nil, err
+	}
+	if len(resp) == 0 || resp[0].Effect != "allow" {
+		return false, nil, nil
+	}
+	if resp[0].ObligationError != "" {
+		log.WithContext(c).Error("evaluate attribute obligations fail", zap.String("service_id", serviceID), zap.String("app", appID), zap.String("error", resp[0].ObligationError))
+		return false, nil, errorcode.Detail(errorcode.ObligationUnavailable, resp[0].ObligationError)
+	}
+	return true, resp[0].Obligations, nil
+}
+
+// signedAppObligations 签名鉴权调用接口时应用生效的属性策略。签名鉴权不要求应用拥有接口的权限，未授权时属性策略单独计算
+func (u *QueryDomain) signedAppObligations(c context.Context, appID, serviceID string) (*microservice.AttributeObligations, error) {
+	allowed, obligations, err := u.enforceApp(c, appID, serviceID)
+	if err != nil || allowed {
+		return obligations, err
+	}
+	return u.authService.EvaluateAttributePolicies(c, &microservice.AttributePolicyEvaluateReq{
+		ObjectId:    serviceID,
+		ObjectType:  "api",
+		SubjectId:   appID,
+		SubjectType: "app",
+	})
+}
+
+// applyObligations 按调用方生效的属性策略处理接口，只处理接口数据源逻辑视图上的属性策略。
+// 接口缓存在内存中被所有调用方共用，需要处理时返回副本。
+// 脱敏、隐藏的字段按查询保护处理，查询时只返回掩码，结果中再删除隐藏的字段。
+func applyObligations(service *model.ServiceAssociations, obligations *microservice.AttributeObligations) (*model.ServiceAssociations, error) {
+	if obligations == nil || (len(obligations.Columns) == 0 && len(obligations.RowFilters) == 0) {
+		return service, nil
+	}
+	// 注册接口的结果由后端服务返回，无法执行属性策略
+	if service.ServiceType != "service_generate" {
+		return nil, errorcode.Desc(errorcode.ObligationNotSupported)
+	}
+
+	viewID := service.ServiceDataSource.DataViewID
+	columns := make(map[string]string)
+	for _, column := range obligations.Columns {
+		if column.DataViewID != viewID {
+			continue
+		}
+		switch column.Action {
+		case microservice.AttributeColumnMask:
+			// 同一字段既脱敏又隐藏时按隐藏处理
+			if columns[column.Name] != microservice.AttributeColumnHide {
+				columns[column.Name] = column.Action
+			}
+		case microservice.AttributeColumnHide:
+			columns[column.Name] = column.Action
+		}
+	}
+	var filters []*microservice.AttributeRowFilter
+	for _, filter := range obligations.RowFilters {
+		if filter.DataViewID == viewID {
+			filters = append(filters, filter)
+		}
+	}
+	if len(columns) == 0 && len(filters) == 0 {
+		return service, nil
+	}
+
+	res := *service
+	res.ServiceParams = make([]model.ServiceParam, len(service.ServiceParams))
+	copy(res.ServiceParams, service.ServiceParams)
+	dataTypes := make(map[string]string, len(res.ServiceParams))
+	for i := range res.ServiceParams {
+		p := &res.ServiceParams[i]
+		dataTypes[p.EnName] = p.DataType
+		if p.ParamType == "response" && columns[p.EnName] != "" {
+			p.DataProtectionQuery = true
+		}
+	}
+	rowFilter, err := obligationRowFilter(filters, dataTypes)
+	if err != nil {
+		return nil, err
+	}
+	res.Obligations = model.ServiceObligations{Columns: columns, RowFilter: rowFilter}
+	return &res, nil
+}
+
+// obligationRowFilter 属性策略的行过滤条件，多个条件使用 and 连接。dataTypes 为接口参数的数据类型
+func obligationRowFilter(filters []*microservice.AttributeRowFilter, dataTypes map[string]string) (string, error) {
+	var conditions []string
+	for _, filter := range filters {
+		name := escape(filter.Name)
+		dataType := dataTypes[filter.Name]
+		var condition string
+		switch filter.Operator {
+		case "=":
+			condition = fmt.Sprintf("%s = %s", name, obligationLiteral(filter.Value, dataType))
+		case "!=":
+			condition = fmt.Sprintf("%s <> %s", name, obligationLiteral(filter.Value, dataType))
+		case "in", "not in":
+			var values []string
+			for _, v := range strings.Split(filter.Value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					values = append(values, obligationLiteral(v, dataType))
+				}
+			}
+			// 值为空时 in 不匹配任何行，not in 匹配所有行
+			switch {
+			case len(values) > 0:
+				condition = fmt.Sprintf("%s %s (%s)", name, filter.Operator, strings.Join(values, ","))
+			case filter.Operator == "in":
+				condition = "1 = 0"
+			default:
+				condition = "1 = 1"
+			}
+		case "null":
+			condition = fmt.Sprintf("%s is null", name)
+		case "not null":
+			condition = fmt.Sprintf("%s is not null", name)
+		default:
+			return "", errorcode.Detail(errorcode.ObligationNotSupported, fmt.Sprintf("unsupported row filter operator: %s", filter.Operator))
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	return strings.Join(conditions, " and "), nil
+}
+
+// obligationLiteral 将过滤值转为 SQL 字面量，数值、布尔类型的参数使用原值，其他类型作为字符串比较
+func obligationLiteral(value, dataType string) string {
+	switch dto.ParamDataType(dataType) {
+	case dto.ParamDataTypeInt, dto.ParamDataTypeLong, dto.ParamDataTypeFloat, dto.ParamDataTypeDouble:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	case dto.ParamDataTypeBoolean:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// withObligationRowFilter 在子服务的行过滤规则上追加属性策略的行过滤条件
+func withObligationRowFilter(rule string, service *model.ServiceAssociations) string {
+	filter := service.Obligations.RowFilter
+	switch {
+	case filter == "":
+		return rule
+	case rule == "":
+		return "(" + filter + ")"
+	default:
+		return "(" + rule + ") and (" + filter + ")"
+	}
+}
+
+// applyColumnObligations 按属性策略处理查询结果：脱敏的字段替换为掩码，隐藏的字段删除
+func applyColumnObligations(columns map[string]string, rows []map[string]interface{}) {
+	if len(columns) == 0 {
+		return
+	}
+	for _, row := range rows {
+		for name, action := range columns {
+			if _, ok := row[name]; !ok {
+				continue
+			}
+			switch action {
+			case microservice.AttributeColumnHide:
+				delete(row, name)
+			case microservice.AttributeColumnMask:
+				row[name] = obligationMaskValue
+			}
+		}
+	}
+}
+
+// obligationRowIterator 按属性策略处理流式返回的每一行
+type obligationRowIterator struct {
+	virtual_engine.RowIterator
+	// 处理后的列
+	columns []virtual_engine.Column
+	// 原结果集每列的处理方式
+	actions []string
+}
+
+// newObligationRowIterator 没有需要处理的字段时返回原迭代器
+func newObligationRowIterator(it virtual_engine.RowIterator, columns map[string]string) virtual_engine.RowIterator {
+	if len(columns) == 0 {
+		return it
+	}
+	o := &obligationRowIterator{RowIterator: it}
+	for _, column := range it.Columns() {
+		action := columns[column.Name]
+		o.actions = append(o.actions, action)
+		if action != microservice.AttributeColumnHide {
+			o.columns = append(o.columns, column)
+		}
+	}
+	return o
+}
+
+func (o *obligationRowIterator) Columns() []virtual_engine.Column {
+	return o.columns
+}
+
+func (o *obligationRowIterator) Next() ([]interface{}, error) {
+	row, err := o.RowIterator.Next()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]interface{}, 0, len(o.columns))
+	for i, v := range row {
+		if i < len(o.actions) {
+			switch o.actions[i] {
+			case microservice.AttributeColumnHide:
+				continue
+			case microservice.AttributeColumnMask:
+				v = obligationMaskValue
+			}
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/virtual_engine"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+)
+
+// 低密级应用调用接口时，带分级标签的字段按属性策略脱敏、隐藏，并追加行过滤条件
+func Test_applyObligations_LowClearanceApp(t *testing.T) {
+	service := &model.ServiceAssociations{
+		Service:           model.Service{ServiceID: "s1", ServiceType: "service_generate", CreateModel: "wizard"},
+		ServiceDataSource: model.ServiceDataSource{DataViewID: "v1"},
+		ServiceParams: []model.ServiceParam{
+			{ParamType: "request", EnName: "dept_id", DataType: string(dto.ParamDataTypeString)},
+			{ParamType: "response", EnName: "id", DataType: string(dto.ParamDataTypeLong), Sort: "asc"},
+			{ParamType: "response", EnName: "id_card", DataType: string(dto.ParamDataTypeString)},
+			{ParamType: "response", EnName: "salary", DataType: string(dto.ParamDataTypeDouble)},
+		},
+		SubServices: []model.SubService{{RowFilterClause: `"region" = 'r1'`}},
+	}
+	// auth-service 为密级不足的应用返回的属性策略
+	obligations := &microservice.AttributeObligations{
+		Columns: []*microservice.AttributeColumnObligation{
+			{DataViewID: "v1", Name: "id_card", Action: microservice.AttributeColumnMask},
+			{DataViewID: "v1", Name: "salary", Action: microservice.AttributeColumnHide},
+			{DataViewID: "v2", Name: "id", Action: microservice.AttributeColumnHide},
+		},
+		RowFilters: []*microservice.AttributeRowFilter{
+			{DataViewID: "v1", Name: "dept_id", Operator: "in", Value: "d1,d'2"},
+			{DataViewID: "v1", Name: "id", Operator: "!=", Value: "0"},
+		},
+	}
+
+	applied, err := applyObligations(service, obligations)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]string{"id_card": "mask", "salary": "hide"}, applied.Obligations.Columns)
+	assert.Equal(t, `("dept_id" in ('d1','d''2')) and ("id" <> 0)`, applied.Obligations.RowFilter)
+	assert.Equal(t, `("region" = 'r1') and (("dept_id" in ('d1','d''2')) and ("id" <> 0))`,
+		withObligationRowFilter(`"region" = 'r1'`, applied))
+	// 脱敏、隐藏的字段按查询保护生成查询语句，缓存中的接口不变
+	assert.Equal(t, []bool{false, false, true, true}, []bool{
+		applied.ServiceParams[0].DataProtectionQuery, applied.ServiceParams[1].DataProtectionQuery,
+		applied.ServiceParams[2].DataProtectionQuery, applied.ServiceParams[3].DataProtectionQuery,
+	})
+	for _, p := range service.ServiceParams {
+		assert.False(t, p.DataProtectionQuery)
+	}
+	assert.Empty(t, service.Obligations)
+	// 不同属性策略的查询结果不共用缓存
+	assert.NotEqual(t, resultCacheKey(service, 0, nil), resultCacheKey(applied, 0, nil))
+
+	rows := []map[string]interface{}{{"id": 1, "id_card": "110101199001011234", "salary": 1000.5}}
+	applyColumnObligations(applied.Obligations.Columns, rows)
+	assert.Equal(t, []map[string]interface{}{{"id": 1, "id_card": "*"}}, rows)
+}
+
+func Test_applyObligations(t *testing.T) {
+	service := &model.ServiceAssociations{
+		Service:           model.Service{ServiceType: "service_generate"},
+		ServiceDataSource: model.ServiceDataSource{DataViewID: "v1"},
+	}
+	// 没有接口数据源逻辑视图上的属性策略时不复制接口
+	applied, err := applyObligations(service, &microservice.AttributeObligations{
+		Columns: []*microservice.AttributeColumnObligation{{DataViewID: "v2", Name: "id", Action: microservice.AttributeColumnMask}},
+	})
+	assert.NoError(t, err)
+	assert.Same(t, service, applied)
+
+	// 注册接口无法执行属性策略
+	_, err = applyObligations(&model.ServiceAssociations{Service: model.Service{ServiceType: "service_register"}}, &microservice.AttributeObligations{
+		RowFilters: []*microservice.AttributeRowFilter{{DataViewID: "v1", Name: "id", Operator: "null"}},
+	})
+	assert.Error(t, err)
+
+	where, err := obligationRowFilter([]*microservice.AttributeRowFilter{
+		{Name: "owner", Operator: "in"},
+		{Name: "owner", Operator: "not null"},
+	}, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `(1 = 0) and ("owner" is not null)`, where)
+	}
+	_, err = obligationRowFilter([]*microservice.AttributeRowFilter{{Name: "owner", Operator: "like"}}, nil)
+	assert.Error(t, err)
+}
+
+type sliceRowIterator struct {
+	columns []virtual_engine.Column
+	rows    [][]interface{}
+}
+
+func (s *sliceRowIterator) Columns() []virtual_engine.Column { return s.columns }
+
+func (s *sliceRowIterator) Next() ([]interface{}, error) {
+	if len(s.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
+
+func (s *sliceRowIterator) Close() error { return nil }
+
+func Test_obligationRowIterator(t *testing.T) {
+	it := newObligationRowIterator(&sliceRowIterator{
+		columns: []virtual_engine.Column{{Name: "id"}, {Name: "id_card"}, {Name: "salary"}},
+		rows:    [][]interface{}{{1, "110101199001011234", 1000.5}},
+	}, map[string]string{"id_card": "mask", "salary": "hide"})
+
+	assert.Equal(t, []virtual_engine.Column{{Name: "id"}, {Name: "id_card"}}, it.Columns())
+	row, err := it.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, "*"}, row)
+	_, err = it.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+// fakeAuthService 应用没有接口的权限，属性策略单独计算
+type fakeAuthService struct {
+	microservice.AuthServiceRepo
+	effect      string
+	obligations *microservice.AttributeObligations
+	err         error
+}
+
+func (a *fakeAuthService) EnforceWithObligations(ctx context.Context, enforcesReq []microservice.Enforce) ([]microservice.EnforceEffect, error) {
+	res := make([]microservice.EnforceEffect, len(enforcesReq))
+	for i, e := range enforcesReq {
+		e.Effect = a.effect
+		res[i] = microservice.EnforceEffect{Enforce: e}
+		if a.effect == "allow" {
+			res[i].Obligations = a.obligations
+		}
+	}
+	return res, nil
+}
+
+func (a *fakeAuthService) EvaluateAttributePolicies(ctx context.Context, evaluateReq *microservice.AttributePolicyEvaluateReq) (*microservice.AttributeObligations, error) {
+	return a.obligations, a.err
+}
+
+// 签名鉴权的应用没有接口的权限时，仍然按属性策略脱敏字段；属性策略计算失败时拒绝调用
+func Test_signedAppObligations(t *testing.T) {
+	obligations := &microservice.AttributeObligations{
+		Columns: []*microservice.AttributeColumnObligation{{DataViewID: "v1", Name: "id_card", Action: microservice.AttributeColumnMask}},
+	}
+	authService := &fakeAuthService{effect: "deny", obligations: obligations}
+	u := &QueryDomain{authService: authService}
+
+	res, err := u.signedAppObligations(context.Background(), "a1", "s1")
+	assert.NoError(t, err)
+	assert.Equal(t, obligations, res)
+
+	authService.effect = "allow"
+	res, err = u.signedAppObligations(context.Background(), "a1", "s1")
+	assert.NoError(t, err)
+	assert.Equal(t, obligations, res)
+
+	authService.effect, authService.err = "deny", errors.New("auth-service unavailable")
+	_, err = u.signedAppObligations(context.Background(), "a1", "s1")
+	assert.Error(t, err)
+}
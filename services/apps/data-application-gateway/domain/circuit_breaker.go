@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/circuit_breaker"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/errorx/agerrors"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 未配置时允许重试的 HTTP 方法
+var defaultRetryMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+// circuitBreakerRule 接口的熔断与重试规则，配置中按接口 ID 覆盖的规则优先于默认规则
+func circuitBreakerRule(conf *settings.CircuitBreaker, serviceID string) settings.CircuitBreakerRule {
+	if rule, ok := conf.Services[serviceID]; ok {
+		return rule
+	}
+	return conf.Default
+}
+
+func breakerConfig(rule settings.CircuitBreakerRule) circuit_breaker.Config {
+	return circuit_breaker.Config{
+		FailureRatio:   rule.FailureRatio,
+		MinRequests:    rule.MinRequests,
+		Window:         time.Duration(rule.Window) * time.Second,
+		OpenInterval:   time.Duration(rule.OpenInterval) * time.Second,
+		HalfOpenProbes: rule.HalfOpenProbes,
+	}
+}
+
+// maxAttempts 后端服务的最大尝试次数，非幂等方法不重试
+func maxAttempts(rule settings.Retry, method string) int {
+	if rule.MaxAttempts <= 1 {
+		return 1
+	}
+	methods := rule.Methods
+	if len(methods) == 0 {
+		methods = defaultRetryMethods
+	}
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return rule.MaxAttempts
+		}
+	}
+	return 1
+}
+
+// retryBackoff 第 attempt 次重试前的等待时间，从 Backoff 开始每次翻倍，不超过 MaxBackoff
+func retryBackoff(rule settings.Retry, attempt int) time.Duration {
+	d := time.Duration(rule.Backoff) * time.Millisecond
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if rule.MaxBackoff > 0 && d >= time.Duration(rule.MaxBackoff)*time.Millisecond {
+			break
+		}
+	}
+	if rule.MaxBackoff > 0 {
+		d = min(d, time.Duration(rule.MaxBackoff)*time.Millisecond)
+	}
+	return d
+}
+
+// isBackendFailure 是否为后端服务故障，只有故障计入熔断并重试，后端返回的业务错误不计入
+func isBackendFailure(err error) bool {
+	return err != nil && errorcode.IsErrorCode(err) && agerrors.Code(err).GetErrorCode() == errorcode.BackendUnavailable
+}
+
+// IsCircuitOpenError 判断错误码是否为熔断错误
+func IsCircuitOpenError(code string) bool {
+	return code == errorcode.CircuitOpenError
+}
+
+// circuitOpenError 熔断错误，详情中返回建议的重试等待秒数，向上取整
+func circuitOpenError(retryAfter time.Duration) error {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	return errorcode.Detail(errorcode.CircuitOpenError, dto.CircuitOpenDetail{RetryAfter: max(seconds, 1)})
+}
+
+// CircuitRetryAfter 返回熔断错误建议的重试等待秒数，用于设置 Retry-After 响应头
+func CircuitRetryAfter(err error) (int64, bool) {
+	if !errorcode.IsErrorCode(err) {
+		return 0, false
+	}
+	code := agerrors.Code(err)
+	if !IsCircuitOpenError(code.GetErrorCode()) {
+		return 0, false
+	}
+	detail, ok := code.GetErrorDetails().(dto.CircuitOpenDetail)
+	return detail.RetryAfter, ok
+}
+
+// serveWithBreaker 经熔断器调用接口注册的后端服务，幂等方法在后端故障时按退避时间重试。
+// 熔断中直接返回错误，不等待后端超时。
+func (u *QueryDomain) serveWithBreaker(c context.Context, params map[string]*dto.Param, service *model.ServiceAssociations) (length int64, res io.ReadCloser, err error) {
+	conf := &settings.Instance.CircuitBreaker
+	if !conf.Enabled {
+		return u.reverseProxyRepo.Serve(c, params, service.HTTPMethod, service.BackendServiceHost, service.BackendServicePath, service.Timeout)
+	}
+
+	rule := circuitBreakerRule(conf, service.ServiceID)
+	attempts := maxAttempts(rule.Retry, service.HTTPMethod)
+	for attempt := 1; ; attempt++ {
+		done, retryAfter, ok := u.circuitBreakerRepo.Allow(service.ServiceID, breakerConfig(rule))
+		if !ok {
+			log.WithContext(c).Warn("serveWithBreaker circuit open", zap.String("service_id", service.ServiceID), zap.Duration("retry_after", retryAfter))
+			return 0, nil, circuitOpenError(retryAfter)
+		}
+
+		length, res, err = u.reverseProxyRepo.Serve(c, params, service.HTTPMethod, service.BackendServiceHost, service.BackendServicePath, service.Timeout)
+		failed := isBackendFailure(err)
+		done(!failed)
+		if !failed || attempt >= attempts {
+			return length, res, err
+		}
+
+		wait := retryBackoff(rule.Retry, attempt)
+		log.WithContext(c).Warn("serveWithBreaker retry", zap.String("service_id", service.ServiceID), zap.Int("attempt", attempt), zap.Duration("backoff", wait), zap.Error(err))
+		select {
+		case <-c.Done():
+			return 0, nil, err
+		case <-time.After(wait):
+		}
+	}
+}
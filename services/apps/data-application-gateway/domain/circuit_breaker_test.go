@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+)
+
+func Test_maxAttempts(t *testing.T) {
+	rule := settings.Retry{MaxAttempts: 3}
+	assert.Equal(t, 3, maxAttempts(rule, "get"))
+	assert.Equal(t, 1, maxAttempts(rule, "POST"))
+
+	rule.Methods = []string{"POST"}
+	assert.Equal(t, 3, maxAttempts(rule, "post"))
+	assert.Equal(t, 1, maxAttempts(settings.Retry{MaxAttempts: 0}, "GET"))
+}
+
+func Test_retryBackoff(t *testing.T) {
+	rule := settings.Retry{Backoff: 100, MaxBackoff: 300}
+	assert.Equal(t, 100*time.Millisecond, retryBackoff(rule, 1))
+	assert.Equal(t, 200*time.Millisecond, retryBackoff(rule, 2))
+	assert.Equal(t, 300*time.Millisecond, retryBackoff(rule, 3))
+	assert.Equal(t, 300*time.Millisecond, retryBackoff(rule, 30))
+}
+
+func Test_circuitOpenError(t *testing.T) {
+	seconds, ok := CircuitRetryAfter(circuitOpenError(1500 * time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), seconds)
+
+	seconds, ok = CircuitRetryAfter(circuitOpenError(0))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), seconds)
+
+	_, ok = CircuitRetryAfter(errorcode.Desc(errorcode.BackendUnavailable))
+	assert.False(t, ok)
+}
@@ -0,0 +1,408 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// queryCursor 分页游标，序列化后以 base64 形式返回给调用方，调用方不应解析其内容。
+// 游标带有 HMAC 签名，调用方修改游标后签名校验失败。
+//
+// 接口为向导模式且配置了排序字段时使用键集分页：记录上一页最后一行的排序字段值，
+// 下一页只查询排序不早于该行的数据，深度翻页不需要重新扫描前面的数据。
+// 其他接口退化为记录下一页的页码。
+type queryCursor struct {
+	// 查询参数指纹，游标只能用于参数相同的查询
+	Fingerprint string `json:"f"`
+	// 上一页最后一行的排序字段值
+	Keys []interface{} `json:"k,omitempty"`
+	// 已返回的排序字段值与 Keys 相同的行数，排序字段值重复时用于跳过已返回的行
+	Ties int `json:"n,omitempty"`
+	// 下一页的页码，不支持键集分页时使用
+	Page int `json:"p,omitempty"`
+	// 每页行数
+	Limit int `json:"l"`
+}
+
+// encodeCursor 序列化游标，格式为 base64(内容).base64(签名)
+func encodeCursor(cursor *queryCursor) string {
+	b, _ := json.Marshal(cursor)
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(cursorMAC(payload))
+}
+
+func decodeCursor(s string) (*queryCursor, error) {
+	payload, sig, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, errorcode.Desc(errorcode.InvalidCursor)
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, cursorMAC(payload)) {
+		return nil, errorcode.Desc(errorcode.InvalidCursor)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, errorcode.Desc(errorcode.InvalidCursor)
+	}
+	cursor := &queryCursor{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(cursor); err != nil || cursor.Limit <= 0 {
+		return nil, errorcode.Desc(errorcode.InvalidCursor)
+	}
+	return cursor, nil
+}
+
+// cursorMAC 游标内容的签名，截取前 16 字节
+func cursorMAC(payload string) []byte {
+	h := hmac.New(sha256.New, cursorKey())
+	h.Write([]byte(payload))
+	return h.Sum(nil)[:16]
+}
+
+// cursorKey 游标的签名密钥。未配置时使用随机密钥，游标只在当前实例重启前有效
+var cursorKey = sync.OnceValue(func() []byte {
+	if settings.Instance.Cursor.Secret != "" {
+		return []byte(settings.Instance.Cursor.Secret)
+	}
+	log.Warn("cursor.secret is not configured, cursors are only valid on this instance")
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+})
+
+// cursorFingerprint 查询参数的指纹，分页参数和请求头参数不参与计算
+func cursorFingerprint(serviceID string, params map[string]*dto.Param) string {
+	values := make(map[string]any, len(params))
+	for name, p := range params {
+		if p == nil || p.Position == dto.ParamPositionHeader {
+			continue
+		}
+		switch name {
+		case dto.Offset, dto.Limit, dto.Cursor, dto.WithTotal:
+			continue
+		}
+		values[name] = p.Value
+	}
+	b, _ := json.Marshal(values)
+	sum := sha256.Sum256(append([]byte(serviceID+":"), b...))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cursorSortKeys 键集分页使用的排序字段，顺序与生成的 order by 一致。不支持键集分页时返回空。
+func cursorSortKeys(service *model.ServiceAssociations) []model.ServiceParam {
+	if service.CreateModel != "wizard" {
+		return nil
+	}
+	var keys []model.ServiceParam
+	for _, p := range service.ServiceParams {
+		if p.ParamType != "response" || (p.Sort != "asc" && p.Sort != "desc") {
+			continue
+		}
+		// 开启查询保护的字段返回的是掩码，无法作为分页位置
+		if p.DataProtectionQuery {
+			return nil
+		}
+		keys = append(keys, p)
+	}
+	return keys
+}
+
+// cursorPage 根据游标计算本次查询的分页
+type cursorPage struct {
+	cursor *queryCursor
+	// 键集分页时的过滤条件
+	seek string
+	// 实际查询的页码和行数
+	offset, limit int
+	// 查询结果中需要跳过的行数
+	skip int
+}
+
+// newCursorPage 解析请求中的游标，返回本次查询的分页。没有游标时按 offset、limit 查询。
+func newCursorPage(service *model.ServiceAssociations, params map[string]*dto.Param) (*cursorPage, error) {
+	page := &cursorPage{offset: cast.ToInt(paramValue(params, dto.Offset)), limit: cast.ToInt(paramValue(params, dto.Limit))}
+	raw := cast.ToString(paramValue(params, dto.Cursor))
+	if raw == "" {
+		return page, nil
+	}
+	cursor, err := decodeCursor(raw)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Fingerprint != cursorFingerprint(service.ServiceID, params) {
+		return nil, errorcode.Desc(errorcode.InvalidCursor)
+	}
+	page.cursor = cursor
+	page.limit = cursor.Limit
+	if len(cursor.Keys) == 0 {
+		if cursor.Page < 1 {
+			return nil, errorcode.Desc(errorcode.InvalidCursor)
+		}
+		page.offset = cursor.Page
+		return page, nil
+	}
+
+	keys := cursorSortKeys(service)
+	if len(keys) != len(cursor.Keys) || cursor.Ties < 0 {
+		return nil, errorcode.Desc(errorcode.InvalidCursor)
+	}
+	seek, err := seekPredicate(keys, cursor.Keys)
+	if err != nil {
+		return nil, err
+	}
+	page.seek = seek
+	page.offset = 1
+	page.limit = cursor.Limit + cursor.Ties
+	page.skip = cursor.Ties
+	return page, nil
+}
+
+// apply 设置实际查询的分页参数，返回新的参数，不修改请求参数
+func (p *cursorPage) apply(params map[string]*dto.Param) map[string]*dto.Param {
+	res := make(map[string]*dto.Param, len(params))
+	for k, v := range params {
+		res[k] = v
+	}
+	res[dto.Offset] = dto.NewParam(p.offset, "", dto.ParamDataTypeInt)
+	res[dto.Limit] = dto.NewParam(p.limit, "", dto.ParamDataTypeInt)
+	return res
+}
+
+// pageLimit 每页行数
+func (p *cursorPage) pageLimit() int {
+	return p.limit - p.skip
+}
+
+// nextCursor 根据本页数据生成下一页的游标，rows 为已跳过重复行的本页数据
+func (p *cursorPage) nextCursor(service *model.ServiceAssociations, params map[string]*dto.Param, rows []map[string]interface{}) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	keys := cursorSortKeys(service)
+	last := rows[len(rows)-1]
+	ties := 0
+	for i := len(rows) - 1; i >= 0 && sameKeys(keys, rows[i], last); i-- {
+		ties++
+	}
+	return p.cursorAfter(service, params, last, ties, len(rows))
+}
+
+// cursorAfter 生成 last 之后的游标，ties 为本页末尾与 last 排序字段值相同的行数，rows 为本页的行数
+func (p *cursorPage) cursorAfter(service *model.ServiceAssociations, params map[string]*dto.Param, last map[string]interface{}, ties, rows int) string {
+	if rows == 0 {
+		return ""
+	}
+	next := &queryCursor{Fingerprint: cursorFingerprint(service.ServiceID, params), Limit: p.pageLimit()}
+
+	keys := cursorSortKeys(service)
+	// 不支持键集分页，或者本次按页码查询时，下一页使用页码
+	if len(keys) == 0 || p.cursor != nil && len(p.cursor.Keys) == 0 {
+		next.Page = p.offset + 1
+		return encodeCursor(next)
+	}
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		v, ok := last[k.EnName]
+		if !ok {
+			// 结果中缺少排序字段时无法确定位置。键集分页的 offset 固定为 1，不能换算为页码
+			if p.seek != "" {
+				return ""
+			}
+			next.Page = p.offset + 1
+			return encodeCursor(next)
+		}
+		values[i] = v
+	}
+
+	next.Keys = values
+	next.Ties = ties
+	// 整页的排序字段值都与上一页最后一行相同
+	if ties == rows && p.cursor != nil && sameValues(p.cursor.Keys, values) {
+		next.Ties += p.cursor.Ties
+	}
+	return encodeCursor(next)
+}
+
+// cursorTracker 流式返回时记录已写出的行，用于生成下一页的游标
+type cursorTracker struct {
+	keys []model.ServiceParam
+	// 排序字段在结果列中的位置，不存在时为 -1
+	index []int
+	// 最后一行的排序字段值
+	last map[string]interface{}
+	// 末尾排序字段值相同的行数和已写出的行数
+	ties, rows int
+}
+
+func newCursorTracker(service *model.ServiceAssociations, columns []string) *cursorTracker {
+	keys := cursorSortKeys(service)
+	t := &cursorTracker{keys: keys, index: make([]int, len(keys))}
+	for i, k := range keys {
+		t.index[i] = lo.IndexOf(columns, k.EnName)
+	}
+	return t
+}
+
+// add 记录一行已写出的数据，列顺序与结果列一致
+func (t *cursorTracker) add(row []interface{}) {
+	current := make(map[string]interface{}, len(t.keys))
+	for i, k := range t.keys {
+		if j := t.index[i]; j >= 0 && j < len(row) {
+			current[k.EnName] = row[j]
+		}
+	}
+	if t.rows > 0 && sameKeys(t.keys, current, t.last) {
+		t.ties++
+	} else {
+		t.ties = 1
+	}
+	t.last = current
+	t.rows++
+}
+
+// sameKeys 两行的排序字段值是否相同，NULL 只与 NULL 相同
+func sameKeys(keys []model.ServiceParam, a, b map[string]interface{}) bool {
+	for _, k := range keys {
+		if !sameValue(a[k.EnName], b[k.EnName]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValues(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameValue(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return cast.ToString(a) == cast.ToString(b)
+}
+
+// seekPredicate 生成排序不早于 values 的过滤条件：
+//
+//	(a > va or a is null) or (a = va and (b > vb or b is null)) or (a = va and b = vb)
+//
+// 降序字段使用 <。排序时 NULL 排在最后，值为 NULL 的字段只能相等（is null），之后没有更晚的值。
+// 与已返回的最后一行排序字段值相同的行由 Ties 跳过。
+func seekPredicate(keys []model.ServiceParam, values []interface{}) (string, error) {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		l, err := sqlLiteral(v, keys[i].DataType)
+		if err != nil {
+			return "", err
+		}
+		literals[i] = l
+	}
+	equal := func(i int) string {
+		if values[i] == nil {
+			return quoteIdent(keys[i].EnName) + " is null"
+		}
+		return quoteIdent(keys[i].EnName) + " = " + literals[i]
+	}
+
+	var ors []string
+	for i := range keys {
+		if values[i] == nil {
+			continue
+		}
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, equal(j))
+		}
+		op := " > "
+		if keys[i].Sort == "desc" {
+			op = " < "
+		}
+		name := quoteIdent(keys[i].EnName)
+		after := "(" + name + op + literals[i] + " or " + name + " is null)"
+		if len(ands) == 0 {
+			ors = append(ors, after)
+			continue
+		}
+		ors = append(ors, "("+strings.Join(append(ands, after), " and ")+")")
+	}
+	var eqs []string
+	for i := range keys {
+		eqs = append(eqs, equal(i))
+	}
+	ors = append(ors, "("+strings.Join(eqs, " and ")+")")
+	return "(" + strings.Join(ors, " or ") + ")", nil
+}
+
+// sqlLiteral 将游标中的值转为 SQL 字面量。游标由调用方传入，只接受数字、布尔和字符串。
+// 字符串按排序字段的数据类型生成日期、时间字面量，其他类型作为字符串比较。
+func sqlLiteral(v interface{}, dataType string) (string, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if _, err := strconv.ParseFloat(v.String(), 64); err != nil {
+			return "", errorcode.Desc(errorcode.InvalidCursor)
+		}
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case string:
+		quoted := "'" + strings.ReplaceAll(v, "'", "''") + "'"
+		if t := temporalLiteralType(dataType); t != "" {
+			return t + " " + quoted, nil
+		}
+		return quoted, nil
+	}
+	return "", errorcode.Desc(errorcode.InvalidCursor)
+}
+
+// temporalLiteralType 日期时间类型字段使用的字面量类型，其他类型返回空
+func temporalLiteralType(dataType string) string {
+	t := strings.ToLower(strings.TrimSpace(dataType))
+	switch {
+	case t == "date":
+		return "date"
+	case strings.HasPrefix(t, "timestamp"), strings.HasPrefix(t, "datetime"), t == "smalldatetime":
+		return "timestamp"
+	case strings.HasPrefix(t, "time"):
+		return "time"
+	}
+	return ""
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func paramValue(params map[string]*dto.Param, name string) interface{} {
+	if p, ok := params[name]; ok && p != nil {
+		return p.Value
+	}
+	return nil
+}
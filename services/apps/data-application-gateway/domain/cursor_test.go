@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+)
+
+func Test_seekPredicate(t *testing.T) {
+	keys := []model.ServiceParam{
+		{EnName: "dept", Sort: "asc"},
+		{EnName: "created_at", Sort: "desc", DataType: "timestamp"},
+	}
+	got, err := seekPredicate(keys, []interface{}{"a'b", "2024-01-02 03:04:05.000"})
+	assert.NoError(t, err)
+	assert.Equal(t, `(("dept" > 'a''b' or "dept" is null) or ("dept" = 'a''b' and ("created_at" < timestamp '2024-01-02 03:04:05.000' or "created_at" is null)) or ("dept" = 'a''b' and "created_at" = timestamp '2024-01-02 03:04:05.000'))`, got)
+
+	// 排序字段值为 NULL 时，NULL 排在最后，该字段之后没有更晚的值
+	got, err = seekPredicate(keys, []interface{}{nil, "2024-01-02 03:04:05.000"})
+	assert.NoError(t, err)
+	assert.Equal(t, `(("dept" is null and ("created_at" < timestamp '2024-01-02 03:04:05.000' or "created_at" is null)) or ("dept" is null and "created_at" = timestamp '2024-01-02 03:04:05.000'))`, got)
+}
+
+func Test_sqlLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		dataType string
+		want     string
+		wantErr  bool
+	}{
+		{name: "数字", value: json.Number("12.5"), dataType: "double", want: "12.5"},
+		{name: "非法数字", value: json.Number("1 or 1=1"), wantErr: true},
+		{name: "布尔", value: true, dataType: "boolean", want: "true"},
+		{name: "字符串", value: "x' or '1'='1", dataType: "string", want: `'x'' or ''1''=''1'`},
+		{name: "日期", value: "2024-01-02", dataType: "date", want: "date '2024-01-02'"},
+		{name: "时间戳", value: "2024-01-02 03:04:05.000", dataType: "timestamp(3)", want: "timestamp '2024-01-02 03:04:05.000'"},
+		{name: "日期时间", value: "2024-01-02 03:04:05", dataType: "datetime", want: "timestamp '2024-01-02 03:04:05'"},
+		{name: "形如日期的字符串", value: "2024-01-02", dataType: "varchar", want: "'2024-01-02'"},
+		{name: "不支持的类型", value: []interface{}{1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sqlLiteral(tt.value, tt.dataType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_cursorPage(t *testing.T) {
+	service := &model.ServiceAssociations{
+		Service: model.Service{ServiceID: "s1", CreateModel: "wizard"},
+		ServiceParams: []model.ServiceParam{
+			{ParamType: "request", EnName: "dept"},
+			{ParamType: "response", EnName: "id", Sort: "asc"},
+			{ParamType: "response", EnName: "name"},
+		},
+	}
+	params := map[string]*dto.Param{
+		"dept":     dto.NewParam("d1", dto.ParamPositionQuery, dto.ParamDataTypeString),
+		dto.Offset: dto.NewParam(1, "", dto.ParamDataTypeInt),
+		dto.Limit:  dto.NewParam(2, "", dto.ParamDataTypeInt),
+	}
+
+	// 第一页
+	page, err := newCursorPage(service, params)
+	assert.NoError(t, err)
+	rows := []map[string]interface{}{
+		{"id": json.Number("1"), "name": "a"},
+		{"id": json.Number("2"), "name": "b"},
+	}
+	next := page.nextCursor(service, params, rows)
+	assert.NotEmpty(t, next)
+
+	// 第二页按键集分页
+	params[dto.Cursor] = dto.NewParam(next, dto.ParamPositionQuery, dto.ParamDataTypeString)
+	page, err = newCursorPage(service, params)
+	assert.NoError(t, err)
+	assert.Equal(t, `(("id" > 2 or "id" is null) or ("id" = 2))`, page.seek)
+	assert.Equal(t, 1, page.offset)
+	assert.Equal(t, 1, page.skip)
+	assert.Equal(t, 3, page.limit)
+	assert.Equal(t, 2, page.pageLimit())
+
+	// 查询参数变化后游标失效
+	params["dept"] = dto.NewParam("d2", dto.ParamPositionQuery, dto.ParamDataTypeString)
+	_, err = newCursorPage(service, params)
+	assert.Error(t, err)
+
+	// 损坏的游标
+	params[dto.Cursor] = dto.NewParam("!!", dto.ParamPositionQuery, dto.ParamDataTypeString)
+	_, err = newCursorPage(service, params)
+	assert.Error(t, err)
+}
+
+func Test_cursorPage_null(t *testing.T) {
+	service := &model.ServiceAssociations{
+		Service:       model.Service{ServiceID: "s1", CreateModel: "wizard"},
+		ServiceParams: []model.ServiceParam{{ParamType: "response", EnName: "name", Sort: "asc"}},
+	}
+	params := map[string]*dto.Param{
+		dto.Offset: dto.NewParam(1, "", dto.ParamDataTypeInt),
+		dto.Limit:  dto.NewParam(2, "", dto.ParamDataTypeInt),
+	}
+	page, err := newCursorPage(service, params)
+	assert.NoError(t, err)
+	next := page.nextCursor(service, params, []map[string]interface{}{{"name": "b"}, {"name": nil}})
+
+	// 最后一行的排序字段为 NULL 时仍按键集分页，只跳过已返回的 NULL 行
+	params[dto.Cursor] = dto.NewParam(next, dto.ParamPositionQuery, dto.ParamDataTypeString)
+	page, err = newCursorPage(service, params)
+	assert.NoError(t, err)
+	assert.Equal(t, `(("name" is null))`, page.seek)
+	assert.Equal(t, 1, page.skip)
+
+	// 整页都是 NULL 时累加需要跳过的行数
+	next = page.nextCursor(service, params, []map[string]interface{}{{"name": nil}, {"name": nil}})
+	params[dto.Cursor] = dto.NewParam(next, dto.ParamPositionQuery, dto.ParamDataTypeString)
+	page, err = newCursorPage(service, params)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.skip)
+}
+
+func Test_decodeCursor_tampered(t *testing.T) {
+	raw := encodeCursor(&queryCursor{Fingerprint: "f", Page: 2, Limit: 10})
+	cursor, err := decodeCursor(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cursor.Page)
+
+	// 修改游标内容后签名校验失败
+	payload, sig, _ := strings.Cut(raw, ".")
+	b, _ := base64.RawURLEncoding.DecodeString(payload)
+	forged := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(b), `"p":2`, `"p":3`, 1)))
+	_, err = decodeCursor(forged + "." + sig)
+	assert.Error(t, err)
+
+	// 没有签名的游标
+	_, err = decodeCursor(payload)
+	assert.Error(t, err)
+}
+
+func Test_cursorTracker(t *testing.T) {
+	service := &model.ServiceAssociations{
+		Service:       model.Service{ServiceID: "s1", CreateModel: "wizard"},
+		ServiceParams: []model.ServiceParam{{ParamType: "response", EnName: "id", Sort: "asc"}},
+	}
+	tracker := newCursorTracker(service, []string{"name", "id"})
+	tracker.add([]interface{}{"a", 1})
+	tracker.add([]interface{}{"b", 2})
+	tracker.add([]interface{}{"c", 2})
+	assert.Equal(t, 3, tracker.rows)
+	assert.Equal(t, 2, tracker.ties)
+	assert.Equal(t, map[string]interface{}{"id": 2}, tracker.last)
+}
+
+func Test_cursorPage_page(t *testing.T) {
+	// 脚本模式不支持键集分页，退化为页码
+	service := &model.ServiceAssociations{Service: model.Service{ServiceID: "s1", CreateModel: "script"}}
+	params := map[string]*dto.Param{
+		dto.Offset: dto.NewParam(3, "", dto.ParamDataTypeInt),
+		dto.Limit:  dto.NewParam(10, "", dto.ParamDataTypeInt),
+	}
+	page, err := newCursorPage(service, params)
+	assert.NoError(t, err)
+	next := page.nextCursor(service, params, []map[string]interface{}{{"id": 1}})
+
+	params[dto.Cursor] = dto.NewParam(next, dto.ParamPositionQuery, dto.ParamDataTypeString)
+	page, err = newCursorPage(service, params)
+	assert.NoError(t, err)
+	assert.Empty(t, page.seek)
+	assert.Equal(t, 4, page.offset)
+	assert.Equal(t, 10, page.limit)
+}
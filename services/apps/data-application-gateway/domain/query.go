@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,14 +23,19 @@ import (
 	"github.com/spf13/cast"
 	"go.uber.org/zap"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/gorm"
 	mdl_uniquery "github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/mdl-uniquery"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/nonce_store"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/rate_limiter"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/result_cache"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/reverse_proxy"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/virtual_engine"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/form_validator"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
 	v1 "github.com/kweaver-ai/idrm-go-common/api/auth-service/v1"
@@ -56,6 +62,10 @@ type QueryDomain struct {
 	applicationService         configuration_center_gocommon.ApplicationService
 	dataApplicationServiceRepo gorm.DataApplicationServiceRepo
 	mdl_uniquery               mdl_uniquery.DrivenMDLUniQuery
+	rateLimiterRepo            rate_limiter.RateLimiterRepo
+	nonceStore                 nonce_store.NonceStore
+	resultCacheRepo            result_cache.ResultCacheRepo
+	circuitBreakerRepo         circuit_breaker.CircuitBreakerRepo
 }
 
 func NewQueryDomain(
@@ -74,6 +84,10 @@ func NewQueryDomain(
 	applicationService configuration_center_gocommon.ApplicationService,
 	dataApplicationServiceRepo gorm.DataApplicationServiceRepo,
 	mdl_uniquery mdl_uniquery.DrivenMDLUniQuery,
+	rateLimiterRepo rate_limiter.RateLimiterRepo,
+	nonceStore nonce_store.NonceStore,
+	resultCacheRepo result_cache.ResultCacheRepo,
+	circuitBreakerRepo circuit_breaker.CircuitBreakerRepo,
 ) *QueryDomain {
 	return &QueryDomain{
 		appRepo:                    appRepo,
@@ -91,6 +105,10 @@ func NewQueryDomain(
 		applicationService:         applicationService,
 		dataApplicationServiceRepo: dataApplicationServiceRepo,
 		mdl_uniquery:               mdl_uniquery,
+		rateLimiterRepo:            rateLimiterRepo,
+		nonceStore:                 nonceStore,
+		resultCacheRepo:            resultCacheRepo,
+		circuitBreakerRepo:         circuitBreakerRepo,
 	}
 }
 
@@ -143,17 +161,31 @@ func (u *QueryDomain) Query(c context.Context, req *dto.QueryReq, cssjj string)
 	if err != nil {
 		return 0, nil, err
 	}
+	if service, err = u.resolveServiceVersion(c, req, service); err != nil {
+		return 0, nil, err
+	}
 	if service.Status != enum.ServiceStatusOnline &&
 		service.Status != enum.ServiceStatusDownAuditing &&
 		service.Status != enum.ServiceStatusDownReject {
 		return 0, nil, errorcode.Desc(errorcode.ServiceStatusNotAvailable)
 	}
 
+	// 调用应用 ID，用于按应用限流
+	var appID string
+	// 调用方生效的属性策略
+	var obligations *microservice.AttributeObligations
 	if cssjj == "true" {
 		//todo xx鉴权逻辑
 		if err := u.cssjjAuth(c, req, service); err != nil {
 			return 0, nil, err
 		}
+		if service.AppsID != nil {
+			appID = *service.AppsID
+			req.CallAppID = appID
+		}
+		if obligations, err = u.signedAppObligations(c, appID, service.ServiceID); err != nil {
+			return 0, nil, err
+		}
 	} else {
 		// 从 context 获取接调用者的信息，如果获取失败或调用者不是一个应用则禁止调用
 		subject, err := interception.AuthServiceSubjectFromContext(c)
@@ -170,20 +202,11 @@ func (u *QueryDomain) Query(c context.Context, req *dto.QueryReq, cssjj string)
 		//	return 0, nil, err
 		//}
 		//service.SubServices = subServices
-		enforce := microservice.Enforce{
-			SubjectType: string(subject.Type),
-			SubjectId:   subject.ID,
-			ObjectType:  "api",
-			ObjectId:    service.ServiceID,
-			Action:      "read",
-		}
-		resp, err := u.authService.Enforce(c, []microservice.Enforce{enforce})
+		authorized, appObligations, err := u.enforceApp(c, subject.ID, service.ServiceID)
 		if err != nil {
 			return 0, nil, err
 		}
 
-		authorized := resp[0]
-
 		// var authorized bool
 		// enforce.Effect = "allow" // 期望的 effect 是 allow
 		// for _, e := range enforceRes {
@@ -197,6 +220,13 @@ func (u *QueryDomain) Query(c context.Context, req *dto.QueryReq, cssjj string)
 		if !authorized {
 			return 0, nil, errorcode.Desc(errorcode.ServiceApplyNotPass)
 		}
+		obligations = appObligations
+		appID = subject.ID
+	}
+
+	// 仅接口生成支持流式返回，接口注册按后端服务的响应返回
+	if req.Format.IsStream() && service.ServiceType != "service_generate" {
+		req.Format = dto.ResultFormatJSON
 	}
 
 	err = u.checkParams(c, req, service)
@@ -207,9 +237,32 @@ func (u *QueryDomain) Query(c context.Context, req *dto.QueryReq, cssjj string)
 	if err = u.getServiceParamDataProtectionQuery(c, service); err != nil {
 		return 0, nil, err
 	}
+	if service, err = applyObligations(service, obligations); err != nil {
+		return 0, nil, err
+	}
 
-	// 执行查询
-	length, res, queryErr := u.query(c, req.Params, service)
+	// 限流
+	release, err := u.rateLimit(c, req, service, appID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// 执行查询，流式返回时长度未知
+	var queryErr error
+	if req.Format.IsStream() {
+		length = -1
+		res, queryErr = u.serviceGenerateStream(c, req, service)
+	} else {
+		length, res, queryErr = u.cachedQuery(c, req, service)
+		if queryErr == nil && service.ServiceType == "service_generate" {
+			res, req.RowsReturned = countResultRows(res)
+		}
+	}
+	if queryErr != nil {
+		release()
+	} else {
+		res = &releaseReadCloser{ReadCloser: res, release: release}
+	}
 
 	// 异步统计埋点，不影响主流程
 	// go func() {
@@ -234,6 +287,22 @@ func (u *QueryDomain) Query(c context.Context, req *dto.QueryReq, cssjj string)
 	return length, res, queryErr
 }
 
+// countResultRows 统计接口生成查询结果的行数，用于调用记录。结果已在内存中，读出后重新包装返回。
+func countResultRows(res io.ReadCloser) (io.ReadCloser, int64) {
+	b, err := io.ReadAll(res)
+	res.Close()
+	if err != nil {
+		return io.NopCloser(bytes.NewReader(b)), 0
+	}
+	var result struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return io.NopCloser(bytes.NewReader(b)), 0
+	}
+	return io.NopCloser(bytes.NewReader(b)), int64(len(result.Data))
+}
+
 // xx鉴权逻辑
 func (u *QueryDomain) cssjjAuth(c context.Context, req *dto.QueryReq, service *model.ServiceAssociations) (err error) {
 	var xTifSignature, xTifTimestamp, xTifNonce string
@@ -289,12 +358,30 @@ func (u *QueryDomain) cssjjAuth(c context.Context, req *dto.QueryReq, service *m
 	secret := application.Token
 
 	// 校验签名
-	ok, resHeaders := checkSign(secret, xTifTimestamp, xTifNonce, xTifSignature)
+	skew := signatureClockSkew()
+	ok, resHeaders := checkSign(secret, xTifTimestamp, xTifNonce, xTifSignature, time.Now(), skew)
 	if !ok {
 		log.WithContext(c).Error("cssjjAuth", zap.String("签名校验失败", "x-tif-signature="+resHeaders["x-tif-signature"]+" x-tif-timestamp="+resHeaders["x-tif-timestamp"]+" x-tif-nonce="+resHeaders["x-tif-nonce"]))
 		return errorcode.Desc(errorcode.ServiceApplyNotPassCssjj + "签名校验失败：x-tif-signature=" + resHeaders["x-tif-signature"] + " x-tif-timestamp=" + resHeaders["x-tif-timestamp"] + " x-tif-nonce=" + resHeaders["x-tif-nonce"])
 	}
 
+	// 防重放：时间戳误差范围内同一应用的随机串只能使用一次，因此随机串至少保留两倍误差时间
+	fresh, err := u.nonceStore.Remember(c, *service.AppsID+":"+xTifNonce, 2*skew)
+	if err != nil {
+		log.WithContext(c).Error("cssjjAuth", zap.String("x-tif-nonce", xTifNonce), zap.Error(err))
+		return errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+	if !fresh {
+		log.WithContext(c).Warn("audit: reject replayed request",
+			zap.String("service_id", service.ServiceID),
+			zap.String("service_path", service.ServicePath),
+			zap.String("app_id", *service.AppsID),
+			zap.String("x-tif-timestamp", xTifTimestamp),
+			zap.String("x-tif-nonce", xTifNonce),
+		)
+		return errorcode.Desc(errorcode.NonceReplayed)
+	}
+
 	// 更新请求头
 	for k, v := range resHeaders {
 		req.Params[k] = dto.NewParam(v, dto.ParamPositionHeader, dto.ParamDataTypeString)
@@ -303,24 +390,34 @@ func (u *QueryDomain) cssjjAuth(c context.Context, req *dto.QueryReq, service *m
 	return nil
 }
 
+// 默认允许的请求时间戳误差
+const defaultSignatureClockSkew = 180 * time.Second
+
+// signatureClockSkew 允许的请求时间戳误差
+func signatureClockSkew() time.Duration {
+	if settings.Instance.Signature.ClockSkew > 0 {
+		return time.Duration(settings.Instance.Signature.ClockSkew) * time.Second
+	}
+	return defaultSignatureClockSkew
+}
+
 // xx签名校验逻辑
-func checkSign(secret, timestamp, nonce, sign string) (bool, map[string]string) {
+func checkSign(secret, timestamp, nonce, sign string, now time.Time, skew time.Duration) (bool, map[string]string) {
 	ts, err := strconv.ParseInt(timestamp, 10, 64)
 	if err != nil {
 		return false, nil
 	}
-	now := time.Now().Unix()
-	if ts > now+180 || ts < now-180 {
+	if !isTimeInDelta(now, time.Unix(ts, 0), skew) {
 		return false, nil
 	}
-	log.Info("checkSign", zap.String("timestamp", timestamp), zap.String("secret", secret), zap.String("nonce", nonce), zap.String("sign", sign))
+	log.Info("checkSign", zap.String("timestamp", timestamp), zap.String("nonce", nonce), zap.String("sign", sign))
 	signData := fmt.Sprintf("%s%s%s%s", timestamp, secret, nonce, timestamp)
 	res := strings.ToUpper(fmt.Sprintf("%x", sha256.Sum256([]byte(signData))))
 
 	// 生成一个类似于 Math.random().toString(36).substr(2) 的随机字符串
 
 	resNonce := randomNonce()
-	resTimestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	resTimestamp := strconv.FormatInt(now.Unix(), 10)
 	resSign := strings.ToUpper(fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s%s%s%s", resTimestamp, secret, resNonce, resTimestamp)))))
 
 	resHeaders := map[string]string{
@@ -328,7 +425,8 @@ func checkSign(secret, timestamp, nonce, sign string) (bool, map[string]string)
 		"x-tif-timestamp": resTimestamp,
 		"x-tif-nonce":     resNonce,
 	}
-	return res == strings.ToUpper(sign), resHeaders
+	// 使用常量时间比较，避免通过响应时间推测签名
+	return subtle.ConstantTimeCompare([]byte(res), []byte(strings.ToUpper(sign))) == 1, resHeaders
 }
 func randomNonce() string {
 	n := rand.Int63() // 生成一个随机int64
@@ -504,41 +602,18 @@ func (u *QueryDomain) QueryTest(c context.Context, req *dto.QueryTestReq) (lengt
 func (u *QueryDomain) serviceGenerateQuery(c context.Context, params map[string]*dto.Param, service *model.ServiceAssociations) (length int64, res io.ReadCloser, err error) {
 	c, span := trace.StartInternalSpan(c)
 	defer func() { trace.TelemetrySpanEnd(span, err) }()
-	catalogName := service.ServiceDataSource.CatalogName
-	schemaName := service.ServiceDataSource.DataSchemaName
-	tableName := service.ServiceDataSource.DataTableName
-	script := service.ServiceScriptModel.Script
-	scriptCount := ""
-	serviceParams := service.ServiceParams
 	serviceResponseFilters := service.ServiceResponseFilters
-	subServiceRule := strings.Join(lo.Times(len(service.SubServices), func(index int) string {
-		return service.SubServices[index].RowFilterClause
-	}), " or  ")
 
-	switch service.CreateModel {
-	case "wizard":
-		script, err = u.serviceRepo.WizardModelScript(c, params, catalogName, schemaName, tableName, subServiceRule, serviceParams, false)
-		scriptCount, err = u.serviceRepo.WizardModelScript(c, params, catalogName, schemaName, tableName, subServiceRule, serviceParams, true)
-	case "script":
-		script, err = u.serviceRepo.ScriptModelScript(c, params, catalogName, schemaName, script, subServiceRule, serviceParams, false)
-		scriptCount, err = u.serviceRepo.ScriptModelScript(c, params, catalogName, schemaName, service.ServiceScriptModel.Script, subServiceRule, serviceParams, true)
-		scriptCount = replaceSelectWithCountSafe(scriptCount)
+	page, err := newCursorPage(service, params)
+	if err != nil {
+		return 0, nil, err
 	}
-
+	script, scriptCount, err := u.serviceGenerateScript(c, page.apply(params), service, page.seek)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	script = strings.ReplaceAll(script, "`", `"`)
-
-	log.Info("serviceGenerateQuery",
-		zap.String("service_path", service.ServicePath),
-		zap.String("service_id", service.ServiceID),
-		zap.String("script", script),
-		zap.Any("params", params),
-	)
-
-	length, err = u.virtualEngineRepo.FetchCount(c, scriptCount, service.Timeout)
+	total, totalType, err := u.totalCount(c, params, service, scriptCount)
 	if err != nil {
 		return
 	}
@@ -566,9 +641,20 @@ func (u *QueryDomain) serviceGenerateQuery(c context.Context, params map[string]
 	if err != nil {
 		return
 	}
-	fetchRes.TotalCount = int(length)
+	fetchRes.TotalCount = int(total)
+	fetchRes.TotalCountType = string(totalType)
+	applyColumnObligations(service.Obligations.Columns, fetchRes.Data)
 	// fetchRes.Data = result2.Entries
 
+	// 跳过上一页已返回的排序字段值重复的行
+	if page.skip > 0 {
+		fetchRes.Data = fetchRes.Data[min(page.skip, len(fetchRes.Data)):]
+	}
+	// 本页已满，或结果过滤导致本页不足一页时，仍可能有下一页
+	if len(fetchRes.Data) >= page.pageLimit() || len(serviceResponseFilters) > 0 {
+		fetchRes.NextCursor = page.nextCursor(service, params, fetchRes.Data)
+	}
+
 	fetchResJSON, err := json.Marshal(fetchRes)
 	if err != nil {
 		return
@@ -577,6 +663,57 @@ func (u *QueryDomain) serviceGenerateQuery(c context.Context, params map[string]
 	return int64(len(fetchResJSON)), io.NopCloser(bytes.NewReader(fetchResJSON)), nil
 }
 
+// serviceGenerateScript 生成接口的查询语句和计数语句，seek 为分页游标的过滤条件，只作用于查询语句
+func (u *QueryDomain) serviceGenerateScript(c context.Context, params map[string]*dto.Param, service *model.ServiceAssociations, seek string) (script, scriptCount string, err error) {
+	catalogName := service.ServiceDataSource.CatalogName
+	schemaName := service.ServiceDataSource.DataSchemaName
+	tableName := service.ServiceDataSource.DataTableName
+	script = service.ServiceScriptModel.Script
+	serviceParams := service.ServiceParams
+	subServiceRule := strings.Join(lo.Times(len(service.SubServices), func(index int) string {
+		return service.SubServices[index].RowFilterClause
+	}), " or  ")
+	subServiceRule = withObligationRowFilter(subServiceRule, service)
+	scriptRule := subServiceRule
+	if seek != "" {
+		if scriptRule != "" {
+			scriptRule = "(" + scriptRule + ") and " + seek
+		} else {
+			scriptRule = seek
+		}
+	}
+
+	switch service.CreateModel {
+	case "wizard":
+		script, err = u.serviceRepo.WizardModelScript(c, params, catalogName, schemaName, tableName, scriptRule, serviceParams, false)
+		if err != nil {
+			return "", "", err
+		}
+		scriptCount, err = u.serviceRepo.WizardModelScript(c, params, catalogName, schemaName, tableName, subServiceRule, serviceParams, true)
+	case "script":
+		script, err = u.serviceRepo.ScriptModelScript(c, params, catalogName, schemaName, script, scriptRule, serviceParams, false)
+		if err != nil {
+			return "", "", err
+		}
+		scriptCount, err = u.serviceRepo.ScriptModelScript(c, params, catalogName, schemaName, service.ServiceScriptModel.Script, subServiceRule, serviceParams, true)
+		scriptCount = replaceSelectWithCountSafe(scriptCount)
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	script = strings.ReplaceAll(script, "`", `"`)
+
+	log.Info("serviceGenerateQuery",
+		zap.String("service_path", service.ServicePath),
+		zap.String("service_id", service.ServiceID),
+		zap.String("script", script),
+		zap.Any("params", params),
+	)
+	return script, scriptCount, nil
+}
+
 func (u *QueryDomain) serviceRegisterQuery(c context.Context, params map[string]*dto.Param, service *model.ServiceAssociations) (length int64, res io.ReadCloser, err error) {
 	c, span := trace.StartInternalSpan(c)
 	defer func() { trace.TelemetrySpanEnd(span, err) }()
@@ -587,7 +724,20 @@ func (u *QueryDomain) serviceRegisterQuery(c context.Context, params map[string]
 		zap.String("backend_service_path", service.BackendServicePath),
 		zap.Any("params", params),
 	)
-	return u.reverseProxyRepo.Serve(c, params, service.HTTPMethod, service.BackendServiceHost, service.BackendServicePath, service.Timeout)
+
+	rules, err := parseTransformRules(service)
+	if err != nil {
+		return 0, nil, err
+	}
+	if rules != nil && rules.Request != nil {
+		params = transformRequestParams(params, rules.Request)
+	}
+
+	length, res, err = u.serveWithBreaker(c, params, service)
+	if err != nil || rules == nil || rules.Response == nil {
+		return length, res, err
+	}
+	return u.transformResponseBody(c, res, rules.Response)
 }
 
 func (u *QueryDomain) checkParams(c context.Context, req *dto.QueryReq, service *model.ServiceAssociations) (err error) {
@@ -608,6 +758,11 @@ func (u *QueryDomain) checkParams(c context.Context, req *dto.QueryReq, service
 		req.Params[dto.Offset] = dto.NewParam(1, "", dto.ParamDataTypeInt)
 	}
 
+	// 流式返回时允许单次返回更多的行
+	maxLimit := 1000
+	if req.Format.IsStream() {
+		maxLimit = streamMaxRows()
+	}
 	limit, ok := req.Params[dto.Limit]
 	if ok {
 		value, err := cast.ToIntE(limit.Value)
@@ -618,11 +773,13 @@ func (u *QueryDomain) checkParams(c context.Context, req *dto.QueryReq, service
 		if value < 0 {
 			validErrors = append(validErrors, &form_validator.ValidError{Key: "limit", Message: "接口 " + req.ServicePath + " 的请求参数 " + "limit" + " 最小值为 1"})
 		}
-		if value > 1000 {
-			validErrors = append(validErrors, &form_validator.ValidError{Key: "limit", Message: "接口 " + req.ServicePath + " 的请求参数 " + "limit" + " 最大值为 1000"})
+		if value > maxLimit {
+			validErrors = append(validErrors, &form_validator.ValidError{Key: "limit", Message: "接口 " + req.ServicePath + " 的请求参数 " + "limit" + " 最大值为 " + strconv.Itoa(maxLimit)})
 		}
 	} else {
-		if service.ServiceScriptModel.PageSize == 0 {
+		if req.Format.IsStream() {
+			req.Params[dto.Limit] = dto.NewParam(maxLimit, "", dto.ParamDataTypeInt)
+		} else if service.ServiceScriptModel.PageSize == 0 {
 			req.Params[dto.Limit] = dto.NewParam(1000, "", dto.ParamDataTypeInt)
 		} else {
 			req.Params[dto.Limit] = dto.NewParam(service.ServiceScriptModel.PageSize, "", dto.ParamDataTypeInt)
@@ -988,3 +1145,28 @@ func replaceSelectWithCountSafe(sql string) string {
 	// 组合结果: SELECT + COUNT(*) + FROM及其后的内容
 	return beforeSelect + " COUNT(*) " + afterFrom
 }
+
+// resolveServiceVersion 按请求头 X-Service-Version 在接口所在的版本组内选择版本，并记录实际调用的版本。
+// 选择了其他版本时把请求的接口路径改为该版本的接口路径，调用记录计入实际调用的版本
+func (u *QueryDomain) resolveServiceVersion(c context.Context, req *dto.QueryReq, service *model.ServiceAssociations) (*model.ServiceAssociations, error) {
+	if req.Version != "" && req.Version != service.Version {
+		if service.VersionGroupID == "" {
+			return nil, errorcode.Desc(errorcode.ServiceVersionNotExist)
+		}
+		versioned, err := u.serviceRepo.ServiceVersionGet(c, service.VersionGroupID, req.Version)
+		if err != nil {
+			return nil, err
+		}
+		service = versioned
+		req.ServicePath = service.ServicePath
+	}
+
+	if service.Version != "" || !service.DeprecatedAt.IsZero() {
+		req.VersionInfo = &dto.ServiceVersionInfo{
+			Version:      service.Version,
+			DeprecatedAt: service.DeprecatedAt,
+			SunsetAt:     service.SunsetAt,
+		}
+	}
+	return service, nil
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -92,3 +95,59 @@ func Test_isTimeInDelta(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkSign(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sign := func(timestamp, secret, nonce string) string {
+		return strings.ToUpper(fmt.Sprintf("%x", sha256.Sum256([]byte(timestamp+secret+nonce+timestamp))))
+	}
+	tests := []struct {
+		name      string
+		timestamp string
+		sign      string
+		assertion assert.BoolAssertionFunc
+	}{
+		{
+			name:      "签名正确",
+			timestamp: "1700000000",
+			sign:      sign("1700000000", "secret", "nonce"),
+			assertion: assert.True,
+		},
+		{
+			name:      "签名小写",
+			timestamp: "1700000000",
+			sign:      strings.ToLower(sign("1700000000", "secret", "nonce")),
+			assertion: assert.True,
+		},
+		{
+			name:      "误差范围内",
+			timestamp: "1700000060",
+			sign:      sign("1700000060", "secret", "nonce"),
+			assertion: assert.True,
+		},
+		{
+			name:      "超出误差范围",
+			timestamp: "1699999939",
+			sign:      sign("1699999939", "secret", "nonce"),
+			assertion: assert.False,
+		},
+		{
+			name:      "签名错误",
+			timestamp: "1700000000",
+			sign:      sign("1700000000", "other", "nonce"),
+			assertion: assert.False,
+		},
+		{
+			name:      "时间戳格式错误",
+			timestamp: "now",
+			sign:      sign("now", "secret", "nonce"),
+			assertion: assert.False,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, _ := checkSign("secret", tt.timestamp, "nonce", tt.sign, now, time.Minute)
+			tt.assertion(t, ok)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 并发名额的最短保留时间，防止实例异常退出后名额无法释放
+const concurrencyMinTTL = time.Minute
+
+// serviceRateLimitRule 接口的限流规则，优先级：配置中按接口 ID 覆盖的规则 > 接口的调用频次 > 默认规则
+func serviceRateLimitRule(conf *settings.RateLimit, service *model.Service) settings.RateLimitRule {
+	if rule, ok := conf.Services[service.ServiceID]; ok {
+		return rule
+	}
+	rule := conf.ServiceDefault
+	if service.RateLimiting != 0 {
+		rule.RequestsPerSecond = int(service.RateLimiting)
+		rule.Burst = 0
+	}
+	return rule
+}
+
+// appRateLimitRule 调用应用的限流规则
+func appRateLimitRule(conf *settings.RateLimit, appID string) settings.RateLimitRule {
+	if rule, ok := conf.Apps[appID]; ok {
+		return rule
+	}
+	return conf.AppDefault
+}
+
+// rateLimit 分别按接口和调用应用检查令牌桶、每日配额和并发数，返回释放并发名额的函数。
+// 限流状态写入 req.RateLimit。redis 不可用时放行，避免限流组件故障影响查询。
+func (u *QueryDomain) rateLimit(ctx context.Context, req *dto.QueryReq, service *model.ServiceAssociations, appID string) (release func(), err error) {
+	release = func() {}
+	conf := &settings.Instance.RateLimit
+	if !conf.Enabled {
+		return
+	}
+
+	type target struct {
+		key  string
+		rule settings.RateLimitRule
+	}
+	targets := []target{{key: "service:" + service.ServiceID, rule: serviceRateLimitRule(conf, &service.Service)}}
+	if appID != "" {
+		targets = append(targets, target{key: "app:" + appID, rule: appRateLimitRule(conf, appID)})
+	}
+
+	info := &dto.RateLimitInfo{Remaining: -1, QuotaRemaining: -1}
+	req.RateLimit = info
+
+	// 令牌桶
+	for _, t := range targets {
+		if t.rule.RequestsPerSecond <= 0 {
+			continue
+		}
+		res, err := u.rateLimiterRepo.TokenBucket(ctx, t.key, t.rule.RequestsPerSecond, t.rule.Burst)
+		if err != nil {
+			log.WithContext(ctx).Warn("rateLimit TokenBucket", zap.String("key", t.key), zap.Error(err))
+			continue
+		}
+		if info.Remaining < 0 || res.Remaining < info.Remaining {
+			info.Limit, info.Remaining = t.rule.RequestsPerSecond, res.Remaining
+		}
+		if !res.Allowed {
+			info.RetryAfter = res.RetryAfter
+			return release, errorcode.Desc(errorcode.RateLimitError)
+		}
+	}
+
+	// 并发数
+	ttl := time.Duration(service.Timeout)*time.Second + concurrencyMinTTL
+	var acquired []string
+	releaseAcquired := func() {
+		c := context.WithoutCancel(ctx)
+		for _, key := range acquired {
+			if err := u.rateLimiterRepo.ReleaseConcurrency(c, key); err != nil {
+				log.WithContext(c).Warn("rateLimit ReleaseConcurrency", zap.String("key", key), zap.Error(err))
+			}
+		}
+	}
+	for _, t := range targets {
+		if t.rule.MaxConcurrent <= 0 {
+			continue
+		}
+		res, err := u.rateLimiterRepo.AcquireConcurrency(ctx, t.key, t.rule.MaxConcurrent, ttl)
+		if err != nil {
+			log.WithContext(ctx).Warn("rateLimit AcquireConcurrency", zap.String("key", t.key), zap.Error(err))
+			continue
+		}
+		if !res.Allowed {
+			info.RetryAfter = res.RetryAfter
+			releaseAcquired()
+			return release, errorcode.Desc(errorcode.ConcurrencyLimitError)
+		}
+		acquired = append(acquired, t.key)
+	}
+
+	// 每日配额
+	for _, t := range targets {
+		if t.rule.DailyQuota <= 0 {
+			continue
+		}
+		res, err := u.rateLimiterRepo.DailyQuota(ctx, t.key, t.rule.DailyQuota)
+		if err != nil {
+			log.WithContext(ctx).Warn("rateLimit DailyQuota", zap.String("key", t.key), zap.Error(err))
+			continue
+		}
+		if info.QuotaRemaining < 0 || res.Remaining < info.QuotaRemaining {
+			info.QuotaLimit, info.QuotaRemaining = t.rule.DailyQuota, res.Remaining
+		}
+		if !res.Allowed {
+			info.RetryAfter = res.RetryAfter
+			releaseAcquired()
+			return release, errorcode.Desc(errorcode.QuotaExceededError)
+		}
+	}
+
+	var once sync.Once
+	return func() { once.Do(releaseAcquired) }, nil
+}
+
+// releaseReadCloser 在关闭时释放限流占用的并发名额
+type releaseReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseReadCloser) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
+
+// IsRateLimitError 判断错误码是否为限流相关错误
+func IsRateLimitError(code string) bool {
+	switch code {
+	case errorcode.RateLimitError, errorcode.QuotaExceededError, errorcode.ConcurrencyLimitError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+)
+
+func Test_serviceRateLimitRule(t *testing.T) {
+	conf := &settings.RateLimit{
+		ServiceDefault: settings.RateLimitRule{RequestsPerSecond: 10, Burst: 20, MaxConcurrent: 5},
+		Services: map[string]settings.RateLimitRule{
+			"override": {RequestsPerSecond: 1, DailyQuota: 100},
+		},
+	}
+	tests := []struct {
+		name    string
+		service *model.Service
+		want    settings.RateLimitRule
+	}{
+		{
+			name:    "默认规则",
+			service: &model.Service{ServiceID: "default"},
+			want:    settings.RateLimitRule{RequestsPerSecond: 10, Burst: 20, MaxConcurrent: 5},
+		},
+		{
+			name:    "接口调用频次",
+			service: &model.Service{ServiceID: "default", RateLimiting: 3},
+			want:    settings.RateLimitRule{RequestsPerSecond: 3, MaxConcurrent: 5},
+		},
+		{
+			name:    "配置覆盖",
+			service: &model.Service{ServiceID: "override", RateLimiting: 3},
+			want:    settings.RateLimitRule{RequestsPerSecond: 1, DailyQuota: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, serviceRateLimitRule(conf, tt.service))
+		})
+	}
+}
+
+// 返回 429、503 的错误码与接口文档中的错误码分组一致
+func Test_errorStatus(t *testing.T) {
+	for _, code := range gateway_errorcode.Codes() {
+		status := gateway_errorcode.Status(code)
+		assert.Equal(t, status == http.StatusTooManyRequests, IsRateLimitError(code), code)
+		assert.Equal(t, status == http.StatusServiceUnavailable, IsCircuitOpenError(code), code)
+	}
+}
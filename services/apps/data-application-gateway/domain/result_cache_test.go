@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+)
+
+func Test_resultCacheKey(t *testing.T) {
+	updateTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newService := func() *model.ServiceAssociations {
+		return &model.ServiceAssociations{Service: model.Service{ServiceID: "s1", UpdateTime: updateTime}}
+	}
+	params := func(offset int, nonce string) map[string]*dto.Param {
+		return map[string]*dto.Param{
+			"name":          dto.NewParam("a", dto.ParamPositionQuery, dto.ParamDataTypeString),
+			dto.Offset:      dto.NewParam(offset, dto.ParamPositionQuery, dto.ParamDataTypeInt),
+			"x-tif-nonce":   dto.NewParam(nonce, dto.ParamPositionHeader, dto.ParamDataTypeString),
+			"x-tif-missing": nil,
+		}
+	}
+	base := resultCacheKey(newService(), 0, params(1, "n1"))
+
+	// 请求头参数不影响缓存 key
+	assert.Equal(t, base, resultCacheKey(newService(), 0, params(1, "n2")))
+	// 参数不同
+	assert.NotEqual(t, base, resultCacheKey(newService(), 0, params(2, "n1")))
+	// 缓存代数变化
+	assert.NotEqual(t, base, resultCacheKey(newService(), 1, params(1, "n1")))
+	// 接口重新发布
+	changed := newService()
+	changed.UpdateTime = updateTime.Add(time.Second)
+	assert.NotEqual(t, base, resultCacheKey(changed, 0, params(1, "n1")))
+	// 子服务行过滤规则不同
+	filtered := newService()
+	filtered.SubServices = []model.SubService{{RowFilterClause: "a = 1"}}
+	assert.NotEqual(t, base, resultCacheKey(filtered, 0, params(1, "n1")))
+}
+
+func Test_cacheHitPayload(t *testing.T) {
+	val := []byte(`{"total_count":12,"data":[{"id":12345678901234567890}],"total_count_type":"exact"}`)
+	assert.JSONEq(t, `{"total_count":12,"data":[{"id":12345678901234567890}],"total_count_type":"estimated"}`, string(cacheHitPayload(val)))
+
+	val = []byte(`{"total_count":-1,"data":[],"total_count_type":"omitted"}`)
+	assert.Equal(t, val, cacheHitPayload(val))
+
+	val = []byte(`{"total_count":3,"data":[]}`)
+	assert.Equal(t, val, cacheHitPayload(val))
+}
@@ -2,11 +2,15 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
+	"sync/atomic"
 	"time"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/call_record_spill"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/gorm"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
 	v1 "github.com/kweaver-ai/idrm-go-common/api/auth-service/v1"
 	"github.com/kweaver-ai/idrm-go-common/interception"
@@ -14,11 +18,35 @@ import (
 	"go.uber.org/zap"
 )
 
+// 调用记录缓冲区的默认配置
+const (
+	defaultCallRecordBufferSize    = 10000
+	defaultCallRecordBatchSize     = 200
+	defaultCallRecordFlushInterval = 2 * time.Second
+)
+
+// ServiceCallRecordDomain 接口调用记录。调用记录先进入内存缓冲区，由后台协程批量写入 service_call_record，
+// 不阻塞查询；数据库不可用时暂存到本地文件，恢复后补写。
 type ServiceCallRecordDomain struct {
 	serviceCallRecordRepo      gorm.ServiceCallRecordRepo
 	serviceRepo                gorm.ServiceRepo
 	configurationCenterRepo    microservice.ConfigurationCenterRepo
 	dataApplicationServiceRepo gorm.DataApplicationServiceRepo
+	callRecordSpill            call_record_spill.CallRecordSpill
+
+	entries       chan *callRecordEntry
+	batchSize     int
+	flushInterval time.Duration
+	// 缓冲区已满被丢弃的记录数
+	dropped atomic.Int64
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// callRecordEntry 缓冲区中的调用记录。接口 ID 等服务方信息由后台协程按接口路径补全，暂存文件中保存的也是该结构。
+type callRecordEntry struct {
+	ServicePath string                   `json:"service_path"`
+	Record      *model.ServiceCallRecord `json:"record"`
 }
 
 func NewServiceCallRecordDomain(
@@ -26,110 +54,237 @@ func NewServiceCallRecordDomain(
 	serviceRepo gorm.ServiceRepo,
 	configurationCenterRepo microservice.ConfigurationCenterRepo,
 	dataApplicationServiceRepo gorm.DataApplicationServiceRepo,
-) *ServiceCallRecordDomain {
-	return &ServiceCallRecordDomain{
+	callRecordSpill call_record_spill.CallRecordSpill,
+) (*ServiceCallRecordDomain, func()) {
+	conf := settings.Instance.CallRecord
+	s := &ServiceCallRecordDomain{
 		serviceRepo:                serviceRepo,
 		serviceCallRecordRepo:      serviceCallRecordRepo,
 		configurationCenterRepo:    configurationCenterRepo,
 		dataApplicationServiceRepo: dataApplicationServiceRepo,
+		callRecordSpill:            callRecordSpill,
+		entries:                    make(chan *callRecordEntry, positiveOr(conf.BufferSize, defaultCallRecordBufferSize)),
+		batchSize:                  positiveOr(conf.BatchSize, defaultCallRecordBatchSize),
+		flushInterval:              defaultCallRecordFlushInterval,
+		stop:                       make(chan struct{}),
+		done:                       make(chan struct{}),
+	}
+	if conf.FlushInterval > 0 {
+		s.flushInterval = time.Duration(conf.FlushInterval) * time.Second
+	}
+	go s.run()
+
+	// 退出时写入缓冲区中剩余的记录
+	return s, func() {
+		close(s.stop)
+		<-s.done
 	}
 }
 
-// RecordServiceCall 记录服务调用信息
-func (s *ServiceCallRecordDomain) RecordServiceCall(ctx context.Context, req *RecordServiceCallReq) error {
-
-	// 从 context 获取接调用者的信息，如果获取失败或调用者不是一个应用则禁止调用
-	subject, err := interception.AuthServiceSubjectFromContext(ctx)
-	log.WithContext(ctx).Warn("subject Info:", zap.Any("subject", subject))
-	if err != nil || subject.Type != v1.SubjectAPP {
-		return errorcode.Desc(errorcode.ServiceApplyNotPass)
-	}
-	req.UserIdentification = subject.ID
-	req.CallAppID = subject.ID
-
-	// if subject != nil {
-	// 	app, err := s.configurationCenterRepo.AppsGetById(ctx, &microservice.AppsID{Id: subject.ID})
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// 	req.CallAppID = app.ID
-	// 	req.CallInfoSystemID = app.InfoSystem.ID
-	// 	req.UserIdentification = app.AccountID
-	// 	uid := app.ApplicationDeveloper.UID
-	// 	if uid != "" {
-	// 		userDepart, err := s.configurationCenterRepo.GetUserIdDepart(ctx, uid)
-	// 		// 打印userDepart
-	// 		log.WithContext(ctx).Warn("userDepart Info:", zap.Any("userDepart", userDepart))
-	// 		if err != nil {
-	// 			return err
-	// 		}
-	// 		if len(userDepart) > 0 {
-	// 			//拼接部门id
-	// 			departmentID := ""
-	// 			for _, depart := range userDepart {
-	// 				departmentID += depart.ID + ","
-	// 			}
-	// 			//去掉最后一个逗号
-	// 			if departmentID != "" {
-	// 				departmentID = departmentID[:len(departmentID)-1]
-	// 			}
-	// 			req.CallDepartmentID = departmentID
-	// 		}
-	// 	}
-	// }
-
-	service, err := s.serviceRepo.ServiceGet(ctx, req.ServiceID)
+// RecordServiceCall 记录服务调用信息。只将记录放入缓冲区，缓冲区已满时丢弃，不阻塞调用方。
+func (s *ServiceCallRecordDomain) RecordServiceCall(ctx context.Context, req *RecordServiceCallReq) {
+	// 从 context 获取调用者的信息，调用者不是应用时仍记录调用，调用方为签名鉴权的应用或为空
+	if subject, err := interception.AuthServiceSubjectFromContext(ctx); err == nil && subject.Type == v1.SubjectAPP {
+		req.UserIdentification = subject.ID
+		req.CallAppID = subject.ID
+	}
+
+	var latency int64
+	if req.CallEndTime != nil {
+		latency = req.CallEndTime.Sub(req.CallStartTime).Milliseconds()
+	}
+	entry := &callRecordEntry{
+		ServicePath: req.ServiceID,
+		Record: &model.ServiceCallRecord{
+			ServiceDepartmentID: req.ServiceDepartmentID,
+			ServiceSystemID:     req.ServiceSystemID,
+			ServiceAppID:        req.ServiceAppID,
+			RemoteAddress:       req.RemoteAddress,
+			ForwardFor:          req.ForwardFor,
+			UserIdentification:  req.UserIdentification,
+			CallDepartmentID:    req.CallDepartmentID,
+			CallInfoSystemID:    req.CallInfoSystemID,
+			CallAppID:           req.CallAppID,
+			CallStartTime:       req.CallStartTime,
+			CallEndTime:         req.CallEndTime,
+			CallHTTPCode:        req.CallHTTPCode,
+			CallStatus:          req.CallStatus,
+			ErrorMessage:        req.ErrorMessage,
+			CallOtherMessage:    req.CallOtherMessage,
+			RecordTime:          time.Now(),
+			LatencyMs:           latency,
+			RowsReturned:        req.RowsReturned,
+			ResponseBytes:       req.ResponseBytes,
+			ErrorCode:           req.ErrorCode,
+		},
+	}
+
+	select {
+	case s.entries <- entry:
+	default:
+		// 每丢弃 1000 条记录输出一次日志，避免缓冲区满时日志过多
+		if n := s.dropped.Add(1); n%1000 == 1 {
+			log.WithContext(ctx).Warn("RecordServiceCall buffer full, call record dropped", zap.Int64("dropped", n))
+		}
+	}
+}
+
+// run 后台批量写入调用记录，缓冲区满一批或到达写入间隔时写入
+func (s *ServiceCallRecordDomain) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	// 启动时补写上次运行暂存的记录
+	pending := true
+	batch := make([]*callRecordEntry, 0, s.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			pending = s.flush(batch) || pending
+			batch = make([]*callRecordEntry, 0, s.batchSize)
+		}
+		if pending {
+			pending = !s.replay()
+		}
+	}
+
+	for {
+		select {
+		case entry := <-s.entries:
+			batch = append(batch, entry)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.stop:
+			for {
+				select {
+				case entry := <-s.entries:
+					batch = append(batch, entry)
+					if len(batch) >= s.batchSize {
+						flush()
+					}
+					continue
+				default:
+				}
+				break
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// flush 写入一批调用记录，写入失败时暂存到本地文件，返回是否有记录被暂存
+func (s *ServiceCallRecordDomain) flush(batch []*callRecordEntry) (spilled bool) {
+	ctx := context.Background()
+	err := s.save(ctx, batch)
+	if err == nil {
+		return false
+	}
+	log.WithContext(ctx).Error("ServiceCallRecordDomain flush", zap.Int("count", len(batch)), zap.Error(err))
+
+	lines := make([][]byte, 0, len(batch))
+	for _, entry := range batch {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := s.callRecordSpill.Write(lines); err != nil {
+		log.WithContext(ctx).Error("ServiceCallRecordDomain spill, call record dropped", zap.Int("count", len(lines)), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// replay 补写暂存的调用记录，返回是否已全部补写
+func (s *ServiceCallRecordDomain) replay() bool {
+	ctx := context.Background()
+	err := s.callRecordSpill.Replay(func(lines [][]byte) error {
+		batch := make([]*callRecordEntry, 0, len(lines))
+		for _, line := range lines {
+			entry := &callRecordEntry{}
+			if err := json.Unmarshal(line, entry); err != nil || entry.Record == nil {
+				log.WithContext(ctx).Warn("ServiceCallRecordDomain replay, invalid call record", zap.ByteString("line", line))
+				continue
+			}
+			batch = append(batch, entry)
+		}
+		return s.save(ctx, batch)
+	})
 	if err != nil {
-		return err
+		log.WithContext(ctx).Warn("ServiceCallRecordDomain replay", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// save 补全接口信息后批量插入调用记录，并按接口统计成功和失败次数
+func (s *ServiceCallRecordDomain) save(ctx context.Context, batch []*callRecordEntry) error {
+	services := make(map[string]*model.ServiceAssociations)
+	records := make([]*model.ServiceCallRecord, 0, len(batch))
+	for _, entry := range batch {
+		service, ok := services[entry.ServicePath]
+		if !ok {
+			var err error
+			service, err = s.serviceRepo.ServiceGet(ctx, entry.ServicePath)
+			if err != nil && !errorcode.IsErrorCode(err) {
+				return err
+			}
+			services[entry.ServicePath] = service
+		}
+		// 接口不存在时不记录
+		if service == nil {
+			continue
+		}
+		record := entry.Record
+		record.ServiceID = service.ServiceID
+		if record.ServiceDepartmentID == "" {
+			record.ServiceDepartmentID = service.DepartmentID
+		}
+		records = append(records, record)
 	}
 
-	if service != nil {
-		req.ServiceDepartmentID = service.DepartmentID
-	}
-
-	record := &model.ServiceCallRecord{
-		ServiceID:           service.ServiceID,
-		ServiceDepartmentID: req.ServiceDepartmentID,
-		ServiceSystemID:     req.ServiceSystemID,
-		ServiceAppID:        req.ServiceAppID,
-		RemoteAddress:       req.RemoteAddress,
-		ForwardFor:          req.ForwardFor,
-		UserIdentification:  req.UserIdentification,
-		CallDepartmentID:    req.CallDepartmentID,
-		CallInfoSystemID:    req.CallInfoSystemID,
-		CallAppID:           req.CallAppID,
-		CallStartTime:       req.CallStartTime,
-		CallEndTime:         req.CallEndTime,
-		CallHTTPCode:        req.CallHTTPCode,
-		CallStatus:          req.CallStatus,
-		ErrorMessage:        req.ErrorMessage,
-		CallOtherMessage:    req.CallOtherMessage,
-		RecordTime:          time.Now(),
-	}
-
-	// 记录服务调用详情
-	if err := s.serviceCallRecordRepo.Create(ctx, record); err != nil {
+	// 只统计新插入的记录，补写时已写入的记录不重复计数
+	inserted, err := s.serviceCallRecordRepo.CreateInBatches(ctx, records)
+	if err != nil {
 		return err
 	}
 
-	// 根据调用状态统计成功或失败次数
-	if req.CallStatus == 1 {
-		// 调用成功，增加成功次数
-		if err := s.dataApplicationServiceRepo.IncrementSuccessCount(ctx, service.ServiceID); err != nil {
-			log.WithContext(ctx).Error("记录成功调用次数失败", zap.Error(err), zap.String("serviceID", req.ServiceID))
-			// 不返回错误，避免影响主流程
+	// 统计失败不影响调用记录
+	type counts struct{ success, fail int }
+	byService := make(map[string]*counts)
+	for _, record := range inserted {
+		c, ok := byService[record.ServiceID]
+		if !ok {
+			c = &counts{}
+			byService[record.ServiceID] = c
 		}
-	} else {
-		// 调用失败，增加失败次数
-		if err := s.dataApplicationServiceRepo.IncrementFailCount(ctx, service.ServiceID); err != nil {
-			log.WithContext(ctx).Error("记录失败调用次数失败", zap.Error(err), zap.String("serviceID", req.ServiceID))
-			// 不返回错误，避免影响主流程
+		if record.CallStatus == 1 {
+			c.success++
+		} else {
+			c.fail++
+		}
+	}
+	for serviceID, c := range byService {
+		if err := s.dataApplicationServiceRepo.IncrementCounts(ctx, serviceID, c.success, c.fail); err != nil {
+			log.WithContext(ctx).Error("记录调用次数失败", zap.Error(err), zap.String("serviceID", serviceID))
 		}
 	}
-
 	return nil
 }
 
+func positiveOr(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
 // RecordServiceCallReq 记录服务调用请求参数
 type RecordServiceCallReq struct {
 	ServiceID           string     `json:"service_id"`
@@ -148,4 +303,7 @@ type RecordServiceCallReq struct {
 	CallStatus          int        `json:"call_status"`
 	ErrorMessage        string     `json:"error_message"`
 	CallOtherMessage    string     `json:"call_other_message"`
+	RowsReturned        int64      `json:"rows_returned"`
+	ResponseBytes       int64      `json:"response_bytes"`
+	ErrorCode           string     `json:"error_code"`
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+)
+
+// fakeServiceCallRecordRepo 主键已存在的记录不插入
+type fakeServiceCallRecordRepo struct {
+	gorm.ServiceCallRecordRepo
+	saved map[int64]bool
+}
+
+func (r *fakeServiceCallRecordRepo) CreateInBatches(ctx context.Context, records []*model.ServiceCallRecord) ([]*model.ServiceCallRecord, error) {
+	var inserted []*model.ServiceCallRecord
+	for _, record := range records {
+		if !r.saved[record.ID] {
+			r.saved[record.ID] = true
+			inserted = append(inserted, record)
+		}
+	}
+	return inserted, nil
+}
+
+type fakeServiceRepo struct {
+	gorm.ServiceRepo
+}
+
+func (r *fakeServiceRepo) ServiceGet(ctx context.Context, servicePath string) (*model.ServiceAssociations, error) {
+	return &model.ServiceAssociations{Service: model.Service{ServiceID: servicePath}}, nil
+}
+
+type fakeDataApplicationServiceRepo struct {
+	gorm.DataApplicationServiceRepo
+	success, fail map[string]int
+}
+
+func (r *fakeDataApplicationServiceRepo) IncrementCounts(ctx context.Context, serviceID string, success, fail int) error {
+	r.success[serviceID] += success
+	r.fail[serviceID] += fail
+	return nil
+}
+
+// 补写已部分写入的一批调用记录时，只统计新插入的记录
+func Test_ServiceCallRecordDomain_save(t *testing.T) {
+	counts := &fakeDataApplicationServiceRepo{success: map[string]int{}, fail: map[string]int{}}
+	s := &ServiceCallRecordDomain{
+		serviceCallRecordRepo:      &fakeServiceCallRecordRepo{saved: map[int64]bool{1: true}},
+		serviceRepo:                &fakeServiceRepo{},
+		dataApplicationServiceRepo: counts,
+	}
+	batch := []*callRecordEntry{
+		{ServicePath: "s1", Record: &model.ServiceCallRecord{ID: 1, CallStatus: 1}},
+		{ServicePath: "s1", Record: &model.ServiceCallRecord{ID: 2, CallStatus: 1}},
+		{ServicePath: "s1", Record: &model.ServiceCallRecord{ID: 3, CallStatus: 0}},
+		{ServicePath: "s2", Record: &model.ServiceCallRecord{ID: 4, CallStatus: 1}},
+	}
+	assert.NoError(t, s.save(context.Background(), batch))
+	assert.Equal(t, map[string]int{"s1": 1, "s2": 1}, counts.success)
+	assert.Equal(t, map[string]int{"s1": 1, "s2": 0}, counts.fail)
+
+	// 重复补写同一批记录不再计数
+	assert.NoError(t, s.save(context.Background(), batch))
+	assert.Equal(t, map[string]int{"s1": 1, "s2": 1}, counts.success)
+	assert.Equal(t, map[string]int{"s1": 1, "s2": 0}, counts.fail)
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/virtual_engine"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/trace"
+)
+
+// 默认流式返回时单次请求的最大行数
+const defaultStreamMaxRows = 1000000
+
+// streamMaxRows 流式返回时单次请求的最大行数
+func streamMaxRows() int {
+	if settings.Instance.Stream.MaxRows > 0 {
+		return settings.Instance.Stream.MaxRows
+	}
+	return defaultStreamMaxRows
+}
+
+// serviceGenerateStream 逐行流式返回接口生成的查询结果，不查询总数，也不在内存中缓存结果集。
+// 开始返回数据之前的错误直接返回；返回过程中的错误会中断响应。
+// 已写出的行数累加到 req.RowsReturned，关闭返回的结果后 req.NextCursor、req.StreamErr 可用。
+func (u *QueryDomain) serviceGenerateStream(c context.Context, req *dto.QueryReq, service *model.ServiceAssociations) (res io.ReadCloser, err error) {
+	c, span := trace.StartInternalSpan(c)
+	defer func() { trace.TelemetrySpanEnd(span, err) }()
+
+	params := req.Params
+	page, err := newCursorPage(service, params)
+	if err != nil {
+		return nil, err
+	}
+	script, _, err := u.serviceGenerateScript(c, page.apply(params), service, page.seek)
+	if err != nil {
+		return nil, err
+	}
+	it, err := u.virtualEngineRepo.FetchStream(c, script, service.Timeout, service.ServiceResponseFilters)
+	if err != nil {
+		return nil, err
+	}
+	it = newObligationRowIterator(it, service.Obligations.Columns)
+
+	pr, pw := io.Pipe()
+	stream := &streamReadCloser{PipeReader: pr, done: make(chan struct{})}
+	go func() {
+		defer close(stream.done)
+		defer it.Close()
+		columns := lo.Map(it.Columns(), func(column virtual_engine.Column, _ int) string { return column.Name })
+		tracker := newCursorTracker(service, columns)
+		err := writeRows(pw, req.Format, it, page.skip, &req.RowsReturned, tracker)
+		switch {
+		case err == nil:
+			// 本页已满，或结果过滤导致本页不足一页时，仍可能有下一页
+			if tracker.rows >= page.pageLimit() || len(service.ServiceResponseFilters) > 0 {
+				req.NextCursor = page.cursorAfter(service, params, tracker.last, tracker.ties, tracker.rows)
+			}
+		case !errors.Is(err, io.ErrClosedPipe):
+			log.WithContext(c).Error("serviceGenerateStream", zap.String("service_id", service.ServiceID), zap.Error(err))
+			req.StreamErr = err
+		}
+		pw.CloseWithError(err)
+	}()
+	return stream, nil
+}
+
+// streamReadCloser 流式返回的查询结果，关闭时中断并等待写出结束
+type streamReadCloser struct {
+	*io.PipeReader
+	done chan struct{}
+}
+
+func (r *streamReadCloser) Close() error {
+	err := r.PipeReader.Close()
+	<-r.done
+	return err
+}
+
+// writeRows 按格式逐行写出查询结果，跳过前 skip 行，已写出的行数累加到 rows，已写出的行记录到 tracker
+func writeRows(w io.Writer, format dto.ResultFormat, it virtual_engine.RowIterator, skip int, rows *int64, tracker *cursorTracker) error {
+	bw := bufio.NewWriter(w)
+	columns := it.Columns()
+
+	var csvWriter *csv.Writer
+	if format == dto.ResultFormatCSV {
+		csvWriter = csv.NewWriter(bw)
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.Name
+		}
+		if err := csvWriter.Write(header); err != nil {
+			return err
+		}
+	}
+
+	// 列名只需序列化一次
+	names := make([][]byte, len(columns))
+	for i, column := range columns {
+		names[i], _ = json.Marshal(column.Name)
+	}
+
+	record := make([]string, len(columns))
+	for {
+		row, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		switch format {
+		case dto.ResultFormatCSV:
+			for i := range columns {
+				record[i] = ""
+				if i < len(row) && row[i] != nil {
+					record[i] = cast.ToString(row[i])
+				}
+			}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		default:
+			if err := writeNDJSONRow(bw, names, row); err != nil {
+				return err
+			}
+		}
+		atomic.AddInt64(rows, 1)
+		tracker.add(row)
+	}
+
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// writeNDJSONRow 按列顺序写出一行 JSON 对象
+func writeNDJSONRow(w *bufio.Writer, names [][]byte, row []interface{}) error {
+	w.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.Write(name)
+		w.WriteByte(':')
+		var value interface{}
+		if i < len(row) {
+			value = row[i]
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		w.Write(b)
+	}
+	w.WriteByte('}')
+	_, err := w.WriteString("\n")
+	return err
+}
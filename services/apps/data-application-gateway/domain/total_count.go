@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// withTotal 请求是否需要返回总数。未传时默认返回，传了时只有 true 才返回
+func withTotal(params map[string]*dto.Param) bool {
+	switch v := paramValue(params, dto.WithTotal).(type) {
+	case nil:
+		return true
+	case bool:
+		return v
+	case string:
+		return v == "" || v == "true"
+	default:
+		return false
+	}
+}
+
+// countCacheKey 总数的缓存 key，相同的查询条件共用，分页参数不参与计算
+func countCacheKey(service *model.ServiceAssociations, generation int64, params map[string]*dto.Param) string {
+	// 与查询结果缓存 key 的计算方式一致，去掉分页参数
+	filtered := make(map[string]*dto.Param, len(params))
+	for k, v := range params {
+		switch k {
+		case dto.Offset, dto.Limit, dto.Cursor, dto.WithTotal:
+			continue
+		}
+		filtered[k] = v
+	}
+	return "count:" + resultCacheKey(service, generation, filtered)
+}
+
+// totalCount 查询总数。
+// 请求参数 with_total 不为 true 时不查询；接口设置了总数缓存时间时，相同查询条件在缓存时间内复用上次的总数。
+// redis 不可用时查询准确总数。
+func (u *QueryDomain) totalCount(c context.Context, params map[string]*dto.Param, service *model.ServiceAssociations, scriptCount string) (int64, dto.TotalCountType, error) {
+	if !withTotal(params) {
+		return -1, dto.TotalCountOmitted, nil
+	}
+	if service.CountCacheTTL == 0 {
+		total, err := u.virtualEngineRepo.FetchCount(c, scriptCount, service.Timeout)
+		return total, dto.TotalCountExact, err
+	}
+
+	generation, err := u.resultCacheRepo.Generation(c, service.ServiceID)
+	if err != nil {
+		log.WithContext(c).Warn("totalCount Generation", zap.String("service_id", service.ServiceID), zap.Error(err))
+		total, err := u.virtualEngineRepo.FetchCount(c, scriptCount, service.Timeout)
+		return total, dto.TotalCountExact, err
+	}
+	key := countCacheKey(service, generation, params)
+	val, ok, err := u.resultCacheRepo.Get(c, key)
+	if err != nil {
+		log.WithContext(c).Warn("totalCount Get", zap.String("key", key), zap.Error(err))
+	} else if ok {
+		if total, err := strconv.ParseInt(string(val), 10, 64); err == nil {
+			return total, dto.TotalCountEstimated, nil
+		}
+	}
+
+	total, err := u.virtualEngineRepo.FetchCount(c, scriptCount, service.Timeout)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := u.resultCacheRepo.Set(c, key, []byte(strconv.FormatInt(total, 10)), time.Duration(service.CountCacheTTL)*time.Second); err != nil {
+		log.WithContext(c).Warn("totalCount Set", zap.String("key", key), zap.Error(err))
+	}
+	return total, dto.TotalCountExact, nil
+}
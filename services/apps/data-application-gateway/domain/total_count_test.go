@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+)
+
+func Test_countCacheKey(t *testing.T) {
+	service := &model.ServiceAssociations{Service: model.Service{ServiceID: "s1"}}
+	params := func(offset int, withTotal string) map[string]*dto.Param {
+		return map[string]*dto.Param{
+			"name":        dto.NewParam("a", dto.ParamPositionQuery, dto.ParamDataTypeString),
+			dto.Offset:    dto.NewParam(offset, dto.ParamPositionQuery, dto.ParamDataTypeInt),
+			dto.WithTotal: dto.NewParam(withTotal, dto.ParamPositionQuery, dto.ParamDataTypeString),
+		}
+	}
+	// 分页参数不影响总数
+	assert.Equal(t, countCacheKey(service, 0, params(1, "true")), countCacheKey(service, 0, params(5, "")))
+	// 与查询结果缓存 key 不冲突
+	assert.NotEqual(t, resultCacheKey(service, 0, params(1, "true")), countCacheKey(service, 0, params(1, "true")))
+}
+
+func Test_withTotal(t *testing.T) {
+	param := func(v interface{}) map[string]*dto.Param {
+		return map[string]*dto.Param{dto.WithTotal: dto.NewParam(v, dto.ParamPositionQuery, dto.ParamDataTypeString)}
+	}
+	// 未传时默认返回总数
+	assert.True(t, withTotal(map[string]*dto.Param{}))
+	assert.True(t, withTotal(map[string]*dto.Param{dto.WithTotal: nil}))
+	assert.True(t, withTotal(param("")))
+	assert.True(t, withTotal(param("true")))
+	assert.True(t, withTotal(param(true)))
+	// 只有明确的 true 才返回总数
+	assert.False(t, withTotal(param("false")))
+	assert.False(t, withTotal(param(false)))
+	assert.False(t, withTotal(param("1")))
+	assert.False(t, withTotal(param("yes")))
+	assert.False(t, withTotal(param(1)))
+}
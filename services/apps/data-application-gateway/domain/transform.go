@@ -0,0 +1,289 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/desensitization"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// parseTransformRules 解析接口注册类接口的转换规则，未配置时返回 nil
+func parseTransformRules(service *model.ServiceAssociations) (*dto.TransformRules, error) {
+	if service.TransformRules == "" {
+		return nil, nil
+	}
+	rules := &dto.TransformRules{}
+	if err := json.Unmarshal([]byte(service.TransformRules), rules); err != nil {
+		return nil, errorcode.Detail(errorcode.ResponseTransformError, err.Error())
+	}
+	return rules, nil
+}
+
+// transformRequestParams 按请求转换规则生成转发到后台服务的参数，不修改调用方传入的参数。
+// 被映射的来源参数不再转发，未配置规则的参数原样转发，映射的来源参数不存在时跳过该规则。
+func transformRequestParams(params map[string]*dto.Param, rules *dto.RequestTransform) map[string]*dto.Param {
+	type target struct {
+		mapping  dto.ParamMapping
+		position dto.ParamPosition
+		value    any
+	}
+
+	var targets []target
+	consumed := make(map[string]bool)
+	collect := func(mappings []dto.ParamMapping, position dto.ParamPosition) {
+		for _, m := range mappings {
+			if m.From == "" {
+				targets = append(targets, target{mapping: m, position: position, value: m.Value})
+				continue
+			}
+			value, ok := lookupParam(params, m.From)
+			if !ok {
+				continue
+			}
+			targets = append(targets, target{mapping: m, position: position, value: value})
+			consumed[m.From] = true
+		}
+	}
+	collect(rules.Headers, dto.ParamPositionHeader)
+	collect(rules.Query, dto.ParamPositionQuery)
+	collect(rules.Body, dto.ParamPositionBody)
+
+	res := make(map[string]*dto.Param, len(params))
+	for name, p := range params {
+		if !consumed[name] {
+			res[name] = p
+		}
+	}
+	for name := range consumed {
+		// 嵌套字段只移除被映射的字段，保留同一个请求体参数中的其他字段
+		if head, rest, nested := strings.Cut(name, "."); nested {
+			if p, ok := res[head]; ok {
+				if obj, ok := p.Value.(map[string]any); ok {
+					obj = cloneObject(obj)
+					deletePath(obj, strings.Split(rest, "."))
+					res[head] = dto.NewParam(obj, p.Position, p.DataType)
+				}
+			}
+		}
+	}
+
+	for _, t := range targets {
+		if t.position != dto.ParamPositionBody {
+			res[t.mapping.To] = dto.NewParam(t.value, t.position, dto.ParamDataTypeString)
+			continue
+		}
+		head, rest, nested := strings.Cut(t.mapping.To, ".")
+		if !nested {
+			res[head] = dto.NewParam(t.value, dto.ParamPositionBody, dto.ParamDataTypeString)
+			continue
+		}
+		obj := map[string]any{}
+		if p, ok := res[head]; ok && p.Position == dto.ParamPositionBody {
+			if v, ok := p.Value.(map[string]any); ok {
+				obj = cloneObject(v)
+			}
+		}
+		setPath(obj, strings.Split(rest, "."), t.value)
+		res[head] = dto.NewParam(obj, dto.ParamPositionBody, dto.ParamDataTypeString)
+	}
+	return res
+}
+
+// lookupParam 获取调用方传入的参数值，name 以 . 分隔时读取请求体参数中的嵌套字段
+func lookupParam(params map[string]*dto.Param, name string) (any, bool) {
+	if p, ok := params[name]; ok {
+		return p.Value, true
+	}
+	head, rest, nested := strings.Cut(name, ".")
+	if !nested {
+		return nil, false
+	}
+	p, ok := params[head]
+	if !ok {
+		return nil, false
+	}
+	return lookupPath(p.Value, strings.Split(rest, "."))
+}
+
+func lookupPath(v any, path []string) (any, bool) {
+	for _, key := range path {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func setPath(obj map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := obj[key].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			obj[key] = child
+		}
+		obj = child
+	}
+	obj[path[len(path)-1]] = value
+}
+
+func deletePath(obj map[string]any, path []string) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := obj[key].(map[string]any)
+		if !ok {
+			return
+		}
+		obj = child
+	}
+	delete(obj, path[len(path)-1])
+}
+
+// cloneObject 深拷贝嵌套的对象，避免修改调用方传入的请求体
+func cloneObject(obj map[string]any) map[string]any {
+	res := make(map[string]any, len(obj))
+	for k, v := range obj {
+		if child, ok := v.(map[string]any); ok {
+			v = cloneObject(child)
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// transformResponseBody 读取后台服务返回的 JSON，按返回结果转换规则重命名、过滤和脱敏字段
+func (u *QueryDomain) transformResponseBody(c context.Context, res io.ReadCloser, rules *dto.ResponseTransform) (length int64, body io.ReadCloser, err error) {
+	defer res.Close()
+
+	b, err := io.ReadAll(res)
+	if err != nil {
+		log.WithContext(c).Error("transformResponseBody", zap.Error(err))
+		return 0, nil, errorcode.Detail(errorcode.BackendUnavailable, err.Error())
+	}
+
+	desensitizationRules := make(map[string]*microservice.DesensitizationRule)
+	var ids []string
+	for _, f := range rules.Fields {
+		if f.DesensitizationRuleID != "" {
+			ids = append(ids, f.DesensitizationRuleID)
+		}
+	}
+	if len(ids) > 0 {
+		list, err := u.dataViewRepo.DesensitizationRulesGet(c, ids)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, r := range list {
+			desensitizationRules[r.ID] = r
+		}
+	}
+
+	b, err = transformResponse(b, rules, desensitizationRules)
+	if err != nil {
+		log.WithContext(c).Error("transformResponseBody", zap.Error(err))
+		return 0, nil, err
+	}
+	return int64(len(b)), io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// transformResponse 转换返回结果。Root 指向的记录可以是对象或对象数组，数组中不是对象的元素原样返回
+func transformResponse(b []byte, rules *dto.ResponseTransform, desensitizationRules map[string]*microservice.DesensitizationRule) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, errorcode.Detail(errorcode.ResponseTransformError, err.Error())
+	}
+
+	records := doc
+	if rules.Root != "" {
+		var ok bool
+		if records, ok = lookupPath(doc, strings.Split(rules.Root, ".")); !ok {
+			return nil, errorcode.Detail(errorcode.ResponseTransformError, "返回结果中不存在 "+rules.Root)
+		}
+	}
+
+	switch v := records.(type) {
+	case map[string]any:
+		transformRecord(v, rules, desensitizationRules)
+	case []any:
+		for _, item := range v {
+			if record, ok := item.(map[string]any); ok {
+				transformRecord(record, rules, desensitizationRules)
+			}
+		}
+	default:
+		return nil, errorcode.Detail(errorcode.ResponseTransformError, rules.Root+" 不是对象或数组")
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, errorcode.Detail(errorcode.ResponseTransformError, err.Error())
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// transformRecord 原地转换一条记录，先移除所有被映射的字段再写入，字段互换名称时不会互相覆盖
+func transformRecord(record map[string]any, rules *dto.ResponseTransform, desensitizationRules map[string]*microservice.DesensitizationRule) {
+	values := make(map[string]any, len(rules.Fields))
+	for _, f := range rules.Fields {
+		v, ok := record[f.From]
+		if !ok {
+			continue
+		}
+		if f.DesensitizationRuleID != "" {
+			v = desensitizeValue(v, desensitizationRules[f.DesensitizationRuleID])
+		}
+		to := f.To
+		if to == "" {
+			to = f.From
+		}
+		values[to] = v
+	}
+
+	if rules.Project {
+		clear(record)
+	} else {
+		for _, f := range rules.Fields {
+			delete(record, f.From)
+		}
+	}
+	for k, v := range values {
+		record[k] = v
+	}
+}
+
+// desensitizeValue 脱敏字段值，null 原样返回。脱敏规则不存在时全部脱敏，避免泄露数据
+func desensitizeValue(v any, rule *microservice.DesensitizationRule) any {
+	if v == nil {
+		return nil
+	}
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if rule == nil {
+		return desensitization.Desensitize(s, desensitization.MethodAll, 0, 0, 0)
+	}
+	return desensitization.Desensitize(s, rule.Method, int(rule.MiddleBit), int(rule.HeadBit), int(rule.TailBit))
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/adapter/driven/microservice"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-gateway/common/dto"
+)
+
+func Test_desensitizeValue(t *testing.T) {
+	rule := &microservice.DesensitizationRule{Method: "middle", MiddleBit: 3}
+	assert.Nil(t, desensitizeValue(nil, rule))
+	assert.Equal(t, "13***678", desensitizeValue("13345678", rule))
+	assert.Equal(t, "13***678", desensitizeValue(json.Number("13345678"), rule))
+	assert.Equal(t, "**", desensitizeValue(12, rule))
+	// 脱敏规则不存在时全部脱敏
+	assert.Equal(t, "******", desensitizeValue("abcdef", nil))
+}
+
+func Test_transformRequestParams(t *testing.T) {
+	params := map[string]*dto.Param{
+		"userId": dto.NewParam("u1", dto.ParamPositionQuery, dto.ParamDataTypeString),
+		"page":   dto.NewParam(1, dto.ParamPositionQuery, dto.ParamDataTypeInt),
+		"filter": dto.NewParam(map[string]any{"name": "n1", "age": 1}, dto.ParamPositionBody, dto.ParamDataTypeString),
+	}
+	res := transformRequestParams(params, &dto.RequestTransform{
+		Headers: []dto.ParamMapping{{To: "X-Tenant", Value: "t1"}},
+		Query:   []dto.ParamMapping{{From: "userId", To: "user_id"}, {From: "missing", To: "m"}},
+		Body:    []dto.ParamMapping{{From: "filter.name", To: "query.name"}},
+	})
+
+	assert.Equal(t, dto.NewParam("t1", dto.ParamPositionHeader, dto.ParamDataTypeString), res["X-Tenant"])
+	assert.Equal(t, "u1", res["user_id"].Value)
+	assert.NotContains(t, res, "userId")
+	assert.NotContains(t, res, "m")
+	assert.Equal(t, 1, res["page"].Value)
+	assert.Equal(t, map[string]any{"age": 1}, res["filter"].Value)
+	assert.Equal(t, map[string]any{"name": "n1"}, res["query"].Value)
+	// 不修改调用方传入的参数
+	assert.Contains(t, params, "userId")
+	assert.Equal(t, map[string]any{"name": "n1", "age": 1}, params["filter"].Value)
+}
+
+func Test_transformResponse(t *testing.T) {
+	rules := map[string]*microservice.DesensitizationRule{
+		"r1": {ID: "r1", Method: "middle", MiddleBit: 4},
+	}
+	body := []byte(`{"code":0,"data":{"entries":[{"id":1,"mobile":"13800001234","name":"a","secret":"s"}]}}`)
+
+	res, err := transformResponse(body, &dto.ResponseTransform{
+		Root: "data.entries",
+		Fields: []dto.FieldMapping{
+			{From: "mobile", To: "phone", DesensitizationRuleID: "r1"},
+			{From: "secret", DesensitizationRuleID: "unknown"},
+		},
+	}, rules)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code":0,"data":{"entries":[{"id":1,"phone":"138****1234","name":"a","secret":"*"}]}}`, string(res))
+
+	res, err = transformResponse(body, &dto.ResponseTransform{
+		Root:    "data.entries",
+		Project: true,
+		Fields:  []dto.FieldMapping{{From: "id"}, {From: "name", To: "title"}},
+	}, rules)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code":0,"data":{"entries":[{"id":1,"title":"a"}]}}`, string(res))
+
+	_, err = transformResponse(body, &dto.ResponseTransform{Root: "data.items"}, rules)
+	assert.Error(t, err)
+	_, err = transformResponse([]byte("not json"), &dto.ResponseTransform{}, rules)
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ require (
 	github.com/jinzhu/copier v0.4.0
 	github.com/json-iterator/go v1.1.12
 	github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2 v2.10.2
+	github.com/kweaver-ai/dsg/services/lib/common v0.0.0-00010101000000-000000000000
 	github.com/kweaver-ai/idrm-go-common v0.1.4-0.20260119010937-2456e402a095
 	github.com/kweaver-ai/idrm-go-frame v0.1.3
 	github.com/redis/go-redis/v9 v9.17.2
@@ -199,3 +200,5 @@ require (
 )
 
 exclude github.com/ugorji/go v1.1.4
+
+replace github.com/kweaver-ai/dsg/services/lib/common => ../../lib/common
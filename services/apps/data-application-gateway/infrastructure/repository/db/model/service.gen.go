@@ -53,6 +53,13 @@ type Service struct {
 	DeveloperName      string    `gorm:"column:developer_name;type:varchar(255);not null" json:"developer_name"`                   // 开发商名称
 	RateLimiting       uint32    `gorm:"column:rate_limiting;type:int(10) unsigned;not null" json:"rate_limiting"`                 // 调用频次 次/秒
 	Timeout            uint32    `gorm:"column:timeout;type:int(10) unsigned;not null" json:"timeout"`                             // 超时时间 秒
+	CacheTTL           uint32    `gorm:"column:cache_ttl;type:int(10) unsigned;not null" json:"cache_ttl"`                         // 结果缓存时间 秒，0 不缓存
+	CountCacheTTL      uint32    `gorm:"column:count_cache_ttl;type:int(10) unsigned;not null" json:"count_cache_ttl"`             // 总数缓存时间 秒，0 每次查询准确总数
+	TransformRules     string    `gorm:"column:transform_rules;type:text" json:"transform_rules"`                                  // 接口注册的请求与返回结果转换规则 JSON
+	VersionGroupID     string    `gorm:"column:version_group_id;type:varchar(36);not null" json:"version_group_id"`                // 版本组id，为空表示未启用多版本
+	Version            string    `gorm:"column:version;type:varchar(32);not null" json:"version"`                                  // 语义化版本号
+	DeprecatedAt       time.Time `gorm:"column:deprecated_at;type:datetime" json:"deprecated_at"`                                  // 废弃时间
+	SunsetAt           time.Time `gorm:"column:sunset_at;type:datetime" json:"sunset_at"`                                          // 计划下线时间
 	ServiceType        string    `gorm:"column:service_type;type:varchar(20);not null" json:"service_type"`                        // 接口类型 service_generate 接口生成 service_register 接口注册
 	FlowID             string    `gorm:"column:flow_id;type:varchar(50);not null" json:"flow_id"`                                  // 审核流程实例id
 	FlowName           string    `gorm:"column:flow_name;type:varchar(200);not null" json:"flow_name"`                             // 审核流程名称
@@ -10,4 +10,14 @@ type ServiceAssociations struct {
 	ServiceParams          []ServiceParam          `gorm:"foreignKey:service_id;references:service_id"`
 	ServiceResponseFilters []ServiceResponseFilter `gorm:"foreignKey:service_id;references:service_id"`
 	SubServices            []SubService            `gorm:"foreignKey:service_id;references:service_id"`
+	// 调用方生效的属性策略，查询时按调用方填充
+	Obligations ServiceObligations `gorm:"-"`
+}
+
+// ServiceObligations 属性策略要求对接口查询结果的处理
+type ServiceObligations struct {
+	// 返回参数英文名称 -> 处理方式 mask 脱敏 hide 隐藏
+	Columns map[string]string `json:"columns,omitempty"`
+	// 行过滤条件，与子服务的行过滤规则是且的关系
+	RowFilter string `json:"row_filter,omitempty"`
 }
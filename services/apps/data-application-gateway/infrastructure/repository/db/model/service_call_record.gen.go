@@ -33,6 +33,10 @@ type ServiceCallRecord struct {
 	ErrorMessage        string    `gorm:"column:error_message;type:text;comment:报错信息" json:"error_message"`                                   // 报错信息
 	CallOtherMessage    string    `gorm:"column:call_other_message;type:text;comment:其他调用信息（预留）" json:"call_other_message"`                   // 其他调用信息（预留）
 	RecordTime          time.Time `gorm:"column:record_time;type:datetime;comment:日志记录时间" json:"record_time"`                                  // 日志记录时间
+	LatencyMs           int64     `gorm:"column:latency_ms;type:bigint(20);comment:调用耗时 毫秒" json:"latency_ms"`                             // 调用耗时 毫秒
+	RowsReturned        int64     `gorm:"column:rows_returned;type:bigint(20);comment:返回行数" json:"rows_returned"`                          // 返回行数
+	ResponseBytes       int64     `gorm:"column:response_bytes;type:bigint(20);comment:响应字节数" json:"response_bytes"`                      // 响应字节数
+	ErrorCode           string    `gorm:"column:error_code;type:varchar(255);comment:错误码" json:"error_code"`                                 // 错误码
 }
 
 func (m *ServiceCallRecord) BeforeCreate(_ *gorm.DB) error {
@@ -3,26 +3,39 @@ package callbacks
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/database_callback/callback"
 )
 
 type EntityChangeTransport struct {
-	sender *mq.MQ
+	outbox outbox.Outbox
 }
 
-func NewEntityChangeTransport(sender *mq.MQ) *EntityChangeTransport {
+func NewEntityChangeTransport(outbox outbox.Outbox) *EntityChangeTransport {
 	return &EntityChangeTransport{
-		sender: sender,
+		outbox: outbox,
 	}
 }
 
+// Send 在触发回调的事务中把实体变更消息写入发件箱，事务提交后发送到 kafka
 func (e *EntityChangeTransport) Send(ctx context.Context, body any) error {
 	bts, _ := json.Marshal(body)
-	return e.sender.KafkaClient.Pub(mq.TopicGraphEntityChange, bts)
+	return e.outbox.Enqueue(ctx, outbox.TxFromContext(ctx), mq.TopicGraphEntityChange, entityChangeKey(body), bts)
 }
 
 func (e *EntityChangeTransport) Process(ctx context.Context, model callback.DataModel, tableName, operation string) (any, error) {
 	return model, nil
 }
+
+// entityChangeKey 同一实体的变更消息按顺序发送，无法确定实体时整个主题按顺序发送
+func entityChangeKey(body any) string {
+	if m, ok := body.(callback.DataModel); ok {
+		if id, ok := m["id"]; ok {
+			return fmt.Sprintf("%v", id)
+		}
+	}
+	return mq.TopicGraphEntityChange
+}
@@ -0,0 +1,29 @@
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
+	"github.com/kweaver-ai/idrm-go-common/workflow"
+	"github.com/kweaver-ai/idrm-go-common/workflow/common"
+)
+
+// NewOutboxPublishers 发件箱中各主题消息的发送方式
+func NewOutboxPublishers(m *mq.MQ, wf workflow.WorkflowInterface) outbox.Publishers {
+	return outbox.Publishers{
+		// 实体变更消息
+		mq.TopicGraphEntityChange: func(ctx context.Context, msg *outbox.Message) error {
+			return m.KafkaClient.Pub(msg.Topic, []byte(msg.Payload))
+		},
+		// 发起审核申请
+		mq.TopicWorkflowAuditApply: func(ctx context.Context, msg *outbox.Message) error {
+			apply := &common.AuditApplyMsg{}
+			if err := json.Unmarshal([]byte(msg.Payload), apply); err != nil {
+				return err
+			}
+			return wf.AuditApply(apply)
+		},
+	}
+}
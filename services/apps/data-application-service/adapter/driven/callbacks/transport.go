@@ -1,8 +1,12 @@
 package callbacks
 
 import (
+	"go.uber.org/zap"
+
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/database_callback/callback"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
 	"gorm.io/gorm"
 )
 
@@ -23,6 +27,10 @@ func NewTransport(
 
 // Register 注册
 func (t *Transports) Register() {
+	// 实体变更消息在触发回调的事务中写入发件箱
+	if err := outbox.RegisterTxCallbacks(t.db); err != nil {
+		log.Error("Transports Register outbox callbacks", zap.Error(err))
+	}
 	callback.Init(t.db)
 
 	//业务架构图谱
@@ -6,12 +6,16 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq/consumer/service"
 
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/callbacks"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_cache"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gorm"
 	hydra "github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/hydra/v6"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/leader_election"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/workflow"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 )
 
 var Set = wire.NewSet(
@@ -28,6 +32,11 @@ var Set = wire.NewSet(
 	gorm.NewSubServiceImpl,
 	gorm.NewServiceCallRecordRepo,
 	gorm.NewGatewayCollectionLogRepo,
+	gateway_cache.NewGatewayCache,
+	gateway_circuit_breaker.NewGatewayCircuitBreaker,
+	leader_election.NewLeaderElection,
+	outbox.NewOutbox,
+	callbacks.NewOutboxPublishers,
 	util.NewHTTPClient,
 	hydra.NewHydra,
 	wire.FieldsOf(new(*mq.MQ), "SaramaSyncProducer"),
@@ -0,0 +1,39 @@
+package gateway_cache
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository"
+)
+
+// generationKeyPrefix 与 data-application-gateway 约定的接口缓存代数 key 前缀
+const generationKeyPrefix = "data-application-gateway-cache-gen:"
+
+// GatewayCache 网关查询结果缓存
+type GatewayCache interface {
+	// Invalidate 使接口在网关中的查询结果缓存失效
+	Invalidate(ctx context.Context, serviceIDs ...string) error
+}
+
+func NewGatewayCache(r *repository.Redis) GatewayCache {
+	return &gatewayCache{client: r.Client}
+}
+
+type gatewayCache struct {
+	client redis.UniversalClient
+}
+
+// Invalidate 递增接口的缓存代数，网关按代数拼接缓存 key，旧缓存不再命中并随 TTL 过期
+func (g *gatewayCache) Invalidate(ctx context.Context, serviceIDs ...string) error {
+	if len(serviceIDs) == 0 {
+		return nil
+	}
+	pipe := g.client.Pipeline()
+	for _, id := range serviceIDs {
+		pipe.Incr(ctx, generationKeyPrefix+id)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
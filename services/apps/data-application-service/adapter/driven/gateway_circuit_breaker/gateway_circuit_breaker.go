@@ -0,0 +1,78 @@
+package gateway_circuit_breaker
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository"
+)
+
+const (
+	// stateKeyPrefix 与 data-application-gateway 约定的熔断状态 key 前缀，hash 的 field 为网关实例
+	stateKeyPrefix = "data-application-gateway-breaker:"
+	// 网关实例每 30 秒重新写入一次状态，超过该时间未刷新的实例视为已下线
+	staleAfter = 90 * time.Second
+)
+
+// InstanceState 网关实例中接口的熔断器状态
+type InstanceState struct {
+	Instance   string     `json:"instance"`            // 网关实例
+	State      string     `json:"state"`               // 熔断器状态 closed 关闭 open 熔断 half_open 半开
+	Requests   int64      `json:"requests"`            // 当前统计窗口内的请求数
+	Failures   int64      `json:"failures"`            // 当前统计窗口内的失败数
+	OpenedAt   *time.Time `json:"opened_at,omitempty"` // 熔断开始时间
+	ChangedAt  time.Time  `json:"changed_at"`          // 状态变化时间
+	ReportedAt time.Time  `json:"reported_at"`         // 网关实例写入状态的时间，超过 staleAfter 未刷新的实例视为已下线
+}
+
+// GatewayCircuitBreaker 网关中接口注册后端服务的熔断状态
+type GatewayCircuitBreaker interface {
+	// States 接口在各网关实例中的熔断器状态，按实例排序
+	States(ctx context.Context, serviceID string) ([]*InstanceState, error)
+}
+
+func NewGatewayCircuitBreaker(r *repository.Redis) GatewayCircuitBreaker {
+	return &gatewayCircuitBreaker{client: r.Client, now: time.Now}
+}
+
+type gatewayCircuitBreaker struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+func (g *gatewayCircuitBreaker) States(ctx context.Context, serviceID string) ([]*InstanceState, error) {
+	key := stateKeyPrefix + serviceID
+	values, err := g.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	res, stale := liveStates(values, g.now())
+	// 清理已下线网关实例留下的状态，清理失败不影响查询
+	if len(stale) > 0 {
+		g.client.HDel(ctx, key, stale...)
+	}
+	return res, nil
+}
+
+// liveStates 解析各网关实例的状态，返回存活实例的状态（按实例排序）和需要清理的 field
+func liveStates(values map[string]string, now time.Time) (res []*InstanceState, stale []string) {
+	res = make([]*InstanceState, 0, len(values))
+	for field, v := range values {
+		state := &InstanceState{}
+		if err := json.Unmarshal([]byte(v), state); err != nil {
+			stale = append(stale, field)
+			continue
+		}
+		if now.Sub(state.ReportedAt) > staleAfter {
+			stale = append(stale, field)
+			continue
+		}
+		res = append(res, state)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Instance < res[j].Instance })
+	return res, stale
+}
@@ -0,0 +1,33 @@
+package gateway_circuit_breaker
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_liveStates(t *testing.T) {
+	now := time.Unix(1000, 0)
+	value := func(instance, state string, reportedAt time.Time) string {
+		b, _ := json.Marshal(&InstanceState{Instance: instance, State: state, ReportedAt: reportedAt})
+		return string(b)
+	}
+	values := map[string]string{
+		"gateway-b": value("gateway-b", "closed", now.Add(-10*time.Second)),
+		"gateway-a": value("gateway-a", "open", now.Add(-staleAfter)),
+		// 已下线实例留下的熔断状态不再展示
+		"gateway-c": value("gateway-c", "open", now.Add(-staleAfter-time.Second)),
+		// 心跳之前的网关版本写入的状态没有写入时间
+		"gateway-d": value("gateway-d", "open", time.Time{}),
+		"gateway-e": "invalid",
+	}
+
+	res, stale := liveStates(values, now)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "gateway-a", res[0].Instance)
+		assert.Equal(t, "gateway-b", res[1].Instance)
+	}
+	assert.ElementsMatch(t, []string{"gateway-c", "gateway-d", "gateway-e"}, stale)
+}
@@ -17,6 +17,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_cache"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/constant"
@@ -27,6 +28,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/callback"
 	callback_register "github.com/kweaver-ai/idrm-go-common/callback/data_application_service/register"
 	configuration_center "github.com/kweaver-ai/idrm-go-common/rest/configuration_center"
@@ -109,6 +111,16 @@ type ServiceRepo interface {
 	GetServicesByIDs(ctx context.Context, ids []string) (res []*dto.ServiceInfoAndDraftFlag, err error)
 	// 处理回调事件
 	HandleCallbackEvent(ctx context.Context, serviceID string) error
+	// 获取版本组内的所有版本
+	ServiceVersions(ctx context.Context, versionGroupID string) (services []*model.Service, err error)
+	// 版本组内是否已存在该版本号
+	IsServiceVersionExist(ctx context.Context, versionGroupID, version string) (exist bool, err error)
+	// 设置接口所属的版本组和版本号
+	UpdateServiceVersion(ctx context.Context, serviceID, versionGroupID, version string) error
+	// 更新接口的废弃时间和计划下线时间
+	UpdateServiceDeprecation(ctx context.Context, serviceID string, deprecatedAt, sunsetAt *time.Time) error
+	// 获取计划下线时间已到且仍处于上线状态的接口
+	SunsetDueServices(ctx context.Context, now time.Time) (services []*model.Service, err error)
 }
 
 // ServiceStatusStatistics 服务状态统计结果
@@ -162,6 +174,8 @@ type serviceRepo struct {
 	wf                          workflow.WorkflowInterface
 	callback                    callback.Interface // callback 客户端
 	configurationCenterDriven   configuration_center.Driven
+	gatewayCache                gateway_cache.GatewayCache
+	outbox                      outbox.Outbox // 审核消息在业务事务中写入发件箱
 }
 
 func (r *serviceRepo) ServiceESIndexCreate(ctx context.Context, service *model.Service) (err error) {
@@ -185,6 +199,8 @@ func NewServiceRepo(
 	wf workflow.WorkflowInterface,
 	callback callback.Interface, // 新增参数
 	configurationCenterDriven configuration_center.Driven,
+	gatewayCache gateway_cache.GatewayCache,
+	outbox outbox.Outbox,
 ) ServiceRepo {
 	return &serviceRepo{
 		data:                        data,
@@ -199,6 +215,8 @@ func NewServiceRepo(
 		wf:                          wf,
 		callback:                    callback, // 新增赋值
 		configurationCenterDriven:   configurationCenterDriven,
+		gatewayCache:                gatewayCache,
+		outbox:                      outbox,
 	}
 }
 
@@ -312,6 +330,19 @@ func (r *serviceRepo) ServiceCreate(ctx context.Context, req *dto.ServiceCreateO
 	} else {
 		serviceCode = codeGeneration.Entries[0]
 	}
+	// 变更产生的草稿沿用原接口的版本信息，变更审核通过后草稿会替换原接口
+	versionGroupID, version := req.VersionGroupID, req.ServiceInfo.Version
+	var deprecatedAt, sunsetAt *time.Time
+	if req.ServiceInfo.ChangedServiceId != "" {
+		base, err := r.ServiceGetFields(ctx, req.ServiceInfo.ChangedServiceId, []string{"version_group_id", "version", "deprecated_at", "sunset_at"})
+		if err != nil {
+			log.WithContext(ctx).Error("ServiceCreate", zap.Error(err))
+			return nil, err
+		}
+		versionGroupID, version = base.VersionGroupID, base.Version
+		deprecatedAt, sunsetAt = base.DeprecatedAt, base.SunsetAt
+	}
+
 	user := util.GetUser(ctx)
 	//接口表
 	serviceID := uuid.New().String()
@@ -340,6 +371,12 @@ func (r *serviceRepo) ServiceCreate(ctx context.Context, req *dto.ServiceCreateO
 		DeveloperName:     req.ServiceInfo.Developer.Name,
 		RateLimiting:      uint32(req.ServiceInfo.RateLimiting),
 		Timeout:           uint32(req.ServiceInfo.Timeout),
+		CacheTTL:          uint32(req.ServiceInfo.CacheTTL),
+		CountCacheTTL:     uint32(req.ServiceInfo.CountCacheTTL),
+		VersionGroupID:    versionGroupID,
+		Version:           version,
+		DeprecatedAt:      deprecatedAt,
+		SunsetAt:          sunsetAt,
 		ServiceType:       req.ServiceInfo.ServiceType,
 		PublishStatus:     req.ServiceInfo.PublishStatus, //这里create加入发布状态没有安全问题，Service层已重新赋值控制
 		AuditType:         req.ServiceInfo.AuditType,
@@ -353,6 +390,11 @@ func (r *serviceRepo) ServiceCreate(ctx context.Context, req *dto.ServiceCreateO
 		service.BackendServiceHost = req.ServiceInfo.BackendServiceHost
 		service.BackendServicePath = req.ServiceInfo.BackendServicePath
 		service.FileID = req.ServiceInfo.File.FileID
+		service.TransformRules, err = dto.MarshalTransformRules(req.ServiceInfo.TransformRules)
+		if err != nil {
+			log.WithContext(ctx).Error("ServiceCreate", zap.Error(err))
+			return nil, errorcode.Detail(errorcode.PublicInvalidParameter, err.Error())
+		}
 	}
 
 	//检查类目信息
@@ -1231,6 +1273,11 @@ func (r *serviceRepo) ServiceList(ctx context.Context, req *dto.ServiceListReq)
 				Developer:          dto.Developer{ID: s.DeveloperID},
 				RateLimiting:       int64(s.RateLimiting),
 				Timeout:            int64(s.Timeout),
+				CacheTTL:           int64(s.CacheTTL),
+				CountCacheTTL:      int64(s.CountCacheTTL),
+				Version:            s.Version,
+				DeprecatedAt:       util.TimeFormat(s.DeprecatedAt),
+				SunsetAt:           util.TimeFormat(s.SunsetAt),
 				PublishTime:        util.TimeFormat(s.PublishTime),
 				OnlineTime:         util.TimeFormat(s.OnlineTime),
 				CreateTime:         util.TimeFormat(&s.CreateTime),
@@ -1532,14 +1579,21 @@ func (r *serviceRepo) ServiceGet(ctx context.Context, serviceID string) (res *dt
 			// 	ContactPerson: s.Developer.ContactPerson,
 			// 	ContactInfo:   s.Developer.ContactInfo,
 			// },
-			RateLimiting: int64(s.RateLimiting),
-			Timeout:      int64(s.Timeout),
-			PublishTime:  util.TimeFormat(s.PublishTime),
-			OnlineTime:   util.TimeFormat(s.OnlineTime),
-			CreateTime:   util.TimeFormat(&s.CreateTime),
-			UpdateTime:   util.TimeFormat(&s.UpdateTime),
-			CreatedBy:    s.CreatedBy,
-			UpdateBy:     s.UpdateBy,
+			RateLimiting:   int64(s.RateLimiting),
+			Timeout:        int64(s.Timeout),
+			CacheTTL:       int64(s.CacheTTL),
+			CountCacheTTL:  int64(s.CountCacheTTL),
+			TransformRules: dto.UnmarshalTransformRules(s.TransformRules),
+			Version:        s.Version,
+			VersionGroupID: s.VersionGroupID,
+			DeprecatedAt:   util.TimeFormat(s.DeprecatedAt),
+			SunsetAt:       util.TimeFormat(s.SunsetAt),
+			PublishTime:    util.TimeFormat(s.PublishTime),
+			OnlineTime:     util.TimeFormat(s.OnlineTime),
+			CreateTime:     util.TimeFormat(&s.CreateTime),
+			UpdateTime:     util.TimeFormat(&s.UpdateTime),
+			CreatedBy:      s.CreatedBy,
+			UpdateBy:       s.UpdateBy,
 		},
 		ServiceTest: dto.ServiceTest{
 			RequestExample:  util.PointerToString(s.ServiceScriptModel.RequestExample),
@@ -1985,6 +2039,8 @@ func (r *serviceRepo) ServiceUpdate(ctx context.Context, req *dto.ServiceUpdateR
 			"developer_id":      req.ServiceInfo.Developer.ID,
 			"developer_name":    req.ServiceInfo.Developer.Name,
 			"rate_limiting":     uint32(req.ServiceInfo.RateLimiting),
+			"cache_ttl":         uint32(req.ServiceInfo.CacheTTL),
+			"count_cache_ttl":   uint32(req.ServiceInfo.CountCacheTTL),
 			"publish_status":    req.ServiceInfo.PublishStatus, //更新或者编辑暂存时，维护下发布状态，该状态重新赋值过，无安全问题
 			"audit_type":        req.ServiceInfo.AuditType,
 			"is_changed":        req.ServiceInfo.IsChanged,
@@ -1999,6 +2055,12 @@ func (r *serviceRepo) ServiceUpdate(ctx context.Context, req *dto.ServiceUpdateR
 			s["backend_service_host"] = req.ServiceInfo.BackendServiceHost
 			s["backend_service_path"] = req.ServiceInfo.BackendServicePath
 			s["file_id"] = req.ServiceInfo.File.FileID
+			transformRules, err := dto.MarshalTransformRules(req.ServiceInfo.TransformRules)
+			if err != nil {
+				log.WithContext(ctx).Error("ServiceUpdate", zap.Error(err))
+				return errorcode.Detail(errorcode.PublicInvalidParameter, err.Error())
+			}
+			s["transform_rules"] = transformRules
 		}
 
 		//更新主表
@@ -2737,13 +2799,22 @@ func (r *serviceRepo) AuditProcessInstanceCreate(ctx context.Context, serviceID
 
 		//需审核的流程发送到 workflow
 		if audit.AuditStatus == enum.AuditStatusAuditing {
-			return r.ProduceWorkflowAuditApply(ctx, audit)
+			return r.ProduceWorkflowAuditApply(ctx, tx, audit)
 		}
 
 		return nil
 	})
 	// 不需审核，直接通过，更新状态后也要发送消息更新ES索引
 	if audit.AuditStatus == enum.AuditStatusPass {
+		// 接口重新发布或上下线，使网关中的查询结果缓存失效
+		invalidateID := service.ServiceID
+		if audit.AuditType == enum.AuditTypeChange {
+			invalidateID = service.ChangedServiceId
+		}
+		if err := r.gatewayCache.Invalidate(ctx, invalidateID); err != nil {
+			log.WithContext(ctx).Warn("AuditProcessInstanceCreate 网关缓存失效失败", zap.String("serviceID", invalidateID), zap.Error(err))
+		}
+
 		if audit.AuditType == enum.AuditTypeChange {
 			err = r.HandleChangeAuditPass(ctx, audit.ApplyID)
 			if err = r.ServiceESIndexCreate(ctx, &model.Service{ServiceID: service.ChangedServiceId}); err != nil {
@@ -2826,7 +2897,8 @@ func (r *serviceRepo) HandleChangeAuditPass(ctx context.Context, applyId string)
 	return nil
 }
 
-func (r *serviceRepo) ProduceWorkflowAuditApply(ctx context.Context, audit *model.Service) (err error) {
+// ProduceWorkflowAuditApply 在 tx 所在的事务中把审核申请写入发件箱，事务提交后发送到 workflow
+func (r *serviceRepo) ProduceWorkflowAuditApply(ctx context.Context, tx *gorm.DB, audit *model.Service) (err error) {
 	user := util.GetUser(ctx)
 	t := time.Now()
 	msg := &common.AuditApplyMsg{
@@ -2860,12 +2932,12 @@ func (r *serviceRepo) ProduceWorkflowAuditApply(ctx context.Context, audit *mode
 		},
 	}
 
-	err = r.wf.AuditApply(msg)
+	err = r.outbox.Enqueue(ctx, tx, mq.TopicWorkflowAuditApply, audit.ServiceID, msg)
 	if err != nil {
 		log.WithContext(ctx).Error("ProduceWorkflowAuditApply", zap.Error(err), zap.Any("msg", msg))
 		return err
 	}
-	log.Info("producer workflow msg", zap.String("topic", mq.TopicWorkflowAuditApply), zap.Any("msg", msg))
+	log.Info("enqueue workflow msg", zap.String("topic", mq.TopicWorkflowAuditApply), zap.Any("msg", msg))
 	return nil
 }
 
@@ -3215,6 +3287,13 @@ func (r *serviceRepo) consumerWorkflowAuditResult(auditType string, result *comm
 		}()
 	}
 
+	// 审核通过后接口重新发布或上下线，使网关中的查询结果缓存失效
+	if err == nil && result.Result == enum.AuditStatusPass {
+		if err := r.gatewayCache.Invalidate(context.Background(), service.ServiceID); err != nil {
+			log.Warn("consumerWorkflowAuditResult 网关缓存失效失败", zap.String("serviceID", service.ServiceID), zap.Error(err))
+		}
+	}
+
 	// 上线/下线审核通过时的回调
 	// if r.callback != nil && result.Result == enum.AuditStatusPass {
 	// 	if auditType == enum.AuditTypeOnline || auditType == enum.AuditTypeOffline {
@@ -4581,6 +4660,11 @@ func (r *serviceRepo) GetServicesByIDs(ctx context.Context, ids []string) (res [
 				Developer:          dto.Developer{ID: s.DeveloperID},
 				RateLimiting:       int64(s.RateLimiting),
 				Timeout:            int64(s.Timeout),
+				CacheTTL:           int64(s.CacheTTL),
+				CountCacheTTL:      int64(s.CountCacheTTL),
+				Version:            s.Version,
+				DeprecatedAt:       util.TimeFormat(s.DeprecatedAt),
+				SunsetAt:           util.TimeFormat(s.SunsetAt),
 				PublishTime:        util.TimeFormat(s.PublishTime),
 				OnlineTime:         util.TimeFormat(s.OnlineTime),
 				CreateTime:         util.TimeFormat(&s.CreateTime),
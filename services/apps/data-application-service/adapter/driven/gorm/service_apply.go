@@ -21,6 +21,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	v1 "github.com/kweaver-ai/idrm-go-common/api/auth-service/v1"
 	"github.com/kweaver-ai/idrm-go-common/interception"
 	"github.com/kweaver-ai/idrm-go-common/util/clock"
@@ -56,6 +57,7 @@ func NewServiceApplyRepo(
 	authServiceRepo microservice.AuthServiceRepo,
 	dataSubjectRepo microservice.DataSubjectRepo,
 	wf workflow.WorkflowInterface,
+	outbox outbox.Outbox,
 ) ServiceApplyRepo {
 	return &serviceApplyRepo{
 		clock:                   clock.RealClock{},
@@ -67,6 +69,7 @@ func NewServiceApplyRepo(
 		authServiceRepo:         authServiceRepo,
 		dataSubjectRepo:         dataSubjectRepo,
 		wf:                      wf,
+		outbox:                  outbox,
 	}
 }
 
@@ -82,6 +85,7 @@ type serviceApplyRepo struct {
 	authServiceRepo         microservice.AuthServiceRepo
 	dataSubjectRepo         microservice.DataSubjectRepo
 	wf                      workflow.WorkflowInterface
+	outbox                  outbox.Outbox // 审核消息在业务事务中写入发件箱
 }
 
 func (r *serviceApplyRepo) List(ctx context.Context, req *dto.ServiceApplyListReq) (res []*model.ServiceApplyAssociations, count int64, err error) {
@@ -241,13 +245,14 @@ func (r *serviceApplyRepo) Create(ctx context.Context, apply *model.ServiceApply
 		}
 
 		//审核流程发送到 workflow
-		return r.produceWorkflowAuditApply(ctx, apply)
+		return r.produceWorkflowAuditApply(ctx, tx, apply)
 	})
 
 	return err
 }
 
-func (r *serviceApplyRepo) produceWorkflowAuditApply(ctx context.Context, apply *model.ServiceApply) (err error) {
+// produceWorkflowAuditApply 在 tx 所在的事务中把审核申请写入发件箱，事务提交后发送到 workflow
+func (r *serviceApplyRepo) produceWorkflowAuditApply(ctx context.Context, tx *gorm.DB, apply *model.ServiceApply) (err error) {
 	user := util.GetUser(ctx)
 	t := time.Now()
 	service, err := r.serviceRepo.ServiceGetFields(ctx, apply.ServiceID, []string{"service_name"})
@@ -287,12 +292,12 @@ func (r *serviceApplyRepo) produceWorkflowAuditApply(ctx context.Context, apply
 		},
 	}
 
-	err = r.wf.AuditApply(msg)
+	err = r.outbox.Enqueue(ctx, tx, mq.TopicWorkflowAuditApply, apply.ServiceID, msg)
 	if err != nil {
 		log.WithContext(ctx).Error("ProduceWorkflowAuditApply", zap.Error(err), zap.Any("msg", msg))
 		return err
 	}
-	log.Info("producer workflow msg", zap.String("topic", mq.TopicWorkflowAuditApply), zap.Any("msg", msg))
+	log.Info("enqueue workflow msg", zap.String("topic", mq.TopicWorkflowAuditApply), zap.Any("msg", msg))
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
 	configuration_center "github.com/kweaver-ai/idrm-go-common/rest/configuration_center"
@@ -19,6 +20,8 @@ import (
 type ServiceCallRecordRepo interface {
 	MonitorList(ctx context.Context, req *dto.MonitorListReq) (res []*dto.MonitorRecord, count int64, err error)
 	DeleteExpiredRecords(ctx context.Context) error
+	// CallStats 按接口统计 since 之后的调用次数、平均耗时和最近一次调用时间
+	CallStats(ctx context.Context, serviceIDs []string, since time.Time) (map[string]*dto.ServiceCallStats, error)
 }
 
 type serviceCallRecordRepo struct {
@@ -339,3 +342,42 @@ func (r *serviceCallRecordRepo) DeleteExpiredRecords(ctx context.Context) error
 		Where("call_start_time < ?", ninetyDaysAgo).
 		Delete(&model.ServiceCallRecord{}).Error
 }
+
+// CallStats 按接口统计 since 之后的调用次数、平均耗时和最近一次调用时间，没有调用记录的接口不在结果中
+func (r *serviceCallRecordRepo) CallStats(ctx context.Context, serviceIDs []string, since time.Time) (map[string]*dto.ServiceCallStats, error) {
+	res := make(map[string]*dto.ServiceCallStats, len(serviceIDs))
+	if len(serviceIDs) == 0 {
+		return res, nil
+	}
+
+	var rows []struct {
+		ServiceID    string
+		SuccessCount int64
+		FailCount    int64
+		AvgLatencyMs float64
+		LastCallTime *time.Time
+	}
+	err := r.data.DB.WithContext(ctx).Model(&model.ServiceCallRecord{}).
+		Select("service_id, "+
+			"SUM(CASE WHEN call_status = 1 THEN 1 ELSE 0 END) AS success_count, "+
+			"SUM(CASE WHEN call_status = 1 THEN 0 ELSE 1 END) AS fail_count, "+
+			"AVG(latency_ms) AS avg_latency_ms, "+
+			"MAX(call_start_time) AS last_call_time").
+		Where("service_id IN ? AND call_start_time >= ?", serviceIDs, since).
+		Group("service_id").
+		Scan(&rows).Error
+	if err != nil {
+		log.WithContext(ctx).Error("CallStats", zap.Error(err))
+		return nil, err
+	}
+
+	for _, row := range rows {
+		res[row.ServiceID] = &dto.ServiceCallStats{
+			SuccessCount: row.SuccessCount,
+			FailCount:    row.FailCount,
+			AvgLatencyMs: int64(row.AvgLatencyMs),
+			LastCallTime: util.TimeFormat(row.LastCallTime),
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/enum"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// NotDraft 排除变更产生的草稿。变更审核通过后草稿的 changed_service_id 会被更新为字符串 NULL
+func NotDraft() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(changed_service_id IS NULL OR changed_service_id IN ('', 'NULL'))")
+	}
+}
+
+// ServiceVersions 获取版本组内的所有版本，不包含变更产生的草稿，按创建时间排序
+func (r *serviceRepo) ServiceVersions(ctx context.Context, versionGroupID string) (services []*model.Service, err error) {
+	err = r.data.DB.WithContext(ctx).Model(&model.Service{}).Scopes(Undeleted(), NotDraft()).
+		Where("version_group_id = ?", versionGroupID).
+		Order("create_time asc").
+		Find(&services).Error
+	if err != nil {
+		log.WithContext(ctx).Error("ServiceVersions", zap.Error(err))
+		return nil, err
+	}
+	return services, nil
+}
+
+// IsServiceVersionExist 版本组内是否已存在该版本号
+func (r *serviceRepo) IsServiceVersionExist(ctx context.Context, versionGroupID, version string) (exist bool, err error) {
+	var count int64
+	err = r.data.DB.WithContext(ctx).Model(&model.Service{}).Scopes(Undeleted(), NotDraft()).
+		Where("version_group_id = ? AND version = ?", versionGroupID, version).
+		Count(&count).Error
+	if err != nil {
+		log.WithContext(ctx).Error("IsServiceVersionExist", zap.Error(err))
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateServiceVersion 设置接口所属的版本组和版本号，同时更新该接口变更产生的草稿
+func (r *serviceRepo) UpdateServiceVersion(ctx context.Context, serviceID, versionGroupID, version string) error {
+	err := r.data.DB.WithContext(ctx).Model(&model.Service{}).Scopes(Undeleted()).
+		Where("service_id = ? OR changed_service_id = ?", serviceID, serviceID).
+		Updates(map[string]any{"version_group_id": versionGroupID, "version": version}).Error
+	if err != nil {
+		log.WithContext(ctx).Error("UpdateServiceVersion", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateServiceDeprecation 更新接口的废弃时间和计划下线时间，同时更新该接口变更产生的草稿，为 nil 时清空
+func (r *serviceRepo) UpdateServiceDeprecation(ctx context.Context, serviceID string, deprecatedAt, sunsetAt *time.Time) error {
+	err := r.data.DB.WithContext(ctx).Model(&model.Service{}).Scopes(Undeleted()).
+		Where("service_id = ? OR changed_service_id = ?", serviceID, serviceID).
+		Updates(map[string]any{"deprecated_at": deprecatedAt, "sunset_at": sunsetAt}).Error
+	if err != nil {
+		log.WithContext(ctx).Error("UpdateServiceDeprecation", zap.Error(err))
+		return err
+	}
+	if err := r.gatewayCache.Invalidate(ctx, serviceID); err != nil {
+		log.WithContext(ctx).Warn("UpdateServiceDeprecation 网关缓存失效失败", zap.String("serviceID", serviceID), zap.Error(err))
+	}
+	return nil
+}
+
+// SunsetDueServices 获取计划下线时间已到且仍处于上线状态的接口
+func (r *serviceRepo) SunsetDueServices(ctx context.Context, now time.Time) (services []*model.Service, err error) {
+	err = r.data.DB.WithContext(ctx).Model(&model.Service{}).Scopes(Undeleted(), NotDraft()).
+		Select("service_id", "service_name", "version_group_id", "version", "sunset_at").
+		Where("sunset_at IS NOT NULL AND sunset_at <= ?", now).
+		Where("status = ?", enum.LineStatusOnLine).
+		Find(&services).Error
+	if err != nil {
+		log.WithContext(ctx).Error("SunsetDueServices", zap.Error(err))
+		return nil, err
+	}
+	return services, nil
+}
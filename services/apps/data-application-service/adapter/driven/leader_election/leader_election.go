@@ -0,0 +1,237 @@
+package leader_election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+const (
+	// leaseKeyPrefix 定时任务租约 key 前缀，value 为持有租约的 Lease
+	leaseKeyPrefix = "data-application-service:job-lease:"
+	// runsKey 定时任务最近一次执行结果，hash 的 field 为定时任务名称
+	runsKey = "data-application-service:job-runs"
+
+	// leaseDuration 租约有效期，leader 异常退出后其他实例最迟在租约过期后接替
+	leaseDuration = 30 * time.Second
+	// retryPeriod 续约和竞选的间隔，需要明显小于 leaseDuration
+	retryPeriod = 10 * time.Second
+)
+
+// renewScript 租约仍由自己持有时续约
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript 租约仍由自己持有时释放
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Lease 定时任务租约
+type Lease struct {
+	Identity   string    `json:"identity"`    // 持有租约的实例
+	AcquiredAt time.Time `json:"acquired_at"` // 获得租约的时间
+}
+
+// JobRun 定时任务的一次执行结果
+type JobRun struct {
+	Job        string    `json:"job"`             // 定时任务名称
+	Identity   string    `json:"identity"`        // 执行的实例
+	StartedAt  time.Time `json:"started_at"`      // 开始时间
+	FinishedAt time.Time `json:"finished_at"`     // 结束时间
+	Success    bool      `json:"success"`         // 是否成功
+	Error      string    `json:"error,omitempty"` // 失败原因
+}
+
+// LeaderElection 基于 Redis 租约的定时任务选主，多副本部署时每个定时任务只在一个实例上执行
+type LeaderElection interface {
+	// Identity 当前实例的标识
+	Identity() string
+	// Campaign 竞选定时任务 job 的 leader，直到 ctx 结束。成为 leader 后在新的 goroutine 中调用
+	// onStartedLeading，传入的 ctx 在续约失败或 ctx 结束时取消，onStartedLeading 返回后才会重新竞选
+	Campaign(ctx context.Context, job string, onStartedLeading func(ctx context.Context))
+	// Leader 当前持有定时任务租约的实例，没有 leader 时返回 nil
+	Leader(ctx context.Context, job string) (*Lease, error)
+	// RecordRun 记录定时任务的执行结果，只保留最近一次
+	RecordRun(ctx context.Context, run *JobRun) error
+	// LastRun 定时任务最近一次执行结果，没有执行过时返回 nil
+	LastRun(ctx context.Context, job string) (*JobRun, error)
+}
+
+func NewLeaderElection(r *repository.Redis) LeaderElection {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return &leaderElection{
+		client:        r.Client,
+		identity:      hostname + "-" + util.GetUniqueString(),
+		leaseDuration: leaseDuration,
+		retryPeriod:   retryPeriod,
+	}
+}
+
+type leaderElection struct {
+	client   redis.UniversalClient
+	identity string
+
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+}
+
+func (l *leaderElection) Identity() string {
+	return l.identity
+}
+
+func (l *leaderElection) Campaign(ctx context.Context, job string, onStartedLeading func(ctx context.Context)) {
+	key := leaseKeyPrefix + job
+	var (
+		value   string             // 持有的租约
+		cancel  context.CancelFunc // 取消 onStartedLeading
+		stopped chan struct{}      // onStartedLeading 已返回
+	)
+	stopLeading := func() {
+		if cancel == nil {
+			return
+		}
+		cancel()
+		<-stopped
+		cancel = nil
+		log.Info("LeaderElection 不再是 leader", zap.String("job", job), zap.String("identity", l.identity))
+	}
+	defer func() {
+		stopLeading()
+		// 主动释放租约，其他实例不用等待租约过期
+		if value != "" {
+			releaseCtx, releaseCancel := context.WithTimeout(context.Background(), l.retryPeriod)
+			defer releaseCancel()
+			if err := releaseScript.Run(releaseCtx, l.client, []string{key}, value).Err(); err != nil {
+				log.Warn("LeaderElection 释放租约失败", zap.String("job", job), zap.Error(err))
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(l.retryPeriod)
+	defer ticker.Stop()
+	for {
+		held, ok := l.tryAcquireOrRenew(ctx, key, value)
+		if ctx.Err() != nil {
+			// ctx 结束时续约请求可能被取消，保留持有的租约用于释放
+			if ok {
+				value = held
+			}
+			return
+		}
+		value = held
+		if ok && cancel != nil {
+			// onStartedLeading 已自行返回时释放租约，让其他实例接替
+			select {
+			case <-stopped:
+				cancel = nil
+				_ = releaseScript.Run(ctx, l.client, []string{key}, value).Err()
+				value, ok = "", false
+			default:
+			}
+		}
+		switch {
+		case ok && cancel == nil:
+			log.Info("LeaderElection 成为 leader", zap.String("job", job), zap.String("identity", l.identity))
+			leadingCtx, leadingCancel := context.WithCancel(ctx)
+			cancel = leadingCancel
+			stopped = make(chan struct{})
+			go func(done chan struct{}) {
+				defer close(done)
+				onStartedLeading(leadingCtx)
+			}(stopped)
+		case !ok:
+			stopLeading()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquireOrRenew 续约 value 对应的租约，没有持有租约或续约失败时尝试获得新的租约，返回持有的租约
+func (l *leaderElection) tryAcquireOrRenew(ctx context.Context, key, value string) (string, bool) {
+	if value != "" {
+		n, err := renewScript.Run(ctx, l.client, []string{key}, value, l.leaseDuration.Milliseconds()).Int()
+		if err != nil {
+			// 无法确认租约状态时放弃 leader 身份，避免租约过期后出现两个 leader
+			log.Warn("LeaderElection 续约失败", zap.String("key", key), zap.Error(err))
+			return "", false
+		}
+		if n == 1 {
+			return value, true
+		}
+	}
+
+	lease, err := json.Marshal(&Lease{Identity: l.identity, AcquiredAt: time.Now()})
+	if err != nil {
+		return "", false
+	}
+	ok, err := l.client.SetNX(ctx, key, string(lease), l.leaseDuration).Result()
+	if err != nil {
+		log.Warn("LeaderElection 获取租约失败", zap.String("key", key), zap.Error(err))
+		return "", false
+	}
+	if !ok {
+		return "", false
+	}
+	return string(lease), true
+}
+
+func (l *leaderElection) Leader(ctx context.Context, job string) (*Lease, error) {
+	value, err := l.client.Get(ctx, leaseKeyPrefix+job).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lease := &Lease{}
+	if err := json.Unmarshal([]byte(value), lease); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func (l *leaderElection) RecordRun(ctx context.Context, run *JobRun) error {
+	value, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	return l.client.HSet(ctx, runsKey, run.Job, value).Err()
+}
+
+func (l *leaderElection) LastRun(ctx context.Context, job string) (*JobRun, error) {
+	value, err := l.client.HGet(ctx, runsKey, job).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	run := &JobRun{}
+	if err := json.Unmarshal([]byte(value), run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
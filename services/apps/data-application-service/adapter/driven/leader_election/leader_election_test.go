@@ -0,0 +1,207 @@
+package leader_election
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/idrm-go-frame/core/logx/zapx"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+const (
+	testJob           = "sync"
+	testRetryPeriod   = 20 * time.Millisecond
+	testLeaseDuration = time.Second
+	// testWait 等待竞选结果的最长时间
+	testWait = 2 * time.Second
+)
+
+func TestMain(m *testing.M) {
+	// 初始化日志，否则调用 log.Info 等方法会 panic
+	log.InitLogger(zapx.LogConfigs{}, &telemetry.Config{})
+	m.Run()
+}
+
+func newTestLeaderElection(t *testing.T, mr *miniredis.Miniredis, identity string) *leaderElection {
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return &leaderElection{
+		client:        client,
+		identity:      identity,
+		leaseDuration: testLeaseDuration,
+		retryPeriod:   testRetryPeriod,
+	}
+}
+
+// campaign 在后台竞选，返回每次成为 leader 时传入的 ctx 和停止竞选的函数，停止竞选后等待 Campaign 返回
+func campaign(l *leaderElection) (<-chan context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	leading := make(chan context.Context, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.Campaign(ctx, testJob, func(ctx context.Context) {
+			leading <- ctx
+			<-ctx.Done()
+		})
+	}()
+	return leading, func() {
+		cancel()
+		<-done
+	}
+}
+
+func waitLeading(t *testing.T, leading <-chan context.Context) context.Context {
+	t.Helper()
+	select {
+	case ctx := <-leading:
+		return ctx
+	case <-time.After(testWait):
+		t.Fatal("not leading")
+		return nil
+	}
+}
+
+func waitDone(t *testing.T, ctx context.Context) {
+	t.Helper()
+	select {
+	case <-ctx.Done():
+	case <-time.After(testWait):
+		t.Fatal("still leading")
+	}
+}
+
+// 同一时间只有一个实例获得租约，续约只延长自己的租约
+func Test_leaderElection_tryAcquireOrRenew(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestLeaderElection(t, mr, "a")
+	b := newTestLeaderElection(t, mr, "b")
+	key := leaseKeyPrefix + testJob
+
+	value, ok := a.tryAcquireOrRenew(context.Background(), key, "")
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, testLeaseDuration, mr.TTL(key))
+	_, ok = b.tryAcquireOrRenew(context.Background(), key, "")
+	assert.False(t, ok)
+
+	lease, err := b.Leader(context.Background(), testJob)
+	if assert.NoError(t, err) && assert.NotNil(t, lease) {
+		assert.Equal(t, "a", lease.Identity)
+	}
+
+	// 续约后租约有效期重新计算
+	mr.FastForward(testLeaseDuration / 2)
+	renewed, ok := a.tryAcquireOrRenew(context.Background(), key, value)
+	assert.True(t, ok)
+	assert.Equal(t, value, renewed)
+	assert.Equal(t, testLeaseDuration, mr.TTL(key))
+
+	// 租约过期后其他实例接替，原 leader 无法续约
+	mr.FastForward(testLeaseDuration)
+	_, ok = b.tryAcquireOrRenew(context.Background(), key, "")
+	assert.True(t, ok)
+	_, ok = a.tryAcquireOrRenew(context.Background(), key, value)
+	assert.False(t, ok)
+	lease, err = a.Leader(context.Background(), testJob)
+	if assert.NoError(t, err) && assert.NotNil(t, lease) {
+		assert.Equal(t, "b", lease.Identity)
+	}
+}
+
+// leader 退出时释放租约，另一个实例不用等待租约过期即可接替
+func Test_leaderElection_Campaign_Takeover(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestLeaderElection(t, mr, "a")
+	b := newTestLeaderElection(t, mr, "b")
+
+	leadingA, stopA := campaign(a)
+	ctxA := waitLeading(t, leadingA)
+	leadingB, stopB := campaign(b)
+	defer stopB()
+
+	// leader 持续续约，另一个实例不会成为 leader
+	time.Sleep(5 * testRetryPeriod)
+	select {
+	case <-leadingB:
+		t.Fatal("b is leading while a holds the lease")
+	default:
+	}
+	assert.NoError(t, ctxA.Err())
+
+	stopA()
+	assert.Error(t, ctxA.Err())
+	waitLeading(t, leadingB)
+	lease, err := b.Leader(context.Background(), testJob)
+	if assert.NoError(t, err) && assert.NotNil(t, lease) {
+		assert.Equal(t, "b", lease.Identity)
+	}
+}
+
+// 租约被其他实例取得后续约失败，leader 停止执行定时任务
+func Test_leaderElection_Campaign_LoseLease(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestLeaderElection(t, mr, "a")
+	b := newTestLeaderElection(t, mr, "b")
+
+	leadingA, stopA := campaign(a)
+	defer stopA()
+	ctxA := waitLeading(t, leadingA)
+
+	// 模拟 a 长时间无法续约，租约过期后被 b 取得
+	mr.Del(leaseKeyPrefix + testJob)
+	_, ok := b.tryAcquireOrRenew(context.Background(), leaseKeyPrefix+testJob, "")
+	if !assert.True(t, ok) {
+		return
+	}
+	waitDone(t, ctxA)
+
+	// a 停止竞选时不会释放 b 持有的租约
+	stopA()
+	lease, err := b.Leader(context.Background(), testJob)
+	if assert.NoError(t, err) && assert.NotNil(t, lease) {
+		assert.Equal(t, "b", lease.Identity)
+	}
+}
+
+// 无法确认租约状态时放弃 leader 身份，Redis 恢复后重新竞选
+func Test_leaderElection_Campaign_RenewError(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestLeaderElection(t, mr, "a")
+
+	leadingA, stopA := campaign(a)
+	defer stopA()
+	ctxA := waitLeading(t, leadingA)
+
+	mr.SetError("LOADING Redis is loading the dataset in memory")
+	waitDone(t, ctxA)
+	// 放弃的租约过期后重新成为 leader
+	mr.SetError("")
+	mr.FastForward(testLeaseDuration)
+	waitLeading(t, leadingA)
+}
+
+// onStartedLeading 自行返回后重新竞选
+func Test_leaderElection_Campaign_Return(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newTestLeaderElection(t, mr, "a")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ran := make(chan struct{}, 10)
+	go a.Campaign(ctx, testJob, func(ctx context.Context) { ran <- struct{}{} })
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ran:
+		case <-time.After(testWait):
+			t.Fatal("not leading")
+		}
+	}
+}
@@ -32,6 +32,7 @@ func NewConsumer(
 
 func (c *Consumer) register() {
 	c.topicHandles[ServiceAuthUpdate] = c.serviceHandler.UpdateAuthedUsers
+	c.topicHandles[FormViewESIndex] = c.serviceHandler.InvalidateFormViewServices
 }
 
 func (c *Consumer) Register() {
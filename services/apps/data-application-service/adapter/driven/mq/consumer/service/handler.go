@@ -4,16 +4,18 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_cache"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gorm"
 	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
 )
 
 type Handler struct {
-	repo gorm.ServiceRepo
+	repo         gorm.ServiceRepo
+	gatewayCache gateway_cache.GatewayCache
 }
 
-func NewHandler(repo gorm.ServiceRepo) *Handler {
-	return &Handler{repo: repo}
+func NewHandler(repo gorm.ServiceRepo, gatewayCache gateway_cache.GatewayCache) *Handler {
+	return &Handler{repo: repo, gatewayCache: gatewayCache}
 }
 
 // UpdateAuthedUsers   更新授权人的信息
@@ -42,3 +44,33 @@ func (h *Handler) UpdateAuthedUsers(msg []byte) error {
 	}
 	return err
 }
+
+// InvalidateFormViewServices 逻辑视图重新发布、变更或删除时，使基于该视图生成的接口在网关中的查询结果缓存失效
+func (h *Handler) InvalidateFormViewServices(msg []byte) error {
+	msgBody := FormViewESIndexMsg{}
+	if err := json.Unmarshal(msg, &msgBody); err != nil {
+		log.Errorf("decoded InvalidateFormViewServices msg :%s error %v", string(msg), err.Error())
+		return err
+	}
+	// 删除消息只携带 docid
+	dataViewID := msgBody.Body.ID
+	if dataViewID == "" {
+		dataViewID = msgBody.Body.DocID
+	}
+	if dataViewID == "" {
+		return nil
+	}
+	ctx := context.Background()
+	services, err := h.repo.ServicesGetByDataViewId(ctx, dataViewID)
+	if err != nil {
+		return err
+	}
+	serviceIDs := make([]string, 0, len(services))
+	for _, s := range services {
+		serviceIDs = append(serviceIDs, s.ServiceID)
+	}
+	if err = h.gatewayCache.Invalidate(ctx, serviceIDs...); err != nil {
+		log.Errorf("invalidate gateway cache of data view %s error %v", dataViewID, err.Error())
+	}
+	return err
+}
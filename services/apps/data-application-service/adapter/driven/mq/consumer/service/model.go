@@ -14,3 +14,14 @@ type UpdateAuthedUsersMsgBody struct {
 	AuthedUsers []string `json:"authed_users"`
 	Method      string   `json:"method"`
 }
+
+// FormViewESIndexMsg 逻辑视图索引消息，逻辑视图发布、变更或删除时由 data-view 发送
+type FormViewESIndexMsg struct {
+	Type string                 `json:"type"`
+	Body FormViewESIndexMsgBody `json:"body"`
+}
+
+type FormViewESIndexMsgBody struct {
+	ID    string `json:"id"`
+	DocID string `json:"docid"`
+}
@@ -2,4 +2,5 @@ package consumer
 
 const (
 	ServiceAuthUpdate = "af.auth-service.authed_user_update" //逻辑视图授权人变化
+	FormViewESIndex   = "af.data-view.es-index"              //逻辑视图发布、变更或删除
 )
@@ -7,6 +7,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/audit_process_bind"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/developer"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/file"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/outbox"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service_apply"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service_call_record"
@@ -34,6 +35,7 @@ var ProviderSet = wire.NewSet(
 	service_stats.NewServiceStatsController,
 	subject_domain.NewSubjectDomainController,
 	sub_service.NewSubServiceService,
+	outbox.NewOutboxController,
 
 	// GoCommon
 	audit.NewKafka,
@@ -19,6 +19,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/audit_process_bind"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/developer"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/file"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/outbox"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service_apply"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service_call_record"
@@ -106,6 +107,7 @@ type Router struct {
 	// 配置中心客户端
 	ConfigurationCenterDriven configuration_center.Driven
 	SubServiceDomainApi       *sub_service.SubServiceService
+	OutboxController          *outbox.OutboxController
 }
 
 func (r *Router) Register(engine *gin.Engine) error {
@@ -165,6 +167,11 @@ func (r *Router) RegisterApi(engine *gin.Engine) {
 	serviceRouter.GET("/max-response", r.ServiceController.GetServicesMaxResponse)
 	serviceRouter.POST("/api-doc/export", r.ServiceController.ExportAPIDoc)                           //导出API接口文档PDF/ZIP
 	serviceRouter.GET("/:service_id/api-doc/example-code", r.ServiceController.ServiceGetExampleCode) //接口使用示例代码
+	serviceRouter.POST("/api-doc/openapi", r.ServiceController.ExportOpenAPI)                         //导出OpenAPI 3文档
+	serviceRouter.GET("/:service_id/api-doc/openapi", r.ServiceController.ServiceGetOpenAPI)          //接口的OpenAPI 3文档
+	serviceRouter.POST("/:service_id/versions", r.ServiceController.ServiceVersionCreate)             //创建接口的新版本
+	serviceRouter.GET("/:service_id/versions", r.ServiceController.ServiceVersionList)                //接口的版本列表及调用统计
+	serviceRouter.PUT("/:service_id/deprecation", r.ServiceController.ServiceDeprecate)               //废弃接口版本
 
 	//审核流程实例
 	auditProcessInstanceRouter := router.Group("/audit-process-instance")
@@ -189,6 +196,14 @@ func (r *Router) RegisterApi(engine *gin.Engine) {
 	//服务调用记录
 	serviceCallRecordRouter := router.Group("/monitor")
 	serviceCallRecordRouter.GET("/list", r.ServiceCallRecordController.MonitorList) //获取服务调用记录监控列表
+
+	//定时任务
+	jobRouter := router.Group("/jobs")
+	jobRouter.GET("/status", r.ServiceDailyRecordController.JobStatus) //定时任务的leader和最近一次执行结果
+
+	//发件箱
+	outboxRouter := router.Group("/outbox")
+	outboxRouter.POST("/replay", r.OutboxController.Replay) //重新发送一段时间内的实体变更、审核申请消息
 }
 
 func (r *Router) RegisterFrontendApi(engine *gin.Engine) {
@@ -226,7 +241,7 @@ func (r *Router) RegisterInternalApi(engine *gin.Engine) {
 	engine.POST("/api/data-application-service/internal/v1/stats/subject-relation-count", r.ServiceStatsController.SubjectRelationCountGet) //获取主题域关联的Service数量
 	engine.PUT("/api/data-application-service/internal/v1/service/index", r.ServiceController.ServiceIndexUpdate)                           //版本升级时更新旧数据的ES索引信息
 	engine.PUT("/api/data-application-service/internal/v1/service/index2", r.ServiceController.ServiceIndexUpdate2)                         //版本升级时更新旧数据的ES索引信息(支持输入接口id参数)
-	//engine.GET("/api/internal/data-application-service/v1/services/:service_id", r.ServiceController.ServiceGet)                            //service detail
+	engine.GET("/api/internal/data-application-service/v1/services/:service_id", r.ServiceController.ServiceGet)                            //service detail
 	// 获取指定接口服务的 OwnerID
 	engine.GET("api/data-application-service/internal/v1/services/:service_id/owner_id", r.ServiceController.GetOwnerID)
 	// 批量发布、上线接口服务，不经过审核
@@ -241,4 +256,6 @@ func (r *Router) RegisterInternalApi(engine *gin.Engine) {
 	engine.GET("api/data-application-service/internal/v1/services/sub-service/batch", r.SubServiceDomainApi.ListSubService)
 
 	engine.POST("api/data-application-service/internal/v1/sub-service", r.SubServiceDomainApi.Create) // 创建子接口
+	// 逻辑视图关联的接口列表，供逻辑视图影响分析使用
+	engine.GET("api/data-application-service/internal/v1/data-view/:data_view_id/services", r.ServiceController.ServicesGetByDataViewId)
 }
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/form_validator"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/domain"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest/ginx"
+)
+
+type OutboxController struct {
+	domain *domain.OutboxRelayDomain
+}
+
+func NewOutboxController(domain *domain.OutboxRelayDomain) *OutboxController {
+	return &OutboxController{
+		domain: domain,
+	}
+}
+
+// Replay 重新发送发件箱消息
+//
+//	@Description	把创建时间在 [start_time, end_time) 内已发送或放弃发送的实体变更、审核申请消息重新置为待发送，按原顺序重新发送
+//	@Tags			发件箱
+//	@Summary		重新发送发件箱消息
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		dto.OutboxReplayReq	true	"请求参数"
+//	@Success		200	{object}	dto.OutboxReplayRes	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError		"失败响应参数"
+//	@Router			/api/data-application-service/v1/outbox/replay [post]
+func (s *OutboxController) Replay(c *gin.Context) {
+	req := &dto.OutboxReplayReq{}
+	_, err := form_validator.BindJsonAndValid(c, req)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
+		return
+	}
+
+	res, err := s.domain.Replay(c, req)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, res)
+}
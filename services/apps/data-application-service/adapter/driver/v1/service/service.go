@@ -1112,6 +1112,202 @@ func (s *ServiceController) ServiceGetExampleCode(c *gin.Context) {
 	ginx.ResOKJson(c, resp)
 }
 
+// ExportOpenAPI 导出OpenAPI 3文档
+//
+//	@Description	导出接口的OpenAPI 3文档，多个接口导出到同一个文档中，service_ids为空时导出app_id应用已授权的全部接口
+//	@Tags			接口文档
+//	@Summary		导出OpenAPI 3文档
+//	@Accept			json
+//	@Produce		application/json,application/yaml
+//	@Param			_	body		dto.ExportOpenAPIReqBody	true	"请求参数"
+//	@Success		200	{object}	string						"OpenAPI 3文档"
+//	@Failure		400	{object}	rest.HttpError				"失败响应参数"
+//	@Router			/api/data-application-service/v1/services/api-doc/openapi [post]
+func (s *ServiceController) ExportOpenAPI(c *gin.Context) {
+	req := &dto.ExportOpenAPIReq{}
+	if _, err := form_validator.BindJsonAndValid(c, &req.ExportOpenAPIReqBody); err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
+		return
+	}
+
+	resp, err := s.domain.ExportOpenAPI(c, &req.ExportOpenAPIReqBody)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+	writeOpenAPIDocument(c, req.Format, resp)
+}
+
+// ServiceGetOpenAPI 接口的OpenAPI 3文档
+//
+//	@Description	接口的OpenAPI 3文档
+//	@Tags			接口文档
+//	@Summary		接口的OpenAPI 3文档
+//	@Accept			json
+//	@Produce		application/json,application/yaml
+//	@Param			service_id	path		string			true	"接口ID"
+//	@Param			format		query		string			false	"文档格式 json 或 yaml，默认 json"
+//	@Success		200			{object}	string			"OpenAPI 3文档"
+//	@Failure		400			{object}	rest.HttpError	"失败响应参数"
+//	@Router			/api/data-application-service/v1/services/{service_id}/api-doc/openapi [get]
+func (s *ServiceController) ServiceGetOpenAPI(c *gin.Context) {
+	req := &dto.ServiceOpenAPIReq{}
+	if _, err := form_validator.BindUriAndValid(c, req); err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
+		return
+	}
+	if _, err := form_validator.BindQueryAndValid(c, req); err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+		return
+	}
+
+	resp, err := s.domain.ExportOpenAPI(c, &dto.ExportOpenAPIReqBody{ServiceIDs: []string{req.ServiceID}, Format: req.Format})
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+	writeOpenAPIDocument(c, req.Format, resp)
+}
+
+func writeOpenAPIDocument(c *gin.Context, format string, resp *dto.ExportAPIDocResp) {
+	contentType := "application/json"
+	if format == dto.OpenAPIFormatYAML {
+		contentType = "application/yaml"
+	}
+	disposition := fmt.Sprintf("attachment; filename=\"%s\"; filename*=utf-8''%s",
+		strings.ReplaceAll(resp.FileName, "\"", "\\\""),
+		url.QueryEscape(resp.FileName))
+	c.Writer.Header().Set("Content-Disposition", disposition)
+	c.Data(http.StatusOK, contentType, resp.Buffer.Bytes())
+}
+
+// ServiceVersionCreate 创建接口的新版本
+//
+//	@Description	基于已发布的接口创建新版本，新版本有独立的接口路径，与原接口属于同一个版本组，可以同时上线
+//	@Tags			接口
+//	@Summary		创建接口的新版本
+//	@Accept			json
+//	@Produce		json
+//	@Param			service_id	path		string						true	"已发布的接口ID"
+//	@Param			_			body		dto.ServiceCreateOrTempReq	true	"请求参数，service_info.version 必填"
+//	@Success		200			{object}	dto.ServiceCreateRes		"成功响应参数"
+//	@Failure		400			{object}	rest.HttpError				"失败响应参数"
+//	@Router			/api/data-application-service/v1/services/{service_id}/versions [post]
+func (s *ServiceController) ServiceVersionCreate(c *gin.Context) {
+	req := &dto.ServiceVersionCreateReq{}
+	_, err := form_validator.BindUriAndValid(c, &req.ServiceUpdateUriReq)
+	if err == nil {
+		_, err = form_validator.BindJsonAndValid(c, &req.ServiceCreateOrTempReq)
+	}
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
+		return
+	}
+
+	res, err := s.domain.ServiceVersionCreate(c, req)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, res)
+}
+
+// ServiceVersionList 接口的版本列表
+//
+//	@Description	接口所在版本组的所有版本及各版本的调用统计
+//	@Tags			接口
+//	@Summary		接口的版本列表
+//	@Accept			json
+//	@Produce		json
+//	@Param			service_id	path		string						true	"接口ID"
+//	@Param			days		query		int							false	"调用统计的时间范围，最近 n 天，默认 30"
+//	@Success		200			{object}	dto.ServiceVersionListRes	"成功响应参数"
+//	@Failure		400			{object}	rest.HttpError				"失败响应参数"
+//	@Router			/api/data-application-service/v1/services/{service_id}/versions [get]
+func (s *ServiceController) ServiceVersionList(c *gin.Context) {
+	req := &dto.ServiceVersionListReq{}
+	_, err := form_validator.BindUriAndValid(c, &req.ServiceUpdateUriReq)
+	if err == nil {
+		_, err = form_validator.BindQueryAndValid(c, req)
+	}
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
+		return
+	}
+
+	res, err := s.domain.ServiceVersionList(c, req)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, res)
+}
+
+// ServiceDeprecate 废弃接口版本
+//
+//	@Description	设置接口版本的废弃时间和计划下线时间，两个时间都为空时取消废弃。到达计划下线时间后由定时任务下线
+//	@Tags			接口
+//	@Summary		废弃接口版本
+//	@Accept			json
+//	@Produce		json
+//	@Param			service_id	path		string						true	"接口ID"
+//	@Param			_			body		dto.ServiceDeprecateBody	true	"请求参数"
+//	@Success		200			{object}	dto.ServiceIdRes			"成功响应参数"
+//	@Failure		400			{object}	rest.HttpError				"失败响应参数"
+//	@Router			/api/data-application-service/v1/services/{service_id}/deprecation [put]
+func (s *ServiceController) ServiceDeprecate(c *gin.Context) {
+	req := &dto.ServiceDeprecateReq{}
+	_, err := form_validator.BindUriAndValid(c, &req.ServiceUpdateUriReq)
+	if err == nil {
+		_, err = form_validator.BindJsonAndValid(c, &req.ServiceDeprecateBody)
+	}
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		if errors.As(err, &form_validator.ValidErrors{}) {
+			ginx.ResErrJson(c, errorcode.Detail(errorcode.PublicInvalidParameter, err))
+			return
+		}
+		ginx.ResErrJson(c, errorcode.Desc(errorcode.PublicRequestParameterError))
+		return
+	}
+
+	if err := s.domain.ServiceDeprecate(c, req); err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, &dto.ServiceIdRes{ServiceID: req.ServiceID})
+}
+
 // ServiceSyncCallback 触发接口同步回调
 //
 //	@Description	触发接口同步回调
@@ -61,3 +61,24 @@ func (s *ServiceDailyRecordController) GetDailyStatistics(c *gin.Context) {
 
 	ginx.ResOKJson(c, res)
 }
+
+// JobStatus 定时任务状态
+//
+//	@Description	多副本部署时每个定时任务只在 leader 实例上执行，查询各定时任务当前的 leader 和最近一次执行结果
+//	@Tags			每日统计
+//	@Summary		定时任务状态
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	domain.ScheduledJobStatusRes	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError					"失败响应参数"
+//	@Router			/api/data-application-service/v1/jobs/status [get]
+func (s *ServiceDailyRecordController) JobStatus(c *gin.Context) {
+	res, err := s.domain.JobStatus(c)
+	if err != nil {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		ginx.ResErrJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, res)
+}
@@ -41,6 +41,10 @@ type AppRunner struct {
 	Callbacks  *callbacks.Transports
 	// 每日统计领域服务
 	ServiceDailyRecordDomain *domain.ServiceDailyRecordDomain
+	// 接口版本下线领域服务
+	ServiceSunsetDomain *domain.ServiceSunsetDomain
+	// 发件箱消息发送领域服务
+	OutboxRelayDomain *domain.OutboxRelayDomain
 }
 
 func newApp(hs *rest.Server) *af_go_frame.App {
@@ -98,6 +102,12 @@ func main() {
 	appRunner.ServiceDailyRecordDomain.StartDailyRecordJob()
 	log.Info("每日统计初始化任务执行完成")
 
+	// 启动接口版本下线定时任务
+	appRunner.ServiceSunsetDomain.StartSunsetJob()
+
+	// 启动发件箱消息发送任务
+	appRunner.OutboxRelayDomain.StartRelayJob()
+
 	// 启动 Workflow Consumer
 	log.Info("开始启动Workflow消费者")
 	if err := appRunner.Consumer.Start(); err != nil {
@@ -137,6 +147,12 @@ func main() {
 			appRunner.ServiceDailyRecordDomain.StopDailyRecordJob()
 			log.Info("定时任务已停止")
 		}
+		if appRunner.ServiceSunsetDomain != nil {
+			appRunner.ServiceSunsetDomain.StopSunsetJob()
+		}
+		if appRunner.OutboxRelayDomain != nil {
+			appRunner.OutboxRelayDomain.StopRelayJob()
+		}
 
 		log.Info("应用优雅关闭完成")
 	}()
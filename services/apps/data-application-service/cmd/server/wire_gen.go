@@ -18,16 +18,21 @@ import (
 	"github.com/kweaver-ai/idrm-go-common/trace"
 	"github.com/kweaver-ai/idrm-go-common/workflow"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/callbacks"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_cache"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/leader_election"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq/consumer"
+	outbox2 "github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	service2 "github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq/consumer/service"
 	workflow2 "github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/workflow"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/audit_process_bind"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/developer"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/file"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/outbox"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service_apply"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driver/v1/service_call_record"
@@ -98,8 +103,11 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	serviceRepo := gorm.NewServiceRepo(data, dataViewRepo, configurationCenterRepo, userManagementRepo, dataSubjectRepo, serviceDailyRecordRepo, serviceCategoryRelationRepo, dataCatalogRepo, mqMQ, workflowInterface, callbackInterface, driven)
 	redis := repository.NewRedis(s)
+	gatewayCache := gateway_cache.NewGatewayCache(redis)
+	publishers := callbacks.NewOutboxPublishers(mqMQ, workflowInterface)
+	outboxOutbox := outbox2.NewOutbox(gormDB, publishers)
+	serviceRepo := gorm.NewServiceRepo(data, dataViewRepo, configurationCenterRepo, userManagementRepo, dataSubjectRepo, serviceDailyRecordRepo, serviceCategoryRelationRepo, dataCatalogRepo, mqMQ, workflowInterface, callbackInterface, driven, gatewayCache, outboxOutbox)
 	serviceStatsRepo := gorm.NewServiceStatsRepo(data, redis, serviceDailyRecordRepo)
 	virtualEngineRepo := microservice.NewVirtualEngineRepo()
 	auditProcessBindRepo := gorm.NewAuditProcessBindRepo(data)
@@ -107,12 +115,14 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 	basicSearchRepo := microservice.NewBasicSearchRepo()
 	appRepo := gorm.NewAppRepo(data)
 	authServiceRepo := microservice.NewAuthServiceRepo()
-	serviceApplyRepo := gorm.NewServiceApplyRepo(data, mqMQ, serviceRepo, appRepo, configurationCenterRepo, authServiceRepo, dataSubjectRepo, workflowInterface)
+	serviceApplyRepo := gorm.NewServiceApplyRepo(data, mqMQ, serviceRepo, appRepo, configurationCenterRepo, authServiceRepo, dataSubjectRepo, workflowInterface, outboxOutbox)
 	data_catalogDriven := impl4.NewDrivenImpl(client)
 	authServiceV1Interface := v1.NewBaseClient(client)
 	subServiceRepo := gorm.NewSubServiceImpl(gormDB)
 	drivenDeployMgm := microservice.NewDeployMgm()
-	serviceDomain := domain.NewServiceDomain(serviceRepo, serviceStatsRepo, dataCatalogRepo, dataViewRepo, virtualEngineRepo, configurationCenterRepo, developerRepo, auditProcessBindRepo, workflowRestRepo, basicSearchRepo, serviceApplyRepo, dataSubjectRepo, authServiceRepo, workflowInterface, userManagementRepo, data_catalogDriven, drivenUserMgnt, authServiceV1Interface, subServiceRepo, authServiceInternalV1Interface, drivenDeployMgm, driven, authorizationDriven)
+	gatewayCircuitBreaker := gateway_circuit_breaker.NewGatewayCircuitBreaker(redis)
+	serviceCallRecordRepo := gorm.NewServiceCallRecordRepo(data, configurationCenterRepo, driven, userManagementRepo)
+	serviceDomain := domain.NewServiceDomain(serviceRepo, serviceStatsRepo, dataCatalogRepo, dataViewRepo, virtualEngineRepo, configurationCenterRepo, developerRepo, auditProcessBindRepo, workflowRestRepo, basicSearchRepo, serviceApplyRepo, dataSubjectRepo, authServiceRepo, workflowInterface, userManagementRepo, data_catalogDriven, drivenUserMgnt, authServiceV1Interface, subServiceRepo, authServiceInternalV1Interface, drivenDeployMgm, driven, authorizationDriven, gatewayCircuitBreaker, serviceCallRecordRepo)
 	serviceController := service.NewServiceController(serviceDomain)
 	fileRepo := gorm.NewFileRepo(data)
 	fileDomain := domain.NewFileDomain(fileRepo)
@@ -126,14 +136,16 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 	serviceStatsController := service_stats.NewServiceStatsController(serviceStatsDomain)
 	subjectDomain := domain.NewSubjectDomain(serviceRepo, dataSubjectRepo)
 	subjectDomainController := subject_domain.NewSubjectDomainController(subjectDomain)
-	serviceCallRecordRepo := gorm.NewServiceCallRecordRepo(data, configurationCenterRepo, driven, userManagementRepo)
 	gatewayCollectionLogRepo := gorm.NewGatewayCollectionLogRepo(data, configurationCenterRepo, driven)
 	serviceCallRecordDomain := domain.NewServiceCallRecordDomain(serviceCallRecordRepo, gatewayCollectionLogRepo, configurationCenterRepo)
 	serviceCallRecordController := service_call_record.NewServiceCallRecordController(serviceCallRecordDomain)
-	serviceDailyRecordDomain := domain.NewServiceDailyRecordDomain(serviceDailyRecordRepo, serviceCallRecordRepo)
+	leaderElection := leader_election.NewLeaderElection(redis)
+	serviceDailyRecordDomain := domain.NewServiceDailyRecordDomain(serviceDailyRecordRepo, serviceCallRecordRepo, leaderElection)
 	serviceDailyRecordController := service_daily_record.NewServiceDailyRecordController(serviceDailyRecordDomain)
 	useCase := impl5.NewSubServiceUseCase(serviceRepo, subServiceRepo, mqMQ, authServiceInternalV1Interface)
 	subServiceService := sub_service.NewSubServiceService(useCase)
+	outboxRelayDomain := domain.NewOutboxRelayDomain(outboxOutbox, leaderElection)
+	outboxController := outbox.NewOutboxController(outboxRelayDomain)
 	router := &driver.Router{
 		Middleware:                   middleware,
 		DeveloperController:          developerController,
@@ -148,20 +160,24 @@ func InitApp(s *settings.Settings) (*AppRunner, func(), error) {
 		AuditLogger:                  logger,
 		ConfigurationCenterDriven:    driven,
 		SubServiceDomainApi:          subServiceService,
+		OutboxController:             outboxController,
 	}
 	server := driver.NewHttpServer(s, router)
 	app := newApp(server)
 	workflowConsumer := workflow2.NewConsumerAndRegisterHandlers(workflowInterface, serviceRepo, serviceApplyRepo)
-	handler := service2.NewHandler(serviceRepo)
+	handler := service2.NewHandler(serviceRepo, gatewayCache)
 	consumerConsumer := consumer.NewConsumer(mqMQ, handler)
-	entityChangeTransport := callbacks.NewEntityChangeTransport(mqMQ)
+	entityChangeTransport := callbacks.NewEntityChangeTransport(outboxOutbox)
 	transports := callbacks.NewTransport(gormDB, entityChangeTransport)
+	serviceSunsetDomain := domain.NewServiceSunsetDomain(serviceRepo, serviceCallRecordRepo, leaderElection, s)
 	appRunner := &AppRunner{
 		App:                      app,
 		Consumer:                 workflowConsumer,
 		MQConsumer:               consumerConsumer,
 		Callbacks:                transports,
 		ServiceDailyRecordDomain: serviceDailyRecordDomain,
+		ServiceSunsetDomain:      serviceSunsetDomain,
+		OutboxRelayDomain:        outboxRelayDomain,
 	}
 	return appRunner, func() {
 		cleanup2()
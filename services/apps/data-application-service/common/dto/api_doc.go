@@ -29,3 +29,28 @@ type ExportAPIDocResp struct {
 	Buffer   *bytes.Buffer `json:"buffer"`
 	FileName string        `json:"file_name"`
 }
+
+// OpenAPI 文档格式
+const (
+	OpenAPIFormatJSON = "json"
+	OpenAPIFormatYAML = "yaml"
+)
+
+// ExportOpenAPIReq OpenAPI 3 文档导出请求，所有接口导出到同一个文档中
+//
+// service_ids 为空时导出 app_id 应用已授权的全部接口
+type ExportOpenAPIReq struct {
+	ExportOpenAPIReqBody `param_type:"body"`
+}
+
+type ExportOpenAPIReqBody struct {
+	ServiceIDs []string `json:"service_ids" form:"service_ids" binding:"omitempty,dive,uuid" example:"019407b3-d158-7177-a0c8-0da2f2683c50" description:"接口ID列表，为空时根据app_id查询该应用已授权的全部接口"`
+	AppID      string   `json:"app_id" form:"app_id" binding:"omitempty,uuid" example:"019407b3-d158-7177-a0c8-0da2f2683c50" description:"应用ID"`
+	Format     string   `json:"format" form:"format" binding:"omitempty,oneof=json yaml" example:"json" description:"文档格式 json 或 yaml，默认 json"`
+}
+
+// ServiceOpenAPIReq 单个接口的 OpenAPI 3 文档导出请求
+type ServiceOpenAPIReq struct {
+	ServiceID string `json:"-" uri:"service_id" binding:"required,VerifyNameEn" example:"019407b3-d158-7177-a0c8-0da2f2683c50"`
+	Format    string `json:"-" form:"format" binding:"omitempty,oneof=json yaml" example:"json"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// OutboxReplayReq 重新发送一段时间内写入发件箱的消息
+type OutboxReplayReq struct {
+	// 开始时间，包含
+	StartTime string `json:"start_time" binding:"required,datetime=2006-01-02 15:04:05" example:"2025-01-01 00:00:00"`
+	// 结束时间，不包含
+	EndTime string `json:"end_time" binding:"required,datetime=2006-01-02 15:04:05" example:"2025-01-02 00:00:00"`
+	// 消息主题，为空时不限
+	Topic string `json:"topic" binding:"omitempty,oneof=af.business-grooming.entity_change workflow.audit.apply" example:"af.business-grooming.entity_change"`
+}
+
+type OutboxReplayRes struct {
+	// 重新置为待发送的消息数量
+	Count int64 `json:"count"`
+}
@@ -119,6 +119,8 @@ type ServiceCreateOrTempReq struct {
 	ServiceParam    ServiceParamWrite `json:"service_param"`           // 参数配置
 	ServiceResponse ServiceResponse   `json:"service_response"`        // 返回结果
 	ServiceTest     ServiceTest       `json:"service_test"`            // 接口测试
+	// 版本组id，创建新版本时由服务端设置
+	VersionGroupID string `json:"-"`
 }
 
 type ServiceCreateReq struct {
@@ -244,6 +246,20 @@ type ServiceInfo struct {
 	RateLimiting int64 `json:"rate_limiting" binding:"omitempty,number,min=0,max=100000"`
 	// 超时时间
 	Timeout int64 `json:"timeout" binding:"omitempty,number,min=1,max=86400"`
+	// 结果缓存时间 秒，0 不缓存，仅对接口生成类接口生效
+	CacheTTL int64 `json:"cache_ttl" binding:"omitempty,number,min=0,max=86400"`
+	// 总数缓存时间 秒，0 每次查询准确总数，缓存期间返回的总数标记为 estimated
+	CountCacheTTL int64 `json:"count_cache_ttl" binding:"omitempty,number,min=0,max=86400"`
+	// 请求与返回结果转换规则，仅对接口注册类接口生效
+	TransformRules *TransformRules `json:"transform_rules,omitempty" binding:"omitempty"`
+	// 语义化版本号，同一接口的多个版本可以同时发布，调用方通过不同的接口路径或请求头 X-Service-Version 选择版本
+	Version string `json:"version,omitempty" binding:"omitempty,max=32" example:"1.0.0"`
+	// 版本组id，同一接口的各个版本相同
+	VersionGroupID string `json:"version_group_id,omitempty"`
+	// 废弃时间
+	DeprecatedAt string `json:"deprecated_at,omitempty"`
+	// 计划下线时间，到期后由定时任务下线
+	SunsetAt string `json:"sunset_at,omitempty"`
 	// 上线时间
 	OnlineTime string `json:"online_time,omitempty"`
 	// 发布时间
@@ -400,6 +416,24 @@ type ServiceGetRes struct {
 	ServiceParam    ServiceParamRead `json:"service_param"`              // 参数配置
 	ServiceResponse *ServiceResponse `json:"service_response,omitempty"` // 返回结果
 	ServiceTest     ServiceTest      `json:"service_test"`               // 接口测试
+	// 接口注册后端服务在网关中的熔断状态，仅接口注册返回
+	CircuitBreaker *ServiceCircuitBreaker `json:"circuit_breaker,omitempty"`
+}
+
+// ServiceCircuitBreaker 接口注册后端服务在网关中的熔断状态
+type ServiceCircuitBreaker struct {
+	State     string                    `json:"state"`     // 汇总状态，任一网关实例熔断时为 open，其次为 half_open，否则为 closed
+	Instances []*CircuitBreakerInstance `json:"instances"` // 各网关实例的熔断器状态
+}
+
+// CircuitBreakerInstance 网关实例中接口的熔断器状态
+type CircuitBreakerInstance struct {
+	Instance  string `json:"instance"`                                           // 网关实例
+	State     string `json:"state"`                                              // 熔断器状态 closed 关闭 open 熔断 half_open 半开
+	Requests  int64  `json:"requests"`                                           // 当前统计窗口内的请求数
+	Failures  int64  `json:"failures"`                                           // 当前统计窗口内的失败数
+	OpenedAt  string `json:"opened_at,omitempty" example:"2024-12-27 18:43:59"`  // 熔断开始时间
+	ChangedAt string `json:"changed_at,omitempty" example:"2024-12-27 18:43:59"` // 状态变化时间
 }
 
 type ServiceGetFrontendRes struct {
@@ -684,6 +718,9 @@ type GatewayCollectionLogRes struct {
 type ServiceGetDocumentationResp struct {
 	ServiceID       string           `json:"service_id" form:"service_id" binding:"omitempty,VerifyNameEn" example:"019407b3-d158-7177-a0c8-0da2f2683c50"`
 	ServiceName     string           `json:"service_name" binding:"required,VerifyDescription" example:"接口名称"`
+	ServiceType     string           `json:"service_type"`
+	ServicePath     string           `json:"service_path"`
+	Description     string           `json:"description"`
 	HTTPMethod      string           `json:"http_method" binding:"omitempty,oneof=post get put delete"`
 	AccessUrl       string           `json:"access_url" binding:"omitempty,omitempty,URL,max=255" example:"https://10.4.134.54/"`
 	ApiUrl          string           `json:"api_url" binding:"omitempty,omitempty,URL,max=255" example:"/api/path"`
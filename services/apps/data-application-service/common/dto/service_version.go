@@ -0,0 +1,63 @@
+package dto
+
+// ServiceVersionHeader 调用方通过该请求头在同一版本组内选择接口版本
+const ServiceVersionHeader = "X-Service-Version"
+
+// ServiceVersionCreateReq 基于已发布的接口创建新版本
+type ServiceVersionCreateReq struct {
+	ServiceUpdateUriReq
+	ServiceCreateOrTempReq
+}
+
+type ServiceVersionListReq struct {
+	ServiceUpdateUriReq
+	// 调用统计的时间范围，最近 n 天，调用记录保留 90 天
+	Days int `json:"days" form:"days,default=30" binding:"omitempty,min=1,max=90" default:"30" example:"30"`
+}
+
+type ServiceVersionListRes struct {
+	// 版本组id
+	VersionGroupID string                `json:"version_group_id"`
+	Entries        []*ServiceVersionInfo `json:"entries"`
+}
+
+// ServiceVersionInfo 接口版本及其调用统计
+type ServiceVersionInfo struct {
+	ServiceID     string `json:"service_id"`
+	ServiceName   string `json:"service_name"`
+	ServicePath   string `json:"service_path"`
+	Version       string `json:"version"`
+	Status        string `json:"status"`
+	PublishStatus string `json:"publish_status"`
+	// 废弃时间
+	DeprecatedAt string `json:"deprecated_at,omitempty"`
+	// 计划下线时间
+	SunsetAt string `json:"sunset_at,omitempty"`
+	// 调用统计
+	CallStats ServiceCallStats `json:"call_stats"`
+}
+
+// ServiceCallStats 接口在统计时间范围内的调用统计
+type ServiceCallStats struct {
+	// 成功次数
+	SuccessCount int64 `json:"success_count"`
+	// 失败次数
+	FailCount int64 `json:"fail_count"`
+	// 平均耗时 毫秒
+	AvgLatencyMs int64 `json:"avg_latency_ms"`
+	// 最近一次调用时间
+	LastCallTime string `json:"last_call_time,omitempty"`
+}
+
+type ServiceDeprecateReq struct {
+	ServiceUpdateUriReq
+	ServiceDeprecateBody
+}
+
+// ServiceDeprecateBody 废弃接口版本，两个时间都为空时取消废弃
+type ServiceDeprecateBody struct {
+	// 废弃时间，为空且设置了计划下线时间时取当前时间
+	DeprecatedAt string `json:"deprecated_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"2025-01-01 00:00:00"`
+	// 计划下线时间，到期且在 idle_days 内没有调用时由定时任务下线
+	SunsetAt string `json:"sunset_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"2025-06-30 00:00:00"`
+}
@@ -0,0 +1,75 @@
+package dto
+
+import "encoding/json"
+
+// TransformRules 接口注册类接口的请求与返回结果转换规则，由网关在转发请求和返回结果时执行
+type TransformRules struct {
+	// 请求转换规则
+	Request *RequestTransform `json:"request,omitempty" binding:"omitempty"`
+	// 返回结果转换规则
+	Response *ResponseTransform `json:"response,omitempty" binding:"omitempty"`
+}
+
+// RequestTransform 请求转换规则，按转发到后台服务的参数位置分别配置
+type RequestTransform struct {
+	// 请求头
+	Headers []ParamMapping `json:"headers,omitempty" binding:"omitempty,dive"`
+	// 查询参数
+	Query []ParamMapping `json:"query,omitempty" binding:"omitempty,dive"`
+	// 请求体，From、To 支持以 . 分隔的嵌套字段路径
+	Body []ParamMapping `json:"body,omitempty" binding:"omitempty,dive"`
+}
+
+// ParamMapping 参数映射，把调用方传入的参数 From 以 To 为参数名转发，From 为空时注入常量 Value
+type ParamMapping struct {
+	// 调用方传入的参数名
+	From string `json:"from" binding:"omitempty,max=255" example:"userId"`
+	// 转发到后台服务的参数名
+	To string `json:"to" binding:"required,max=255" example:"user_id"`
+	// 注入的常量值
+	Value string `json:"value" binding:"omitempty,max=1024"`
+}
+
+// ResponseTransform 返回结果转换规则，返回结果必须为 JSON
+type ResponseTransform struct {
+	// 记录所在位置，以 . 分隔的字段路径，为空表示整个返回结果。记录可以是对象或对象数组
+	Root string `json:"root" binding:"omitempty,max=255" example:"data.entries"`
+	// 为 true 时记录只保留 Fields 中配置的字段
+	Project bool `json:"project"`
+	// 字段的重命名和脱敏规则
+	Fields []FieldMapping `json:"fields,omitempty" binding:"omitempty,dive"`
+}
+
+// FieldMapping 返回字段映射
+type FieldMapping struct {
+	// 后台服务返回的字段名
+	From string `json:"from" binding:"required,max=255" example:"mobile"`
+	// 返回给调用方的字段名，为空时不重命名
+	To string `json:"to" binding:"omitempty,max=255" example:"phone"`
+	// 脱敏规则 id，即数据视图中配置的脱敏规则
+	DesensitizationRuleID string `json:"desensitization_rule_id" binding:"omitempty,max=36"`
+}
+
+// MarshalTransformRules 转换为数据库中保存的 JSON，未配置时为空字符串
+func MarshalTransformRules(rules *TransformRules) (string, error) {
+	if rules == nil || (rules.Request == nil && rules.Response == nil) {
+		return "", nil
+	}
+	b, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// UnmarshalTransformRules 解析数据库中保存的 JSON，未配置或无法解析时返回 nil
+func UnmarshalTransformRules(s string) *TransformRules {
+	if s == "" {
+		return nil
+	}
+	rules := &TransformRules{}
+	if err := json.Unmarshal([]byte(s), rules); err != nil {
+		return nil
+	}
+	return rules
+}
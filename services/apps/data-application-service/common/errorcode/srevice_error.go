@@ -47,6 +47,12 @@ const (
 	InfoSystemIdNotExist = servicePreCoder + "InfoSystemIdNotExist"
 	// 应用ID不存在
 	AppsIdNotExist = servicePreCoder + "AppsIdNotExist"
+	// 版本号已存在
+	ServiceVersionExist = servicePreCoder + "ServiceVersionExist"
+	// 只能基于已发布的接口创建新版本
+	ServiceVersionUnPublished = servicePreCoder + "ServiceVersionUnPublished"
+	// 计划下线时间早于废弃时间
+	ServiceSunsetBeforeDeprecation = servicePreCoder + "ServiceSunsetBeforeDeprecation"
 )
 
 var serviceErrorMap = errorCode{
@@ -199,4 +205,19 @@ var serviceErrorMap = errorCode{
 		cause:       "",
 		solution:    "请检查接口名称是否正确",
 	},
+	ServiceVersionExist: {
+		description: "接口版本号已存在",
+		cause:       "",
+		solution:    "请重新输入版本号",
+	},
+	ServiceVersionUnPublished: {
+		description: "只有处于【已发布】状态的接口才能创建新版本",
+		cause:       "",
+		solution:    "请先发布接口",
+	},
+	ServiceSunsetBeforeDeprecation: {
+		description: "计划下线时间不能早于废弃时间",
+		cause:       "",
+		solution:    "请重新设置计划下线时间",
+	},
 }
@@ -29,6 +29,8 @@ type Settings struct {
 	Workflow Workflow
 	// 回调配置
 	Callback Callback `json:"callback,omitempty" yaml:"callback"`
+	// 接口版本下线配置
+	ServiceSunset ServiceSunset `json:"service_sunset,omitempty" yaml:"service_sunset"`
 }
 
 type Server struct {
@@ -132,3 +134,11 @@ type Callback struct {
 	// 回调接口前缀
 	PrePath string `json:"pre_path,omitempty" yaml:"pre_path"`
 }
+
+// 接口版本下线配置
+type ServiceSunset struct {
+	// 检查到期版本的间隔，如 1h，默认 1h
+	Interval string `json:"interval,omitempty" yaml:"interval"`
+	// 到期版本最近 IdleDays 天内仍有调用时推迟下线，0 表示到期后直接下线
+	IdleDays int `json:"idle_days,omitempty" yaml:"idle_days"`
+}
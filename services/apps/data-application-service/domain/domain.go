@@ -15,6 +15,8 @@ var ProviderSet = wire.NewSet(
 	NewServiceApplyDomain,
 	NewSubjectDomain,
 	NewServiceDailyRecordDomain,
+	NewServiceSunsetDomain,
+	NewOutboxRelayDomain,
 	sub_service.NewSubServiceUseCase,
 	NewServiceCallRecordDomain,
 )
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/leader_election"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// OutboxRelayDomain 发件箱消息发送领域服务，多副本部署时只有 leader 发送，保证同一实体的消息按顺序发送
+type OutboxRelayDomain struct {
+	outbox         outbox.Outbox
+	leaderElection leader_election.LeaderElection
+	stopChan       chan struct{} // 停止信号
+	isRunning      bool          // 运行状态
+	mu             sync.RWMutex  // 保护状态变量
+}
+
+// NewOutboxRelayDomain 创建发件箱消息发送领域服务
+func NewOutboxRelayDomain(outbox outbox.Outbox, leaderElection leader_election.LeaderElection) *OutboxRelayDomain {
+	return &OutboxRelayDomain{
+		outbox:         outbox,
+		leaderElection: leaderElection,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+// StartRelayJob 启动发件箱消息发送任务
+func (d *OutboxRelayDomain) StartRelayJob() {
+	d.mu.Lock()
+	if d.isRunning {
+		d.mu.Unlock()
+		log.Warn("StartRelayJob 已经在运行中")
+		return
+	}
+	d.isRunning = true
+	d.stopChan = make(chan struct{})
+	d.mu.Unlock()
+
+	log.Info("StartRelayJob 发件箱消息发送任务已启动")
+	go d.run()
+}
+
+func (d *OutboxRelayDomain) run() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("StartRelayJob panic recovered", zap.Any("panic", r))
+		}
+
+		d.mu.Lock()
+		d.isRunning = false
+		d.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopChan := d.stopChan
+	go func() {
+		select {
+		case <-stopChan:
+			log.Info("StartRelayJob 收到停止信号，退出循环")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	d.leaderElection.Campaign(ctx, ScheduledJobOutboxRelay, func(ctx context.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("StartRelayJob panic recovered", zap.Any("panic", r))
+			}
+		}()
+		d.outbox.Relay(ctx)
+	})
+}
+
+// StopRelayJob 停止发件箱消息发送任务
+func (d *OutboxRelayDomain) StopRelayJob() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.isRunning {
+		close(d.stopChan)
+		d.isRunning = false
+		log.Info("StartRelayJob 已停止")
+	}
+}
+
+// IsRunning 检查发件箱消息发送任务是否正在运行
+func (d *OutboxRelayDomain) IsRunning() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.isRunning
+}
+
+// Replay 把一段时间内已发送或放弃发送的消息重新置为待发送，由 leader 按顺序重新发送
+func (d *OutboxRelayDomain) Replay(ctx context.Context, req *dto.OutboxReplayReq) (*dto.OutboxReplayRes, error) {
+	start, err := parseServiceTime(req.StartTime)
+	if err != nil {
+		return nil, errorcode.Detail(errorcode.PublicInvalidParameter, err.Error())
+	}
+	end, err := parseServiceTime(req.EndTime)
+	if err != nil {
+		return nil, errorcode.Detail(errorcode.PublicInvalidParameter, err.Error())
+	}
+	if !start.Before(*end) {
+		return nil, errorcode.Detail(errorcode.PublicInvalidParameter, "start_time 必须早于 end_time")
+	}
+
+	count, err := d.outbox.Replay(ctx, req.Topic, *start, *end)
+	if err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Info("OutboxRelayDomain Replay",
+		zap.String("topic", req.Topic), zap.String("startTime", req.StartTime), zap.String("endTime", req.EndTime), zap.Int64("count", count))
+	return &dto.OutboxReplayRes{Count: count}, nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/leader_election"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 定时任务名称，多副本部署时每个定时任务单独选主
+const (
+	ScheduledJobDailyRecord   = "service_daily_record" // 每日统计记录
+	ScheduledJobServiceSunset = "service_sunset"       // 接口版本下线
+	ScheduledJobOutboxRelay   = "outbox_relay"         // 发件箱消息发送
+)
+
+var errScheduledJobStopped = errors.New("定时任务已停止")
+
+var scheduledJobs = []string{ScheduledJobDailyRecord, ScheduledJobServiceSunset, ScheduledJobOutboxRelay}
+
+type ScheduledJobStatusRes struct {
+	// 当前实例
+	Instance string                `json:"instance"`
+	Entries  []*ScheduledJobStatus `json:"entries"`
+}
+
+// ScheduledJobStatus 定时任务的 leader 和最近一次执行结果
+type ScheduledJobStatus struct {
+	// 定时任务名称
+	Job string `json:"job"`
+	// 当前 leader 实例，为空表示没有 leader
+	Leader string `json:"leader"`
+	// 成为 leader 的时间
+	LeaderSince string `json:"leader_since,omitempty"`
+	// 当前实例是否是 leader
+	IsLeader bool `json:"is_leader"`
+	// 最近一次执行结果
+	LastRun *ScheduledJobRun `json:"last_run,omitempty"`
+}
+
+type ScheduledJobRun struct {
+	// 执行的实例
+	Instance   string `json:"instance"`
+	StartedAt  string `json:"started_at"`
+	FinishedAt string `json:"finished_at"`
+	// 是否成功
+	Success bool `json:"success"`
+	// 失败原因
+	Error string `json:"error,omitempty"`
+}
+
+// scheduledJobStatus 查询定时任务的 leader 和最近一次执行结果
+func scheduledJobStatus(ctx context.Context, le leader_election.LeaderElection) (*ScheduledJobStatusRes, error) {
+	res := &ScheduledJobStatusRes{Instance: le.Identity(), Entries: make([]*ScheduledJobStatus, 0, len(scheduledJobs))}
+	for _, job := range scheduledJobs {
+		status := &ScheduledJobStatus{Job: job}
+		lease, err := le.Leader(ctx, job)
+		if err != nil {
+			log.WithContext(ctx).Error("scheduledJobStatus Leader", zap.String("job", job), zap.Error(err))
+			return nil, err
+		}
+		if lease != nil {
+			status.Leader = lease.Identity
+			status.LeaderSince = lease.AcquiredAt.Format("2006-01-02 15:04:05")
+			status.IsLeader = lease.Identity == le.Identity()
+		}
+		run, err := le.LastRun(ctx, job)
+		if err != nil {
+			log.WithContext(ctx).Error("scheduledJobStatus LastRun", zap.String("job", job), zap.Error(err))
+			return nil, err
+		}
+		if run != nil {
+			status.LastRun = &ScheduledJobRun{
+				Instance:   run.Identity,
+				StartedAt:  run.StartedAt.Format("2006-01-02 15:04:05"),
+				FinishedAt: run.FinishedAt.Format("2006-01-02 15:04:05"),
+				Success:    run.Success,
+				Error:      run.Error,
+			}
+		}
+		res.Entries = append(res.Entries, status)
+	}
+	return res, nil
+}
+
+// recordScheduledJobRun 记录定时任务的执行结果，记录失败不影响定时任务
+func recordScheduledJobRun(le leader_election.LeaderElection, job string, startedAt time.Time, err error) {
+	run := &leader_election.JobRun{
+		Job:        job,
+		Identity:   le.Identity(),
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		Success:    err == nil,
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if e := le.RecordRun(ctx, run); e != nil {
+		log.Warn("recordScheduledJobRun 记录定时任务执行结果失败", zap.String("job", job), zap.Error(e))
+	}
+}
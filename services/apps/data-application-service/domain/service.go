@@ -22,6 +22,7 @@ import (
 	"github.com/valyala/fasttemplate"
 	"go.uber.org/zap"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gateway_circuit_breaker"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gorm"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/microservice"
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/mq"
@@ -73,6 +74,8 @@ type ServiceDomain struct {
 	deployMgmRepo             microservice.DrivenDeployMgm
 	configurationCenterDriven configuration_center.Driven
 	authorizationDriven       authorization.Driven
+	gatewayCircuitBreaker     gateway_circuit_breaker.GatewayCircuitBreaker
+	serviceCallRecordRepo     gorm.ServiceCallRecordRepo
 }
 
 func NewServiceDomain(
@@ -100,6 +103,8 @@ func NewServiceDomain(
 	deployMgmRepo microservice.DrivenDeployMgm,
 	configurationCenterDriven configuration_center.Driven,
 	authorizationDriven authorization.Driven,
+	gatewayCircuitBreaker gateway_circuit_breaker.GatewayCircuitBreaker,
+	serviceCallRecordRepo gorm.ServiceCallRecordRepo,
 ) *ServiceDomain {
 	return &ServiceDomain{
 		clock:                   clock.RealClock{},
@@ -127,6 +132,8 @@ func NewServiceDomain(
 		deployMgmRepo:             deployMgmRepo,
 		configurationCenterDriven: configurationCenterDriven,
 		authorizationDriven:       authorizationDriven,
+		gatewayCircuitBreaker:     gatewayCircuitBreaker,
+		serviceCallRecordRepo:     serviceCallRecordRepo,
 	}
 }
 
@@ -239,6 +246,42 @@ func (u *ServiceDomain) ServiceGet(ctx context.Context, req *dto.ServiceGetReq)
 		return nil, err
 	}
 
+	// 接口注册展示后端服务的熔断状态，读取失败不影响接口详情
+	if res.ServiceInfo.ServiceType == "service_register" {
+		res.CircuitBreaker, err = u.serviceCircuitBreaker(ctx, req.ServiceID)
+		if err != nil {
+			log.WithContext(ctx).Warn("ServiceGet serviceCircuitBreaker", zap.String("service_id", req.ServiceID), zap.Error(err))
+		}
+	}
+
+	return res, nil
+}
+
+// serviceCircuitBreaker 汇总接口在各网关实例中的熔断器状态
+func (u *ServiceDomain) serviceCircuitBreaker(ctx context.Context, serviceID string) (*dto.ServiceCircuitBreaker, error) {
+	states, err := u.gatewayCircuitBreaker.States(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	res := &dto.ServiceCircuitBreaker{State: "closed", Instances: make([]*dto.CircuitBreakerInstance, 0, len(states))}
+	for _, s := range states {
+		instance := &dto.CircuitBreakerInstance{
+			Instance:  s.Instance,
+			State:     s.State,
+			Requests:  s.Requests,
+			Failures:  s.Failures,
+			OpenedAt:  util.TimeFormat(s.OpenedAt),
+			ChangedAt: util.TimeFormat(&s.ChangedAt),
+		}
+		res.Instances = append(res.Instances, instance)
+
+		switch {
+		case s.State == "open":
+			res.State = "open"
+		case s.State == "half_open" && res.State != "open":
+			res.State = "half_open"
+		}
+	}
 	return res, nil
 }
 
@@ -869,6 +912,12 @@ func (u *ServiceDomain) serviceCheckParam(ctx context.Context, serviceInfo dto.S
 		if serviceInfo.BackendServicePath == "" {
 			validErrors = append(validErrors, &form_validator.ValidError{Key: "service_info.backend_service_path", Message: "backend_service_path为必填字段"})
 		}
+
+		validErrors = append(validErrors, transformRulesCheck(serviceInfo.TransformRules)...)
+	}
+
+	if serviceInfo.Version != "" && !semverRegexp.MatchString(serviceInfo.Version) {
+		validErrors = append(validErrors, &form_validator.ValidError{Key: "service_info.version", Message: "version必须是语义化版本号，如 2.0.0"})
 	}
 
 	if serviceInfo.HTTPMethod == "" {
@@ -956,6 +1005,55 @@ func (u *ServiceDomain) serviceCheckParam(ctx context.Context, serviceInfo dto.S
 	return validErrors
 }
 
+// transformRulesCheck 检查接口注册的转换规则，参数映射需指定来源参数或常量值，同一位置的目标参数不能重复
+func transformRulesCheck(rules *dto.TransformRules) (validErrors form_validator.ValidErrors) {
+	if rules == nil {
+		return nil
+	}
+
+	if rules.Request != nil {
+		positions := []struct {
+			key      string
+			mappings []dto.ParamMapping
+		}{
+			{key: "headers", mappings: rules.Request.Headers},
+			{key: "query", mappings: rules.Request.Query},
+			{key: "body", mappings: rules.Request.Body},
+		}
+		for _, p := range positions {
+			targets := make(map[string]bool)
+			for i, m := range p.mappings {
+				key := fmt.Sprintf("service_info.transform_rules.request.%s[%d]", p.key, i)
+				if m.From == "" && m.Value == "" {
+					validErrors = append(validErrors, &form_validator.ValidError{Key: key + ".from", Message: "from和value不能同时为空"})
+				}
+				if p.key == "body" && (strings.HasPrefix(m.To, ".") || strings.HasSuffix(m.To, ".") || strings.Contains(m.To, "..")) {
+					validErrors = append(validErrors, &form_validator.ValidError{Key: key + ".to", Message: "to不是有效的字段路径"})
+				}
+				if targets[m.To] {
+					validErrors = append(validErrors, &form_validator.ValidError{Key: key + ".to", Message: "to重复: " + m.To})
+				}
+				targets[m.To] = true
+			}
+		}
+	}
+
+	if rules.Response != nil {
+		targets := make(map[string]bool)
+		for i, f := range rules.Response.Fields {
+			to := f.To
+			if to == "" {
+				to = f.From
+			}
+			if targets[to] {
+				validErrors = append(validErrors, &form_validator.ValidError{Key: fmt.Sprintf("service_info.transform_rules.response.fields[%d].to", i), Message: "to重复: " + to})
+			}
+			targets[to] = true
+		}
+	}
+	return validErrors
+}
+
 func (u *ServiceDomain) AuditProcessInstanceCreate(c context.Context, req *dto.AuditProcessInstanceCreateReq) error {
 	service, err := u.serviceRepo.ServiceGet(c, req.ServiceID)
 	if err != nil {
@@ -2464,6 +2562,9 @@ func (u *ServiceDomain) GetServicesByIDs(ctx context.Context, ids []string) (*dr
 				Description:        serviceInfo.Description,
 				RateLimiting:       serviceInfo.RateLimiting,
 				Timeout:            serviceInfo.Timeout,
+				CacheTTL:           serviceInfo.CacheTTL,
+				CountCacheTTL:      serviceInfo.CountCacheTTL,
+				TransformRules:     serviceInfo.TransformRules,
 				OnlineTime:         serviceInfo.OnlineTime,
 				ChangedServiceId:   serviceInfo.ChangedServiceId,
 				IsChanged:          serviceInfo.IsChanged,
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/leader_election"
 	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
 	"go.uber.org/zap"
 )
@@ -17,9 +19,10 @@ import (
 type ServiceDailyRecordDomain struct {
 	dailyRecordRepo gorm.ServiceDailyRecordRepo
 	callRecordRepo  gorm.ServiceCallRecordRepo
-	stopChan        chan struct{} // 停止信号
-	isRunning       bool          // 运行状态
-	mu              sync.RWMutex  // 保护状态变量
+	leaderElection  leader_election.LeaderElection // 多副本部署时只有 leader 执行定时任务
+	stopChan        chan struct{}                  // 停止信号
+	isRunning       bool                           // 运行状态
+	mu              sync.RWMutex                   // 保护状态变量
 }
 
 // start GetDailyStatistic
@@ -46,17 +49,19 @@ type DailyStatistics struct {
 // end GetDailyStatistics
 
 // NewServiceDailyRecordDomain 创建每日统计记录领域服务
-func NewServiceDailyRecordDomain(dailyRecordRepo gorm.ServiceDailyRecordRepo, callRecordRepo gorm.ServiceCallRecordRepo) *ServiceDailyRecordDomain {
+func NewServiceDailyRecordDomain(dailyRecordRepo gorm.ServiceDailyRecordRepo, callRecordRepo gorm.ServiceCallRecordRepo, leaderElection leader_election.LeaderElection) *ServiceDailyRecordDomain {
 	return &ServiceDailyRecordDomain{
 		dailyRecordRepo: dailyRecordRepo,
 		callRecordRepo:  callRecordRepo,
+		leaderElection:  leaderElection,
 		stopChan:        make(chan struct{}),
 		isRunning:       false,
 		mu:              sync.RWMutex{},
 	}
 }
 
-// StartDailyRecordJob 启动定时任务，每天00:00:00为当天创建基础统计记录
+// StartDailyRecordJob 启动定时任务，每天00:00:00为当天创建基础统计记录。多副本部署时各实例竞选 leader，
+// 只有 leader 执行启动检查和每日任务，leader 退出后其他实例接替并重新执行启动检查
 func (d *ServiceDailyRecordDomain) StartDailyRecordJob() {
 	// 检查是否已经在运行
 	d.mu.Lock()
@@ -89,14 +94,68 @@ func (d *ServiceDailyRecordDomain) StartDailyRecordJob() {
 		zap.String("utcTime", utcNow.Format("2006-01-02 15:04:05 MST")),
 		zap.String("location", now.Location().String()))
 
+	// 竞选 leader，成为 leader 后执行定时任务
+	go d.campaign()
+}
+
+// campaign 竞选定时任务的 leader 直到收到停止信号
+func (d *ServiceDailyRecordDomain) campaign() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("StartDailyRecordJob panic recovered",
+				zap.Any("panic", r))
+		}
+
+		// 确保状态被正确重置
+		d.mu.Lock()
+		d.isRunning = false
+		d.mu.Unlock()
+
+		log.Info("StartDailyRecordJob goroutine已退出")
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopChan := d.stopChan
+	go func() {
+		select {
+		case <-stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	d.leaderElection.Campaign(ctx, ScheduledJobDailyRecord, d.lead)
+}
+
+// lead 成为 leader 后执行启动检查和每日任务，ctx 在失去 leader 身份或收到停止信号时取消
+func (d *ServiceDailyRecordDomain) lead(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("StartDailyRecordJob panic recovered",
+				zap.Any("panic", r))
+		}
+	}()
+
+	startedAt := time.Now()
+	err := d.runStartupTasks(ctx)
+	recordScheduledJobRun(d.leaderElection, ScheduledJobDailyRecord, startedAt, err)
+
+	d.runScheduledJob(ctx)
+}
+
+// runStartupTasks 启动时检查当天记录，删除过期记录，同步历史部门信息
+func (d *ServiceDailyRecordDomain) runStartupTasks(ctx context.Context) error {
+	var errs []error
+	now := time.Now()
 	// 启动时先检查并创建今天的记录（如果缺失的话）
-	ctx := context.Background()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	log.WithContext(ctx).Info("StartDailyRecordJob 启动时检查当天记录", zap.String("date", today.Format("2006-01-02")))
 
 	err := d.dailyRecordRepo.GenerateDailyRecords(ctx, today)
 	if err != nil {
 		log.WithContext(ctx).Error("StartDailyRecordJob 启动时创建当天记录失败", zap.Error(err))
+		errs = append(errs, err)
 	} else {
 		log.WithContext(ctx).Info("StartDailyRecordJob 启动时检查当天记录完成", zap.String("date", today.Format("2006-01-02")))
 	}
@@ -105,6 +164,7 @@ func (d *ServiceDailyRecordDomain) StartDailyRecordJob() {
 	err = d.dailyRecordRepo.DeleteExpiredRecords(ctx)
 	if err != nil {
 		log.WithContext(ctx).Error("StartDailyRecordJob 启动时删除过期记录失败", zap.Error(err))
+		errs = append(errs, err)
 	} else {
 		log.WithContext(ctx).Info("StartDailyRecordJob 启动时删除过期记录完成")
 	}
@@ -114,6 +174,7 @@ func (d *ServiceDailyRecordDomain) StartDailyRecordJob() {
 		err = d.callRecordRepo.DeleteExpiredRecords(ctx)
 		if err != nil {
 			log.WithContext(ctx).Error("StartDailyRecordJob 启动时删除过期服务调用记录失败", zap.Error(err))
+			errs = append(errs, err)
 		} else {
 			log.WithContext(ctx).Info("StartDailyRecordJob 启动时删除过期服务调用记录完成")
 		}
@@ -124,37 +185,24 @@ func (d *ServiceDailyRecordDomain) StartDailyRecordJob() {
 	err = d.dailyRecordRepo.SyncAllRecordsDepartmentInfo(ctx)
 	if err != nil {
 		log.WithContext(ctx).Error("StartDailyRecordJob 启动时同步历史部门信息失败", zap.Error(err))
+		errs = append(errs, err)
 	} else {
 		log.WithContext(ctx).Info("StartDailyRecordJob 启动时同步历史部门信息完成")
 	}
-
-	// 启动定时任务goroutine
-	go d.runScheduledJob()
+	return errors.Join(errs...)
 }
 
-// runScheduledJob 运行定时任务的核心逻辑
-func (d *ServiceDailyRecordDomain) runScheduledJob() {
-	// 添加panic恢复机制
-	defer func() {
-		if r := recover(); r != nil {
-			log.Error("StartDailyRecordJob panic recovered",
-				zap.Any("panic", r))
-		}
-
-		// 确保状态被正确重置
-		d.mu.Lock()
-		d.isRunning = false
-		d.mu.Unlock()
-
-		log.Info("StartDailyRecordJob goroutine已退出")
-	}()
-
+// runScheduledJob 运行定时任务的核心逻辑，ctx 取消时退出
+func (d *ServiceDailyRecordDomain) runScheduledJob(ctx context.Context) {
 	for {
 		// 检查是否收到停止信号
 		select {
 		case <-d.stopChan:
 			log.Info("StartDailyRecordJob 收到停止信号，退出循环")
 			return
+		case <-ctx.Done():
+			log.Info("StartDailyRecordJob 不再是 leader，退出循环")
+			return
 		default:
 			// 继续执行
 		}
@@ -198,10 +246,16 @@ func (d *ServiceDailyRecordDomain) runScheduledJob() {
 			timer.Stop() // 确保timer被停止
 			log.Info("StartDailyRecordJob 在等待期间收到停止信号")
 			return
+		case <-ctx.Done():
+			timer.Stop()
+			log.Info("StartDailyRecordJob 在等待期间不再是 leader")
+			return
 		}
 
 		// 执行定时任务，增加重试机制
-		d.executeWithRetry()
+		startedAt := time.Now()
+		err := d.executeWithRetry(ctx)
+		recordScheduledJobRun(d.leaderElection, ScheduledJobDailyRecord, startedAt, err)
 	}
 }
 
@@ -226,10 +280,15 @@ func (d *ServiceDailyRecordDomain) IsRunning() bool {
 	return d.isRunning
 }
 
-// executeWithRetry 执行定时任务，包含重试机制
-func (d *ServiceDailyRecordDomain) executeWithRetry() {
+// JobStatus 定时任务的 leader 和最近一次执行结果
+func (d *ServiceDailyRecordDomain) JobStatus(ctx context.Context) (*ScheduledJobStatusRes, error) {
+	return scheduledJobStatus(ctx, d.leaderElection)
+}
+
+// executeWithRetry 执行定时任务，包含重试机制，返回最后一次失败的原因
+func (d *ServiceDailyRecordDomain) executeWithRetry(parent context.Context) error {
 	// 添加超时控制
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	ctx, cancel := context.WithTimeout(parent, 10*time.Minute)
 	defer cancel()
 
 	maxRetries := 3
@@ -239,7 +298,7 @@ func (d *ServiceDailyRecordDomain) executeWithRetry() {
 		select {
 		case <-d.stopChan:
 			log.Info("executeWithRetry 收到停止信号，退出重试")
-			return
+			return errScheduledJobStopped
 		default:
 			// 继续执行
 		}
@@ -256,7 +315,7 @@ func (d *ServiceDailyRecordDomain) executeWithRetry() {
 		// 再次检查dailyRecordRepo是否为nil
 		if d.dailyRecordRepo == nil {
 			log.WithContext(ctx).Error("StartDailyRecordJob: dailyRecordRepo is nil during execution")
-			return
+			return errors.New("dailyRecordRepo is nil")
 		}
 
 		// 使用带超时的context执行任务
@@ -279,15 +338,15 @@ func (d *ServiceDailyRecordDomain) executeWithRetry() {
 					// 继续重试
 				case <-d.stopChan:
 					log.Info("executeWithRetry 在重试等待期间收到停止信号")
-					return
+					return errScheduledJobStopped
 				case <-ctx.Done():
 					log.Warn("executeWithRetry 执行超时，退出重试")
-					return
+					return ctx.Err()
 				}
 				continue
 			} else {
 				log.WithContext(ctx).Error("StartDailyRecordJob 达到最大重试次数，放弃执行")
-				return
+				return err
 			}
 		}
 
@@ -309,15 +368,15 @@ func (d *ServiceDailyRecordDomain) executeWithRetry() {
 					// 继续重试
 				case <-d.stopChan:
 					log.Info("executeWithRetry 在重试等待期间收到停止信号")
-					return
+					return errScheduledJobStopped
 				case <-ctx.Done():
 					log.Warn("executeWithRetry 执行超时，退出重试")
-					return
+					return ctx.Err()
 				}
 				continue
 			} else {
 				log.WithContext(ctx).Error("StartDailyRecordJob 达到最大重试次数，放弃执行")
-				return
+				return err
 			}
 		}
 
@@ -338,8 +397,9 @@ func (d *ServiceDailyRecordDomain) executeWithRetry() {
 		log.WithContext(ctx).Info("StartDailyRecordJob completed successfully",
 			zap.String("date", today.Format("2006-01-02")),
 			zap.Int("attempt", attempt))
-		return
+		return nil
 	}
+	return nil
 }
 
 func (d *ServiceDailyRecordDomain) GetDailyStatistics(ctx context.Context, req *GetDailyStatisticsReq) (res *GetDailyStatisticsRes, err error) {
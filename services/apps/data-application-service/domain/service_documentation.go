@@ -839,6 +839,9 @@ func (u *ServiceDomain) ServiceGetDocumentationData(ctx context.Context, cssjj b
 		apiResp := &dto.ServiceGetDocumentationResp{}
 		apiResp.ServiceID = serviceId
 		apiResp.ServiceName = serviceRes.ServiceInfo.ServiceName
+		apiResp.ServiceType = serviceRes.ServiceInfo.ServiceType
+		apiResp.ServicePath = serviceRes.ServiceInfo.ServicePath
+		apiResp.Description = serviceRes.ServiceInfo.Description
 		apiResp.AccessUrl = accessUrl
 		// 请求方式
 		apiResp.HTTPMethod = serviceRes.ServiceInfo.HTTPMethod
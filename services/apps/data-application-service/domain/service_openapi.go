@@ -0,0 +1,420 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// OpenAPI 3 文档结构，只包含导出接口文档用到的部分
+// https://spec.openapis.org/oas/v3.0.3
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi" yaml:"openapi"`
+	Info       openAPIInfo                             `json:"info" yaml:"info"`
+	Servers    []openAPIServer                         `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Security   []map[string][]string                   `json:"security,omitempty" yaml:"security,omitempty"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths" yaml:"paths"`
+	Components openAPIComponents                       `json:"components" yaml:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+type openAPIServer struct {
+	URL       string                           `json:"url" yaml:"url"`
+	Variables map[string]openAPIServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+type openAPIServerVariable struct {
+	Default     string `json:"default" yaml:"default"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId" yaml:"operationId"`
+	Summary     string                      `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses" yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name" yaml:"name"`
+	In          string         `json:"in" yaml:"in"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema" yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]openAPIMediaType `json:"content" yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Ref         string                      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema  *openAPISchema `json:"schema" yaml:"schema"`
+	Example any            `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+type openAPISchema struct {
+	Ref         string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type        string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string                    `json:"format,omitempty" yaml:"format,omitempty"`
+	Description string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Enum        []string                  `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default     any                       `json:"default,omitempty" yaml:"default,omitempty"`
+	Minimum     *int                      `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Items       *openAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties  map[string]*openAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Responses       map[string]*openAPIResponse       `json:"responses,omitempty" yaml:"responses,omitempty"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+type openAPISecurityScheme struct {
+	Type        string `json:"type" yaml:"type"`
+	Scheme      string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	In          string `json:"in,omitempty" yaml:"in,omitempty"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// cssjj 项目网关的签名请求头，四个请求头需同时传入
+var openAPISignatureHeaders = []struct {
+	name        string
+	description string
+}{
+	{name: "x-tif-paasid", description: "应用的PaaSID"},
+	{name: "x-tif-timestamp", description: "当前unix时间戳(秒)"},
+	{name: "x-tif-nonce", description: "随机字符串"},
+	{name: "x-tif-signature", description: "签名字符串，sha256(timestamp + token + nonce + timestamp)"},
+}
+
+// 网关返回错误时各 HTTP 状态码的说明，每个状态码下的错误码按网关的错误码定义生成
+var openAPIErrorStatusDescriptions = map[int]string{
+	http.StatusBadRequest:         "请求参数错误、签名校验失败、无调用权限或后台服务返回错误",
+	http.StatusUnauthorized:       "令牌校验失败",
+	http.StatusTooManyRequests:    "调用频次、每日配额或并发数超过限制，可按 Retry-After 响应头等待后重试",
+	http.StatusServiceUnavailable: "后台服务熔断中，可按 Retry-After 响应头等待后重试",
+}
+
+// openAPIErrorDescription 错误响应的说明，列出该 HTTP 状态码下网关返回的全部错误码
+func openAPIErrorDescription(status int, codes []string) string {
+	description, ok := openAPIErrorStatusDescriptions[status]
+	if !ok {
+		description = http.StatusText(status)
+	}
+	return description + "，错误码：" + strings.Join(codes, "、")
+}
+
+// ExportOpenAPI 导出接口的 OpenAPI 3 文档，多个接口导出到同一个文档中。
+// service_ids 为空时导出应用已授权的全部接口
+func (u *ServiceDomain) ExportOpenAPI(ctx context.Context, req *dto.ExportOpenAPIReqBody) (*dto.ExportAPIDocResp, error) {
+	cssjj, err := u.IsCSSJJ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var title string
+	serviceIds := req.ServiceIDs
+	if len(serviceIds) == 0 && req.AppID != "" {
+		serviceIds, err = u.serviceApplyRepo.AvailableServiceIDs(ctx, req.AppID)
+		if err != nil {
+			return nil, err
+		}
+		if app, err := u.configurationCenterDriven.GetApplication(ctx, req.AppID); err == nil {
+			title = app.Name
+		}
+	}
+	if len(serviceIds) == 0 {
+		return nil, errorcode.Detail(errorcode.PublicInvalidParameter, "service_ids 为空且 app_id 应用没有已授权的接口")
+	}
+
+	serviceInfos, err := u.ServiceGetDocumentationData(ctx, cssjj, serviceIds...)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := buildOpenAPIDocument(title, cssjj, serviceInfos)
+	buf, err := marshalOpenAPIDocument(doc, req.Format)
+	if err != nil {
+		log.WithContext(ctx).Error("ExportOpenAPI", zap.Error(err))
+		return nil, errorcode.Detail(errorcode.PublicInternalError, err.Error())
+	}
+
+	name := title
+	if len(serviceInfos) == 1 {
+		name = serviceInfos[0].ServiceName
+	}
+	if name == "" {
+		name = "openapi"
+	}
+	ext := dto.OpenAPIFormatJSON
+	if req.Format == dto.OpenAPIFormatYAML {
+		ext = dto.OpenAPIFormatYAML
+	}
+	fileName := fmt.Sprintf("%s_%s.%s", truncateForFileName(sanitizeFileName(name), 25), time.Now().Format("20060102150405"), ext)
+	return &dto.ExportAPIDocResp{Buffer: buf, FileName: fileName}, nil
+}
+
+func marshalOpenAPIDocument(doc *openAPIDocument, format string) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	if format == dto.OpenAPIFormatYAML {
+		encoder := yaml.NewEncoder(buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return nil, err
+		}
+		return buf, encoder.Close()
+	}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// buildOpenAPIDocument 根据接口的请求参数、返回参数生成 OpenAPI 3 文档。
+// cssjj 项目使用 x-tif 签名请求头鉴权，其他项目使用应用申请的令牌鉴权
+func buildOpenAPIDocument(title string, cssjj bool, serviceInfos []*dto.ServiceGetDocumentationResp) *openAPIDocument {
+	if title == "" && len(serviceInfos) == 1 {
+		title = serviceInfos[0].ServiceName
+	}
+	if title == "" {
+		title = "数据服务接口"
+	}
+
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: title, Version: "1.0.0"},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{
+			Schemas:         map[string]*openAPISchema{"Error": openAPIErrorSchema()},
+			Responses:       make(map[string]*openAPIResponse),
+			SecuritySchemes: make(map[string]*openAPISecurityScheme),
+		},
+	}
+	if len(serviceInfos) == 1 {
+		doc.Info.Description = serviceInfos[0].Description
+	}
+
+	if cssjj {
+		requirement := make(map[string][]string)
+		for _, h := range openAPISignatureHeaders {
+			doc.Components.SecuritySchemes[h.name] = &openAPISecurityScheme{Type: "apiKey", In: "header", Name: h.name, Description: h.description}
+			requirement[h.name] = []string{}
+		}
+		doc.Security = []map[string][]string{requirement}
+	} else {
+		doc.Components.SecuritySchemes["bearerAuth"] = &openAPISecurityScheme{Type: "http", Scheme: "bearer", Description: "通过应用申请的令牌 access_token"}
+		doc.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	for status, codes := range gateway_errorcode.CodesByStatus() {
+		doc.Components.Responses["Error"+strconv.Itoa(status)] = &openAPIResponse{
+			Description: openAPIErrorDescription(status, codes),
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: &openAPISchema{Ref: "#/components/schemas/Error"}}},
+		}
+	}
+
+	servers := make(map[string]bool)
+	for _, info := range serviceInfos {
+		// 接口地址去掉接口路径即网关地址，cssjj 项目的网关地址包含应用的 PaaSID
+		server := strings.TrimSuffix(info.ApiUrl, info.ServicePath)
+		if !servers[server] {
+			servers[server] = true
+			s := openAPIServer{URL: strings.Replace(server, "%s", "{paasid}", 1)}
+			if cssjj {
+				s.Variables = map[string]openAPIServerVariable{"paasid": {Default: "paasid", Description: "应用的PaaSID"}}
+			}
+			doc.Servers = append(doc.Servers, s)
+		}
+
+		method := strings.ToLower(info.HTTPMethod)
+		if method == "" {
+			method = "get"
+		}
+		if doc.Paths[info.ServicePath] == nil {
+			doc.Paths[info.ServicePath] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[info.ServicePath][method] = openAPIServiceOperation(info, method)
+	}
+	return doc
+}
+
+// openAPIServiceOperation 生成接口的 OpenAPI 操作。GET 请求的参数为查询参数，其他请求的参数在 JSON 请求体中
+func openAPIServiceOperation(info *dto.ServiceGetDocumentationResp, method string) *openAPIOperation {
+	op := &openAPIOperation{
+		OperationID: "service_" + strings.ReplaceAll(info.ServiceID, "-", "_"),
+		Summary:     info.ServiceName,
+		Description: info.Description,
+		Responses:   make(map[string]*openAPIResponse),
+	}
+
+	type param struct {
+		name     string
+		required bool
+		schema   *openAPISchema
+	}
+	var params []param
+	// 接口生成类接口由网关分页查询
+	if info.ServiceType == "service_generate" {
+		minimum := 1
+		params = append(params,
+			param{name: "offset", schema: &openAPISchema{Type: "integer", Description: "分页-页编号", Minimum: &minimum, Default: 1}},
+			param{name: "limit", schema: &openAPISchema{Type: "integer", Description: "分页-单页大小", Minimum: &minimum}},
+			param{name: "cursor", schema: &openAPISchema{Type: "string", Description: "分页游标，即上一页返回的 next_cursor，传入后忽略 offset"}},
+			param{name: "with_total", schema: &openAPISchema{Type: "boolean", Description: "是否返回总数", Default: true}},
+		)
+	}
+	for _, p := range info.ServiceParam.DataTableRequestParams {
+		schema := openAPIDataTypeSchema(p.DataType)
+		schema.Description = p.Description
+		schema.Default = openAPIDefaultValue(p.DataType, p.DefaultValue)
+		params = append(params, param{name: p.EnName, required: p.Required == "yes", schema: schema})
+	}
+
+	if method == "get" {
+		for _, p := range params {
+			op.Parameters = append(op.Parameters, &openAPIParameter{Name: p.name, In: "query", Description: p.schema.Description, Required: p.required, Schema: p.schema})
+		}
+	} else if len(params) > 0 {
+		body := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+		for _, p := range params {
+			body.Properties[p.name] = p.schema
+			if p.required {
+				body.Required = append(body.Required, p.name)
+			}
+		}
+		op.RequestBody = &openAPIRequestBody{
+			Required: len(body.Required) > 0,
+			Content:  map[string]openAPIMediaType{"application/json": {Schema: body}},
+		}
+	}
+
+	record := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for _, p := range info.ServiceParam.DataTableResponseParams {
+		schema := openAPIDataTypeSchema(p.DataType)
+		schema.Description = p.Description
+		record.Properties[p.EnName] = schema
+	}
+
+	var example any
+	if info.ServiceTest.ResponseExample != "" {
+		if err := json.Unmarshal([]byte(info.ServiceTest.ResponseExample), &example); err != nil {
+			example = nil
+		}
+	}
+
+	ok := &openAPIResponse{Description: "成功", Content: make(map[string]openAPIMediaType)}
+	if info.ServiceType == "service_generate" {
+		ok.Content["application/json"] = openAPIMediaType{
+			Schema: &openAPISchema{
+				Type: "object",
+				Properties: map[string]*openAPISchema{
+					"total_count":      {Type: "integer", Format: "int64", Description: "查询总数量，with_total 为 false 时为 -1"},
+					"total_count_type": {Type: "string", Description: "总数的准确性 exact 准确 estimated 估计 omitted 未查询", Enum: []string{"exact", "estimated", "omitted"}},
+					"next_cursor":      {Type: "string", Description: "下一页的分页游标，没有下一页时为空"},
+					"data":             {Type: "array", Description: "查询的数据", Items: record},
+				},
+			},
+			Example: example,
+		}
+		// 请求头 Accept 指定流式格式时逐行返回
+		ok.Content["application/x-ndjson"] = openAPIMediaType{Schema: record}
+		ok.Content["text/csv"] = openAPIMediaType{Schema: &openAPISchema{Type: "string"}}
+	} else {
+		ok.Content["application/json"] = openAPIMediaType{Schema: record, Example: example}
+	}
+	op.Responses["200"] = ok
+	for status := range gateway_errorcode.CodesByStatus() {
+		op.Responses[strconv.Itoa(status)] = &openAPIResponse{Ref: "#/components/responses/Error" + strconv.Itoa(status)}
+	}
+	return op
+}
+
+// openAPIDataTypeSchema 接口参数的字段类型对应的 OpenAPI 类型
+func openAPIDataTypeSchema(dataType string) *openAPISchema {
+	switch dataType {
+	case "int":
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case "long":
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case "float":
+		return &openAPISchema{Type: "number", Format: "float"}
+	case "double":
+		return &openAPISchema{Type: "number", Format: "double"}
+	case "boolean":
+		return &openAPISchema{Type: "boolean"}
+	default:
+		return &openAPISchema{Type: "string"}
+	}
+}
+
+// openAPIDefaultValue 按字段类型转换参数的默认值，无法转换时返回 nil
+func openAPIDefaultValue(dataType, value string) any {
+	if value == "" {
+		return nil
+	}
+	switch dataType {
+	case "int", "long":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case "float", "double":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	default:
+		return value
+	}
+	return nil
+}
+
+func openAPIErrorSchema() *openAPISchema {
+	return &openAPISchema{
+		Type:     "object",
+		Required: []string{"code", "description"},
+		Properties: map[string]*openAPISchema{
+			"code":        {Type: "string", Description: "错误码"},
+			"description": {Type: "string", Description: "错误描述"},
+			"solution":    {Type: "string", Description: "解决方法"},
+			"detail":      {Description: "错误详情"},
+		},
+	}
+}
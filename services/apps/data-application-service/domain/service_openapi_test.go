@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/lib/common/gateway_errorcode"
+)
+
+func Test_buildOpenAPIDocument(t *testing.T) {
+	info := &dto.ServiceGetDocumentationResp{
+		ServiceID:   "019407b3-d158-7177-a0c8-0da2f2683c50",
+		ServiceName: "用户查询",
+		ServiceType: "service_generate",
+		ServicePath: "/users",
+		HTTPMethod:  "post",
+		ApiUrl:      "https://10.4.134.54:443/data-application-gateway/users",
+	}
+	info.ServiceParam.DataTableRequestParams = []dto.DataTableRequestParam{
+		{EnName: "age", DataType: "int", Required: "yes", DefaultValue: "18"},
+	}
+	info.ServiceParam.DataTableResponseParams = []dto.DataTableResponseParam{
+		{EnName: "name", DataType: "string"},
+	}
+	info.ServiceTest.ResponseExample = `{"total_count":1,"data":[{"name":"a"}]}`
+
+	doc := buildOpenAPIDocument("", false, []*dto.ServiceGetDocumentationResp{info})
+	assert.Equal(t, "用户查询", doc.Info.Title)
+	assert.Equal(t, "https://10.4.134.54:443/data-application-gateway", doc.Servers[0].URL)
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, doc.Security)
+
+	op := doc.Paths["/users"]["post"]
+	assert.Equal(t, "service_019407b3_d158_7177_a0c8_0da2f2683c50", op.OperationID)
+	body := op.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, []string{"age"}, body.Required)
+	assert.Equal(t, "integer", body.Properties["age"].Type)
+	assert.Equal(t, int64(18), body.Properties["age"].Default)
+	assert.Contains(t, body.Properties, "offset")
+	data := op.Responses["200"].Content["application/json"].Schema.Properties["data"]
+	assert.Equal(t, "string", data.Items.Properties["name"].Type)
+	assert.NotNil(t, op.Responses["200"].Content["application/json"].Example)
+	assert.Equal(t, "#/components/responses/Error429", op.Responses["429"].Ref)
+	assert.Equal(t, "#/components/responses/Error503", op.Responses["503"].Ref)
+	// 错误响应按网关的错误码定义生成
+	for _, code := range []string{gateway_errorcode.NonceReplayed, gateway_errorcode.SignValidateError, gateway_errorcode.ObligationNotSupported} {
+		assert.Contains(t, doc.Components.Responses["Error400"].Description, code)
+	}
+	assert.Contains(t, doc.Components.Responses["Error503"].Description, gateway_errorcode.CircuitOpenError)
+	assert.NotContains(t, doc.Components.Responses["Error503"].Description, gateway_errorcode.BackendUnavailable)
+
+	info.HTTPMethod = "get"
+	info.ServiceType = "service_register"
+	info.ApiUrl = "https://smartgate.changsha.gov.cn/ebus/%s/data-application-gateway/users"
+	doc = buildOpenAPIDocument("应用", true, []*dto.ServiceGetDocumentationResp{info})
+	assert.Equal(t, "https://smartgate.changsha.gov.cn/ebus/{paasid}/data-application-gateway", doc.Servers[0].URL)
+	assert.Len(t, doc.Security[0], 4)
+	op = doc.Paths["/users"]["get"]
+	assert.Nil(t, op.RequestBody)
+	assert.Len(t, op.Parameters, 1)
+	assert.Equal(t, "query", op.Parameters[0].In)
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/gorm"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/adapter/driven/leader_election"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/enum"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/settings"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 检查到期版本的默认间隔
+const defaultServiceSunsetInterval = time.Hour
+
+// ServiceSunsetDomain 接口版本下线领域服务，定时下线到达计划下线时间的接口版本
+type ServiceSunsetDomain struct {
+	serviceRepo    gorm.ServiceRepo
+	callRecordRepo gorm.ServiceCallRecordRepo
+	leaderElection leader_election.LeaderElection // 多副本部署时只有 leader 执行定时任务
+	interval       time.Duration
+	idleDays       int
+	stopChan       chan struct{} // 停止信号
+	isRunning      bool          // 运行状态
+	mu             sync.RWMutex  // 保护状态变量
+}
+
+// NewServiceSunsetDomain 创建接口版本下线领域服务
+func NewServiceSunsetDomain(serviceRepo gorm.ServiceRepo, callRecordRepo gorm.ServiceCallRecordRepo, leaderElection leader_election.LeaderElection, s *settings.Settings) *ServiceSunsetDomain {
+	interval := defaultServiceSunsetInterval
+	if s.ServiceSunset.Interval != "" {
+		d, err := time.ParseDuration(s.ServiceSunset.Interval)
+		if err != nil || d < time.Minute {
+			log.Warn("NewServiceSunsetDomain 检查间隔配置无效，使用默认值",
+				zap.String("interval", s.ServiceSunset.Interval), zap.Error(err))
+		} else {
+			interval = d
+		}
+	}
+	return &ServiceSunsetDomain{
+		serviceRepo:    serviceRepo,
+		callRecordRepo: callRecordRepo,
+		leaderElection: leaderElection,
+		interval:       interval,
+		idleDays:       s.ServiceSunset.IdleDays,
+		stopChan:       make(chan struct{}),
+	}
+}
+
+// StartSunsetJob 启动定时任务，按配置的间隔下线到期的接口版本，多副本部署时只有 leader 执行
+func (d *ServiceSunsetDomain) StartSunsetJob() {
+	d.mu.Lock()
+	if d.isRunning {
+		d.mu.Unlock()
+		log.Warn("StartSunsetJob 已经在运行中")
+		return
+	}
+	d.isRunning = true
+	d.stopChan = make(chan struct{})
+	d.mu.Unlock()
+
+	log.Info("StartSunsetJob 定时任务已启动", zap.Duration("interval", d.interval), zap.Int("idleDays", d.idleDays))
+	go d.run()
+}
+
+func (d *ServiceSunsetDomain) run() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("StartSunsetJob panic recovered", zap.Any("panic", r))
+		}
+
+		d.mu.Lock()
+		d.isRunning = false
+		d.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopChan := d.stopChan
+	go func() {
+		select {
+		case <-stopChan:
+			log.Info("StartSunsetJob 收到停止信号，退出循环")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	d.leaderElection.Campaign(ctx, ScheduledJobServiceSunset, d.lead)
+}
+
+// lead 成为 leader 后按间隔下线到期的接口版本，ctx 在失去 leader 身份或收到停止信号时取消
+func (d *ServiceSunsetDomain) lead(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("StartSunsetJob panic recovered", zap.Any("panic", r))
+		}
+	}()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			startedAt := time.Now()
+			runCtx, cancel := context.WithTimeout(ctx, d.interval)
+			err := d.Sunset(runCtx, startedAt)
+			cancel()
+			recordScheduledJobRun(d.leaderElection, ScheduledJobServiceSunset, startedAt, err)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// StopSunsetJob 停止定时任务
+func (d *ServiceSunsetDomain) StopSunsetJob() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.isRunning {
+		close(d.stopChan)
+		d.isRunning = false
+		log.Info("StartSunsetJob 已停止")
+	}
+}
+
+// IsRunning 检查定时任务是否正在运行
+func (d *ServiceSunsetDomain) IsRunning() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.isRunning
+}
+
+// Sunset 下线到达计划下线时间的接口版本。计划下线时间由管理员设置，下线不再经过下线审核；
+// 配置了 idleDays 时，最近 idleDays 天内仍有调用的版本推迟下线，等待调用方迁移到新版本。
+// 单个版本下线失败不影响其他版本，返回最后一个失败原因
+func (d *ServiceSunsetDomain) Sunset(ctx context.Context, now time.Time) (err error) {
+	services, err := d.serviceRepo.SunsetDueServices(ctx, now)
+	if err != nil || len(services) == 0 {
+		return err
+	}
+
+	stats := make(map[string]bool)
+	if d.idleDays > 0 {
+		ids := make([]string, 0, len(services))
+		for _, s := range services {
+			ids = append(ids, s.ServiceID)
+		}
+		res, err := d.callRecordRepo.CallStats(ctx, ids, now.AddDate(0, 0, -d.idleDays))
+		if err != nil {
+			return err
+		}
+		for id, st := range res {
+			stats[id] = st.SuccessCount+st.FailCount > 0
+		}
+	}
+
+	for _, s := range services {
+		if stats[s.ServiceID] {
+			log.WithContext(ctx).Warn("Sunset 接口版本仍有调用，推迟下线",
+				zap.String("serviceID", s.ServiceID), zap.String("version", s.Version), zap.Int("idleDays", d.idleDays))
+			continue
+		}
+
+		audit := &model.Service{
+			ApplyID:     util.GetUniqueString(),
+			AuditType:   enum.AuditTypeOffline,
+			AuditStatus: enum.AuditStatusPass,
+			Status:      enum.LineStatusOffLine,
+			UpdateTime:  now,
+		}
+		if e := d.serviceRepo.AuditProcessInstanceCreate(ctx, s.ServiceID, audit); e != nil {
+			log.WithContext(ctx).Error("Sunset 下线接口版本失败", zap.String("serviceID", s.ServiceID), zap.Error(e))
+			err = e
+			continue
+		}
+		log.WithContext(ctx).Info("Sunset 接口版本已下线",
+			zap.String("serviceID", s.ServiceID), zap.String("serviceName", s.ServiceName), zap.String("version", s.Version))
+	}
+	return err
+}
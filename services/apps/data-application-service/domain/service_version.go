@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/dto"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/enum"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/form_validator"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-application-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 未设置版本号的接口加入版本组时使用的版本号
+const defaultServiceVersion = "1.0.0"
+
+// semverRegexp 语义化版本号 MAJOR.MINOR.PATCH，可带预发布标识，如 2.0.0-beta.1
+var semverRegexp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+var serviceVersionFields = []string{"service_id", "service_name", "service_path", "status", "publish_status", "changed_service_id", "version_group_id", "version", "deprecated_at", "sunset_at"}
+
+// ServiceVersionCreate 基于已发布的接口创建新版本。新版本是一个独立的接口，有自己的接口路径，
+// 发布、上下线流程与普通接口相同，与原接口属于同一个版本组，可以同时上线
+func (u *ServiceDomain) ServiceVersionCreate(ctx context.Context, req *dto.ServiceVersionCreateReq) (res *dto.ServiceCreateRes, err error) {
+	base, err := u.serviceRepo.ServiceGetFields(ctx, req.ServiceID, serviceVersionFields)
+	if err != nil {
+		return nil, err
+	}
+	if base.ServiceID == "" {
+		return nil, errorcode.Desc(errorcode.ServiceIDNotExist)
+	}
+	if base.PublishStatus != enum.PublishStatusPublished || !isPublishedVersion(base) {
+		return nil, errorcode.Desc(errorcode.ServiceVersionUnPublished)
+	}
+
+	version := req.ServiceInfo.Version
+	if !semverRegexp.MatchString(version) {
+		return nil, form_validator.ValidErrors{{Key: "service_info.version", Message: "version必须是语义化版本号，如 2.0.0"}}
+	}
+
+	versionGroupID, baseVersion := base.VersionGroupID, base.Version
+	if versionGroupID == "" {
+		versionGroupID = base.ServiceID
+	}
+	if baseVersion == "" {
+		baseVersion = defaultServiceVersion
+	}
+	if version == baseVersion {
+		return nil, errorcode.Desc(errorcode.ServiceVersionExist)
+	}
+	exist, err := u.serviceRepo.IsServiceVersionExist(ctx, versionGroupID, version)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errorcode.Desc(errorcode.ServiceVersionExist)
+	}
+
+	// 原接口第一次创建新版本时加入版本组
+	if base.VersionGroupID == "" || base.Version == "" {
+		if err = u.serviceRepo.UpdateServiceVersion(ctx, base.ServiceID, versionGroupID, baseVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	req.ServiceCreateOrTempReq.VersionGroupID = versionGroupID
+	return u.ServiceCreate(ctx, &req.ServiceCreateOrTempReq)
+}
+
+// ServiceVersionList 接口所在版本组的所有版本，按版本号排序，附带最近 days 天的调用统计
+func (u *ServiceDomain) ServiceVersionList(ctx context.Context, req *dto.ServiceVersionListReq) (res *dto.ServiceVersionListRes, err error) {
+	base, err := u.serviceRepo.ServiceGetFields(ctx, req.ServiceID, serviceVersionFields)
+	if err != nil {
+		return nil, err
+	}
+	if base.ServiceID == "" {
+		return nil, errorcode.Desc(errorcode.ServiceIDNotExist)
+	}
+
+	// 未启用多版本的接口只有它自己
+	services := []*model.Service{base}
+	if base.VersionGroupID != "" {
+		if services, err = u.serviceRepo.ServiceVersions(ctx, base.VersionGroupID); err != nil {
+			return nil, err
+		}
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = 30
+	}
+	ids := make([]string, 0, len(services))
+	for _, s := range services {
+		ids = append(ids, s.ServiceID)
+	}
+	stats, err := u.serviceCallRecordRepo.CallStats(ctx, ids, u.clock.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	res = &dto.ServiceVersionListRes{VersionGroupID: base.VersionGroupID, Entries: make([]*dto.ServiceVersionInfo, 0, len(services))}
+	for _, s := range services {
+		info := &dto.ServiceVersionInfo{
+			ServiceID:     s.ServiceID,
+			ServiceName:   s.ServiceName,
+			ServicePath:   s.ServicePath,
+			Version:       s.Version,
+			Status:        s.Status,
+			PublishStatus: s.PublishStatus,
+			DeprecatedAt:  util.TimeFormat(s.DeprecatedAt),
+			SunsetAt:      util.TimeFormat(s.SunsetAt),
+		}
+		if st, ok := stats[s.ServiceID]; ok {
+			info.CallStats = *st
+		}
+		res.Entries = append(res.Entries, info)
+	}
+	sort.SliceStable(res.Entries, func(i, j int) bool {
+		return compareSemver(res.Entries[i].Version, res.Entries[j].Version) < 0
+	})
+	return res, nil
+}
+
+// ServiceDeprecate 设置接口版本的废弃时间和计划下线时间。废弃的版本仍可调用，网关在返回结果中添加
+// Deprecation、Sunset 响应头提示调用方迁移，到达计划下线时间后由定时任务下线
+func (u *ServiceDomain) ServiceDeprecate(ctx context.Context, req *dto.ServiceDeprecateReq) (err error) {
+	exist, err := u.serviceRepo.IsServiceIDExist(ctx, req.ServiceID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errorcode.Desc(errorcode.ServiceIDNotExist)
+	}
+
+	deprecatedAt, err := parseServiceTime(req.DeprecatedAt)
+	if err != nil {
+		return errorcode.Detail(errorcode.PublicInvalidParameter, err.Error())
+	}
+	sunsetAt, err := parseServiceTime(req.SunsetAt)
+	if err != nil {
+		return errorcode.Detail(errorcode.PublicInvalidParameter, err.Error())
+	}
+	if sunsetAt != nil && deprecatedAt == nil {
+		now := u.clock.Now()
+		deprecatedAt = &now
+	}
+	if sunsetAt != nil && sunsetAt.Before(*deprecatedAt) {
+		return errorcode.Desc(errorcode.ServiceSunsetBeforeDeprecation)
+	}
+
+	return u.serviceRepo.UpdateServiceDeprecation(ctx, req.ServiceID, deprecatedAt, sunsetAt)
+}
+
+// isPublishedVersion 是否是已发布的版本，而不是变更产生的草稿
+func isPublishedVersion(s *model.Service) bool {
+	return s.ChangedServiceId == "" || s.ChangedServiceId == "NULL"
+}
+
+func parseServiceTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+	if err != nil {
+		log.Warn("parseServiceTime", zap.String("time", s), zap.Error(err))
+		return nil, err
+	}
+	return &t, nil
+}
+
+// compareSemver 比较两个语义化版本号，返回 -1、0、1。预发布版本低于对应的正式版本，
+// 不是语义化版本号的排在最前面
+func compareSemver(a, b string) int {
+	pa, oka := parseSemver(a)
+	pb, okb := parseSemver(b)
+	switch {
+	case !oka && !okb:
+		return strings.Compare(a, b)
+	case !oka:
+		return -1
+	case !okb:
+		return 1
+	}
+	for i := 0; i < 3; i++ {
+		if pa.numbers[i] != pb.numbers[i] {
+			if pa.numbers[i] < pb.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case pa.prerelease == pb.prerelease:
+		return 0
+	case pa.prerelease == "":
+		return 1
+	case pb.prerelease == "":
+		return -1
+	}
+	return comparePrerelease(pa.prerelease, pb.prerelease)
+}
+
+type semver struct {
+	numbers    [3]uint64
+	prerelease string
+}
+
+func parseSemver(s string) (v semver, ok bool) {
+	m := semverRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return v, false
+	}
+	for i := 0; i < 3; i++ {
+		n, err := strconv.ParseUint(m[i+1], 10, 64)
+		if err != nil {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	v.prerelease = strings.TrimPrefix(m[4], "-")
+	return v, true
+}
+
+// comparePrerelease 按语义化版本规范逐段比较预发布标识，数字段按数值比较且低于非数字段
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		na, erra := strconv.ParseUint(as[i], 10, 64)
+		nb, errb := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case erra == nil && errb == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case erra == nil:
+			return -1
+		case errb == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
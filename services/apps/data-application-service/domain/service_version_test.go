@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compareSemver(t *testing.T) {
+	versions := []string{"2.0.0", "1.10.0", "v1", "1.2.0", "2.0.0-rc.1", "2.0.0-beta.11", "2.0.0-beta.2", "2.0.0-beta", "1.2.0"}
+	sort.SliceStable(versions, func(i, j int) bool { return compareSemver(versions[i], versions[j]) < 0 })
+	assert.Equal(t, []string{"v1", "1.2.0", "1.2.0", "1.10.0", "2.0.0-beta", "2.0.0-beta.2", "2.0.0-beta.11", "2.0.0-rc.1", "2.0.0"}, versions)
+
+	assert.True(t, semverRegexp.MatchString("1.0.0"))
+	assert.True(t, semverRegexp.MatchString("2.1.3-alpha.1"))
+	assert.False(t, semverRegexp.MatchString("01.0.0"))
+	assert.False(t, semverRegexp.MatchString("1.0"))
+	assert.False(t, semverRegexp.MatchString("v1.0.0"))
+}
@@ -6,6 +6,7 @@ toolchain go1.24.11
 
 require (
 	github.com/IBM/sarama v1.42.1
+	github.com/alicebob/miniredis/v2 v2.37.0
 	github.com/avast/retry-go v3.0.0+incompatible
 	github.com/blastrain/vitess-sqlparser v0.0.0-20201030050434-a139afbb1aba
 	github.com/bwmarrin/snowflake v0.3.0
@@ -22,6 +23,7 @@ require (
 	github.com/json-iterator/go v1.1.12
 	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2 v2.10.2
+	github.com/kweaver-ai/dsg/services/lib/common v0.0.0-00010101000000-000000000000
 	github.com/kweaver-ai/idrm-go-common v0.1.4-0.20260119010937-2456e402a095
 	github.com/kweaver-ai/idrm-go-frame v0.1.3
 	github.com/nsqio/go-nsq v1.1.0
@@ -160,6 +162,7 @@ require (
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
 	github.com/zeromicro/go-zero v1.4.1 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
@@ -205,3 +208,5 @@ require (
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1 // indirect
 	sigs.k8s.io/yaml v1.4.0 // indirect
 )
+
+replace github.com/kweaver-ai/dsg/services/lib/common => ../../lib/common
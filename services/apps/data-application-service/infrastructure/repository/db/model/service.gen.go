@@ -58,6 +58,13 @@ type Service struct {
 	DeveloperName      string     `gorm:"column:developer_name;type:varchar(255);not null;comment:开发商名称" json:"developer_name"`                                       // 开发商名称
 	RateLimiting       uint32     `gorm:"column:rate_limiting;type:int(10);not null;comment:调用频次 次/秒" json:"rate_limiting"`                                  // 调用频次 次/秒
 	Timeout            uint32     `gorm:"column:timeout;type:int(10);not null;comment:超时时间 秒" json:"timeout"`                                                // 超时时间 秒
+	CacheTTL           uint32     `gorm:"column:cache_ttl;type:int(10);not null;comment:结果缓存时间 秒，0 不缓存" json:"cache_ttl"`                                    // 结果缓存时间 秒，0 不缓存
+	CountCacheTTL      uint32     `gorm:"column:count_cache_ttl;type:int(10);not null;comment:总数缓存时间 秒，0 每次查询准确总数" json:"count_cache_ttl"`                   // 总数缓存时间 秒，0 每次查询准确总数
+	TransformRules     string     `gorm:"column:transform_rules;type:text;comment:请求与返回结果转换规则 JSON" json:"transform_rules"`                                 // 接口注册的请求与返回结果转换规则 JSON
+	VersionGroupID     string     `gorm:"column:version_group_id;type:varchar(36);not null;comment:版本组id" json:"version_group_id"`                                  // 版本组id，同一接口的各个版本相同，为空表示未启用多版本
+	Version            string     `gorm:"column:version;type:varchar(32);not null;comment:语义化版本号" json:"version"`                                                 // 语义化版本号
+	DeprecatedAt       *time.Time `gorm:"column:deprecated_at;type:datetime;comment:废弃时间" json:"deprecated_at"`                                                   // 废弃时间
+	SunsetAt           *time.Time `gorm:"column:sunset_at;type:datetime;comment:计划下线时间" json:"sunset_at"`                                                       // 计划下线时间
 	ServiceType        string     `gorm:"column:service_type;type:varchar(20);not null;comment:接口类型 service_generate 接口生成 service_register 接口注册" json:"service_type"` // 接口类型 service_generate 接口生成 service_register 接口注册
 	FlowID             string     `gorm:"column:flow_id;type:varchar(50);not null;comment:审核流程实例id" json:"flow_id"`                                                   // 审核流程实例id
 	FlowName           string     `gorm:"column:flow_name;type:varchar(200);not null;comment:审核流程名称" json:"flow_name"`                                                // 审核流程名称
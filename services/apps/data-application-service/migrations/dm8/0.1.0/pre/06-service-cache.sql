@@ -0,0 +1,4 @@
+SET SCHEMA data_application_service;
+
+-- 为接口服务表(service)添加结果缓存时间字段
+ALTER TABLE "service" ADD COLUMN IF NOT EXISTS "cache_ttl" INT NOT NULL DEFAULT 0;
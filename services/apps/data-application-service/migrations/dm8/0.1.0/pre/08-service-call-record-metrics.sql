@@ -0,0 +1,7 @@
+SET SCHEMA data_application_service;
+
+-- 为接口调用记录表(service_call_record)添加耗时、返回行数、响应字节数和错误码字段
+ALTER TABLE "service_call_record" ADD COLUMN IF NOT EXISTS "latency_ms" BIGINT NULL DEFAULT NULL;
+ALTER TABLE "service_call_record" ADD COLUMN IF NOT EXISTS "rows_returned" BIGINT NULL DEFAULT NULL;
+ALTER TABLE "service_call_record" ADD COLUMN IF NOT EXISTS "response_bytes" BIGINT NULL DEFAULT NULL;
+ALTER TABLE "service_call_record" ADD COLUMN IF NOT EXISTS "error_code" VARCHAR(255 char) NULL DEFAULT NULL;
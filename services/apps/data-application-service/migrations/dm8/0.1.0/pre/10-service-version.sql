@@ -0,0 +1,8 @@
+SET SCHEMA data_application_service;
+
+-- 为接口服务表(service)添加多版本并行发布所需的版本组、版本号、废弃时间和计划下线时间字段
+ALTER TABLE "service" ADD COLUMN IF NOT EXISTS "version_group_id" VARCHAR(36 char) NOT NULL DEFAULT '';
+ALTER TABLE "service" ADD COLUMN IF NOT EXISTS "version" VARCHAR(32 char) NOT NULL DEFAULT '';
+ALTER TABLE "service" ADD COLUMN IF NOT EXISTS "deprecated_at" datetime(0) DEFAULT NULL;
+ALTER TABLE "service" ADD COLUMN IF NOT EXISTS "sunset_at" datetime(0) DEFAULT NULL;
+CREATE INDEX IF NOT EXISTS service_version_group_id ON service("version_group_id");
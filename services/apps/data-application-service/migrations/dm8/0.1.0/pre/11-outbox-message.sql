@@ -0,0 +1,17 @@
+SET SCHEMA data_application_service;
+
+CREATE TABLE IF NOT EXISTS "outbox_message" (
+    "id" BIGINT NOT NULL,
+    "topic" VARCHAR(255 char) NOT NULL,
+    "msg_key" VARCHAR(255 char) NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" TINYINT NOT NULL DEFAULT 0,
+    "attempts" INT NOT NULL DEFAULT 0,
+    "next_retry_at" DATETIME(3) NOT NULL,
+    "last_error" VARCHAR(1024 char) NULL DEFAULT NULL,
+    "created_at" DATETIME(3) NOT NULL,
+    "sent_at" DATETIME(3) NULL DEFAULT NULL,
+    CLUSTER PRIMARY KEY ("id")
+    ) ;
+CREATE INDEX IF NOT EXISTS outbox_message_status_next_retry_at_IDX ON outbox_message("status", "next_retry_at");
+CREATE INDEX IF NOT EXISTS outbox_message_created_at_IDX ON outbox_message("created_at");
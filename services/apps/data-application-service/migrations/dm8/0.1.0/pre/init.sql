@@ -96,6 +96,13 @@ CREATE TABLE IF NOT EXISTS "service"
     "developer_name"       VARCHAR(255 char)        NOT NULL DEFAULT '',
     "rate_limiting"        INT     NOT NULL DEFAULT 0,
     "timeout"              INT     NOT NULL DEFAULT 0,
+    "cache_ttl"            INT     NOT NULL DEFAULT 0,
+    "count_cache_ttl"      INT     NOT NULL DEFAULT 0,
+    "transform_rules"      text                DEFAULT NULL,
+    "version_group_id"     VARCHAR(36 char)         NOT NULL DEFAULT '',
+    "version"              VARCHAR(32 char)         NOT NULL DEFAULT '',
+    "deprecated_at"        datetime(0) DEFAULT NULL,
+    "sunset_at"            datetime(0) DEFAULT NULL,
     "service_type"         VARCHAR(20 char)         NOT NULL DEFAULT '',
     "flow_id"              VARCHAR(50 char)         NOT NULL DEFAULT '',
     "flow_name"            VARCHAR(200 char)        NOT NULL DEFAULT '',
@@ -135,6 +142,8 @@ CREATE INDEX IF NOT EXISTS service_department_id ON service("department_id");
 
 CREATE INDEX IF NOT EXISTS service_subject_domain_id ON service("subject_domain_id");
 
+CREATE INDEX IF NOT EXISTS service_version_group_id ON service("version_group_id");
+
 CREATE INDEX IF NOT EXISTS service_create_time ON service("create_time");
 
 CREATE INDEX IF NOT EXISTS service_audit_type ON service("audit_type");
@@ -358,6 +367,10 @@ CREATE TABLE IF NOT EXISTS "service_call_record" (
     "error_message" TEXT NULL,
     "call_other_message" TEXT NULL,
     "record_time" DATETIME NULL DEFAULT NULL,
+    "latency_ms" BIGINT NULL DEFAULT NULL,
+    "rows_returned" BIGINT NULL DEFAULT NULL,
+    "response_bytes" BIGINT NULL DEFAULT NULL,
+    "error_code" VARCHAR(255 char) NULL DEFAULT NULL,
     CLUSTER PRIMARY KEY ("id")
     ) ;
 CREATE INDEX IF NOT EXISTS service_call_record_service_id_IDX ON service_call_record("service_id");
@@ -377,4 +390,20 @@ CREATE TABLE IF NOT EXISTS gateway_collection_log (
     "invoke_num" INT,
     "invoke_average_call_duration" INT,
     CLUSTER PRIMARY KEY ("id")
-    ) ;
\ No newline at end of file
+    ) ;
+
+CREATE TABLE IF NOT EXISTS "outbox_message" (
+    "id" BIGINT NOT NULL,
+    "topic" VARCHAR(255 char) NOT NULL,
+    "msg_key" VARCHAR(255 char) NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" TINYINT NOT NULL DEFAULT 0,
+    "attempts" INT NOT NULL DEFAULT 0,
+    "next_retry_at" DATETIME(3) NOT NULL,
+    "last_error" VARCHAR(1024 char) NULL DEFAULT NULL,
+    "created_at" DATETIME(3) NOT NULL,
+    "sent_at" DATETIME(3) NULL DEFAULT NULL,
+    CLUSTER PRIMARY KEY ("id")
+    ) ;
+CREATE INDEX IF NOT EXISTS outbox_message_status_next_retry_at_IDX ON outbox_message("status", "next_retry_at");
+CREATE INDEX IF NOT EXISTS outbox_message_created_at_IDX ON outbox_message("created_at");
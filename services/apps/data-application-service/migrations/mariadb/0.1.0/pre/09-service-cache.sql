@@ -0,0 +1,4 @@
+use data_application_service;
+
+-- 为接口服务表(service)添加结果缓存时间字段
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `cache_ttl` int(10) NOT NULL DEFAULT 0 COMMENT '结果缓存时间 秒，0 不缓存' AFTER `timeout`;
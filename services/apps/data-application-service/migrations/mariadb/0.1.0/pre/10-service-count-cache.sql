@@ -0,0 +1,4 @@
+use data_application_service;
+
+-- 为接口服务表(service)添加总数缓存时间字段
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `count_cache_ttl` int(10) NOT NULL DEFAULT 0 COMMENT '总数缓存时间 秒，0 每次查询准确总数' AFTER `cache_ttl`;
@@ -0,0 +1,7 @@
+use data_application_service;
+
+-- 为接口调用记录表(service_call_record)添加耗时、返回行数、响应字节数和错误码字段
+ALTER TABLE `service_call_record` ADD COLUMN IF NOT EXISTS `latency_ms` BIGINT(20) NULL DEFAULT NULL COMMENT '调用耗时 毫秒' AFTER `record_time`;
+ALTER TABLE `service_call_record` ADD COLUMN IF NOT EXISTS `rows_returned` BIGINT(20) NULL DEFAULT NULL COMMENT '返回行数' AFTER `latency_ms`;
+ALTER TABLE `service_call_record` ADD COLUMN IF NOT EXISTS `response_bytes` BIGINT(20) NULL DEFAULT NULL COMMENT '响应字节数' AFTER `rows_returned`;
+ALTER TABLE `service_call_record` ADD COLUMN IF NOT EXISTS `error_code` VARCHAR(255) NULL DEFAULT NULL COMMENT '错误码' AFTER `response_bytes`;
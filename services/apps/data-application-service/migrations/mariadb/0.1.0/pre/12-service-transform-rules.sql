@@ -0,0 +1,4 @@
+use data_application_service;
+
+-- 为接口服务表(service)添加接口注册的请求与返回结果转换规则字段
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `transform_rules` text DEFAULT NULL COMMENT '请求与返回结果转换规则 JSON' AFTER `count_cache_ttl`;
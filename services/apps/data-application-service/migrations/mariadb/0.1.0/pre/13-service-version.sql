@@ -0,0 +1,8 @@
+use data_application_service;
+
+-- 为接口服务表(service)添加多版本并行发布所需的版本组、版本号、废弃时间和计划下线时间字段
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `version_group_id` varchar(36) NOT NULL DEFAULT '' COMMENT '版本组id，同一接口的各个版本相同，为空表示未启用多版本' AFTER `transform_rules`;
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `version` varchar(32) NOT NULL DEFAULT '' COMMENT '语义化版本号' AFTER `version_group_id`;
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `deprecated_at` datetime DEFAULT NULL COMMENT '废弃时间' AFTER `version`;
+ALTER TABLE `service` ADD COLUMN IF NOT EXISTS `sunset_at` datetime DEFAULT NULL COMMENT '计划下线时间' AFTER `deprecated_at`;
+CREATE INDEX IF NOT EXISTS `version_group_id` ON `service` (`version_group_id`);
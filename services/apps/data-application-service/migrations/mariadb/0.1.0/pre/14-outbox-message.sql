@@ -0,0 +1,17 @@
+USE data_application_service;
+
+CREATE TABLE IF NOT EXISTS `outbox_message` (
+    `id` BIGINT(20) NOT NULL COMMENT '唯一id，雪花算法，同一 key 的消息按 id 顺序发送',
+    `topic` VARCHAR(255) NOT NULL COMMENT '消息主题',
+    `msg_key` VARCHAR(255) NOT NULL COMMENT '保序 key，通常是实体id',
+    `payload` LONGTEXT NOT NULL COMMENT '消息内容',
+    `status` TINYINT(4) NOT NULL DEFAULT 0 COMMENT '状态 0 待发送 1 已发送 2 放弃发送',
+    `attempts` INT(11) NOT NULL DEFAULT 0 COMMENT '发送次数',
+    `next_retry_at` DATETIME(3) NOT NULL COMMENT '下次发送时间',
+    `last_error` VARCHAR(1024) NULL DEFAULT NULL COMMENT '最近一次发送失败的原因',
+    `created_at` DATETIME(3) NOT NULL COMMENT '创建时间',
+    `sent_at` DATETIME(3) NULL DEFAULT NULL COMMENT '发送时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_status_next_retry_at` (`status`, `next_retry_at`),
+    KEY `idx_created_at` (`created_at`)
+) COMMENT='事务发件箱，与业务数据在同一事务中写入的待发送消息';
@@ -101,6 +101,13 @@ CREATE TABLE IF NOT EXISTS `service`
     `source_type`          int             NOT NULL DEFAULT 0 COMMENT '来源类型（0原生，1迁移）',
     `rate_limiting`        int(10)    NOT NULL DEFAULT 0 COMMENT '调用频次 次/秒',
     `timeout`              int(10)    NOT NULL DEFAULT 0 COMMENT '超时时间 秒',
+    `cache_ttl`            int(10)    NOT NULL DEFAULT 0 COMMENT '结果缓存时间 秒，0 不缓存',
+    `count_cache_ttl`      int(10)    NOT NULL DEFAULT 0 COMMENT '总数缓存时间 秒，0 每次查询准确总数',
+    `transform_rules`      text                DEFAULT NULL COMMENT '请求与返回结果转换规则 JSON',
+    `version_group_id`     varchar(36)         NOT NULL DEFAULT '' COMMENT '版本组id，同一接口的各个版本相同，为空表示未启用多版本',
+    `version`              varchar(32)         NOT NULL DEFAULT '' COMMENT '语义化版本号',
+    `deprecated_at`        datetime                     DEFAULT NULL COMMENT '废弃时间',
+    `sunset_at`            datetime                     DEFAULT NULL COMMENT '计划下线时间',
     `service_type`         varchar(20)         NOT NULL DEFAULT '' COMMENT '接口类型 service_generate 接口生成 service_register 接口注册',
     `flow_id`              varchar(50)         NOT NULL DEFAULT '' COMMENT '审核流程实例id',
     `flow_name`            varchar(200)        NOT NULL DEFAULT '' COMMENT '审核流程名称',
@@ -126,6 +133,7 @@ CREATE TABLE IF NOT EXISTS `service`
     KEY `audit_type` (`audit_type`),
     KEY `audit_status` (`audit_status`),
     KEY `apply_id` (`apply_id`),
+    KEY `version_group_id` (`version_group_id`),
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4  COLLATE utf8mb4_unicode_ci  COMMENT ='接口表';
 
@@ -331,6 +339,10 @@ CREATE TABLE IF NOT EXISTS `service_call_record` (
     `error_message` TEXT NULL COMMENT '报错信息',
     `call_other_message` TEXT NULL COMMENT '其他调用信息（预留）',
     `record_time` DATETIME NULL DEFAULT NULL COMMENT '日志记录时间',
+    `latency_ms` BIGINT(20) NULL DEFAULT NULL COMMENT '调用耗时 毫秒',
+    `rows_returned` BIGINT(20) NULL DEFAULT NULL COMMENT '返回行数',
+    `response_bytes` BIGINT(20) NULL DEFAULT NULL COMMENT '响应字节数',
+    `error_code` VARCHAR(255) NULL DEFAULT NULL COMMENT '错误码',
     KEY `idx_service_id` (`service_id`),
     PRIMARY KEY (`id`)
 ) COMMENT='接口调用记录表';
@@ -360,4 +372,20 @@ CREATE TABLE if not exists `service_authed_users` (
     PRIMARY KEY (`id`),
     KEY `service_authed_users_user_id_IDX` (`user_id`,`service_id`) USING BTREE,
     KEY `service_authed_users_service_id_IDX` (`service_id`,`user_id`) USING BTREE
-    ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='接口服务授权用户关系表';
\ No newline at end of file
+    ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='接口服务授权用户关系表';
+
+CREATE TABLE IF NOT EXISTS `outbox_message` (
+    `id` BIGINT(20) NOT NULL COMMENT '唯一id，雪花算法，同一 key 的消息按 id 顺序发送',
+    `topic` VARCHAR(255) NOT NULL COMMENT '消息主题',
+    `msg_key` VARCHAR(255) NOT NULL COMMENT '保序 key，通常是实体id',
+    `payload` LONGTEXT NOT NULL COMMENT '消息内容',
+    `status` TINYINT(4) NOT NULL DEFAULT 0 COMMENT '状态 0 待发送 1 已发送 2 放弃发送',
+    `attempts` INT(11) NOT NULL DEFAULT 0 COMMENT '发送次数',
+    `next_retry_at` DATETIME(3) NOT NULL COMMENT '下次发送时间',
+    `last_error` VARCHAR(1024) NULL DEFAULT NULL COMMENT '最近一次发送失败的原因',
+    `created_at` DATETIME(3) NOT NULL COMMENT '创建时间',
+    `sent_at` DATETIME(3) NULL DEFAULT NULL COMMENT '发送时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_status_next_retry_at` (`status`, `next_retry_at`),
+    KEY `idx_created_at` (`created_at`)
+) COMMENT='事务发件箱，与业务数据在同一事务中写入的待发送消息';
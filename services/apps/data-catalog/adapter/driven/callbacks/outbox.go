@@ -0,0 +1,28 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-catalog/adapter/driver/mq"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/mq/kafkax"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLease 发件箱发送任务的租约名称，多副本部署时只有一个实例发送
+const outboxRelayLease = "data-catalog"
+
+// NewOutboxPublishers 发件箱中各主题消息的发送方式
+func NewOutboxPublishers(sender kafkax.Producer) outbox.Publishers {
+	return outbox.Publishers{
+		// 实体变更消息
+		mq.TOPIC_PUB_ENTITY_CHANGE: func(ctx context.Context, msg *outbox.Message) error {
+			return sender.Send(mq.TOPIC_PUB_ENTITY_CHANGE, []byte(msg.Payload))
+		},
+	}
+}
+
+// NewOutboxRelayServer 发送发件箱消息的后台服务
+func NewOutboxRelayServer(o outbox.Outbox, db *gorm.DB) *outbox.RelayServer {
+	return outbox.NewRelayServer(o, db, outboxRelayLease)
+}
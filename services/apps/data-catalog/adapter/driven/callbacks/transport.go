@@ -2,7 +2,10 @@ package callbacks
 
 import (
 	"github.com/kweaver-ai/dsg/services/apps/data-catalog/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/database_callback/callback"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -23,6 +26,10 @@ func NewTransport(
 
 // Register 注册
 func (t *Transports) Register() {
+	// 实体变更消息在触发回调的事务中写入发件箱
+	if err := outbox.RegisterTxCallbacks(t.db); err != nil {
+		log.Error("Transports Register outbox callbacks", zap.Error(err))
+	}
 	callback.Init(t.db)
 	//业务架构图谱
 	callback.RegisterByTransport(t.EntityChangeTransport, callback.BusinessRelationGraph, new(model.TDataCatalog)) //数据据目录
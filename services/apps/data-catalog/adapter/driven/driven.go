@@ -26,6 +26,7 @@ import (
 	task_center "github.com/kweaver-ai/dsg/services/apps/data-catalog/adapter/driven/task_center/impl"
 	"github.com/kweaver-ai/dsg/services/apps/data-catalog/adapter/driven/virtualization_engine"
 	workflow "github.com/kweaver-ai/dsg/services/apps/data-catalog/adapter/driven/workflow"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	gocephclient "github.com/kweaver-ai/idrm-go-common/go-ceph-client"
 	auth_service_v1 "github.com/kweaver-ai/idrm-go-common/rest/auth-service/v1"
 	basic_bigdata_service "github.com/kweaver-ai/idrm-go-common/rest/basic_bigdata_service/impl"
@@ -79,6 +80,10 @@ var databaseCallback = wire.NewSet(
 	callbacks.NewTransport,
 	callbacks.NewEntityChangeTransport,
 	callbacks.NewDataPushCallback,
+	//发件箱
+	outbox.NewOutbox,
+	callbacks.NewOutboxPublishers,
+	callbacks.NewOutboxRelayServer,
 )
 
 // NewLocalDataViewRepo 提供 localDataView.Repo 接口的实现
@@ -5,6 +5,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-catalog/adapter/driven/callbacks"
 	"github.com/kweaver-ai/dsg/services/apps/data-catalog/adapter/driver/mq/kafka"
 	"github.com/kweaver-ai/dsg/services/apps/data-catalog/domain/statistics/impl"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/workflow"
 	af_go_frame "github.com/kweaver-ai/idrm-go-frame"
 	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest"
@@ -19,10 +20,11 @@ type AppRunner struct {
 	AssessmentController *assessmentv1.Controller // 新增：用于定时任务
 }
 
-func newApp(hs *rest.Server) *af_go_frame.App {
+// newApp outboxRelayServer 发送实体变更等写入发件箱的消息
+func newApp(hs *rest.Server, outboxRelayServer *outbox.RelayServer) *af_go_frame.App {
 	return af_go_frame.New(
 		af_go_frame.Name(Name),
-		af_go_frame.Server(hs),
+		af_go_frame.Server(hs, outboxRelayServer),
 	)
 }
 
@@ -140,6 +140,7 @@ import (
 	impl33 "github.com/kweaver-ai/dsg/services/apps/data-catalog/infrastructure/repository/db/gorm/tree_node/impl"
 	impl36 "github.com/kweaver-ai/dsg/services/apps/data-catalog/infrastructure/repository/db/gorm/user_data_catalog_rel/impl"
 	impl38 "github.com/kweaver-ai/dsg/services/apps/data-catalog/infrastructure/repository/db/gorm/user_data_catalog_stats_info/impl"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	gocephclient "github.com/kweaver-ai/idrm-go-common/go-ceph-client"
 	v1_8 "github.com/kweaver-ai/idrm-go-common/middleware/v1"
 	v1_5 "github.com/kweaver-ai/idrm-go-common/rest/auth-service/v1"
@@ -416,8 +417,11 @@ func InitApp(config *settings.Config) (*AppRunner, func(), error) {
 		ResFeedbackController:                controller24,
 	}
 	server := controller.NewHttpServer(router)
-	app := newApp(server)
-	entityChangeTransport := callbacks.NewEntityChangeTransport(producer)
+	publishers := callbacks.NewOutboxPublishers(producer)
+	outboxOutbox := outbox.NewOutbox(gormDB, publishers)
+	relayServer := callbacks.NewOutboxRelayServer(outboxOutbox, gormDB)
+	app := newApp(server, relayServer)
+	entityChangeTransport := callbacks.NewEntityChangeTransport(outboxOutbox)
 	transports := callbacks.NewTransport(gormDB, entityChangeTransport)
 	wfStarter := workflow.NewWFStarter(workflowInterface, dataCatalogDomain, data_catalogDataCatalogDomain)
 	entityChangeHandler := entity_change.NewEntityChangeHandler(implDataResourceDomain)
@@ -18,6 +18,7 @@ require (
 	github.com/jinzhu/copier v0.4.0
 	github.com/json-iterator/go v1.1.12
 	github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2 v2.10.2
+	github.com/kweaver-ai/dsg/services/lib/common v0.0.0-00010101000000-000000000000
 	github.com/kweaver-ai/idrm-go-common v0.1.4-0.20260119010937-2456e402a095
 	github.com/kweaver-ai/idrm-go-frame v0.1.3
 	github.com/mitchellh/mapstructure v1.5.0
@@ -215,3 +216,5 @@ require (
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1 // indirect
 )
+
+replace github.com/kweaver-ai/dsg/services/lib/common => ../../lib/common
@@ -1637,4 +1637,28 @@ CREATE TABLE IF NOT EXISTS "category_node_ext" (
 
 CREATE INDEX  IF NOT EXISTS "ux_category_node_ext_node_id" on category_node_ext("category_node_id");
 CREATE INDEX  IF NOT EXISTS "ux_category_node_ext_sort"  on category_node_ext("category_id","parent_id","sort_weight","deleted_at");
-CREATE INDEX  IF NOT EXISTS "ux_category_node_ext_name"  on category_node_ext("category_id","parent_id","name","deleted_at");
\ No newline at end of file
+CREATE INDEX  IF NOT EXISTS "ux_category_node_ext_name"  on category_node_ext("category_id","parent_id","name","deleted_at");
+
+CREATE TABLE IF NOT EXISTS "outbox_message" (
+    "id" BIGINT NOT NULL,
+    "topic" VARCHAR(255 char) NOT NULL,
+    "msg_key" VARCHAR(255 char) NOT NULL,
+    "payload" text NOT NULL,
+    "status" TINYINT NOT NULL DEFAULT 0,
+    "attempts" int NOT NULL DEFAULT 0,
+    "next_retry_at" datetime(3) NOT NULL,
+    "last_error" VARCHAR(1024 char) DEFAULT NULL,
+    "created_at" datetime(3) NOT NULL,
+    "sent_at" datetime(3) DEFAULT NULL,
+    CLUSTER PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS outbox_message_idx_status_next_retry_at ON "outbox_message"("status", "next_retry_at");
+CREATE INDEX IF NOT EXISTS outbox_message_idx_created_at ON "outbox_message"("created_at");
+
+CREATE TABLE IF NOT EXISTS "outbox_relay_lease" (
+    "name" VARCHAR(255 char) NOT NULL,
+    "holder" VARCHAR(255 char) NOT NULL,
+    "expires_at" datetime(3) NOT NULL,
+    CLUSTER PRIMARY KEY ("name")
+    );
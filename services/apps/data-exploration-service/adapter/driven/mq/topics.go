@@ -8,5 +8,6 @@ const (
 	BusinessEntityChangeTopic          = "af.business-grooming.entity_change"
 	DeleteExploreTaskTopic             = "af.data-exploration-service.delete_explore_task"
 	//VirtualEngineExploreDataTopic      = "af.virtual-engine.explore_data_result"
-	QualityReportTopic = "af.task-center.v1.quality-reports"
+	QualityReportTopic  = "af.task-center.v1.quality-reports"
+	QualityAnomalyTopic = "af.data-exploration-service.quality_anomaly"
 )
@@ -40,6 +40,9 @@ type ReportFormat struct {
 	CreatedAt       *int64                `json:"created_at"`    // 创建时间
 	FinishedAt      *int64                `json:"finished_at"`   // 完成时间
 	TotalScore      *float64              `json:"total_score"`   // 总分，缺省为NULL
+	Incremental     bool                  `json:"incremental"`   // 是否增量探查，增量探查的结果已合并到上次报告的结果中
+	BaseCode        *string               `json:"base_code"`     // 增量探查合并的上次报告编号
+	Watermark       *string               `json:"watermark"`     // 本次探查的业务时间水位
 	DimensionScores
 }
 type ExploreDetails struct {
@@ -65,6 +68,7 @@ type RuleResult struct {
 	Result          *string `json:"result"`           // 规则输出结果 []any规则输出列级结果
 	InspectedCount  int64   `json:"inspected_count"`  // 检测数据量
 	IssueCount      int64   `json:"issue_count"`      // 问题数据量
+	Approximate     bool    `json:"approximate"`      // 检测结果是否为近似值，增量探查合并的重复值检查结果为基数估计值，不参与评分
 	DimensionScores
 }
 
@@ -148,8 +152,9 @@ type FieldInfo struct {
 }
 
 type CountData struct {
-	Count1 interface{} `json:"count1"`
-	Count2 float64     `json:"count2"`
+	Count1      interface{} `json:"count1"`
+	Count2      float64     `json:"count2"`
+	Approximate bool        `json:"approximate"` // count1 是否为近似值
 }
 
 type CountInfo struct {
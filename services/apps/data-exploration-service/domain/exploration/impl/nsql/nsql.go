@@ -6,6 +6,9 @@ const RT = ` ( SELECT * FROM ${ve_catalog_id}.${schema_name}."${name}" ORDER BY
 
 const T = ` ${ve_catalog_id}.${schema_name}."${name}" `
 
+// 增量探查，只查询业务时间在水位范围内的数据
+const IT = ` ( SELECT * FROM ${ve_catalog_id}.${schema_name}."${name}" WHERE ${incremental_filter} ) AS T `
+
 // count table 查询表总数据量
 const CountTable = `SELECT  COUNT(1) AS result FROM ${T} `
 
@@ -58,6 +61,17 @@ const Null = `COUNT(CASE WHEN ${null_config} THEN 1 ELSE NULL END) AS "${rule_id
 // 字段唯一性统计
 const Unique = `COUNT(CASE WHEN "${column_name}" IS NOT NULL then "${column_name}" end) - COUNT(DISTINCT CASE WHEN "${column_name}" IS NOT NULL then "${column_name}" end) AS "${rule_id}"`
 
+// 增量探查的字段唯一性统计，使用可合并的基数估计草图计算去重数量，${base_sketch}为上次报告的草图
+const UniqueSketch = `SELECT SUM(non_null) AS "${rule_id}_non_null", SUM(total) AS count2, cardinality(merge(sketch)) AS "${rule_id}_distinct", to_base64(cast(merge(sketch) AS varbinary)) AS "${rule_id}_sketch" FROM (SELECT COUNT("${column_name}") AS non_null, COUNT(1) AS total, approx_set(cast("${column_name}" AS varchar)) AS sketch FROM ${T}${base_sketch}) hll`
+
+const BaseSketch = ` UNION ALL SELECT 0, 0, cast(from_base64('${sketch}') AS HyperLogLog)`
+
+// 增量探查的业务时间水位，${watermark_filter}为上次水位之后的过滤条件
+const Watermark = `SELECT CAST(MAX("${column_name}") AS varchar) AS watermark FROM ${T}${watermark_filter}`
+
+// 增量探查任务探查全表时的字段唯一性统计，去重数量精确计算，同时生成草图供之后的增量探查合并
+const UniqueSketchFull = `SELECT COUNT("${column_name}") AS "${rule_id}_non_null", COUNT(1) AS count2, COUNT(DISTINCT "${column_name}") AS "${rule_id}_distinct", to_base64(cast(approx_set(cast("${column_name}" AS varchar)) AS varbinary)) AS "${rule_id}_sketch" FROM ${T}`
+
 // 字段码值检查
 const Dict = `COUNT(CASE WHEN "${column_name}" in (${dict_config}) THEN 1 ELSE NULL END) AS "${rule_id}"`
 
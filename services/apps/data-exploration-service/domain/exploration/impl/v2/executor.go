@@ -16,7 +16,10 @@ func getSql(sql string, table string) string {
 }
 
 func (e *ExplorationDomainImplV2) generateSql(sql string, tableInfo exploration.MetaDataTableInfo, totalSample int32, field *exploration.ExploreField) (string, error) {
-	if totalSample > 0 {
+	if tableInfo.Window != nil {
+		sql = getSql(sql, nsql.IT)
+		sql = strings.Replace(sql, "${incremental_filter}", incrementalFilterSql(tableInfo.Window), -1)
+	} else if totalSample > 0 {
 		sql = getSql(sql, nsql.RT)
 	} else {
 		sql = getSql(sql, nsql.T)
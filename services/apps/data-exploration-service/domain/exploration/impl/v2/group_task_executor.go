@@ -371,8 +371,12 @@ func (g *groupManager) start() error {
 								)
 								if *g.group.ExploreType == ExploreType_Data {
 									var report exploration.ReportFormat
-									// 计算报告得分
-									if report, err = g.e.getReport(nil, ctx, g.group); err == nil {
+									// 增量探查先合并上次报告的结果
+									if err = g.e.mergeIncrementalItems(ctx, g.group); err == nil {
+										// 计算报告得分
+										report, err = g.e.getReport(nil, ctx, g.group)
+									}
+									if err == nil {
 										g.group.TotalCompleteness = report.CompletenessScore
 										g.group.TotalStandardization = report.StandardizationScore
 										g.group.TotalUniqueness = report.UniquenessScore
@@ -449,6 +453,10 @@ func (g *groupManager) start() error {
 										log.Errorf("report code %s g.e.mq_producter.SyncProduce failed: %v", *g.group.Code, errorcode.Detail(errorcode.MqProduceError, err))
 										panic(err)
 									}
+									if *g.group.ExploreType == ExploreType_Data {
+										// 检测质量评分异常并告警
+										g.e.alertQualityAnomaly(ctx, g.group)
+									}
 								}
 							}()
 						}
@@ -0,0 +1,361 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	mdl_uniquery "github.com/kweaver-ai/dsg/services/apps/data-exploration-service/adapter/driven/mdl-uniquery"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/domain/exploration"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/domain/exploration/impl/nsql"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 增量探查结果的合并方式
+const (
+	mergeKindNone     = iota // 无法合并，探查全表
+	mergeKindCount           // count1、count2 累加
+	mergeKindSum             // 结果累加
+	mergeKindMax             // 取最大值
+	mergeKindMin             // 取最小值
+	mergeKindDistinct        // 合并去重草图
+)
+
+// isIncrementalTask 是否增量探查任务，采样探查的结果无法合并，只有全量探查支持增量
+func isIncrementalTask(exploreReq *exploration.DataExploreReq) bool {
+	return exploreReq.Incremental && exploreReq.TotalSample == 0 && exploreReq.TimestampFieldId != ""
+}
+
+// incrementalMergeKind 探查规则增量结果的合并方式
+func incrementalMergeKind(ruleName, dimensionType string) int {
+	switch ruleName {
+	case constant.Max:
+		return mergeKindMax
+	case constant.Min:
+		return mergeKindMin
+	case constant.TrueCount, constant.FalseCount:
+		return mergeKindSum
+	case constant.Unique:
+		return mergeKindDistinct
+	case constant.Quantile, constant.Avg, constant.StddevPop, constant.Group,
+		constant.Day, constant.Month, constant.Year, constant.RowUnique:
+		return mergeKindNone
+	}
+	switch dimensionType {
+	case constant.DimensionTypeRepeat.String:
+		return mergeKindDistinct
+	case constant.DimensionTypeRowRepeat.String:
+		return mergeKindNone
+	}
+	// 空值、码值、格式检查及自定义规则的结果都是 count1、count2
+	return mergeKindCount
+}
+
+// ruleTableInfo 增量探查时结果无法合并的规则仍然探查全表
+func ruleTableInfo(tableInfo exploration.MetaDataTableInfo, ruleName, dimensionType string) exploration.MetaDataTableInfo {
+	if incrementalMergeKind(ruleName, dimensionType) == mergeKindNone {
+		return fullTableInfo(tableInfo)
+	}
+	return tableInfo
+}
+
+func fullTableInfo(tableInfo exploration.MetaDataTableInfo) exploration.MetaDataTableInfo {
+	tableInfo.Window = nil
+	return tableInfo
+}
+
+// incrementalFilterSql 增量探查范围的过滤条件
+func incrementalFilterSql(window *exploration.IncrementalWindow) string {
+	return fmt.Sprintf(`"%s" > %s AND "%s" <= %s`, window.Column, watermarkLiteral(window.DataType, window.From),
+		window.Column, watermarkLiteral(window.DataType, window.To))
+}
+
+// watermarkLiteral 业务时间水位的 sql 字面量，字符型的业务时间按字符串比较。水位取自表中的数据，需要转义单引号
+func watermarkLiteral(dataType, value string) string {
+	value = strings.ReplaceAll(value, "'", "''")
+	if constant.DataType2string(dataType) == constant.DataTypeChar.String {
+		return fmt.Sprintf(`'%s'`, value)
+	}
+	return fmt.Sprintf(`TIMESTAMP '%s'`, value)
+}
+
+// sameIncrementalRules 两次探查的表和规则是否一致，只有一致时才能合并结果
+func sameIncrementalRules(a, b *exploration.DataExploreReq) bool {
+	fingerprint := func(req *exploration.DataExploreReq) []byte {
+		buf, _ := json.Marshal([]any{req.VeCatalog, req.Schema, req.Table, req.TimestampFieldId, req.FieldInfo,
+			req.FieldExplore, req.RowExplore, req.ViewExplore})
+		return buf
+	}
+	return bytes.Equal(fingerprint(a), fingerprint(b))
+}
+
+// reportItemKey 报告中探查项目的唯一标识
+func reportItemKey(item *model.ReportItem) string {
+	return util.PtrToValue(item.RuleId) + "_" + util.PtrToValue(item.Column)
+}
+
+// incrementalWindow 设置本次探查的业务时间水位，存在可以合并的上次报告时返回增量探查范围，否则返回 nil 探查全表
+func (e *ExplorationDomainImplV2) incrementalWindow(ctx context.Context, report *model.Report, exploreReq *exploration.DataExploreReq, tableInfo exploration.MetaDataTableInfo) (*exploration.IncrementalWindow, error) {
+	if !isIncrementalTask(exploreReq) {
+		return nil, nil
+	}
+	column, ok := tableInfo.Columns[exploreReq.TimestampFieldId]
+	if !ok {
+		return nil, errorcode.Detail(errorcode.PublicInvalidParameter, "增量探查的业务时间字段不存在")
+	}
+	base, window, err := e.baseWindow(ctx, report, exploreReq, column)
+	if err != nil {
+		return nil, err
+	}
+	// 水位取本次探查范围内业务时间的最大值，不使用报告的创建时间，避免业务时间晚于探查时间的数据被跳过
+	watermark, err := e.queryWatermark(ctx, report, tableInfo, column, window)
+	if err != nil {
+		return nil, err
+	}
+	if window == nil {
+		report.Watermark = watermark
+		return nil, nil
+	}
+	// 上次探查之后没有新数据时水位不变，本次探查范围为空
+	if watermark == nil {
+		watermark = &window.From
+	}
+	window.To = *watermark
+	report.Watermark = watermark
+	report.Incremental = constant.YES
+	report.BaseCode = base.Code
+	return window, nil
+}
+
+// baseWindow 获取可以合并的上次报告，返回从上次水位开始的增量探查范围，没有可以合并的报告时返回 nil
+func (e *ExplorationDomainImplV2) baseWindow(ctx context.Context, report *model.Report, exploreReq *exploration.DataExploreReq, column exploration.ColumnInfo) (*model.Report, *exploration.IncrementalWindow, error) {
+	base, err := e.repo.GetRecentSuccessReportByParams(nil, ctx, util.ValueToPtr(strconv.FormatUint(report.TaskID, 10)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if base == nil || base.Watermark == nil || util.PtrToValue(base.ExploreType) != ExploreType_Data {
+		return nil, nil, nil
+	}
+	var baseReq exploration.DataExploreReq
+	if err = json.Unmarshal([]byte(util.PtrToValue(base.QueryParams)), &baseReq); err != nil || !sameIncrementalRules(&baseReq, exploreReq) {
+		log.WithContext(ctx).Infof("explore task %d rules changed since report %s, explore full table", report.TaskID, *base.Code)
+		return nil, nil, nil
+	}
+
+	baseItems, err := e.item_repo.GetByCodeV2(nil, ctx, *base.Code)
+	if err != nil {
+		return nil, nil, errorcode.Detail(errorcode.PublicDatabaseError, err)
+	}
+	sketches := make(map[string]string)
+	for _, item := range baseItems {
+		if incrementalMergeKind(util.PtrToValue(item.Project), util.PtrToValue(item.DimensionType)) != mergeKindDistinct {
+			continue
+		}
+		row := firstResultRow(item.Result)
+		sketch, _ := row["sketch"].(string)
+		if sketch == "" {
+			// 上次报告缺少去重草图时无法合并重复值检查，探查全表
+			return nil, nil, nil
+		}
+		sketches[reportItemKey(item)] = sketch
+	}
+	return base, &exploration.IncrementalWindow{
+		Column:       column.Name,
+		DataType:     column.Type,
+		From:         *base.Watermark,
+		BaseSketches: sketches,
+	}, nil
+}
+
+// queryWatermark 查询本次探查范围内业务时间的最大值，增量探查时只查询上次水位之后的数据，没有数据时返回 nil
+func (e *ExplorationDomainImplV2) queryWatermark(ctx context.Context, report *model.Report, tableInfo exploration.MetaDataTableInfo, column exploration.ColumnInfo, window *exploration.IncrementalWindow) (*string, error) {
+	var mdlID string
+	if err := e.data.DB.WithContext(ctx).Table("af_main.form_view").Select("mdl_id").Where("id = ?", util.PtrToValue(report.TableID)).Take(&mdlID).Error; err != nil {
+		return nil, errorcode.Detail(errorcode.PublicDatabaseError, err)
+	}
+	var filter string
+	if window != nil {
+		filter = fmt.Sprintf(` WHERE "%s" > %s`, column.Name, watermarkLiteral(column.Type, window.From))
+	}
+	sql := strings.Replace(nsql.Watermark, "${column_name}", column.Name, -1)
+	sql = strings.Replace(sql, "${watermark_filter}", filter, -1)
+	sql, err := e.generateSql(sql, fullTableInfo(tableInfo), 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := e.mdl_uniquery.QueryDataV2(ctx, util.PtrToValue(report.CreatedByUID), mdlID, mdl_uniquery.QueryDataBody{SQL: sql})
+	if err != nil {
+		log.WithContext(ctx).Errorf("explore task %d query watermark failed: %v", report.TaskID, err)
+		return nil, errorcode.Detail(errorcode.PublicExploreError, err)
+	}
+	if result == nil || len(result.Entries) == 0 || result.Entries[0]["watermark"] == nil {
+		return nil, nil
+	}
+	return util.ValueToPtr(fmt.Sprint(result.Entries[0]["watermark"])), nil
+}
+
+// mergeIncrementalItems 将增量探查的结果合并到上次报告的结果中
+func (e *ExplorationDomainImplV2) mergeIncrementalItems(ctx context.Context, report *model.Report) error {
+	if report.Incremental != constant.YES || report.BaseCode == nil {
+		return nil
+	}
+	baseItems, err := e.item_repo.GetByCodeV2(nil, ctx, *report.BaseCode)
+	if err != nil {
+		return errorcode.Detail(errorcode.PublicDatabaseError, err)
+	}
+	baseItemMap := make(map[string]*model.ReportItem, len(baseItems))
+	for _, item := range baseItems {
+		baseItemMap[reportItemKey(item)] = item
+	}
+	items, err := e.item_repo.GetByCodeV2(nil, ctx, *report.Code)
+	if err != nil {
+		return errorcode.Detail(errorcode.PublicDatabaseError, err)
+	}
+
+	mergedItems := make([]*model.ReportItem, 0, len(items))
+	for _, item := range items {
+		kind := incrementalMergeKind(util.PtrToValue(item.Project), util.PtrToValue(item.DimensionType))
+		if kind == mergeKindNone || item.Result == nil {
+			continue
+		}
+		baseItem, ok := baseItemMap[reportItemKey(item)]
+		if !ok || baseItem.Result == nil {
+			return errorcode.Detail(errorcode.PublicReportFailedError, fmt.Sprintf("上次探查报告%s缺少探查项目%s的结果", *report.BaseCode, util.PtrToValue(item.Project)))
+		}
+		result, merged, err := mergeItemResult(kind, *baseItem.Result, *item.Result)
+		if err != nil {
+			log.WithContext(ctx).Errorf("merge report %s item %s result failed: %v", *report.Code, reportItemKey(item), err)
+			return errorcode.Detail(errorcode.PublicReportFailedError, err)
+		}
+		if merged {
+			item.Result = &result
+			mergedItems = append(mergedItems, item)
+		}
+	}
+	if len(mergedItems) == 0 {
+		return nil
+	}
+	if err = e.item_repo.BatchUpdate(nil, ctx, mergedItems); err != nil {
+		return errorcode.Detail(errorcode.PublicDatabaseError, err)
+	}
+	return nil
+}
+
+// mergeItemResult 合并探查项目的结果，已经合并过的结果不再合并，merged 为 false
+func mergeItemResult(kind int, baseResult, result string) (string, bool, error) {
+	var baseRows, rows []map[string]any
+	if err := json.Unmarshal([]byte(baseResult), &baseRows); err != nil {
+		return "", false, err
+	}
+	if err := json.Unmarshal([]byte(result), &rows); err != nil {
+		return "", false, err
+	}
+	if len(rows) == 0 || len(baseRows) == 0 {
+		return result, false, nil
+	}
+	base, row := baseRows[0], rows[0]
+	if merged, _ := row["merged"].(bool); merged {
+		return result, false, nil
+	}
+
+	switch kind {
+	case mergeKindCount:
+		row["count1"] = toFloat(base["count1"]) + toFloat(row["count1"])
+		row["count2"] = toFloat(base["count2"]) + toFloat(row["count2"])
+	case mergeKindSum:
+		row["result"] = toFloat(base["result"]) + toFloat(row["result"])
+	case mergeKindMax:
+		row["result"] = pickExtreme(base["result"], row["result"], true)
+	case mergeKindMin:
+		row["result"] = pickExtreme(base["result"], row["result"], false)
+	case mergeKindDistinct:
+		// 本次的去重数量已经合并了上次的草图，行数、非空行数累加。
+		// 合并草图得到的去重数量是基数估计值，重复值数量标记为近似值，不参与评分
+		row["count2"] = toFloat(base["count2"]) + toFloat(row["count2"])
+		row["non_null"] = toFloat(base["non_null"]) + toFloat(row["non_null"])
+		row["count1"] = math.Max(toFloat(row["non_null"])-toFloat(row["distinct"]), 0)
+		row["approximate"] = true
+	default:
+		return result, false, nil
+	}
+	row["merged"] = true
+	buf, err := json.Marshal([]map[string]any{row})
+	if err != nil {
+		return "", false, err
+	}
+	return string(buf), true, nil
+}
+
+// uniqueSketchResult 将去重草图的查询结果转换为重复值检查的结果，查询结果中没有草图时返回 nil
+func uniqueSketchResult(ruleId, column string, row map[string]any) map[string]any {
+	prefix := fmt.Sprintf("%s_%s", ruleId, column)
+	sketch, ok := row[prefix+"_sketch"]
+	if !ok {
+		return nil
+	}
+	nonNull := toFloat(row[prefix+"_non_null"])
+	distinct := toFloat(row[prefix+"_distinct"])
+	return map[string]any{
+		"count1":   math.Max(nonNull-distinct, 0),
+		"count2":   toFloat(row["count2"]),
+		"non_null": nonNull,
+		"distinct": distinct,
+		"sketch":   sketch,
+	}
+}
+
+func firstResultRow(result *string) map[string]any {
+	var rows []map[string]any
+	if result == nil || json.Unmarshal([]byte(*result), &rows) != nil || len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
+
+// pickExtreme 取最大值或最小值，数值按大小比较，其他类型（日期、字符串）按字符串比较
+func pickExtreme(a, b any, max bool) any {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	var less bool
+	fa, okA := a.(float64)
+	fb, okB := b.(float64)
+	if okA && okB {
+		less = fa < fb
+	} else {
+		less = fmt.Sprint(a) < fmt.Sprint(b)
+	}
+	if less == max {
+		return b
+	}
+	return a
+}
+
+func toFloat(v any) float64 {
+	switch value := v.(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case json.Number:
+		f, _ := value.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	}
+	return 0
+}
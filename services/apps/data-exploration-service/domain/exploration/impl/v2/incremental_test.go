@@ -0,0 +1,104 @@
+package v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/domain/exploration"
+)
+
+func TestMergeItemResult(t *testing.T) {
+	t.Run("空值检查累加count1和count2", func(t *testing.T) {
+		result, merged, err := mergeItemResult(mergeKindCount, `[{"count1":3,"count2":100}]`, `[{"count1":2,"count2":20}]`)
+		if err != nil || !merged {
+			t.Fatalf("期望合并成功，但得到: %v, %v", merged, err)
+		}
+		row := firstRow(t, result)
+		if row["count1"] != float64(5) || row["count2"] != float64(120) {
+			t.Errorf("期望 count1=5 count2=120，但得到: %v", row)
+		}
+	})
+
+	t.Run("最大值和最小值", func(t *testing.T) {
+		result, _, _ := mergeItemResult(mergeKindMax, `[{"result":10}]`, `[{"result":8}]`)
+		if row := firstRow(t, result); row["result"] != float64(10) {
+			t.Errorf("期望最大值为10，但得到: %v", row["result"])
+		}
+		result, _, _ = mergeItemResult(mergeKindMin, `[{"result":"2024-01-02"}]`, `[{"result":"2023-12-31"}]`)
+		if row := firstRow(t, result); row["result"] != "2023-12-31" {
+			t.Errorf("期望最小值为2023-12-31，但得到: %v", row["result"])
+		}
+		result, _, _ = mergeItemResult(mergeKindMax, `[{"result":10}]`, `[{"result":null}]`)
+		if row := firstRow(t, result); row["result"] != float64(10) {
+			t.Errorf("增量数据为空时期望保留上次的最大值，但得到: %v", row["result"])
+		}
+	})
+
+	t.Run("重复值检查使用合并后的去重数量", func(t *testing.T) {
+		result, _, _ := mergeItemResult(mergeKindDistinct,
+			`[{"count1":10,"count2":100,"non_null":90,"distinct":80,"sketch":"a"}]`,
+			`[{"count1":0,"count2":20,"non_null":20,"distinct":95,"sketch":"b"}]`)
+		row := firstRow(t, result)
+		if row["count2"] != float64(120) || row["non_null"] != float64(110) || row["count1"] != float64(15) || row["sketch"] != "b" {
+			t.Errorf("期望 count1=15 count2=120 non_null=110，但得到: %v", row)
+		}
+		if row["approximate"] != true {
+			t.Errorf("期望合并草图的结果标记为近似值，但得到: %v", row)
+		}
+	})
+
+	t.Run("已经合并的结果不再合并", func(t *testing.T) {
+		result, merged, err := mergeItemResult(mergeKindCount, `[{"count1":3,"count2":100}]`, `[{"count1":5,"count2":120,"merged":true}]`)
+		if err != nil || merged {
+			t.Fatalf("期望不再合并，但得到: %v, %v", merged, err)
+		}
+		if row := firstRow(t, result); row["count1"] != float64(5) {
+			t.Errorf("期望结果不变，但得到: %v", row)
+		}
+	})
+}
+
+func TestUniqueSketchResult(t *testing.T) {
+	if uniqueSketchResult("r1", "c", map[string]any{"r1_c": 1, "count2": 10}) != nil {
+		t.Errorf("没有草图时期望返回nil")
+	}
+	row := uniqueSketchResult("r1", "c", map[string]any{"r1_c_non_null": float64(8), "r1_c_distinct": float64(6), "r1_c_sketch": "s", "count2": float64(10)})
+	if row["count1"] != float64(2) || row["count2"] != float64(10) || row["sketch"] != "s" {
+		t.Errorf("期望 count1=2 count2=10，但得到: %v", row)
+	}
+}
+
+func TestGetFieldUniqueSketchSql(t *testing.T) {
+	full := GetFieldUniqueSketchSql(exploration.MetaDataTableInfo{}, "c", "r1")
+	if !strings.Contains(full, `COUNT(DISTINCT "c") AS "r1_c_distinct"`) || strings.Contains(full, "cardinality") {
+		t.Errorf("探查全表时期望精确计算去重数量，但得到: %s", full)
+	}
+	delta := GetFieldUniqueSketchSql(exploration.MetaDataTableInfo{Window: &exploration.IncrementalWindow{
+		BaseSketches: map[string]string{"r1_c": "base"},
+	}}, "c", "r1")
+	if !strings.Contains(delta, "cardinality(merge(sketch))") || !strings.Contains(delta, "from_base64('base')") {
+		t.Errorf("增量探查时期望合并上次报告的草图，但得到: %s", delta)
+	}
+}
+
+func TestIncrementalFilterSql(t *testing.T) {
+	window := &exploration.IncrementalWindow{Column: "updated", DataType: "varchar", From: "2024-01-01", To: "2024-01-02' OR '1'='1"}
+	want := `"updated" > '2024-01-01' AND "updated" <= '2024-01-02'' OR ''1''=''1'`
+	if got := incrementalFilterSql(window); got != want {
+		t.Errorf("期望水位中的单引号被转义为 %s，但得到: %s", want, got)
+	}
+	window.DataType, window.To = "timestamp", "2024-01-02 00:00:00"
+	want = `"updated" > TIMESTAMP '2024-01-01' AND "updated" <= TIMESTAMP '2024-01-02 00:00:00'`
+	if got := incrementalFilterSql(window); got != want {
+		t.Errorf("期望 %s，但得到: %s", want, got)
+	}
+}
+
+func firstRow(t *testing.T, result string) map[string]any {
+	var rows []map[string]any
+	if err := json.Unmarshal([]byte(result), &rows); err != nil || len(rows) != 1 {
+		t.Fatalf("结果格式错误: %s", result)
+	}
+	return rows[0]
+}
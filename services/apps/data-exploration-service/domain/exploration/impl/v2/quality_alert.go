@@ -0,0 +1,345 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/adapter/driven/mq"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/models/request"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/domain/exploration"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 质量异常检测的指标
+const (
+	metricCompletenessScore    = "completeness_score"
+	metricUniquenessScore      = "uniqueness_score"
+	metricStandardizationScore = "standardization_score"
+	metricAccuracyScore        = "accuracy_score"
+	metricConsistencyScore     = "consistency_score"
+	metricRowCount             = "row_count"
+	metricNullRatio            = "null_ratio"
+	metricDistinctCount        = "distinct_count"
+)
+
+// 质量异常类型
+const (
+	anomalyKindMinimum   = "minimum"   // 低于下限
+	anomalyKindThreshold = "threshold" // 变化超过阈值
+	anomalyKindDrift     = "drift"     // 统计漂移
+)
+
+// 质量异常告警的默认配置
+const (
+	defaultMaxScoreDrop      = 0.1
+	defaultMaxNullRatioRise  = 0.1
+	defaultMaxRowCountChange = 0.5
+	defaultMaxDistinctChange = 0.5
+	defaultDriftThreshold    = 3
+	defaultAlertHistorySize  = 10
+	// 历史报告少于该数量时不做统计漂移检测
+	minDriftHistorySize = 3
+)
+
+// metricKey 指标的唯一标识，报告级指标的 FieldId 为空
+type metricKey struct {
+	Metric  string
+	FieldId string
+}
+
+// qualityAlertConfig 补全告警配置的默认值，关闭告警时返回 false
+func qualityAlertConfig(config *exploration.QualityAlertConfig) (exploration.QualityAlertConfig, bool) {
+	var c exploration.QualityAlertConfig
+	if config != nil {
+		c = *config
+	}
+	if c.Disabled {
+		return c, false
+	}
+	if c.MaxScoreDrop <= 0 {
+		c.MaxScoreDrop = defaultMaxScoreDrop
+	}
+	if c.MaxNullRatioRise <= 0 {
+		c.MaxNullRatioRise = defaultMaxNullRatioRise
+	}
+	if c.MaxRowCountChange <= 0 {
+		c.MaxRowCountChange = defaultMaxRowCountChange
+	}
+	if c.MaxDistinctChange <= 0 {
+		c.MaxDistinctChange = defaultMaxDistinctChange
+	}
+	if c.DriftThreshold <= 0 {
+		c.DriftThreshold = defaultDriftThreshold
+	}
+	if c.HistorySize <= 0 {
+		c.HistorySize = defaultAlertHistorySize
+	}
+	return c, true
+}
+
+// reportQualityMetrics 从探查报告中提取维度评分、总行数及字段的空值率、去重数量
+func reportQualityMetrics(report *exploration.ReportFormat) map[metricKey]float64 {
+	metrics := make(map[metricKey]float64)
+	scores := map[string]*float64{
+		metricCompletenessScore:    report.CompletenessScore,
+		metricUniquenessScore:      report.UniquenessScore,
+		metricStandardizationScore: report.StandardizationScore,
+		metricAccuracyScore:        report.AccuracyScore,
+		metricConsistencyScore:     report.ConsistencyScore,
+	}
+	for metric, score := range scores {
+		if score != nil {
+			metrics[metricKey{Metric: metric}] = *score
+		}
+	}
+	if report.Total > 0 {
+		metrics[metricKey{Metric: metricRowCount}] = float64(report.Total)
+	}
+
+	for _, field := range report.FieldExplore {
+		var nullCount, nonNullCount *float64
+		var unique *exploration.RuleResult
+		for _, detail := range field.Details {
+			if detail.InspectedCount <= 0 {
+				continue
+			}
+			switch {
+			case detail.RuleName == constant.NullCount || detail.DimensionType == constant.DimensionTypeNull.String:
+				metrics[metricKey{Metric: metricNullRatio, FieldId: field.FieldId}] = float64(detail.IssueCount) / float64(detail.InspectedCount)
+				nullCount = util.ValueToPtr(float64(detail.IssueCount))
+			case detail.Approximate:
+				// 增量探查合并的近似结果不参与异常检测
+				continue
+			case detail.RuleName == constant.Unique || detail.DimensionType == constant.DimensionTypeRepeat.String:
+				unique = detail
+				// 增量探查的去重草图结果中带有非空行数
+				if row := firstResultRow(detail.Result); row != nil {
+					if v, ok := row["non_null"]; ok {
+						nonNullCount = util.ValueToPtr(toFloat(v))
+					}
+				}
+			}
+		}
+		if unique == nil {
+			continue
+		}
+		// 重复值检查的问题数据量为非空行数减去去重数量
+		switch {
+		case nonNullCount != nil:
+			metrics[metricKey{Metric: metricDistinctCount, FieldId: field.FieldId}] = *nonNullCount - float64(unique.IssueCount)
+		case nullCount != nil:
+			metrics[metricKey{Metric: metricDistinctCount, FieldId: field.FieldId}] = float64(unique.InspectedCount) - *nullCount - float64(unique.IssueCount)
+		}
+	}
+	return metrics
+}
+
+// detectQualityAnomalies 将本次探查的指标与历史报告比较，history 按探查时间倒序，history[0] 为上次探查的指标
+func detectQualityAnomalies(current map[metricKey]float64, history []map[metricKey]float64, config exploration.QualityAlertConfig) []*exploration.QualityAnomaly {
+	keys := make([]metricKey, 0, len(current))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Metric != keys[j].Metric {
+			return keys[i].Metric < keys[j].Metric
+		}
+		return keys[i].FieldId < keys[j].FieldId
+	})
+
+	anomalies := make([]*exploration.QualityAnomaly, 0)
+	for _, key := range keys {
+		value := current[key]
+		var prev *float64
+		if len(history) > 0 {
+			if v, ok := history[0][key]; ok {
+				prev = &v
+			}
+		}
+		if anomaly := thresholdAnomaly(key.Metric, value, prev, config); anomaly != nil {
+			anomaly.FieldId = key.FieldId
+			anomalies = append(anomalies, anomaly)
+			continue
+		}
+
+		series := make([]float64, 0, len(history))
+		for _, metrics := range history {
+			if v, ok := metrics[key]; ok {
+				series = append(series, v)
+			}
+		}
+		if anomaly := driftAnomaly(key.Metric, value, series, config.DriftThreshold); anomaly != nil {
+			anomaly.FieldId = key.FieldId
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+	return anomalies
+}
+
+// thresholdAnomaly 检查指标是否低于下限或相比上次探查的变化超过阈值
+func thresholdAnomaly(metric string, value float64, prev *float64, config exploration.QualityAlertConfig) *exploration.QualityAnomaly {
+	anomaly := func(kind string, baseline, threshold float64) *exploration.QualityAnomaly {
+		return &exploration.QualityAnomaly{Metric: metric, Kind: kind, Value: value, Baseline: baseline, Threshold: threshold}
+	}
+	switch metric {
+	case metricRowCount, metricDistinctCount:
+		limit := config.MaxRowCountChange
+		if metric == metricDistinctCount {
+			limit = config.MaxDistinctChange
+		}
+		if prev != nil && *prev > 0 && math.Abs(value-*prev)/(*prev) > limit {
+			return anomaly(anomalyKindThreshold, *prev, limit)
+		}
+	case metricNullRatio:
+		if prev != nil && value-*prev > config.MaxNullRatioRise {
+			return anomaly(anomalyKindThreshold, *prev, config.MaxNullRatioRise)
+		}
+	default:
+		if config.MinScore > 0 && value < config.MinScore {
+			return anomaly(anomalyKindMinimum, util.PtrToValue(prev), config.MinScore)
+		}
+		if prev != nil && *prev-value > config.MaxScoreDrop {
+			return anomaly(anomalyKindThreshold, *prev, config.MaxScoreDrop)
+		}
+	}
+	return nil
+}
+
+// driftAnomaly 使用 z-score 检查指标是否偏离历史均值，评分只检查下降，空值率只检查上升
+func driftAnomaly(metric string, value float64, series []float64, threshold float64) *exploration.QualityAnomaly {
+	if len(series) < minDriftHistorySize {
+		return nil
+	}
+	var mean, variance float64
+	for _, v := range series {
+		mean += v
+	}
+	mean /= float64(len(series))
+	for _, v := range series {
+		variance += (v - mean) * (v - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(series)))
+
+	// 历史值几乎不变时标准差接近0，设置下限避免微小波动触发告警
+	switch metric {
+	case metricRowCount, metricDistinctCount:
+		stddev = math.Max(stddev, math.Max(math.Abs(mean)*0.01, 1))
+	default:
+		stddev = math.Max(stddev, 0.01)
+	}
+	z := (value - mean) / stddev
+	switch metric {
+	case metricRowCount, metricDistinctCount:
+		z = math.Abs(z)
+	case metricNullRatio:
+		// 空值率只检查上升
+	default:
+		// 评分只检查下降
+		z = -z
+	}
+	if z <= threshold {
+		return nil
+	}
+	return &exploration.QualityAnomaly{Metric: metric, Kind: anomalyKindDrift, Value: value, Baseline: mean, Threshold: threshold}
+}
+
+// alertQualityAnomaly 检测探查报告的质量异常，存在异常时通知任务中心生成告警，检测失败不影响探查结果
+func (e *ExplorationDomainImplV2) alertQualityAnomaly(ctx context.Context, report *model.Report) {
+	if report.Result == nil || report.QueryParams == nil || util.PtrToValue(report.ExploreType) != ExploreType_Data {
+		return
+	}
+	var exploreReq exploration.DataExploreReq
+	if err := json.Unmarshal([]byte(*report.QueryParams), &exploreReq); err != nil {
+		log.WithContext(ctx).Errorf("report code %s unmarshal query params failed: %v", *report.Code, err)
+		return
+	}
+	config, enabled := qualityAlertConfig(exploreReq.QualityAlert)
+	if !enabled {
+		return
+	}
+	var current exploration.ReportFormat
+	if err := json.Unmarshal([]byte(*report.Result), &current); err != nil {
+		log.WithContext(ctx).Errorf("report code %s unmarshal result failed: %v", *report.Code, err)
+		return
+	}
+
+	// 多取一条，排除本次报告
+	page := &request.PageInfo{
+		Offset:    util.ValueToPtr(1),
+		Limit:     util.ValueToPtr(config.HistorySize + 1),
+		Direction: util.ValueToPtr("desc"),
+		Sort:      util.ValueToPtr("f_created_at"),
+	}
+	reports, _, err := e.repo.ListByPage(ctx, page, nil, util.ValueToPtr(strconv.FormatUint(report.TaskID, 10)))
+	if err != nil {
+		log.WithContext(ctx).Errorf("report code %s list history reports failed: %v", *report.Code, err)
+		return
+	}
+	history := make([]map[metricKey]float64, 0, len(reports))
+	for _, r := range reports {
+		if *r.Code == *report.Code || util.PtrToValue(r.ExploreType) != ExploreType_Data || r.Result == nil {
+			continue
+		}
+		var format exploration.ReportFormat
+		if err = json.Unmarshal([]byte(*r.Result), &format); err != nil {
+			continue
+		}
+		history = append(history, reportQualityMetrics(&format))
+		if len(history) == config.HistorySize {
+			break
+		}
+	}
+	// 首次探查没有可以比较的基线
+	if len(history) == 0 {
+		return
+	}
+
+	anomalies := detectQualityAnomalies(reportQualityMetrics(&current), history, config)
+	if len(anomalies) == 0 {
+		return
+	}
+	fieldNames := make(map[string]string, len(exploreReq.FieldExplore))
+	for _, field := range exploreReq.FieldExplore {
+		fieldNames[field.FieldId] = field.FieldName
+	}
+	for _, anomaly := range anomalies {
+		anomaly.FieldName = fieldNames[anomaly.FieldId]
+	}
+
+	recipientIds := config.RecipientIds
+	if len(recipientIds) == 0 && util.PtrToValue(report.CreatedByUID) != "" {
+		recipientIds = []string{*report.CreatedByUID}
+	}
+	if len(recipientIds) == 0 {
+		log.WithContext(ctx).Warnf("report code %s found %d quality anomalies but no recipient", *report.Code, len(anomalies))
+		return
+	}
+
+	msg := &exploration.QualityAnomalyMsg{
+		ReportCode:   *report.Code,
+		TaskId:       strconv.FormatUint(report.TaskID, 10),
+		TaskVersion:  report.TaskVersion,
+		TableId:      util.PtrToValue(report.TableID),
+		Table:        util.PtrToValue(report.Table),
+		RecipientIds: recipientIds,
+		DetectedAt:   time.Now().UnixMilli(),
+		Anomalies:    anomalies,
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		log.WithContext(ctx).Errorf("report code %s json.Marshal quality anomaly msg failed: %v", *report.Code, err)
+		return
+	}
+	if err = e.mq_producter.SyncProduce(mq.QualityAnomalyTopic, util.StringToBytes(msg.TableId), b); err != nil {
+		log.WithContext(ctx).Errorf("report code %s e.mq_producter.SyncProduce quality anomaly failed: %v", *report.Code, err)
+		return
+	}
+	log.WithContext(ctx).Infof("report code %s found %d quality anomalies", *report.Code, len(anomalies))
+}
@@ -0,0 +1,102 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/data-exploration-service/domain/exploration"
+)
+
+func TestReportQualityMetrics(t *testing.T) {
+	score := 0.95
+	report := &exploration.ReportFormat{
+		Total: 100,
+		FieldExplore: []*exploration.ExploreFieldDetail{
+			{
+				FieldId: "f1",
+				Details: []*exploration.RuleResult{
+					{RuleName: constant.NullCount, InspectedCount: 100, IssueCount: 20},
+					{RuleName: constant.Unique, InspectedCount: 100, IssueCount: 30},
+				},
+			},
+		},
+	}
+	report.CompletenessScore = &score
+
+	metrics := reportQualityMetrics(report)
+	if metrics[metricKey{Metric: metricCompletenessScore}] != 0.95 {
+		t.Errorf("期望完整性评分为0.95，但得到: %v", metrics)
+	}
+	if metrics[metricKey{Metric: metricRowCount}] != 100 {
+		t.Errorf("期望总行数为100，但得到: %v", metrics)
+	}
+	if metrics[metricKey{Metric: metricNullRatio, FieldId: "f1"}] != 0.2 {
+		t.Errorf("期望空值率为0.2，但得到: %v", metrics)
+	}
+	if metrics[metricKey{Metric: metricDistinctCount, FieldId: "f1"}] != 50 {
+		t.Errorf("期望去重数量为50，但得到: %v", metrics)
+	}
+
+	// 增量探查合并的近似结果不参与异常检测
+	report.FieldExplore[0].Details[1].Approximate = true
+	metrics = reportQualityMetrics(report)
+	if _, ok := metrics[metricKey{Metric: metricDistinctCount, FieldId: "f1"}]; ok {
+		t.Errorf("期望近似结果不计算去重数量，但得到: %v", metrics)
+	}
+}
+
+func TestDetectQualityAnomalies(t *testing.T) {
+	config, _ := qualityAlertConfig(nil)
+	completeness := metricKey{Metric: metricCompletenessScore}
+	rowCount := metricKey{Metric: metricRowCount}
+	nullRatio := metricKey{Metric: metricNullRatio, FieldId: "f1"}
+
+	t.Run("评分相比上次探查大幅下降", func(t *testing.T) {
+		anomalies := detectQualityAnomalies(
+			map[metricKey]float64{completeness: 0.6},
+			[]map[metricKey]float64{{completeness: 0.99}},
+			config)
+		if len(anomalies) != 1 || anomalies[0].Kind != anomalyKindThreshold || anomalies[0].Baseline != 0.99 {
+			t.Fatalf("期望一个超过阈值的异常，但得到: %+v", anomalies)
+		}
+	})
+
+	t.Run("评分上升不告警", func(t *testing.T) {
+		anomalies := detectQualityAnomalies(
+			map[metricKey]float64{completeness: 0.99},
+			[]map[metricKey]float64{{completeness: 0.6}, {completeness: 0.6}, {completeness: 0.6}},
+			config)
+		if len(anomalies) != 0 {
+			t.Fatalf("期望没有异常，但得到: %+v", anomalies)
+		}
+	})
+
+	t.Run("空值率逐渐上升触发统计漂移", func(t *testing.T) {
+		anomalies := detectQualityAnomalies(
+			map[metricKey]float64{nullRatio: 0.1},
+			[]map[metricKey]float64{{nullRatio: 0.04}, {nullRatio: 0.01}, {nullRatio: 0.01}, {nullRatio: 0.01}},
+			config)
+		if len(anomalies) != 1 || anomalies[0].Kind != anomalyKindDrift || anomalies[0].FieldId != "f1" {
+			t.Fatalf("期望一个统计漂移异常，但得到: %+v", anomalies)
+		}
+	})
+
+	t.Run("总行数小幅波动不告警", func(t *testing.T) {
+		anomalies := detectQualityAnomalies(
+			map[metricKey]float64{rowCount: 1010},
+			[]map[metricKey]float64{{rowCount: 1000}, {rowCount: 1000}, {rowCount: 1000}},
+			config)
+		if len(anomalies) != 0 {
+			t.Fatalf("期望没有异常，但得到: %+v", anomalies)
+		}
+	})
+
+	t.Run("评分低于下限", func(t *testing.T) {
+		c := config
+		c.MinScore = 0.8
+		anomalies := detectQualityAnomalies(map[metricKey]float64{completeness: 0.7}, []map[metricKey]float64{{completeness: 0.72}}, c)
+		if len(anomalies) != 1 || anomalies[0].Kind != anomalyKindMinimum || anomalies[0].Threshold != 0.8 {
+			t.Fatalf("期望一个低于下限的异常，但得到: %+v", anomalies)
+		}
+	})
+}
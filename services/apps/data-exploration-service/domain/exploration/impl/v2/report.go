@@ -36,6 +36,9 @@ func (e *ExplorationDomainImplV2) getReport(tx *gorm.DB, ctx context.Context, re
 	reportFormat.CreatedAt = util.ValueToPtr(report.CreatedAt.UnixMilli())
 	now := time.Now()
 	reportFormat.FinishedAt = util.ValueToPtr(now.UnixMilli())
+	reportFormat.Incremental = report.Incremental == constant.YES
+	reportFormat.BaseCode = report.BaseCode
+	reportFormat.Watermark = report.Watermark
 	if report.TotalNum != nil {
 		//全量探查时，总行数为实际探查数量
 		reportFormat.Total = *report.TotalNum
@@ -329,8 +332,14 @@ func (e *ExplorationDomainImplV2) calculateFieldExploreScore(tx *gorm.DB, ctx co
 							ruleResult.IssueCount = int64(count2 - count1)
 							score = formatCalculateScoreResult(count1, count2)
 						}
+						ruleResult.Approximate = data[0].Approximate
 					}
 				}
+				// 近似的检测结果只展示，不按阈值评分
+				if ruleResult.Approximate {
+					ruleResults = append(ruleResults, ruleResult)
+					continue
+				}
 
 				switch rule.Dimension {
 				case constant.DimensionCompleteness.String:
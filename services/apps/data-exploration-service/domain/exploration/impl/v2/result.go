@@ -267,6 +267,9 @@ func (e *ExplorationDomainImplV2) ResultToItem(item *model.ReportItem, result []
 		itemResult = append(itemResult, resultMap)
 		resultBytes, _ := json.Marshal(itemResult)
 		res = string(resultBytes)
+	} else if sketchResult := uniqueSketchResult(*item.RuleId, util.PtrToValue(item.Column), result[0]); sketchResult != nil {
+		resultBytes, _ := json.Marshal([]map[string]any{sketchResult})
+		res = string(resultBytes)
 	} else if *item.Project == constant.NullCount || *item.Project == constant.Dict ||
 		*item.Project == constant.Regexp || *item.Project == constant.Unique ||
 		(item.DimensionType != nil && (*item.DimensionType == constant.DimensionTypeNull.String || *item.DimensionType == constant.DimensionTypeDict.String ||
@@ -656,6 +659,8 @@ func (e *ExplorationDomainImplV2) updateReport(tx *gorm.DB, ctx context.Context,
 					if err != nil {
 						return errorcode.Detail(errorcode.MqProduceError, err)
 					}
+					// 检测质量评分异常并告警
+					e.alertQualityAnomaly(ctx, report)
 				}
 			}
 
@@ -775,6 +780,8 @@ func (e *ExplorationDomainImplV2) updateExploreReport(tx *gorm.DB, ctx context.C
 					if err != nil {
 						return errorcode.Detail(errorcode.MqProduceError, err)
 					}
+					// 检测质量评分异常并告警
+					e.alertQualityAnomaly(ctx, report)
 				}
 			}
 
@@ -166,6 +166,8 @@ func (e *ExplorationDomainImplV2) exploreTaskDevideProc(ctx context.Context) {
 					log.WithContext(ctx).Errorf("explore task %d version %d e.mq_producter.SyncProduce failed: %v", report.TaskID, *report.TaskVersion, err)
 					return
 				}
+				// 检测质量评分异常并告警
+				e.alertQualityAnomaly(ctx, report)
 			}
 		}()
 	}
@@ -245,6 +247,12 @@ func (e *ExplorationDomainImplV2) devideExploreTaskReportItems(ctx context.Conte
 			return nil, err
 		}
 	} else {
+		// 增量探查只探查上次水位之后的数据
+		tableInfo.Window, err = e.incrementalWindow(ctx, report, exploreReq, tableInfo)
+		if err != nil {
+			log.WithContext(ctx).Errorf("explore task %d version %d get incremental window failed: %v", report.TaskID, *report.TaskVersion, err)
+			return nil, err
+		}
 		sqls := make([]string, 0)
 		sqlMap := make(map[string]string)
 
@@ -372,8 +380,13 @@ func fieldExploreSqlGenerate(exploreReq *exploration.DataExploreReq, e *Explorat
 				continue
 			}
 			if project.RuleName == constant.Unique || project.DimensionType == constant.DimensionTypeRepeat.String {
-				uniqueSql := GetFieldUniqueSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId)
-				mergeSqls = append(mergeSqls, uniqueSql)
+				if isIncrementalTask(exploreReq) {
+					// 增量探查任务单独计算可合并的去重草图
+					sql = GetFieldUniqueSketchSql(tableInfo, tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId)
+				} else {
+					uniqueSql := GetFieldUniqueSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId)
+					mergeSqls = append(mergeSqls, uniqueSql)
+				}
 			}
 			if project.RuleName == constant.Dict || project.DimensionType == constant.DimensionTypeDict.String {
 				dictSql, err := GetFieldDictSql(res, fieldProject.FieldId, tableInfo, project.RuleId)
@@ -402,16 +415,28 @@ func fieldExploreSqlGenerate(exploreReq *exploration.DataExploreReq, e *Explorat
 				continue
 			}
 			if project.RuleName == constant.Quantile {
-				statisticsSqls = append(statisticsSqls, GetFieldQuantileSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId))
-				continue
+				if tableInfo.Window == nil {
+					statisticsSqls = append(statisticsSqls, GetFieldQuantileSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId))
+					continue
+				}
+				// 增量探查时无法合并的统计项单独探查全表
+				sql = strings.Replace(nsql.StatisticsSql, "${sql}", GetFieldQuantileSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId), -1)
 			}
 			if project.RuleName == constant.Avg {
-				statisticsSqls = append(statisticsSqls, GetFieldAvgSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId))
-				continue
+				if tableInfo.Window == nil {
+					statisticsSqls = append(statisticsSqls, GetFieldAvgSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId))
+					continue
+				}
+				// 增量探查时无法合并的统计项单独探查全表
+				sql = strings.Replace(nsql.StatisticsSql, "${sql}", GetFieldAvgSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId), -1)
 			}
 			if project.RuleName == constant.StddevPop {
-				statisticsSqls = append(statisticsSqls, GetFieldStddevPopSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId))
-				continue
+				if tableInfo.Window == nil {
+					statisticsSqls = append(statisticsSqls, GetFieldStddevPopSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId))
+					continue
+				}
+				// 增量探查时无法合并的统计项单独探查全表
+				sql = strings.Replace(nsql.StatisticsSql, "${sql}", GetFieldStddevPopSql(tableInfo.Columns[fieldProject.FieldId].Name, project.RuleId), -1)
 			}
 			if project.RuleName == constant.Group {
 				sql = nsql.Group
@@ -428,7 +453,7 @@ func fieldExploreSqlGenerate(exploreReq *exploration.DataExploreReq, e *Explorat
 				continue
 			}
 			if sql != "" {
-				sql, err = e.generateSql(sql, tableInfo, exploreReq.TotalSample, fieldProject)
+				sql, err = e.generateSql(sql, ruleTableInfo(tableInfo, project.RuleName, project.DimensionType), exploreReq.TotalSample, fieldProject)
 				if err != nil {
 					return nil, nil, statisticsSql, mergeSql, groupMap, err
 				}
@@ -456,7 +481,7 @@ func fieldExploreSqlGenerate(exploreReq *exploration.DataExploreReq, e *Explorat
 			groupSql := strings.Replace(nsql.GroupSql, "${group_column_name}", strings.Join(groupColumns, ", "), -1)
 			groupSql = strings.Replace(groupSql, "${group_sql}", strings.Join(groupSqls, ", "), -1)
 			groupSql = strings.Replace(groupSql, "${column_name}", fieldProject.FieldName, -1)
-			groupSql, err = e.generateSql(groupSql, tableInfo, exploreReq.TotalSample, fieldProject)
+			groupSql, err = e.generateSql(groupSql, fullTableInfo(tableInfo), exploreReq.TotalSample, fieldProject)
 			if err != nil {
 				return nil, nil, statisticsSql, mergeSql, groupMap, err
 			}
@@ -787,6 +812,24 @@ func GetFieldUniqueSql(fieldName, ruleId string) (sql string) {
 	return sql
 }
 
+// GetFieldUniqueSketchSql 增量探查任务的重复值检查。探查全表时精确计算去重数量，
+// 只有增量探查时才用上次报告的草图合并估计去重数量
+func GetFieldUniqueSketchSql(tableInfo exploration.MetaDataTableInfo, fieldName, ruleId string) (sql string) {
+	ruleColumn := fmt.Sprintf("%s_%s", ruleId, fieldName)
+	if tableInfo.Window == nil {
+		sql = strings.Replace(nsql.UniqueSketchFull, "${column_name}", fieldName, -1)
+		return strings.Replace(sql, "${rule_id}", ruleColumn, -1)
+	}
+	var baseSketch string
+	if tableInfo.Window.BaseSketches[ruleColumn] != "" {
+		baseSketch = strings.Replace(nsql.BaseSketch, "${sketch}", tableInfo.Window.BaseSketches[ruleColumn], -1)
+	}
+	sql = strings.Replace(nsql.UniqueSketch, "${column_name}", fieldName, -1)
+	sql = strings.Replace(sql, "${rule_id}", ruleColumn, -1)
+	sql = strings.Replace(sql, "${base_sketch}", baseSketch, -1)
+	return sql
+}
+
 func GetFieldDictSql(res *exploration.RuleConfig, fieldId string, tableInfo exploration.MetaDataTableInfo, ruleId string) (sql string, err error) {
 	if res.Dict == nil {
 		return sql, errorcode.Detail(errorcode.PublicInvalidParameterJson, "规则配置错误")
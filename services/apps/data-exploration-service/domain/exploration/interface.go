@@ -22,22 +22,38 @@ type CodePathParam struct {
 }
 
 type DataExploreReq struct {
-	Table           string          `json:"table" binding:"required,TrimSpace,min=1,max=255" example:"1"`      // 表名称
-	Schema          string          `json:"schema" binding:"required,TrimSpace,min=1,max=255" example:"1"`     // 数据库名
-	VeCatalog       string          `json:"ve_catalog" binding:"required,TrimSpace,min=1,max=255" example:"1"` // 数据源编目
-	TableId         string          `json:"table_id" binding:"TrimSpace,max=255" example:"1"`                  // 数据源表ID
-	MdlId           string          `json:"mdl_id" `                                                           // mdl_id
-	ExploreModel    int32           `json:"explore_model" binding:"TrimSpace,oneof=0 1"`                       // 探查模式，0指定字段探查，1自动探查
-	FieldExplore    []*ExploreField `json:"field_explore" binding:"omitempty,dive"`                            // 字段探查参数
-	MetadataExplore []*Project      `json:"metadata_explore" binding:"omitempty"`                              // 元数据级探查项目
-	RowExplore      []*Project      `json:"row_explore" binding:"omitempty"`                                   // 行级级探查项目
-	ViewExplore     []*Project      `json:"view_explore" binding:"omitempty"`                                  // 视图级探查项目
-	ExploreType     int32           `json:"explore_type" binding:"TrimSpace,oneof=0 1"`                        // 探查类型,0 快速探查,1 随机快速探,2 全量探查
-	TotalSample     int32           `json:"total_sample" binding:"TrimSpace,min=0,max=1000"`                   // 探查样本总数
-	ExpireTime      int32           `json:"expire_time" binding:"omitempty,min=0,max=1440" example:"1"`        // 缓存过期时间，单位分钟,为0时默认30分钟，最大24小时
-	Cache           int32           `json:"cache" binding:"omitempty,oneof=0 1" example:"1"`                   // 是否从缓存中获取查询结果(默认查询结果缓存30分钟)0不缓存，1从缓存中获取
-	DvTaskID        string          `json:"dv_task_id" binding:"TrimSpace,uuid"`                               // data-view任务id
-	FieldInfo       string          `json:"field_info" binding:"required"`                                     // 视图字段信息
+	Table            string              `json:"table" binding:"required,TrimSpace,min=1,max=255" example:"1"`      // 表名称
+	Schema           string              `json:"schema" binding:"required,TrimSpace,min=1,max=255" example:"1"`     // 数据库名
+	VeCatalog        string              `json:"ve_catalog" binding:"required,TrimSpace,min=1,max=255" example:"1"` // 数据源编目
+	TableId          string              `json:"table_id" binding:"TrimSpace,max=255" example:"1"`                  // 数据源表ID
+	MdlId            string              `json:"mdl_id" `                                                           // mdl_id
+	ExploreModel     int32               `json:"explore_model" binding:"TrimSpace,oneof=0 1"`                       // 探查模式，0指定字段探查，1自动探查
+	FieldExplore     []*ExploreField     `json:"field_explore" binding:"omitempty,dive"`                            // 字段探查参数
+	MetadataExplore  []*Project          `json:"metadata_explore" binding:"omitempty"`                              // 元数据级探查项目
+	RowExplore       []*Project          `json:"row_explore" binding:"omitempty"`                                   // 行级级探查项目
+	ViewExplore      []*Project          `json:"view_explore" binding:"omitempty"`                                  // 视图级探查项目
+	ExploreType      int32               `json:"explore_type" binding:"TrimSpace,oneof=0 1"`                        // 探查类型,0 快速探查,1 随机快速探,2 全量探查
+	TotalSample      int32               `json:"total_sample" binding:"TrimSpace,min=0,max=1000"`                   // 探查样本总数
+	ExpireTime       int32               `json:"expire_time" binding:"omitempty,min=0,max=1440" example:"1"`        // 缓存过期时间，单位分钟,为0时默认30分钟，最大24小时
+	Cache            int32               `json:"cache" binding:"omitempty,oneof=0 1" example:"1"`                   // 是否从缓存中获取查询结果(默认查询结果缓存30分钟)0不缓存，1从缓存中获取
+	DvTaskID         string              `json:"dv_task_id" binding:"TrimSpace,uuid"`                               // data-view任务id
+	FieldInfo        string              `json:"field_info" binding:"required"`                                     // 视图字段信息
+	Incremental      bool                `json:"incremental"`                                                       // 是否增量探查，只在全量探查时生效
+	TimestampFieldId string              `json:"timestamp_field_id" binding:"omitempty"`                            // 增量探查使用的业务时间字段id
+	QualityAlert     *QualityAlertConfig `json:"quality_alert" binding:"omitempty"`                                 // 质量异常告警配置，缺省使用默认配置
+}
+
+// QualityAlertConfig 质量异常告警配置，数值为0时使用默认值
+type QualityAlertConfig struct {
+	Disabled          bool     `json:"disabled"`                                            // 是否关闭质量异常告警
+	MinScore          float64  `json:"min_score" binding:"omitempty,min=0,max=1"`           // 维度评分下限，低于下限时告警，为0时不检查
+	MaxScoreDrop      float64  `json:"max_score_drop" binding:"omitempty,min=0,max=1"`      // 维度评分相比上次探查的最大降幅，默认0.1
+	MaxNullRatioRise  float64  `json:"max_null_ratio_rise" binding:"omitempty,min=0,max=1"` // 字段空值率相比上次探查的最大升幅，默认0.1
+	MaxRowCountChange float64  `json:"max_row_count_change" binding:"omitempty,min=0"`      // 总行数相比上次探查的最大变化比例，默认0.5
+	MaxDistinctChange float64  `json:"max_distinct_change" binding:"omitempty,min=0"`       // 字段去重数量相比上次探查的最大变化比例，默认0.5
+	DriftThreshold    float64  `json:"drift_threshold" binding:"omitempty,min=0"`           // 偏离历史均值的标准差倍数，超过时视为统计漂移，默认3
+	HistorySize       int      `json:"history_size" binding:"omitempty,min=0,max=100"`      // 统计漂移参考的历史报告数量，默认10
+	RecipientIds      []string `json:"recipient_ids" binding:"omitempty,dive,uuid"`         // 告警接收人id，缺省为探查任务的创建人
 }
 
 type ExploreField struct {
@@ -223,6 +239,29 @@ type ExploreDataFinishedMsg struct {
 	FinishedAt  int64  `json:"finished_at"`  // 结束时间
 }
 
+// QualityAnomalyMsg 质量异常告警消息，由任务中心生成用户通知
+type QualityAnomalyMsg struct {
+	ReportCode   string            `json:"report_code"`   // 探查报告编号
+	TaskId       string            `json:"task_id"`       // 任务id
+	TaskVersion  *int32            `json:"task_version"`  // 任务版本
+	TableId      string            `json:"table_id"`      // 视图id
+	Table        string            `json:"table"`         // 表名称
+	RecipientIds []string          `json:"recipient_ids"` // 告警接收人id
+	DetectedAt   int64             `json:"detected_at"`   // 检测时间
+	Anomalies    []*QualityAnomaly `json:"anomalies"`     // 异常指标
+}
+
+// QualityAnomaly 异常指标
+type QualityAnomaly struct {
+	Metric    string  `json:"metric"`               // 指标：completeness_score、uniqueness_score、standardization_score、accuracy_score、consistency_score、row_count、null_ratio、distinct_count
+	Kind      string  `json:"kind"`                 // 异常类型：minimum 低于下限，threshold 变化超过阈值，drift 统计漂移
+	FieldId   string  `json:"field_id,omitempty"`   // 字段id，字段级指标时有值
+	FieldName string  `json:"field_name,omitempty"` // 字段名称，字段级指标时有值
+	Value     float64 `json:"value"`                // 本次探查的值
+	Baseline  float64 `json:"baseline"`             // 比较基线，统计漂移时为历史均值，其他为上次探查的值
+	Threshold float64 `json:"threshold"`            // 触发告警的阈值
+}
+
 type DataASyncExploreResult struct {
 	Status string `json:"msg"`     // 执行状态
 	TaskId string `json:"task_id"` // 任务编号
@@ -25,6 +25,16 @@ type MetaDataTableInfo struct {
 	VeCatalogId     string                `json:"ve_catalog_id" binding:"omitempty"`     // ve_catalog_id
 	Description     string                `json:"description" binding:"omitempty"`       // description
 	Columns         map[string]ColumnInfo `json:"columns" binding:"omitempty"`           // columns
+	Window          *IncrementalWindow    `json:"-"`                                     // 增量探查范围，为空时探查全表
+}
+
+// IncrementalWindow 增量探查范围，只探查业务时间在 (From, To] 内的数据
+type IncrementalWindow struct {
+	Column       string            // 业务时间字段名称
+	DataType     string            // 业务时间字段类型
+	From         string            // 上次探查的业务时间水位
+	To           string            // 本次探查的业务时间水位
+	BaseSketches map[string]string // 上次报告中重复值检查的基数估计草图，key为 规则id_字段名称
 }
 
 type ColumnInfo struct {
@@ -29,23 +29,39 @@ type TaskConfigDeleteReq struct {
 }
 
 type TaskConfigReq struct {
-	TaskName        string          `json:"task_name" binding:"TrimSpace,min=1,max=255,VerifyDescription" example:"1"`           // 探查任务配置名称
-	TaskDesc        string          `json:"task_desc" binding:"TrimSpace,min=0,max=255,VerifyDescription" example:"1"`           // 探查描述
-	TableId         string          `json:"table_id" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"`   // 数据源表ID
-	Table           string          `json:"table" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"`      // 表名称
-	Schema          string          `json:"schema" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"`     // 数据库名
-	VeCatalog       string          `json:"ve_catalog" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"` // 数据源编
-	FieldExplore    []*ExploreField `json:"field_explore" binding:"omitempty,dive"`                                              // 字段探查参数
-	MetadataExplore []*Projects     `json:"metadata_explore" binding:"omitempty"`                                                // 元数据级探查项目
-	RowExplore      []*Projects     `json:"row_explore" binding:"omitempty"`                                                     // 行级级探查项目
-	ViewExplore     []*Projects     `json:"view_explore" binding:"omitempty"`                                                    // 视图级探查项目
-	ExploreType     int32           `json:"explore_type" binding:"required,TrimSpace,oneof=1 2"`                                 // 探查类型,1 探查数据,2 探查时间戳
-	TotalSample     int32           `json:"total_sample" binding:"TrimSpace,min=0"`                                              // 探查样本总数，全量探查时该参数无效
-	TaskEnabled     int32           `json:"task_enabled" binding:"required,TrimSpace,oneof=0 1"`                                 // 探查配置启用禁用状态，0禁用，1启用
-	UserId          string          `json:"user_id" binding:"omitempty,uuid"`                                                    // 用户id
-	UserName        string          `json:"user_name" binding:"omitempty"`                                                       // 用户名
-	DvTaskId        string          `json:"dv_task_id" binding:"required,uuid"`                                                  // data-view任务id
-	FieldInfo       string          `json:"field_info"`
+	TaskName         string              `json:"task_name" binding:"TrimSpace,min=1,max=255,VerifyDescription" example:"1"`           // 探查任务配置名称
+	TaskDesc         string              `json:"task_desc" binding:"TrimSpace,min=0,max=255,VerifyDescription" example:"1"`           // 探查描述
+	TableId          string              `json:"table_id" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"`   // 数据源表ID
+	Table            string              `json:"table" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"`      // 表名称
+	Schema           string              `json:"schema" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"`     // 数据库名
+	VeCatalog        string              `json:"ve_catalog" binding:"required,TrimSpace,min=1,max=255,VerifyDescription" example:"1"` // 数据源编
+	FieldExplore     []*ExploreField     `json:"field_explore" binding:"omitempty,dive"`                                              // 字段探查参数
+	MetadataExplore  []*Projects         `json:"metadata_explore" binding:"omitempty"`                                                // 元数据级探查项目
+	RowExplore       []*Projects         `json:"row_explore" binding:"omitempty"`                                                     // 行级级探查项目
+	ViewExplore      []*Projects         `json:"view_explore" binding:"omitempty"`                                                    // 视图级探查项目
+	ExploreType      int32               `json:"explore_type" binding:"required,TrimSpace,oneof=1 2"`                                 // 探查类型,1 探查数据,2 探查时间戳
+	TotalSample      int32               `json:"total_sample" binding:"TrimSpace,min=0"`                                              // 探查样本总数，全量探查时该参数无效
+	TaskEnabled      int32               `json:"task_enabled" binding:"required,TrimSpace,oneof=0 1"`                                 // 探查配置启用禁用状态，0禁用，1启用
+	UserId           string              `json:"user_id" binding:"omitempty,uuid"`                                                    // 用户id
+	UserName         string              `json:"user_name" binding:"omitempty"`                                                       // 用户名
+	DvTaskId         string              `json:"dv_task_id" binding:"required,uuid"`                                                  // data-view任务id
+	FieldInfo        string              `json:"field_info"`
+	Incremental      bool                `json:"incremental"`                            // 是否增量探查，只在全量探查时生效
+	TimestampFieldId string              `json:"timestamp_field_id" binding:"omitempty"` // 增量探查使用的业务时间字段id
+	QualityAlert     *QualityAlertConfig `json:"quality_alert" binding:"omitempty"`      // 质量异常告警配置，缺省使用默认配置
+}
+
+// QualityAlertConfig 质量异常告警配置，数值为0时使用默认值
+type QualityAlertConfig struct {
+	Disabled          bool     `json:"disabled"`                                            // 是否关闭质量异常告警
+	MinScore          float64  `json:"min_score" binding:"omitempty,min=0,max=1"`           // 维度评分下限，低于下限时告警，为0时不检查
+	MaxScoreDrop      float64  `json:"max_score_drop" binding:"omitempty,min=0,max=1"`      // 维度评分相比上次探查的最大降幅，默认0.1
+	MaxNullRatioRise  float64  `json:"max_null_ratio_rise" binding:"omitempty,min=0,max=1"` // 字段空值率相比上次探查的最大升幅，默认0.1
+	MaxRowCountChange float64  `json:"max_row_count_change" binding:"omitempty,min=0"`      // 总行数相比上次探查的最大变化比例，默认0.5
+	MaxDistinctChange float64  `json:"max_distinct_change" binding:"omitempty,min=0"`       // 字段去重数量相比上次探查的最大变化比例，默认0.5
+	DriftThreshold    float64  `json:"drift_threshold" binding:"omitempty,min=0"`           // 偏离历史均值的标准差倍数，超过时视为统计漂移，默认3
+	HistorySize       int      `json:"history_size" binding:"omitempty,min=0,max=100"`      // 统计漂移参考的历史报告数量，默认10
+	RecipientIds      []string `json:"recipient_ids" binding:"omitempty,dive,uuid"`         // 告警接收人id，缺省为探查任务的创建人
 }
 
 type ThirdPartyTaskConfigReq struct {
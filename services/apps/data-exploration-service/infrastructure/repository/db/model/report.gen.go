@@ -45,6 +45,9 @@ type Report struct {
 	TotalAccuracy        *float64   `gorm:"column:f_total_accuracy;comment:准确性总分" json:"f_total_accuracy"`               // 准确性总分
 	TotalConsistency     *float64   `gorm:"column:f_total_consistency;comment:一致性总分" json:"f_total_consistency"`         // 一致性总分
 	DeletedAt            *time.Time `gorm:"column:f_deleted_at;comment:删除时间" json:"deleted_at"`                          // 删除时间
+	Incremental          int32      `gorm:"column:f_incremental;not null;comment:是否增量探查" json:"incremental"`             // 是否增量探查
+	BaseCode             *string    `gorm:"column:f_base_code;comment:增量探查合并的报告编号" json:"base_code"`                     // 增量探查合并的报告编号
+	Watermark            *string    `gorm:"column:f_watermark;comment:业务时间水位" json:"watermark"`                          // 业务时间水位
 }
 
 func (m *Report) UniqueKey() string {
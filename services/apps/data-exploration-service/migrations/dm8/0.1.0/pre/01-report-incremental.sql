@@ -0,0 +1,6 @@
+SET SCHEMA af_data_exploration;
+
+-- 为探查报告记录表(t_report)添加增量探查字段
+ALTER TABLE "t_report" ADD COLUMN IF NOT EXISTS "f_incremental" TINYINT NOT NULL DEFAULT 0;
+ALTER TABLE "t_report" ADD COLUMN IF NOT EXISTS "f_base_code" VARCHAR(256 char) DEFAULT NULL;
+ALTER TABLE "t_report" ADD COLUMN IF NOT EXISTS "f_watermark" VARCHAR(64 char) DEFAULT NULL;
@@ -63,6 +63,9 @@ CREATE TABLE if not exists "t_report" (
     "f_total_accuracy" FLOAT DEFAULT NULL,
     "f_total_consistency" FLOAT DEFAULT NULL,
     "f_deleted_at" datetime(3) DEFAULT NULL,
+    "f_incremental" TINYINT NOT NULL DEFAULT 0,
+    "f_base_code" VARCHAR(256 char) DEFAULT NULL,
+    "f_watermark" VARCHAR(64 char) DEFAULT NULL,
     CLUSTER PRIMARY KEY ("f_id")
     );
 
@@ -0,0 +1,6 @@
+USE af_data_exploration;
+
+-- 为探查报告记录表(t_report)添加增量探查字段
+ALTER TABLE `t_report` ADD COLUMN IF NOT EXISTS `f_incremental` TINYINT(2) NOT NULL DEFAULT 0 COMMENT '是否增量探查' AFTER `f_deleted_at`;
+ALTER TABLE `t_report` ADD COLUMN IF NOT EXISTS `f_base_code` varchar(256) DEFAULT NULL COMMENT '增量探查合并的报告编号' AFTER `f_incremental`;
+ALTER TABLE `t_report` ADD COLUMN IF NOT EXISTS `f_watermark` varchar(64) DEFAULT NULL COMMENT '业务时间水位' AFTER `f_base_code`;
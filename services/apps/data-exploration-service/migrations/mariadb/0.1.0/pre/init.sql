@@ -60,6 +60,9 @@ create table if not exists `t_report` (
     `f_total_accuracy` float(10,4) DEFAULT NULL COMMENT '准确性总分',
     `f_total_consistency` float(10,4) DEFAULT NULL COMMENT '一致性总分',
     `f_deleted_at` datetime(3) DEFAULT NULL COMMENT '删除时间',
+    `f_incremental` TINYINT(2) NOT NULL DEFAULT 0 COMMENT '是否增量探查',
+    `f_base_code` varchar(256) DEFAULT NULL COMMENT '增量探查合并的报告编号',
+    `f_watermark` varchar(64) DEFAULT NULL COMMENT '业务时间水位',
     KEY `idx_report_task_id`(`f_task_id`),
     KEY `idx_report_code`(`f_code`),
     PRIMARY KEY (`f_id`)
@@ -0,0 +1,28 @@
+package callbacks
+
+import (
+	"context"
+
+	kafka_pub "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/mq/kafka"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLease 发件箱发送任务的租约名称，多副本部署时只有一个实例发送
+const outboxRelayLease = "data-view"
+
+// NewOutboxPublishers 发件箱中各主题消息的发送方式
+func NewOutboxPublishers(sender kafka_pub.KafkaPub) outbox.Publishers {
+	return outbox.Publishers{
+		// 实体变更消息
+		constant.EntityChangeTopic: func(ctx context.Context, msg *outbox.Message) error {
+			return sender.SyncProduce(constant.EntityChangeTopic, nil, []byte(msg.Payload))
+		},
+	}
+}
+
+// NewOutboxRelayServer 发送发件箱消息的后台服务
+func NewOutboxRelayServer(o outbox.Outbox, db *gorm.DB) *outbox.RelayServer {
+	return outbox.NewRelayServer(o, db, outboxRelayLease)
+}
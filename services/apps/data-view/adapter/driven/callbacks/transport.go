@@ -2,7 +2,10 @@ package callbacks
 
 import (
 	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/database_callback/callback"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -26,6 +29,10 @@ func NewTransport(
 
 // Register 注册
 func (t *Transports) Register() {
+	// 实体变更消息在触发回调的事务中写入发件箱
+	if err := outbox.RegisterTxCallbacks(t.db); err != nil {
+		log.Error("Transports Register outbox callbacks", zap.Error(err))
+	}
 	callback.Init(t.db)
 	//血缘注册
 	callback.RegisterByTransport(t.dataLineageCallback, callback.LineageTransportName, new(model.FormView))
@@ -0,0 +1,35 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
+)
+
+// deliverTimeout 单个文件投递的超时时间
+const deliverTimeout = 30 * time.Minute
+
+type deliverer struct {
+	transport http.RoundTripper
+}
+
+func NewDeliverer() delivery.Deliverer {
+	// 上传的文件可能较大，不使用带有统一超时时间的 http client
+	return &deliverer{transport: http.DefaultTransport}
+}
+
+func (d *deliverer) Deliver(ctx context.Context, target *delivery.Target, name string, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, deliverTimeout)
+	defer cancel()
+	switch {
+	case target.Type == delivery.TargetTypeSFTP && target.SFTP != nil:
+		return uploadSFTP(ctx, target.SFTP, name, path)
+	case target.Type == delivery.TargetTypeS3 && target.S3 != nil:
+		return uploadS3(ctx, d.transport, target.S3, name, path)
+	default:
+		return fmt.Errorf("invalid delivery target %s", target.Type)
+	}
+}
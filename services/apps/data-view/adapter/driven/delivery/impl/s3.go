@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
+)
+
+const s3DefaultRegion = "us-east-1"
+
+// s3PartSize 分片上传的分片大小，超过分片大小的文件使用分片上传
+var s3PartSize uint64 = 64 << 20
+
+// uploadS3 上传文件，大文件按 s3PartSize 分片上传
+func uploadS3(ctx context.Context, transport http.RoundTripper, conf *delivery.S3Config, name, path string) error {
+	client, err := newS3Client(transport, conf)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	key := s3ObjectKey(conf.Prefix, name)
+	if _, err = client.PutObject(ctx, conf.Bucket, key, file, info.Size(), minio.PutObjectOptions{PartSize: s3PartSize}); err != nil {
+		return fmt.Errorf("put object %s failed: %w", key, err)
+	}
+	return nil
+}
+
+// newS3Client 默认使用虚拟主机风格 bucket.endpoint/key 访问，PathStyle 为 true 时使用 endpoint/bucket/key
+func newS3Client(transport http.RoundTripper, conf *delivery.S3Config) (*minio.Client, error) {
+	u, err := url.Parse(strings.TrimRight(conf.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %s", conf.Endpoint)
+	}
+	region := conf.Region
+	if region == "" {
+		region = s3DefaultRegion
+	}
+	lookup := minio.BucketLookupDNS
+	if conf.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	return minio.New(u.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(conf.AccessKey, conf.SecretKey, ""),
+		Secure:       u.Scheme == "https",
+		Transport:    transport,
+		Region:       region,
+		BucketLookup: lookup,
+	})
+}
+
+func s3ObjectKey(prefix, name string) string {
+	if prefix = strings.Trim(prefix, "/"); prefix == "" {
+		return name
+	}
+	return prefix + "/" + name
+}
@@ -0,0 +1,125 @@
+package impl
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
+)
+
+// fakeS3Server 在内存中保存对象，只处理上传用到的请求
+type fakeS3Server struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+	// parts 分片上传中的分片，按 uploadId 和分片序号保存
+	parts map[string]map[int][]byte
+	// uploads 完成的分片上传的分片数
+	uploads map[string]int
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	query := r.URL.Query()
+	key := r.URL.Path
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		uploadID := fmt.Sprintf("upload-%d", len(s.parts)+1)
+		s.parts[uploadID] = map[int][]byte{}
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>exports</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, key, uploadID)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		body, _ := io.ReadAll(r.Body)
+		s.parts[query.Get("uploadId")][number] = body
+		w.Header().Set("ETag", etag(body))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		uploadID := query.Get("uploadId")
+		var complete struct {
+			Parts []struct {
+				PartNumber int
+			} `xml:"Part"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&complete); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var numbers []int
+		for _, part := range complete.Parts {
+			numbers = append(numbers, part.PartNumber)
+		}
+		sort.Ints(numbers)
+		object := new(bytes.Buffer)
+		for _, number := range numbers {
+			object.Write(s.parts[uploadID][number])
+		}
+		s.objects[key] = object.Bytes()
+		s.uploads[key] = len(numbers)
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>exports</Bucket><Key>%s</Key><ETag>%s</ETag></CompleteMultipartUploadResult>`, key, etag(object.Bytes()))
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.objects[key] = body
+		w.Header().Set("ETag", etag(body))
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func TestUploadS3(t *testing.T) {
+	s := &fakeS3Server{objects: map[string][]byte{}, parts: map[string]map[int][]byte{}, uploads: map[string]int{}}
+	server := httptest.NewTLSServer(s)
+	defer server.Close()
+
+	partSize := s3PartSize
+	s3PartSize = 5 << 20
+	defer func() { s3PartSize = partSize }()
+
+	conf := &delivery.S3Config{Endpoint: server.URL + "/", Bucket: "exports", AccessKey: "ak", SecretKey: "sk", Prefix: "/daily/", PathStyle: true}
+	dir := t.TempDir()
+
+	// 超过分片大小的文件分片上传
+	large := make([]byte, 11<<20)
+	for i := range large {
+		large[i] = byte(i % 251)
+	}
+	largePath := filepath.Join(dir, "large.csv")
+	if !assert.NoError(t, os.WriteFile(largePath, large, 0o600)) {
+		return
+	}
+	if assert.NoError(t, uploadS3(t.Context(), server.Client().Transport, conf, "订单 2024.csv", largePath)) {
+		assert.Equal(t, 3, s.uploads["/exports/daily/订单 2024.csv"])
+		assert.True(t, bytes.Equal(large, s.objects["/exports/daily/订单 2024.csv"]))
+	}
+
+	smallPath := filepath.Join(dir, "small.csv")
+	if !assert.NoError(t, os.WriteFile(smallPath, []byte("id\n1\n"), 0o600)) {
+		return
+	}
+	conf.Prefix = ""
+	if assert.NoError(t, uploadS3(t.Context(), server.Client().Transport, conf, "a+b.csv", smallPath)) {
+		assert.Equal(t, []byte("id\n1\n"), s.objects["/exports/a+b.csv"])
+	}
+}
+
+func TestS3ObjectKey(t *testing.T) {
+	assert.Equal(t, "daily/a.csv", s3ObjectKey("/daily/", "a.csv"))
+	assert.Equal(t, "a.csv", s3ObjectKey("", "a.csv"))
+}
@@ -0,0 +1,138 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
+)
+
+const (
+	sftpDefaultPort = 22
+	sftpDialTimeout = 30 * time.Second
+	// sftpPartSuffix 上传过程中使用的临时文件后缀，上传完成后重命名
+	sftpPartSuffix = ".part"
+	// sftpPosixRename 支持覆盖已有文件的重命名扩展
+	sftpPosixRename = "posix-rename@openssh.com"
+)
+
+// uploadSFTP 通过 ssh 连接的 sftp 子系统上传文件
+func uploadSFTP(ctx context.Context, conf *delivery.SFTPConfig, name, localPath string) error {
+	config, err := sshClientConfig(conf)
+	if err != nil {
+		return err
+	}
+	port := conf.Port
+	if port == 0 {
+		port = sftpDefaultPort
+	}
+	addr := net.JoinHostPort(conf.Host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: sftpDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	// 超时或者取消时关闭连接，中断正在进行的读写
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+	sc, err := sftp.NewClient(client, sftp.UseConcurrentWrites(true))
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+	return sftpUpload(sc, conf.Directory, name, localPath)
+}
+
+func sshClientConfig(conf *delivery.SFTPConfig) (*ssh.ClientConfig, error) {
+	auths := make([]ssh.AuthMethod, 0, 2)
+	if conf.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(conf.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("parse private key: %w", err)
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if conf.Password != "" {
+		auths = append(auths, ssh.Password(conf.Password))
+	}
+	if len(auths) == 0 {
+		return nil, errors.New("sftp password or private key is required")
+	}
+	// 必须校验服务端身份，避免将文件上传到被冒充的服务器
+	if conf.HostKeyFingerprint == "" {
+		return nil, errors.New("sftp host key fingerprint is required")
+	}
+	hostKeyCallback := func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if fingerprint := ssh.FingerprintSHA256(key); fingerprint != conf.HostKeyFingerprint {
+			return fmt.Errorf("host key fingerprint mismatch: %s", fingerprint)
+		}
+		return nil
+	}
+	return &ssh.ClientConfig{
+		User:            conf.Username,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sftpDialTimeout,
+	}, nil
+}
+
+// sftpUpload 先写入临时文件，完成后重命名为 name，避免接收方读到不完整的文件
+func sftpUpload(c *sftp.Client, dir, name, localPath string) error {
+	dir = strings.TrimRight(dir, "/")
+	remote := name
+	if dir != "" {
+		if err := c.MkdirAll(dir); err != nil {
+			return fmt.Errorf("mkdir %s: %w", dir, err)
+		}
+		remote = path.Join(dir, name)
+	}
+	tmp := remote + sftpPartSuffix
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	dst, err := c.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", tmp, err)
+	}
+	// ReadFrom 并发发送多个 WRITE 请求
+	if _, err = dst.ReadFrom(file); err != nil {
+		dst.Close()
+		c.Remove(tmp)
+		return fmt.Errorf("write %s: %w", tmp, err)
+	}
+	if err = dst.Close(); err != nil {
+		c.Remove(tmp)
+		return fmt.Errorf("close %s: %w", tmp, err)
+	}
+
+	if _, ok := c.HasExtension(sftpPosixRename); ok {
+		return c.PosixRename(tmp, remote)
+	}
+	// 第3版协议的 RENAME 不会覆盖已经存在的文件
+	if err = c.Remove(remote); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", remote, err)
+	}
+	return c.Rename(tmp, remote)
+}
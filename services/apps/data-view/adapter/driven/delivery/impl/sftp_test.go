@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestSFTPClient 连接到在内存中保存文件的 sftp 服务端
+func newTestSFTPClient(t *testing.T) *sftp.Client {
+	serverR, clientW := io.Pipe()
+	clientR, serverW := io.Pipe()
+	server := sftp.NewRequestServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverR, serverW}, sftp.InMemHandler())
+	go server.Serve()
+
+	client, err := sftp.NewClientPipe(clientR, clientW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 先关闭服务端，客户端读到 EOF 后才能关闭
+	t.Cleanup(func() {
+		server.Close()
+		client.Close()
+	})
+	return client
+}
+
+func TestSFTPUpload(t *testing.T) {
+	client := newTestSFTPClient(t)
+
+	// 超过单个 WRITE 请求长度的文件分多次写入
+	content := make([]byte, 100*1024+7)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	local := filepath.Join(t.TempDir(), "data.csv")
+	if !assert.NoError(t, os.WriteFile(local, content, 0o600)) {
+		return
+	}
+
+	// 上传目录不存在时自动创建
+	if !assert.NoError(t, sftpUpload(client, "/upload/daily/", "data.csv", local)) {
+		return
+	}
+	assert.Equal(t, content, readSFTPFile(t, client, "/upload/daily/data.csv"))
+	_, err := client.Stat("/upload/daily/data.csv" + sftpPartSuffix)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// 覆盖已经存在的文件
+	if !assert.NoError(t, os.WriteFile(local, []byte("id\n1\n"), 0o600)) {
+		return
+	}
+	if assert.NoError(t, sftpUpload(client, "/upload/daily", "data.csv", local)) {
+		assert.Equal(t, []byte("id\n1\n"), readSFTPFile(t, client, "/upload/daily/data.csv"))
+	}
+}
+
+func readSFTPFile(t *testing.T, client *sftp.Client, name string) []byte {
+	f, err := client.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"context"
+)
+
+const (
+	TargetTypeSFTP = "sftp"
+	TargetTypeS3   = "s3"
+
+	// MaskedSecret 返回给前端的密码、密钥的掩码，更新时传回掩码表示不修改
+	MaskedSecret = "******"
+)
+
+// Deliverer 将生成的下载文件投递到外部存储
+type Deliverer interface {
+	// Deliver 将本地文件 path 上传到投递目标，name 为目标目录下的文件名
+	Deliver(ctx context.Context, target *Target, name string, path string) error
+}
+
+// Target 投递目标，按 Type 使用对应的配置
+type Target struct {
+	Type string      `json:"type" binding:"required,oneof=sftp s3" example:"sftp"` // 投递目标类型，sftp 或 s3
+	SFTP *SFTPConfig `json:"sftp,omitempty" binding:"required_if=Type sftp,omitempty"`
+	S3   *S3Config   `json:"s3,omitempty" binding:"required_if=Type s3,omitempty"`
+}
+
+// SFTPConfig SFTP 服务器配置，密码和私钥至少填写一个，必须填写主机公钥指纹
+type SFTPConfig struct {
+	Host               string `json:"host" binding:"required"`                         // 主机地址
+	Port               int    `json:"port" binding:"omitempty,min=1,max=65535"`        // 端口，默认22
+	Username           string `json:"username" binding:"required"`                     // 用户名
+	Password           string `json:"password,omitempty"`                              // 密码
+	PrivateKey         string `json:"private_key,omitempty"`                           // PEM 格式的私钥
+	HostKeyFingerprint string `json:"host_key_fingerprint" binding:"required"`         // 主机公钥的 SHA256 指纹，如 SHA256:xxx，用于校验服务端身份
+	Directory          string `json:"directory,omitempty" binding:"omitempty,max=255"` // 上传目录，不存在时自动创建
+}
+
+// S3Config S3 兼容对象存储配置
+type S3Config struct {
+	Endpoint  string `json:"endpoint" binding:"required,url"`              // 服务地址，如 https://s3.amazonaws.com
+	Region    string `json:"region,omitempty"`                             // 区域，默认 us-east-1
+	Bucket    string `json:"bucket" binding:"required"`                    // 桶名称
+	AccessKey string `json:"access_key" binding:"required"`                // 访问密钥ID
+	SecretKey string `json:"secret_key,omitempty"`                         // 访问密钥
+	Prefix    string `json:"prefix,omitempty" binding:"omitempty,max=255"` // 对象名称前缀
+	PathStyle bool   `json:"path_style,omitempty"`                         // 使用路径风格访问，MinIO 等需要开启
+}
+
+// Masked 返回将密码、私钥、访问密钥替换为掩码后的副本，用于接口返回
+func (t *Target) Masked() *Target {
+	if t == nil {
+		return nil
+	}
+	masked := &Target{Type: t.Type}
+	if t.SFTP != nil {
+		sftp := *t.SFTP
+		sftp.Password = maskSecret(sftp.Password)
+		sftp.PrivateKey = maskSecret(sftp.PrivateKey)
+		masked.SFTP = &sftp
+	}
+	if t.S3 != nil {
+		s3 := *t.S3
+		s3.SecretKey = maskSecret(s3.SecretKey)
+		masked.S3 = &s3
+	}
+	return masked
+}
+
+// KeepSecrets 密码、私钥、访问密钥为空或者为掩码时沿用 old 中的值
+func (t *Target) KeepSecrets(old *Target) {
+	if old == nil || old.Type != t.Type {
+		return
+	}
+	if t.SFTP != nil && old.SFTP != nil {
+		t.SFTP.Password = keepSecret(t.SFTP.Password, old.SFTP.Password)
+		t.SFTP.PrivateKey = keepSecret(t.SFTP.PrivateKey, old.SFTP.PrivateKey)
+	}
+	if t.S3 != nil && old.S3 != nil {
+		t.S3.SecretKey = keepSecret(t.S3.SecretKey, old.S3.SecretKey)
+	}
+}
+
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return MaskedSecret
+}
+
+func keepSecret(secret, old string) string {
+	if secret == "" || secret == MaskedSecret {
+		return old
+	}
+	return secret
+}
@@ -0,0 +1,139 @@
+package delivery
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+)
+
+const (
+	// SecretKeyEnv 加密保存密码、私钥、访问密钥使用的密钥的环境变量
+	SecretKeyEnv = "DELIVERY_SECRET_KEY"
+
+	// encryptedPrefix 加密后的值的前缀，没有前缀的值是加密保存之前写入的明文
+	encryptedPrefix = "enc:"
+)
+
+// Encrypted 返回将密码、私钥、访问密钥加密后的副本，用于保存投递目标
+func (t *Target) Encrypted() (*Target, error) {
+	key, err := secretKey()
+	if err != nil {
+		return nil, err
+	}
+	return t.encrypted(key)
+}
+
+// DecryptSecrets 解密保存的密码、私钥、访问密钥
+func (t *Target) DecryptSecrets() error {
+	key, err := secretKey()
+	if err != nil {
+		return err
+	}
+	return t.decryptSecrets(key)
+}
+
+func (t *Target) encrypted(key []byte) (*Target, error) {
+	if t == nil {
+		return nil, nil
+	}
+	res := &Target{Type: t.Type}
+	var err error
+	if t.SFTP != nil {
+		sftp := *t.SFTP
+		if sftp.Password, err = encryptSecret(key, sftp.Password); err != nil {
+			return nil, err
+		}
+		if sftp.PrivateKey, err = encryptSecret(key, sftp.PrivateKey); err != nil {
+			return nil, err
+		}
+		res.SFTP = &sftp
+	}
+	if t.S3 != nil {
+		s3 := *t.S3
+		if s3.SecretKey, err = encryptSecret(key, s3.SecretKey); err != nil {
+			return nil, err
+		}
+		res.S3 = &s3
+	}
+	return res, nil
+}
+
+func (t *Target) decryptSecrets(key []byte) (err error) {
+	if t.SFTP != nil {
+		if t.SFTP.Password, err = decryptSecret(key, t.SFTP.Password); err != nil {
+			return err
+		}
+		if t.SFTP.PrivateKey, err = decryptSecret(key, t.SFTP.PrivateKey); err != nil {
+			return err
+		}
+	}
+	if t.S3 != nil {
+		if t.S3.SecretKey, err = decryptSecret(key, t.S3.SecretKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretKey 由环境变量中的密钥生成 AES-256 密钥
+func secretKey() ([]byte, error) {
+	secret := os.Getenv(SecretKeyEnv)
+	if secret == "" {
+		return nil, errors.New("need to set $" + SecretKeyEnv)
+	}
+	key := sha256.Sum256([]byte(secret))
+	return key[:], nil
+}
+
+// encryptSecret 使用 AES-GCM 加密，结果为 enc: 前缀加 base64 编码的随机数和密文
+func encryptSecret(key []byte, secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptSecret(key []byte, secret string) (string, error) {
+	encoded, ok := strings.CutPrefix(secret, encryptedPrefix)
+	if !ok {
+		return secret, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted secret")
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,48 @@
+package delivery
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetEncrypted(t *testing.T) {
+	t.Setenv(SecretKeyEnv, "test-key")
+	target := &Target{
+		Type: TargetTypeSFTP,
+		SFTP: &SFTPConfig{Host: "127.0.0.1", Username: "u", Password: "secret", HostKeyFingerprint: "SHA256:abc"},
+	}
+	encrypted, err := target.Encrypted()
+	if !assert.NoError(t, err) {
+		return
+	}
+	// 不修改原配置，保存的配置中没有明文
+	assert.Equal(t, "secret", target.SFTP.Password)
+	assert.True(t, strings.HasPrefix(encrypted.SFTP.Password, encryptedPrefix))
+	assert.Empty(t, encrypted.SFTP.PrivateKey)
+	data, _ := json.Marshal(encrypted)
+	assert.NotContains(t, string(data), "secret")
+
+	saved := &Target{}
+	if !assert.NoError(t, json.Unmarshal(data, saved)) || !assert.NoError(t, saved.DecryptSecrets()) {
+		return
+	}
+	assert.Equal(t, target, saved)
+	assert.Equal(t, MaskedSecret, encrypted.Masked().SFTP.Password)
+
+	// 加密保存之前写入的明文原样读取
+	plain := &Target{Type: TargetTypeS3, S3: &S3Config{Bucket: "b", AccessKey: "ak", SecretKey: "sk"}}
+	if assert.NoError(t, plain.DecryptSecrets()) {
+		assert.Equal(t, "sk", plain.S3.SecretKey)
+	}
+
+	// 密钥变化后无法解密
+	t.Setenv(SecretKeyEnv, "other-key")
+	assert.Error(t, encrypted.DecryptSecrets())
+
+	t.Setenv(SecretKeyEnv, "")
+	_, err = target.Encrypted()
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_download_subscription"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+func NewDataDownloadSubscriptionRepo(db *gorm.DB) data_download_subscription.DataDownloadSubscriptionRepo {
+	return &dataDownloadSubscriptionRepo{db: db}
+}
+
+type dataDownloadSubscriptionRepo struct {
+	db *gorm.DB
+}
+
+func (r *dataDownloadSubscriptionRepo) Create(ctx context.Context, m *model.TDataDownloadSubscription) error {
+	return r.db.WithContext(ctx).Create(m).Error
+}
+
+func (r *dataDownloadSubscriptionRepo) Update(ctx context.Context, m *model.TDataDownloadSubscription) error {
+	return r.db.WithContext(ctx).Where("id = ?", m.ID).Save(m).Error
+}
+
+// UpdateSchedule 只更新执行时间和状态，不覆盖执行期间对订阅的修改
+func (r *dataDownloadSubscriptionRepo) UpdateSchedule(ctx context.Context, id uint64, lastRunAt, nextRunAt *time.Time, status int) (bool, error) {
+	tx := r.db.WithContext(ctx).Model(&model.TDataDownloadSubscription{}).
+		Where("id = ? and status = ?", id, form_view.SUBSCRIPTION_STATUS_ENABLED).
+		UpdateColumns(map[string]any{
+			"last_run_at": lastRunAt,
+			"next_run_at": nextRunAt,
+			"status":      status,
+		})
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// UpdateWatermark 只更新增量水位，订阅不存在时不会重新插入
+func (r *dataDownloadSubscriptionRepo) UpdateWatermark(ctx context.Context, id uint64, watermark *time.Time) (bool, error) {
+	tx := r.db.WithContext(ctx).Model(&model.TDataDownloadSubscription{}).
+		Where("id = ?", id).
+		UpdateColumn("watermark", watermark)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+func (r *dataDownloadSubscriptionRepo) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&model.TDataDownloadSubscriptionRun{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.TDataDownloadSubscription{}).Error
+	})
+}
+
+func (r *dataDownloadSubscriptionRepo) Get(ctx context.Context, id uint64) (*model.TDataDownloadSubscription, error) {
+	subscription := &model.TDataDownloadSubscription{}
+	err := r.db.WithContext(ctx).Where("id = ?", id).Take(subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (r *dataDownloadSubscriptionRepo) List(ctx context.Context, opts *data_download_subscription.ListOptions) (totalCount int64, subscriptions []*model.TDataDownloadSubscription, err error) {
+	d := r.db.WithContext(ctx).Model(&model.TDataDownloadSubscription{})
+	if opts.CreatedBy != "" {
+		d = d.Where("created_by = ?", opts.CreatedBy)
+	}
+	if opts.Keyword != "" {
+		d = d.Where("name LIKE ?", "%"+opts.Keyword+"%")
+	}
+	if err = d.Count(&totalCount).Error; err != nil {
+		return
+	}
+	if opts.Offset > 0 && opts.Limit > 0 {
+		d = d.Offset((opts.Offset - 1) * opts.Limit).Limit(opts.Limit)
+	}
+	err = d.Order("created_at desc").Find(&subscriptions).Error
+	return
+}
+
+func (r *dataDownloadSubscriptionRepo) ListDue(ctx context.Context, now time.Time, limit int) (subscriptions []*model.TDataDownloadSubscription, err error) {
+	err = r.db.WithContext(ctx).
+		Where("status = ? and next_run_at <= ?", form_view.SUBSCRIPTION_STATUS_ENABLED, now).
+		Order("next_run_at asc").
+		Limit(limit).
+		Find(&subscriptions).Error
+	return
+}
+
+func (r *dataDownloadSubscriptionRepo) CreateRun(ctx context.Context, run *model.TDataDownloadSubscriptionRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// UpdateRun 更新执行结果，订阅在执行期间被删除时执行记录也已删除，不会重新插入
+func (r *dataDownloadSubscriptionRepo) UpdateRun(ctx context.Context, run *model.TDataDownloadSubscriptionRun) error {
+	return r.db.WithContext(ctx).Model(&model.TDataDownloadSubscriptionRun{}).
+		Where("id = ?", run.ID).
+		UpdateColumns(map[string]any{
+			"status":      run.Status,
+			"row_count":   run.RowCount,
+			"file_name":   run.FileName,
+			"remark":      run.Remark,
+			"finished_at": run.FinishedAt,
+		}).Error
+}
+
+func (r *dataDownloadSubscriptionRepo) ListRuns(ctx context.Context, subscriptionID uint64, offset, limit int) (totalCount int64, runs []*model.TDataDownloadSubscriptionRun, err error) {
+	d := r.db.WithContext(ctx).Model(&model.TDataDownloadSubscriptionRun{}).Where("subscription_id = ?", subscriptionID)
+	if err = d.Count(&totalCount).Error; err != nil {
+		return
+	}
+	if offset > 0 && limit > 0 {
+		d = d.Offset((offset - 1) * limit).Limit(limit)
+	}
+	err = d.Order("started_at desc").Find(&runs).Error
+	return
+}
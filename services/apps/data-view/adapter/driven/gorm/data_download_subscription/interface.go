@@ -0,0 +1,38 @@
+package data_download_subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+)
+
+type ListOptions struct {
+	CreatedBy string // 创建人id
+	Keyword   string // 按订阅名称模糊搜索
+	Offset    int    // 页码，从1开始
+	Limit     int    // 每页大小
+}
+
+type DataDownloadSubscriptionRepo interface {
+	Create(ctx context.Context, m *model.TDataDownloadSubscription) error
+	Update(ctx context.Context, m *model.TDataDownloadSubscription) error
+	// UpdateSchedule 记录执行时间并设置下次执行时间和状态，只更新仍启用的订阅。
+	// 返回 false 表示订阅已被删除或停用，不需要执行
+	UpdateSchedule(ctx context.Context, id uint64, lastRunAt, nextRunAt *time.Time, status int) (bool, error)
+	// UpdateWatermark 推进增量水位，订阅已被删除时返回 false
+	UpdateWatermark(ctx context.Context, id uint64, watermark *time.Time) (bool, error)
+	// Delete 删除订阅及其执行记录
+	Delete(ctx context.Context, id uint64) error
+	// Get 获取订阅，不存在时返回 nil
+	Get(ctx context.Context, id uint64) (*model.TDataDownloadSubscription, error)
+	// List 获取订阅列表，按创建时间倒序
+	List(ctx context.Context, opts *ListOptions) (int64, []*model.TDataDownloadSubscription, error)
+	// ListDue 获取已启用且下次执行时间不晚于 now 的订阅，按下次执行时间正序
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*model.TDataDownloadSubscription, error)
+
+	CreateRun(ctx context.Context, run *model.TDataDownloadSubscriptionRun) error
+	UpdateRun(ctx context.Context, run *model.TDataDownloadSubscriptionRun) error
+	// ListRuns 获取订阅的执行记录，按开始时间倒序
+	ListRuns(ctx context.Context, subscriptionID uint64, offset, limit int) (int64, []*model.TDataDownloadSubscriptionRun, error)
+}
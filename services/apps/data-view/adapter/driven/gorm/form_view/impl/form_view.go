@@ -711,6 +711,10 @@ func (r *formViewRepo) DeleteCustomOrLogicEntityViewTransaction(ctx context.Cont
 			Where("form_view_id =?", id).Delete(&model.FormViewField{}).Error; err != nil {
 			return err
 		}
+		if err := r.db.WithContext(ctx).Table(model.TableNameLineageField).
+			Where("form_view_id =?", id).Delete(&model.LineageField{}).Error; err != nil {
+			return err
+		}
 		return nil
 	})
 }
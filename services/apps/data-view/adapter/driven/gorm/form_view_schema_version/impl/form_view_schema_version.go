@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_schema_version"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+func NewFormViewSchemaVersionRepo(db *gorm.DB) form_view_schema_version.FormViewSchemaVersionRepo {
+	return &formViewSchemaVersionRepo{db: db}
+}
+
+type formViewSchemaVersionRepo struct {
+	db *gorm.DB
+}
+
+func (r *formViewSchemaVersionRepo) GetLatest(ctx context.Context, formViewID string) (*model.FormViewSchemaVersion, error) {
+	version := &model.FormViewSchemaVersion{}
+	err := r.db.WithContext(ctx).Where("form_view_id = ?", formViewID).Order("version desc").Take(version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+func (r *formViewSchemaVersionRepo) GetByVersion(ctx context.Context, formViewID string, v int) (*model.FormViewSchemaVersion, error) {
+	version := &model.FormViewSchemaVersion{}
+	err := r.db.WithContext(ctx).Where("form_view_id = ? and version = ?", formViewID, v).Take(version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+func (r *formViewSchemaVersionRepo) List(ctx context.Context, formViewID string) (versions []*model.FormViewSchemaVersion, err error) {
+	err = r.db.WithContext(ctx).
+		Omit("fields").
+		Where("form_view_id = ?", formViewID).
+		Order("version desc").
+		Find(&versions).Error
+	return
+}
+
+func (r *formViewSchemaVersionRepo) Create(ctx context.Context, version *model.FormViewSchemaVersion) error {
+	return r.db.WithContext(ctx).Create(version).Error
+}
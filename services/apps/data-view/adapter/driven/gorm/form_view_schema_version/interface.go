@@ -0,0 +1,17 @@
+package form_view_schema_version
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+)
+
+type FormViewSchemaVersionRepo interface {
+	// GetLatest 获取逻辑视图最新的结构版本，不存在时返回 nil
+	GetLatest(ctx context.Context, formViewID string) (*model.FormViewSchemaVersion, error)
+	// GetByVersion 获取逻辑视图指定的结构版本，不存在时返回 nil
+	GetByVersion(ctx context.Context, formViewID string, version int) (*model.FormViewSchemaVersion, error)
+	// List 获取逻辑视图的结构版本列表，按版本号倒序，不包含字段快照
+	List(ctx context.Context, formViewID string) ([]*model.FormViewSchemaVersion, error)
+	Create(ctx context.Context, version *model.FormViewSchemaVersion) error
+}
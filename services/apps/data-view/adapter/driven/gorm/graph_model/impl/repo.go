@@ -431,3 +431,33 @@ func (r *repo) GetMetaUsedCount(ctx context.Context, metaModelID string) (count
 	}
 	return int64(len(lo.Uniq(append(relatedModels, singleModels...)))), err
 }
+
+func (r *repo) ListMetaModelsByDataView(ctx context.Context, dataViewID string, fieldID string) (models []*model.TGraphModel, err error) {
+	db := r.DB(ctx).Where("data_view_id=?", dataViewID)
+	if fieldID != "" {
+		db = db.Where("id in (?)", r.DB(ctx).Model(new(model.TModelField)).Select("model_id").Where("field_id=?", fieldID))
+	}
+	if err = db.Find(&models).Error; err != nil {
+		return nil, errorcode2.PublicDatabaseErr.Detail(err.Error())
+	}
+	return models, nil
+}
+
+func (r *repo) ListModelsUsingMetas(ctx context.Context, metaModelIDs ...string) (models []*model.TGraphModel, err error) {
+	if len(metaModelIDs) <= 0 {
+		return make([]*model.TGraphModel, 0), nil
+	}
+	//有关系的模型
+	relatedModels := make([]string, 0)
+	db := r.DB(ctx).Model(new(model.TModelRelationLink)).Select("model_id").Distinct("model_id")
+	if err = db.Where("start_model_id in ? or end_model_id in ?", metaModelIDs, metaModelIDs).Find(&relatedModels).Error; err != nil {
+		return nil, errorcode2.PublicDatabaseErr.Detail(err.Error())
+	}
+	//孤立节点所在的模型
+	singleModels := make([]string, 0)
+	db = r.DB(ctx).Model(new(model.TModelSingleNode)).Select("model_id").Distinct("model_id")
+	if err = db.Where("meta_model_id in ?", metaModelIDs).Find(&singleModels).Error; err != nil {
+		return nil, errorcode2.PublicDatabaseErr.Detail(err.Error())
+	}
+	return r.GetModelSlice(ctx, lo.Uniq(append(relatedModels, singleModels...))...)
+}
@@ -36,6 +36,10 @@ type queryRepo interface {
 	ExistsTechnicalName(ctx context.Context, modelID string, technicalName string) error
 	ExistsBusinessName(ctx context.Context, modelID string, businessName string) error
 	GetMetaUsedCount(ctx context.Context, metaModelID string) (count int64, err error)
+	// ListMetaModelsByDataView 查询基于逻辑视图的元模型，fieldID 不为空时只返回包含该字段的元模型
+	ListMetaModelsByDataView(ctx context.Context, dataViewID string, fieldID string) (models []*model.TGraphModel, err error)
+	// ListModelsUsingMetas 查询引用了元模型的专题模型、主题模型
+	ListModelsUsingMetas(ctx context.Context, metaModelIDs ...string) (models []*model.TGraphModel, err error)
 }
 
 type CanvasRepo interface {
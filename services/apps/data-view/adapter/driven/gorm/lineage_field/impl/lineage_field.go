@@ -0,0 +1,58 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/lineage_field"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+func NewLineageFieldRepo(db *gorm.DB) lineage_field.LineageFieldRepo {
+	return &lineageFieldRepo{db: db}
+}
+
+type lineageFieldRepo struct {
+	db *gorm.DB
+}
+
+func (r *lineageFieldRepo) Replace(ctx context.Context, formViewID string, fields []*model.LineageField) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("form_view_id = ?", formViewID).Delete(&model.LineageField{}).Error; err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(fields, len(fields)).Error
+	})
+}
+
+func (r *lineageFieldRepo) ReplaceFields(ctx context.Context, formViewID string, fieldIDs []string, fields []*model.LineageField) error {
+	if len(fieldIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("form_view_id = ? and field_id in ?", formViewID, fieldIDs).Delete(&model.LineageField{}).Error; err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(fields, len(fields)).Error
+	})
+}
+
+func (r *lineageFieldRepo) GetByFormViewID(ctx context.Context, formViewID string) (fields []*model.LineageField, err error) {
+	err = r.db.WithContext(ctx).Where("form_view_id = ?", formViewID).Find(&fields).Error
+	return
+}
+
+func (r *lineageFieldRepo) GetBySourceFieldID(ctx context.Context, sourceFieldID string) (fields []*model.LineageField, err error) {
+	err = r.db.WithContext(ctx).Where("source_field_id = ?", sourceFieldID).Find(&fields).Error
+	return
+}
+
+func (r *lineageFieldRepo) DeleteByFormViewID(ctx context.Context, formViewID string) error {
+	return r.db.WithContext(ctx).Where("form_view_id = ?", formViewID).Delete(&model.LineageField{}).Error
+}
@@ -0,0 +1,18 @@
+package lineage_field
+
+import (
+	"context"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+)
+
+type LineageFieldRepo interface {
+	// Replace 用新解析的结果覆盖视图的字段级血缘
+	Replace(ctx context.Context, formViewID string, fields []*model.LineageField) error
+	// ReplaceFields 只覆盖视图中 fieldIDs 对应字段的血缘，其它字段的记录保持不变
+	ReplaceFields(ctx context.Context, formViewID string, fieldIDs []string, fields []*model.LineageField) error
+	GetByFormViewID(ctx context.Context, formViewID string) ([]*model.LineageField, error)
+	// GetBySourceFieldID 获取引用了来源字段的字段级血缘
+	GetBySourceFieldID(ctx context.Context, sourceFieldID string) ([]*model.LineageField, error)
+	DeleteByFormViewID(ctx context.Context, formViewID string) error
+}
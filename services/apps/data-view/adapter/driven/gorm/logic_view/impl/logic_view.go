@@ -158,6 +158,10 @@ func (l *logicViewRepo) GetLogicViewSQLs(ctx context.Context, logicViewIds []str
 	err = l.db.WithContext(ctx).Where("form_view_id in ?", logicViewIds).Find(&formViewSql).Error
 	return
 }
+func (l *logicViewRepo) GetLogicViewSQLsByKeyword(ctx context.Context, keyword string) (formViewSql []*model.FormViewSql, err error) {
+	err = l.db.WithContext(ctx).Where("`sql` like ?", "%"+util.KeywordEscape(keyword)+"%").Find(&formViewSql).Error
+	return
+}
 
 func (l *logicViewRepo) CustomLogicEntityViewNameExist(ctx context.Context, businessName string, technicalName string) error {
 	var formView *model.FormView
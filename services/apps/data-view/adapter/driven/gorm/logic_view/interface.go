@@ -17,6 +17,8 @@ type LogicViewRepo interface {
 	UpdateLogicViewAndField(ctx context.Context, formView *model.FormView, formViewFields []*model.FormViewField, req *UpdateLogicViewAndFieldReq) error
 	GetLogicViewSQL(ctx context.Context, logicViewId string) (formViewSql []*model.FormViewSql, err error)
 	GetLogicViewSQLs(ctx context.Context, logicViewIds []string) (formViewSql []*model.FormViewSql, err error)
+	// GetLogicViewSQLsByKeyword 获取 sql 中包含关键字的视图 sql，用于查找引用了指定视图的逻辑视图
+	GetLogicViewSQLsByKeyword(ctx context.Context, keyword string) (formViewSql []*model.FormViewSql, err error)
 	CustomLogicEntityViewNameExist(ctx context.Context, businessName string, technicalName string) error
 	// 获取逻辑视图
 	Get(ctx context.Context, logicViewId string) (*model.FormView, error)
@@ -45,6 +45,8 @@ func NewKafkaProducer(bootstrap *config.Bootstrap) (kafka_pub.KafkaPub, error) {
 		constant.FormViewPublicTopic,
 		// 子视图（行列规则）
 		constant.TopicSubView,
+		// 逻辑视图结构变更
+		constant.TopicFormViewSchemaChange,
 		constant.EntityChangeTopic,
 	} {
 		// 跳过已经存在的 Topic
@@ -309,3 +309,44 @@ func (a *AuthService) Enforce(ctx context.Context, requests []auth_service.Enfor
 
 	return
 }
+
+// EvaluateAttributePolicies implements auth_service.DrivenAuthService.
+func (a *AuthService) EvaluateAttributePolicies(ctx context.Context, evaluateReq *auth_service.AttributePolicyEvaluateReq) (*auth_service.AttributeObligations, error) {
+	const drivenMsg = "DrivenAuthService EvaluateAttributePolicies"
+	url := fmt.Sprintf("%s/api/internal/auth-service/v1/attribute-policies/evaluate", a.baseURL)
+	buf, err := jsoniter.Marshal(evaluateReq)
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" jsoniter.Marshal error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.PublicInternalServerError, err.Error())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" http.NewRequestWithContext error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.AuthServiceCheckUsersAuthorityFailed, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	log.Info("request", zap.String("method", req.Method), zap.String("url", url))
+	resp, err := a.HttpClient.Do(req)
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" http.DefaultClient.Do error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.AuthServiceCheckUsersAuthorityFailed, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" io.ReadAll error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.AuthServiceCheckUsersAuthorityFailed, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, TransparentErrorCode(ctx, body, drivenMsg)
+	}
+
+	res := &auth_service.AttributeObligations{}
+	if err = json.Unmarshal(body, res); err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" json.Unmarshal error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.AuthServiceCheckUsersAuthorityFailed, err.Error())
+	}
+	return res, nil
+}
@@ -15,6 +15,8 @@ type DrivenAuthService interface {
 	VerifyUserPermissionObject(ctx context.Context, action string, objectType string, objectID string) (bool, error)
 	// 验证策略
 	Enforce(ctx context.Context, requests []EnforceRequest) (responses []bool, err error)
+	// 计算访问者访问资源时生效的属性策略，与访问者是否拥有资源的权限无关
+	EvaluateAttributePolicies(ctx context.Context, req *AttributePolicyEvaluateReq) (*AttributeObligations, error)
 }
 
 // region GetView
@@ -100,4 +102,39 @@ type EnforceResponse struct {
 	Result bool   `json:"result,omitempty"`
 }
 
+// AttributePolicyEvaluateReq 计算属性策略的请求
+type AttributePolicyEvaluateReq struct {
+	ObjectId    string `json:"object_id"`    // 资源id
+	ObjectType  string `json:"object_type"`  // 资源类型 data_view 逻辑视图
+	SubjectId   string `json:"subject_id"`   // 访问者id
+	SubjectType string `json:"subject_type"` // 访问者类型 user 用户 app 应用
+}
+
+// 属性策略对字段的处理方式
+const (
+	AttributeColumnMask = "mask" // 脱敏
+	AttributeColumnHide = "hide" // 隐藏
+)
+
+// AttributeObligations 访问资源时生效的属性策略
+type AttributeObligations struct {
+	Columns    []*AttributeColumnObligation `json:"columns"`     // 需要脱敏或隐藏的字段
+	RowFilters []*AttributeRowFilter        `json:"row_filters"` // 行过滤条件，条件之间是且的关系
+}
+
+// AttributeColumnObligation 需要脱敏或隐藏的字段
+type AttributeColumnObligation struct {
+	DataViewID string `json:"data_view_id"` // 字段所属逻辑视图的ID
+	Name       string `json:"name"`         // 字段技术名称
+	Action     string `json:"action"`       // mask 脱敏 hide 隐藏
+}
+
+// AttributeRowFilter 行过滤条件
+type AttributeRowFilter struct {
+	DataViewID string `json:"data_view_id"` // 字段所属逻辑视图的ID
+	Name       string `json:"name"`         // 字段技术名称
+	Operator   string `json:"operator"`     // 运算逻辑 = != in not in null not null
+	Value      string `json:"value"`        // 过滤值，in、not in 的多个值用逗号分隔
+}
+
 //endregion
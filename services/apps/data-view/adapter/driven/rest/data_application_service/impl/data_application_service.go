@@ -0,0 +1,84 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/data_application_service"
+	my_errorcode "github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
+	"github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/rest"
+	"go.uber.org/zap"
+)
+
+// defaultHost 未配置 DATA_APPLICATION_SERVICE_HOST 时使用集群内的服务地址
+const defaultHost = "http://data-application-service:8156"
+
+type DataApplicationService struct {
+	baseURL    string
+	HttpClient *http.Client
+}
+
+func NewDataApplicationService(httpClient *http.Client) data_application_service.DrivenDataApplicationService {
+	baseURL := os.Getenv("DATA_APPLICATION_SERVICE_HOST")
+	if baseURL == "" {
+		baseURL = defaultHost
+	}
+	return &DataApplicationService{
+		baseURL:    baseURL,
+		HttpClient: httpClient,
+	}
+}
+
+// GetServicesByDataViewID 获取逻辑视图关联的已发布接口
+func (d *DataApplicationService) GetServicesByDataViewID(ctx context.Context, dataViewID string) ([]*data_application_service.Service, error) {
+	drivenMsg := "DrivenDataApplicationService GetServicesByDataViewID "
+	urlStr := fmt.Sprintf("%s/api/data-application-service/internal/v1/data-view/%s/services", d.baseURL, dataViewID)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+"http.NewRequest error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DataApplicationServiceGetServicesError, err.Error())
+	}
+	resp, err := d.HttpClient.Do(request)
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+"client.Do error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DataApplicationServiceGetServicesError, err.Error())
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" io.ReadAll error", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DataApplicationServiceGetServicesError, err.Error())
+	}
+	if resp.StatusCode == http.StatusOK {
+		var res data_application_service.ServicesRes
+		if err = jsoniter.Unmarshal(body, &res); err != nil {
+			log.WithContext(ctx).Error(drivenMsg+" jsoniter.Unmarshal error", zap.Error(err))
+			return nil, errorcode.Detail(my_errorcode.DataApplicationServiceGetServicesError, err.Error())
+		}
+		return res.Entries, nil
+	} else {
+		if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusInternalServerError || resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return nil, Unmarshal(ctx, body, drivenMsg)
+		} else {
+			log.WithContext(ctx).Error(drivenMsg+"http status error", zap.String("status", resp.Status), zap.String("body", string(body)))
+			return nil, errorcode.Desc(my_errorcode.DataApplicationServiceGetServicesError, resp.StatusCode)
+		}
+	}
+}
+
+func Unmarshal(ctx context.Context, body []byte, drivenMsg string) error {
+	var res rest.HttpError
+	if err := jsoniter.Unmarshal(body, &res); err != nil {
+		log.WithContext(ctx).Error(drivenMsg+" jsoniter.Unmarshal error", zap.Error(err))
+		return errorcode.Detail(my_errorcode.DataApplicationServiceGetServicesError, err.Error())
+	}
+	log.WithContext(ctx).Errorf("%+v", res)
+	return errorcode.New(res.Code, res.Description, res.Cause, res.Solution, res.Detail, "")
+}
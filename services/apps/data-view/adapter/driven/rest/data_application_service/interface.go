@@ -0,0 +1,20 @@
+package data_application_service
+
+import (
+	"context"
+)
+
+type DrivenDataApplicationService interface {
+	// GetServicesByDataViewID 获取逻辑视图关联的已发布接口
+	GetServicesByDataViewID(ctx context.Context, dataViewID string) ([]*Service, error)
+}
+
+type ServicesRes struct {
+	Entries []*Service `json:"entries"`
+}
+
+type Service struct {
+	ServiceID   string `json:"service_id"`   // 接口ID
+	ServiceCode string `json:"service_code"` // 接口编码
+	ServiceName string `json:"service_name"` // 接口名称
+}
@@ -3,13 +3,17 @@ package gorm
 import (
 	"github.com/google/wire"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/callbacks"
+	delivery "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery/impl"
+	data_download_subscription "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_download_subscription/impl"
 	data_set_impl "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_set/impl"
 	department_explore_report "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/department_explore_report/impl"
 	desensitization_rule "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/desensitization_rule/impl"
 	form_view_extend "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_extend/impl"
+	form_view_schema_version "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_schema_version/impl"
 	grade_rule "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/grade_rule/impl"
 	grade_rule_group "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/grade_rule_group/impl"
 	graph_model "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/graph_model/impl"
+	lineage_field "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/lineage_field/impl"
 	template_rule "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/template_rule/impl"
 	white_list_policy "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/white_list_policy/impl"
 	es "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/mq/es/impl"
@@ -39,11 +43,13 @@ import (
 	redisson "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/redis"
 	auth_service "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/auth_service/impl"
 	configuration_center1 "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/configuration_center/impl"
+	data_application_service "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/data_application_service/impl"
 	data_exploration "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/data_exploration/impl"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/oss_gateway"
 	scene_analysis "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/scene_analysis/impl"
 	standardization_backend "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/standardization_backend/impl"
 	sailorService "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/sailor_service/impl"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 
 	//"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/configuration_center"
 	classification_rule "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/classification_rule/impl"
@@ -97,6 +103,9 @@ var Set = wire.NewSet(
 	graph_model.NewRepo,
 	template_rule.NewTemplateRuleRepo,
 	department_explore_report.NewDepartmentExploreReportRepo,
+	form_view_schema_version.NewFormViewSchemaVersionRepo,
+	lineage_field.NewLineageFieldRepo,
+	data_download_subscription.NewDataDownloadSubscriptionRepo,
 
 	//redisson
 	redisson.NewRedisson,
@@ -121,9 +130,13 @@ var Set = wire.NewSet(
 	data_subject.NewDataSubject,
 	standardization_backend.NewStandardizationBackend,
 	data_exploration.NewDataExploration,
+	data_application_service.NewDataApplicationService,
 	oss_gateway.NewCephClient,
 	//data_subject_impl.NewDataViewDriven,
 
+	// 数据下载订阅投递
+	delivery.NewDeliverer,
+
 	//entity_change
 	databaseCallback,
 
@@ -191,6 +204,7 @@ var MockSet = wire.NewSet(
 	data_subject.NewDataSubject,
 	standardization_backend.NewStandardizationBackend,
 	data_exploration.NewDataExploration,
+	data_application_service.NewDataApplicationService,
 	oss_gateway.NewCephClient,
 	//data_subject_impl.NewDataViewDriven,
 	//standardization.NewDriven,
@@ -209,4 +223,8 @@ var databaseCallback = wire.NewSet(
 	//认知搜索资源版
 	callbacks.NewEntityChangeTransport,
 	callbacks.NewDataLineageTransport,
+	//发件箱
+	outbox.NewOutbox,
+	callbacks.NewOutboxPublishers,
+	callbacks.NewOutboxRelayServer,
 )
@@ -98,3 +98,58 @@ func (s *Service) ParserLineage(c *gin.Context) {
 
 	ginx.ResOKJson(c, resp)
 }
+
+// ImportOpenLineage 导入 OpenLineage 运行事件
+//
+//	@Description	导入 OpenLineage 运行事件，将输出数据集的字段级血缘合并到对应元数据视图的血缘中，非 COMPLETE 事件不处理
+//	@Tags			数据血缘
+//	@Summary		导入OpenLineage血缘
+//	@Accept			json
+//	@Produce		json
+//	@Param			_	body		data_lineage.OpenLineageRunEvent	true	"OpenLineage运行事件"
+//	@Success		200	{object}	data_lineage.ImportOpenLineageResp	"成功响应参数"
+//	@Failure		400	{object}	rest.HttpError						"失败响应参数"
+//	@Router			/data-lineage/openlineage [post]
+func (s *Service) ImportOpenLineage(c *gin.Context) {
+	req := form_validator.Valid[data_lineage.ImportOpenLineageReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := util.TraceA1R2(c, req, s.uc.ImportOpenLineage)
+	if err != nil {
+		log.WithContext(c.Request.Context()).Errorf("failed to import openlineage event, run: %v, err: %v", req.Run.RunID, err)
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
+
+// ExportOpenLineage 导出视图的OpenLineage血缘
+//
+//	@Description	将视图的字段级血缘导出为 OpenLineage 运行事件
+//	@Tags			数据血缘
+//	@Summary		导出视图的OpenLineage血缘
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"token"
+//	@Param			id				path		string							true	"视图ID"
+//	@Success		200				{object}	data_lineage.OpenLineageRunEvent	"成功响应参数"
+//	@Failure		400				{object}	rest.HttpError					"失败响应参数"
+//	@Router			/data-lineage/{id}/openlineage [get]
+func (s *Service) ExportOpenLineage(c *gin.Context) {
+	req := form_validator.Valid[data_lineage.ExportOpenLineageReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := util.TraceA1R2(c, req, s.uc.ExportOpenLineage)
+	if err != nil {
+		log.WithContext(c.Request.Context()).Errorf("failed to export openlineage, req: %#v, err: %v", req, err)
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
@@ -116,6 +116,143 @@ func (f *FormViewService) GetDataDownloadLink(c *gin.Context) {
 	ginx.ResOKJson(c, resp)
 }
 
+// CreateDownloadSubscription 创建数据下载订阅
+//
+//	@Description	创建数据下载订阅，按cron表达式定时导出数据并投递到SFTP或S3
+//	@Tags			数据下载
+//	@Summary		创建数据下载订阅
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			Authorization	header		string					        true	"token"
+//	@Param			_			body		form_view.DownloadSubscriptionCreateParams	true	"请求参数"
+//	@Success		200				{object}	form_view.DownloadSubscriptionIDResp	    "成功响应参数"
+//	@Failure		400				{object}	rest.HttpError			        "失败响应参数"
+//	@Router			/download-subscription [post]
+func (f *FormViewService) CreateDownloadSubscription(c *gin.Context) {
+	req := form_validator.Valid[form_view.DownloadSubscriptionCreateParams](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := f.uc.CreateDownloadSubscription(c, &req.DownloadSubscriptionCreateReq)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
+
+// GetDownloadSubscriptionList 获取数据下载订阅列表
+//
+//	@Description	获取当前用户创建的数据下载订阅列表
+//	@Tags			数据下载
+//	@Summary		获取数据下载订阅列表
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			Authorization	header		string					        true	"token"
+//	@Param			query			query		form_view.GetDownloadSubscriptionListParams	true	"查询参数"
+//	@Success		200				{object}	form_view.PageResultNew[form_view.DownloadSubscriptionEntry]	    "成功响应参数"
+//	@Failure		400				{object}	rest.HttpError			        "失败响应参数"
+//	@Router			/download-subscription [get]
+func (f *FormViewService) GetDownloadSubscriptionList(c *gin.Context) {
+	req := form_validator.Valid[form_view.GetDownloadSubscriptionListParams](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := f.uc.GetDownloadSubscriptionList(c, &req.GetDownloadSubscriptionListReq)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
+
+// UpdateDownloadSubscription 修改数据下载订阅
+//
+//	@Description	修改数据下载订阅，投递目标的密码、密钥为空或者为掩码时沿用原配置
+//	@Tags			数据下载
+//	@Summary		修改数据下载订阅
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			Authorization	header		string					        true	"token"
+//	@Param			subscriptionID	path		uint64	true	"订阅ID"
+//	@Param			_			body		form_view.DownloadSubscriptionConfig	true	"请求参数"
+//	@Success		200				{object}	form_view.DownloadSubscriptionIDResp	    "成功响应参数"
+//	@Failure		400				{object}	rest.HttpError			        "失败响应参数"
+//	@Router			/download-subscription/{subscriptionID} [put]
+func (f *FormViewService) UpdateDownloadSubscription(c *gin.Context) {
+	req := form_validator.Valid[form_view.DownloadSubscriptionUpdateReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := f.uc.UpdateDownloadSubscription(c, req)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
+
+// DeleteDownloadSubscription 删除数据下载订阅
+//
+//	@Description	删除数据下载订阅及其执行记录
+//	@Tags			数据下载
+//	@Summary		删除数据下载订阅
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			Authorization	header		string					        true	"token"
+//	@Param			subscriptionID	path		uint64	true	"订阅ID"
+//	@Success		200				{object}	form_view.DownloadSubscriptionIDResp	    "成功响应参数"
+//	@Failure		400				{object}	rest.HttpError			        "失败响应参数"
+//	@Router			/download-subscription/{subscriptionID} [delete]
+func (f *FormViewService) DeleteDownloadSubscription(c *gin.Context) {
+	req := form_validator.Valid[form_view.DownloadSubscriptionPath](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := f.uc.DeleteDownloadSubscription(c, &req.DownloadSubscriptionPathReq)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
+
+// GetDownloadSubscriptionRuns 获取数据下载订阅的执行记录
+//
+//	@Description	获取数据下载订阅的执行记录
+//	@Tags			数据下载
+//	@Summary		获取数据下载订阅的执行记录
+//	@Accept			application/json
+//	@Produce		application/json
+//	@Param			Authorization	header		string					        true	"token"
+//	@Param			subscriptionID	path		uint64	true	"订阅ID"
+//	@Param			query			query		form_view.GetDownloadSubscriptionRunsQuery	true	"查询参数"
+//	@Success		200				{object}	form_view.PageResultNew[form_view.DownloadSubscriptionRunEntry]	    "成功响应参数"
+//	@Failure		400				{object}	rest.HttpError			        "失败响应参数"
+//	@Router			/download-subscription/{subscriptionID}/runs [get]
+func (f *FormViewService) GetDownloadSubscriptionRuns(c *gin.Context) {
+	req := form_validator.Valid[form_view.GetDownloadSubscriptionRunsReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := f.uc.GetDownloadSubscriptionRuns(c, req)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+
+	ginx.ResOKJson(c, resp)
+}
+
 // DataPreview 逻辑视图数据预览
 // @Description	逻辑视图数据预览
 // @Tags		逻辑视图数据预览
@@ -319,6 +319,7 @@ func (f *FormViewService) ExcelBatchPublish(c *gin.Context) {
 // @Param       Authorization header string true "token"
 // @Param       id          path  string true "视图ID"
 // @Param       keyword     query string false "名称"
+// @Param       dry_run     query bool   false "试运行，为 true 时不删除，返回 form_view.ImpactAnalysisResp"
 // @Success     200         {object} form_view.UpdateRes "成功响应参数"
 // @Failure     400         {object} rest.HttpError      "失败响应参数"
 // @Router      /form-view/:id [delete]
@@ -328,6 +329,16 @@ func (f *FormViewService) DeleteFormView(c *gin.Context) {
 		return
 	}
 
+	if req.DryRun {
+		resp, err := util.TraceA1R2(c, req, f.uc.DeleteFormViewDryRun)
+		if err != nil {
+			ginx.ResBadRequestJson(c, err)
+			return
+		}
+		ginx.ResOKJson(c, resp)
+		return
+	}
+
 	err := f.uc.DeleteFormView(c, req) // 已记录业务审计日志
 	if err != nil {
 		ginx.ResBadRequestJson(c, err)
@@ -1976,6 +1987,108 @@ func (f *FormViewService) GetDepartmentExploreReports(c *gin.Context) {
 	ginx.ResOKJson(c, resp)
 }
 
+// GetSchemaVersions 获取逻辑视图结构版本列表
+// @Description	获取逻辑视图结构版本列表，每次扫描发现源表结构变化时生成新版本
+// @Tags		逻辑视图
+// @Summary		获取逻辑视图结构版本列表
+// @Accept		json
+// @Produce		json
+// @Param       Authorization header string true "token"
+// @Param       id          path  string true "视图ID"
+// @Success		200				{object}	form_view.GetSchemaVersionsResp		"成功响应参数"
+// @Failure		400				{object}	rest.HttpError						"失败响应参数"
+// @Router		/form-view/{id}/schema-versions [get]
+func (f *FormViewService) GetSchemaVersions(c *gin.Context) {
+	req := form_validator.Valid[form_view.GetSchemaVersionsReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := util.TraceA1R2(c, req, f.uc.GetSchemaVersions)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, resp)
+}
+
+// DiffSchemaVersions 对比逻辑视图结构版本
+// @Description	对比逻辑视图两个结构版本的字段差异，包括字段的新增、删除以及名称、类型、长度、精度、是否为空、注释等属性的变更
+// @Tags		逻辑视图
+// @Summary		对比逻辑视图结构版本
+// @Accept		json
+// @Produce		json
+// @Param       Authorization header string true "token"
+// @Param       id          path  string true "视图ID"
+// @Param       _     query    form_view.DiffSchemaVersionsReqParam true "查询参数"
+// @Success		200				{object}	form_view.DiffSchemaVersionsResp		"成功响应参数"
+// @Failure		400				{object}	rest.HttpError						"失败响应参数"
+// @Router		/form-view/{id}/schema-versions/diff [get]
+func (f *FormViewService) DiffSchemaVersions(c *gin.Context) {
+	req := form_validator.Valid[form_view.DiffSchemaVersionsReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := util.TraceA1R2(c, req, f.uc.DiffSchemaVersions)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, resp)
+}
+
+// ImpactAnalysis 逻辑视图影响分析
+// @Description	查询逻辑视图或字段的下游依赖，包括行列规则、引用该视图的逻辑视图、元模型及专题/主题模型、接口服务、数据资源目录、探查规则
+// @Tags		逻辑视图
+// @Summary		逻辑视图影响分析
+// @Accept		json
+// @Produce		json
+// @Param       Authorization header string true "token"
+// @Param       id          path  string true "视图ID"
+// @Param       _     query    form_view.ImpactAnalysisReqParam true "查询参数"
+// @Success		200				{object}	form_view.ImpactAnalysisResp		"成功响应参数"
+// @Failure		400				{object}	rest.HttpError						"失败响应参数"
+// @Router		/form-view/{id}/impact [get]
+func (f *FormViewService) ImpactAnalysis(c *gin.Context) {
+	req := form_validator.Valid[form_view.ImpactAnalysisReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := util.TraceA1R2(c, req, f.uc.ImpactAnalysis)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, resp)
+}
+
+// ScanDryRun 试运行扫描数据源
+// @Description	将元数据平台中已采集的数据源元数据与逻辑视图对比，返回源表删除、字段删除、字段类型变更及其下游依赖，不触发采集，不更新逻辑视图
+// @Tags		元数据视图
+// @Summary		试运行扫描数据源
+// @Accept		json
+// @Produce		json
+// @Param       Authorization header string true "token"
+// @Param       _     body    form_view.ScanReq true "请求参数"
+// @Success		200				{object}	form_view.ScanDryRunResp		"成功响应参数"
+// @Failure		400				{object}	rest.HttpError					"失败响应参数"
+// @Router		/form-view/scan/dry-run [post]
+func (f *FormViewService) ScanDryRun(c *gin.Context) {
+	req := form_validator.Valid[form_view.ScanReq](c)
+	if req == nil {
+		return
+	}
+
+	resp, err := util.TraceA1R2(c, req, f.uc.ScanDryRun)
+	if err != nil {
+		ginx.ResBadRequestJson(c, err)
+		return
+	}
+	ginx.ResOKJson(c, resp)
+}
+
 func (f *FormViewService) CreateExploreReports(c *gin.Context) {
 	go f.uc.CreateExploreReports()
 	ginx.ResOKJson(c, nil)
@@ -91,6 +91,8 @@ func (r *Router) Register(engine *gin.Engine) {
 			formViewRouter.GET("/repeat", r.FormViewDomainApi.NameRepeat)                                             // 逻辑视图重名校验
 			formViewRouter.PUT("/:id", r.middleware.AuditLogger(), r.FormViewDomainApi.UpdateFormView)                // 编辑元数据视图
 			formViewRouter.DELETE("/:id", r.middleware.AuditLogger(), r.FormViewDomainApi.DeleteFormView)             // 删除逻辑视图
+			formViewRouter.GET("/:id/impact", r.FormViewDomainApi.ImpactAnalysis)                                     // 逻辑视图影响分析
+			formViewRouter.POST("/scan/dry-run", r.FormViewDomainApi.ScanDryRun)                                      // 试运行扫描数据源
 			formViewRouter.PUT("/:id/details", r.middleware.AuditLogger(), r.FormViewDomainApi.UpdateFormViewDetails) // 编辑逻辑视图基本信息
 			formViewRouter.GET("/by-audit-status", r.FormViewDomainApi.GetByAuditStatus)                              // 根据稽核状态获取逻辑视图列表
 			formViewRouter.GET("/basic", r.FormViewDomainApi.GetBasicViewList)                                        // 根据ID批量查询逻辑视图基本信息
@@ -152,6 +154,8 @@ func (r *Router) Register(engine *gin.Engine) {
 			dataViewRouter.GET("/department/explore-reports", r.FormViewDomainApi.GetExploreReports)                        // 单个部门探查报告列表查询
 			dataViewRouter.POST("/department/explore-reports/export", r.FormViewDomainApi.ExportExploreReports)             // 单个部门导出探查报告
 			formViewRouter.GET("/explore-reports", r.FormViewDomainApi.GetDepartmentExploreReports)                         // 所有部门探查报告列表查询
+			formViewRouter.GET("/:id/schema-versions", r.FormViewDomainApi.GetSchemaVersions)                               // 逻辑视图结构版本列表
+			formViewRouter.GET("/:id/schema-versions/diff", r.FormViewDomainApi.DiffSchemaVersions)                         // 对比逻辑视图结构版本
 		}
 	}
 
@@ -258,8 +262,9 @@ func (r *Router) Register(engine *gin.Engine) {
 	//数据血缘, 从数据目录迁移过来的
 	dataLineageRouter := dataViewRouter.Group("/data-lineage")
 	{
-		dataLineageRouter.GET("/:id/base", r.DataLineageApi.GetBase)     // 前端展示下的获取base节点及相关信息
-		dataLineageRouter.GET("/pre/:vid", r.DataLineageApi.ListLineage) // 前端展示下的分页获取指定节点上一度血缘关系
+		dataLineageRouter.GET("/:id/base", r.DataLineageApi.GetBase)                  // 前端展示下的获取base节点及相关信息
+		dataLineageRouter.GET("/pre/:vid", r.DataLineageApi.ListLineage)              // 前端展示下的分页获取指定节点上一度血缘关系
+		dataLineageRouter.GET("/:id/openlineage", r.DataLineageApi.ExportOpenLineage) // 导出视图的OpenLineage血缘
 
 	}
 
@@ -296,6 +301,15 @@ func (r *Router) Register(engine *gin.Engine) {
 		downloadTaskRouter.GET("/:taskID/download-link", r.middleware.AuditLogger(), r.FormViewDomainApi.GetDataDownloadLink) // 获取下载任务导出文件下载链接
 	}
 
+	downloadSubscriptionRouter := dataViewRouter.Group("/download-subscription")
+	{
+		downloadSubscriptionRouter.POST("", r.middleware.AuditLogger(), r.FormViewDomainApi.CreateDownloadSubscription)                   // 创建数据下载订阅
+		downloadSubscriptionRouter.GET("", r.FormViewDomainApi.GetDownloadSubscriptionList)                                               // 获取数据下载订阅列表
+		downloadSubscriptionRouter.PUT("/:subscriptionID", r.middleware.AuditLogger(), r.FormViewDomainApi.UpdateDownloadSubscription)    // 修改数据下载订阅
+		downloadSubscriptionRouter.DELETE("/:subscriptionID", r.middleware.AuditLogger(), r.FormViewDomainApi.DeleteDownloadSubscription) // 删除数据下载订阅
+		downloadSubscriptionRouter.GET("/:subscriptionID/runs", r.FormViewDomainApi.GetDownloadSubscriptionRuns)                          // 获取数据下载订阅的执行记录
+	}
+
 	// 子视图
 	subViewRouter := dataViewRouter.Group("sub-views")
 	{
@@ -406,6 +420,7 @@ func (r *Router) RegisterInternal(engine *gin.Engine) {
 	internalRouter.GET("/form-view/simple/:key", r.FormViewDomainApi.GetViewByKey)
 
 	internalRouter.GET("/data-lineage/parser", r.DataLineageApi.ParserLineage)                                                                    // 解析得到视图血缘数据
+	internalRouter.POST("/data-lineage/openlineage", r.DataLineageApi.ImportOpenLineage)                                                          // 导入OpenLineage运行事件
 	internalRouter.GET("/form-view", r.FormViewDomainApi.PageList)                                                                                // 获取逻辑视图列表
 	engine.POST(common_form_view.GetViewListByTechnicalNameInMultiDatasourceUrl, r.FormViewDomainApi.GetViewListByTechnicalNameInMultiDatasource) // 查询多个数据源下根据技术名称数组查询视图
 	internalRouter.GET("/explore-config/rule", r.ExploreTaskDomainApi.GetRuleList)                                                                // 查看视图规则列表
@@ -416,6 +431,7 @@ func (r *Router) RegisterInternal(engine *gin.Engine) {
 	internalRouter.POST("/form-view/sync", r.FormViewDomainApi.Sync)                                                                              // 同步统一视图服务视图信息
 	internalRouter.GET("/explore-task", r.ExploreTaskDomainApi.GetList)                                                                           // 探查任务列表
 	internalRouter.POST("/department/explore-reports", r.FormViewDomainApi.CreateExploreReports)                                                  // 定时更新探查报告表
+	internalRouter.POST("/desensitization-rule/ids", r.FormViewDomainApi.GetDesensitizationRuleByIds)                                             // 根据ID批量查询脱敏规则
 }
 
 // RegisterMigration 版本升级接口，发布后不可修改
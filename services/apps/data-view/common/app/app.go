@@ -5,6 +5,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driver/mq"
 	formView "github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view/v1"
 	my_config "github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/config"
+	"github.com/kweaver-ai/dsg/services/lib/common/outbox"
 	"github.com/kweaver-ai/idrm-go-common/workflow"
 	go_frame "github.com/kweaver-ai/idrm-go-frame"
 	"github.com/kweaver-ai/idrm-go-frame/core/transport"
@@ -52,6 +53,7 @@ func (a *AppRunner) Run() error {
 	return a.App.Run()
 }
 
-func ToTransportServer(hs *rest.Server, formViewServer *formView.Server) []transport.Server {
-	return []transport.Server{hs, formViewServer}
+// ToTransportServer outboxRelayServer 发送实体变更等写入发件箱的消息
+func ToTransportServer(hs *rest.Server, formViewServer *formView.Server, outboxRelayServer *outbox.RelayServer) []transport.Server {
+	return []transport.Server{hs, formViewServer, outboxRelayServer}
 }
@@ -103,6 +103,9 @@ func IsCouldBindSubject(subject string) bool {
 // 消息队列 topic：子视图 SubView
 const TopicSubView = "af.data-view.sub-view"
 
+// 消息队列 topic：逻辑视图结构变更
+const TopicFormViewSchemaChange = "af.data-view.schema-change"
+
 const (
 	DepartmentCateId = "00000000-0000-0000-0000-000000000001"
 	InfoSystemCateId = "00000000-0000-0000-0000-000000000002"
@@ -21,6 +21,8 @@ const (
 	GetAccessPermissionError = UserPreCoder + "GetAccessPermissionError"
 	GetInfoSystemDetail      = drivenPreCoder + "GetInfoSystemDetail"
 	GetStatusCheck           = drivenPreCoder + "GetStatusCheck"
+	OpenLineageImportFailed  = lineagePreCoder + "OpenLineageImportFailed"
+	OpenLineageExportFailed  = lineagePreCoder + "OpenLineageExportFailed"
 )
 
 var lineageErrorMap = errorcode.ErrorCode{
@@ -69,4 +71,14 @@ var lineageErrorMap = errorcode.ErrorCode{
 		Cause:       "",
 		Solution:    "请重试",
 	},
+	OpenLineageImportFailed: {
+		Description: "导入OpenLineage血缘失败",
+		Cause:       "",
+		Solution:    "请检查运行事件中的数据集和字段血缘后重试",
+	},
+	OpenLineageExportFailed: {
+		Description: "导出OpenLineage血缘失败",
+		Cause:       "",
+		Solution:    "请检查视图sql是否正确后重试",
+	},
 }
@@ -12,59 +12,60 @@ func init() {
 const (
 	drivenPreCoder = constant.ServiceName + ".Driven."
 
-	VirtualizationEngineError            = drivenPreCoder + "VirtualizationEngineError"
-	GetViewError                         = drivenPreCoder + "GetViewError"
-	CreateViewError                      = drivenPreCoder + "CreateViewError"
-	DeleteViewError                      = drivenPreCoder + "DeleteViewError"
-	ModifyViewError                      = drivenPreCoder + "ModifyViewError"
-	CreateViewSourceError                = drivenPreCoder + "CreateViewSourceError"
-	DeleteDataSourceError                = drivenPreCoder + "DeleteDataSourceError"
-	DrivenMetadataError                  = drivenPreCoder + "DrivenMetadataError"
-	GetDataTablesError                   = drivenPreCoder + "GetDataTablesError"
-	GetDataTableDetailError              = drivenPreCoder + "GetDataTableDetailError"
-	GetDataTableDetailBatchError         = drivenPreCoder + "GetDataTableDetailBatchError"
-	FetchDataError                       = drivenPreCoder + "FetchDataError"
-	DownloadDataError                    = drivenPreCoder + "DownloadDataError"
-	UserMgrBatchGetUserInfoByIDFailure   = drivenPreCoder + "UserMgrBatchGetUserInfoByIDFailure"
-	DoCollectFailure                     = drivenPreCoder + "DoCollectFailure"
-	MetaGetTaskIdFailure                 = drivenPreCoder + "MetaGetTaskIdFailure"
-	CodeGenerationFailure                = drivenPreCoder + "CodeGenerationFailure" // 生成编码失败，且 configuration-center 未返回预定义的错误码时使用此错误
-	DrivenGetConnectorsFailed            = constant.ServiceName + "." + "DrivenGetConnectorsFailed"
-	AuthServiceGetUsersObjectsFailed     = constant.ServiceName + "." + "AuthServiceGetUsersObjectsFailed"
-	GetsObjectByIdError                  = constant.ServiceName + "." + "GetsObjectByIdError"
-	GetObjectPrecisionError              = constant.ServiceName + "." + "GetObjectPrecisionError"
-	GetStandardDataElementError          = constant.ServiceName + "." + "GetStandardDataElementError"
-	GetStandardDictError                 = constant.ServiceName + "." + "GetStandardDictError"
-	DataSourceNotFound                   = drivenPreCoder + "DataSourceNotFound"
-	DrivenDataExploration                = drivenPreCoder + "DrivenDataExploration"
-	DataExplorationCreateTaskError       = drivenPreCoder + "DataExplorationCreateTaskError"
-	DataExplorationUpdateTaskError       = drivenPreCoder + "DataExplorationUpdateTaskError"
-	DataExplorationGetTaskError          = drivenPreCoder + "DataExplorationGetTaskError"
-	DataExplorationGetReportError        = drivenPreCoder + "DataExplorationGetReportError"
-	DataExplorationGetRuleListError      = drivenPreCoder + "DataExplorationGetRuleListError"
-	DataExplorationGetScoreError         = drivenPreCoder + "DataExplorationGetScoreError"
-	GetSubjectListError                  = constant.ServiceName + "." + "GetSubjectListError"
-	SceneAnalysisDrivenGetSceneError     = constant.ServiceName + "." + "SceneAnalysisDrivenGetSceneError"
-	PublicInternalServerError            = constant.ServiceName + "." + "PublicInternalServerError"
-	AuthServiceCheckUsersAuthorityFailed = constant.ServiceName + "." + "AuthServiceCheckUsersAuthorityFailed"
-	UserDoNotHaveDownloadAuthority       = constant.ServiceName + "." + "UserDoNotHaveDownloadAuthority"
-	DataExplorationGetStatusError        = drivenPreCoder + "DataExplorationGetStatusError"
-	DataExplorationStartExploreError     = drivenPreCoder + "DataExplorationStartExploreError"
-	DataExplorationDeleteTaskError       = drivenPreCoder + "DataExplorationDeleteTaskError"
-	GetTimestampBlacklistError           = drivenPreCoder + "GetTimestampBlacklistError"
-	WorkflowGETProcessError              = drivenPreCoder + "WorkflowGETProcessError"
-	SailorGenerateFakeSamplesError       = drivenPreCoder + "SailorGenerateFakeSamplesError"
-	GetUserRolesError                    = drivenPreCoder + "GetUserRolesError"
-	UserNotHavePermission                = constant.ServiceName + "." + "UserNotHavePermission"
-	GetStandardRuleError                 = drivenPreCoder + "GetStandardRuleError"
-	CreateExcelViewError                 = drivenPreCoder + "." + "CreateExcelViewError"
-	DeleteExcelViewError                 = drivenPreCoder + "." + "DeleteExcelViewError"
-	GetPreviewError                      = drivenPreCoder + "." + "GetPreviewError"
-	MdlGetViewsError                     = drivenPreCoder + "MdlGetViewsError"
-	MdlGetViewError                      = drivenPreCoder + "MdlGetViewError"
-	MdlUpdateViewError                   = drivenPreCoder + "MdlUpdateViewError"
-	MdlDeleteViewError                   = drivenPreCoder + "MdlDeleteViewError"
-	DrivenMdlError                       = drivenPreCoder + "DrivenMdlError"
+	VirtualizationEngineError              = drivenPreCoder + "VirtualizationEngineError"
+	GetViewError                           = drivenPreCoder + "GetViewError"
+	CreateViewError                        = drivenPreCoder + "CreateViewError"
+	DeleteViewError                        = drivenPreCoder + "DeleteViewError"
+	ModifyViewError                        = drivenPreCoder + "ModifyViewError"
+	CreateViewSourceError                  = drivenPreCoder + "CreateViewSourceError"
+	DeleteDataSourceError                  = drivenPreCoder + "DeleteDataSourceError"
+	DrivenMetadataError                    = drivenPreCoder + "DrivenMetadataError"
+	GetDataTablesError                     = drivenPreCoder + "GetDataTablesError"
+	GetDataTableDetailError                = drivenPreCoder + "GetDataTableDetailError"
+	GetDataTableDetailBatchError           = drivenPreCoder + "GetDataTableDetailBatchError"
+	FetchDataError                         = drivenPreCoder + "FetchDataError"
+	DownloadDataError                      = drivenPreCoder + "DownloadDataError"
+	UserMgrBatchGetUserInfoByIDFailure     = drivenPreCoder + "UserMgrBatchGetUserInfoByIDFailure"
+	DoCollectFailure                       = drivenPreCoder + "DoCollectFailure"
+	MetaGetTaskIdFailure                   = drivenPreCoder + "MetaGetTaskIdFailure"
+	CodeGenerationFailure                  = drivenPreCoder + "CodeGenerationFailure" // 生成编码失败，且 configuration-center 未返回预定义的错误码时使用此错误
+	DrivenGetConnectorsFailed              = constant.ServiceName + "." + "DrivenGetConnectorsFailed"
+	AuthServiceGetUsersObjectsFailed       = constant.ServiceName + "." + "AuthServiceGetUsersObjectsFailed"
+	GetsObjectByIdError                    = constant.ServiceName + "." + "GetsObjectByIdError"
+	GetObjectPrecisionError                = constant.ServiceName + "." + "GetObjectPrecisionError"
+	GetStandardDataElementError            = constant.ServiceName + "." + "GetStandardDataElementError"
+	GetStandardDictError                   = constant.ServiceName + "." + "GetStandardDictError"
+	DataSourceNotFound                     = drivenPreCoder + "DataSourceNotFound"
+	DrivenDataExploration                  = drivenPreCoder + "DrivenDataExploration"
+	DataExplorationCreateTaskError         = drivenPreCoder + "DataExplorationCreateTaskError"
+	DataExplorationUpdateTaskError         = drivenPreCoder + "DataExplorationUpdateTaskError"
+	DataExplorationGetTaskError            = drivenPreCoder + "DataExplorationGetTaskError"
+	DataExplorationGetReportError          = drivenPreCoder + "DataExplorationGetReportError"
+	DataExplorationGetRuleListError        = drivenPreCoder + "DataExplorationGetRuleListError"
+	DataExplorationGetScoreError           = drivenPreCoder + "DataExplorationGetScoreError"
+	GetSubjectListError                    = constant.ServiceName + "." + "GetSubjectListError"
+	SceneAnalysisDrivenGetSceneError       = constant.ServiceName + "." + "SceneAnalysisDrivenGetSceneError"
+	PublicInternalServerError              = constant.ServiceName + "." + "PublicInternalServerError"
+	AuthServiceCheckUsersAuthorityFailed   = constant.ServiceName + "." + "AuthServiceCheckUsersAuthorityFailed"
+	UserDoNotHaveDownloadAuthority         = constant.ServiceName + "." + "UserDoNotHaveDownloadAuthority"
+	DataExplorationGetStatusError          = drivenPreCoder + "DataExplorationGetStatusError"
+	DataExplorationStartExploreError       = drivenPreCoder + "DataExplorationStartExploreError"
+	DataExplorationDeleteTaskError         = drivenPreCoder + "DataExplorationDeleteTaskError"
+	GetTimestampBlacklistError             = drivenPreCoder + "GetTimestampBlacklistError"
+	WorkflowGETProcessError                = drivenPreCoder + "WorkflowGETProcessError"
+	SailorGenerateFakeSamplesError         = drivenPreCoder + "SailorGenerateFakeSamplesError"
+	GetUserRolesError                      = drivenPreCoder + "GetUserRolesError"
+	UserNotHavePermission                  = constant.ServiceName + "." + "UserNotHavePermission"
+	GetStandardRuleError                   = drivenPreCoder + "GetStandardRuleError"
+	CreateExcelViewError                   = drivenPreCoder + "." + "CreateExcelViewError"
+	DeleteExcelViewError                   = drivenPreCoder + "." + "DeleteExcelViewError"
+	GetPreviewError                        = drivenPreCoder + "." + "GetPreviewError"
+	MdlGetViewsError                       = drivenPreCoder + "MdlGetViewsError"
+	MdlGetViewError                        = drivenPreCoder + "MdlGetViewError"
+	MdlUpdateViewError                     = drivenPreCoder + "MdlUpdateViewError"
+	MdlDeleteViewError                     = drivenPreCoder + "MdlDeleteViewError"
+	DrivenMdlError                         = drivenPreCoder + "DrivenMdlError"
+	DataApplicationServiceGetServicesError = drivenPreCoder + "DataApplicationServiceGetServicesError"
 )
 
 var drivenErrorMap = errorcode.ErrorCode{
@@ -330,4 +331,9 @@ var drivenErrorMap = errorcode.ErrorCode{
 		Cause:       "",
 		Solution:    "请重试",
 	},
+	DataApplicationServiceGetServicesError: {
+		Description: "数据服务获取逻辑视图关联接口失败",
+		Cause:       "",
+		Solution:    "请重试",
+	},
 }
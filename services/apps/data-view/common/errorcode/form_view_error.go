@@ -81,6 +81,10 @@ const (
 	SubjectHasLabel                                     = formViewPreCoder + "SubjectHasLabel"
 	DataSourceSourceTypeAndDataSourceIDExclude          = formViewPreCoder + "DataSourceSourceTypeAndDataSourceIDExclude"
 	InfoSystemIDAndDataSourceIDAndDataSourceTypeExclude = formViewPreCoder + "InfoSystemIDAndDataSourceIDAndDataSourceTypeExclude"
+	FormViewSchemaVersionNotExist                       = formViewPreCoder + "FormViewSchemaVersionNotExist"
+	DownloadSubscriptionNotFound                        = formViewPreCoder + "DownloadSubscriptionNotFound"
+	DownloadSubscriptionCronInvalid                     = formViewPreCoder + "DownloadSubscriptionCronInvalid"
+	DownloadSubscriptionTargetInvalid                   = formViewPreCoder + "DownloadSubscriptionTargetInvalid"
 )
 
 var FormViewErrorMap = errorcode.ErrorCode{
@@ -411,4 +415,24 @@ var FormViewErrorMap = errorcode.ErrorCode{
 		Cause:       "",
 		Solution:    "请先删除或更改分类",
 	},
+	FormViewSchemaVersionNotExist: {
+		Description: "逻辑视图结构版本不存在",
+		Cause:       "",
+		Solution:    "请检查版本号",
+	},
+	DownloadSubscriptionNotFound: {
+		Description: "数据下载订阅不存在",
+		Cause:       "",
+		Solution:    "请检查订阅ID",
+	},
+	DownloadSubscriptionCronInvalid: {
+		Description: "执行周期cron表达式无效",
+		Cause:       "",
+		Solution:    "请使用五段式cron表达式，如 0 2 * * *",
+	},
+	DownloadSubscriptionTargetInvalid: {
+		Description: "投递目标配置无效",
+		Cause:       "",
+		Solution:    "请检查投递目标配置",
+	},
 }
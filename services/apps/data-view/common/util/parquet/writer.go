@@ -0,0 +1,294 @@
+// Package parquet 使用 parquet-go 写入数据下载的 Parquet 文件。
+//
+// 列按虚拟化引擎返回的字段类型生成对应的物理类型和逻辑类型，所有列都可以为空，列的顺序与字段顺序一致。
+package parquet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultRowGroupSize 默认每个行组的行数
+const DefaultRowGroupSize = 64 * 1024
+
+const (
+	// maxInt64DecimalPrecision 使用 INT64 保存的 decimal 的最大精度，更大的精度使用 16 字节的 FIXED_LEN_BYTE_ARRAY
+	maxInt64DecimalPrecision = 18
+	maxDecimalPrecision      = 38
+	decimalByteLength        = 16
+)
+
+// 虚拟化引擎返回的日期、时间戳的格式，时间戳的小数秒可选
+const (
+	dateLayout      = "2006-01-02"
+	timestampLayout = "2006-01-02 15:04:05.999999999"
+)
+
+// Column 列名及虚拟化引擎返回的字段类型，类型为空或者不支持时按字符串写入
+type Column struct {
+	Name string
+	Type string
+}
+
+// Writer 按行写入 Parquet 文件
+type Writer struct {
+	writer  *parquet.Writer
+	columns []*column
+	row     parquet.Row
+	closed  bool
+}
+
+// NewWriter 创建 Writer，rowGroupSize 小于等于 0 时使用 DefaultRowGroupSize
+func NewWriter(w io.Writer, columns []Column, rowGroupSize int) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("parquet: no columns")
+	}
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+	group := &orderedGroup{Group: parquet.Group{}}
+	pw := &Writer{row: make(parquet.Row, len(columns))}
+	for i := range columns {
+		c := newColumn(columns[i])
+		if _, ok := group.Group[c.name]; ok {
+			return nil, fmt.Errorf("parquet: duplicate column %s", c.name)
+		}
+		group.Group[c.name] = c.Node
+		group.fields = append(group.fields, c)
+		pw.columns = append(pw.columns, c)
+	}
+	pw.writer = parquet.NewWriter(w,
+		parquet.NewSchema("schema", group),
+		parquet.Compression(&parquet.Gzip),
+		parquet.MaxRowsPerRowGroup(int64(rowGroupSize)),
+	)
+	return pw, nil
+}
+
+// Write 写入一行，nil 表示空值
+func (pw *Writer) Write(row []any) error {
+	if pw.closed {
+		return errors.New("parquet: writer closed")
+	}
+	if len(row) != len(pw.columns) {
+		return errors.New("parquet: column count mismatch")
+	}
+	for i, c := range pw.columns {
+		if row[i] == nil {
+			pw.row[i] = parquet.NullValue().Level(0, 0, i)
+			continue
+		}
+		v, err := c.value(row[i])
+		if err != nil {
+			return fmt.Errorf("parquet: column %s: %w", c.name, err)
+		}
+		pw.row[i] = v.Level(0, 1, i)
+	}
+	_, err := pw.writer.WriteRows([]parquet.Row{pw.row})
+	return err
+}
+
+// Close 写出剩余的行组及文件元数据，不会关闭底层的 io.Writer
+func (pw *Writer) Close() error {
+	if pw.closed {
+		return nil
+	}
+	pw.closed = true
+	return pw.writer.Close()
+}
+
+// orderedGroup 按字段顺序排列列，parquet.Group 会按列名排序
+type orderedGroup struct {
+	parquet.Group
+	fields []parquet.Field
+}
+
+func (g *orderedGroup) Fields() []parquet.Field { return g.fields }
+
+// column 可为空的列，value 将查询结果中的值转为列类型的值
+type column struct {
+	parquet.Node
+	name  string
+	value func(v any) (parquet.Value, error)
+}
+
+func (c *column) Name() string { return c.name }
+
+func (c *column) Value(base reflect.Value) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(c.name))
+}
+
+// newColumn 按字段类型生成列，字段类型如 integer、decimal(10,2)、varchar(255)、timestamp(3)
+func newColumn(col Column) *column {
+	typ := strings.ToLower(strings.TrimSpace(col.Type))
+	base, params, _ := strings.Cut(typ, "(")
+	c := &column{name: col.Name}
+	switch strings.TrimSpace(base) {
+	case "boolean":
+		c.Node, c.value = parquet.Leaf(parquet.BooleanType), booleanValue
+	case "tinyint", "smallint", "integer", "int":
+		c.Node, c.value = parquet.Int(32), int32Value
+	case "bigint":
+		c.Node, c.value = parquet.Int(64), int64Value
+	case "real", "float":
+		c.Node, c.value = parquet.Leaf(parquet.FloatType), floatValue
+	case "double":
+		c.Node, c.value = parquet.Leaf(parquet.DoubleType), doubleValue
+	case "decimal":
+		precision, scale, ok := decimalParams(params)
+		switch {
+		case !ok:
+			c.Node, c.value = parquet.String(), stringValue
+		case precision <= maxInt64DecimalPrecision:
+			c.Node, c.value = parquet.Decimal(scale, precision, parquet.Int64Type), int64DecimalValue(scale)
+		default:
+			c.Node, c.value = parquet.Decimal(scale, precision, parquet.FixedLenByteArrayType(decimalByteLength)), fixedDecimalValue(scale)
+		}
+	case "date":
+		c.Node, c.value = parquet.Date(), dateValue
+	case "timestamp":
+		// 带时区的时间戳按字符串写入，保留时区信息
+		if strings.Contains(typ, "with time zone") {
+			c.Node, c.value = parquet.String(), stringValue
+		} else {
+			c.Node, c.value = parquet.TimestampAdjusted(parquet.Microsecond, false), timestampValue
+		}
+	default:
+		c.Node, c.value = parquet.String(), stringValue
+	}
+	c.Node = parquet.Optional(c.Node)
+	return c
+}
+
+// decimalParams 解析 decimal 类型的精度和小数位数，如 "10,2)"
+func decimalParams(params string) (precision, scale int, ok bool) {
+	p, s, _ := strings.Cut(strings.TrimSuffix(strings.TrimSpace(params), ")"), ",")
+	precision, err := strconv.Atoi(strings.TrimSpace(p))
+	if err != nil || precision <= 0 || precision > maxDecimalPrecision {
+		return 0, 0, false
+	}
+	if s = strings.TrimSpace(s); s != "" {
+		if scale, err = strconv.Atoi(s); err != nil || scale < 0 || scale > precision {
+			return 0, 0, false
+		}
+	}
+	return precision, scale, true
+}
+
+// text 查询结果中的值的文本，数值不使用科学计数法
+func text(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func stringValue(v any) (parquet.Value, error) {
+	return parquet.ByteArrayValue([]byte(text(v))), nil
+}
+
+func booleanValue(v any) (parquet.Value, error) {
+	if b, ok := v.(bool); ok {
+		return parquet.BooleanValue(b), nil
+	}
+	b, err := strconv.ParseBool(text(v))
+	return parquet.BooleanValue(b), err
+}
+
+func int32Value(v any) (parquet.Value, error) {
+	i, err := strconv.ParseInt(text(v), 10, 32)
+	return parquet.Int32Value(int32(i)), err
+}
+
+func int64Value(v any) (parquet.Value, error) {
+	i, err := strconv.ParseInt(text(v), 10, 64)
+	return parquet.Int64Value(i), err
+}
+
+func floatValue(v any) (parquet.Value, error) {
+	f, err := strconv.ParseFloat(text(v), 32)
+	return parquet.FloatValue(float32(f)), err
+}
+
+func doubleValue(v any) (parquet.Value, error) {
+	f, err := strconv.ParseFloat(text(v), 64)
+	return parquet.DoubleValue(f), err
+}
+
+// unscaled decimal 乘以 10^scale 后的整数
+func unscaled(v any, scale int) (*big.Int, error) {
+	d, err := decimal.NewFromString(text(v))
+	if err != nil {
+		return nil, err
+	}
+	return d.Shift(int32(scale)).Round(0).BigInt(), nil
+}
+
+func int64DecimalValue(scale int) func(v any) (parquet.Value, error) {
+	return func(v any) (parquet.Value, error) {
+		i, err := unscaled(v, scale)
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		if !i.IsInt64() {
+			return parquet.Value{}, fmt.Errorf("decimal %s out of range", text(v))
+		}
+		return parquet.Int64Value(i.Int64()), nil
+	}
+}
+
+// fixedDecimalValue 大端序的补码
+func fixedDecimalValue(scale int) func(v any) (parquet.Value, error) {
+	return func(v any) (parquet.Value, error) {
+		i, err := unscaled(v, scale)
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		if i.Sign() < 0 {
+			i.Add(i, new(big.Int).Lsh(big.NewInt(1), decimalByteLength*8))
+		}
+		if i.BitLen() > decimalByteLength*8 {
+			return parquet.Value{}, fmt.Errorf("decimal %s out of range", text(v))
+		}
+		return parquet.FixedLenByteArrayValue(i.FillBytes(make([]byte, decimalByteLength))), nil
+	}
+}
+
+// dateValue 自 1970-01-01 起的天数
+func dateValue(v any) (parquet.Value, error) {
+	t, err := time.ParseInLocation(dateLayout, text(v), time.UTC)
+	if err != nil {
+		return parquet.Value{}, err
+	}
+	return parquet.Int32Value(int32(t.Unix() / 86400)), nil
+}
+
+// timestampValue 不带时区的时间戳按字面时间保存为微秒数
+func timestampValue(v any) (parquet.Value, error) {
+	s := strings.Replace(text(v), "T", " ", 1)
+	layout := timestampLayout
+	if len(s) == len(dateLayout) {
+		layout = dateLayout
+	}
+	t, err := time.ParseInLocation(layout, s, time.UTC)
+	if err != nil {
+		return parquet.Value{}, err
+	}
+	return parquet.Int64Value(t.UnixMicro()), nil
+}
@@ -0,0 +1,101 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w, err := NewWriter(buf, []Column{
+		{Name: "id", Type: "bigint"},
+		{Name: "名称", Type: "varchar(255)"},
+		{Name: "amount", Type: "decimal(10,2)"},
+		{Name: "big_amount", Type: "decimal(38,4)"},
+		{Name: "score", Type: "double"},
+		{Name: "enabled", Type: "boolean"},
+		{Name: "day", Type: "date"},
+		{Name: "updated_at", Type: "timestamp(3)"},
+		{Name: "extra", Type: ""},
+	}, 2)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, w.Write([]any{float64(1), "名称", "12.34", "-1.5", 1.5, true, "2024-05-01", "2024-05-01 12:30:00.123", float64(3)}))
+	assert.NoError(t, w.Write([]any{float64(2), nil, nil, nil, nil, nil, nil, nil, nil}))
+	assert.NoError(t, w.Write([]any{"9007199254740993", "a", float64(-0.5), "123456789012345678901234.5678", float64(0), "false", "1969-12-31", "2024-05-01T00:00:00", true}))
+	assert.Error(t, w.Write([]any{float64(1)}))
+	assert.Error(t, w.Write([]any{"x", nil, nil, nil, nil, nil, nil, nil, nil}))
+	if !assert.NoError(t, w.Close()) {
+		return
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(3), f.NumRows())
+	assert.Len(t, f.RowGroups(), 2)
+
+	// 列的顺序与字段顺序一致，类型与字段类型对应
+	var names, types []string
+	for _, field := range f.Schema().Fields() {
+		assert.True(t, field.Optional())
+		names = append(names, field.Name())
+		types = append(types, field.Type().String())
+	}
+	assert.Equal(t, []string{"id", "名称", "amount", "big_amount", "score", "enabled", "day", "updated_at", "extra"}, names)
+	assert.Equal(t, []string{"INT(64,true)", "STRING", "DECIMAL(10,2)", "DECIMAL(38,4)", "DOUBLE", "BOOLEAN", "DATE", "TIMESTAMP(isAdjustedToUTC=false,unit=MICROS)", "STRING"}, types)
+
+	r := parquet.NewReader(f)
+	defer r.Close()
+	rows := make([]parquet.Row, 4)
+	n, err := r.ReadRows(rows)
+	if err != io.EOF {
+		assert.NoError(t, err)
+	}
+	if !assert.Equal(t, 3, n) {
+		return
+	}
+
+	row := rows[0]
+	assert.Equal(t, int64(1), row[0].Int64())
+	assert.Equal(t, "名称", row[1].String())
+	assert.Equal(t, int64(1234), row[2].Int64())
+	assert.Equal(t, append(bytes.Repeat([]byte{0xff}, 14), 0xc5, 0x68), row[3].ByteArray())
+	assert.Equal(t, 1.5, row[4].Double())
+	assert.True(t, row[5].Boolean())
+	assert.Equal(t, int32(19844), row[6].Int32())
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 30, 0, 123000000, time.UTC).UnixMicro(), row[7].Int64())
+	assert.Equal(t, "3", row[8].String())
+
+	for _, v := range rows[1][1:] {
+		assert.True(t, v.IsNull())
+	}
+
+	row = rows[2]
+	assert.Equal(t, int64(9007199254740993), row[0].Int64())
+	assert.Equal(t, int64(-50), row[2].Int64())
+	assert.Equal(t, int32(-1), row[6].Int32())
+	assert.False(t, row[5].Boolean())
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC).UnixMicro(), row[7].Int64())
+	assert.Equal(t, "true", row[8].String())
+}
+
+func TestDecimalParams(t *testing.T) {
+	precision, scale, ok := decimalParams("10, 2)")
+	assert.True(t, ok)
+	assert.Equal(t, []int{10, 2}, []int{precision, scale})
+	precision, scale, ok = decimalParams("18)")
+	assert.True(t, ok)
+	assert.Equal(t, []int{18, 0}, []int{precision, scale})
+	_, _, ok = decimalParams("")
+	assert.False(t, ok)
+	_, _, ok = decimalParams("40,2)")
+	assert.False(t, ok)
+}
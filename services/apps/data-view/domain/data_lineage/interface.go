@@ -8,4 +8,8 @@ type UseCase interface {
 	GetBase(ctx context.Context, req *GetBaseReqParam) (*GetBaseResp, error)
 	ListLineage(ctx context.Context, req *ListLineageReqParam) (*ListLineageResp, error)
 	ParserLineage(ctx context.Context, req *ParseLineageParamReq) (any, error)
+	// ImportOpenLineage 导入 OpenLineage 运行事件，将输出数据集的字段级血缘合并到血缘图谱
+	ImportOpenLineage(ctx context.Context, req *ImportOpenLineageReq) (*ImportOpenLineageResp, error)
+	// ExportOpenLineage 将视图的字段级血缘导出为 OpenLineage 运行事件
+	ExportOpenLineage(ctx context.Context, req *ExportOpenLineageReq) (*OpenLineageRunEvent, error)
 }
@@ -0,0 +1,272 @@
+package data_lineage
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/samber/lo"
+)
+
+const (
+	OpenLineageNamespace = "data-view" // 导出的作业以及 sql 解析的血缘中数据集所在的命名空间，数据集名称为虚拟化引擎中的 catalog.schema.table
+	OpenLineageProducer  = "https://github.com/kweaver-ai/dsg/services/apps/data-view"
+	OpenLineageSchemaURL = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/RunEvent"
+
+	OpenLineageEventComplete = "COMPLETE" // 只有运行成功的事件才合并到血缘中
+
+	OpenLineageTransformationIdentity       = "IDENTITY"
+	OpenLineageTransformationTransformation = "TRANSFORMATION"
+	OpenLineageTransformationDirect         = "DIRECT"
+
+	OpenLineageImportMerged  = "merged"
+	OpenLineageImportIgnored = "ignored"
+)
+
+//region OpenLineage
+
+// OpenLineageRunEvent OpenLineage 运行事件，只包含血缘用到的部分
+type OpenLineageRunEvent struct {
+	EventType string                `json:"eventType" binding:"required"`
+	EventTime string                `json:"eventTime" binding:"required"`
+	Run       OpenLineageRun        `json:"run"`
+	Job       OpenLineageJob        `json:"job"`
+	Inputs    []*OpenLineageDataset `json:"inputs"`
+	Outputs   []*OpenLineageDataset `json:"outputs"`
+	Producer  string                `json:"producer" binding:"required"`
+	SchemaURL string                `json:"schemaURL"`
+}
+
+type OpenLineageRun struct {
+	RunID string `json:"runId" binding:"required"`
+}
+
+type OpenLineageJob struct {
+	Namespace string `json:"namespace" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+}
+
+type OpenLineageDataset struct {
+	Namespace string                   `json:"namespace" binding:"required"`
+	Name      string                   `json:"name" binding:"required"`
+	Facets    *OpenLineageDatasetFacet `json:"facets,omitempty"`
+}
+
+type OpenLineageDatasetFacet struct {
+	Schema        *OpenLineageSchemaFacet        `json:"schema,omitempty"`
+	ColumnLineage *OpenLineageColumnLineageFacet `json:"columnLineage,omitempty"`
+}
+
+type OpenLineageSchemaFacet struct {
+	Producer  string                    `json:"_producer"`
+	SchemaURL string                    `json:"_schemaURL"`
+	Fields    []*OpenLineageSchemaField `json:"fields"`
+}
+
+type OpenLineageSchemaField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenLineageColumnLineageFacet struct {
+	Producer  string                                    `json:"_producer"`
+	SchemaURL string                                    `json:"_schemaURL"`
+	Fields    map[string]*OpenLineageColumnLineageField `json:"fields"` // key 为输出字段名
+}
+
+type OpenLineageColumnLineageField struct {
+	InputFields               []*OpenLineageInputField `json:"inputFields"`
+	TransformationDescription string                   `json:"transformationDescription,omitempty"`
+	TransformationType        string                   `json:"transformationType,omitempty"`
+}
+
+type OpenLineageInputField struct {
+	Namespace       string                       `json:"namespace"`
+	Name            string                       `json:"name"`
+	Field           string                       `json:"field"`
+	Transformations []*OpenLineageTransformation `json:"transformations,omitempty"`
+}
+
+type OpenLineageTransformation struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+//endregion
+
+//region ImportOpenLineage
+
+type ImportOpenLineageReq struct {
+	OpenLineageRunEvent `param_type:"body"`
+}
+
+type ImportOpenLineageResp struct {
+	Status   string                      `json:"status"`   // 处理结果，merged：已合并，ignored：非 COMPLETE 事件，未处理
+	Outputs  []*ImportedOpenLineageTable `json:"outputs"`  // 合并了字段级血缘的输出数据集
+	Unmapped []string                    `json:"unmapped"` // 没有对应到元数据视图的输出数据集，namespace/name
+}
+
+type ImportedOpenLineageTable struct {
+	Namespace  string `json:"namespace"`    // 数据集命名空间
+	Name       string `json:"name"`         // 数据集名称
+	FormViewID string `json:"form_view_id"` // 对应的视图id
+	FieldCount int    `json:"field_count"`  // 合并了血缘的字段数量
+}
+
+//endregion
+
+//region ExportOpenLineage
+
+type ExportOpenLineageReq struct {
+	IDReqParamPath `param_type:"path"`
+}
+
+//endregion
+
+// SplitDatasetName 将数据集名称拆分为 catalog（库）、schema、表名，缺少的部分为空
+func SplitDatasetName(name string) (catalog, schema, table string) {
+	parts := strings.Split(name, ".")
+	table = parts[len(parts)-1]
+	if len(parts) >= 2 {
+		schema = parts[len(parts)-2]
+	}
+	if len(parts) >= 3 {
+		catalog = parts[len(parts)-3]
+	}
+	return
+}
+
+// MatchDatasource 根据数据集的命名空间（如 mysql://host:3306）和库、schema 找到唯一的数据源，无法唯一确定时返回 nil
+func MatchDatasource(datasources []*model.Datasource, namespace, database, schema string) *model.Datasource {
+	var host, port string
+	if u, err := url.Parse(namespace); err == nil {
+		host, port = u.Hostname(), u.Port()
+	}
+	matched := make([]*model.Datasource, 0)
+	for _, ds := range datasources {
+		if host != "" && !strings.EqualFold(ds.Host, host) {
+			continue
+		}
+		if port != "" && strconv.Itoa(int(ds.Port)) != port {
+			continue
+		}
+		if database != "" {
+			if !strings.EqualFold(ds.DatabaseName, database) && !strings.EqualFold(ds.CatalogName, database) {
+				continue
+			}
+			if !strings.EqualFold(ds.Schema, schema) {
+				continue
+			}
+		} else if !strings.EqualFold(ds.Schema, schema) && !strings.EqualFold(ds.DatabaseName, schema) {
+			continue
+		}
+		matched = append(matched, ds)
+	}
+	if len(matched) != 1 {
+		return nil
+	}
+	return matched[0]
+}
+
+// NewOpenLineageRunEvent 将视图及其字段级血缘转换为 OpenLineage 运行事件，datasetName 为视图在虚拟化引擎中的 catalog.schema.table。
+// 通过 OpenLineage 导入的血缘使用导入时的数据集命名空间和名称，sql 解析的血缘使用本服务的命名空间
+func NewOpenLineageRunEvent(view *model.FormView, datasetName string, fields []*model.FormViewField, lineages []*model.LineageField) *OpenLineageRunEvent {
+	fieldNames := lo.SliceToMap(fields, func(f *model.FormViewField) (string, string) { return f.ID, f.TechnicalName })
+	columnLineage := make(map[string]*OpenLineageColumnLineageField)
+	inputs := make([]*OpenLineageDataset, 0)
+	outputNamespace := OpenLineageNamespace
+	for _, l := range lineages {
+		if l.OutputNamespace != "" {
+			outputNamespace, datasetName = l.OutputNamespace, l.OutputName
+		}
+		namespace := datasetNamespace(l.SourceNamespace)
+		name := l.FieldName
+		if n, ok := fieldNames[l.FieldID]; ok {
+			name = n
+		}
+		column, ok := columnLineage[name]
+		if !ok {
+			column = &OpenLineageColumnLineageField{TransformationType: OpenLineageTransformationIdentity}
+			columnLineage[name] = column
+		}
+		subtype := OpenLineageTransformationIdentity
+		if l.Expression != "" {
+			subtype = OpenLineageTransformationTransformation
+			column.TransformationType = OpenLineageTransformationTransformation
+			column.TransformationDescription = l.Expression
+		}
+		column.InputFields = append(column.InputFields, &OpenLineageInputField{
+			Namespace: namespace,
+			Name:      l.SourceTable,
+			Field:     l.SourceColumn,
+			Transformations: []*OpenLineageTransformation{
+				{Type: OpenLineageTransformationDirect, Subtype: subtype, Description: l.Expression},
+			},
+		})
+		if !lo.ContainsBy(inputs, func(d *OpenLineageDataset) bool { return d.Namespace == namespace && d.Name == l.SourceTable }) {
+			inputs = append(inputs, &OpenLineageDataset{Namespace: namespace, Name: l.SourceTable})
+		}
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		if inputs[i].Namespace != inputs[j].Namespace {
+			return inputs[i].Namespace < inputs[j].Namespace
+		}
+		return inputs[i].Name < inputs[j].Name
+	})
+
+	schema := &OpenLineageSchemaFacet{Producer: OpenLineageProducer, SchemaURL: "https://openlineage.io/spec/facets/1-1-1/SchemaDatasetFacet.json#/$defs/SchemaDatasetFacet"}
+	for _, f := range fields {
+		schema.Fields = append(schema.Fields, &OpenLineageSchemaField{Name: f.TechnicalName, Type: f.DataType, Description: f.BusinessName})
+	}
+	facet := &OpenLineageDatasetFacet{Schema: schema}
+	if len(columnLineage) > 0 {
+		facet.ColumnLineage = &OpenLineageColumnLineageFacet{
+			Producer:  OpenLineageProducer,
+			SchemaURL: "https://openlineage.io/spec/facets/1-2-0/ColumnLineageDatasetFacet.json#/$defs/ColumnLineageDatasetFacet",
+			Fields:    columnLineage,
+		}
+	}
+	return &OpenLineageRunEvent{
+		EventType: OpenLineageEventComplete,
+		EventTime: view.UpdatedAt.Format(time.RFC3339),
+		Run:       OpenLineageRun{RunID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(view.ID+view.UpdatedAt.String())).String()},
+		Job:       OpenLineageJob{Namespace: OpenLineageNamespace, Name: view.TechnicalName},
+		Inputs:    inputs,
+		Outputs:   []*OpenLineageDataset{{Namespace: outputNamespace, Name: datasetName, Facets: facet}},
+		Producer:  OpenLineageProducer,
+		SchemaURL: OpenLineageSchemaURL,
+	}
+}
+
+// datasetNamespace 血缘记录的数据集命名空间，sql 解析的血缘没有记录命名空间，使用本服务的命名空间
+func datasetNamespace(namespace string) string {
+	if namespace == "" {
+		return OpenLineageNamespace
+	}
+	return namespace
+}
+
+// ImportedLineageExpression 导入的字段级血缘的加工表达式，直接映射时为空
+func ImportedLineageExpression(column *OpenLineageColumnLineageField, input *OpenLineageInputField) string {
+	for _, t := range input.Transformations {
+		if t.Description != "" {
+			return t.Description
+		}
+		if t.Subtype != "" && !strings.EqualFold(t.Subtype, OpenLineageTransformationIdentity) {
+			return t.Subtype
+		}
+	}
+	if column.TransformationDescription != "" {
+		return column.TransformationDescription
+	}
+	if column.TransformationType != "" && !strings.EqualFold(column.TransformationType, OpenLineageTransformationIdentity) {
+		return column.TransformationType
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package data_lineage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+)
+
+func TestSplitDatasetName(t *testing.T) {
+	catalog, schema, table := SplitDatasetName("vdm_maria_abc.default.orders")
+	assert.Equal(t, []string{"vdm_maria_abc", "default", "orders"}, []string{catalog, schema, table})
+	catalog, schema, table = SplitDatasetName("public.orders")
+	assert.Equal(t, []string{"", "public", "orders"}, []string{catalog, schema, table})
+	catalog, schema, table = SplitDatasetName("orders")
+	assert.Equal(t, []string{"", "", "orders"}, []string{catalog, schema, table})
+}
+
+func TestMatchDatasource(t *testing.T) {
+	datasources := []*model.Datasource{
+		{ID: "1", Host: "10.0.0.1", Port: 3306, DatabaseName: "sales", Schema: "sales"},
+		{ID: "2", Host: "10.0.0.1", Port: 5432, DatabaseName: "dw", Schema: "public"},
+		{ID: "3", Host: "10.0.0.2", Port: 5432, DatabaseName: "dw", Schema: "public"},
+	}
+	if ds := MatchDatasource(datasources, "mysql://10.0.0.1:3306", "", "sales"); assert.NotNil(t, ds) {
+		assert.Equal(t, "1", ds.ID)
+	}
+	if ds := MatchDatasource(datasources, "postgres://10.0.0.2:5432", "dw", "public"); assert.NotNil(t, ds) {
+		assert.Equal(t, "3", ds.ID)
+	}
+	assert.Nil(t, MatchDatasource(datasources, "spark", "dw", "public"))
+	assert.Nil(t, MatchDatasource(datasources, "postgres://10.0.0.1:5432", "dw", "ods"))
+}
+
+func TestNewOpenLineageRunEvent(t *testing.T) {
+	view := &model.FormView{ID: "v1", TechnicalName: "order_summary", UpdatedAt: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)}
+	fields := []*model.FormViewField{
+		{ID: "f1", TechnicalName: "id", DataType: "bigint"},
+		{ID: "f2", TechnicalName: "amount", DataType: "decimal"},
+	}
+	lineages := []*model.LineageField{
+		{FieldID: "f1", SourceTable: "c.s.orders", SourceColumn: "id"},
+		{FieldID: "f2", SourceTable: "c.s.orders", SourceColumn: "price", Expression: "price * qty"},
+		{FieldID: "f2", SourceTable: "c.s.orders", SourceColumn: "qty", Expression: "price * qty"},
+	}
+
+	event := NewOpenLineageRunEvent(view, "custom_view_source.default.order_summary", fields, lineages)
+	assert.Equal(t, OpenLineageEventComplete, event.EventType)
+	assert.Equal(t, "2024-05-01T08:00:00Z", event.EventTime)
+	if assert.Len(t, event.Inputs, 1) {
+		assert.Equal(t, OpenLineageNamespace, event.Inputs[0].Namespace)
+		assert.Equal(t, "c.s.orders", event.Inputs[0].Name)
+	}
+	if !assert.Len(t, event.Outputs, 1) {
+		return
+	}
+	assert.Equal(t, OpenLineageNamespace, event.Outputs[0].Namespace)
+	assert.Equal(t, "custom_view_source.default.order_summary", event.Outputs[0].Name)
+	facets := event.Outputs[0].Facets
+	assert.Len(t, facets.Schema.Fields, 2)
+	columns := facets.ColumnLineage.Fields
+	if assert.Len(t, columns, 2) {
+		assert.Equal(t, OpenLineageTransformationIdentity, columns["id"].TransformationType)
+		assert.Equal(t, OpenLineageTransformationTransformation, columns["amount"].TransformationType)
+		assert.Len(t, columns["amount"].InputFields, 2)
+		assert.Equal(t, "price * qty", ImportedLineageExpression(columns["amount"], columns["amount"].InputFields[0]))
+		assert.Equal(t, "", ImportedLineageExpression(columns["id"], columns["id"].InputFields[0]))
+	}
+}
+
+// 导入的血缘导出时使用导入时的数据集命名空间和名称
+func TestNewOpenLineageRunEvent_ImportedNamespace(t *testing.T) {
+	view := &model.FormView{ID: "v1", TechnicalName: "orders"}
+	fields := []*model.FormViewField{{ID: "f1", TechnicalName: "id"}, {ID: "f2", TechnicalName: "user_name"}}
+	lineages := []*model.LineageField{
+		{FieldID: "f1", SourceTable: "ods.orders", SourceColumn: "id", SourceNamespace: "postgres://10.0.0.2:5432",
+			OutputNamespace: "mysql://10.0.0.1:3306", OutputName: "sales.orders"},
+		{FieldID: "f2", SourceTable: "ods.users", SourceColumn: "name", SourceNamespace: "hive://warehouse",
+			OutputNamespace: "mysql://10.0.0.1:3306", OutputName: "sales.orders"},
+	}
+
+	event := NewOpenLineageRunEvent(view, "vdm_maria_abc.default.orders", fields, lineages)
+	if assert.Len(t, event.Inputs, 2) {
+		assert.Equal(t, &OpenLineageDataset{Namespace: "hive://warehouse", Name: "ods.users"}, event.Inputs[0])
+		assert.Equal(t, &OpenLineageDataset{Namespace: "postgres://10.0.0.2:5432", Name: "ods.orders"}, event.Inputs[1])
+	}
+	if assert.Len(t, event.Outputs, 1) {
+		assert.Equal(t, "mysql://10.0.0.1:3306", event.Outputs[0].Namespace)
+		assert.Equal(t, "sales.orders", event.Outputs[0].Name)
+	}
+	assert.Equal(t, "hive://warehouse", event.Outputs[0].Facets.ColumnLineage.Fields["user_name"].InputFields[0].Namespace)
+	assert.Equal(t, OpenLineageNamespace, event.Job.Namespace)
+}
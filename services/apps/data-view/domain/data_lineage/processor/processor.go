@@ -9,6 +9,8 @@ import (
 	"github.com/jinzhu/copier"
 	datasourceRpoo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/datasource"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_field"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/lineage_field"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/user"
 	scene_analysis "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/scene_analysis"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
@@ -22,13 +24,15 @@ import (
 )
 
 type FormViewInfoFetcher struct {
-	formViewRepo   form_view.FormViewRepo
-	db             *gorm.DB
-	userRepo       user.UserRepo
-	datasourceRepo datasourceRpoo.DatasourceRepo
-	cc             configuration_center.Driven
-	sa             scene_analysis.SceneAnalysisDriven
-	poolDict       map[string]*sync.Pool
+	formViewRepo     form_view.FormViewRepo
+	fieldRepo        form_view_field.FormViewFieldRepo
+	lineageFieldRepo lineage_field.LineageFieldRepo
+	db               *gorm.DB
+	userRepo         user.UserRepo
+	datasourceRepo   datasourceRpoo.DatasourceRepo
+	cc               configuration_center.Driven
+	sa               scene_analysis.SceneAnalysisDriven
+	poolDict         map[string]*sync.Pool
 }
 
 func NewFormViewInfoFetcher(c configuration_center.Driven,
@@ -37,15 +41,19 @@ func NewFormViewInfoFetcher(c configuration_center.Driven,
 	userRepo user.UserRepo,
 	datasourceRepo datasourceRpoo.DatasourceRepo,
 	sa scene_analysis.SceneAnalysisDriven,
+	fieldRepo form_view_field.FormViewFieldRepo,
+	lineageFieldRepo lineage_field.LineageFieldRepo,
 ) *FormViewInfoFetcher {
 	return &FormViewInfoFetcher{
-		formViewRepo:   formViewRepo,
-		db:             db,
-		userRepo:       userRepo,
-		datasourceRepo: datasourceRepo,
-		cc:             c,
-		sa:             sa,
-		poolDict:       make(map[string]*sync.Pool),
+		formViewRepo:     formViewRepo,
+		fieldRepo:        fieldRepo,
+		lineageFieldRepo: lineageFieldRepo,
+		db:               db,
+		userRepo:         userRepo,
+		datasourceRepo:   datasourceRepo,
+		cc:               c,
+		sa:               sa,
+		poolDict:         make(map[string]*sync.Pool),
 	}
 }
 
@@ -0,0 +1,251 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/data_lineage/processor/sqlparser"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/idrm-go-common/database_callback/callback"
+	"github.com/kweaver-ai/idrm-go-common/database_callback/data_lineage"
+	"github.com/kweaver-ai/idrm-go-common/util"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// SyncSQLFieldLineage 解析自定义视图、逻辑实体视图的 sql，用得到的字段级血缘覆盖 lineage_field 中的记录
+func (f FormViewInfoFetcher) SyncSQLFieldLineage(ctx context.Context, formViewID string) ([]*model.LineageField, error) {
+	view, err := f.formViewRepo.GetExistedViewByID(ctx, formViewID)
+	if err != nil {
+		return nil, err
+	}
+	if view.Type == constant.FormViewTypeDatasource.Integer.Int32() {
+		return nil, nil
+	}
+	viewSQLs := make([]*model.FormViewSql, 0)
+	if err = f.db.WithContext(ctx).Where("form_view_id = ?", formViewID).Find(&viewSQLs).Error; err != nil {
+		return nil, err
+	}
+	if len(viewSQLs) == 0 {
+		return nil, f.lineageFieldRepo.DeleteByFormViewID(ctx, formViewID)
+	}
+	columns, err := sqlparser.Parse(viewSQLs[0].Sql)
+	if err != nil {
+		return nil, fmt.Errorf("parse form view %v sql error %v", formViewID, err)
+	}
+	fields, err := f.fieldRepo.GetFormViewFields(ctx, formViewID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &sourceResolver{fetcher: f, views: make(map[string]*model.FormView), fields: make(map[string][]*model.FormViewField)}
+	lineages := make([]*model.LineageField, 0)
+	matched := make(map[string]bool)
+	for _, column := range columns {
+		if column.Name == "*" {
+			continue
+		}
+		field := findField(fields, column.Name)
+		if field != nil {
+			matched[field.ID] = true
+		}
+		for _, source := range column.Sources {
+			lineage, _ := resolver.lineage(ctx, formViewID, field, column.Name, source, column.Expression)
+			lineages = append(lineages, lineage)
+		}
+	}
+	// select * 透传的字段，按字段名对应来源字段
+	for _, column := range columns {
+		if column.Name != "*" {
+			continue
+		}
+		for _, field := range fields {
+			if matched[field.ID] {
+				continue
+			}
+			for _, source := range column.Sources {
+				ref := sqlparser.ColumnRef{Table: source.Table, Column: field.TechnicalName}
+				if lineage, ok := resolver.lineage(ctx, formViewID, field, field.TechnicalName, ref, ""); ok {
+					lineages = append(lineages, lineage)
+				}
+			}
+		}
+	}
+	if err = f.lineageFieldRepo.Replace(ctx, formViewID, lineages); err != nil {
+		return nil, err
+	}
+	return lineages, nil
+}
+
+// ViewFieldLineage 获取视图的字段级血缘，自定义视图、逻辑实体视图还没有解析结果时先解析一次
+func (f FormViewInfoFetcher) ViewFieldLineage(ctx context.Context, view *model.FormView) ([]*model.LineageField, error) {
+	lineages, err := f.lineageFieldRepo.GetByFormViewID(ctx, view.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lineages) == 0 && view.Type != constant.FormViewTypeDatasource.Integer.Int32() {
+		return f.SyncSQLFieldLineage(ctx, view.ID)
+	}
+	return lineages, nil
+}
+
+// MergeImportedFieldLineage 用导入的字段级血缘覆盖元数据视图中对应字段的记录，事件中没有出现的字段保留原有血缘，
+// 返回需要更新到血缘图谱的视图字段
+func (f FormViewInfoFetcher) MergeImportedFieldLineage(ctx context.Context, formViewID string, lineages []*model.LineageField) (*callback.DataLineageContent, error) {
+	fieldIDs := lo.Uniq(lo.FilterMap(lineages, func(l *model.LineageField, _ int) (string, bool) { return l.FieldID, l.FieldID != "" }))
+	if err := f.lineageFieldRepo.ReplaceFields(ctx, formViewID, fieldIDs, lineages); err != nil {
+		return nil, err
+	}
+	content := &callback.DataLineageContent{
+		Type:      data_lineage.ChangeOptionUpdate,
+		ClassName: callback.LineageEntityTypeField,
+		Entities:  make([]any, 0),
+	}
+	for _, id := range fieldIDs {
+		data, err := f.handlerFormViewField(ctx, callback.DataModel{new(model.FormViewField).UniqueKey(): id}, data_lineage.ChangeOptionUpdate)
+		if err != nil {
+			return nil, err
+		}
+		content.Entities = append(content.Entities, data)
+	}
+	return content, nil
+}
+
+// sqlColumnUniqueID 根据 sql 解析得到的字段级血缘生成来源字段和表达式
+func (f FormViewInfoFetcher) sqlColumnUniqueID(formViewID string, formViewField *model.FormViewField) (string, string) {
+	ctx := context.Background()
+	view, err := f.formViewRepo.GetExistedViewByID(ctx, formViewID)
+	if err != nil {
+		log.Errorf("query form view %v error %v", formViewID, err.Error())
+		return "", ""
+	}
+	lineages, err := f.ViewFieldLineage(ctx, view)
+	if err != nil {
+		log.Warnf("query field lineage of %v error %v", formViewID, err.Error())
+		return "", ""
+	}
+	return fieldLineageRefs(lineages, formViewField.ID)
+}
+
+// importedColumnUniqueID 元数据视图通过 OpenLineage 导入的字段级血缘
+func (f FormViewInfoFetcher) importedColumnUniqueID(formViewID string, formViewField *model.FormViewField) (string, string) {
+	lineages, err := f.lineageFieldRepo.GetByFormViewID(context.Background(), formViewID)
+	if err != nil {
+		log.Warnf("query field lineage of %v error %v", formViewID, err.Error())
+		return "", ""
+	}
+	return fieldLineageRefs(lineages, formViewField.ID)
+}
+
+func fieldLineageRefs(lineages []*model.LineageField, fieldID string) (string, string) {
+	refs := make([]string, 0)
+	exprs := make([]string, 0)
+	for _, lineage := range lineages {
+		if lineage.FieldID != fieldID {
+			continue
+		}
+		refs = append(refs, lineageRef(lineage))
+		if lineage.Expression != "" {
+			exprs = append(exprs, lineage.Expression)
+		}
+	}
+	return strings.Join(lo.Uniq(refs), ","), strings.Join(lo.Uniq(exprs), ",")
+}
+
+// lineageRef 来源字段匹配到视图字段时使用字段id，否则与元数据视图一样使用 catalog、schema、表名、字段名的摘要
+func lineageRef(lineage *model.LineageField) string {
+	if lineage.SourceFieldID != "" {
+		return lineage.SourceFieldID
+	}
+	return util.MD5(strings.ToLower(strings.ReplaceAll(lineage.SourceTable, ".", "") + lineage.SourceColumn))
+}
+
+func findField(fields []*model.FormViewField, technicalName string) *model.FormViewField {
+	for _, field := range fields {
+		if strings.EqualFold(field.TechnicalName, technicalName) {
+			return field
+		}
+	}
+	return nil
+}
+
+// sourceResolver 将 sql 中的来源表、来源字段对应到视图、视图字段，查询结果在一次解析内缓存
+type sourceResolver struct {
+	fetcher FormViewInfoFetcher
+	views   map[string]*model.FormView
+	fields  map[string][]*model.FormViewField
+}
+
+// lineage 生成一条字段级血缘，来源表对应到视图但视图中没有该字段时返回 false
+func (r *sourceResolver) lineage(ctx context.Context, formViewID string, field *model.FormViewField, name string, source sqlparser.ColumnRef, expression string) (*model.LineageField, bool) {
+	lineage := &model.LineageField{
+		FormViewID:   formViewID,
+		FieldName:    name,
+		SourceTable:  source.Table.String(),
+		SourceColumn: source.Column,
+		Expression:   expression,
+	}
+	if field != nil {
+		lineage.FieldID = field.ID
+	}
+	view := r.view(ctx, source.Table)
+	if view == nil {
+		return lineage, true
+	}
+	lineage.SourceViewID = view.ID
+	fields, ok := r.fields[view.ID]
+	if !ok {
+		var err error
+		if fields, err = r.fetcher.fieldRepo.GetFormViewFields(ctx, view.ID); err != nil {
+			log.Warnf("query fields of source view %v error %v", view.ID, err.Error())
+		}
+		r.fields[view.ID] = fields
+	}
+	sourceField := findField(fields, source.Column)
+	if sourceField == nil {
+		return lineage, false
+	}
+	lineage.SourceFieldID = sourceField.ID
+	return lineage, true
+}
+
+// view 查找 catalog.schema.table 对应的视图，找不到时返回 nil
+func (r *sourceResolver) view(ctx context.Context, table sqlparser.Table) *model.FormView {
+	key := strings.ToLower(table.String())
+	if view, ok := r.views[key]; ok {
+		return view
+	}
+	view, err := r.fetcher.QuerySourceView(ctx, table)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Warnf("query source view %v error %v", key, err.Error())
+	}
+	r.views[key] = view
+	return view
+}
+
+// QuerySourceView 查找虚拟化引擎中 catalog.schema.table 对应的视图
+func (f FormViewInfoFetcher) QuerySourceView(ctx context.Context, table sqlparser.Table) (*model.FormView, error) {
+	viewSource := strings.ToLower(table.Catalog + "." + table.Schema)
+	tx := f.db.WithContext(ctx).Where("technical_name = ?", table.Name)
+	switch viewSource {
+	case constant.CustomViewSource + constant.CustomAndLogicEntityViewSourceSchema:
+		tx = tx.Where("type = ?", constant.FormViewTypeCustom.Integer.Int32())
+	case constant.LogicEntityViewSource + constant.CustomAndLogicEntityViewSourceSchema:
+		tx = tx.Where("type = ?", constant.FormViewTypeLogicEntity.Integer.Int32())
+	default:
+		datasource := new(model.Datasource)
+		if err := f.db.WithContext(ctx).Where("data_view_source = ?", viewSource).Take(datasource).Error; err != nil {
+			return nil, err
+		}
+		tx = tx.Where("datasource_id = ?", datasource.ID)
+	}
+	view := new(model.FormView)
+	if err := tx.Take(view).Error; err != nil {
+		return nil, err
+	}
+	return view, nil
+}
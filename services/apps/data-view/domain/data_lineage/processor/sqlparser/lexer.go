@@ -0,0 +1,169 @@
+package sqlparser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenIdent       tokenKind = iota // 未加引号的标识符或关键字
+	tokenQuotedIdent                  // 双引号、反引号包裹的标识符
+	tokenString                       // 字符串字面量
+	tokenNumber                       // 数字字面量
+	tokenSymbol                       // 运算符、标点
+)
+
+type token struct {
+	kind  tokenKind
+	text  string // 原始文本
+	value string // 标识符去掉引号后的值
+}
+
+// keywords 不能作为字段、别名的关键字，只对未加引号的标识符生效
+var keywords = map[string]bool{
+	"select": true, "from": true, "where": true, "group": true, "by": true, "having": true, "order": true,
+	"limit": true, "offset": true, "fetch": true, "union": true, "intersect": true, "except": true,
+	"all": true, "distinct": true, "as": true, "on": true, "using": true, "join": true, "inner": true,
+	"left": true, "right": true, "full": true, "outer": true, "cross": true, "natural": true, "lateral": true,
+	"with": true, "recursive": true, "and": true, "or": true, "not": true, "in": true, "is": true, "null": true,
+	"like": true, "ilike": true, "between": true, "case": true, "when": true, "then": true, "else": true,
+	"end": true, "exists": true, "true": true, "false": true, "interval": true, "escape": true, "asc": true,
+	"desc": true, "nulls": true, "first": true, "last": true, "rows": true, "row": true, "only": true,
+	"window": true, "over": true, "partition": true, "range": true, "preceding": true, "following": true,
+	"unbounded": true, "current": true, "filter": true, "within": true, "tablesample": true, "values": true,
+	"array": true, "at": true, "year": true, "month": true, "day": true, "hour": true, "minute": true,
+	"second": true, "current_date": true, "current_time": true, "current_timestamp": true, "localtime": true,
+	"localtimestamp": true, "current_user": true, "for": true, "both": true, "leading": true, "trailing": true,
+	"placing": true,
+}
+
+func (t token) isSymbol(s string) bool {
+	return t.kind == tokenSymbol && t.text == s
+}
+
+func (t token) isKeyword(words ...string) bool {
+	if t.kind != tokenIdent {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// isName 是否可以作为标识符使用，关键字需要加引号
+func (t token) isName() bool {
+	return t.kind == tokenQuotedIdent || (t.kind == tokenIdent && !keywords[strings.ToLower(t.text)])
+}
+
+// isPart 是否可以作为限定名中的一部分，如 t.year
+func (t token) isPart() bool {
+	return t.kind == tokenQuotedIdent || t.kind == tokenIdent
+}
+
+var multiCharSymbols = []string{"<=", ">=", "<>", "!=", "||", "->", "=>", "::"}
+
+func lex(sql string) ([]token, error) {
+	tokens := make([]token, 0)
+	for i := 0; i < len(sql); {
+		r, size := utf8.DecodeRuneInString(sql[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 1
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at %d", i)
+			}
+			i += end + 4
+		case r == '\'':
+			end, err := quoteEnd(sql, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sql[i:end]})
+			i = end
+		case r == '"' || r == '`':
+			end, err := quoteEnd(sql, i, byte(r))
+			if err != nil {
+				return nil, err
+			}
+			text := sql[i:end]
+			value := strings.ReplaceAll(text[1:len(text)-1], string([]rune{r, r}), string(r))
+			tokens = append(tokens, token{kind: tokenQuotedIdent, text: text, value: value})
+			i = end
+		case isDigit(r) || (r == '.' && i+1 < len(sql) && isDigit(rune(sql[i+1]))):
+			end := i + 1
+			for end < len(sql) {
+				c := sql[end]
+				if isDigit(rune(c)) || c == '.' {
+					end++
+				} else if (c == 'e' || c == 'E') && end+1 < len(sql) && (isDigit(rune(sql[end+1])) || sql[end+1] == '+' || sql[end+1] == '-') {
+					end += 2
+				} else {
+					break
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: sql[i:end]})
+			i = end
+		case isIdentStart(r):
+			end := i + size
+			for end < len(sql) {
+				c, s := utf8.DecodeRuneInString(sql[end:])
+				if !isIdentStart(c) && !isDigit(c) && c != '$' {
+					break
+				}
+				end += s
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: sql[i:end], value: sql[i:end]})
+			i = end
+		default:
+			text := string(r)
+			for _, s := range multiCharSymbols {
+				if strings.HasPrefix(sql[i:], s) {
+					text = s
+					break
+				}
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: text})
+			i += len(text)
+		}
+	}
+	return tokens, nil
+}
+
+// quoteEnd 返回引号包裹内容结束后的位置，连续两个引号表示转义
+func quoteEnd(sql string, start int, quote byte) (int, error) {
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1, nil
+	}
+	return 0, fmt.Errorf("unterminated quote %c at %d", quote, start)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || r >= utf8.RuneSelf && !unicode.IsSpace(r) && !unicode.IsPunct(r) && !unicode.IsSymbol(r)
+}
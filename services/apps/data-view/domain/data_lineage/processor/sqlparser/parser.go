@@ -0,0 +1,883 @@
+// Package sqlparser 解析自定义视图、逻辑实体视图的 sql，得到输出字段的字段级血缘
+package sqlparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Table 被引用的表，catalog、schema 在 sql 中未写明时为空
+type Table struct {
+	Catalog string `json:"catalog"`
+	Schema  string `json:"schema"`
+	Name    string `json:"name"`
+}
+
+func (t Table) String() string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{t.Catalog, t.Schema, t.Name} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// ColumnRef 来源字段，Column 为 * 时表示引用了来源表的全部字段
+type ColumnRef struct {
+	Table  Table  `json:"table"`
+	Column string `json:"column"`
+}
+
+func (c ColumnRef) String() string {
+	return c.Table.String() + "." + c.Column
+}
+
+// Column 查询的输出字段
+type Column struct {
+	Name       string      `json:"name"`       // 输出字段名，未指定别名的表达式为 _col{序号}，对来源表 * 的透传为 *
+	Expression string      `json:"expression"` // 加工表达式，直接引用来源字段时为空
+	Sources    []ColumnRef `json:"sources"`    // 来源字段
+}
+
+// Parse 解析查询 sql，返回每个输出字段的来源字段
+//
+// 支持表达式、别名、join、union、with、from 和 select 中的子查询。
+// 未加限定的字段在多张来源表中无法确定时，会同时记录为这些表的字段
+func Parse(sql string) ([]*Column, error) {
+	tokens, err := lex(sql)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	rel, err := p.parseQuery(nil)
+	if err != nil {
+		return nil, err
+	}
+	p.acceptSymbol(";")
+	if !p.eof() {
+		return nil, fmt.Errorf("unexpected token %q", p.peek().text)
+	}
+	return rel.columns, nil
+}
+
+// relation from 中的一张表或一个子查询
+type relation struct {
+	alias   string
+	table   *Table    // 来源表，子查询时为空
+	columns []*Column // 子查询的输出字段
+}
+
+// column 查找关系中的字段，返回来源字段和加工表达式
+func (r *relation) column(name string) ([]ColumnRef, string, bool) {
+	if r.table != nil {
+		return []ColumnRef{{Table: *r.table, Column: name}}, "", true
+	}
+	for _, c := range r.columns {
+		if strings.EqualFold(c.Name, name) {
+			return c.Sources, c.Expression, true
+		}
+	}
+	// 子查询透传了来源表的 *
+	refs := make([]ColumnRef, 0)
+	for _, c := range r.columns {
+		if c.Name != "*" {
+			continue
+		}
+		for _, s := range c.Sources {
+			refs = append(refs, ColumnRef{Table: s.Table, Column: name})
+		}
+	}
+	return refs, "", len(refs) > 0
+}
+
+type scope struct {
+	parent    *scope
+	ctes      map[string]*relation
+	relations []*relation
+}
+
+func (s *scope) cte(name string) *relation {
+	for sc := s; sc != nil; sc = sc.parent {
+		if r, ok := sc.ctes[strings.ToLower(name)]; ok {
+			return r
+		}
+	}
+	return nil
+}
+
+// resolve 按作用域由内向外查找字段
+func (s *scope) resolve(parts []string) ([]ColumnRef, string, bool) {
+	for sc := s; sc != nil; sc = sc.parent {
+		if refs, expr, ok := sc.resolveLocal(parts); ok {
+			return refs, expr, true
+		}
+	}
+	return nil, "", false
+}
+
+func (s *scope) resolveLocal(parts []string) ([]ColumnRef, string, bool) {
+	name, qualifier := parts[len(parts)-1], parts[:len(parts)-1]
+	if len(qualifier) > 0 {
+		r := s.relation(qualifier)
+		if r == nil {
+			return nil, "", false
+		}
+		return r.column(name)
+	}
+	var refs []ColumnRef
+	var expr string
+	var found bool
+	for _, r := range s.relations {
+		if r.table != nil {
+			continue
+		}
+		if rs, e, ok := r.column(name); ok {
+			refs, expr, found = append(refs, rs...), e, true
+		}
+	}
+	if found {
+		return uniqueRefs(refs), expr, true
+	}
+	for _, r := range s.relations {
+		if r.table != nil {
+			refs = append(refs, ColumnRef{Table: *r.table, Column: name})
+		}
+	}
+	return refs, "", len(refs) > 0
+}
+
+// relation 根据别名或表名查找关系
+func (s *scope) relation(qualifier []string) *relation {
+	if len(qualifier) == 1 {
+		for _, r := range s.relations {
+			if strings.EqualFold(r.alias, qualifier[0]) {
+				return r
+			}
+		}
+	}
+	for _, r := range s.relations {
+		if r.table == nil || len(qualifier) > 3 {
+			continue
+		}
+		names := []string{r.table.Catalog, r.table.Schema, r.table.Name}
+		matched := true
+		for i := range qualifier {
+			if !strings.EqualFold(names[len(names)-1-i], qualifier[len(qualifier)-1-i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return r
+		}
+	}
+	return nil
+}
+
+// expandStar 展开 * 或 t.*，来源表的字段未知，保留为 *
+func (s *scope) expandStar(qualifier []string) ([]*Column, error) {
+	relations := s.relations
+	if len(qualifier) > 0 {
+		r := s.relation(qualifier)
+		if r == nil {
+			return nil, fmt.Errorf("unknown relation %q", strings.Join(qualifier, "."))
+		}
+		relations = []*relation{r}
+	}
+	columns := make([]*Column, 0)
+	for _, r := range relations {
+		if r.table != nil {
+			columns = append(columns, &Column{Name: "*", Sources: []ColumnRef{{Table: *r.table, Column: "*"}}})
+			continue
+		}
+		for _, c := range r.columns {
+			columns = append(columns, &Column{Name: c.Name, Expression: c.Expression, Sources: c.Sources})
+		}
+	}
+	return columns, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.peekAt(0)
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return token{kind: tokenSymbol}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) acceptKeyword(words ...string) bool {
+	if p.peek().isKeyword(words...) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptSymbol(s string) bool {
+	if p.peek().isSymbol(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectSymbol(s string) error {
+	if !p.acceptSymbol(s) {
+		return p.unexpected(s)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(want string) error {
+	if p.eof() {
+		return fmt.Errorf("expected %s, got end of sql", want)
+	}
+	return fmt.Errorf("expected %s, got %q", want, p.peek().text)
+}
+
+// skipUntil 跳过 token，直到括号外遇到 stop 为真的 token 或右括号
+func (p *parser) skipUntil(stop func(t token) bool) {
+	depth := 0
+	for !p.eof() {
+		t := p.peek()
+		if depth == 0 && (t.isSymbol(")") || t.isSymbol(";") || stop(t)) {
+			return
+		}
+		if t.isSymbol("(") {
+			depth++
+		} else if t.isSymbol(")") {
+			depth--
+		}
+		p.pos++
+	}
+}
+
+// name 解析限定名 a.b.c
+func (p *parser) name() ([]string, error) {
+	if !p.peek().isPart() {
+		return nil, p.unexpected("identifier")
+	}
+	parts := []string{p.next().value}
+	for p.peek().isSymbol(".") && p.peekAt(1).isPart() {
+		p.pos++
+		parts = append(parts, p.next().value)
+	}
+	return parts, nil
+}
+
+func (p *parser) parseQuery(parent *scope) (*relation, error) {
+	s := &scope{parent: parent, ctes: make(map[string]*relation)}
+	if p.acceptKeyword("with") {
+		p.acceptKeyword("recursive")
+		for {
+			if !p.peek().isName() {
+				return nil, p.unexpected("cte name")
+			}
+			name := p.next().value
+			aliases, err := p.columnAliases()
+			if err != nil {
+				return nil, err
+			}
+			if !p.acceptKeyword("as") {
+				return nil, p.unexpected("AS")
+			}
+			if err = p.expectSymbol("("); err != nil {
+				return nil, err
+			}
+			r, err := p.parseQuery(s)
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectSymbol(")"); err != nil {
+				return nil, err
+			}
+			s.ctes[strings.ToLower(name)] = &relation{columns: renameColumns(r.columns, aliases)}
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	r, err := p.parseSetOperation(s)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("order", "limit", "offset", "fetch") {
+		p.pos++
+		p.skipUntil(func(t token) bool {
+			return t.isKeyword("order", "limit", "offset", "fetch", "union", "intersect", "except")
+		})
+	}
+	return r, nil
+}
+
+// parseSetOperation 解析 union、intersect、except，只有 union 两侧的字段都会流入输出
+func (p *parser) parseSetOperation(s *scope) (*relation, error) {
+	first, err := p.parseQueryTerm(s)
+	if err != nil {
+		return nil, err
+	}
+	branches := []*relation{first}
+	for p.peek().isKeyword("union", "intersect", "except") {
+		union := p.next().isKeyword("union")
+		p.acceptKeyword("all", "distinct")
+		r, err := p.parseQueryTerm(s)
+		if err != nil {
+			return nil, err
+		}
+		if union {
+			branches = append(branches, r)
+			continue
+		}
+		branches = []*relation{mergeUnion(branches)}
+	}
+	return mergeUnion(branches), nil
+}
+
+func (p *parser) parseQueryTerm(s *scope) (*relation, error) {
+	if p.acceptSymbol("(") {
+		r, err := p.parseQuery(s)
+		if err != nil {
+			return nil, err
+		}
+		return r, p.expectSymbol(")")
+	}
+	if p.peek().isKeyword("select") {
+		return p.parseSelect(s)
+	}
+	return nil, p.unexpected("SELECT")
+}
+
+var selectItemsEnd = []string{"from", "where", "group", "having", "window", "order", "limit", "offset", "fetch", "union", "intersect", "except"}
+
+func (p *parser) parseSelect(parent *scope) (*relation, error) {
+	p.pos++
+	p.acceptKeyword("distinct", "all")
+	items := make([][]token, 0)
+	start, depth := p.pos, 0
+	for !p.eof() {
+		t := p.peek()
+		if depth == 0 && (t.isSymbol(")") || t.isSymbol(";") || t.isKeyword(selectItemsEnd...)) {
+			break
+		}
+		if t.isSymbol("(") {
+			depth++
+		} else if t.isSymbol(")") {
+			depth--
+		} else if depth == 0 && t.isSymbol(",") {
+			items = append(items, p.tokens[start:p.pos])
+			start = p.pos + 1
+		}
+		p.pos++
+	}
+	items = append(items, p.tokens[start:p.pos])
+
+	s := &scope{parent: parent}
+	if p.acceptKeyword("from") {
+		if err := p.parseFrom(s); err != nil {
+			return nil, err
+		}
+	}
+	p.skipUntil(func(t token) bool {
+		return t.isKeyword("order", "limit", "offset", "fetch", "union", "intersect", "except")
+	})
+
+	r := &relation{}
+	for _, item := range items {
+		columns, err := s.selectItem(item, len(r.columns))
+		if err != nil {
+			return nil, err
+		}
+		r.columns = append(r.columns, columns...)
+	}
+	return r, nil
+}
+
+func (p *parser) parseFrom(s *scope) error {
+	for {
+		if err := p.parseJoinedTable(s); err != nil {
+			return err
+		}
+		if !p.acceptSymbol(",") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseJoinedTable(s *scope) error {
+	if err := p.parseTableFactor(s); err != nil {
+		return err
+	}
+	for {
+		p.acceptKeyword("natural")
+		switch {
+		case p.acceptKeyword("join"):
+		case p.acceptKeyword("inner", "cross"):
+			if !p.acceptKeyword("join") {
+				return p.unexpected("JOIN")
+			}
+		case p.acceptKeyword("left", "right", "full"):
+			p.acceptKeyword("outer")
+			if !p.acceptKeyword("join") {
+				return p.unexpected("JOIN")
+			}
+		default:
+			return nil
+		}
+		if err := p.parseTableFactor(s); err != nil {
+			return err
+		}
+		if p.acceptKeyword("on") {
+			p.skipUntil(func(t token) bool {
+				return t.isSymbol(",") || t.isKeyword("join", "inner", "cross", "left", "right", "full", "natural") ||
+					t.isKeyword(selectItemsEnd...)
+			})
+		} else if p.acceptKeyword("using") {
+			if _, err := p.columnAliases(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *parser) parseTableFactor(s *scope) error {
+	p.acceptKeyword("lateral")
+	var r *relation
+	switch {
+	case p.peek().isSymbol("(") && p.peekAt(1).isKeyword("select", "with"):
+		p.pos++
+		sub, err := p.parseQuery(s)
+		if err != nil {
+			return err
+		}
+		if err = p.expectSymbol(")"); err != nil {
+			return err
+		}
+		r = &relation{columns: sub.columns}
+	case p.peek().isSymbol("(") && p.peekAt(1).isKeyword("values"):
+		p.pos++
+		p.skipUntil(func(token) bool { return false })
+		if err := p.expectSymbol(")"); err != nil {
+			return err
+		}
+		r = &relation{}
+	case p.peek().isSymbol("("):
+		// 括号包裹的 join
+		p.pos++
+		if err := p.parseJoinedTable(s); err != nil {
+			return err
+		}
+		return p.expectSymbol(")")
+	default:
+		parts, err := p.name()
+		if err != nil {
+			return err
+		}
+		if p.acceptSymbol("(") {
+			// 表函数，如 unnest(...)
+			p.skipUntil(func(token) bool { return false })
+			if err = p.expectSymbol(")"); err != nil {
+				return err
+			}
+			r = &relation{}
+		} else if cte := s.cte(parts[0]); len(parts) == 1 && cte != nil {
+			r = &relation{alias: parts[0], columns: cte.columns}
+		} else {
+			r = &relation{alias: parts[len(parts)-1], table: newTable(parts)}
+		}
+	}
+	if p.acceptKeyword("tablesample") {
+		p.pos++
+		p.skipUntil(func(t token) bool { return !t.isSymbol("(") })
+	}
+	if p.acceptKeyword("as") || p.peek().isName() {
+		if !p.peek().isName() {
+			return p.unexpected("alias")
+		}
+		r.alias = p.next().value
+		aliases, err := p.columnAliases()
+		if err != nil {
+			return err
+		}
+		if r.table == nil && len(r.columns) == 0 {
+			for _, a := range aliases {
+				r.columns = append(r.columns, &Column{Name: a})
+			}
+		} else if r.table == nil {
+			r.columns = renameColumns(r.columns, aliases)
+		}
+	}
+	s.relations = append(s.relations, r)
+	return nil
+}
+
+// columnAliases 解析可选的字段别名列表 (a, b)
+func (p *parser) columnAliases() ([]string, error) {
+	if !p.acceptSymbol("(") {
+		return nil, nil
+	}
+	aliases := make([]string, 0)
+	for {
+		if !p.peek().isPart() {
+			return nil, p.unexpected("column name")
+		}
+		aliases = append(aliases, p.next().value)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	return aliases, p.expectSymbol(")")
+}
+
+// selectItem 解析 select 中的一项，index 为该项第一个输出字段的序号
+func (s *scope) selectItem(item []token, index int) ([]*Column, error) {
+	n := len(item)
+	if n == 0 {
+		return nil, fmt.Errorf("empty select item")
+	}
+	if item[n-1].isSymbol("*") {
+		qualifier := make([]string, 0)
+		for i := 0; i < n-1; i += 2 {
+			if !item[i].isPart() || !item[i+1].isSymbol(".") {
+				return nil, fmt.Errorf("invalid select item %q", formatTokens(item))
+			}
+			qualifier = append(qualifier, item[i].value)
+		}
+		return s.expandStar(qualifier)
+	}
+
+	var alias string
+	expr := item
+	if n >= 3 && item[n-2].isKeyword("as") && item[n-1].isPart() {
+		alias, expr = item[n-1].value, item[:n-2]
+	} else if n >= 2 && item[n-1].isName() && !endsWithCast(item) && (endsWithCast(item[:n-1]) || canPrecedeAlias(item[n-2])) {
+		// x::double precision 中的 precision 属于类型名，x::interval d 中的 d 是别名
+		alias, expr = item[n-1].value, item[:n-1]
+	}
+
+	sources, inherited, err := s.expressionSources(expr)
+	if err != nil {
+		return nil, err
+	}
+	column := &Column{Name: alias, Sources: sources}
+	if parts, ok := columnName(expr); ok {
+		if column.Name == "" {
+			column.Name = parts[len(parts)-1]
+		}
+		column.Expression = inherited
+	} else {
+		if column.Name == "" {
+			column.Name = fmt.Sprintf("_col%d", index)
+			// 未指定别名的 x::int 与 postgresql 一致使用字段名，外层查询可以按字段名引用
+			if parts, ok := columnName(castOperand(expr)); ok {
+				column.Name = parts[len(parts)-1]
+			}
+		}
+		column.Expression = formatTokens(expr)
+	}
+	return []*Column{column}, nil
+}
+
+// castOperand 表达式为 x::type 或 x::type1::type2 时返回被转换的 x，否则返回 nil
+func castOperand(expr []token) []token {
+	for i, t := range expr {
+		if t.isSymbol("(") {
+			return nil
+		}
+		if t.isSymbol("::") {
+			if i == 0 || castChainEnd(expr, i) != len(expr) {
+				return nil
+			}
+			return expr[:i]
+		}
+	}
+	return nil
+}
+
+// endsWithCast 表达式是否以 ::type 结尾
+func endsWithCast(expr []token) bool {
+	depth := 0
+	for i, t := range expr {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case t.isSymbol("::") && depth == 0:
+			if castChainEnd(expr, i) == len(expr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// castChainEnd 从 start 处的 :: 开始跳过连续的类型转换，返回最后一个类型名之后的位置
+func castChainEnd(expr []token, start int) int {
+	i := start
+	for i < len(expr) && expr[i].isSymbol("::") {
+		i = skipTypeName(expr, i+1)
+	}
+	return i
+}
+
+func canPrecedeAlias(t token) bool {
+	switch t.kind {
+	case tokenSymbol:
+		return t.text == ")"
+	case tokenIdent:
+		return t.isName() || t.isKeyword("end", "null", "true", "false")
+	}
+	return true
+}
+
+// columnName 表达式是否只是一个字段引用
+func columnName(expr []token) ([]string, bool) {
+	if len(expr)%2 == 0 {
+		return nil, false
+	}
+	parts := make([]string, 0)
+	for i, t := range expr {
+		if i%2 == 1 {
+			if !t.isSymbol(".") {
+				return nil, false
+			}
+			continue
+		}
+		if (i == 0 && !t.isName()) || !t.isPart() {
+			return nil, false
+		}
+		parts = append(parts, t.value)
+	}
+	return parts, true
+}
+
+// expressionSources 返回表达式引用的来源字段，表达式只是一个字段引用时同时返回该字段的加工表达式
+func (s *scope) expressionSources(expr []token) ([]ColumnRef, string, error) {
+	refs := make([]ColumnRef, 0)
+	var inherited string
+	depth := 0
+	for i := 0; i < len(expr); {
+		t := expr[i]
+		switch {
+		case t.isSymbol("(") && i+1 < len(expr) && expr[i+1].isKeyword("select", "with"):
+			// 标量子查询、exists、in 子查询
+			end := matchingParen(expr, i)
+			if end < 0 {
+				return nil, "", fmt.Errorf("unbalanced parentheses in %q", formatTokens(expr))
+			}
+			sub := &parser{tokens: expr[i+1 : end]}
+			r, err := sub.parseQuery(s)
+			if err != nil {
+				return nil, "", err
+			}
+			if !sub.eof() {
+				return nil, "", fmt.Errorf("unexpected token %q", sub.peek().text)
+			}
+			for _, c := range r.columns {
+				refs = append(refs, c.Sources...)
+			}
+			i = end + 1
+		case t.isSymbol("("):
+			depth++
+			i++
+		case t.isSymbol(")"):
+			depth--
+			i++
+		case t.isKeyword("as") && depth > 0:
+			// cast(x as varchar(10)) 中的类型
+			level := depth
+			for i < len(expr) && !(expr[i].isSymbol(")") && depth == level) {
+				if expr[i].isSymbol("(") {
+					depth++
+				} else if expr[i].isSymbol(")") {
+					depth--
+				}
+				i++
+			}
+		case t.isKeyword("time") && i+1 < len(expr) && expr[i+1].isKeyword("zone"):
+			i += 2
+		case t.isSymbol("::"):
+			// x::varchar(10) 中的类型
+			i = skipTypeName(expr, i+1)
+		case t.isName():
+			parts := []string{t.value}
+			j := i + 1
+			for j+1 < len(expr) && expr[j].isSymbol(".") && expr[j+1].isPart() {
+				parts = append(parts, expr[j+1].value)
+				j += 2
+			}
+			next := token{kind: tokenSymbol}
+			if j < len(expr) {
+				next = expr[j]
+			}
+			i = j
+			if next.isSymbol("(") || next.isSymbol(".") || (len(parts) == 1 && t.kind == tokenIdent && next.kind == tokenString) {
+				// 函数名、t.* 或 date '2020-01-01' 这样的类型字面量
+				continue
+			}
+			if rs, e, ok := s.resolve(parts); ok {
+				refs = append(refs, rs...)
+				inherited = e
+			}
+		default:
+			i++
+		}
+	}
+	return uniqueRefs(refs), inherited, nil
+}
+
+// typeNameWords 多个单词组成的类型名中第一个单词之后的部分，如 double precision、timestamp with time zone
+var typeNameWords = map[string]bool{
+	"precision": true, "varying": true, "with": true, "without": true, "time": true, "zone": true,
+}
+
+// skipTypeName 跳过从 start 开始的类型名，返回类型名之后的位置
+func skipTypeName(tokens []token, start int) int {
+	i := start
+	if i < len(tokens) && tokens[i].isPart() {
+		i++
+		// pg_catalog.int4 这样带模式的类型名
+		for i+1 < len(tokens) && tokens[i].isSymbol(".") && tokens[i+1].isPart() {
+			i += 2
+		}
+	}
+	for i < len(tokens) && tokens[i].kind == tokenIdent && typeNameWords[strings.ToLower(tokens[i].text)] {
+		i++
+	}
+	if i < len(tokens) && tokens[i].isSymbol("(") {
+		if end := matchingParen(tokens, i); end >= 0 {
+			i = end + 1
+		}
+	}
+	for i+1 < len(tokens) && tokens[i].isSymbol("[") && tokens[i+1].isSymbol("]") {
+		i += 2
+	}
+	return i
+}
+
+func matchingParen(tokens []token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].isSymbol("(") {
+			depth++
+		} else if tokens[i].isSymbol(")") {
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// mergeUnion 按位置合并 union 各分支的输出字段，字段名取第一个分支
+func mergeUnion(branches []*relation) *relation {
+	if len(branches) == 1 {
+		return branches[0]
+	}
+	merged := &relation{}
+	for i, c := range branches[0].columns {
+		refs := make([]ColumnRef, 0)
+		exprs := make([]string, 0)
+		for _, b := range branches {
+			if i >= len(b.columns) {
+				continue
+			}
+			refs = append(refs, b.columns[i].Sources...)
+			if e := b.columns[i].Expression; e != "" && !contains(exprs, e) {
+				exprs = append(exprs, e)
+			}
+		}
+		expr := "UNION"
+		if len(exprs) > 0 {
+			expr = fmt.Sprintf("UNION(%s)", strings.Join(exprs, ", "))
+		}
+		merged.columns = append(merged.columns, &Column{Name: c.Name, Expression: expr, Sources: uniqueRefs(refs)})
+	}
+	return merged
+}
+
+func renameColumns(columns []*Column, aliases []string) []*Column {
+	if len(aliases) == 0 {
+		return columns
+	}
+	renamed := make([]*Column, len(columns))
+	for i, c := range columns {
+		renamed[i] = &Column{Name: c.Name, Expression: c.Expression, Sources: c.Sources}
+		if i < len(aliases) {
+			renamed[i].Name = aliases[i]
+		}
+	}
+	return renamed
+}
+
+func newTable(parts []string) *Table {
+	t := &Table{Name: parts[len(parts)-1]}
+	if len(parts) >= 2 {
+		t.Schema = parts[len(parts)-2]
+	}
+	if len(parts) >= 3 {
+		t.Catalog = parts[len(parts)-3]
+	}
+	return t
+}
+
+func uniqueRefs(refs []ColumnRef) []ColumnRef {
+	seen := make(map[string]bool)
+	result := make([]ColumnRef, 0, len(refs))
+	for _, r := range refs {
+		key := strings.ToLower(r.String())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, r)
+	}
+	return result
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTokens 将 token 还原为 sql 文本
+func formatTokens(tokens []token) string {
+	var b strings.Builder
+	for i, t := range tokens {
+		if i > 0 {
+			prev := tokens[i-1]
+			space := !prev.isSymbol("(") && !prev.isSymbol(".") &&
+				!t.isSymbol(")") && !t.isSymbol(",") && !t.isSymbol(".") &&
+				!(t.isSymbol("(") && prev.isPart())
+			if space {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(t.text)
+	}
+	return b.String()
+}
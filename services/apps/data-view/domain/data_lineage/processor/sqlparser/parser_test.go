@@ -0,0 +1,129 @@
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sources(c *Column) []string {
+	result := make([]string, 0, len(c.Sources))
+	for _, s := range c.Sources {
+		result = append(result, s.String())
+	}
+	return result
+}
+
+func TestParse_ExpressionAndAlias(t *testing.T) {
+	columns, err := Parse(`SELECT "t1"."id", "t1"."price" * "t1"."qty" AS "amount", cast("t1"."created_at" AS varchar(20)) created,
+		upper(t2.name) FROM vdm_maria_abc."default"."orders" t1 LEFT JOIN vdm_maria_abc.default.users AS t2 ON t1.user_id = t2.id
+		WHERE t1.status = 1 ORDER BY t1.id LIMIT 10`)
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 4) {
+		return
+	}
+	assert.Equal(t, "id", columns[0].Name)
+	assert.Equal(t, "", columns[0].Expression)
+	assert.Equal(t, []string{"vdm_maria_abc.default.orders.id"}, sources(columns[0]))
+
+	assert.Equal(t, "amount", columns[1].Name)
+	assert.Equal(t, `"t1"."price" * "t1"."qty"`, columns[1].Expression)
+	assert.Equal(t, []string{"vdm_maria_abc.default.orders.price", "vdm_maria_abc.default.orders.qty"}, sources(columns[1]))
+
+	assert.Equal(t, "created", columns[2].Name)
+	assert.Equal(t, []string{"vdm_maria_abc.default.orders.created_at"}, sources(columns[2]))
+
+	assert.Equal(t, "_col3", columns[3].Name)
+	assert.Equal(t, "upper(t2.name)", columns[3].Expression)
+	assert.Equal(t, []string{"vdm_maria_abc.default.users.name"}, sources(columns[3]))
+}
+
+func TestParse_SubqueryAndWith(t *testing.T) {
+	columns, err := Parse(`WITH u AS (SELECT id AS uid, concat(first_name, last_name) AS full_name FROM c.s.users)
+		SELECT o.id, u.full_name, (SELECT max(p.price) FROM c.s.products p WHERE p.id = o.product_id) AS max_price
+		FROM (SELECT * FROM c.s.orders WHERE deleted = 0) o JOIN u ON o.user_id = u.uid`)
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 3) {
+		return
+	}
+	assert.Equal(t, []string{"c.s.orders.id"}, sources(columns[0]))
+	assert.Equal(t, "full_name", columns[1].Name)
+	assert.Equal(t, "concat(first_name, last_name)", columns[1].Expression)
+	assert.Equal(t, []string{"c.s.users.first_name", "c.s.users.last_name"}, sources(columns[1]))
+	assert.Equal(t, []string{"c.s.products.price"}, sources(columns[2]))
+}
+
+func TestParse_Union(t *testing.T) {
+	columns, err := Parse(`select id, name from c.s.a union all select id, upper(title) from c.s.b union select 1, 'x'`)
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 2) {
+		return
+	}
+	assert.Equal(t, "id", columns[0].Name)
+	assert.Equal(t, "UNION(1)", columns[0].Expression)
+	assert.Equal(t, []string{"c.s.a.id", "c.s.b.id"}, sources(columns[0]))
+	assert.Equal(t, "UNION(upper(title), 'x')", columns[1].Expression)
+	assert.Equal(t, []string{"c.s.a.name", "c.s.b.title"}, sources(columns[1]))
+}
+
+func TestParse_Star(t *testing.T) {
+	columns, err := Parse("select t.*, b.code from `c`.`s`.`a` t, c.s.b b")
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 2) {
+		return
+	}
+	assert.Equal(t, "*", columns[0].Name)
+	assert.Equal(t, []string{"c.s.a.*"}, sources(columns[0]))
+	assert.Equal(t, []string{"c.s.b.code"}, sources(columns[1]))
+
+	columns, err = Parse(`select x.name from (select * from c.s.a) x`)
+	if assert.NoError(t, err) && assert.Len(t, columns, 1) {
+		assert.Equal(t, []string{"c.s.a.name"}, sources(columns[0]))
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse(`select a from (select b from c.s.t`)
+	assert.Error(t, err)
+	_, err = Parse(`update t set a = 1`)
+	assert.Error(t, err)
+}
+
+func TestParse_Cast(t *testing.T) {
+	columns, err := Parse(`select a.x::int, a.y::varchar(20) y, a.z::timestamp with time zone::date z, a.w::numeric(10, 2)[] w from c.s.t a`)
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 4) {
+		return
+	}
+	assert.Equal(t, "x", columns[0].Name)
+	assert.Equal(t, []string{"c.s.t.x"}, sources(columns[0]))
+	assert.Equal(t, "y", columns[1].Name)
+	assert.Equal(t, []string{"c.s.t.y"}, sources(columns[1]))
+	assert.Equal(t, "z", columns[2].Name)
+	assert.Equal(t, []string{"c.s.t.z"}, sources(columns[2]))
+	assert.Equal(t, []string{"c.s.t.w"}, sources(columns[3]))
+}
+
+func TestParse_CastColumnName(t *testing.T) {
+	// 外层查询按字段名引用子查询中未指定别名的类型转换
+	columns, err := Parse(`select q.x, q.y, q.z from (select a.x::int, a.y::double precision, a.z::interval d, a.z::pg_catalog.int4 from c.s.t a) q`)
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 3) {
+		return
+	}
+	assert.Equal(t, []string{"c.s.t.x"}, sources(columns[0]))
+	assert.Equal(t, "a.x :: int", columns[0].Expression)
+	assert.Equal(t, []string{"c.s.t.y"}, sources(columns[1]))
+	assert.Equal(t, "a.y :: double precision", columns[1].Expression)
+	assert.Equal(t, []string{"c.s.t.z"}, sources(columns[2]))
+	assert.Equal(t, "a.z :: pg_catalog.int4", columns[2].Expression)
+
+	columns, err = Parse(`select (a.x + a.y)::int, a.x::int + 1 from c.s.t a`)
+	if assert.NoError(t, err) && assert.Len(t, columns, 2) {
+		assert.Equal(t, []string{"_col0", "_col1"}, []string{columns[0].Name, columns[1].Name})
+	}
+}
+
+func TestParse_SubstringFromFor(t *testing.T) {
+	columns, err := Parse(`select substring(a.x from 2 for 3) s1, substring(a.x from a.y for a.z) s2, trim(leading from a.x) s3 from c.s.t a`)
+	if !assert.NoError(t, err) || !assert.Len(t, columns, 3) {
+		return
+	}
+	assert.Equal(t, []string{"c.s.t.x"}, sources(columns[0]))
+	assert.Equal(t, []string{"c.s.t.x", "c.s.t.y", "c.s.t.z"}, sources(columns[1]))
+	assert.Equal(t, []string{"c.s.t.x"}, sources(columns[2]))
+}
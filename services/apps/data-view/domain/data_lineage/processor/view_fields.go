@@ -107,8 +107,13 @@ func (f FormViewInfoFetcher) formFieldBase(ctx context.Context, d *model.FormVie
 
 func (f FormViewInfoFetcher) genColumnUniqueID(table *data_lineage.LineageTable, formViewField *model.FormViewField) (string, string) {
 	if table.CatalogName != "" {
-		return util.MD5(fmt.Sprintf("%s%s%s%s", strings.ToLower(table.CatalogName), strings.ToLower(table.DatabaseName),
-			strings.ToLower(table.TechnicalName), strings.ToLower(formViewField.TechnicalName))), ""
+		id := util.MD5(fmt.Sprintf("%s%s%s%s", strings.ToLower(table.CatalogName), strings.ToLower(table.DatabaseName),
+			strings.ToLower(table.TechnicalName), strings.ToLower(formViewField.TechnicalName)))
+		//合并通过 OpenLineage 导入的上游字段
+		if refs, expr := f.importedColumnUniqueID(table.UniqueID, formViewField); refs != "" {
+			return id + "," + refs, expr
+		}
+		return id, ""
 	}
 	if table.SceneID != "" {
 		refFieldDict, err := f.QueryViewSourceFields(context.Background(), table.SceneID)
@@ -120,5 +125,9 @@ func (f FormViewInfoFetcher) genColumnUniqueID(table *data_lineage.LineageTable,
 			return strings.Join(ex.Ref, ","), strings.Join(ex.Expr, ",")
 		}
 	}
+	//没有画布信息的视图（如通过接口创建的），使用 sql 解析得到的字段级血缘
+	if table.TableType == data_lineage.LineageNodeTypeCustomView.String || table.TableType == data_lineage.LineageNodeTypeLogicView.String {
+		return f.sqlColumnUniqueID(table.UniqueID, formViewField)
+	}
 	return "", ""
 }
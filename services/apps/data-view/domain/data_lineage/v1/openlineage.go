@@ -0,0 +1,225 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
+	domain "github.com/kweaver-ai/dsg/services/apps/data-view/domain/data_lineage"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/data_lineage/processor/sqlparser"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	code "github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"gorm.io/gorm"
+)
+
+// ImportOpenLineage 导入 OpenLineage 运行事件，输出数据集对应到元数据视图后合并字段级血缘并推送到血缘图谱
+func (v ViewLineageUseCase) ImportOpenLineage(ctx context.Context, req *domain.ImportOpenLineageReq) (*domain.ImportOpenLineageResp, error) {
+	resp := &domain.ImportOpenLineageResp{
+		Status:   domain.OpenLineageImportIgnored,
+		Outputs:  make([]*domain.ImportedOpenLineageTable, 0),
+		Unmapped: make([]string, 0),
+	}
+	if !strings.EqualFold(req.EventType, domain.OpenLineageEventComplete) {
+		return resp, nil
+	}
+	resp.Status = domain.OpenLineageImportMerged
+
+	datasources, err := v.datasourceRepo.GetAll(ctx)
+	if err != nil {
+		log.WithContext(ctx).Errorf("failed to get datasource list, err info: %v", err.Error())
+		return nil, code.Detail(errorcode.OpenLineageImportFailed, err.Error())
+	}
+	resolver := &openLineageResolver{
+		usecase:     v,
+		datasources: datasources,
+		views:       make(map[string]*model.FormView),
+		fields:      make(map[string][]*model.FormViewField),
+	}
+	for _, output := range req.Outputs {
+		if output.Facets == nil || output.Facets.ColumnLineage == nil || len(output.Facets.ColumnLineage.Fields) == 0 {
+			continue
+		}
+		view, err := resolver.view(ctx, output.Namespace, output.Name)
+		if err != nil {
+			return nil, code.Detail(errorcode.OpenLineageImportFailed, err.Error())
+		}
+		// 自定义视图、逻辑实体视图的血缘以 sql 解析结果为准
+		if view == nil || view.Type != constant.FormViewTypeDatasource.Integer.Int32() {
+			resp.Unmapped = append(resp.Unmapped, output.Namespace+"/"+output.Name)
+			continue
+		}
+		fields, err := resolver.viewFields(ctx, view.ID)
+		if err != nil {
+			return nil, code.Detail(errorcode.OpenLineageImportFailed, err.Error())
+		}
+
+		columns := make([]string, 0, len(output.Facets.ColumnLineage.Fields))
+		for column := range output.Facets.ColumnLineage.Fields {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		lineages := make([]*model.LineageField, 0)
+		fieldCount := 0
+		for _, column := range columns {
+			columnLineage := output.Facets.ColumnLineage.Fields[column]
+			field := findViewField(fields, column)
+			if field == nil || columnLineage == nil || len(columnLineage.InputFields) == 0 {
+				continue
+			}
+			fieldCount++
+			for _, input := range columnLineage.InputFields {
+				// 记录导入时的数据集命名空间和名称，导出时原样返回
+				lineage := &model.LineageField{
+					FormViewID:      view.ID,
+					FieldID:         field.ID,
+					FieldName:       field.TechnicalName,
+					SourceTable:     input.Name,
+					SourceColumn:    input.Field,
+					Expression:      domain.ImportedLineageExpression(columnLineage, input),
+					SourceNamespace: input.Namespace,
+					OutputNamespace: output.Namespace,
+					OutputName:      output.Name,
+				}
+				sourceView, err := resolver.view(ctx, input.Namespace, input.Name)
+				if err != nil {
+					return nil, code.Detail(errorcode.OpenLineageImportFailed, err.Error())
+				}
+				if sourceView != nil {
+					lineage.SourceViewID = sourceView.ID
+					sourceFields, err := resolver.viewFields(ctx, sourceView.ID)
+					if err != nil {
+						return nil, code.Detail(errorcode.OpenLineageImportFailed, err.Error())
+					}
+					if sourceField := findViewField(sourceFields, input.Field); sourceField != nil {
+						lineage.SourceFieldID = sourceField.ID
+					}
+				}
+				lineages = append(lineages, lineage)
+			}
+		}
+		if len(lineages) == 0 {
+			continue
+		}
+
+		content, err := v.process.MergeImportedFieldLineage(ctx, view.ID, lineages)
+		if err != nil {
+			log.WithContext(ctx).Errorf("failed to merge imported lineage of %v, err info: %v", view.ID, err.Error())
+			return nil, code.Detail(errorcode.OpenLineageImportFailed, err.Error())
+		}
+		if len(content.Entities) > 0 {
+			if err = v.metadataDriven.SendLineage(ctx, content); err != nil {
+				log.WithContext(ctx).Errorf("failed to send imported lineage of %v, err info: %v", view.ID, err.Error())
+				return nil, code.Detail(errorcode.LineageReqFailed, err.Error())
+			}
+		}
+		resp.Outputs = append(resp.Outputs, &domain.ImportedOpenLineageTable{
+			Namespace:  output.Namespace,
+			Name:       output.Name,
+			FormViewID: view.ID,
+			FieldCount: fieldCount,
+		})
+	}
+	return resp, nil
+}
+
+// ExportOpenLineage 将视图的字段级血缘导出为 OpenLineage 运行事件
+func (v ViewLineageUseCase) ExportOpenLineage(ctx context.Context, req *domain.ExportOpenLineageReq) (*domain.OpenLineageRunEvent, error) {
+	view, err := v.repo.GetExistedViewByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := v.fieldRepo.GetFormViewFields(ctx, view.ID)
+	if err != nil {
+		log.WithContext(ctx).Errorf("failed to get table fields list, err info: %v", err.Error())
+		return nil, code.Detail(errorcode.GetTableFailed, err.Error())
+	}
+	lineages, err := v.process.ViewFieldLineage(ctx, view)
+	if err != nil {
+		log.WithContext(ctx).Errorf("failed to get field lineage of %v, err info: %v", view.ID, err.Error())
+		return nil, code.Detail(errorcode.OpenLineageExportFailed, err.Error())
+	}
+
+	var viewSource string
+	switch view.Type {
+	case constant.FormViewTypeCustom.Integer.Int32():
+		viewSource = constant.CustomViewSource + constant.CustomAndLogicEntityViewSourceSchema
+	case constant.FormViewTypeLogicEntity.Integer.Int32():
+		viewSource = constant.LogicEntityViewSource + constant.CustomAndLogicEntityViewSourceSchema
+	default:
+		datasource, err := v.datasourceRepo.GetById(ctx, view.DatasourceID)
+		if err != nil {
+			log.WithContext(ctx).Errorf("failed to get datasource of %v, err info: %v", view.ID, err.Error())
+			return nil, code.Detail(errorcode.OpenLineageExportFailed, err.Error())
+		}
+		viewSource = datasource.DataViewSource
+	}
+	return domain.NewOpenLineageRunEvent(view, viewSource+"."+view.TechnicalName, fields, lineages), nil
+}
+
+// openLineageResolver 将 OpenLineage 数据集对应到视图，缓存同一个事件中已经查过的视图及字段
+type openLineageResolver struct {
+	usecase     ViewLineageUseCase
+	datasources []*model.Datasource
+	views       map[string]*model.FormView
+	fields      map[string][]*model.FormViewField
+}
+
+// view 查找数据集对应的视图，找不到时返回 nil。
+// 命名空间为本服务时数据集名称是虚拟化引擎中的 catalog.schema.table，否则按数据源地址和库名匹配
+func (r *openLineageResolver) view(ctx context.Context, namespace, name string) (*model.FormView, error) {
+	key := namespace + "/" + name
+	if view, ok := r.views[key]; ok {
+		return view, nil
+	}
+	catalog, schema, table := domain.SplitDatasetName(name)
+	var view *model.FormView
+	var err error
+	if catalog != "" {
+		view, err = r.usecase.process.QuerySourceView(ctx, sqlparser.Table{Catalog: catalog, Schema: schema, Name: table})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			view, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if view == nil && namespace != domain.OpenLineageNamespace {
+		if datasource := domain.MatchDatasource(r.datasources, namespace, catalog, schema); datasource != nil {
+			views, err := r.usecase.repo.GetViewsByDIdName(ctx, datasource.ID, []string{table})
+			if err != nil {
+				return nil, err
+			}
+			if len(views) > 0 {
+				view = views[0]
+			}
+		}
+	}
+	r.views[key] = view
+	return view, nil
+}
+
+func (r *openLineageResolver) viewFields(ctx context.Context, formViewID string) ([]*model.FormViewField, error) {
+	if fields, ok := r.fields[formViewID]; ok {
+		return fields, nil
+	}
+	fields, err := r.usecase.fieldRepo.GetFormViewFields(ctx, formViewID)
+	if err != nil {
+		return nil, err
+	}
+	r.fields[formViewID] = fields
+	return fields, nil
+}
+
+// findViewField 按技术名称（忽略大小写）查找视图字段
+func findViewField(fields []*model.FormViewField, name string) *model.FormViewField {
+	for _, field := range fields {
+		if strings.EqualFold(field.TechnicalName, name) {
+			return field
+		}
+	}
+	return nil
+}
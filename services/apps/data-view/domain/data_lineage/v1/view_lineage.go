@@ -19,6 +19,7 @@ import (
 	my_config "github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/config"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
 	code "github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-common/rest/metadata_manage"
 	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
@@ -38,6 +39,7 @@ type ViewLineageUseCase struct {
 	objectSearchCall configuration_center.ObjectSearch
 	redisClient      *cache.Redis
 	process          *processor.FormViewInfoFetcher
+	metadataDriven   metadata_manage.Driven
 }
 
 func NewViewLineageUseCase(repo repo.FormViewRepo,
@@ -48,6 +50,7 @@ func NewViewLineageUseCase(repo repo.FormViewRepo,
 	objectSearchCall configuration_center.ObjectSearch,
 	redisClient *cache.Redis,
 	process *processor.FormViewInfoFetcher,
+	metadataDriven metadata_manage.Driven,
 ) domain.UseCase {
 	return &ViewLineageUseCase{
 		config:           config,
@@ -58,6 +61,7 @@ func NewViewLineageUseCase(repo repo.FormViewRepo,
 		objectSearchCall: objectSearchCall,
 		redisClient:      redisClient,
 		process:          process,
+		metadataDriven:   metadataDriven,
 	}
 }
 
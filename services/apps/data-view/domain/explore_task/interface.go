@@ -50,6 +50,7 @@ type DatasourceExploreDataConfig struct {
 	MetadataConfig *MetadataConfig        `json:"metadata"`     // 元数据级探查配置
 	ViewConfig     *ViewConfig            `json:"view"`         // 视图级探查配置
 	TotalSample    int64                  `json:"total_sample"` // 采样数据量,0为全量数据
+	Incremental    bool                   `json:"incremental"`  // 是否按业务时间增量探查，只在全量探查时生效
 }
 
 type ExploreFieldTypeConf struct {
@@ -79,6 +80,21 @@ type FormViewExploreDataConfig struct {
 	ViewConfig     *View               `json:"view"`                                                 // 视图级探查配置
 	FieldConf      []*ExploreFieldConf `json:"field" binding:"required,dive,Min=1"`                  // 字段探查配置
 	TotalSample    int64               `json:"total_sample" form:"total_sample" binding:"omitempty"` // 采样数据量,0为全量数据
+	Incremental    bool                `json:"incremental" form:"incremental" binding:"omitempty"`   // 是否按业务时间增量探查，只在全量探查时生效
+	QualityAlert   *QualityAlertConfig `json:"quality_alert" binding:"omitempty"`                    // 质量异常告警配置，缺省使用默认配置
+}
+
+// QualityAlertConfig 质量异常告警配置，数值为0时使用默认值
+type QualityAlertConfig struct {
+	Disabled          bool     `json:"disabled"`                                            // 是否关闭质量异常告警
+	MinScore          float64  `json:"min_score" binding:"omitempty,min=0,max=1"`           // 维度评分下限，低于下限时告警，为0时不检查
+	MaxScoreDrop      float64  `json:"max_score_drop" binding:"omitempty,min=0,max=1"`      // 维度评分相比上次探查的最大降幅，默认0.1
+	MaxNullRatioRise  float64  `json:"max_null_ratio_rise" binding:"omitempty,min=0,max=1"` // 字段空值率相比上次探查的最大升幅，默认0.1
+	MaxRowCountChange float64  `json:"max_row_count_change" binding:"omitempty,min=0"`      // 总行数相比上次探查的最大变化比例，默认0.5
+	MaxDistinctChange float64  `json:"max_distinct_change" binding:"omitempty,min=0"`       // 字段去重数量相比上次探查的最大变化比例，默认0.5
+	DriftThreshold    float64  `json:"drift_threshold" binding:"omitempty,min=0"`           // 偏离历史均值的标准差倍数，超过时视为统计漂移，默认3
+	HistorySize       int      `json:"history_size" binding:"omitempty,min=0,max=100"`      // 统计漂移参考的历史报告数量，默认10
+	RecipientIds      []string `json:"recipient_ids" binding:"omitempty,dive,uuid"`         // 告警接收人id，缺省为探查任务的创建人
 }
 
 type Metadata struct {
@@ -442,23 +458,26 @@ type DataASyncExploreMsg struct {
 }
 
 type JobConf struct {
-	Name                 string          `json:"task_name"`
-	Desc                 string          `json:"task_desc"`
-	TableID              string          `json:"table_id"`
-	TableName            string          `json:"table"`
-	Schema               string          `json:"schema"`
-	VeCatalog            string          `json:"ve_catalog"`
-	TaskEnabled          int             `json:"task_enabled"`
-	MetadataExploreConfs []*JobRuleConf  `json:"metadata_explore" binding:"omitempty"`
-	FieldExploreConfs    []*JobFieldConf `json:"field_explore" binding:"omitempty"`
-	RowExploreConfs      []*JobRuleConf  `json:"row_explore" binding:"omitempty"`
-	ViewExploreConfs     []*JobRuleConf  `json:"view_explore" binding:"omitempty"`
-	TotalSample          int64           `json:"total_sample,omitempty" binding:"omitempty"`
-	ExploreType          int             `json:"explore_type" binding:"required,oneof=1 2 3"`
-	UserId               string          `json:"user_id"`
-	UserName             string          `json:"user_name"`
-	TaskId               string          `json:"dv_task_id"`
-	FieldInfo            string          `json:"field_info"`
+	Name                 string              `json:"task_name"`
+	Desc                 string              `json:"task_desc"`
+	TableID              string              `json:"table_id"`
+	TableName            string              `json:"table"`
+	Schema               string              `json:"schema"`
+	VeCatalog            string              `json:"ve_catalog"`
+	TaskEnabled          int                 `json:"task_enabled"`
+	MetadataExploreConfs []*JobRuleConf      `json:"metadata_explore" binding:"omitempty"`
+	FieldExploreConfs    []*JobFieldConf     `json:"field_explore" binding:"omitempty"`
+	RowExploreConfs      []*JobRuleConf      `json:"row_explore" binding:"omitempty"`
+	ViewExploreConfs     []*JobRuleConf      `json:"view_explore" binding:"omitempty"`
+	TotalSample          int64               `json:"total_sample,omitempty" binding:"omitempty"`
+	ExploreType          int                 `json:"explore_type" binding:"required,oneof=1 2 3"`
+	UserId               string              `json:"user_id"`
+	UserName             string              `json:"user_name"`
+	TaskId               string              `json:"dv_task_id"`
+	FieldInfo            string              `json:"field_info"`
+	Incremental          bool                `json:"incremental"`        // 是否增量探查
+	TimestampFieldId     string              `json:"timestamp_field_id"` // 业务时间字段id
+	QualityAlert         *QualityAlertConfig `json:"quality_alert"`      // 质量异常告警配置
 }
 
 type ColumnInfo struct {
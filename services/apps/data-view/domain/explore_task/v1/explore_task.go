@@ -1176,27 +1176,32 @@ func (e *exploreTaskUseCase) getViewConfig(ctx context.Context, view *model.Form
 		return nil, errorcode.Detail(my_errorcode.GetDataTableDetailError, err)
 	}
 	fieldInfoMap := make(map[string]explore_task.ColumnInfo)
+	var timestampFieldId string
 	for _, column := range columns {
 		fieldInfoMap[column.ID] = explore_task.ColumnInfo{
 			Name:       column.TechnicalName,
 			Type:       column.DataType,
 			OriginType: column.OriginalDataType,
 		}
+		if column.BusinessTimestamp {
+			timestampFieldId = column.ID
+		}
 	}
 	buf, _ := json.Marshal(fieldInfoMap)
 	jc := &explore_task.JobConf{
-		Name:        fmt.Sprintf("%s(%s)", view.TechnicalName, view.ID),
-		TableID:     view.ID,
-		TableName:   view.TechnicalName,
-		Schema:      schema,
-		VeCatalog:   catalogName,
-		TaskEnabled: explore_task.EXPLORE_JOB_ENABLED,
-		UserId:      userId,
-		UserName:    userName,
-		ExploreType: explore_task.TaskExploreData.Integer.Int(),
-		TaskId:      taskId,
-		TotalSample: totalSample,
-		FieldInfo:   util.BytesToString(buf),
+		Name:             fmt.Sprintf("%s(%s)", view.TechnicalName, view.ID),
+		TableID:          view.ID,
+		TableName:        view.TechnicalName,
+		Schema:           schema,
+		VeCatalog:        catalogName,
+		TaskEnabled:      explore_task.EXPLORE_JOB_ENABLED,
+		UserId:           userId,
+		UserName:         userName,
+		ExploreType:      explore_task.TaskExploreData.Integer.Int(),
+		TaskId:           taskId,
+		TotalSample:      totalSample,
+		FieldInfo:        util.BytesToString(buf),
+		TimestampFieldId: timestampFieldId,
 	}
 	metadataRules := make([]*explore_task.JobRuleConf, 0)
 	fieldConfRules := make([]*explore_task.JobFieldConf, 0)
@@ -1665,6 +1670,9 @@ func (e *exploreTaskUseCase) ExploreData(ctx context.Context, taskId, formViewId
 	if err != nil {
 		return err
 	}
+	// 增量探查需要视图设置了业务时间字段
+	jc.Incremental = ret.Incremental && jc.TimestampFieldId != ""
+	jc.QualityAlert = ret.QualityAlert
 	err = e.StartExploreData(ctx, view, jc)
 	if err != nil {
 		return err
@@ -1760,6 +1768,8 @@ func (e *exploreTaskUseCase) saveExploreViewDataConfig(ctx context.Context, task
 		return errorcode.Desc(my_errorcode.GetTaskConfigError)
 	}
 	formViewConfig.TotalSample = ret.TotalSample
+	formViewConfig.Incremental = ret.Incremental
+	formViewConfig.QualityAlert = ret.QualityAlert
 	buf, err := json.Marshal(formViewConfig)
 	if err != nil {
 		log.WithContext(ctx).Errorf("json.Marshal failed, body: %v, err: %v", formViewConfig, err)
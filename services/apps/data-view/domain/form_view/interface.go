@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"mime/multipart"
 	"strings"
+	"time"
 
 	"github.com/kweaver-ai/dsg/services/apps/data-view/common/models/response"
 
 	"github.com/samber/lo"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/auth_service"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/virtualization_engine"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
@@ -60,6 +62,11 @@ type FormViewUseCase interface {
 	DeleteDataDownloadTask(ctx context.Context, req *DownlaodTaskPathReq) (*DownloadTaskIDResp, error)
 	GetDataDownloadTaskList(ctx context.Context, req *GetDownloadTaskListReq) (*PageResultNew[DownloadTaskEntry], error)
 	GetDataDownloadLink(ctx context.Context, req *DownlaodTaskPathReq) (*DownloadLinkResp, error)
+	CreateDownloadSubscription(ctx context.Context, req *DownloadSubscriptionCreateReq) (*DownloadSubscriptionIDResp, error)
+	UpdateDownloadSubscription(ctx context.Context, req *DownloadSubscriptionUpdateReq) (*DownloadSubscriptionIDResp, error)
+	DeleteDownloadSubscription(ctx context.Context, req *DownloadSubscriptionPathReq) (*DownloadSubscriptionIDResp, error)
+	GetDownloadSubscriptionList(ctx context.Context, req *GetDownloadSubscriptionListReq) (*PageResultNew[DownloadSubscriptionEntry], error)
+	GetDownloadSubscriptionRuns(ctx context.Context, req *GetDownloadSubscriptionRunsReq) (*PageResultNew[DownloadSubscriptionRunEntry], error)
 	MarkFormViewBusinessTimestamp(ctx context.Context, msg []byte) error
 
 	GetDatasourceOverview(ctx context.Context, req *GetDatasourceOverviewReq) (*DatasourceOverviewResp, error)
@@ -120,6 +127,16 @@ type FormViewUseCase interface {
 	ExportExploreReports(ctx context.Context, req *ExportExploreReportsReq) (*ExportExploreReportsResp, error)
 	GetDepartmentExploreReports(ctx context.Context, req *GetDepartmentExploreReportsReq) (*GetDepartmentExploreReportsResp, error)
 	CreateExploreReports()
+	// GetSchemaVersions 获取逻辑视图的结构版本列表
+	GetSchemaVersions(ctx context.Context, req *GetSchemaVersionsReq) (*GetSchemaVersionsResp, error)
+	// DiffSchemaVersions 对比逻辑视图两个结构版本的字段差异
+	DiffSchemaVersions(ctx context.Context, req *DiffSchemaVersionsReq) (*DiffSchemaVersionsResp, error)
+	// ImpactAnalysis 分析逻辑视图或字段的下游依赖
+	ImpactAnalysis(ctx context.Context, req *ImpactAnalysisReq) (*ImpactAnalysisResp, error)
+	// DeleteFormViewDryRun 试运行删除逻辑视图，只返回会受影响的下游依赖，不执行删除
+	DeleteFormViewDryRun(ctx context.Context, req *DeleteReq) (*ImpactAnalysisResp, error)
+	// ScanDryRun 试运行扫描数据源，只对比元数据平台中已采集的元数据，返回会导致下游依赖失效的变更，不触发采集，不更新逻辑视图
+	ScanDryRun(ctx context.Context, req *ScanReq) (*ScanDryRunResp, error)
 }
 type InternalFormViewUseCase interface {
 	GetLogicViewReportInfo(ctx context.Context, req *data_view.GetLogicViewReportInfoReq) (*data_view.GetLogicViewReportInfoRes, error)
@@ -490,6 +507,11 @@ type BatchPublishRes struct {
 
 type DeleteReq struct {
 	IDReqParamPath `param_type:"path"`
+	DeleteReqQuery `param_type:"query"`
+}
+
+type DeleteReqQuery struct {
+	DryRun bool `json:"dry_run" form:"dry_run" binding:"omitempty"` // 试运行，为 true 时不删除，只返回会受影响的下游依赖
 }
 
 //endregion
@@ -526,6 +548,7 @@ type SimpleViewField struct {
 	StandardName     string `json:"standard_name"`      // 数据标准名称
 	CodeTableID      string `json:"code_table_id"`      // 码表ID
 	Index            int    `json:"index"`              // 字段顺序
+	LabelID          string `json:"label_id"`           // 分级标签ID
 }
 
 //endregion
@@ -1340,6 +1363,160 @@ type ExploreRule struct {
 
 //endregion
 
+//region GetSchemaVersions
+
+type GetSchemaVersionsReq struct {
+	IDReqParamPath `param_type:"path"`
+}
+
+type GetSchemaVersionsResp struct {
+	response.PageResult[SchemaVersionInfo]
+}
+
+type SchemaVersionInfo struct {
+	Version       int   `json:"version"`        // 结构版本号
+	FieldCount    int   `json:"field_count"`    // 字段数量
+	AddedCount    int   `json:"added_count"`    // 相对上一版本新增字段数
+	RemovedCount  int   `json:"removed_count"`  // 相对上一版本删除字段数
+	ModifiedCount int   `json:"modified_count"` // 相对上一版本变更字段数
+	CreatedAt     int64 `json:"created_at"`     // 扫描时间
+}
+
+// SchemaVersionField 结构版本中的字段快照，取自扫描到的源表字段
+type SchemaVersionField struct {
+	TechnicalName    string `json:"technical_name"`     // 列技术名称
+	OriginalName     string `json:"original_name"`      // 原始字段名称
+	DataType         string `json:"data_type"`          // 数据类型，为空表示不支持的类型
+	OriginalDataType string `json:"original_data_type"` // 原始数据类型
+	DataLength       int32  `json:"data_length"`        // 数据长度
+	DataAccuracy     *int32 `json:"data_accuracy"`      // 数据精度，为空表示没有精度
+	IsNullable       string `json:"is_nullable"`        // 是否为空
+	PrimaryKey       bool   `json:"primary_key"`        // 是否主键
+	Comment          string `json:"comment"`            // 列注释
+}
+
+//endregion
+
+//region DiffSchemaVersions
+
+type DiffSchemaVersionsReq struct {
+	IDReqParamPath             `param_type:"path"`
+	DiffSchemaVersionsReqParam `param_type:"query"`
+}
+
+type DiffSchemaVersionsReqParam struct {
+	From int `json:"from" form:"from" binding:"required,min=1"` // 对比的起始版本
+	To   int `json:"to" form:"to" binding:"required,min=1"`     // 对比的目标版本
+}
+
+type DiffSchemaVersionsResp struct {
+	FormViewID string             `json:"form_view_id"` // 逻辑视图id
+	From       int                `json:"from"`         // 起始版本
+	To         int                `json:"to"`           // 目标版本
+	Changes    []*SchemaFieldDiff `json:"changes"`      // 字段差异，按目标版本字段顺序排列，删除的字段在最后
+}
+
+// 字段变更类型
+const (
+	SchemaFieldAdded    = "added"    // 新增
+	SchemaFieldRemoved  = "removed"  // 删除
+	SchemaFieldModified = "modified" // 变更
+)
+
+type SchemaFieldDiff struct {
+	TechnicalName string                 `json:"technical_name"`       // 列技术名称
+	ChangeType    string                 `json:"change_type"`          // 变更类型，枚举：added、removed、modified
+	Attributes    []*SchemaAttributeDiff `json:"attributes,omitempty"` // 变更的属性，仅 modified 有值
+	From          *SchemaVersionField    `json:"from,omitempty"`       // 起始版本中的字段，added 为空
+	To            *SchemaVersionField    `json:"to,omitempty"`         // 目标版本中的字段，removed 为空
+}
+
+type SchemaAttributeDiff struct {
+	Name string `json:"name"` // 属性名称，与 SchemaVersionField 的 json 字段名一致
+	From any    `json:"from"` // 起始版本中的值
+	To   any    `json:"to"`   // 目标版本中的值
+}
+
+// SchemaChangeEvent 逻辑视图结构变更消息，扫描发现源表字段变化并生成新的结构版本时发送
+type SchemaChangeEvent struct {
+	FormViewID      string             `json:"form_view_id"`     // 逻辑视图id
+	TechnicalName   string             `json:"technical_name"`   // 逻辑视图技术名称
+	DatasourceID    string             `json:"datasource_id"`    // 数据源id
+	Version         int                `json:"version"`          // 新的结构版本
+	PreviousVersion int                `json:"previous_version"` // 上一个结构版本
+	Changes         []*SchemaFieldDiff `json:"changes"`          // 字段差异
+	ChangedAt       int64              `json:"changed_at"`       // 扫描时间
+}
+
+//endregion
+
+//region ImpactAnalysis
+
+type ImpactAnalysisReq struct {
+	IDReqParamPath         `param_type:"path"`
+	ImpactAnalysisReqParam `param_type:"query"`
+}
+
+type ImpactAnalysisReqParam struct {
+	FieldID string `json:"field_id" form:"field_id" binding:"omitempty,uuid" example:"88f78432-ee4e-43df-804c-4ccc4ff17f15"` // 字段id，为空时分析整个逻辑视图
+}
+
+type ImpactAnalysisResp struct {
+	Root  *ImpactNode `json:"root"`  // 依赖树，根节点为逻辑视图或字段
+	Total int         `json:"total"` // 下游依赖对象数量，不包含根节点
+}
+
+// 依赖对象类型
+const (
+	ImpactNodeFormView       = "form_view"       // 逻辑视图
+	ImpactNodeField          = "field"           // 字段
+	ImpactNodeSubView        = "sub_view"        // 行列规则（子视图）
+	ImpactNodeLogicView      = "logic_view"      // 基于该视图 sql 构建的逻辑视图
+	ImpactNodeMetaModel      = "meta_model"      // 元模型
+	ImpactNodeCompositeModel = "composite_model" // 引用元模型的专题模型、主题模型
+	ImpactNodeService        = "service"         // 接口服务
+	ImpactNodeDataCatalog    = "data_catalog"    // 数据资源目录
+	ImpactNodeExploreRule    = "explore_rule"    // 探查规则
+)
+
+type ImpactNode struct {
+	Type     string        `json:"type"`               // 依赖对象类型，枚举：form_view、field、sub_view、logic_view、meta_model、composite_model、service、data_catalog、explore_rule
+	ID       string        `json:"id"`                 // 依赖对象id，查询失败的节点为空
+	Name     string        `json:"name"`               // 依赖对象名称
+	Code     string        `json:"code,omitempty"`     // 依赖对象编码，视图、字段、模型为技术名称
+	Error    string        `json:"error,omitempty"`    // 该类依赖查询失败的原因，有值时依赖树不完整
+	Children []*ImpactNode `json:"children,omitempty"` // 下游依赖
+}
+
+//endregion
+
+//region ScanDryRun
+
+type ScanDryRunResp struct {
+	ScanViewCount int           `json:"scan_view_count"` // 采集到的表数量
+	Changes       []*ScanChange `json:"changes"`         // 会导致下游依赖失效的变更
+}
+
+// 扫描变更类型
+const (
+	ScanChangeViewDeleted   = "view_deleted"   // 源表删除
+	ScanChangeFieldRemoved  = "field_removed"  // 字段删除
+	ScanChangeFieldModified = "field_modified" // 字段类型、长度或精度变更
+)
+
+type ScanChange struct {
+	FormViewID         string                 `json:"form_view_id"`                   // 逻辑视图id
+	TechnicalName      string                 `json:"technical_name"`                 // 逻辑视图技术名称
+	BusinessName       string                 `json:"business_name"`                  // 逻辑视图业务名称
+	ChangeType         string                 `json:"change_type"`                    // 变更类型，枚举：view_deleted、field_removed、field_modified
+	FieldID            string                 `json:"field_id,omitempty"`             // 字段id，view_deleted 为空
+	FieldTechnicalName string                 `json:"field_technical_name,omitempty"` // 字段技术名称，view_deleted 为空
+	Attributes         []*SchemaAttributeDiff `json:"attributes,omitempty"`           // 变更的属性，仅 field_modified 有值
+	Impact             *ImpactNode            `json:"impact"`                         // 受影响的下游依赖
+}
+
+//endregion
+
 //region GetDepartmentExploreReports
 
 type GetDepartmentExploreReportsReq struct {
@@ -1472,8 +1649,12 @@ type DownloadTaskCreateParams struct {
 }
 
 type DownloadTaskCreateReq struct {
-	FormViewID string `json:"form_view_id" binding:"required,uuid"`      // 逻辑视图ID
-	Detail     string `json:"detail" binding:"required,TrimSpace,min=1"` // 下载任务配置详情，以json字符串聚合存储
+	FormViewID string `json:"form_view_id" binding:"required,uuid"`                                  // 逻辑视图ID
+	Detail     string `json:"detail" binding:"required,TrimSpace,min=1"`                             // 下载任务配置详情，以json字符串聚合存储
+	Format     string `json:"format" binding:"omitempty,oneof=xlsx csv jsonl.gz parquet"`            // 文件格式，枚举：xlsx、csv、jsonl.gz、parquet，默认xlsx
+	Delimiter  string `json:"delimiter" binding:"omitempty,oneof=comma tab semicolon pipe"`          // csv分隔符，枚举：comma：逗号；tab：制表符；semicolon：分号；pipe：竖线，默认逗号
+	Encoding   string `json:"encoding" binding:"omitempty,oneof=utf-8 gbk"`                          // csv字符编码，枚举：utf-8、gbk，默认utf-8
+	SplitRows  int    `json:"split_rows" binding:"omitempty,min=1000,max=1000000" example:"1000000"` // 单个文件的最大行数，超过后拆分为多个文件并打包为zip，默认1000000
 }
 
 type GetDownloadTaskListParams struct {
@@ -1514,6 +1695,7 @@ type DownloadTaskEntry struct {
 	CreatedAt  int64   `json:"created_at"`   // 创建时间戳
 	UpdatedAt  int64   `json:"updated_at"`   // 更新时间戳
 	Remark     *string `json:"remark"`       // 异常原因
+	Format     string  `json:"format"`       // 文件格式
 }
 
 const (
@@ -1530,6 +1712,26 @@ const (
 	TASK_STATUS_STR_FAILED   = "failed"    // 执行失败/异常
 )
 
+const (
+	DOWNLOAD_FORMAT_XLSX    = "xlsx"     // Excel
+	DOWNLOAD_FORMAT_CSV     = "csv"      // csv
+	DOWNLOAD_FORMAT_JSONL   = "jsonl.gz" // gzip 压缩的 JSON Lines
+	DOWNLOAD_FORMAT_PARQUET = "parquet"  // Parquet
+
+	DOWNLOAD_ENCODING_UTF8 = "utf-8"
+	DOWNLOAD_ENCODING_GBK  = "gbk"
+
+	DOWNLOAD_SPLIT_ROWS_DEFAULT = 1000000 // 单个文件默认最大行数，xlsx 单个 sheet 最多 1048576 行
+)
+
+// DownloadDelimiters csv分隔符枚举对应的字符
+var DownloadDelimiters = map[string]string{
+	"comma":     ",",
+	"tab":       "\t",
+	"semicolon": ";",
+	"pipe":      "|",
+}
+
 func TaskStatus2Enum(ts string) int {
 	switch ts {
 	case TASK_STATUS_STR_QUEUING:
@@ -1610,11 +1812,180 @@ func GenTaskListResult(totalCount int64, tasks []*model.TDataDownloadTask) *Page
 			CreatedAt:  tasks[i].CreatedAt.UnixMilli(),
 			UpdatedAt:  tasks[i].UpdatedAt.UnixMilli(),
 			Remark:     tasks[i].Remark,
+			Format:     tasks[i].FileFormat,
 		}
 	}
 	return resp
 }
 
+// data-download-subscription
+type DownloadSubscriptionCreateParams struct {
+	DownloadSubscriptionCreateReq `param_type:"body"`
+}
+
+type DownloadSubscriptionCreateReq struct {
+	FormViewID string `json:"form_view_id" binding:"required,uuid"` // 逻辑视图ID
+	DownloadSubscriptionConfig
+}
+
+type DownloadSubscriptionUpdateReq struct {
+	DownloadSubscriptionPathReq `param_type:"path"`
+	DownloadSubscriptionConfig  `param_type:"body"`
+}
+
+// DownloadSubscriptionConfig 定时数据下载订阅的配置
+type DownloadSubscriptionConfig struct {
+	Name        string           `json:"name" binding:"required,TrimSpace,min=1,max=128"`                       // 订阅名称
+	Detail      string           `json:"detail" binding:"required,TrimSpace,min=1"`                             // 导出配置详情，与下载任务相同，以json字符串聚合存储
+	Format      string           `json:"format" binding:"omitempty,oneof=xlsx csv jsonl.gz parquet"`            // 文件格式，枚举：xlsx、csv、jsonl.gz、parquet，默认csv
+	Delimiter   string           `json:"delimiter" binding:"omitempty,oneof=comma tab semicolon pipe"`          // csv分隔符，枚举：comma：逗号；tab：制表符；semicolon：分号；pipe：竖线，默认逗号
+	Encoding    string           `json:"encoding" binding:"omitempty,oneof=utf-8 gbk"`                          // csv字符编码，枚举：utf-8、gbk，默认utf-8
+	SplitRows   int              `json:"split_rows" binding:"omitempty,min=1000,max=1000000" example:"1000000"` // 单个文件的最大行数，超过后拆分为多个文件并打包为zip，默认1000000
+	CronExpr    string           `json:"cron_expr" binding:"required,TrimSpace,max=128" example:"0 2 * * *"`    // 执行周期，标准五段式cron表达式（分 时 日 月 周），也支持 @daily、@every 1h 等写法
+	Incremental bool             `json:"incremental"`                                                           // 是否增量导出，增量导出时按逻辑视图的业务更新时间字段只导出上次执行以来的数据
+	Target      *delivery.Target `json:"target" binding:"required"`                                             // 投递目标，更新时密码、密钥为空或者为掩码表示不修改
+	Status      string           `json:"status" binding:"omitempty,oneof=enabled disabled" example:"enabled"`   // 订阅状态，枚举：enabled：启用；disabled：停用，默认启用
+}
+
+type DownloadSubscriptionPath struct {
+	DownloadSubscriptionPathReq `param_type:"path"`
+}
+
+type DownloadSubscriptionPathReq struct {
+	SubscriptionID constant.ModelID `uri:"subscriptionID" binding:"required,VerifyModelID"` // 订阅ID
+}
+
+type GetDownloadSubscriptionListParams struct {
+	GetDownloadSubscriptionListReq `param_type:"query"`
+}
+
+type GetDownloadSubscriptionListReq struct {
+	Offset int `json:"offset" form:"offset,default=1" binding:"omitempty,min=1" default:"1"`          // 页码，默认1
+	Limit  int `json:"limit" form:"limit,default=10" binding:"omitempty,min=1,max=2000" default:"10"` // 每页大小，默认10
+	request.KeywordInfo
+}
+
+type GetDownloadSubscriptionRunsReq struct {
+	DownloadSubscriptionPathReq      `param_type:"path"`
+	GetDownloadSubscriptionRunsQuery `param_type:"query"`
+}
+
+type GetDownloadSubscriptionRunsQuery struct {
+	Offset int `json:"offset" form:"offset,default=1" binding:"omitempty,min=1" default:"1"`          // 页码，默认1
+	Limit  int `json:"limit" form:"limit,default=10" binding:"omitempty,min=1,max=2000" default:"10"` // 每页大小，默认10
+}
+
+type DownloadSubscriptionIDResp struct {
+	ID uint64 `json:"id,string"` // 订阅ID
+}
+
+type DownloadSubscriptionEntry struct {
+	ID               uint64           `json:"id,string"`          // 订阅ID
+	FormViewID       string           `json:"form_view_id"`       // 逻辑视图ID
+	Name             string           `json:"name"`               // 订阅名称
+	Detail           string           `json:"detail"`             // 导出配置详情
+	Format           string           `json:"format"`             // 文件格式
+	Delimiter        string           `json:"delimiter"`          // csv分隔符
+	Encoding         string           `json:"encoding"`           // csv字符编码
+	SplitRows        int              `json:"split_rows"`         // 单个文件的最大行数
+	CronExpr         string           `json:"cron_expr"`          // 执行周期
+	Incremental      bool             `json:"incremental"`        // 是否增量导出
+	TimestampFieldID string           `json:"timestamp_field_id"` // 增量导出使用的业务更新时间字段ID
+	Watermark        int64            `json:"watermark"`          // 增量水位时间戳，为0表示尚未导出
+	Target           *delivery.Target `json:"target"`             // 投递目标，密码、密钥以掩码返回
+	Status           string           `json:"status"`             // 订阅状态 enabled：启用 disabled：停用
+	NextRunAt        int64            `json:"next_run_at"`        // 下次执行时间戳
+	LastRunAt        int64            `json:"last_run_at"`        // 上次执行时间戳
+	CreatedAt        int64            `json:"created_at"`         // 创建时间戳
+	UpdatedAt        int64            `json:"updated_at"`         // 更新时间戳
+}
+
+type DownloadSubscriptionRunEntry struct {
+	ID            uint64  `json:"id,string"`      // 执行记录ID
+	Status        string  `json:"status"`         // 执行状态 executing：执行中 finished：已完成 failed：异常
+	WatermarkFrom int64   `json:"watermark_from"` // 增量导出的起始时间戳，为0表示不限制
+	WatermarkTo   int64   `json:"watermark_to"`   // 增量导出的截止时间戳，全量导出时为0
+	RowCount      int64   `json:"row_count"`      // 导出行数
+	FileName      string  `json:"file_name"`      // 投递的文件名称
+	Remark        *string `json:"remark"`         // 异常原因
+	StartedAt     int64   `json:"started_at"`     // 开始时间戳
+	FinishedAt    int64   `json:"finished_at"`    // 结束时间戳
+}
+
+const (
+	SUBSCRIPTION_STATUS_ENABLED  = iota + 1 // 启用
+	SUBSCRIPTION_STATUS_DISABLED            // 停用
+)
+
+const (
+	SUBSCRIPTION_STATUS_STR_ENABLED  = "enabled"  // 启用
+	SUBSCRIPTION_STATUS_STR_DISABLED = "disabled" // 停用
+)
+
+func SubscriptionStatus2Enum(status string) int {
+	if status == SUBSCRIPTION_STATUS_STR_DISABLED {
+		return SUBSCRIPTION_STATUS_DISABLED
+	}
+	return SUBSCRIPTION_STATUS_ENABLED
+}
+
+func SubscriptionStatus2String(status int) string {
+	if status == SUBSCRIPTION_STATUS_DISABLED {
+		return SUBSCRIPTION_STATUS_STR_DISABLED
+	}
+	return SUBSCRIPTION_STATUS_STR_ENABLED
+}
+
+// unixMilli 时间为空时返回0
+func unixMilli(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// GenSubscriptionEntry 投递目标配置中的密码、密钥以掩码返回
+func GenSubscriptionEntry(s *model.TDataDownloadSubscription) *DownloadSubscriptionEntry {
+	target := &delivery.Target{}
+	if err := json.Unmarshal([]byte(s.TargetConfig), target); err != nil {
+		target = &delivery.Target{Type: s.TargetType}
+	}
+	return &DownloadSubscriptionEntry{
+		ID:               s.ID,
+		FormViewID:       s.FormViewID,
+		Name:             s.Name,
+		Detail:           s.Detail,
+		Format:           s.FileFormat,
+		Delimiter:        s.Delimiter,
+		Encoding:         s.Encoding,
+		SplitRows:        s.SplitRows,
+		CronExpr:         s.CronExpr,
+		Incremental:      s.Incremental,
+		TimestampFieldID: s.TimestampFieldID,
+		Watermark:        unixMilli(s.Watermark),
+		Target:           target.Masked(),
+		Status:           SubscriptionStatus2String(s.Status),
+		NextRunAt:        unixMilli(s.NextRunAt),
+		LastRunAt:        unixMilli(s.LastRunAt),
+		CreatedAt:        s.CreatedAt.UnixMilli(),
+		UpdatedAt:        s.UpdatedAt.UnixMilli(),
+	}
+}
+
+func GenSubscriptionRunEntry(run *model.TDataDownloadSubscriptionRun) *DownloadSubscriptionRunEntry {
+	return &DownloadSubscriptionRunEntry{
+		ID:            run.ID,
+		Status:        TaskStatus2String(run.Status),
+		WatermarkFrom: unixMilli(run.WatermarkFrom),
+		WatermarkTo:   unixMilli(run.WatermarkTo),
+		RowCount:      run.RowCount,
+		FileName:      run.FileName,
+		Remark:        run.Remark,
+		StartedAt:     run.StartedAt.UnixMilli(),
+		FinishedAt:    unixMilli(run.FinishedAt),
+	}
+}
+
 type RowFilters struct {
 	Member []*Member `json:"member" form:"member" binding:"required,gte=1,dive"` // 限定对象
 }
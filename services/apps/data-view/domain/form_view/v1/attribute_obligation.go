@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/auth_service"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
+	my_errorcode "github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// obligationMaskRule 属性策略要求脱敏的字段，字段值全部替换为 *
+var obligationMaskRule = &model.DesensitizationRule{Method: "all"}
+
+// viewObligations 获取用户访问逻辑视图时生效的属性策略。
+// 通过子视图授权访问的用户没有逻辑视图的权限，属性策略与逻辑视图的权限无关，单独计算；计算失败时不能按没有属性策略处理。
+func (f *formViewUseCase) viewObligations(ctx context.Context, viewID, userID string) (*auth_service.AttributeObligations, error) {
+	obligations, err := f.DrivenAuthService.EvaluateAttributePolicies(ctx, &auth_service.AttributePolicyEvaluateReq{
+		ObjectId:    viewID,
+		ObjectType:  auth_service.ObjectTypeDataView,
+		SubjectId:   userID,
+		SubjectType: auth_service.SubjectTypeUser,
+	})
+	if err != nil {
+		log.WithContext(ctx).Error("evaluate attribute policies fail", zap.String("view", viewID), zap.String("user", userID), zap.Error(err))
+		return nil, err
+	}
+	return obligations, nil
+}
+
+// applyColumnObligations 按属性策略处理逻辑视图的字段：脱敏的字段写入 rules，返回需要隐藏的字段ID
+func applyColumnObligations(viewID string, obligations *auth_service.AttributeObligations, fields []*model.FormViewField, rules map[string]*model.DesensitizationRule) (hidden map[string]bool) {
+	hidden = make(map[string]bool)
+	if obligations == nil {
+		return hidden
+	}
+	fieldIDs := make(map[string]string, len(fields))
+	for _, field := range fields {
+		fieldIDs[field.TechnicalName] = field.ID
+	}
+	for _, column := range obligations.Columns {
+		id, ok := fieldIDs[column.Name]
+		if !ok || column.DataViewID != viewID {
+			continue
+		}
+		switch column.Action {
+		case auth_service.AttributeColumnHide:
+			hidden[id] = true
+		case auth_service.AttributeColumnMask:
+			rules[id] = obligationMaskRule
+		}
+	}
+	return hidden
+}
+
+// obligationRowSQL 属性策略的行过滤条件，多个条件使用 AND 连接
+func obligationRowSQL(viewID string, obligations *auth_service.AttributeObligations, fields []*model.FormViewField) (string, error) {
+	if obligations == nil {
+		return "", nil
+	}
+	dataTypes := make(map[string]string, len(fields))
+	for _, field := range fields {
+		dataTypes[field.TechnicalName] = field.DataType
+	}
+	var conditions []string
+	for _, filter := range obligations.RowFilters {
+		if filter.DataViewID != viewID {
+			continue
+		}
+		dataType, ok := dataTypes[filter.Name]
+		if !ok {
+			return "", errorcode.Detail(my_errorcode.FormViewFieldIDNotExist, fmt.Sprintf("row filter field: %s", filter.Name))
+		}
+		name := escape(filter.Name)
+		var condition string
+		switch filter.Operator {
+		case "=":
+			condition = fmt.Sprintf("%s = %s", name, obligationLiteral(filter.Value, dataType))
+		case "!=":
+			condition = fmt.Sprintf("%s <> %s", name, obligationLiteral(filter.Value, dataType))
+		case "in", "not in":
+			var values []string
+			for _, v := range strings.Split(filter.Value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					values = append(values, obligationLiteral(v, dataType))
+				}
+			}
+			// 值为空时 in 不匹配任何行，not in 匹配所有行
+			switch {
+			case len(values) > 0:
+				condition = fmt.Sprintf("%s %s (%s)", name, strings.ToUpper(filter.Operator), strings.Join(values, ","))
+			case filter.Operator == "in":
+				condition = "1 = 0"
+			default:
+				condition = "1 = 1"
+			}
+		case "null":
+			condition = fmt.Sprintf("%s IS NULL", name)
+		case "not null":
+			condition = fmt.Sprintf("%s IS NOT NULL", name)
+		default:
+			return "", errorcode.Detail(my_errorcode.AuthServiceCheckUsersAuthorityFailed, fmt.Sprintf("unsupported row filter operator: %s", filter.Operator))
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// obligationLiteral 将过滤值转为 SQL 字面量，数值类型的字段使用数值，其他类型作为字符串比较
+func obligationLiteral(value, dataType string) string {
+	if simple, ok := constant.SimpleTypeMapping[dataType]; ok {
+		dataType = simple
+	}
+	switch dataType {
+	case constant.SimpleInt, constant.SimpleFloat, constant.SimpleDecimal:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
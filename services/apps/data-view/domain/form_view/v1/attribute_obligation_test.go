@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/auth_service"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+)
+
+// 低密级用户下载时，带分级标签的字段按属性策略脱敏、隐藏，并追加行过滤条件
+func Test_applyObligations_LowClearanceUser(t *testing.T) {
+	fields := []*model.FormViewField{
+		{ID: "f1", TechnicalName: "id", BusinessName: "编号", DataType: "bigint"},
+		{ID: "f2", TechnicalName: "id_card", BusinessName: "身份证号", DataType: "varchar"},
+		{ID: "f3", TechnicalName: "salary", BusinessName: "薪资", DataType: "decimal"},
+		{ID: "f4", TechnicalName: "dept_id", BusinessName: "部门", DataType: "varchar"},
+	}
+	// auth-service 为密级不足的用户返回的属性策略
+	obligations := &auth_service.AttributeObligations{
+		Columns: []*auth_service.AttributeColumnObligation{
+			{DataViewID: "v1", Name: "id_card", Action: auth_service.AttributeColumnMask},
+			{DataViewID: "v1", Name: "salary", Action: auth_service.AttributeColumnHide},
+			{DataViewID: "v2", Name: "id", Action: auth_service.AttributeColumnHide},
+		},
+		RowFilters: []*auth_service.AttributeRowFilter{
+			{DataViewID: "v1", Name: "dept_id", Operator: "in", Value: "d1,d'2"},
+			{DataViewID: "v1", Name: "id", Operator: "!=", Value: "0"},
+		},
+	}
+
+	rules := make(map[string]*model.DesensitizationRule)
+	hidden := applyColumnObligations("v1", obligations, fields, rules)
+	assert.Equal(t, map[string]bool{"f3": true}, hidden)
+	assert.Equal(t, map[string]*model.DesensitizationRule{"f2": obligationMaskRule}, rules)
+
+	where, err := obligationRowSQL("v1", obligations, fields)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `("dept_id" IN ('d1','d''2')) AND ("id" <> 0)`, where)
+	}
+
+	td := &form_view.TaskDetailV2{Fields: []*form_view.FieldObjV1{{ID: "f1"}, {ID: "f2"}}, RowFilters: &form_view.RuleExpression{}}
+	_, drParams, err := generateDownloadReqParams(context.Background(), "u1", "c", "s", "t", td, fields, rules)
+	if assert.NoError(t, err) {
+		columns := strings.Split(drParams.Columns, ";")
+		assert.Equal(t, `"id"`, columns[0])
+		assert.Equal(t, `regexp_replace(CAST(id_card AS VARCHAR), '.', '*') AS id_card`, columns[1])
+	}
+}
+
+// fakeAuthService 用户只通过子视图获得授权，没有逻辑视图的权限
+type fakeAuthService struct {
+	auth_service.DrivenAuthService
+	obligations *auth_service.AttributeObligations
+	err         error
+}
+
+func (a *fakeAuthService) Enforce(ctx context.Context, requests []auth_service.EnforceRequest) ([]bool, error) {
+	return make([]bool, len(requests)), nil
+}
+
+func (a *fakeAuthService) VerifyUserPermissionObject(ctx context.Context, action string, objectType string, objectID string) (bool, error) {
+	return false, nil
+}
+
+func (a *fakeAuthService) EvaluateAttributePolicies(ctx context.Context, req *auth_service.AttributePolicyEvaluateReq) (*auth_service.AttributeObligations, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return a.obligations, nil
+}
+
+// 只有子视图授权的用户没有逻辑视图的权限，仍然按属性策略脱敏字段；属性策略计算失败时拒绝访问
+func Test_viewObligations_SubViewOnlyUser(t *testing.T) {
+	fields := []*model.FormViewField{{ID: "f1", TechnicalName: "id_card", DataType: "varchar"}}
+	authService := &fakeAuthService{obligations: &auth_service.AttributeObligations{
+		Columns: []*auth_service.AttributeColumnObligation{{DataViewID: "v1", Name: "id_card", Action: auth_service.AttributeColumnMask}},
+	}}
+	f := &formViewUseCase{DrivenAuthService: authService}
+
+	allowed, err := f.DrivenAuthService.VerifyUserPermissionObject(context.Background(), auth_service.Action_Read, auth_service.ObjectTypeDataView, "v1")
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	obligations, err := f.viewObligations(context.Background(), "v1", "u1")
+	if assert.NoError(t, err) {
+		rules := make(map[string]*model.DesensitizationRule)
+		applyColumnObligations("v1", obligations, fields, rules)
+		assert.Equal(t, map[string]*model.DesensitizationRule{"f1": obligationMaskRule}, rules)
+	}
+
+	authService.err = errors.New("auth-service unavailable")
+	obligations, err = f.viewObligations(context.Background(), "v1", "u1")
+	assert.Error(t, err)
+	assert.Nil(t, obligations)
+}
+
+func Test_obligationRowSQL(t *testing.T) {
+	fields := []*model.FormViewField{{ID: "f1", TechnicalName: "owner", DataType: "varchar"}}
+	where, err := obligationRowSQL("v1", &auth_service.AttributeObligations{RowFilters: []*auth_service.AttributeRowFilter{
+		{DataViewID: "v1", Name: "owner", Operator: "in", Value: ""},
+		{DataViewID: "v1", Name: "owner", Operator: "not null"},
+	}}, fields)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `(1 = 0) AND ("owner" IS NOT NULL)`, where)
+	}
+
+	_, err = obligationRowSQL("v1", &auth_service.AttributeObligations{RowFilters: []*auth_service.AttributeRowFilter{
+		{DataViewID: "v1", Name: "missing", Operator: "="},
+	}}, fields)
+	assert.Error(t, err)
+}
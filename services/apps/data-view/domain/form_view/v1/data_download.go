@@ -38,7 +38,7 @@ import (
 )
 
 const (
-	TMP_FILE_NAME         = "./.tmpexcel.xlsx"
+	TMP_DIR_PATTERN       = "data-download-"
 	TASK_EXECUTE_LOCK_KEY = "DOWNLOAD-TASK.EXECUTE-LOCK"
 )
 
@@ -64,6 +64,10 @@ func (f *formViewUseCase) CreateDataDownloadTask(ctx context.Context, req *form_
 		NameEN:     formview.TechnicalName,
 		Detail:     req.Detail,
 		Status:     form_view.TASK_STATUS_QUEUING,
+		FileFormat: lo.CoalesceOrEmpty(req.Format, form_view.DOWNLOAD_FORMAT_XLSX),
+		Delimiter:  lo.CoalesceOrEmpty(form_view.DownloadDelimiters[req.Delimiter], ","),
+		Encoding:   lo.CoalesceOrEmpty(req.Encoding, form_view.DOWNLOAD_ENCODING_UTF8),
+		SplitRows:  lo.CoalesceOrEmpty(req.SplitRows, form_view.DOWNLOAD_SPLIT_ROWS_DEFAULT),
 		CreatedAt:  timeNow,
 		CreatedBy:  ctx.Value(interception.InfoName).(*middleware.User).ID,
 		UpdatedAt:  timeNow,
@@ -161,7 +165,7 @@ func (f *formViewUseCase) GetDataDownloadLink(ctx context.Context, req *form_vie
 	}
 
 	var link string
-	link, err = f.ossGateway.DownloadLink(*tasks[0].FileUUID, fmt.Sprintf("%s-%s.xlsx", tasks[0].NameEN, tasks[0].CreatedAt.Format("20060102150405")))
+	link, err = f.ossGateway.DownloadLink(*tasks[0].FileUUID, fmt.Sprintf("%s-%s.%s", tasks[0].NameEN, tasks[0].CreatedAt.Format("20060102150405"), lo.CoalesceOrEmpty(tasks[0].FileExt, form_view.DOWNLOAD_FORMAT_XLSX)))
 	if err != nil {
 		log.WithContext(ctx).Errorf("f.downloadTaskRepo.Create DatabaseError", zap.Error(err))
 		return nil, errorcode.Detail(my_errorcode.PublicInternalServerError, err)
@@ -244,155 +248,55 @@ func (f *formViewUseCase) taskProcess(ctx context.Context) {
 
 	tasks[0].Status = form_view.TASK_STATUS_FAILED
 	if len(fvs) > 0 {
-		var (
-			catalog, schema string
-			dss             []*model.Datasource
-		)
-		switch fvs[0].Type {
-		case constant.FormViewTypeDatasource.Integer.Int32():
-			dss, err = f.datasourceRepo.GetByIds(ctx, []string{fvs[0].DatasourceID})
-			if err != nil {
-				log.WithContext(ctx).Errorf("f.datasourceRepo.GetByIds DatabaseError", zap.Error(err))
-				break
+		td = new(form_view.TaskDetailV2)
+		if err = jsoniter.Unmarshal(util.StringToBytes(tasks[0].Detail), td); err == nil {
+			var (
+				fields            []string
+				downloadReqParams *virtualization_engine.StreamDownloadReq
+			)
+			if fields, downloadReqParams, err = f.downloadReqParams(ctx, fvs[0], tasks[0].NameEN, tasks[0].CreatedBy, td); err != nil {
+				goto TASK_UPDATE
 			}
-			if len(dss) == 0 {
-				log.WithContext(ctx).Errorf("task related data source not existed")
-				err = errors.New("task related data source not existed")
-				break
-			}
-			strs := strings.Split(dss[0].DataViewSource, ".")
-			if len(strs) != 2 {
-				log.WithContext(ctx).Errorf("task related data source invalid")
-				err = errors.New("task related data source invalid")
-				break
-			}
-			catalog = strs[0]
-			schema = strs[1]
-		case constant.FormViewTypeCustom.Integer.Int32():
-			catalog = constant.CustomViewSource
-			schema = constant.ViewSourceSchema
-		case constant.FormViewTypeLogicEntity.Integer.Int32():
-			catalog = constant.LogicEntityViewSource
-			schema = constant.ViewSourceSchema
-		default:
-			err = errors.New("unknown form view type")
-			log.WithContext(ctx).Errorf("unknown form view type")
-		}
-
-		if err == nil {
-			td = new(form_view.TaskDetailV2)
-			if err = jsoniter.Unmarshal(util.StringToBytes(tasks[0].Detail), td); err == nil {
-				var (
-					streamWriter      *excelize.StreamWriter
-					fvFields          []*model.FormViewField
-					fields            []string
-					downloadReqParams *virtualization_engine.StreamDownloadReq
-				)
-
-				if fvFields, err = f.fieldRepo.GetFormViewFields(ctx, tasks[0].FormViewID); err != nil {
-					goto TASK_UPDATE
-				}
-				//脱敏
-				log.WithContext(ctx).Info("tasks[0].FormViewID:", zap.Any("tasks[0].FormViewID", tasks[0].FormViewID))
-				dataPrivacyPolicy, err := f.dataPrivacyPolicyRepo.GetByFormViewId(ctx, tasks[0].FormViewID)
-				if err != nil {
-					goto TASK_UPDATE
-				}
-				log.WithContext(ctx).Info("dataPrivacyPolicy:", zap.Any("dataPrivacyPolicy", dataPrivacyPolicy))
-				var dataPrivacyPolicyFields []*model.DataPrivacyPolicyField
-				if dataPrivacyPolicy != nil {
-					dataPrivacyPolicyFields, err = f.dataPrivacyPolicyFieldRepo.GetFieldsByDataPrivacyPolicyId(ctx, dataPrivacyPolicy.ID)
-					if err != nil {
-						goto TASK_UPDATE
-					}
-					log.WithContext(ctx).Info("dataPrivacyPolicyFields:", zap.Any("dataPrivacyPolicyFields", dataPrivacyPolicyFields))
-				}
-				fieldDesensitizationRuleMap := make(map[string]*model.DesensitizationRule)
-				if len(dataPrivacyPolicyFields) > 0 {
-					desensitizeRuleIds := make([]string, 0)
-					for _, field := range dataPrivacyPolicyFields {
-						desensitizeRuleIds = append(desensitizeRuleIds, field.DesensitizationRuleID)
-					}
-					desensitizationRuleMap := make(map[string]*model.DesensitizationRule)
-					desensitizationRules, err := f.desensitizationRuleRepo.GetByIds(ctx, desensitizeRuleIds)
-					if err != nil {
-						goto TASK_UPDATE
-					}
-					if len(desensitizationRules) > 0 {
-						for _, desensitizationRule := range desensitizationRules {
-							desensitizationRuleMap[desensitizationRule.ID] = desensitizationRule
-						}
-						for _, policyField := range dataPrivacyPolicyFields {
-							fieldDesensitizationRuleMap[policyField.FormViewFieldID] = desensitizationRuleMap[policyField.DesensitizationRuleID]
-						}
-					}
-				}
-				log.WithContext(ctx).Info("data download fieldDesensitizationRuleMap:", zap.Any("fieldDesensitizationRuleMap", fieldDesensitizationRuleMap))
-
-				if fields, downloadReqParams, err = generateDownloadReqParams(ctx, tasks[0].CreatedBy, catalog, schema, tasks[0].NameEN, td, fvFields, fieldDesensitizationRuleMap); err != nil {
-					goto TASK_UPDATE
-				}
-
-				// 查询白名单策略数据，添加筛选策略
-				var whitePolicyWhereSql = ""
-				if whitePolicyWhereSql, err = f.GetWhiteListPolicySql(ctx, tasks[0].FormViewID, tasks[0].CreatedBy); err != nil {
-					goto TASK_UPDATE
-				}
-
-				if whitePolicyWhereSql != "" {
-					if downloadReqParams.RowRules == "" {
-						downloadReqParams.RowRules = whitePolicyWhereSql
-					} else {
-						downloadReqParams.RowRules = fmt.Sprintf(`%s AND %s`, downloadReqParams.RowRules, whitePolicyWhereSql)
-					}
-				}
 
-				// 删除遗留的导出临时文件
-				os.Remove(TMP_FILE_NAME)
-
-				excelfile := excelize.NewFile(excelize.Options{CultureInfo: excelize.CultureNameZhCN})
-				defer excelfile.Close()
-				if streamWriter, err = excelfile.NewStreamWriter("Sheet1"); err != nil {
-					log.WithContext(ctx).Errorf("excelfile.NewStreamWriter failed", zap.Error(err))
+			var tmpDir string
+			if tmpDir, err = os.MkdirTemp("", TMP_DIR_PATTERN); err != nil {
+				log.WithContext(ctx).Errorf("os.MkdirTemp failed", zap.Error(err))
+				goto TASK_UPDATE
+			}
+			defer os.RemoveAll(tmpDir)
+
+			files := newDownloadFileSet(tmpDir, tasks[0].NameEN, fields, &downloadFileOptions{
+				Format:    lo.CoalesceOrEmpty(tasks[0].FileFormat, form_view.DOWNLOAD_FORMAT_XLSX),
+				Delimiter: tasks[0].Delimiter,
+				Encoding:  tasks[0].Encoding,
+				SplitRows: tasks[0].SplitRows,
+			})
+			defer files.Close()
+			if err = getDownloadResultSetV1(ctx, f, downloadReqParams, files, len(fields)); err == nil {
+				var filePath, fileExt string
+				if filePath, fileExt, err = files.Finish(); err != nil {
+					log.WithContext(ctx).Errorf("files.Finish failed", zap.Error(err))
 					goto TASK_UPDATE
 				}
 
-				rIdx := 1
-				if err = excelRowWrite(streamWriter, rIdx, lo.ToAnySlice[string](fields)); err != nil {
-					log.WithContext(ctx).Errorf("excelRowWrite field title failed", zap.Error(err))
+				var file *os.File
+				if file, err = os.Open(filePath); err != nil {
+					log.WithContext(ctx).Errorf("os.Open failed", zap.Error(err))
 					goto TASK_UPDATE
 				}
-
-				if err = getDownloadResultSetV1(ctx, f, downloadReqParams, streamWriter, rIdx, len(fields)); err == nil {
-					if err = streamWriter.Flush(); err != nil {
-						log.WithContext(ctx).Errorf("streamWriter.Flush failed", zap.Error(err))
-						goto TASK_UPDATE
-					}
-
-					if err := excelfile.SaveAs(TMP_FILE_NAME); err != nil {
-						log.WithContext(ctx).Errorf("excelfile.SaveAs failed", zap.Error(err))
-						goto TASK_UPDATE
-					}
-
-					var file *os.File
-					if file, err = os.Open(TMP_FILE_NAME); err != nil {
-						log.WithContext(ctx).Errorf("os.Open failed", zap.Error(err))
-						goto TASK_UPDATE
-					}
-					defer os.Remove(TMP_FILE_NAME)
-					defer file.Close()
-					fileUUID := uuid.NewString()
-					if err = f.ossGateway.MultiUpload(fileUUID, file); err == nil {
-						tasks[0].FileUUID = &fileUUID
-						tasks[0].Status = form_view.TASK_STATUS_FINISHED
-						tasks[0].Remark = nil
-					} else {
-						log.WithContext(ctx).Errorf("f.ossGateway.MultiUpload failed", zap.Error(err))
-					}
+				defer file.Close()
+				fileUUID := uuid.NewString()
+				if err = f.ossGateway.MultiUpload(fileUUID, file); err == nil {
+					tasks[0].FileUUID = &fileUUID
+					tasks[0].FileExt = fileExt
+					tasks[0].Status = form_view.TASK_STATUS_FINISHED
+					tasks[0].Remark = nil
+				} else {
+					log.WithContext(ctx).Errorf("f.ossGateway.MultiUpload failed", zap.Error(err))
 				}
-			} else {
-				log.WithContext(ctx).Errorf("jsoniter.Unmarshal task detail failed", zap.Error(err))
 			}
+		} else {
+			log.WithContext(ctx).Errorf("jsoniter.Unmarshal task detail failed", zap.Error(err))
 		}
 	} else {
 		log.WithContext(ctx).Errorf("task related form view not existed")
@@ -410,6 +314,116 @@ TASK_UPDATE:
 	}
 }
 
+// downloadViewSource 获取逻辑视图在虚拟化引擎中的 catalog 和 schema
+func (f *formViewUseCase) downloadViewSource(ctx context.Context, fv *model.FormView) (catalog, schema string, err error) {
+	switch fv.Type {
+	case constant.FormViewTypeDatasource.Integer.Int32():
+		dss, err := f.datasourceRepo.GetByIds(ctx, []string{fv.DatasourceID})
+		if err != nil {
+			log.WithContext(ctx).Errorf("f.datasourceRepo.GetByIds DatabaseError", zap.Error(err))
+			return "", "", err
+		}
+		if len(dss) == 0 {
+			log.WithContext(ctx).Errorf("task related data source not existed")
+			return "", "", errors.New("task related data source not existed")
+		}
+		strs := strings.Split(dss[0].DataViewSource, ".")
+		if len(strs) != 2 {
+			log.WithContext(ctx).Errorf("task related data source invalid")
+			return "", "", errors.New("task related data source invalid")
+		}
+		return strs[0], strs[1], nil
+	case constant.FormViewTypeCustom.Integer.Int32():
+		return constant.CustomViewSource, constant.ViewSourceSchema, nil
+	case constant.FormViewTypeLogicEntity.Integer.Int32():
+		return constant.LogicEntityViewSource, constant.ViewSourceSchema, nil
+	default:
+		log.WithContext(ctx).Errorf("unknown form view type")
+		return "", "", errors.New("unknown form view type")
+	}
+}
+
+// downloadDesensitizationRules 获取逻辑视图隐私策略中各字段的脱敏规则，key 为字段ID
+func (f *formViewUseCase) downloadDesensitizationRules(ctx context.Context, formViewID string) (map[string]*model.DesensitizationRule, error) {
+	fieldDesensitizationRuleMap := make(map[string]*model.DesensitizationRule)
+	dataPrivacyPolicy, err := f.dataPrivacyPolicyRepo.GetByFormViewId(ctx, formViewID)
+	if err != nil || dataPrivacyPolicy == nil {
+		return fieldDesensitizationRuleMap, err
+	}
+	dataPrivacyPolicyFields, err := f.dataPrivacyPolicyFieldRepo.GetFieldsByDataPrivacyPolicyId(ctx, dataPrivacyPolicy.ID)
+	if err != nil || len(dataPrivacyPolicyFields) == 0 {
+		return fieldDesensitizationRuleMap, err
+	}
+	desensitizeRuleIds := make([]string, 0, len(dataPrivacyPolicyFields))
+	for _, field := range dataPrivacyPolicyFields {
+		desensitizeRuleIds = append(desensitizeRuleIds, field.DesensitizationRuleID)
+	}
+	desensitizationRules, err := f.desensitizationRuleRepo.GetByIds(ctx, desensitizeRuleIds)
+	if err != nil || len(desensitizationRules) == 0 {
+		return fieldDesensitizationRuleMap, err
+	}
+	desensitizationRuleMap := make(map[string]*model.DesensitizationRule)
+	for _, desensitizationRule := range desensitizationRules {
+		desensitizationRuleMap[desensitizationRule.ID] = desensitizationRule
+	}
+	for _, policyField := range dataPrivacyPolicyFields {
+		fieldDesensitizationRuleMap[policyField.FormViewFieldID] = desensitizationRuleMap[policyField.DesensitizationRuleID]
+	}
+	log.WithContext(ctx).Info("data download fieldDesensitizationRuleMap:", zap.Any("fieldDesensitizationRuleMap", fieldDesensitizationRuleMap))
+	return fieldDesensitizationRuleMap, nil
+}
+
+// downloadReqParams 按下载配置生成导出数据的请求参数，字段按隐私策略脱敏，行过滤条件追加用户的白名单策略
+func (f *formViewUseCase) downloadReqParams(ctx context.Context, fv *model.FormView, tableName, userID string, td *form_view.TaskDetailV2) (fields []string, drParams *virtualization_engine.StreamDownloadReq, err error) {
+	catalog, schema, err := f.downloadViewSource(ctx, fv)
+	if err != nil {
+		return nil, nil, err
+	}
+	fvFields, err := f.fieldRepo.GetFormViewFields(ctx, fv.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	//脱敏
+	fieldDesensitizationRuleMap, err := f.downloadDesensitizationRules(ctx, fv.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 属性策略：脱敏、隐藏带分级标签的字段，追加行过滤条件
+	obligations, err := f.viewObligations(ctx, fv.ID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	hiddenFields := applyColumnObligations(fv.ID, obligations, fvFields, fieldDesensitizationRuleMap)
+	obligationWhereSql, err := obligationRowSQL(fv.ID, obligations, fvFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(hiddenFields) > 0 {
+		visible := *td
+		visible.Fields = lo.Filter(td.Fields, func(field *form_view.FieldObjV1, _ int) bool { return !hiddenFields[field.ID] })
+		if len(visible.Fields) == 0 {
+			return nil, nil, errorcode.Desc(my_errorcode.UserNotHaveThisFormViewPermissions)
+		}
+		td = &visible
+	}
+	if fields, drParams, err = generateDownloadReqParams(ctx, userID, catalog, schema, tableName, td, fvFields, fieldDesensitizationRuleMap); err != nil {
+		return nil, nil, err
+	}
+
+	// 查询白名单策略数据，添加筛选策略
+	whitePolicyWhereSql, err := f.GetWhiteListPolicySql(ctx, fv.ID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	drParams.RowRules = joinRowRules(drParams.RowRules, whitePolicyWhereSql, obligationWhereSql)
+	return fields, drParams, nil
+}
+
+// joinRowRules 使用 AND 连接非空的行过滤条件
+func joinRowRules(rules ...string) string {
+	return strings.Join(lo.Compact(rules), " AND ")
+}
+
 func excelRowWrite(streamWriter *excelize.StreamWriter, rowIdx int, rVals []interface{}) error {
 	if len(rVals) == 0 {
 		return nil
@@ -422,25 +436,21 @@ func excelRowWrite(streamWriter *excelize.StreamWriter, rowIdx int, rVals []inte
 	return streamWriter.SetRow(cell, rVals)
 }
 
-func getDownloadResultSetV1(ctx context.Context, f *formViewUseCase, drParams *virtualization_engine.StreamDownloadReq, streamWriter *excelize.StreamWriter, rowIdx int, fieldNum int) (err error) {
+func getDownloadResultSetV1(ctx context.Context, f *formViewUseCase, drParams *virtualization_engine.StreamDownloadReq, files *downloadFileSet, fieldNum int) (err error) {
 	var resp *virtualization_engine.StreamFetchResp
-	row := make([]interface{}, fieldNum)
+	row := make([]any, fieldNum)
 	if resp, err = f.DrivenVirtualizationEngine.StreamDataDownload(ctx, "", drParams); err != nil {
 		log.WithContext(ctx).Errorf("start f.DrivenVirtualizationEngine.StreamDataDownload failed", zap.Error(err))
 		return err
 	}
+	files.SetColumnTypes(lo.Map(resp.Columns, func(c *virtualization_engine.Column, _ int) string { return c.Type }))
 	for {
 		for i := range resp.Data {
 			for j := range resp.Data[i] {
-				if resp.Data[i][j] == nil {
-					row[j] = ""
-				} else {
-					row[j] = fmt.Sprint(resp.Data[i][j])
-				}
+				row[j] = resp.Data[i][j]
 			}
-			rowIdx++
-			if err = excelRowWrite(streamWriter, rowIdx, row); err != nil {
-				log.WithContext(ctx).Errorf("excelRowWrite failed", zap.Error(err))
+			if err = files.WriteRow(row); err != nil {
+				log.WithContext(ctx).Errorf("files.WriteRow failed", zap.Error(err))
 				return err
 			}
 		}
@@ -892,15 +902,34 @@ func (f *formViewUseCase) DataPreview(ctx context.Context, req *form_view.DataPr
 		}
 
 	}
+	// 属性策略：脱敏、隐藏带分级标签的字段，追加行过滤条件
+	obligations, err := f.viewObligations(ctx, req.FormViewId, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	hiddenFields := applyColumnObligations(req.FormViewId, obligations, fields, fieldDesensitizationRuleMap)
+	obligationWhereSql, err := obligationRowSQL(req.FormViewId, obligations, fields)
+	if err != nil {
+		return nil, err
+	}
+	whereSql = joinRowRules(whereSql, obligationWhereSql)
+	// 未指定字段时查询所有字段，有属性策略要求处理的字段时不能使用 *
+	previewFields := req.Fields
+	if len(previewFields) == 0 && obligations != nil && len(obligations.Columns) > 0 {
+		previewFields = lo.Map(fields, func(field *model.FormViewField, _ int) string { return field.ID })
+	}
 	log.WithContext(ctx).Info("DataPreview,fieldDesensitizationRuleMap:", zap.Any("fieldDesensitizationRuleMap", fieldDesensitizationRuleMap))
 	log.WithContext(ctx).Infof("fieldIDGradeIDMAP: %v", fieldIDGradeIDMap)
 	log.WithContext(ctx).Infof("fieldProtectionQueryMap: %v", fieldProtectionQueryMap)
 
 	var selectSql string
 	fieldNames := make([]string, 0)
-	for _, fieldId := range req.Fields {
+	for _, fieldId := range previewFields {
 		if fieldName, exist := fieldMap[fieldId]; exist {
 			escapeFieldName := escape(fieldName)
+			if hiddenFields[fieldId] {
+				continue
+			}
 			if gradeID, exist := fieldIDGradeIDMap[fieldId]; exist {
 				if isProtecdtion, valid := fieldProtectionQueryMap[gradeID]; valid && isProtecdtion {
 					continue
@@ -935,6 +964,9 @@ func (f *formViewUseCase) DataPreview(ctx context.Context, req *form_view.DataPr
 	}
 	if len(fieldNames) > 0 {
 		selectSql = strings.Join(fieldNames, ",")
+	} else if len(previewFields) > 0 {
+		// 指定的字段都不可见
+		return nil, errorcode.Desc(my_errorcode.UserNotHaveThisFormViewPermissions)
 	} else {
 		selectSql = "*"
 	}
@@ -0,0 +1,518 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/robfig/cron/v3"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_download_subscription"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/sub_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/auth_service"
+	my_errorcode "github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+const (
+	SUBSCRIPTION_EXECUTE_LOCK_KEY = "DOWNLOAD-SUBSCRIPTION.EXECUTE-LOCK"
+	// SUBSCRIPTION_BATCH_SIZE 每轮最多执行的订阅数量
+	SUBSCRIPTION_BATCH_SIZE = 10
+	// SUBSCRIPTION_WATERMARK_LAYOUT 增量导出时间条件的格式
+	SUBSCRIPTION_WATERMARK_LAYOUT = "2006-01-02 15:04:05.000"
+)
+
+func (f *formViewUseCase) CreateDownloadSubscription(ctx context.Context, req *form_view.DownloadSubscriptionCreateReq) (*form_view.DownloadSubscriptionIDResp, error) {
+	u, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	formView, err := f.repo.GetById(ctx, req.FormViewID)
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.repo.GetById error", zap.Error(err))
+		return nil, err
+	}
+
+	subscription := &model.TDataDownloadSubscription{FormViewID: formView.ID, CreatedBy: u.ID}
+	if err = f.applyDownloadSubscriptionConfig(ctx, formView, subscription, &req.DownloadSubscriptionConfig); err != nil {
+		return nil, err
+	}
+	if err = f.downloadSubscriptionRepo.Create(ctx, subscription); err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.Create DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err)
+	}
+	return &form_view.DownloadSubscriptionIDResp{ID: subscription.ID}, nil
+}
+
+func (f *formViewUseCase) UpdateDownloadSubscription(ctx context.Context, req *form_view.DownloadSubscriptionUpdateReq) (*form_view.DownloadSubscriptionIDResp, error) {
+	subscription, err := f.getOwnDownloadSubscription(ctx, req.SubscriptionID.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	formView, err := f.repo.GetById(ctx, subscription.FormViewID)
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.repo.GetById error", zap.Error(err))
+		return nil, err
+	}
+
+	// 未修改的密码、密钥沿用原配置
+	oldTarget := &delivery.Target{}
+	if err = json.Unmarshal([]byte(subscription.TargetConfig), oldTarget); err == nil && req.Target != nil {
+		if err = oldTarget.DecryptSecrets(); err != nil {
+			log.WithContext(ctx).Errorf("oldTarget.DecryptSecrets error", zap.Error(err))
+			return nil, errorcode.Detail(my_errorcode.PublicInternalServerError, err.Error())
+		}
+		req.Target.KeepSecrets(oldTarget)
+	}
+	if err = f.applyDownloadSubscriptionConfig(ctx, formView, subscription, &req.DownloadSubscriptionConfig); err != nil {
+		return nil, err
+	}
+	subscription.UpdatedAt = time.Now()
+	if err = f.downloadSubscriptionRepo.Update(ctx, subscription); err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.Update DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err)
+	}
+	return &form_view.DownloadSubscriptionIDResp{ID: subscription.ID}, nil
+}
+
+func (f *formViewUseCase) DeleteDownloadSubscription(ctx context.Context, req *form_view.DownloadSubscriptionPathReq) (*form_view.DownloadSubscriptionIDResp, error) {
+	subscription, err := f.getOwnDownloadSubscription(ctx, req.SubscriptionID.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if err = f.downloadSubscriptionRepo.Delete(ctx, subscription.ID); err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.Delete DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err)
+	}
+	return &form_view.DownloadSubscriptionIDResp{ID: subscription.ID}, nil
+}
+
+func (f *formViewUseCase) GetDownloadSubscriptionList(ctx context.Context, req *form_view.GetDownloadSubscriptionListReq) (*form_view.PageResultNew[form_view.DownloadSubscriptionEntry], error) {
+	u, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, subscriptions, err := f.downloadSubscriptionRepo.List(ctx, &data_download_subscription.ListOptions{
+		CreatedBy: u.ID,
+		Keyword:   req.Keyword,
+		Offset:    lo.CoalesceOrEmpty(req.Offset, 1),
+		Limit:     lo.CoalesceOrEmpty(req.Limit, 10),
+	})
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.List DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err)
+	}
+	resp := &form_view.PageResultNew[form_view.DownloadSubscriptionEntry]{
+		TotalCount: totalCount,
+		Entries:    make([]*form_view.DownloadSubscriptionEntry, len(subscriptions)),
+	}
+	for i := range subscriptions {
+		resp.Entries[i] = form_view.GenSubscriptionEntry(subscriptions[i])
+	}
+	return resp, nil
+}
+
+func (f *formViewUseCase) GetDownloadSubscriptionRuns(ctx context.Context, req *form_view.GetDownloadSubscriptionRunsReq) (*form_view.PageResultNew[form_view.DownloadSubscriptionRunEntry], error) {
+	subscription, err := f.getOwnDownloadSubscription(ctx, req.SubscriptionID.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	totalCount, runs, err := f.downloadSubscriptionRepo.ListRuns(ctx, subscription.ID, lo.CoalesceOrEmpty(req.Offset, 1), lo.CoalesceOrEmpty(req.Limit, 10))
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.ListRuns DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err)
+	}
+	resp := &form_view.PageResultNew[form_view.DownloadSubscriptionRunEntry]{
+		TotalCount: totalCount,
+		Entries:    make([]*form_view.DownloadSubscriptionRunEntry, len(runs)),
+	}
+	for i := range runs {
+		resp.Entries[i] = form_view.GenSubscriptionRunEntry(runs[i])
+	}
+	return resp, nil
+}
+
+// getOwnDownloadSubscription 获取当前用户创建的订阅
+func (f *formViewUseCase) getOwnDownloadSubscription(ctx context.Context, id uint64) (*model.TDataDownloadSubscription, error) {
+	u, err := util.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := f.downloadSubscriptionRepo.Get(ctx, id)
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.Get DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err)
+	}
+	if subscription == nil {
+		return nil, errorcode.Desc(my_errorcode.DownloadSubscriptionNotFound)
+	}
+	if subscription.CreatedBy != u.ID {
+		log.WithContext(ctx).Errorf("subscription create and operate user not matched")
+		return nil, errorcode.Desc(my_errorcode.UserNotHaveThisTaskPermissions)
+	}
+	return subscription, nil
+}
+
+// applyDownloadSubscriptionConfig 校验订阅配置及创建人的下载权限，并写入订阅
+func (f *formViewUseCase) applyDownloadSubscriptionConfig(ctx context.Context, formView *model.FormView, subscription *model.TDataDownloadSubscription, config *form_view.DownloadSubscriptionConfig) error {
+	td, err := parseSubscriptionDetail(config.Detail)
+	if err != nil {
+		return errorcode.Detail(my_errorcode.PublicInvalidParameter, err.Error())
+	}
+	if ok, err := f.isFormViewDownloadableBy(ctx, formView, subscription.CreatedBy, downloadDetailFieldIDs(td)); err != nil {
+		return err
+	} else if !ok {
+		log.WithContext(ctx).Errorf("user cannot download data-view %s", formView.ID)
+		return errorcode.Desc(my_errorcode.UserDoNotHaveDownloadAuthority)
+	}
+
+	schedule, err := cron.ParseStandard(config.CronExpr)
+	if err != nil {
+		return errorcode.Detail(my_errorcode.DownloadSubscriptionCronInvalid, err.Error())
+	}
+	if err = validateDeliveryTarget(config.Target); err != nil {
+		return errorcode.Detail(my_errorcode.DownloadSubscriptionTargetInvalid, err.Error())
+	}
+	// 密码、密钥加密保存
+	target, err := config.Target.Encrypted()
+	if err != nil {
+		return errorcode.Detail(my_errorcode.PublicInternalServerError, err.Error())
+	}
+	targetConfig, err := json.Marshal(target)
+	if err != nil {
+		return errorcode.Detail(my_errorcode.PublicInternalServerError, err.Error())
+	}
+
+	if config.Incremental {
+		timestampField, err := f.subscriptionTimestampField(ctx, formView.ID, "")
+		if err != nil {
+			return err
+		}
+		// 更换业务更新时间字段后重新全量导出
+		if subscription.TimestampFieldID != timestampField.ID {
+			subscription.Watermark = nil
+		}
+		subscription.TimestampFieldID = timestampField.ID
+	} else {
+		subscription.TimestampFieldID = ""
+		subscription.Watermark = nil
+	}
+
+	subscription.Name = config.Name
+	subscription.Detail = config.Detail
+	subscription.FileFormat = lo.CoalesceOrEmpty(config.Format, form_view.DOWNLOAD_FORMAT_CSV)
+	subscription.Delimiter = lo.CoalesceOrEmpty(form_view.DownloadDelimiters[config.Delimiter], ",")
+	subscription.Encoding = lo.CoalesceOrEmpty(config.Encoding, form_view.DOWNLOAD_ENCODING_UTF8)
+	subscription.SplitRows = lo.CoalesceOrEmpty(config.SplitRows, form_view.DOWNLOAD_SPLIT_ROWS_DEFAULT)
+	subscription.CronExpr = config.CronExpr
+	subscription.Incremental = config.Incremental
+	subscription.TargetType = config.Target.Type
+	subscription.TargetConfig = string(targetConfig)
+	subscription.Status = form_view.SubscriptionStatus2Enum(config.Status)
+	nextRunAt := schedule.Next(time.Now())
+	subscription.NextRunAt = &nextRunAt
+	return nil
+}
+
+// validateDeliveryTarget 校验投递目标中与类型对应的配置
+func validateDeliveryTarget(target *delivery.Target) error {
+	switch target.Type {
+	case delivery.TargetTypeSFTP:
+		if target.SFTP == nil {
+			return errors.New("sftp config is required")
+		}
+		if target.SFTP.Password == "" && target.SFTP.PrivateKey == "" {
+			return errors.New("sftp password or private key is required")
+		}
+		if !strings.HasPrefix(target.SFTP.HostKeyFingerprint, "SHA256:") {
+			return errors.New("sftp host key fingerprint is required, e.g. SHA256:xxx")
+		}
+		target.S3 = nil
+	case delivery.TargetTypeS3:
+		if target.S3 == nil {
+			return errors.New("s3 config is required")
+		}
+		if target.S3.SecretKey == "" {
+			return errors.New("s3 secret key is required")
+		}
+		target.SFTP = nil
+	default:
+		return fmt.Errorf("unsupported target type %s", target.Type)
+	}
+	return nil
+}
+
+// subscriptionTimestampField 获取增量导出使用的业务更新时间字段，优先使用 fieldID 对应的字段
+func (f *formViewUseCase) subscriptionTimestampField(ctx context.Context, formViewID, fieldID string) (*model.FormViewField, error) {
+	fields, err := f.fieldRepo.GetBusinessTimestamp(ctx, formViewID)
+	if err != nil {
+		log.WithContext(ctx).Errorf("get business timestamp for form view fiel: %v failed, err: %v", formViewID, err)
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+	}
+	if len(fields) == 0 {
+		return nil, errorcode.Desc(my_errorcode.BusinessTimestampNotFound)
+	}
+	for _, field := range fields {
+		if field.ID == fieldID {
+			return field, nil
+		}
+	}
+	return fields[0], nil
+}
+
+// parseSubscriptionDetail 解析订阅的导出配置，未配置行过滤规则时使用空规则
+func parseSubscriptionDetail(detail string) (*form_view.TaskDetailV2, error) {
+	td := new(form_view.TaskDetailV2)
+	if err := jsoniter.Unmarshal(util.StringToBytes(detail), td); err != nil {
+		return nil, err
+	}
+	if td.RowFilters == nil {
+		td.RowFilters = &form_view.RuleExpression{}
+	}
+	return td, nil
+}
+
+// downloadDetailFieldIDs 下载配置中需要导出的字段ID
+func downloadDetailFieldIDs(td *form_view.TaskDetailV2) []string {
+	ids := make([]string, 0, len(td.Fields))
+	for _, field := range td.Fields {
+		ids = append(ids, field.ID)
+	}
+	return ids
+}
+
+// isFormViewDownloadableBy 判断指定用户是否可以下载逻辑视图的指定字段，用于没有登录信息的定时执行。满足下列任
+// 意条件即可下载
+//  1. 用户是逻辑视图的 Owner
+//  2. 用户拥有逻辑视图的下载权限
+//  3. 用户拥有下载权限的子视图(行列规则)包含了全部字段
+func (f *formViewUseCase) isFormViewDownloadableBy(ctx context.Context, fv *model.FormView, userID string, fieldIDs []string) (bool, error) {
+	if userID == fv.OwnerId.String {
+		return true, nil
+	}
+
+	subViews, _, err := f.subViewRepo.List(ctx, sub_view.ListOptions{LogicViewID: uuid.MustParse(fv.ID)})
+	if err != nil {
+		return false, err
+	}
+	reqs := []*auth_service.VerifyUserAuthorityReq{{
+		ObjectId: fv.ID,
+		Action:   auth_service.Action_Download,
+		GetUsersObjectsReq: auth_service.GetUsersObjectsReq{
+			ObjectType:  auth_service.ObjectTypeDataView,
+			SubjectId:   userID,
+			SubjectType: auth_service.SubjectTypeUser,
+		},
+	}}
+	for _, sv := range subViews {
+		reqs = append(reqs, &auth_service.VerifyUserAuthorityReq{
+			ObjectId: sv.ID.String(),
+			Action:   auth_service.Action_Download,
+			GetUsersObjectsReq: auth_service.GetUsersObjectsReq{
+				ObjectType:  auth_service.ObjectTypeSubView,
+				SubjectId:   userID,
+				SubjectType: auth_service.SubjectTypeUser,
+			},
+		})
+	}
+	entries, err := f.DrivenAuthService.VerifyUserAuthority(ctx, reqs)
+	if err != nil {
+		return false, err
+	}
+
+	allowed := make(map[string]bool)
+	for _, e := range entries {
+		if e.Effect == auth_service.Effect_Allow {
+			allowed[e.ObjectId] = true
+		}
+	}
+	if allowed[fv.ID] {
+		return true, nil
+	}
+	// 有下载权限的子视图
+	var downloadable []model.SubView
+	for _, sv := range subViews {
+		if allowed[sv.ID.String()] {
+			downloadable = append(downloadable, sv)
+		}
+	}
+	if len(downloadable) == 0 {
+		return false, nil
+	}
+	downloadableFieldIDs := getFieldIDsFromSubViews(downloadable)
+	for _, id := range fieldIDs {
+		if !lo.Contains(downloadableFieldIDs, id) {
+			return false, errorcode.Detail(my_errorcode.UserNotHaveThisFieldPermissions, id)
+		}
+	}
+	return true, nil
+}
+
+// incrementalWhereSQL 按业务更新时间字段生成增量导出的过滤条件，范围为 [from, to)，from 为空时只限制截止时间
+func incrementalWhereSQL(field *model.FormViewField, from *time.Time, to time.Time) string {
+	name := escape(field.TechnicalName)
+	// 字符类型的业务更新时间字段转换为时间后比较
+	if field.DataType == "char" || field.DataType == "varchar" {
+		name = fmt.Sprintf("CAST(%s AS TIMESTAMP)", name)
+	}
+	where := fmt.Sprintf("%s < CAST('%s' AS TIMESTAMP)", name, to.Format(SUBSCRIPTION_WATERMARK_LAYOUT))
+	if from != nil {
+		where = fmt.Sprintf("%s >= CAST('%s' AS TIMESTAMP) AND %s", name, from.Format(SUBSCRIPTION_WATERMARK_LAYOUT), where)
+	}
+	return fmt.Sprintf(" ( %s ) ", where)
+}
+
+// subscriptionProcess 执行到期的订阅，多个实例通过分布式锁保证同一时间只有一个实例执行
+func (f *formViewUseCase) subscriptionProcess(ctx context.Context) {
+	if !f.redissonLock.TryLock(SUBSCRIPTION_EXECUTE_LOCK_KEY) {
+		return
+	}
+	defer f.redissonLock.Unlock(SUBSCRIPTION_EXECUTE_LOCK_KEY)
+
+	subscriptions, err := f.downloadSubscriptionRepo.ListDue(ctx, time.Now(), SUBSCRIPTION_BATCH_SIZE)
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.ListDue DatabaseError", zap.Error(err))
+		return
+	}
+	for _, subscription := range subscriptions {
+		f.runDownloadSubscription(ctx, subscription)
+	}
+}
+
+// runDownloadSubscription 执行一次订阅并记录执行结果，成功时推进增量水位
+func (f *formViewUseCase) runDownloadSubscription(ctx context.Context, subscription *model.TDataDownloadSubscription) {
+	now := time.Now()
+	// 先计算下次执行时间，执行失败时等待下一个周期重试，增量水位不变
+	subscription.LastRunAt = &now
+	if schedule, err := cron.ParseStandard(subscription.CronExpr); err == nil {
+		nextRunAt := schedule.Next(now)
+		subscription.NextRunAt = &nextRunAt
+	} else {
+		log.WithContext(ctx).Errorf("invalid cron expr of subscription %d", subscription.ID, zap.Error(err))
+		subscription.Status = form_view.SUBSCRIPTION_STATUS_DISABLED
+		subscription.NextRunAt = nil
+	}
+	// 只更新执行时间，避免覆盖调度期间对订阅的修改；订阅已被删除或停用时不执行
+	ok, err := f.downloadSubscriptionRepo.UpdateSchedule(ctx, subscription.ID, subscription.LastRunAt, subscription.NextRunAt, subscription.Status)
+	if err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.UpdateSchedule DatabaseError", zap.Error(err))
+		return
+	}
+	if !ok || subscription.Status != form_view.SUBSCRIPTION_STATUS_ENABLED {
+		return
+	}
+
+	run := &model.TDataDownloadSubscriptionRun{
+		SubscriptionID: subscription.ID,
+		Status:         form_view.TASK_STATUS_EXECUING,
+		StartedAt:      now,
+	}
+	if subscription.Incremental {
+		run.WatermarkFrom = subscription.Watermark
+		run.WatermarkTo = &now
+	}
+	if err := f.downloadSubscriptionRepo.CreateRun(ctx, run); err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.CreateRun DatabaseError", zap.Error(err))
+		return
+	}
+
+	err = f.exportDownloadSubscription(ctx, subscription, run)
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = form_view.TASK_STATUS_FINISHED
+	if err != nil {
+		log.WithContext(ctx).Errorf("run download subscription %d failed", subscription.ID, zap.Error(err))
+		remark := err.Error()
+		run.Remark = &remark
+		run.Status = form_view.TASK_STATUS_FAILED
+	} else if subscription.Incremental {
+		// 导出耗时较长，只推进水位，不覆盖执行期间对订阅的修改，订阅已被删除时不会重新插入
+		if ok, err = f.downloadSubscriptionRepo.UpdateWatermark(ctx, subscription.ID, run.WatermarkTo); err != nil {
+			log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.UpdateWatermark DatabaseError", zap.Error(err))
+		} else if !ok {
+			log.WithContext(ctx).Warnf("download subscription %d was deleted during run", subscription.ID)
+		}
+	}
+	if err = f.downloadSubscriptionRepo.UpdateRun(ctx, run); err != nil {
+		log.WithContext(ctx).Errorf("f.downloadSubscriptionRepo.UpdateRun DatabaseError", zap.Error(err))
+	}
+}
+
+// exportDownloadSubscription 以订阅创建人的身份导出数据，行列权限、脱敏规则及白名单策略与下载任务相同，导出后投递到目标
+func (f *formViewUseCase) exportDownloadSubscription(ctx context.Context, subscription *model.TDataDownloadSubscription, run *model.TDataDownloadSubscriptionRun) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+
+	formView, err := f.repo.GetById(ctx, subscription.FormViewID)
+	if err != nil {
+		return err
+	}
+	td, err := parseSubscriptionDetail(subscription.Detail)
+	if err != nil {
+		return err
+	}
+	if ok, err := f.isFormViewDownloadableBy(ctx, formView, subscription.CreatedBy, downloadDetailFieldIDs(td)); err != nil {
+		return err
+	} else if !ok {
+		return errorcode.Desc(my_errorcode.UserDoNotHaveDownloadAuthority)
+	}
+	target := &delivery.Target{}
+	if err = json.Unmarshal([]byte(subscription.TargetConfig), target); err != nil {
+		return err
+	}
+	if err = target.DecryptSecrets(); err != nil {
+		return err
+	}
+
+	fields, downloadReqParams, err := f.downloadReqParams(ctx, formView, formView.TechnicalName, subscription.CreatedBy, td)
+	if err != nil {
+		return err
+	}
+	if subscription.Incremental {
+		timestampField, err := f.subscriptionTimestampField(ctx, formView.ID, subscription.TimestampFieldID)
+		if err != nil {
+			return err
+		}
+		downloadReqParams.RowRules = joinRowRules(downloadReqParams.RowRules, incrementalWhereSQL(timestampField, run.WatermarkFrom, *run.WatermarkTo))
+	}
+
+	tmpDir, err := os.MkdirTemp("", TMP_DIR_PATTERN)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	name := fmt.Sprintf("%s-%s", formView.TechnicalName, run.StartedAt.Format("20060102150405"))
+	files := newDownloadFileSet(tmpDir, name, fields, &downloadFileOptions{
+		Format:    subscription.FileFormat,
+		Delimiter: subscription.Delimiter,
+		Encoding:  subscription.Encoding,
+		SplitRows: subscription.SplitRows,
+	})
+	defer files.Close()
+	if err = getDownloadResultSetV1(ctx, f, downloadReqParams, files, len(fields)); err != nil {
+		return err
+	}
+	filePath, fileExt, err := files.Finish()
+	if err != nil {
+		return err
+	}
+	run.RowCount = int64(files.rows)
+	run.FileName = strings.Join([]string{name, fileExt}, ".")
+	return f.deliverer.Deliver(ctx, target, run.FileName, filePath)
+}
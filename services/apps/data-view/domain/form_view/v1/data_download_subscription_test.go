@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+)
+
+func Test_incrementalWhereSQL(t *testing.T) {
+	from := time.Date(2024, 5, 1, 2, 0, 0, 0, time.Local)
+	to := time.Date(2024, 5, 2, 2, 0, 0, 123000000, time.Local)
+	tests := []struct {
+		name  string
+		field *model.FormViewField
+		from  *time.Time
+		want  string
+	}{
+		{
+			name:  "首次执行",
+			field: &model.FormViewField{TechnicalName: "updated_at", DataType: "timestamp"},
+			want:  ` ( "updated_at" < CAST('2024-05-02 02:00:00.123' AS TIMESTAMP) ) `,
+		},
+		{
+			name:  "增量",
+			field: &model.FormViewField{TechnicalName: "updated_at", DataType: "timestamp"},
+			from:  &from,
+			want:  ` ( "updated_at" >= CAST('2024-05-01 02:00:00.000' AS TIMESTAMP) AND "updated_at" < CAST('2024-05-02 02:00:00.123' AS TIMESTAMP) ) `,
+		},
+		{
+			name:  "字符类型",
+			field: &model.FormViewField{TechnicalName: "mtime", DataType: "varchar"},
+			from:  &from,
+			want:  ` ( CAST("mtime" AS TIMESTAMP) >= CAST('2024-05-01 02:00:00.000' AS TIMESTAMP) AND CAST("mtime" AS TIMESTAMP) < CAST('2024-05-02 02:00:00.123' AS TIMESTAMP) ) `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, incrementalWhereSQL(tt.field, tt.from, to))
+		})
+	}
+}
+
+func Test_joinRowRules(t *testing.T) {
+	assert.Equal(t, "", joinRowRules("", ""))
+	assert.Equal(t, "a = 1", joinRowRules("", "a = 1"))
+	assert.Equal(t, "a = 1 AND ( b < 2 )", joinRowRules("a = 1", "", "( b < 2 )"))
+}
+
+func Test_validateDeliveryTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  *delivery.Target
+		wantErr bool
+	}{
+		{
+			name:   "sftp 密码",
+			target: &delivery.Target{Type: delivery.TargetTypeSFTP, SFTP: &delivery.SFTPConfig{Host: "127.0.0.1", Password: "secret", HostKeyFingerprint: "SHA256:abc"}},
+		},
+		{
+			name:    "sftp 缺少主机公钥指纹",
+			target:  &delivery.Target{Type: delivery.TargetTypeSFTP, SFTP: &delivery.SFTPConfig{Host: "127.0.0.1", Password: "secret"}},
+			wantErr: true,
+		},
+		{
+			name:    "sftp 缺少认证信息",
+			target:  &delivery.Target{Type: delivery.TargetTypeSFTP, SFTP: &delivery.SFTPConfig{Host: "127.0.0.1"}},
+			wantErr: true,
+		},
+		{
+			name:    "s3 缺少配置",
+			target:  &delivery.Target{Type: delivery.TargetTypeS3},
+			wantErr: true,
+		},
+		{
+			name:   "s3",
+			target: &delivery.Target{Type: delivery.TargetTypeS3, S3: &delivery.S3Config{Bucket: "b", AccessKey: "ak", SecretKey: "sk"}, SFTP: &delivery.SFTPConfig{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDeliveryTarget(tt.target)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if err == nil && tt.target.Type == delivery.TargetTypeS3 {
+				assert.Nil(t, tt.target.SFTP)
+			}
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package v1
 
 import (
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
 )
 
 func Test_quote(t *testing.T) {
@@ -29,3 +36,73 @@ func Test_quote(t *testing.T) {
 		})
 	}
 }
+
+func Test_downloadFileSet_Split(t *testing.T) {
+	dir := t.TempDir()
+	files := newDownloadFileSet(dir, "orders", []string{"id", "名称"}, &downloadFileOptions{
+		Format:    form_view.DOWNLOAD_FORMAT_CSV,
+		Delimiter: ";",
+		Encoding:  form_view.DOWNLOAD_ENCODING_GBK,
+		SplitRows: 2,
+	})
+	for _, row := range [][]any{{1, "名称"}, {2, nil}, {3, "a;b"}, {4, "d"}, {5, "e"}} {
+		if !assert.NoError(t, files.WriteRow(row)) {
+			return
+		}
+	}
+	path, ext, err := files.Finish()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "zip", ext)
+	assert.Equal(t, filepath.Join(dir, "orders.zip"), path)
+
+	reader, err := zip.OpenReader(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer reader.Close()
+	if !assert.Len(t, reader.File, 3) {
+		return
+	}
+	assert.Equal(t, "orders-1.csv", reader.File[0].Name)
+	content := readZipFile(t, reader.File[0])
+	// 表头及内容均为 GBK 编码，“名称”为 c3fb b3c6
+	assert.Equal(t, "id;\xc3\xfb\xb3\xc6\n1;\xc3\xfb\xb3\xc6\n2;\n", content)
+	assert.Equal(t, "id;\xc3\xfb\xb3\xc6\n3;\"a;b\"\n4;d\n", readZipFile(t, reader.File[1]))
+}
+
+func Test_downloadFileSet_Jsonl(t *testing.T) {
+	files := newDownloadFileSet(t.TempDir(), "orders", []string{"id", "name"}, &downloadFileOptions{Format: form_view.DOWNLOAD_FORMAT_JSONL})
+	assert.NoError(t, files.WriteRow([]any{float64(1), "a"}))
+	assert.NoError(t, files.WriteRow([]any{float64(2), nil}))
+	path, ext, err := files.Finish()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, form_view.DOWNLOAD_FORMAT_JSONL, ext)
+
+	file, err := os.Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	content, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":null}\n", string(content))
+}
+
+func readZipFile(t *testing.T, file *zip.File) string {
+	r, err := file.Open()
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	return string(content)
+}
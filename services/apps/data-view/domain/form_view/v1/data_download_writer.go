@@ -0,0 +1,381 @@
+package v1
+
+import (
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/transform"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/util/parquet"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+)
+
+// downloadFileOptions 下载文件的格式配置
+type downloadFileOptions struct {
+	Format    string // 文件格式
+	Delimiter string // csv分隔符
+	Encoding  string // csv字符编码
+	SplitRows int    // 单个文件的最大行数
+}
+
+// downloadFileWriter 将查询结果写入单个文件，值为 nil 表示空值
+type downloadFileWriter interface {
+	WriteRow(row []any) error
+	Close() error
+}
+
+// newDownloadFileWriter 按格式创建文件并写入表头，types 为字段类型，只用于 parquet
+func newDownloadFileWriter(path string, fields, types []string, opts *downloadFileOptions) (downloadFileWriter, error) {
+	switch opts.Format {
+	case form_view.DOWNLOAD_FORMAT_XLSX:
+		return newXlsxDownloadWriter(path, fields)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	var w downloadFileWriter
+	switch opts.Format {
+	case form_view.DOWNLOAD_FORMAT_CSV:
+		w, err = newCsvDownloadWriter(file, fields, opts)
+	case form_view.DOWNLOAD_FORMAT_JSONL:
+		w = newJsonlDownloadWriter(file, fields)
+	case form_view.DOWNLOAD_FORMAT_PARQUET:
+		w, err = newParquetDownloadWriter(file, fields, types)
+	default:
+		err = fmt.Errorf("unsupported download format %s", opts.Format)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// downloadCellString 单元格的文本，空值为空字符串
+func downloadCellString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+//region xlsx
+
+type xlsxDownloadWriter struct {
+	path         string
+	file         *excelize.File
+	streamWriter *excelize.StreamWriter
+	rowIdx       int
+	row          []interface{}
+}
+
+func newXlsxDownloadWriter(path string, fields []string) (*xlsxDownloadWriter, error) {
+	file := excelize.NewFile(excelize.Options{CultureInfo: excelize.CultureNameZhCN})
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	w := &xlsxDownloadWriter{path: path, file: file, streamWriter: streamWriter, row: make([]interface{}, len(fields))}
+	header := make([]any, len(fields))
+	for i := range fields {
+		header[i] = fields[i]
+	}
+	if err = w.WriteRow(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *xlsxDownloadWriter) WriteRow(row []any) error {
+	for i := range row {
+		w.row[i] = downloadCellString(row[i])
+	}
+	w.rowIdx++
+	return excelRowWrite(w.streamWriter, w.rowIdx, w.row)
+}
+
+func (w *xlsxDownloadWriter) Close() error {
+	defer w.file.Close()
+	if err := w.streamWriter.Flush(); err != nil {
+		return err
+	}
+	return w.file.SaveAs(w.path)
+}
+
+//endregion
+
+//region csv
+
+type csvDownloadWriter struct {
+	file   *os.File
+	buf    *bufio.Writer
+	writer *csv.Writer
+	closer io.Closer // 转换编码时的 transform.Writer
+	record []string
+}
+
+func newCsvDownloadWriter(file *os.File, fields []string, opts *downloadFileOptions) (*csvDownloadWriter, error) {
+	buf := bufio.NewWriter(file)
+	var out io.Writer = buf
+	if opts.Encoding == form_view.DOWNLOAD_ENCODING_GBK {
+		// GBK 无法表示的字符替换为问号，避免整个下载任务失败
+		out = transform.NewWriter(buf, encoding.ReplaceUnsupported(simplifiedchinese.GBK.NewEncoder()))
+	}
+	writer := csv.NewWriter(out)
+	if opts.Delimiter != "" {
+		writer.Comma = []rune(opts.Delimiter)[0]
+	}
+	w := &csvDownloadWriter{file: file, buf: buf, writer: writer, record: make([]string, len(fields))}
+	// transform.Writer 需要在关闭时写出剩余的数据
+	if closer, ok := out.(io.Closer); ok {
+		w.closer = closer
+	}
+	if err := writer.Write(fields); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *csvDownloadWriter) WriteRow(row []any) error {
+	for i := range row {
+		w.record[i] = downloadCellString(row[i])
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvDownloadWriter) Close() error {
+	defer w.file.Close()
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return err
+	}
+	if w.closer != nil {
+		if err := w.closer.Close(); err != nil {
+			return err
+		}
+	}
+	return w.buf.Flush()
+}
+
+//endregion
+
+//region jsonl.gz
+
+type jsonlDownloadWriter struct {
+	file   *os.File
+	gzip   *gzip.Writer
+	buf    *bufio.Writer
+	fields [][]byte // json 编码后的字段名
+}
+
+func newJsonlDownloadWriter(file *os.File, fields []string) *jsonlDownloadWriter {
+	zw := gzip.NewWriter(file)
+	w := &jsonlDownloadWriter{file: file, gzip: zw, buf: bufio.NewWriter(zw), fields: make([][]byte, len(fields))}
+	for i := range fields {
+		w.fields[i], _ = json.Marshal(fields[i])
+	}
+	return w
+}
+
+// WriteRow 每行写为一个按字段顺序输出的 json 对象
+func (w *jsonlDownloadWriter) WriteRow(row []any) error {
+	w.buf.WriteByte('{')
+	for i := range row {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		value, err := json.Marshal(row[i])
+		if err != nil {
+			return err
+		}
+		w.buf.Write(w.fields[i])
+		w.buf.WriteByte(':')
+		w.buf.Write(value)
+	}
+	w.buf.WriteByte('}')
+	return w.buf.WriteByte('\n')
+}
+
+func (w *jsonlDownloadWriter) Close() error {
+	defer w.file.Close()
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	return w.gzip.Close()
+}
+
+//endregion
+
+//region parquet
+
+type parquetDownloadWriter struct {
+	file   *os.File
+	buf    *bufio.Writer
+	writer *parquet.Writer
+}
+
+// newParquetDownloadWriter 按字段类型生成列，没有字段类型时按字符串写入
+func newParquetDownloadWriter(file *os.File, fields, types []string) (*parquetDownloadWriter, error) {
+	columns := make([]parquet.Column, len(fields))
+	for i := range fields {
+		columns[i].Name = fields[i]
+		if i < len(types) {
+			columns[i].Type = types[i]
+		}
+	}
+	buf := bufio.NewWriter(file)
+	writer, err := parquet.NewWriter(buf, columns, parquet.DefaultRowGroupSize)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetDownloadWriter{file: file, buf: buf, writer: writer}, nil
+}
+
+func (w *parquetDownloadWriter) WriteRow(row []any) error {
+	return w.writer.Write(row)
+}
+
+func (w *parquetDownloadWriter) Close() error {
+	defer w.file.Close()
+	if err := w.writer.Close(); err != nil {
+		return err
+	}
+	return w.buf.Flush()
+}
+
+//endregion
+
+// downloadFileSet 写入下载结果，行数超过 SplitRows 时拆分为多个文件，最后打包为 zip
+type downloadFileSet struct {
+	dir    string
+	name   string
+	fields []string
+	types  []string // 虚拟化引擎返回的字段类型
+	opts   *downloadFileOptions
+
+	rows    int
+	parts   []string
+	current downloadFileWriter
+}
+
+func newDownloadFileSet(dir, name string, fields []string, opts *downloadFileOptions) *downloadFileSet {
+	if opts.SplitRows <= 0 {
+		opts.SplitRows = form_view.DOWNLOAD_SPLIT_ROWS_DEFAULT
+	}
+	return &downloadFileSet{dir: dir, name: name, fields: fields, opts: opts}
+}
+
+// SetColumnTypes 设置字段类型，需要在写入数据之前调用
+func (s *downloadFileSet) SetColumnTypes(types []string) {
+	s.types = types
+}
+
+func (s *downloadFileSet) WriteRow(row []any) error {
+	if s.current == nil || s.rows%s.opts.SplitRows == 0 && s.rows > 0 {
+		if err := s.nextPart(); err != nil {
+			return err
+		}
+	}
+	s.rows++
+	return s.current.WriteRow(row)
+}
+
+func (s *downloadFileSet) nextPart() error {
+	if s.current != nil {
+		if err := s.current.Close(); err != nil {
+			return err
+		}
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%s-%d.%s", s.name, len(s.parts)+1, s.opts.Format))
+	w, err := newDownloadFileWriter(path, s.fields, s.types, s.opts)
+	if err != nil {
+		return err
+	}
+	s.current = w
+	s.parts = append(s.parts, path)
+	return nil
+}
+
+// Close 关闭正在写入的文件，用于写入失败时释放文件句柄
+func (s *downloadFileSet) Close() {
+	if s.current != nil {
+		s.current.Close()
+		s.current = nil
+	}
+}
+
+// Finish 关闭正在写入的文件，返回需要上传的文件路径及其扩展名，拆分为多个文件时打包为 zip
+func (s *downloadFileSet) Finish() (path, ext string, err error) {
+	// 没有数据时也生成只有表头的文件
+	if s.current == nil {
+		if err = s.nextPart(); err != nil {
+			return "", "", err
+		}
+	}
+	current := s.current
+	s.current = nil
+	if err = current.Close(); err != nil {
+		return "", "", err
+	}
+	if len(s.parts) == 1 {
+		return s.parts[0], s.opts.Format, nil
+	}
+
+	path = filepath.Join(s.dir, s.name+".zip")
+	if err = zipFiles(path, s.parts); err != nil {
+		return "", "", err
+	}
+	return path, "zip", nil
+}
+
+// zipFiles 将文件打包到 zip 中，zip 内只保留文件名
+func zipFiles(path string, files []string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	zw := zip.NewWriter(out)
+	for _, name := range files {
+		if err = addZipFile(zw, name); err != nil {
+			return err
+		}
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func addZipFile(zw *zip.Writer, name string) error {
+	in, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	header := &zip.FileHeader{Name: filepath.Base(name), Method: zip.Deflate}
+	// 已经压缩过的格式直接存储
+	if filepath.Ext(name) == ".gz" || filepath.Ext(name) == ".parquet" || filepath.Ext(name) == ".xlsx" {
+		header.Method = zip.Store
+	}
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, in)
+	return err
+}
@@ -869,6 +869,7 @@ func (f *formViewUseCase) createView(ctx context.Context, createViewErrorCh chan
 		}
 		return errorcode.Detail(my_errorcode.DatabaseError, err.Error())
 	}
+	f.recordSchemaVersion(ctx, formView, table)
 	return nil
 }
 func (f *formViewUseCase) AutomaticallyForm(ctx context.Context, table *metadata.GetDataTableDetailDataBatchRes) (businessName string) {
@@ -1107,6 +1108,7 @@ func (f *formViewUseCase) updateView(ctx context.Context, createViewErrorCh chan
 		}
 		log.WithContext(ctx).Infof("【formViewUseCase】updateView fieldNewOrDelete clear synthetic-data result %d", result)
 	}
+	f.recordSchemaVersion(ctx, formView, table)
 
 	return nil
 }
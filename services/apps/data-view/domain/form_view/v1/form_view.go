@@ -33,7 +33,9 @@ import (
 	"golang.org/x/exp/slices"
 	"gorm.io/gorm"
 
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/delivery"
 	dataClassifyAttrBlacklistRepo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_classify_attribute_blacklist"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_download_subscription"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_preview_config"
 	dataPrivacyPolicyRepo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_privacy_policy"
 	dataPrivacyPolicyFieldRepo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/data_privacy_policy_field"
@@ -44,6 +46,8 @@ import (
 	repo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_extend"
 	fieldRepo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_field"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/form_view_schema_version"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/graph_model"
 	logicViewRepo "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/logic_view"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/scan_record"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/sub_view"
@@ -58,6 +62,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/auth_service"
 	configuration_center_local "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/configuration_center"
 	data_subject_local "github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/data-subject"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/data_application_service"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/data_exploration"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/metadata"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/oss_gateway"
@@ -73,6 +78,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/cache"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/dsg/services/lib/common/desensitization"
 	api_audit_v1 "github.com/kweaver-ai/idrm-go-common/api/audit/v1"
 	auth_service_v1 "github.com/kweaver-ai/idrm-go-common/api/auth-service/v1"
 	"github.com/kweaver-ai/idrm-go-common/audit"
@@ -155,6 +161,11 @@ type formViewUseCase struct {
 	authorizationDriven         authorization.Driven
 	DrivenMdlDataModel          mdl_data_model.DrivenMdlDataModel
 	departmentExploreReportRepo department_explore_report.DepartmentExploreReportRepo
+	schemaVersionRepo           form_view_schema_version.FormViewSchemaVersionRepo
+	graphModelRepo              graph_model.Repo
+	dataApplicationService      data_application_service.DrivenDataApplicationService
+	downloadSubscriptionRepo    data_download_subscription.DataDownloadSubscriptionRepo
+	deliverer                   delivery.Deliverer
 }
 
 func NewFormViewUseCase(
@@ -204,6 +215,11 @@ func NewFormViewUseCase(
 	authorizationDriven authorization.Driven,
 	drivenMdlDataModel mdl_data_model.DrivenMdlDataModel,
 	departmentExploreReportRepo department_explore_report.DepartmentExploreReportRepo,
+	schemaVersionRepo form_view_schema_version.FormViewSchemaVersionRepo,
+	graphModelRepo graph_model.Repo,
+	dataApplicationService data_application_service.DrivenDataApplicationService,
+	downloadSubscriptionRepo data_download_subscription.DataDownloadSubscriptionRepo,
+	deliverer delivery.Deliverer,
 ) form_view.FormViewUseCase {
 	useCase := &formViewUseCase{
 		repo:                          repo,
@@ -252,6 +268,11 @@ func NewFormViewUseCase(
 		authorizationDriven:           authorizationDriven,
 		DrivenMdlDataModel:            drivenMdlDataModel,
 		departmentExploreReportRepo:   departmentExploreReportRepo,
+		schemaVersionRepo:             schemaVersionRepo,
+		graphModelRepo:                graphModelRepo,
+		dataApplicationService:        dataApplicationService,
+		downloadSubscriptionRepo:      downloadSubscriptionRepo,
+		deliverer:                     deliverer,
 	}
 	useCase.clock = clock.RealClock{}
 	//go useCase.FixDatasourceStatus(context.Background())
@@ -268,6 +289,15 @@ func NewFormViewUseCase(
 			}
 		}()
 	}()
+	// 定时执行到期的数据下载订阅
+	go func() {
+		for {
+			ctx, span := af_trace.StartInternalSpan(context.Background())
+			useCase.subscriptionProcess(ctx)
+			af_trace.TelemetrySpanEnd(span, nil)
+			time.Sleep(30 * time.Second)
+		}
+	}()
 	return useCase
 }
 
@@ -1650,6 +1680,9 @@ func (f *formViewUseCase) GetViewsFields(ctx context.Context, req *form_view.Get
 			CodeTableID:      field.CodeTableID.String,
 			Index:            field.Index,
 		}
+		if field.GradeID.Valid {
+			fieldResult.LabelID = strconv.FormatInt(field.GradeID.Int64, 10)
+		}
 		viewResult.Fields = append(viewResult.Fields, fieldResult)
 	}
 	return results, nil
@@ -4074,23 +4107,7 @@ func reportConvert(ctx context.Context, isFullReport bool, fieldCount int, srcDa
 						for _, item := range enumItems {
 							if desensitizedRule != nil {
 								key := fmt.Sprintf("%v", item.Key)
-								if desensitizedRule.Method == "all" {
-									masked = strings.Repeat("*", len(key))
-								} else if desensitizedRule.Method == "middle" {
-									if int(desensitizedRule.MiddleBit) < len(key) {
-										headLen := (len(key) - int(desensitizedRule.MiddleBit)) / 2
-										tailLen := len(key) - int(desensitizedRule.MiddleBit) - headLen
-										masked = key[:headLen] + strings.Repeat("*", int(desensitizedRule.MiddleBit)) + key[len(key)-tailLen:]
-									} else {
-										masked = strings.Repeat("*", len(key))
-									}
-								} else if desensitizedRule.Method == "head-tail" {
-									if int(desensitizedRule.HeadBit)+int(desensitizedRule.TailBit) < len(key) {
-										masked = strings.Repeat("*", int(desensitizedRule.HeadBit)) + key[int(desensitizedRule.HeadBit):len(key)-int(desensitizedRule.TailBit)] + strings.Repeat("*", int(desensitizedRule.TailBit))
-									} else {
-										masked = strings.Repeat("*", len(key))
-									}
-								}
+								masked = desensitization.Desensitize(key, desensitizedRule.Method, int(desensitizedRule.MiddleBit), int(desensitizedRule.HeadBit), int(desensitizedRule.TailBit))
 							} else {
 								if item.Key != nil {
 									masked = fmt.Sprintf("%v", item.Key)
@@ -4135,23 +4152,7 @@ func reportConvert(ctx context.Context, isFullReport bool, fieldCount int, srcDa
 						for _, item := range maxValueItems {
 							if desensitizedRule != nil {
 								key := fmt.Sprintf("%v", item.Result)
-								if desensitizedRule.Method == "all" {
-									masked = strings.Repeat("*", len(key))
-								} else if desensitizedRule.Method == "middle" {
-									if int(desensitizedRule.MiddleBit) < len(key) {
-										headLen := (len(key) - int(desensitizedRule.MiddleBit)) / 2
-										tailLen := len(key) - int(desensitizedRule.MiddleBit) - headLen
-										masked = key[:headLen] + strings.Repeat("*", int(desensitizedRule.MiddleBit)) + key[len(key)-tailLen:]
-									} else {
-										masked = strings.Repeat("*", len(key))
-									}
-								} else if desensitizedRule.Method == "head-tail" {
-									if int(desensitizedRule.HeadBit)+int(desensitizedRule.TailBit) < len(key) {
-										masked = strings.Repeat("*", int(desensitizedRule.HeadBit)) + key[int(desensitizedRule.HeadBit):len(key)-int(desensitizedRule.TailBit)] + strings.Repeat("*", int(desensitizedRule.TailBit))
-									} else {
-										masked = strings.Repeat("*", len(key))
-									}
-								}
+								masked = desensitization.Desensitize(key, desensitizedRule.Method, int(desensitizedRule.MiddleBit), int(desensitizedRule.HeadBit), int(desensitizedRule.TailBit))
 							} else {
 								masked = fmt.Sprintf("%v", item.Result)
 							}
@@ -4864,28 +4865,8 @@ func (f *formViewUseCase) GetFieldExploreReport(ctx context.Context, req *form_v
 								}
 								log.WithContext(ctx).Info("GetFieldExploreReport,desensitizedRule:", zap.Any("desensitizedRule", desensitizedRule))
 								if desensitizedRule != nil {
-									if desensitizedRule.Method == "all" {
-										masked := strings.Repeat("*", len(value))
-										groupInfo.Value = &masked
-									} else if desensitizedRule.Method == "middle" {
-										if int(desensitizedRule.MiddleBit) < len(value) {
-											headLen := (len(value) - int(desensitizedRule.MiddleBit)) / 2
-											tailLen := len(value) - int(desensitizedRule.MiddleBit) - headLen
-											masked := value[:headLen] + strings.Repeat("*", int(desensitizedRule.MiddleBit)) + value[len(value)-tailLen:]
-											groupInfo.Value = &masked
-										} else {
-											masked := strings.Repeat("*", len(value))
-											groupInfo.Value = &masked
-										}
-									} else if desensitizedRule.Method == "head-tail" {
-										if int(desensitizedRule.HeadBit)+int(desensitizedRule.TailBit) < len(value) {
-											masked := strings.Repeat("*", int(desensitizedRule.HeadBit)) + value[int(desensitizedRule.HeadBit):len(value)-int(desensitizedRule.TailBit)] + strings.Repeat("*", int(desensitizedRule.TailBit))
-											groupInfo.Value = &masked
-										} else {
-											masked := strings.Repeat("*", len(value))
-											groupInfo.Value = &masked
-										}
-									}
+									masked := desensitization.Desensitize(value, desensitizedRule.Method, int(desensitizedRule.MiddleBit), int(desensitizedRule.HeadBit), int(desensitizedRule.TailBit))
+									groupInfo.Value = &masked
 								}
 								log.WithContext(ctx).Info("GetFieldExploreReport,groupInfo:", zap.Any("groupInfo", groupInfo))
 							}
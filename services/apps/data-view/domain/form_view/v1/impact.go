@@ -0,0 +1,346 @@
+package v1
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/gorm/sub_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
+	my_errorcode "github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// impactMaxDepth 逻辑视图逐层引用时依赖树的最大深度
+const impactMaxDepth = 5
+
+// impactBreakingAttributes 会导致下游依赖失效的字段属性变更，业务名称、注释等变更不影响下游
+var impactBreakingAttributes = map[string]bool{
+	"original_data_type": true,
+	"data_length":        true,
+	"data_accuracy":      true,
+}
+
+func (f *formViewUseCase) ImpactAnalysis(ctx context.Context, req *form_view.ImpactAnalysisReq) (*form_view.ImpactAnalysisResp, error) {
+	formView, err := f.repo.GetById(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	var field *model.FormViewField
+	if req.FieldID != "" {
+		if field, err = f.fieldRepo.GetField(ctx, req.FieldID); err != nil {
+			return nil, err
+		}
+		if field.FormViewID != formView.ID {
+			return nil, errorcode.Desc(my_errorcode.FormViewFieldIDNotExist)
+		}
+	}
+	root := f.impactTree(ctx, formView, field)
+	return &form_view.ImpactAnalysisResp{Root: root, Total: countImpactNodes(root)}, nil
+}
+
+func (f *formViewUseCase) DeleteFormViewDryRun(ctx context.Context, req *form_view.DeleteReq) (*form_view.ImpactAnalysisResp, error) {
+	formView, err := f.repo.GetById(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if formView.AuditStatus == constant.AuditStatusAuditing {
+		return nil, errorcode.Desc(my_errorcode.AuditStatusAuditingCannotDelete)
+	}
+	root := f.impactTree(ctx, formView, nil)
+	return &form_view.ImpactAnalysisResp{Root: root, Total: countImpactNodes(root)}, nil
+}
+
+func (f *formViewUseCase) ScanDryRun(ctx context.Context, req *form_view.ScanReq) (*form_view.ScanDryRunResp, error) {
+	dataSource, err := f.datasourceRepo.GetByIdWithCode(ctx, req.DatasourceID)
+	if err != nil {
+		return nil, err
+	}
+	if dataSource.Status == constant.DataSourceScanning {
+		return nil, errorcode.Desc(my_errorcode.DataSourceIsScanning)
+	}
+	//只读取元数据平台中已采集的元数据，不触发采集任务，试运行没有副作用
+	allTable, err := f.GetDataSourceAllTableInfo(ctx, dataSource)
+	if err != nil {
+		return nil, err
+	}
+	formViews, err := f.repo.GetFormViews(ctx, dataSource.ID)
+	if err != nil {
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+	}
+	formViewIDs := make([]string, 0, len(formViews))
+	for _, formView := range formViews {
+		formViewIDs = append(formViewIDs, formView.ID)
+	}
+	fields := make([]*model.FormViewField, 0)
+	if len(formViewIDs) > 0 {
+		if fields, err = f.fieldRepo.GetFieldsByFormViewIds(ctx, formViewIDs); err != nil {
+			return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+		}
+	}
+	viewFields := make(map[string][]*model.FormViewField)
+	for _, field := range fields {
+		if field.Status == constant.FormViewFieldDelete.Integer.Int32() {
+			continue
+		}
+		viewFields[field.FormViewID] = append(viewFields[field.FormViewID], field)
+	}
+	tableMap := make(map[string][]*form_view.SchemaVersionField, len(allTable))
+	for _, table := range allTable {
+		tableMap[table.Name] = schemaVersionFields(table)
+	}
+
+	res := &form_view.ScanDryRunResp{ScanViewCount: len(allTable), Changes: make([]*form_view.ScanChange, 0)}
+	for _, formView := range formViews {
+		collected, ok := tableMap[formView.TechnicalName]
+		if !ok {
+			res.Changes = append(res.Changes, &form_view.ScanChange{
+				FormViewID:    formView.ID,
+				TechnicalName: formView.TechnicalName,
+				BusinessName:  formView.BusinessName,
+				ChangeType:    form_view.ScanChangeViewDeleted,
+				Impact:        f.impactTree(ctx, formView, nil),
+			})
+			continue
+		}
+		fieldMap := make(map[string]*model.FormViewField, len(viewFields[formView.ID]))
+		for _, field := range viewFields[formView.ID] {
+			fieldMap[field.TechnicalName] = field
+		}
+		for _, change := range breakingFieldChanges(diffSchemaFields(formViewFieldSchema(viewFields[formView.ID]), collected)) {
+			field := fieldMap[change.TechnicalName]
+			scanChange := &form_view.ScanChange{
+				FormViewID:         formView.ID,
+				TechnicalName:      formView.TechnicalName,
+				BusinessName:       formView.BusinessName,
+				ChangeType:         form_view.ScanChangeFieldModified,
+				FieldID:            field.ID,
+				FieldTechnicalName: field.TechnicalName,
+				Attributes:         change.Attributes,
+				Impact:             f.impactTree(ctx, formView, field),
+			}
+			if change.ChangeType == form_view.SchemaFieldRemoved {
+				scanChange.ChangeType = form_view.ScanChangeFieldRemoved
+			}
+			res.Changes = append(res.Changes, scanChange)
+		}
+	}
+	return res, nil
+}
+
+// impactTree 生成逻辑视图的依赖树，field 不为空时根节点为字段，只包含引用了该字段的下游依赖
+func (f *formViewUseCase) impactTree(ctx context.Context, formView *model.FormView, field *model.FormViewField) *form_view.ImpactNode {
+	visited := map[string]bool{formView.ID: true}
+	root := &form_view.ImpactNode{Type: form_view.ImpactNodeFormView, ID: formView.ID, Name: formView.BusinessName, Code: formView.TechnicalName}
+	if field != nil {
+		root = &form_view.ImpactNode{Type: form_view.ImpactNodeField, ID: field.ID, Name: field.BusinessName, Code: field.TechnicalName}
+	}
+	root.Children = f.viewDependents(ctx, formView, field, visited, 1)
+	return root
+}
+
+// viewDependents 查询逻辑视图的下游依赖，某一类依赖查询失败时返回带错误信息的节点，不影响其它依赖
+func (f *formViewUseCase) viewDependents(ctx context.Context, formView *model.FormView, field *model.FormViewField, visited map[string]bool, depth int) []*form_view.ImpactNode {
+	children := make([]*form_view.ImpactNode, 0)
+
+	//行列规则
+	if logicViewID, err := uuid.Parse(formView.ID); err == nil {
+		subViews, _, err := f.subViewRepo.List(ctx, sub_view.ListOptions{LogicViewID: logicViewID})
+		if err != nil {
+			children = append(children, impactErrorNode(ctx, form_view.ImpactNodeSubView, err))
+		}
+		for _, subView := range subViews {
+			if field != nil && !strings.Contains(subView.Detail, field.ID) {
+				continue
+			}
+			children = append(children, &form_view.ImpactNode{Type: form_view.ImpactNodeSubView, ID: subView.ID.String(), Name: subView.Name})
+		}
+	}
+
+	//基于该视图 sql 构建的逻辑视图，逐层查询其下游依赖
+	if logicViews, err := f.referencingLogicViews(ctx, formView, field); err != nil {
+		children = append(children, impactErrorNode(ctx, form_view.ImpactNodeLogicView, err))
+	} else {
+		for _, logicView := range logicViews {
+			node := &form_view.ImpactNode{Type: form_view.ImpactNodeLogicView, ID: logicView.ID, Name: logicView.BusinessName, Code: logicView.TechnicalName}
+			if !visited[logicView.ID] && depth < impactMaxDepth {
+				visited[logicView.ID] = true
+				node.Children = f.viewDependents(ctx, logicView, nil, visited, depth+1)
+			}
+			children = append(children, node)
+		}
+	}
+
+	//元模型及引用元模型的专题模型、主题模型
+	var fieldID string
+	if field != nil {
+		fieldID = field.ID
+	}
+	if metaModels, err := f.graphModelRepo.ListMetaModelsByDataView(ctx, formView.ID, fieldID); err != nil {
+		children = append(children, impactErrorNode(ctx, form_view.ImpactNodeMetaModel, err))
+	} else {
+		for _, metaModel := range metaModels {
+			node := &form_view.ImpactNode{Type: form_view.ImpactNodeMetaModel, ID: metaModel.ID, Name: metaModel.BusinessName, Code: metaModel.TechnicalName}
+			compositeModels, err := f.graphModelRepo.ListModelsUsingMetas(ctx, metaModel.ID)
+			if err != nil {
+				node.Children = append(node.Children, impactErrorNode(ctx, form_view.ImpactNodeCompositeModel, err))
+			}
+			for _, compositeModel := range compositeModels {
+				node.Children = append(node.Children, &form_view.ImpactNode{Type: form_view.ImpactNodeCompositeModel, ID: compositeModel.ID, Name: compositeModel.BusinessName, Code: compositeModel.TechnicalName})
+			}
+			children = append(children, node)
+		}
+	}
+
+	//接口服务，接口引用的是整个视图，字段级分析时同样列出
+	if services, err := f.dataApplicationService.GetServicesByDataViewID(ctx, formView.ID); err != nil {
+		children = append(children, impactErrorNode(ctx, form_view.ImpactNodeService, err))
+	} else {
+		for _, service := range services {
+			children = append(children, &form_view.ImpactNode{Type: form_view.ImpactNodeService, ID: service.ServiceID, Name: service.ServiceName, Code: service.ServiceCode})
+		}
+	}
+
+	//数据资源目录
+	if catalogs, err := f.logicViewRepo.ViewsCatalogs(ctx, []string{formView.ID}); err != nil {
+		children = append(children, impactErrorNode(ctx, form_view.ImpactNodeDataCatalog, err))
+	} else {
+		for _, catalog := range catalogs {
+			children = append(children, &form_view.ImpactNode{Type: form_view.ImpactNodeDataCatalog, ID: strconv.FormatUint(catalog.ID, 10), Name: catalog.Name})
+		}
+	}
+
+	//已启用的探查规则
+	var rules []*model.ExploreRuleConfig
+	var err error
+	if field != nil {
+		rules, err = f.exploreRuleConfigRepo.GetByFieldId(ctx, field.ID)
+	} else {
+		rules, err = f.exploreRuleConfigRepo.GetRulesByFormViewIds(ctx, []string{formView.ID})
+	}
+	if err != nil {
+		children = append(children, impactErrorNode(ctx, form_view.ImpactNodeExploreRule, err))
+	}
+	for _, rule := range rules {
+		if rule.Enable != 1 {
+			continue
+		}
+		children = append(children, &form_view.ImpactNode{Type: form_view.ImpactNodeExploreRule, ID: rule.RuleID, Name: rule.RuleName})
+	}
+	return children
+}
+
+// referencingLogicViews 查询 sql 中引用了该视图（及字段）的自定义视图、逻辑实体视图
+func (f *formViewUseCase) referencingLogicViews(ctx context.Context, formView *model.FormView, field *model.FormViewField) ([]*model.FormView, error) {
+	viewSource, err := f.GetViewSource(ctx, formView.Type, formView.DatasourceID)
+	if err != nil {
+		return nil, err
+	}
+	sqls, err := f.logicViewRepo.GetLogicViewSQLsByKeyword(ctx, formView.TechnicalName)
+	if err != nil {
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+	}
+	ids := make([]string, 0)
+	for _, s := range sqls {
+		if s.FormViewID == formView.ID || !sqlReferencesView(s.Sql, viewSource, formView.TechnicalName) {
+			continue
+		}
+		if field != nil && !sqlReferencesField(s.Sql, field.TechnicalName) {
+			continue
+		}
+		ids = append(ids, s.FormViewID)
+	}
+	return f.logicViewRepo.GetBasicInfo(ctx, ids)
+}
+
+func impactErrorNode(ctx context.Context, nodeType string, err error) *form_view.ImpactNode {
+	log.WithContext(ctx).Warn("impact analysis query dependents failed", zap.String("type", nodeType), zap.Error(err))
+	return &form_view.ImpactNode{Type: nodeType, Error: err.Error()}
+}
+
+// countImpactNodes 统计依赖树中下游依赖的数量，不包含根节点和查询失败的节点
+func countImpactNodes(node *form_view.ImpactNode) int {
+	var count int
+	for _, child := range node.Children {
+		if child.Error == "" {
+			count++
+		}
+		count += countImpactNodes(child)
+	}
+	return count
+}
+
+// sqlReferencesView sql 中是否以 catalog.schema.table 的形式引用了视图，各部分可以带引号
+func sqlReferencesView(sql, viewSource, technicalName string) bool {
+	if viewSource == "" {
+		return false
+	}
+	parts := append(strings.Split(viewSource, "."), technicalName)
+	for i, part := range parts {
+		parts[i] = "[\"`]?" + regexp.QuoteMeta(part) + "[\"`]?"
+	}
+	return regexp.MustCompile(`(?i)(^|[^\w.])` + strings.Join(parts, `\s*\.\s*`) + `($|[^\w])`).MatchString(sql)
+}
+
+// sqlReferencesField sql 中是否引用了字段
+func sqlReferencesField(sql, technicalName string) bool {
+	return regexp.MustCompile("(?i)(^|[^\\w])[\"`]?" + regexp.QuoteMeta(technicalName) + "[\"`]?($|[^\\w])").MatchString(sql)
+}
+
+// formViewFieldSchema 逻辑视图当前字段，与扫描到的字段快照格式一致，便于对比
+func formViewFieldSchema(fields []*model.FormViewField) []*form_view.SchemaVersionField {
+	res := make([]*form_view.SchemaVersionField, 0, len(fields))
+	for _, field := range fields {
+		sf := &form_view.SchemaVersionField{
+			TechnicalName:    field.TechnicalName,
+			OriginalName:     field.OriginalName,
+			DataType:         field.DataType,
+			OriginalDataType: field.OriginalDataType,
+			DataLength:       field.DataLength,
+			IsNullable:       field.IsNullable,
+			PrimaryKey:       field.PrimaryKey.Bool,
+			Comment:          field.Comment.String,
+		}
+		if field.DataAccuracy.Valid {
+			accuracy := field.DataAccuracy.Int32
+			sf.DataAccuracy = &accuracy
+		}
+		res = append(res, sf)
+	}
+	return res
+}
+
+// breakingFieldChanges 筛选会导致下游依赖失效的字段变更：字段删除，以及原始类型、长度、精度的变更
+func breakingFieldChanges(changes []*form_view.SchemaFieldDiff) []*form_view.SchemaFieldDiff {
+	res := make([]*form_view.SchemaFieldDiff, 0)
+	for _, change := range changes {
+		switch change.ChangeType {
+		case form_view.SchemaFieldRemoved:
+			res = append(res, change)
+		case form_view.SchemaFieldModified:
+			attrs := make([]*form_view.SchemaAttributeDiff, 0)
+			for _, attr := range change.Attributes {
+				if impactBreakingAttributes[attr.Name] {
+					attrs = append(attrs, attr)
+				}
+			}
+			if len(attrs) > 0 {
+				res = append(res, &form_view.SchemaFieldDiff{
+					TechnicalName: change.TechnicalName,
+					ChangeType:    change.ChangeType,
+					Attributes:    attrs,
+					From:          change.From,
+					To:            change.To,
+				})
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+)
+
+func Test_sqlReferencesView(t *testing.T) {
+	source := "vdm_maria_abc.default"
+	assert.True(t, sqlReferencesView(`SELECT "id" FROM vdm_maria_abc.default.orders`, source, "orders"))
+	assert.True(t, sqlReferencesView(`select * from "vdm_maria_abc"."default"."orders" t1 join x on 1=1`, source, "orders"))
+	assert.True(t, sqlReferencesView("select * from `VDM_MARIA_ABC`.`default`.`Orders`", source, "orders"))
+	assert.False(t, sqlReferencesView(`select * from vdm_maria_abc.default.orders_his`, source, "orders"))
+	assert.False(t, sqlReferencesView(`select * from vdm_maria_xyz.default.orders`, source, "orders"))
+	assert.False(t, sqlReferencesView(`select * from orders`, source, "orders"))
+	assert.False(t, sqlReferencesView(`select * from vdm_maria_abc.default.orders`, "", "orders"))
+}
+
+func Test_sqlReferencesField(t *testing.T) {
+	assert.True(t, sqlReferencesField(`select "t1"."amount" from x t1`, "amount"))
+	assert.True(t, sqlReferencesField(`select amount, name from x`, "amount"))
+	assert.False(t, sqlReferencesField(`select amount_total from x`, "amount"))
+}
+
+func Test_breakingFieldChanges(t *testing.T) {
+	changes := []*form_view.SchemaFieldDiff{
+		{TechnicalName: "created_at", ChangeType: form_view.SchemaFieldAdded},
+		{TechnicalName: "name", ChangeType: form_view.SchemaFieldModified, Attributes: []*form_view.SchemaAttributeDiff{
+			{Name: "comment", From: "", To: "名称"},
+		}},
+		{TechnicalName: "price", ChangeType: form_view.SchemaFieldModified, Attributes: []*form_view.SchemaAttributeDiff{
+			{Name: "comment", From: "", To: "价格"},
+			{Name: "data_length", From: int32(10), To: int32(12)},
+		}},
+		{TechnicalName: "deleted", ChangeType: form_view.SchemaFieldRemoved},
+	}
+
+	res := breakingFieldChanges(changes)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "price", res[0].TechnicalName)
+		assert.Equal(t, []*form_view.SchemaAttributeDiff{{Name: "data_length", From: int32(10), To: int32(12)}}, res[0].Attributes)
+		assert.Equal(t, "deleted", res[1].TechnicalName)
+	}
+	assert.Len(t, changes[2].Attributes, 2)
+}
+
+func Test_countImpactNodes(t *testing.T) {
+	root := &form_view.ImpactNode{Type: form_view.ImpactNodeFormView, Children: []*form_view.ImpactNode{
+		{Type: form_view.ImpactNodeLogicView, Children: []*form_view.ImpactNode{
+			{Type: form_view.ImpactNodeService},
+			{Type: form_view.ImpactNodeService, Error: "timeout"},
+		}},
+		{Type: form_view.ImpactNodeMetaModel, Children: []*form_view.ImpactNode{
+			{Type: form_view.ImpactNodeCompositeModel},
+		}},
+	}}
+	assert.Equal(t, 4, countImpactNodes(root))
+}
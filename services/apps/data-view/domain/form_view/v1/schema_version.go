@@ -0,0 +1,251 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/adapter/driven/rest/metadata"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
+	my_errorcode "github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/infrastructure/db/model"
+	"github.com/kweaver-ai/idrm-go-common/errorcode"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+func (f *formViewUseCase) GetSchemaVersions(ctx context.Context, req *form_view.GetSchemaVersionsReq) (*form_view.GetSchemaVersionsResp, error) {
+	if _, err := f.repo.GetById(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	versions, err := f.schemaVersionRepo.List(ctx, req.ID)
+	if err != nil {
+		log.WithContext(ctx).Error("GetSchemaVersions List DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+	}
+	res := &form_view.GetSchemaVersionsResp{}
+	res.Entries = make([]*form_view.SchemaVersionInfo, 0, len(versions))
+	for _, v := range versions {
+		res.Entries = append(res.Entries, &form_view.SchemaVersionInfo{
+			Version:       v.Version,
+			FieldCount:    v.FieldCount,
+			AddedCount:    v.AddedCount,
+			RemovedCount:  v.RemovedCount,
+			ModifiedCount: v.ModifiedCount,
+			CreatedAt:     v.CreatedAt.UnixMilli(),
+		})
+	}
+	res.TotalCount = int64(len(res.Entries))
+	return res, nil
+}
+
+func (f *formViewUseCase) DiffSchemaVersions(ctx context.Context, req *form_view.DiffSchemaVersionsReq) (*form_view.DiffSchemaVersionsResp, error) {
+	if _, err := f.repo.GetById(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	from, err := f.getSchemaVersionFields(ctx, req.ID, req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := f.getSchemaVersionFields(ctx, req.ID, req.To)
+	if err != nil {
+		return nil, err
+	}
+	return &form_view.DiffSchemaVersionsResp{
+		FormViewID: req.ID,
+		From:       req.From,
+		To:         req.To,
+		Changes:    diffSchemaFields(from, to),
+	}, nil
+}
+
+func (f *formViewUseCase) getSchemaVersionFields(ctx context.Context, formViewID string, version int) ([]*form_view.SchemaVersionField, error) {
+	v, err := f.schemaVersionRepo.GetByVersion(ctx, formViewID, version)
+	if err != nil {
+		log.WithContext(ctx).Error("getSchemaVersionFields GetByVersion DatabaseError", zap.Error(err))
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+	}
+	if v == nil {
+		return nil, errorcode.Desc(my_errorcode.FormViewSchemaVersionNotExist)
+	}
+	var fields []*form_view.SchemaVersionField
+	if err = json.Unmarshal([]byte(v.Fields), &fields); err != nil {
+		return nil, errorcode.Detail(my_errorcode.DatabaseError, err.Error())
+	}
+	return fields, nil
+}
+
+// recordSchemaVersion 记录扫描到的逻辑视图结构，与最新版本相比没有变化时不生成新版本。
+// 生成新版本且存在上一个版本时发送结构变更消息。记录失败只打印日志，不影响扫描
+func (f *formViewUseCase) recordSchemaVersion(ctx context.Context, formView *model.FormView, table *metadata.GetDataTableDetailDataBatchRes) {
+	fields := schemaVersionFields(table)
+	fingerprint := schemaFingerprint(fields)
+
+	latest, err := f.schemaVersionRepo.GetLatest(ctx, formView.ID)
+	if err != nil {
+		log.WithContext(ctx).Error("recordSchemaVersion GetLatest DatabaseError", zap.String("formViewID", formView.ID), zap.Error(err))
+		return
+	}
+	if latest != nil && latest.Fingerprint == fingerprint {
+		return
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		log.WithContext(ctx).Error("recordSchemaVersion json.Marshal", zap.Error(err))
+		return
+	}
+	version := &model.FormViewSchemaVersion{
+		FormViewID:  formView.ID,
+		Version:     1,
+		Fields:      string(data),
+		Fingerprint: fingerprint,
+		FieldCount:  len(fields),
+		CreatedAt:   time.Now(),
+	}
+	var changes []*form_view.SchemaFieldDiff
+	if latest != nil {
+		var previous []*form_view.SchemaVersionField
+		if err = json.Unmarshal([]byte(latest.Fields), &previous); err != nil {
+			log.WithContext(ctx).Warn("recordSchemaVersion 上一版本字段快照解析失败", zap.String("formViewID", formView.ID), zap.Error(err))
+		}
+		changes = diffSchemaFields(previous, fields)
+		version.Version = latest.Version + 1
+		for _, c := range changes {
+			switch c.ChangeType {
+			case form_view.SchemaFieldAdded:
+				version.AddedCount++
+			case form_view.SchemaFieldRemoved:
+				version.RemovedCount++
+			case form_view.SchemaFieldModified:
+				version.ModifiedCount++
+			}
+		}
+	}
+	if err = f.schemaVersionRepo.Create(ctx, version); err != nil {
+		log.WithContext(ctx).Error("recordSchemaVersion Create DatabaseError", zap.String("formViewID", formView.ID), zap.Error(err))
+		return
+	}
+	if latest == nil {
+		return
+	}
+
+	event, err := json.Marshal(&form_view.SchemaChangeEvent{
+		FormViewID:      formView.ID,
+		TechnicalName:   formView.TechnicalName,
+		DatasourceID:    formView.DatasourceID,
+		Version:         version.Version,
+		PreviousVersion: latest.Version,
+		Changes:         changes,
+		ChangedAt:       version.CreatedAt.UnixMilli(),
+	})
+	if err != nil {
+		log.WithContext(ctx).Error("recordSchemaVersion json.Marshal event", zap.Error(err))
+		return
+	}
+	if err = f.kafkaPub.SyncProduce(constant.TopicFormViewSchemaChange, []byte(formView.ID), event); err != nil {
+		log.WithContext(ctx).Error("recordSchemaVersion 发送结构变更消息失败", zap.String("formViewID", formView.ID), zap.Error(err))
+	}
+}
+
+// schemaVersionFields 扫描到的源表字段快照
+func schemaVersionFields(table *metadata.GetDataTableDetailDataBatchRes) []*form_view.SchemaVersionField {
+	fields := make([]*form_view.SchemaVersionField, 0, len(table.Fields))
+	for _, field := range table.Fields {
+		sf := &form_view.SchemaVersionField{
+			TechnicalName:    field.FieldName,
+			OriginalName:     field.OrgFieldName,
+			DataType:         field.AdvancedParams.GetValue(constant.VirtualDataType),
+			OriginalDataType: field.FieldTypeName,
+			DataLength:       field.FieldLength,
+			IsNullable:       field.AdvancedParams.GetValue(constant.IsNullable),
+			PrimaryKey:       field.AdvancedParams.IsPrimaryKey(),
+			Comment:          util.CutStringByCharCount(field.FieldComment, constant.CommentCharCountLimit),
+		}
+		if field.FieldPrecision != nil {
+			accuracy := *field.FieldPrecision
+			sf.DataAccuracy = &accuracy
+		}
+		fields = append(fields, sf)
+	}
+	return fields
+}
+
+// schemaFingerprint 字段快照摘要，按字段技术名称排序后计算，只调整字段顺序不生成新版本
+func schemaFingerprint(fields []*form_view.SchemaVersionField) string {
+	sorted := make([]*form_view.SchemaVersionField, len(fields))
+	copy(sorted, fields)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TechnicalName < sorted[j].TechnicalName })
+	data, _ := json.Marshal(sorted)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// diffSchemaFields 按字段技术名称对比两个版本的字段，结果按 to 的字段顺序排列，删除的字段按 from 的字段顺序排在最后
+func diffSchemaFields(from, to []*form_view.SchemaVersionField) []*form_view.SchemaFieldDiff {
+	fromMap := make(map[string]*form_view.SchemaVersionField, len(from))
+	for _, field := range from {
+		fromMap[field.TechnicalName] = field
+	}
+	toMap := make(map[string]*form_view.SchemaVersionField, len(to))
+	for _, field := range to {
+		toMap[field.TechnicalName] = field
+	}
+
+	changes := make([]*form_view.SchemaFieldDiff, 0)
+	for _, field := range to {
+		old, ok := fromMap[field.TechnicalName]
+		if !ok {
+			changes = append(changes, &form_view.SchemaFieldDiff{TechnicalName: field.TechnicalName, ChangeType: form_view.SchemaFieldAdded, To: field})
+			continue
+		}
+		if attrs := diffSchemaField(old, field); len(attrs) > 0 {
+			changes = append(changes, &form_view.SchemaFieldDiff{TechnicalName: field.TechnicalName, ChangeType: form_view.SchemaFieldModified, Attributes: attrs, From: old, To: field})
+		}
+	}
+	for _, field := range from {
+		if _, ok := toMap[field.TechnicalName]; !ok {
+			changes = append(changes, &form_view.SchemaFieldDiff{TechnicalName: field.TechnicalName, ChangeType: form_view.SchemaFieldRemoved, From: field})
+		}
+	}
+	return changes
+}
+
+// diffSchemaField 对比同名字段的属性
+func diffSchemaField(from, to *form_view.SchemaVersionField) []*form_view.SchemaAttributeDiff {
+	attrs := make([]*form_view.SchemaAttributeDiff, 0)
+	add := func(name string, changed bool, fromValue, toValue any) {
+		if changed {
+			attrs = append(attrs, &form_view.SchemaAttributeDiff{Name: name, From: fromValue, To: toValue})
+		}
+	}
+	add("original_name", from.OriginalName != to.OriginalName, from.OriginalName, to.OriginalName)
+	add("data_type", from.DataType != to.DataType, from.DataType, to.DataType)
+	add("original_data_type", from.OriginalDataType != to.OriginalDataType, from.OriginalDataType, to.OriginalDataType)
+	add("data_length", from.DataLength != to.DataLength, from.DataLength, to.DataLength)
+	add("data_accuracy", !equalAccuracy(from.DataAccuracy, to.DataAccuracy), accuracyValue(from.DataAccuracy), accuracyValue(to.DataAccuracy))
+	add("is_nullable", from.IsNullable != to.IsNullable, from.IsNullable, to.IsNullable)
+	add("primary_key", from.PrimaryKey != to.PrimaryKey, from.PrimaryKey, to.PrimaryKey)
+	add("comment", from.Comment != to.Comment, from.Comment, to.Comment)
+	return attrs
+}
+
+func equalAccuracy(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func accuracyValue(a *int32) any {
+	if a == nil {
+		return nil
+	}
+	return *a
+}
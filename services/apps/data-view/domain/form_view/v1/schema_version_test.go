@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
+)
+
+func Test_diffSchemaFields(t *testing.T) {
+	accuracy := int32(2)
+	from := []*form_view.SchemaVersionField{
+		{TechnicalName: "id", DataType: "int", OriginalDataType: "int", PrimaryKey: true},
+		{TechnicalName: "name", DataType: "char", OriginalDataType: "varchar", DataLength: 50, IsNullable: "YES", Comment: "名称"},
+		{TechnicalName: "deleted", DataType: "boolean", OriginalDataType: "tinyint"},
+		{TechnicalName: "price", DataType: "decimal", OriginalDataType: "decimal", DataLength: 10},
+	}
+	to := []*form_view.SchemaVersionField{
+		{TechnicalName: "id", DataType: "int", OriginalDataType: "int", PrimaryKey: true},
+		{TechnicalName: "created_at", DataType: "timestamp", OriginalDataType: "datetime"},
+		{TechnicalName: "name", DataType: "char", OriginalDataType: "varchar", DataLength: 100, IsNullable: "NO", Comment: "名称"},
+		{TechnicalName: "price", DataType: "decimal", OriginalDataType: "decimal", DataLength: 10, DataAccuracy: &accuracy},
+	}
+
+	changes := diffSchemaFields(from, to)
+	if assert.Len(t, changes, 4) {
+		assert.Equal(t, "created_at", changes[0].TechnicalName)
+		assert.Equal(t, form_view.SchemaFieldAdded, changes[0].ChangeType)
+		assert.Nil(t, changes[0].From)
+
+		assert.Equal(t, "name", changes[1].TechnicalName)
+		assert.Equal(t, form_view.SchemaFieldModified, changes[1].ChangeType)
+		assert.Equal(t, []*form_view.SchemaAttributeDiff{
+			{Name: "data_length", From: int32(50), To: int32(100)},
+			{Name: "is_nullable", From: "YES", To: "NO"},
+		}, changes[1].Attributes)
+
+		assert.Equal(t, "price", changes[2].TechnicalName)
+		assert.Equal(t, []*form_view.SchemaAttributeDiff{
+			{Name: "data_accuracy", From: nil, To: int32(2)},
+		}, changes[2].Attributes)
+
+		assert.Equal(t, "deleted", changes[3].TechnicalName)
+		assert.Equal(t, form_view.SchemaFieldRemoved, changes[3].ChangeType)
+		assert.Nil(t, changes[3].To)
+	}
+
+	assert.Empty(t, diffSchemaFields(from, from))
+}
+
+func Test_schemaFingerprint(t *testing.T) {
+	a := []*form_view.SchemaVersionField{{TechnicalName: "id"}, {TechnicalName: "name"}}
+	b := []*form_view.SchemaVersionField{{TechnicalName: "name"}, {TechnicalName: "id"}}
+	c := []*form_view.SchemaVersionField{{TechnicalName: "id"}, {TechnicalName: "name", Comment: "名称"}}
+
+	assert.Equal(t, schemaFingerprint(a), schemaFingerprint(b))
+	assert.NotEqual(t, schemaFingerprint(a), schemaFingerprint(c))
+	assert.Equal(t, "id", a[0].TechnicalName)
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/kweaver-ai/dsg/services/apps/data-view/common/constant"
 	my_errorcode "github.com/kweaver-ai/dsg/services/apps/data-view/common/errorcode"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/common/util"
+	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/data_lineage/processor"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
 	formViewDomain "github.com/kweaver-ai/dsg/services/apps/data-view/domain/form_view"
 	"github.com/kweaver-ai/dsg/services/apps/data-view/domain/logic_view"
@@ -85,6 +86,7 @@ type logicViewUseCase struct {
 	exploreRuleConfigRepo explore_rule_config.ExploreRuleConfigRepo
 	subViewRepo           sub_view.SubViewRepo
 	DrivenMdlDataModel    mdl_data_model.DrivenMdlDataModel
+	lineageProcessor      *processor.FormViewInfoFetcher
 }
 
 func NewLogicViewUseCase(
@@ -113,6 +115,7 @@ func NewLogicViewUseCase(
 	exploreRuleConfigRepo explore_rule_config.ExploreRuleConfigRepo,
 	subViewRepo sub_view.SubViewRepo,
 	DrivenMdlDataModel mdl_data_model.DrivenMdlDataModel,
+	lineageProcessor *processor.FormViewInfoFetcher,
 ) logic_view.LogicViewUseCase {
 	useCase := &logicViewUseCase{
 		conf:                        conf,
@@ -141,6 +144,7 @@ func NewLogicViewUseCase(
 		subViewRepo:           subViewRepo,
 		clock:                 clock.RealClock{},
 		DrivenMdlDataModel:    DrivenMdlDataModel,
+		lineageProcessor:      lineageProcessor,
 	}
 	useCase.workflow.RegistConusmeHandlers(constant.AuditTypePublish,
 		useCase.logicViewRepo.ConsumerWorkflowAuditMsg,
@@ -509,6 +513,7 @@ func (l *logicViewUseCase) CreateLogicView(ctx context.Context, req *logic_view.
 		}
 		return "", errorcode.Detail(my_errorcode.LogicDatabaseError, err.Error())
 	}
+	l.syncSQLFieldLineage(ctx, logicView.ID)
 
 	//auditType, err := l.configurationCenterDriven.GetProcessBindByAuditType(ctx, &configuration_center.GetProcessBindByAuditTypeReq{AuditType: constant.AuditTypeOnline})
 	//if err != nil {
@@ -758,6 +763,7 @@ func (l *logicViewUseCase) UpdateLogicView(ctx context.Context, req *logic_view.
 		}
 		return errorcode.Detail(my_errorcode.LogicDatabaseError, err.Error())
 	}
+	l.syncSQLFieldLineage(ctx, logicView.ID)
 	//if err = l.formViewUseCase.LogicViewCreatePubES(ctx, logicView, fieldObjs); err != nil {
 	if err = l.esRepo.PubToES(ctx, logicView, fieldObjs); err != nil { //更新自定义、逻辑实体视图
 		return err
@@ -772,6 +778,14 @@ func (l *logicViewUseCase) UpdateLogicView(ctx context.Context, req *logic_view.
 	}
 	return nil
 }
+
+// syncSQLFieldLineage 解析视图 sql 更新字段级血缘，失败不影响视图的创建和编辑
+func (l *logicViewUseCase) syncSQLFieldLineage(ctx context.Context, id string) {
+	if _, err := l.lineageProcessor.SyncSQLFieldLineage(ctx, id); err != nil {
+		log.WithContext(ctx).Warn("sync sql field lineage failed", zap.String("id", id), zap.Error(err))
+	}
+}
+
 func (l *logicViewUseCase) GetDraftReq(ctx context.Context, req *logic_view.GetDraftReq) (*logic_view.GetDraftRes, error) {
 	return nil, nil
 }
@@ -19,8 +19,13 @@ require (
 	github.com/json-iterator/go v1.1.12
 	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/kweaver-ai/TelemetrySDK-Go/exporter/v2 v2.10.2
+	github.com/kweaver-ai/dsg/services/lib/common v0.0.0-00010101000000-000000000000
 	github.com/kweaver-ai/idrm-go-common v0.1.4-0.20260119010937-2456e402a095
 	github.com/kweaver-ai/idrm-go-frame v0.1.3
+	github.com/minio/minio-go/v7 v7.0.98
+	github.com/parquet-go/parquet-go v0.25.1
+	github.com/pkg/sftp v1.13.9
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/samber/lo v1.52.0
 	github.com/schollz/progressbar/v3 v3.14.5
 	github.com/shopspring/decimal v1.3.1
@@ -36,8 +41,10 @@ require (
 	go.opentelemetry.io/otel/sdk v1.39.0
 	go.opentelemetry.io/otel/trace v1.39.0
 	go.uber.org/zap v1.27.1
+	golang.org/x/crypto v0.46.0
 	golang.org/x/exp v0.0.0-20240823005443-9b4947da3948
 	golang.org/x/net v0.48.0
+	golang.org/x/text v0.32.0
 	google.golang.org/protobuf v1.34.2
 	gorm.io/driver/mysql v1.6.0
 	gorm.io/gen v0.3.27
@@ -170,13 +177,11 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/arch v0.23.0 // indirect
-	golang.org/x/crypto v0.46.0 // indirect
 	golang.org/x/mod v0.30.0 // indirect
 	golang.org/x/oauth2 v0.34.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/term v0.38.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
 	golang.org/x/time v0.14.0 // indirect
 	golang.org/x/tools v0.39.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
@@ -201,10 +206,22 @@ require (
 
 require (
 	dario.cat/mergo v1.0.2 // indirect
+	github.com/andybalholm/brotli v1.1.0 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/emirpasic/gods v1.18.1 // indirect
+	github.com/go-ini/ini v1.67.0 // indirect
 	github.com/google/pprof v0.0.0-20250208200701-d0013a598941 // indirect
+	github.com/klauspost/crc32 v1.3.0 // indirect
+	github.com/kr/fs v0.1.0 // indirect
+	github.com/minio/crc64nvme v1.1.1 // indirect
+	github.com/minio/md5-simd v1.1.2 // indirect
 	github.com/onsi/ginkgo/v2 v2.22.0 // indirect
 	github.com/onsi/gomega v1.34.2 // indirect
+	github.com/philhofer/fwd v1.2.0 // indirect
+	github.com/rs/xid v1.6.0 // indirect
 	github.com/thoas/go-funk v0.8.0 // indirect
+	github.com/tinylib/msgp v1.6.1 // indirect
 	go.uber.org/mock v0.6.0 // indirect
 )
+
+replace github.com/kweaver-ai/dsg/services/lib/common => ../../lib/common
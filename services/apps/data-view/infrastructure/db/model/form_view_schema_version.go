@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	utilities "github.com/kweaver-ai/idrm-go-frame/core/utils"
+	"gorm.io/gorm"
+)
+
+const TableNameFormViewSchemaVersion = "form_view_schema_version"
+
+// FormViewSchemaVersion mapped from table <form_view_schema_version>
+type FormViewSchemaVersion struct {
+	ID            uint64    `gorm:"column:id;primaryKey;comment:雪花id" json:"id"`                                         // 雪花id
+	FormViewID    string    `gorm:"column:form_view_id;not null;comment:逻辑视图id" json:"form_view_id"`                     // 逻辑视图id
+	Version       int       `gorm:"column:version;not null;comment:结构版本号，从1开始递增" json:"version"`                         // 结构版本号，从1开始递增
+	Fields        string    `gorm:"column:fields;not null;comment:字段快照，json数组" json:"fields"`                            // 字段快照，json数组
+	Fingerprint   string    `gorm:"column:fingerprint;not null;comment:字段快照摘要，用于判断结构是否变化" json:"fingerprint"`            // 字段快照摘要，用于判断结构是否变化
+	FieldCount    int       `gorm:"column:field_count;not null;comment:字段数量" json:"field_count"`                         // 字段数量
+	AddedCount    int       `gorm:"column:added_count;not null;comment:相对上一版本新增字段数" json:"added_count"`                  // 相对上一版本新增字段数
+	RemovedCount  int       `gorm:"column:removed_count;not null;comment:相对上一版本删除字段数" json:"removed_count"`              // 相对上一版本删除字段数
+	ModifiedCount int       `gorm:"column:modified_count;not null;comment:相对上一版本变更字段数" json:"modified_count"`            // 相对上一版本变更字段数
+	CreatedAt     time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:扫描时间" json:"created_at"` // 扫描时间
+}
+
+func (v *FormViewSchemaVersion) BeforeCreate(_ *gorm.DB) error {
+	if v == nil {
+		return nil
+	}
+	var err error
+	if v.ID == 0 {
+		v.ID, err = utilities.GetUniqueID()
+	}
+	return err
+}
+
+// TableName FormViewSchemaVersion's table name
+func (*FormViewSchemaVersion) TableName() string {
+	return TableNameFormViewSchemaVersion
+}
@@ -1,8 +1,50 @@
 package model
 
+import (
+	"time"
+
+	utilities "github.com/kweaver-ai/idrm-go-frame/core/utils"
+	"gorm.io/gorm"
+)
+
 type LineageFieldInfo struct {
 	FormViewField
 	TechnicalName    string `gorm:"column:view_technical_name" json:"view_technical_name" ` // 表技术名称
 	ViewBusinessName string `gorm:"column:view_business_name" json:"view_business_name"`    // 表业务名称
 	ViewDatasourceID string `gorm:"column:datasource_id"  json:"datasource_id"`             // 数据源id
 }
+
+const TableNameLineageField = "lineage_field"
+
+// LineageField mapped from table <lineage_field>，由自定义视图、逻辑实体视图的 sql 解析得到的字段级血缘
+type LineageField struct {
+	ID              uint64    `gorm:"column:id;primaryKey;comment:雪花id" json:"id"`                                                           // 雪花id
+	FormViewID      string    `gorm:"column:form_view_id;not null;comment:视图id（自定义视图及逻辑实体视图）" json:"form_view_id"`                           // 视图id（自定义视图及逻辑实体视图）
+	FieldID         string    `gorm:"column:field_id;not null;comment:视图字段id，sql 输出字段未匹配到视图字段时为空" json:"field_id"`                           // 视图字段id，sql 输出字段未匹配到视图字段时为空
+	FieldName       string    `gorm:"column:field_name;not null;comment:sql 输出字段名" json:"field_name"`                                        // sql 输出字段名
+	SourceTable     string    `gorm:"column:source_table;not null;comment:来源表，catalog.schema.table" json:"source_table"`                     // 来源表，catalog.schema.table
+	SourceColumn    string    `gorm:"column:source_column;not null;comment:来源字段名" json:"source_column"`                                      // 来源字段名
+	SourceViewID    string    `gorm:"column:source_view_id;not null;comment:来源视图id，未匹配到视图时为空" json:"source_view_id"`                         // 来源视图id，未匹配到视图时为空
+	SourceFieldID   string    `gorm:"column:source_field_id;not null;comment:来源视图字段id，未匹配到字段时为空" json:"source_field_id"`                     // 来源视图字段id，未匹配到字段时为空
+	Expression      string    `gorm:"column:expression;comment:加工表达式，直接引用来源字段时为空" json:"expression"`                                         // 加工表达式，直接引用来源字段时为空
+	SourceNamespace string    `gorm:"column:source_namespace;not null;comment:OpenLineage 导入的来源数据集命名空间，sql 解析的血缘为空" json:"source_namespace"` // OpenLineage 导入的来源数据集命名空间，sql 解析的血缘为空
+	OutputNamespace string    `gorm:"column:output_namespace;not null;comment:OpenLineage 导入的输出数据集命名空间，sql 解析的血缘为空" json:"output_namespace"` // OpenLineage 导入的输出数据集命名空间，sql 解析的血缘为空
+	OutputName      string    `gorm:"column:output_name;not null;comment:OpenLineage 导入的输出数据集名称，sql 解析的血缘为空" json:"output_name"`             // OpenLineage 导入的输出数据集名称，sql 解析的血缘为空
+	CreatedAt       time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP;comment:解析时间" json:"created_at"`                   // 解析时间
+}
+
+func (l *LineageField) BeforeCreate(_ *gorm.DB) error {
+	if l == nil {
+		return nil
+	}
+	var err error
+	if l.ID == 0 {
+		l.ID, err = utilities.GetUniqueID()
+	}
+	return err
+}
+
+// TableName LineageField's table name
+func (*LineageField) TableName() string {
+	return TableNameLineageField
+}
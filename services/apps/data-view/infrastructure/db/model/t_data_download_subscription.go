@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+
+	utilities "github.com/kweaver-ai/idrm-go-frame/core/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	TableNameDataDownloadSubscription    = "t_data_download_subscription"
+	TableNameDataDownloadSubscriptionRun = "t_data_download_subscription_run"
+)
+
+// TDataDownloadSubscription mapped from table <t_data_download_subscription>
+type TDataDownloadSubscription struct {
+	ID               uint64     `gorm:"column:id;primaryKey" json:"id"`                      // 订阅ID，雪花ID
+	FormViewID       string     `gorm:"column:form_view_id" json:"form_view_id"`             // 逻辑视图uuid
+	Name             string     `gorm:"column:name" json:"name"`                             // 订阅名称
+	Detail           string     `gorm:"column:detail" json:"detail"`                         // 导出配置详情，聚合json字符串（包含需要下载的列、行过滤条件信息）
+	FileFormat       string     `gorm:"column:file_format" json:"file_format"`               // 文件格式 xlsx csv jsonl.gz parquet
+	Delimiter        string     `gorm:"column:delimiter" json:"delimiter"`                   // csv分隔符
+	Encoding         string     `gorm:"column:encoding" json:"encoding"`                     // csv字符编码 utf-8 gbk
+	SplitRows        int        `gorm:"column:split_rows" json:"split_rows"`                 // 单个文件的最大行数
+	CronExpr         string     `gorm:"column:cron_expr" json:"cron_expr"`                   // 执行周期，标准五段式cron表达式
+	Incremental      bool       `gorm:"column:incremental" json:"incremental"`               // 是否按业务更新时间字段增量导出
+	TimestampFieldID string     `gorm:"column:timestamp_field_id" json:"timestamp_field_id"` // 增量导出使用的业务更新时间字段id
+	Watermark        *time.Time `gorm:"column:watermark" json:"watermark"`                   // 增量水位，上一次成功导出数据的截止时间
+	TargetType       string     `gorm:"column:target_type" json:"target_type"`               // 投递目标类型 sftp s3
+	TargetConfig     string     `gorm:"column:target_config" json:"target_config"`           // 投递目标配置，json字符串
+	Status           int        `gorm:"column:status" json:"status"`                         // 订阅状态 1 启用 2 停用
+	NextRunAt        *time.Time `gorm:"column:next_run_at" json:"next_run_at"`               // 下次执行时间
+	LastRunAt        *time.Time `gorm:"column:last_run_at" json:"last_run_at"`               // 上次执行时间
+	CreatedAt        time.Time  `gorm:"column:created_at" json:"created_at"`                 // 创建时间
+	CreatedBy        string     `gorm:"column:created_by" json:"created_by"`                 // 创建人id
+	UpdatedAt        time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`  // 更新时间
+}
+
+// TableName TDataDownloadSubscription's table name
+func (*TDataDownloadSubscription) TableName() string {
+	return TableNameDataDownloadSubscription
+}
+
+func (d *TDataDownloadSubscription) BeforeCreate(_ *gorm.DB) error {
+	if d == nil {
+		return nil
+	}
+	var err error
+	if d.ID == 0 {
+		d.ID, err = utilities.GetUniqueID()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = time.Now()
+	}
+	return err
+}
+
+// TDataDownloadSubscriptionRun mapped from table <t_data_download_subscription_run>
+type TDataDownloadSubscriptionRun struct {
+	ID             uint64     `gorm:"column:id;primaryKey" json:"id"`                // 执行记录ID，雪花ID
+	SubscriptionID uint64     `gorm:"column:subscription_id" json:"subscription_id"` // 订阅ID
+	Status         int        `gorm:"column:status" json:"status"`                   // 执行状态 2 执行中 3 已完成 4 执行失败
+	WatermarkFrom  *time.Time `gorm:"column:watermark_from" json:"watermark_from"`   // 增量导出的起始时间，全量导出时为空
+	WatermarkTo    *time.Time `gorm:"column:watermark_to" json:"watermark_to"`       // 增量导出的截止时间，全量导出时为空
+	RowCount       int64      `gorm:"column:row_count" json:"row_count"`             // 导出行数
+	FileName       string     `gorm:"column:file_name" json:"file_name"`             // 投递的文件名称
+	Remark         *string    `gorm:"column:remark" json:"remark"`                   // 执行失败说明
+	StartedAt      time.Time  `gorm:"column:started_at" json:"started_at"`           // 开始时间
+	FinishedAt     *time.Time `gorm:"column:finished_at" json:"finished_at"`         // 结束时间
+}
+
+// TableName TDataDownloadSubscriptionRun's table name
+func (*TDataDownloadSubscriptionRun) TableName() string {
+	return TableNameDataDownloadSubscriptionRun
+}
+
+func (r *TDataDownloadSubscriptionRun) BeforeCreate(_ *gorm.DB) error {
+	if r == nil {
+		return nil
+	}
+	var err error
+	if r.ID == 0 {
+		r.ID, err = utilities.GetUniqueID()
+	}
+	if r.StartedAt.IsZero() {
+		r.StartedAt = time.Now()
+	}
+	return err
+}
@@ -19,6 +19,11 @@ type TDataDownloadTask struct {
 	Status     int       `gorm:"column:status" json:"status"`                        // 任务状态 1 排队中 2 执行中（数据准备中） 3 已完成（可下载） 4 执行失败（异常）
 	Remark     *string   `gorm:"column:remark" json:"remark"`                        // 执行失败说明
 	FileUUID   *string   `gorm:"column:file_uuid" json:"created_by_uid"`             // 创建人id
+	FileFormat string    `gorm:"column:file_format" json:"file_format"`              // 文件格式 xlsx csv jsonl.gz parquet
+	Delimiter  string    `gorm:"column:delimiter" json:"delimiter"`                  // csv分隔符
+	Encoding   string    `gorm:"column:encoding" json:"encoding"`                    // csv字符编码 utf-8 gbk
+	SplitRows  int       `gorm:"column:split_rows" json:"split_rows"`                // 单个文件的最大行数
+	FileExt    string    `gorm:"column:file_ext" json:"file_ext"`                    // 导出文件的扩展名，拆分为多个文件时为zip
 	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`                // 创建时间
 	CreatedBy  string    `gorm:"column:created_by" json:"created_by"`                // 创建人id
 	UpdatedAt  time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"` // 更新时间
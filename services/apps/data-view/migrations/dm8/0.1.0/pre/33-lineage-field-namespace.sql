@@ -0,0 +1,6 @@
+SET SCHEMA af_main;
+
+-- 为视图字段级血缘表(lineage_field)添加 OpenLineage 导入的数据集命名空间和输出数据集名称字段
+ALTER TABLE "lineage_field" ADD COLUMN IF NOT EXISTS "source_namespace" VARCHAR(255 char) NOT NULL DEFAULT '';
+ALTER TABLE "lineage_field" ADD COLUMN IF NOT EXISTS "output_namespace" VARCHAR(255 char) NOT NULL DEFAULT '';
+ALTER TABLE "lineage_field" ADD COLUMN IF NOT EXISTS "output_name" VARCHAR(512 char) NOT NULL DEFAULT '';
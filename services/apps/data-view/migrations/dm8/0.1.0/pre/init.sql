@@ -160,6 +160,11 @@ CREATE TABLE IF NOT EXISTS  "t_data_download_task" (
     "status" int NOT NULL,
     "remark" text  DEFAULT NULL,
     "file_uuid" VARCHAR(36 char) DEFAULT NULL,
+    "file_format" VARCHAR(16 char) NOT NULL DEFAULT 'xlsx',
+    "delimiter" VARCHAR(4 char) NOT NULL DEFAULT ',',
+    "encoding" VARCHAR(16 char) NOT NULL DEFAULT 'utf-8',
+    "split_rows" int NOT NULL DEFAULT 1000000,
+    "file_ext" VARCHAR(16 char) NOT NULL DEFAULT 'xlsx',
     "created_at" datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3),
     "created_by" VARCHAR(36 char) NOT NULL,
     "updated_at" datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3),
@@ -168,6 +173,51 @@ CREATE TABLE IF NOT EXISTS  "t_data_download_task" (
 
 CREATE INDEX IF NOT EXISTS t_data_download_task_idx_data_download_task_status_created_by ON "t_data_download_task"("status", "created_by");
 
+CREATE TABLE IF NOT EXISTS "t_data_download_subscription" (
+    "id" BIGINT NOT NULL,
+    "form_view_id" VARCHAR(36 char) NOT NULL,
+    "name" VARCHAR(255 char) NOT NULL,
+    "detail" text NOT NULL,
+    "file_format" VARCHAR(16 char) NOT NULL DEFAULT 'csv',
+    "delimiter" VARCHAR(4 char) NOT NULL DEFAULT ',',
+    "encoding" VARCHAR(16 char) NOT NULL DEFAULT 'utf-8',
+    "split_rows" int NOT NULL DEFAULT 1000000,
+    "cron_expr" VARCHAR(128 char) NOT NULL,
+    "incremental" TINYINT NOT NULL DEFAULT 0,
+    "timestamp_field_id" VARCHAR(36 char) NOT NULL DEFAULT '',
+    "watermark" datetime(3) DEFAULT NULL,
+    "target_type" VARCHAR(16 char) NOT NULL,
+    "target_config" text NOT NULL,
+    "status" int NOT NULL DEFAULT 1,
+    "next_run_at" datetime(3) DEFAULT NULL,
+    "last_run_at" datetime(3) DEFAULT NULL,
+    "created_at" datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3),
+    "created_by" VARCHAR(36 char) NOT NULL,
+    "updated_at" datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3),
+    CLUSTER PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS t_data_download_subscription_idx_created_by ON "t_data_download_subscription"("created_by");
+CREATE INDEX IF NOT EXISTS t_data_download_subscription_idx_status_next_run_at ON "t_data_download_subscription"("status", "next_run_at");
+
+
+
+CREATE TABLE IF NOT EXISTS "t_data_download_subscription_run" (
+    "id" BIGINT NOT NULL,
+    "subscription_id" BIGINT NOT NULL,
+    "status" int NOT NULL,
+    "watermark_from" datetime(3) DEFAULT NULL,
+    "watermark_to" datetime(3) DEFAULT NULL,
+    "row_count" BIGINT NOT NULL DEFAULT 0,
+    "file_name" VARCHAR(512 char) NOT NULL DEFAULT '',
+    "remark" text DEFAULT NULL,
+    "started_at" datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3),
+    "finished_at" datetime(3) DEFAULT NULL,
+    CLUSTER PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS t_data_download_subscription_run_idx_subscription_id ON "t_data_download_subscription_run"("subscription_id", "started_at");
+
 
 
 CREATE TABLE IF NOT EXISTS "tmp_explore_sub_task" (
@@ -907,4 +957,64 @@ create table if not EXISTS "user"(
     "status"    tinyint default 1 not null  ,
     "user_type" tinyint default 1 not null ,
     CLUSTER primary key  ("id")
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS "form_view_schema_version" (
+    "id" BIGINT NOT NULL,
+    "form_view_id" VARCHAR(36 char) NOT NULL,
+    "version" INT NOT NULL,
+    "fields" TEXT NOT NULL,
+    "fingerprint" VARCHAR(64 char) NOT NULL,
+    "field_count" INT NOT NULL DEFAULT 0,
+    "added_count" INT NOT NULL DEFAULT 0,
+    "removed_count" INT NOT NULL DEFAULT 0,
+    "modified_count" INT NOT NULL DEFAULT 0,
+    "created_at" DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    CLUSTER PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "form_view_schema_version_uk_form_view_version" ON "form_view_schema_version"("form_view_id","version");
+
+CREATE TABLE IF NOT EXISTS "lineage_field" (
+    "id" BIGINT NOT NULL,
+    "form_view_id" VARCHAR(36 char) NOT NULL,
+    "field_id" VARCHAR(36 char) NOT NULL DEFAULT '',
+    "field_name" VARCHAR(255 char) NOT NULL,
+    "source_table" VARCHAR(512 char) NOT NULL,
+    "source_column" VARCHAR(255 char) NOT NULL,
+    "source_view_id" VARCHAR(36 char) NOT NULL DEFAULT '',
+    "source_field_id" VARCHAR(36 char) NOT NULL DEFAULT '',
+    "expression" TEXT DEFAULT NULL,
+    "source_namespace" VARCHAR(255 char) NOT NULL DEFAULT '',
+    "output_namespace" VARCHAR(255 char) NOT NULL DEFAULT '',
+    "output_name" VARCHAR(512 char) NOT NULL DEFAULT '',
+    "created_at" DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    CLUSTER PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS lineage_field_form_view_id_btr ON "lineage_field"("form_view_id");
+CREATE INDEX IF NOT EXISTS lineage_field_source_field_id_btr ON "lineage_field"("source_field_id");
+
+CREATE TABLE IF NOT EXISTS "outbox_message" (
+    "id" BIGINT NOT NULL,
+    "topic" VARCHAR(255 char) NOT NULL,
+    "msg_key" VARCHAR(255 char) NOT NULL,
+    "payload" text NOT NULL,
+    "status" TINYINT NOT NULL DEFAULT 0,
+    "attempts" int NOT NULL DEFAULT 0,
+    "next_retry_at" datetime(3) NOT NULL,
+    "last_error" VARCHAR(1024 char) DEFAULT NULL,
+    "created_at" datetime(3) NOT NULL,
+    "sent_at" datetime(3) DEFAULT NULL,
+    CLUSTER PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS outbox_message_idx_status_next_retry_at ON "outbox_message"("status", "next_retry_at");
+CREATE INDEX IF NOT EXISTS outbox_message_idx_created_at ON "outbox_message"("created_at");
+
+CREATE TABLE IF NOT EXISTS "outbox_relay_lease" (
+    "name" VARCHAR(255 char) NOT NULL,
+    "holder" VARCHAR(255 char) NOT NULL,
+    "expires_at" datetime(3) NOT NULL,
+    CLUSTER PRIMARY KEY ("name")
+    );
@@ -0,0 +1,16 @@
+USE af_main;
+
+CREATE TABLE IF NOT EXISTS `form_view_schema_version` (
+    `id` BIGINT(20) NOT NULL COMMENT '雪花id',
+    `form_view_id` CHAR(36) NOT NULL COMMENT '逻辑视图id',
+    `version` INT(11) NOT NULL COMMENT '结构版本号，从1开始递增',
+    `fields` LONGTEXT NOT NULL COMMENT '字段快照，json数组',
+    `fingerprint` CHAR(64) NOT NULL COMMENT '字段快照摘要，用于判断结构是否变化',
+    `field_count` INT(11) NOT NULL DEFAULT 0 COMMENT '字段数量',
+    `added_count` INT(11) NOT NULL DEFAULT 0 COMMENT '相对上一版本新增字段数',
+    `removed_count` INT(11) NOT NULL DEFAULT 0 COMMENT '相对上一版本删除字段数',
+    `modified_count` INT(11) NOT NULL DEFAULT 0 COMMENT '相对上一版本变更字段数',
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '扫描时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_form_view_version` (`form_view_id`,`version`)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='逻辑视图结构版本表';
\ No newline at end of file
@@ -0,0 +1,17 @@
+USE af_main;
+
+CREATE TABLE IF NOT EXISTS `lineage_field` (
+    `id` BIGINT(20) NOT NULL COMMENT '雪花id',
+    `form_view_id` CHAR(36) NOT NULL COMMENT '视图id（自定义视图及逻辑实体视图）',
+    `field_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '视图字段id，sql 输出字段未匹配到视图字段时为空',
+    `field_name` VARCHAR(255) NOT NULL COMMENT 'sql 输出字段名',
+    `source_table` VARCHAR(512) NOT NULL COMMENT '来源表，catalog.schema.table',
+    `source_column` VARCHAR(255) NOT NULL COMMENT '来源字段名',
+    `source_view_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '来源视图id，未匹配到视图时为空',
+    `source_field_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '来源视图字段id，未匹配到字段时为空',
+    `expression` TEXT DEFAULT NULL COMMENT '加工表达式，直接引用来源字段时为空',
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '解析时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_lineage_field_form_view_id` (`form_view_id`),
+    KEY `idx_lineage_field_source_field_id` (`source_field_id`)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='视图字段级血缘表';
\ No newline at end of file
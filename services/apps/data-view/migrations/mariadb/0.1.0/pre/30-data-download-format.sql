@@ -0,0 +1,7 @@
+USE af_main;
+
+ALTER TABLE `t_data_download_task` ADD COLUMN IF NOT EXISTS `file_format` varchar(16) NOT NULL DEFAULT 'xlsx' COMMENT '文件格式 xlsx csv jsonl.gz parquet' AFTER `file_uuid`;
+ALTER TABLE `t_data_download_task` ADD COLUMN IF NOT EXISTS `delimiter` varchar(4) NOT NULL DEFAULT ',' COMMENT 'csv分隔符' AFTER `file_format`;
+ALTER TABLE `t_data_download_task` ADD COLUMN IF NOT EXISTS `encoding` varchar(16) NOT NULL DEFAULT 'utf-8' COMMENT 'csv字符编码 utf-8 gbk' AFTER `delimiter`;
+ALTER TABLE `t_data_download_task` ADD COLUMN IF NOT EXISTS `split_rows` int NOT NULL DEFAULT 1000000 COMMENT '单个文件的最大行数，超过后拆分为多个文件并打包为zip' AFTER `encoding`;
+ALTER TABLE `t_data_download_task` ADD COLUMN IF NOT EXISTS `file_ext` varchar(16) NOT NULL DEFAULT 'xlsx' COMMENT '导出文件的扩展名，拆分为多个文件时为zip' AFTER `split_rows`;
@@ -0,0 +1,42 @@
+USE af_main;
+
+CREATE TABLE IF NOT EXISTS `t_data_download_subscription` (
+  `id` bigint NOT NULL COMMENT '订阅ID，雪花ID',
+  `form_view_id` char(36) NOT NULL COMMENT '逻辑视图uuid',
+  `name` varchar(255) NOT NULL COMMENT '订阅名称',
+  `detail` text NOT NULL COMMENT '导出配置详情，聚合json字符串（包含需要下载的列、行过滤条件信息）',
+  `file_format` varchar(16) NOT NULL DEFAULT 'csv' COMMENT '文件格式 xlsx csv jsonl.gz parquet',
+  `delimiter` varchar(4) NOT NULL DEFAULT ',' COMMENT 'csv分隔符',
+  `encoding` varchar(16) NOT NULL DEFAULT 'utf-8' COMMENT 'csv字符编码 utf-8 gbk',
+  `split_rows` int NOT NULL DEFAULT 1000000 COMMENT '单个文件的最大行数，超过后拆分为多个文件并打包为zip',
+  `cron_expr` varchar(128) NOT NULL COMMENT '执行周期，标准五段式cron表达式',
+  `incremental` tinyint NOT NULL DEFAULT 0 COMMENT '是否按业务更新时间字段增量导出 0 否 1 是',
+  `timestamp_field_id` char(36) NOT NULL DEFAULT '' COMMENT '增量导出使用的业务更新时间字段id',
+  `watermark` datetime(3) DEFAULT NULL COMMENT '增量水位，上一次成功导出数据的截止时间',
+  `target_type` varchar(16) NOT NULL COMMENT '投递目标类型 sftp s3',
+  `target_config` text NOT NULL COMMENT '投递目标配置，json字符串',
+  `status` int NOT NULL DEFAULT 1 COMMENT '订阅状态 1 启用 2 停用',
+  `next_run_at` datetime(3) DEFAULT NULL COMMENT '下次执行时间',
+  `last_run_at` datetime(3) DEFAULT NULL COMMENT '上次执行时间',
+  `created_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+  `created_by` varchar(36) NOT NULL COMMENT '创建人id',
+  `updated_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '编辑时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_data_download_subscription_created_by` (`created_by`),
+  KEY `idx_data_download_subscription_status_next_run_at` (`status`, `next_run_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='定时数据下载订阅表';
+
+CREATE TABLE IF NOT EXISTS `t_data_download_subscription_run` (
+  `id` bigint NOT NULL COMMENT '执行记录ID，雪花ID',
+  `subscription_id` bigint NOT NULL COMMENT '订阅ID',
+  `status` int NOT NULL COMMENT '执行状态 2 执行中 3 已完成 4 执行失败',
+  `watermark_from` datetime(3) DEFAULT NULL COMMENT '增量导出的起始时间，全量导出时为空',
+  `watermark_to` datetime(3) DEFAULT NULL COMMENT '增量导出的截止时间，全量导出时为空',
+  `row_count` bigint NOT NULL DEFAULT 0 COMMENT '导出行数',
+  `file_name` varchar(512) NOT NULL DEFAULT '' COMMENT '投递的文件名称',
+  `remark` text DEFAULT NULL COMMENT '执行失败说明',
+  `started_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '开始时间',
+  `finished_at` datetime(3) DEFAULT NULL COMMENT '结束时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_data_download_subscription_run_subscription_id` (`subscription_id`, `started_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='定时数据下载执行记录表';
@@ -0,0 +1,24 @@
+USE af_main;
+
+CREATE TABLE IF NOT EXISTS `outbox_message` (
+  `id` bigint NOT NULL COMMENT '唯一id，雪花算法，同一 key 的消息按 id 顺序发送',
+  `topic` varchar(255) NOT NULL COMMENT '消息主题',
+  `msg_key` varchar(255) NOT NULL COMMENT '保序 key，通常是实体id',
+  `payload` longtext NOT NULL COMMENT '消息内容',
+  `status` tinyint NOT NULL DEFAULT 0 COMMENT '状态 0 待发送 1 已发送 2 放弃发送',
+  `attempts` int NOT NULL DEFAULT 0 COMMENT '发送次数',
+  `next_retry_at` datetime(3) NOT NULL COMMENT '下次发送时间',
+  `last_error` varchar(1024) DEFAULT NULL COMMENT '最近一次发送失败的原因',
+  `created_at` datetime(3) NOT NULL COMMENT '创建时间',
+  `sent_at` datetime(3) DEFAULT NULL COMMENT '发送时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_outbox_message_status_next_retry_at` (`status`, `next_retry_at`),
+  KEY `idx_outbox_message_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='事务发件箱，与业务数据在同一事务中写入的待发送消息';
+
+CREATE TABLE IF NOT EXISTS `outbox_relay_lease` (
+  `name` varchar(255) NOT NULL COMMENT '租约名称',
+  `holder` varchar(255) NOT NULL COMMENT '持有租约的实例',
+  `expires_at` datetime(3) NOT NULL COMMENT '租约过期时间',
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='发件箱发送任务租约，多副本部署时只有持有租约的实例发送消息';
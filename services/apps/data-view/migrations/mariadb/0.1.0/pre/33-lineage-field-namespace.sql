@@ -0,0 +1,6 @@
+USE af_main;
+
+-- 为视图字段级血缘表(lineage_field)添加 OpenLineage 导入的数据集命名空间和输出数据集名称字段
+ALTER TABLE `lineage_field` ADD COLUMN IF NOT EXISTS `source_namespace` VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'OpenLineage 导入的来源数据集命名空间，sql 解析的血缘为空' AFTER `expression`;
+ALTER TABLE `lineage_field` ADD COLUMN IF NOT EXISTS `output_namespace` VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'OpenLineage 导入的输出数据集命名空间，sql 解析的血缘为空' AFTER `source_namespace`;
+ALTER TABLE `lineage_field` ADD COLUMN IF NOT EXISTS `output_name` VARCHAR(512) NOT NULL DEFAULT '' COMMENT 'OpenLineage 导入的输出数据集名称，sql 解析的血缘为空' AFTER `output_namespace`;
@@ -145,6 +145,11 @@ CREATE TABLE IF NOT EXISTS  `t_data_download_task` (
   `status` int NOT NULL COMMENT '任务状态 1 排队中 2 执行中（数据准备中） 3 已完成（可下载） 4 执行失败（异常）',
   `remark` text  DEFAULT NULL COMMENT '执行失败说明',
   `file_uuid` varchar(36) DEFAULT NULL COMMENT '导出数据文件UUID',
+  `file_format` varchar(16) NOT NULL DEFAULT 'xlsx' COMMENT '文件格式 xlsx csv jsonl.gz parquet',
+  `delimiter` varchar(4) NOT NULL DEFAULT ',' COMMENT 'csv分隔符',
+  `encoding` varchar(16) NOT NULL DEFAULT 'utf-8' COMMENT 'csv字符编码 utf-8 gbk',
+  `split_rows` int NOT NULL DEFAULT 1000000 COMMENT '单个文件的最大行数，超过后拆分为多个文件并打包为zip',
+  `file_ext` varchar(16) NOT NULL DEFAULT 'xlsx' COMMENT '导出文件的扩展名，拆分为多个文件时为zip',
   `created_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT '创建时间',
   `created_by` varchar(36) NOT NULL COMMENT '创建人id',
   `updated_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP (3) COMMENT '编辑时间',
@@ -152,6 +157,47 @@ CREATE TABLE IF NOT EXISTS  `t_data_download_task` (
   KEY `idx_data_download_task_status_created_by` (`status`, `created_by`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='数据下载任务表';
 
+CREATE TABLE IF NOT EXISTS `t_data_download_subscription` (
+  `id` bigint NOT NULL COMMENT '订阅ID，雪花ID',
+  `form_view_id` char(36) NOT NULL COMMENT '逻辑视图uuid',
+  `name` varchar(255) NOT NULL COMMENT '订阅名称',
+  `detail` text NOT NULL COMMENT '导出配置详情，聚合json字符串（包含需要下载的列、行过滤条件信息）',
+  `file_format` varchar(16) NOT NULL DEFAULT 'csv' COMMENT '文件格式 xlsx csv jsonl.gz parquet',
+  `delimiter` varchar(4) NOT NULL DEFAULT ',' COMMENT 'csv分隔符',
+  `encoding` varchar(16) NOT NULL DEFAULT 'utf-8' COMMENT 'csv字符编码 utf-8 gbk',
+  `split_rows` int NOT NULL DEFAULT 1000000 COMMENT '单个文件的最大行数，超过后拆分为多个文件并打包为zip',
+  `cron_expr` varchar(128) NOT NULL COMMENT '执行周期，标准五段式cron表达式',
+  `incremental` tinyint NOT NULL DEFAULT 0 COMMENT '是否按业务更新时间字段增量导出 0 否 1 是',
+  `timestamp_field_id` char(36) NOT NULL DEFAULT '' COMMENT '增量导出使用的业务更新时间字段id',
+  `watermark` datetime(3) DEFAULT NULL COMMENT '增量水位，上一次成功导出数据的截止时间',
+  `target_type` varchar(16) NOT NULL COMMENT '投递目标类型 sftp s3',
+  `target_config` text NOT NULL COMMENT '投递目标配置，json字符串',
+  `status` int NOT NULL DEFAULT 1 COMMENT '订阅状态 1 启用 2 停用',
+  `next_run_at` datetime(3) DEFAULT NULL COMMENT '下次执行时间',
+  `last_run_at` datetime(3) DEFAULT NULL COMMENT '上次执行时间',
+  `created_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',
+  `created_by` varchar(36) NOT NULL COMMENT '创建人id',
+  `updated_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '编辑时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_data_download_subscription_created_by` (`created_by`),
+  KEY `idx_data_download_subscription_status_next_run_at` (`status`, `next_run_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='定时数据下载订阅表';
+
+CREATE TABLE IF NOT EXISTS `t_data_download_subscription_run` (
+  `id` bigint NOT NULL COMMENT '执行记录ID，雪花ID',
+  `subscription_id` bigint NOT NULL COMMENT '订阅ID',
+  `status` int NOT NULL COMMENT '执行状态 2 执行中 3 已完成 4 执行失败',
+  `watermark_from` datetime(3) DEFAULT NULL COMMENT '增量导出的起始时间，全量导出时为空',
+  `watermark_to` datetime(3) DEFAULT NULL COMMENT '增量导出的截止时间，全量导出时为空',
+  `row_count` bigint NOT NULL DEFAULT 0 COMMENT '导出行数',
+  `file_name` varchar(512) NOT NULL DEFAULT '' COMMENT '投递的文件名称',
+  `remark` text DEFAULT NULL COMMENT '执行失败说明',
+  `started_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '开始时间',
+  `finished_at` datetime(3) DEFAULT NULL COMMENT '结束时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_data_download_subscription_run_subscription_id` (`subscription_id`, `started_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='定时数据下载执行记录表';
+
 
 
 CREATE TABLE IF NOT EXISTS `tmp_explore_sub_task` (
@@ -709,4 +755,61 @@ create table if not EXISTS `user`(
     `status`    tinyint default 1 not null comment '用户状态,1正常,2删除',
     `user_type` tinyint default 1 not null comment '用户分类 (1 普通用户， 2 AF应用)',
     primary key  (`id`)
-    )ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户表';
\ No newline at end of file
+    )ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户表';
+
+CREATE TABLE IF NOT EXISTS `form_view_schema_version` (
+    `id` BIGINT(20) NOT NULL COMMENT '雪花id',
+    `form_view_id` CHAR(36) NOT NULL COMMENT '逻辑视图id',
+    `version` INT(11) NOT NULL COMMENT '结构版本号，从1开始递增',
+    `fields` LONGTEXT NOT NULL COMMENT '字段快照，json数组',
+    `fingerprint` CHAR(64) NOT NULL COMMENT '字段快照摘要，用于判断结构是否变化',
+    `field_count` INT(11) NOT NULL DEFAULT 0 COMMENT '字段数量',
+    `added_count` INT(11) NOT NULL DEFAULT 0 COMMENT '相对上一版本新增字段数',
+    `removed_count` INT(11) NOT NULL DEFAULT 0 COMMENT '相对上一版本删除字段数',
+    `modified_count` INT(11) NOT NULL DEFAULT 0 COMMENT '相对上一版本变更字段数',
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '扫描时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_form_view_version` (`form_view_id`,`version`)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='逻辑视图结构版本表';
+
+CREATE TABLE IF NOT EXISTS `lineage_field` (
+    `id` BIGINT(20) NOT NULL COMMENT '雪花id',
+    `form_view_id` CHAR(36) NOT NULL COMMENT '视图id（自定义视图及逻辑实体视图）',
+    `field_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '视图字段id，sql 输出字段未匹配到视图字段时为空',
+    `field_name` VARCHAR(255) NOT NULL COMMENT 'sql 输出字段名',
+    `source_table` VARCHAR(512) NOT NULL COMMENT '来源表，catalog.schema.table',
+    `source_column` VARCHAR(255) NOT NULL COMMENT '来源字段名',
+    `source_view_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '来源视图id，未匹配到视图时为空',
+    `source_field_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '来源视图字段id，未匹配到字段时为空',
+    `expression` TEXT DEFAULT NULL COMMENT '加工表达式，直接引用来源字段时为空',
+    `source_namespace` VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'OpenLineage 导入的来源数据集命名空间，sql 解析的血缘为空',
+    `output_namespace` VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'OpenLineage 导入的输出数据集命名空间，sql 解析的血缘为空',
+    `output_name` VARCHAR(512) NOT NULL DEFAULT '' COMMENT 'OpenLineage 导入的输出数据集名称，sql 解析的血缘为空',
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '解析时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_lineage_field_form_view_id` (`form_view_id`),
+    KEY `idx_lineage_field_source_field_id` (`source_field_id`)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='视图字段级血缘表';
+
+CREATE TABLE IF NOT EXISTS `outbox_message` (
+  `id` bigint NOT NULL COMMENT '唯一id，雪花算法，同一 key 的消息按 id 顺序发送',
+  `topic` varchar(255) NOT NULL COMMENT '消息主题',
+  `msg_key` varchar(255) NOT NULL COMMENT '保序 key，通常是实体id',
+  `payload` longtext NOT NULL COMMENT '消息内容',
+  `status` tinyint NOT NULL DEFAULT 0 COMMENT '状态 0 待发送 1 已发送 2 放弃发送',
+  `attempts` int NOT NULL DEFAULT 0 COMMENT '发送次数',
+  `next_retry_at` datetime(3) NOT NULL COMMENT '下次发送时间',
+  `last_error` varchar(1024) DEFAULT NULL COMMENT '最近一次发送失败的原因',
+  `created_at` datetime(3) NOT NULL COMMENT '创建时间',
+  `sent_at` datetime(3) DEFAULT NULL COMMENT '发送时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_outbox_message_status_next_retry_at` (`status`, `next_retry_at`),
+  KEY `idx_outbox_message_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='事务发件箱，与业务数据在同一事务中写入的待发送消息';
+
+CREATE TABLE IF NOT EXISTS `outbox_relay_lease` (
+  `name` varchar(255) NOT NULL COMMENT '租约名称',
+  `holder` varchar(255) NOT NULL COMMENT '持有租约的实例',
+  `expires_at` datetime(3) NOT NULL COMMENT '租约过期时间',
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='发件箱发送任务租约，多副本部署时只有持有租约的实例发送消息';
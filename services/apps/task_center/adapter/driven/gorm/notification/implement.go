@@ -52,6 +52,38 @@ func (c *Client) CheckExistenceByWorkOrderIDAndWorkOrderAlarmIndex(ctx context.C
 	return
 }
 
+// 返回指定用户收到的指定探查报告的质量异常告警是否存在
+func (c *Client) CheckExistenceByRecipientIDAndQualityReportCode(ctx context.Context, recipientID uuid.UUID, code string) (result bool, err error) {
+	var count int64
+	if err = c.DB.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where(&model.NotificationSpec{
+			RecipientID:       recipientID,
+			QualityReportCode: code,
+		}).
+		Count(&count).Error; err != nil {
+		return
+	}
+	result = count > 0
+	return
+}
+
+// 返回指定用户收到的指定紧急访问的通知是否存在
+func (c *Client) CheckExistenceByRecipientIDAndBreakGlassGrantID(ctx context.Context, recipientID uuid.UUID, grantID string) (result bool, err error) {
+	var count int64
+	if err = c.DB.WithContext(ctx).
+		Model(&model.Notification{}).
+		Where(&model.NotificationSpec{
+			RecipientID:       recipientID,
+			BreakGlassGrantID: grantID,
+		}).
+		Count(&count).Error; err != nil {
+		return
+	}
+	result = count > 0
+	return
+}
+
 // 获取指定用户收到的通知列表
 func (c *Client) List(ctx context.Context, recipientID uuid.UUID, opts *ListOptions) (result []model.Notification, total int, err error) {
 	tx := c.DB.WithContext(ctx).
@@ -16,6 +16,10 @@ type Interface interface {
 	Get(ctx context.Context, recipientID, id uuid.UUID) (result *model.Notification, err error)
 	// 返回指定工单告警、索引对应的用户消息是否存在
 	CheckExistenceByWorkOrderIDAndWorkOrderAlarmIndex(ctx context.Context, id uuid.UUID, index int) (bool, error)
+	// 返回指定用户收到的指定探查报告的质量异常告警是否存在
+	CheckExistenceByRecipientIDAndQualityReportCode(ctx context.Context, recipientID uuid.UUID, code string) (bool, error)
+	// 返回指定用户收到的指定紧急访问的通知是否存在
+	CheckExistenceByRecipientIDAndBreakGlassGrantID(ctx context.Context, recipientID uuid.UUID, grantID string) (bool, error)
 	// 获取指定用户收到的通知列表
 	List(ctx context.Context, recipientID uuid.UUID, opts *ListOptions) (result []model.Notification, total int, err error)
 	// 标记指定用户收到的通知为已读
@@ -3,7 +3,9 @@ package driver
 import (
 	"github.com/google/wire"
 	db_sandbox "github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/db_sandbox/v1"
+	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/auth_service"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/data_catalog"
+	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/data_exploration"
 
 	data_aggregation_inventory "github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/data_aggregation_inventory/v1"
 	data_aggregation_plan "github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/data_aggregation_plan/v1"
@@ -59,6 +61,8 @@ var consumerServiceSet = wire.NewSet(
 	role.NewRoleHandler,
 	points.NewPointsEventHandler,
 	data_catalog.NewDataCatalogHandler,
+	data_exploration.NewDataExplorationHandler,
+	auth_service.NewAuthServiceHandler,
 )
 
 var ServiceProviderSet = wire.NewSet(
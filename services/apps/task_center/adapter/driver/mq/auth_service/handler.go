@@ -0,0 +1,37 @@
+package auth_service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kweaver-ai/dsg/services/apps/task_center/domain/notification"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/mq/kafkax"
+	"go.uber.org/zap"
+)
+
+type AuthServiceHandler struct {
+	notification notification.Interface
+}
+
+func NewAuthServiceHandler(notification notification.Interface) *AuthServiceHandler {
+	return &AuthServiceHandler{
+		notification: notification,
+	}
+}
+
+// HandlerBreakGlassMsg 处理紧急访问消息，通知数据 Owner 和安全团队
+func (m *AuthServiceHandler) HandlerBreakGlassMsg(ctx context.Context, message *kafkax.Message) error {
+	defer func() {
+		if err := recover(); err != nil {
+			log.WithContext(ctx).Error("[mq] HandlerBreakGlassMsg ", zap.Any("err", err))
+		}
+	}()
+
+	msg := new(notification.BreakGlassMessage)
+	if err := json.Unmarshal(message.Value, msg); err != nil {
+		log.WithContext(ctx).Error("consumer HandlerBreakGlassMsg Unmarshal error", zap.Error(err))
+		return err
+	}
+	return m.notification.CreateForBreakGlass(ctx, msg)
+}
@@ -0,0 +1,37 @@
+package data_exploration
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kweaver-ai/dsg/services/apps/task_center/domain/notification"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/transport/mq/kafkax"
+	"go.uber.org/zap"
+)
+
+type DataExplorationHandler struct {
+	notification notification.Interface
+}
+
+func NewDataExplorationHandler(notification notification.Interface) *DataExplorationHandler {
+	return &DataExplorationHandler{
+		notification: notification,
+	}
+}
+
+// HandlerQualityAnomalyMsg 处理数据质量异常告警消息
+func (m *DataExplorationHandler) HandlerQualityAnomalyMsg(ctx context.Context, message *kafkax.Message) error {
+	defer func() {
+		if err := recover(); err != nil {
+			log.WithContext(ctx).Error("[mq] HandlerQualityAnomalyMsg ", zap.Any("err", err))
+		}
+	}()
+
+	msg := new(notification.QualityAnomalyMessage)
+	if err := json.Unmarshal(message.Value, msg); err != nil {
+		log.WithContext(ctx).Error("consumer HandlerQualityAnomalyMsg Unmarshal error", zap.Error(err))
+		return err
+	}
+	return m.notification.CreateForQualityAnomaly(ctx, msg)
+}
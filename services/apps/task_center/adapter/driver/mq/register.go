@@ -1,7 +1,9 @@
 package mq
 
 import (
+	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/auth_service"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/data_catalog"
+	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/data_exploration"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/domain"
 	points "github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/points"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/role"
@@ -20,6 +22,8 @@ type MQConsumerService struct {
 	pointsHandler      *points.PointsEventHandler
 	dataCatalogHandler *data_catalog.DataCatalogHandler
 	workOrderAlarm     work_order_alarm.Interface
+	dataExploration    *data_exploration.DataExplorationHandler
+	authService        *auth_service.AuthServiceHandler
 }
 
 func NewMQConsumerService(
@@ -30,6 +34,8 @@ func NewMQConsumerService(
 	pointsHandler *points.PointsEventHandler,
 	dataCatalogHandler *data_catalog.DataCatalogHandler,
 	workOrderAlarm work_order_alarm.Interface,
+	dataExploration *data_exploration.DataExplorationHandler,
+	authService *auth_service.AuthServiceHandler,
 ) *MQConsumerService {
 	m := &MQConsumerService{
 		Consumer:           consumer,
@@ -39,6 +45,8 @@ func NewMQConsumerService(
 		pointsHandler:      pointsHandler,
 		dataCatalogHandler: dataCatalogHandler,
 		workOrderAlarm:     workOrderAlarm,
+		dataExploration:    dataExploration,
+		authService:        authService,
 	}
 	m.RegisterHandles()
 	return m
@@ -71,4 +79,8 @@ func (m *MQConsumerService) RegisterHandles() {
 	m.Consumer.RegisterHandles(kafkax.Wrap(m.pointsHandler.PointsEventPubHandler), constant.PointsEventTopic)
 	// 数据推送
 	m.Consumer.RegisterHandles(kafkax.Wrap(m.dataCatalogHandler.HandlerDataPushMsg), constant.DataPushTaskExecutingTopic)
+	// 数据质量异常告警
+	m.Consumer.RegisterHandles(kafkax.Wrap(m.dataExploration.HandlerQualityAnomalyMsg), constant.QualityAnomalyTopic)
+	// 紧急访问通知
+	m.Consumer.RegisterHandles(kafkax.Wrap(m.authService.HandlerBreakGlassMsg), constant.BreakGlassTopic)
 }
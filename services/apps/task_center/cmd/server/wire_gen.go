@@ -60,7 +60,9 @@ import (
 	v1_20 "github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/db_sandbox/v1"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/middleware"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq"
+	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/auth_service"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/data_catalog"
+	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/data_exploration"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/domain"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/points"
 	"github.com/kweaver-ai/dsg/services/apps/task_center/adapter/driver/mq/role"
@@ -298,10 +300,12 @@ func InitApp(server *conf.Server, dbDatabase *db.Database) (*AppRunner, func(),
 	businessDomainHandler := domain.NewBusinessDomainHandler(tc_projectUserCase, userCase)
 	pointsEventHandler := points.NewPointsEventHandler(pointsManagement)
 	dataCatalogHandler := data_catalog.NewDataCatalogHandler(useCase)
+	dataExplorationHandler := data_exploration.NewDataExplorationHandler(interface2)
+	authServiceHandler := auth_service.NewAuthServiceHandler(interface2)
 	alarm_ruleInterface := alarm_rule.New(data)
 	work_order_alarmInterface := work_order_alarm.New(data)
 	interface3 := work_order_alarm2.New(alarm_ruleInterface, work_order_alarmInterface)
-	mqConsumerService := mq.NewMQConsumerService(consumer, roleHandler, userMgmHandler, businessDomainHandler, pointsEventHandler, dataCatalogHandler, interface3)
+	mqConsumerService := mq.NewMQConsumerService(consumer, roleHandler, userMgmHandler, businessDomainHandler, pointsEventHandler, dataCatalogHandler, interface3, dataExplorationHandler, authServiceHandler)
 	v := driver.NewHttpServer(server, router, mqConsumerService)
 	user_singleInterface := user_single.New(data)
 	workOrderAlarmController := controller.NewWorkOrderAlarm(alarm_ruleInterface, notificationInterface, work_order_singleInterface, work_order_alarmInterface, user_singleInterface, callbackInterface)
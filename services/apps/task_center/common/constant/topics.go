@@ -41,4 +41,10 @@ const (
 	//数据推送
 
 	DataPushTaskExecutingTopic = "af.data-catalog.data-push-task-executing" //数据推送任务执行
+
+	//数据探查的消息
+	QualityAnomalyTopic = "af.data-exploration-service.quality_anomaly" //数据质量异常告警
+
+	//权限服务的消息
+	BreakGlassTopic = "af.auth-service.break_glass" //紧急访问
 )
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/task_center/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-common/util/ptr"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 根据权限服务的紧急访问消息创建用户通知，同一个用户对同一次紧急访问只创建一条通知
+func (c *Domain) CreateForBreakGlass(ctx context.Context, msg *BreakGlassMessage) error {
+	if msg.GrantID == "" {
+		return nil
+	}
+	message, err := newBreakGlassNotificationMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, id := range msg.RecipientIDs {
+		recipientID, err := uuid.Parse(id)
+		if err != nil {
+			log.Warn("invalid break glass recipient id", zap.String("id", id), zap.String("grantID", msg.GrantID))
+			continue
+		}
+
+		// 消息重复投递时不重复创建用户通知
+		ok, err := c.notification.CheckExistenceByRecipientIDAndBreakGlassGrantID(ctx, recipientID, msg.GrantID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			log.Debug("notification for break glass already exists", zap.Stringer("recipientID", recipientID), zap.String("grantID", msg.GrantID))
+			continue
+		}
+
+		n := &model.Notification{
+			Metadata: model.Metadata{
+				ID:        uuid.Must(uuid.NewV7()),
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			Spec: model.NotificationSpec{
+				RecipientID:       recipientID,
+				Reason:            model.NotificationReasonBreakGlassAccess,
+				Message:           message,
+				BreakGlassGrantID: msg.GrantID,
+			},
+			Status: model.NotificationStatus{
+				Read: ptr.To(false),
+			},
+		}
+		log.Info("create notification for break glass", zap.Any("notification", n))
+		if err := c.notification.Create(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var breakGlassTpl = template.Must(template.New("break-glass").Parse(`<b>{{ .SubjectName }}</b> 通过紧急访问获得了【<a><b>{{ .ObjectName }}</b></a>】的读取权限，将于 <c>{{ .ExpiredAt }}</c> 自动回收。理由：{{ .Justification }}`))
+
+// 用于填充紧急访问对应的用户消息的值
+type breakGlassNotificationMessageValue struct {
+	// 申请人名称
+	SubjectName string
+	// 视图名称
+	ObjectName string
+	// 过期时间
+	ExpiredAt string
+	// 紧急访问的理由
+	Justification string
+}
+
+// 渲染紧急访问对应的用户消息
+func newBreakGlassNotificationMessage(msg *BreakGlassMessage) (string, error) {
+	var buf bytes.Buffer
+	if err := breakGlassTpl.Execute(&buf, &breakGlassNotificationMessageValue{
+		SubjectName:   msg.SubjectName,
+		ObjectName:    msg.ObjectName,
+		ExpiredAt:     time.UnixMilli(msg.ExpiredAt).Format(time.DateTime),
+		Justification: msg.Justification,
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -19,4 +19,64 @@ type Interface interface {
 	Read(ctx context.Context, recipientID, id uuid.UUID) error
 	// 标记指定用户收到的所有通知为已读
 	ReadAll(ctx context.Context, recipientID uuid.UUID) error
+	// 根据数据探查的质量异常告警创建用户通知
+	CreateForQualityAnomaly(ctx context.Context, msg *QualityAnomalyMessage) error
+	// 根据权限服务的紧急访问消息创建用户通知
+	CreateForBreakGlass(ctx context.Context, msg *BreakGlassMessage) error
+}
+
+// 数据探查服务发送的质量异常告警消息
+type QualityAnomalyMessage struct {
+	// 探查报告编号
+	ReportCode string `json:"report_code"`
+	// 探查任务 ID
+	TaskID string `json:"task_id"`
+	// 视图 ID
+	TableID string `json:"table_id"`
+	// 表名称
+	Table string `json:"table"`
+	// 告警接收人 ID
+	RecipientIDs []string `json:"recipient_ids"`
+	// 检测时间，毫秒时间戳
+	DetectedAt int64 `json:"detected_at"`
+	// 异常指标
+	Anomalies []QualityAnomaly `json:"anomalies"`
+}
+
+// 质量异常指标
+type QualityAnomaly struct {
+	// 指标，例如：completeness_score、row_count、null_ratio
+	Metric string `json:"metric"`
+	// 异常类型：minimum 低于下限，threshold 变化超过阈值，drift 统计漂移
+	Kind string `json:"kind"`
+	// 字段名称，字段级指标时有值
+	FieldName string `json:"field_name,omitempty"`
+	// 本次探查的值
+	Value float64 `json:"value"`
+	// 比较基线，统计漂移时为历史均值，其他为上次探查的值
+	Baseline float64 `json:"baseline"`
+	// 触发告警的阈值
+	Threshold float64 `json:"threshold"`
+}
+
+// 权限服务发送的紧急访问消息
+type BreakGlassMessage struct {
+	// 紧急访问 ID
+	GrantID string `json:"grant_id"`
+	// 视图 ID
+	ObjectID string `json:"object_id"`
+	// 视图名称
+	ObjectName string `json:"object_name"`
+	// 申请人 ID
+	SubjectID string `json:"subject_id"`
+	// 申请人名称
+	SubjectName string `json:"subject_name"`
+	// 紧急访问的理由
+	Justification string `json:"justification"`
+	// 过期时间，毫秒时间戳
+	ExpiredAt int64 `json:"expired_at"`
+	// 通知接收人 ID
+	RecipientIDs []string `json:"recipient_ids"`
+	// 申请时间，毫秒时间戳
+	CreatedAt int64 `json:"created_at"`
 }
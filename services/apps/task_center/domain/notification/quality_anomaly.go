@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/kweaver-ai/dsg/services/apps/task_center/infrastructure/repository/db/model"
+	"github.com/kweaver-ai/idrm-go-common/util/ptr"
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+)
+
+// 根据数据探查的质量异常告警创建用户通知，同一个用户对同一份探查报告只创建一条通知
+func (c *Domain) CreateForQualityAnomaly(ctx context.Context, msg *QualityAnomalyMessage) error {
+	if msg.ReportCode == "" || len(msg.Anomalies) == 0 {
+		return nil
+	}
+	message, err := newQualityAnomalyNotificationMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, id := range msg.RecipientIDs {
+		recipientID, err := uuid.Parse(id)
+		if err != nil {
+			log.Warn("invalid quality anomaly recipient id", zap.String("id", id), zap.String("reportCode", msg.ReportCode))
+			continue
+		}
+
+		// 消息重复投递时不重复创建用户通知
+		ok, err := c.notification.CheckExistenceByRecipientIDAndQualityReportCode(ctx, recipientID, msg.ReportCode)
+		if err != nil {
+			return err
+		}
+		if ok {
+			log.Debug("notification for quality anomaly already exists", zap.Stringer("recipientID", recipientID), zap.String("reportCode", msg.ReportCode))
+			continue
+		}
+
+		n := &model.Notification{
+			Metadata: model.Metadata{
+				ID:        uuid.Must(uuid.NewV7()),
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			Spec: model.NotificationSpec{
+				RecipientID:       recipientID,
+				Reason:            model.NotificationReasonDataQualityAnomaly,
+				Message:           message,
+				QualityReportCode: msg.ReportCode,
+			},
+			Status: model.NotificationStatus{
+				Read: ptr.To(false),
+			},
+		}
+		log.Info("create notification for quality anomaly", zap.Any("notification", n))
+		if err := c.notification.Create(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 通知中最多列出的异常指标数量
+const maxQualityAnomaliesInMessage = 5
+
+var qualityAnomalyTpl = template.Must(template.New("quality-anomaly").Parse(`【<a><b>{{ .Table }}</b>({{ .ReportCode }})</a>】数据质量异常：<c>{{ .Summary }}</c>，请及时处理！`))
+
+// 用于填充质量异常告警对应的用户消息的值
+type qualityAnomalyNotificationMessageValue struct {
+	// 表名称
+	Table string
+	// 探查报告编号
+	ReportCode string
+	// 异常指标摘要
+	Summary string
+}
+
+// 渲染质量异常告警对应的用户消息
+func newQualityAnomalyNotificationMessage(msg *QualityAnomalyMessage) (string, error) {
+	descriptions := make([]string, 0, maxQualityAnomaliesInMessage)
+	for i := range msg.Anomalies {
+		if i == maxQualityAnomaliesInMessage {
+			break
+		}
+		descriptions = append(descriptions, describeQualityAnomaly(&msg.Anomalies[i]))
+	}
+	summary := strings.Join(descriptions, "；")
+	if len(msg.Anomalies) > maxQualityAnomaliesInMessage {
+		summary = fmt.Sprintf("%s 等 %d 项指标异常", summary, len(msg.Anomalies))
+	}
+
+	var buf bytes.Buffer
+	if err := qualityAnomalyTpl.Execute(&buf, &qualityAnomalyNotificationMessageValue{
+		Table:      msg.Table,
+		ReportCode: msg.ReportCode,
+		Summary:    summary,
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// 质量异常指标的名称
+var qualityMetricNames = map[string]string{
+	"completeness_score":    "完整性评分",
+	"uniqueness_score":      "唯一性评分",
+	"standardization_score": "规范性评分",
+	"accuracy_score":        "准确性评分",
+	"consistency_score":     "一致性评分",
+	"row_count":             "总行数",
+	"null_ratio":            "空值率",
+	"distinct_count":        "去重数量",
+}
+
+// 描述一个异常指标，例如：完整性评分由 99.00% 变为 60.00%
+func describeQualityAnomaly(a *QualityAnomaly) string {
+	name := qualityMetricNames[a.Metric]
+	if name == "" {
+		name = a.Metric
+	}
+	if a.FieldName != "" {
+		name = fmt.Sprintf("字段 %s %s", a.FieldName, name)
+	}
+	format := func(v float64) string {
+		if a.Metric == "row_count" || a.Metric == "distinct_count" {
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.2f%%", v*100)
+	}
+	switch a.Kind {
+	case "minimum":
+		return fmt.Sprintf("%s %s 低于下限 %s", name, format(a.Value), format(a.Threshold))
+	case "drift":
+		return fmt.Sprintf("%s %s 偏离历史均值 %s", name, format(a.Value), format(a.Baseline))
+	default:
+		return fmt.Sprintf("%s由 %s 变为 %s", name, format(a.Baseline), format(a.Value))
+	}
+}
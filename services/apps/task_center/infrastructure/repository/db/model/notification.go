@@ -31,6 +31,10 @@ type NotificationSpec struct {
 	// 同一个工单所发出的通知的索引。用于避免重复发送。0 代表临期告警，1 代表剩
 	// 余 1 天的提前告警，n 代表剩余 n 天的提前告警
 	WorkOrderAlarmIndex *int `json:"work_order_alarm_index,omitempty"`
+	// 探查报告编号，通知的理由是数据质量异常告警时有值
+	QualityReportCode string `json:"quality_report_code,omitempty"`
+	// 紧急访问 ID，通知的理由是紧急访问时有值
+	BreakGlassGrantID string `json:"break_glass_grant_id,omitempty"`
 }
 
 // 用户收到消息通知的理由，例如：数据质量工单告警
@@ -39,6 +43,10 @@ type Reason string
 const (
 	// 数据质量工单告警
 	NotificationReasonDataQualityWorkOrderAlarm Reason = "DataQualityWorkOrderAlarm"
+	// 数据质量异常告警
+	NotificationReasonDataQualityAnomaly Reason = "DataQualityAnomaly"
+	// 紧急访问
+	NotificationReasonBreakGlassAccess Reason = "BreakGlassAccess"
 )
 
 // NotificationStatus 代表消息通知的状态
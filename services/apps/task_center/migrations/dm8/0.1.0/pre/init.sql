@@ -882,10 +882,14 @@ CREATE TABLE  IF NOT EXISTS  "notifications" (
     "message"                   TEXT        NOT NULL  ,
     "work_order_id"             VARCHAR(36 char)    NOT NULL  ,
     "work_order_alarm_index"    TINYINT     NULL      ,
+    "quality_report_code"       VARCHAR(64 char) NOT NULL DEFAULT '',
+    "break_glass_grant_id"      VARCHAR(36 char) NOT NULL DEFAULT '',
     "read"                      TINYINT  NOT NULL   ,
     CLUSTER PRIMARY KEY ("id")
     ) ;
 CREATE INDEX IF NOT EXISTS notifications_idx_work_order ON notifications("work_order_id", "work_order_alarm_index");
+CREATE INDEX IF NOT EXISTS notifications_idx_quality_report_code ON notifications("quality_report_code");
+CREATE INDEX IF NOT EXISTS notifications_idx_break_glass_grant_id ON notifications("break_glass_grant_id");
 
 CREATE TABLE IF NOT EXISTS "work_order_alarms" (
     "id"                    VARCHAR(36 char)    NOT NULL,
@@ -0,0 +1,5 @@
+USE `af_tasks`;
+
+-- 用户通知关联数据质量异常告警的探查报告
+ALTER TABLE `notifications` ADD COLUMN IF NOT EXISTS `quality_report_code` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '通知关联的探查报告编号' AFTER `work_order_alarm_index`;
+CREATE INDEX IF NOT EXISTS `idx_quality_report_code` ON `notifications` (`quality_report_code`);
@@ -0,0 +1,5 @@
+USE `af_tasks`;
+
+-- 用户通知关联紧急访问
+ALTER TABLE `notifications` ADD COLUMN IF NOT EXISTS `break_glass_grant_id` CHAR(36) NOT NULL DEFAULT '' COMMENT '通知关联的紧急访问的 ID' AFTER `quality_report_code`;
+CREATE INDEX IF NOT EXISTS `idx_break_glass_grant_id` ON `notifications` (`break_glass_grant_id`);
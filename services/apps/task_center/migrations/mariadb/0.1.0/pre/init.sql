@@ -879,9 +879,13 @@ CREATE TABLE  IF NOT EXISTS  `notifications` (
     `work_order_id`             CHAR(36)    NOT NULL    COMMENT '通知关联的工单的 ID',
     -- 用于避免重复发送。0 代表临期告警，1 代表剩余 1 天的提前告警，n 代表剩余 n 天的提前告警
     `work_order_alarm_index`    TINYINT     NULL        COMMENT '同一个工单所发出的通知的索引',
+    `quality_report_code`       VARCHAR(64) NOT NULL    DEFAULT ''  COMMENT '通知关联的探查报告编号',
+    `break_glass_grant_id`      CHAR(36)    NOT NULL    DEFAULT ''  COMMENT '通知关联的紧急访问的 ID',
     `read`                      TINYINT(4)  NOT NULL    COMMENT '是否已读',
     PRIMARY KEY (`id`) USING BTREE,
-    UNIQUE INDEX `idx_work_order` (`work_order_id`, `work_order_alarm_index`) USING BTREE
+    UNIQUE INDEX `idx_work_order` (`work_order_id`, `work_order_alarm_index`) USING BTREE,
+    INDEX `idx_quality_report_code` (`quality_report_code`) USING BTREE,
+    INDEX `idx_break_glass_grant_id` (`break_glass_grant_id`) USING BTREE
 ) ENGINE=InnoDB DEFAULT CHARSET='utf8mb4' COLLATE='utf8mb4_unicode_ci' COMMENT='用户通知';
 
 -- 工单告警
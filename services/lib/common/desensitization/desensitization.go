@@ -0,0 +1,37 @@
+// Package desensitization 按数据视图的脱敏规则在内存中脱敏字段值。
+//
+// 数据视图下载数据时在 SQL 中脱敏，探查报告、接口返回结果等已经取出的值使用这里的实现，两者的结果一致。
+package desensitization
+
+import "strings"
+
+// 脱敏方法，对应数据视图脱敏规则的 method
+const (
+	// MethodAll 全部脱敏
+	MethodAll = "all"
+	// MethodMiddle 中间脱敏
+	MethodMiddle = "middle"
+	// MethodHeadTail 首尾脱敏
+	MethodHeadTail = "head-tail"
+)
+
+// Desensitize 按字符脱敏：all 全部替换为 *；middle 居中替换 middleBit 个字符；
+// head-tail 替换开头 headBit 个和结尾 tailBit 个字符。字符数不足或脱敏方法未知时全部替换
+func Desensitize(s, method string, middleBit, headBit, tailBit int) string {
+	runes := []rune(s)
+	n := len(runes)
+	switch method {
+	case MethodMiddle:
+		if n < middleBit {
+			break
+		}
+		head := (n - middleBit) / 2
+		return string(runes[:head]) + strings.Repeat("*", middleBit) + string(runes[head+middleBit:])
+	case MethodHeadTail:
+		if n < headBit+tailBit {
+			break
+		}
+		return strings.Repeat("*", headBit) + string(runes[headBit:n-tailBit]) + strings.Repeat("*", tailBit)
+	}
+	return strings.Repeat("*", n)
+}
@@ -0,0 +1,19 @@
+package desensitization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesensitize(t *testing.T) {
+	assert.Equal(t, "******", Desensitize("abcdef", MethodAll, 0, 0, 0))
+	assert.Equal(t, "13***678", Desensitize("13345678", MethodMiddle, 3, 0, 0))
+	assert.Equal(t, "a**de", Desensitize("abcde", MethodMiddle, 2, 0, 0))
+	assert.Equal(t, "***", Desensitize("abc", MethodMiddle, 4, 0, 0))
+	assert.Equal(t, "***", Desensitize("abc", MethodMiddle, 3, 0, 0))
+	assert.Equal(t, "*三*", Desensitize("张三丰", MethodHeadTail, 0, 1, 1))
+	assert.Equal(t, "**", Desensitize("ab", MethodHeadTail, 0, 2, 1))
+	assert.Equal(t, "***", Desensitize("abc", "unknown", 0, 0, 0))
+	assert.Equal(t, "", Desensitize("", MethodMiddle, 2, 0, 0))
+}
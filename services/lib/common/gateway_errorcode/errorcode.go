@@ -0,0 +1,183 @@
+// Package gateway_errorcode 数据服务网关返回给接口调用方的错误码，以及返回每个错误码时的 HTTP 状态码。
+//
+// 网关的错误码引用这里的定义，接口服务导出接口文档时按这里的定义生成错误响应，避免两边不一致。
+// 新增网关错误码时需要同时加入 codes。
+package gateway_errorcode
+
+import (
+	"net/http"
+	"slices"
+)
+
+const ServiceName = "DataApplicationGateway"
+
+// Public error
+const (
+	publicPreCoder              = ServiceName + ".Public."
+	PublicInternalError         = publicPreCoder + "InternalError"
+	PublicInvalidParameter      = publicPreCoder + "InvalidParameter"
+	PublicInvalidParameterJson  = publicPreCoder + "InvalidParameterJson"
+	PublicDatabaseError         = publicPreCoder + "DatabaseError"
+	PublicRequestParameterError = publicPreCoder + "RequestParameterError"
+)
+
+// PublicAuthenticationFailure 令牌校验失败，由网关使用的令牌校验中间件返回
+const PublicAuthenticationFailure = "Public.AuthenticationFailure"
+
+// Auth error
+const (
+	authPreCoder              = ServiceName + ".Auth."
+	TokenAuditFailed          = authPreCoder + "TokenAuditFailed"
+	UserNotActive             = authPreCoder + "UserNotActive"
+	GetUserInfoFailed         = authPreCoder + "GetUserInfoFailed"
+	GetUserInfoFailedInterior = authPreCoder + "GetUserInfoFailedInterior"
+	GetTokenEmpty             = authPreCoder + "GetTokenEmpty"
+)
+
+// Sign error
+const (
+	signPreCoder      = ServiceName + ".Sign."
+	SignValidateError = signPreCoder + "SignValidateError"
+	TimestampRequired = signPreCoder + "TimestampRequired"
+	TimestampError    = signPreCoder + "TimestampError"
+	TimestampExpired  = signPreCoder + "TimestampExpired"
+	AppIdRequired     = signPreCoder + "AppIdRequired"
+	AppIdNotExist     = signPreCoder + "AppIdNotExist"
+	NonceReplayed     = signPreCoder + "NonceReplayed"
+)
+
+// Query error
+const (
+	queryPreCoder = ServiceName + ".Query."
+
+	QueryError     = queryPreCoder + "QueryError"
+	RateLimitError = queryPreCoder + "RateLimitError"
+	// 超出每日调用配额
+	QuotaExceededError = queryPreCoder + "QuotaExceededError"
+	// 超出最大并发查询数
+	ConcurrencyLimitError = queryPreCoder + "ConcurrencyLimitError"
+	// 分页游标无效
+	InvalidCursor = queryPreCoder + "InvalidCursor"
+	// 接口服务的后端返回不支持的 content-type
+	BackendUnsupportedContentType = queryPreCoder + "UnsupportedContentType"
+	// 后端服务连接失败、超时或返回服务端错误
+	BackendUnavailable = queryPreCoder + "BackendUnavailable"
+	// 后端服务熔断中
+	CircuitOpenError = queryPreCoder + "CircuitOpenError"
+	// 后端服务返回结果转换失败
+	ResponseTransformError = queryPreCoder + "ResponseTransformError"
+	// 属性策略计算失败
+	ObligationUnavailable = queryPreCoder + "ObligationUnavailable"
+	// 属性策略无法在接口上执行
+	ObligationNotSupported = queryPreCoder + "ObligationNotSupported"
+)
+
+// ServiceApply error
+const (
+	serviceApplyPreCoder = ServiceName + ".ServiceApply."
+
+	ServiceApplyNotPass       = serviceApplyPreCoder + "ServiceApplyNotPass"
+	ServiceApplyNotPassCssjj  = serviceApplyPreCoder + "ServiceApplyNotPassCssjj"
+	ServiceStatusNotAvailable = serviceApplyPreCoder + "ServiceStatusNotAvailable"
+)
+
+// Service error
+const (
+	servicePreCoder = ServiceName + ".Service."
+
+	ServiceNameExist         = servicePreCoder + "ServiceNameExist"
+	ServicePathExist         = servicePreCoder + "ServicePathExist"
+	ServicePathNotExist      = servicePreCoder + "ServicePathNotExist"
+	ServiceIDNotExist        = servicePreCoder + "ServiceIDNotExist"
+	ServiceSQLSyntaxError    = servicePreCoder + "ServiceSQLSyntaxError"
+	ServiceSQLSchemaError    = servicePreCoder + "ServiceSQLSchemaError"
+	ServiceSQLTableError     = servicePreCoder + "ServiceSQLTableError"
+	ServiceQueryPublishError = servicePreCoder + "ServiceQueryOnlineError"
+	DataViewIdNotExist       = servicePreCoder + "DataViewIdNotExist"
+	DataViewIdNotPublish     = servicePreCoder + "DataViewIdNotPublish"
+	DatasourceIdNotExist     = servicePreCoder + "DatasourceIdNotExist"
+	ServiceVersionNotExist   = servicePreCoder + "ServiceVersionNotExist"
+)
+
+// codes 网关返回的全部错误码
+var codes = []string{
+	PublicInternalError,
+	PublicInvalidParameter,
+	PublicInvalidParameterJson,
+	PublicDatabaseError,
+	PublicRequestParameterError,
+	PublicAuthenticationFailure,
+	TokenAuditFailed,
+	UserNotActive,
+	GetUserInfoFailed,
+	GetUserInfoFailedInterior,
+	GetTokenEmpty,
+	SignValidateError,
+	TimestampRequired,
+	TimestampError,
+	TimestampExpired,
+	AppIdRequired,
+	AppIdNotExist,
+	NonceReplayed,
+	QueryError,
+	RateLimitError,
+	QuotaExceededError,
+	ConcurrencyLimitError,
+	InvalidCursor,
+	BackendUnsupportedContentType,
+	BackendUnavailable,
+	CircuitOpenError,
+	ResponseTransformError,
+	ObligationUnavailable,
+	ObligationNotSupported,
+	ServiceApplyNotPass,
+	ServiceApplyNotPassCssjj,
+	ServiceStatusNotAvailable,
+	ServiceNameExist,
+	ServicePathExist,
+	ServicePathNotExist,
+	ServiceIDNotExist,
+	ServiceSQLSyntaxError,
+	ServiceSQLSchemaError,
+	ServiceSQLTableError,
+	ServiceQueryPublishError,
+	DataViewIdNotExist,
+	DataViewIdNotPublish,
+	DatasourceIdNotExist,
+	ServiceVersionNotExist,
+}
+
+// statuses 错误码对应的 HTTP 状态码，未列出的错误码返回 400
+var statuses = map[string]int{
+	PublicAuthenticationFailure: http.StatusUnauthorized,
+	RateLimitError:              http.StatusTooManyRequests,
+	QuotaExceededError:          http.StatusTooManyRequests,
+	ConcurrencyLimitError:       http.StatusTooManyRequests,
+	CircuitOpenError:            http.StatusServiceUnavailable,
+}
+
+// Codes 网关返回的全部错误码
+func Codes() []string {
+	return slices.Clone(codes)
+}
+
+// Status 网关返回错误码时的 HTTP 状态码
+func Status(code string) int {
+	if status, ok := statuses[code]; ok {
+		return status
+	}
+	return http.StatusBadRequest
+}
+
+// CodesByStatus 按 HTTP 状态码分组的错误码，组内按错误码排序
+func CodesByStatus() map[int][]string {
+	result := make(map[int][]string)
+	for _, code := range codes {
+		status := Status(code)
+		result[status] = append(result[status], code)
+	}
+	for _, group := range result {
+		slices.Sort(group)
+	}
+	return result
+}
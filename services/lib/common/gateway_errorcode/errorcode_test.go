@@ -0,0 +1,37 @@
+package gateway_errorcode
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodes(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, code := range Codes() {
+		assert.False(t, seen[code], "错误码重复: %s", code)
+		seen[code] = true
+	}
+	for code := range statuses {
+		assert.True(t, seen[code], "设置了状态码的错误码不在 codes 中: %s", code)
+	}
+}
+
+func TestCodesByStatus(t *testing.T) {
+	groups := CodesByStatus()
+
+	assert.Equal(t, []string{ConcurrencyLimitError, QuotaExceededError, RateLimitError}, groups[http.StatusTooManyRequests])
+	assert.Equal(t, []string{CircuitOpenError}, groups[http.StatusServiceUnavailable])
+	assert.Equal(t, []string{PublicAuthenticationFailure}, groups[http.StatusUnauthorized])
+	assert.Contains(t, groups[http.StatusBadRequest], NonceReplayed)
+	assert.Contains(t, groups[http.StatusBadRequest], SignValidateError)
+	assert.Contains(t, groups[http.StatusBadRequest], ObligationNotSupported)
+	assert.IsIncreasing(t, groups[http.StatusBadRequest])
+
+	total := 0
+	for _, group := range groups {
+		total += len(group)
+	}
+	assert.Equal(t, len(Codes()), total)
+}
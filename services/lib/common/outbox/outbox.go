@@ -0,0 +1,294 @@
+// Package outbox 事务发件箱。业务数据和待发送的消息在同一个事务中写入数据库，事务提交后由 Relay 发送到消息队列，
+// 避免提交后发送失败丢失消息，或者回滚后仍然发出消息。
+//
+// 使用方式：注册 RegisterTxCallbacks，Transport.Send 中通过 TxFromContext 取得当前事务后调用 Enqueue，再以定时任务的方式运行 Relay。
+// 没有定时任务选主的服务可以使用 RelayServer，多副本之间通过数据库租约保证只有一个实例运行 Relay。
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/utils"
+)
+
+const TableNameMessage = "outbox_message"
+
+// 消息状态
+const (
+	StatusPending = 0 // 待发送
+	StatusSent    = 1 // 已发送
+	StatusDead    = 2 // 超过最大重试次数，不再自动重试，可以通过 Replay 重新发送
+)
+
+const (
+	// relayInterval 查询待发送消息的间隔
+	relayInterval = time.Second
+	// relayBatchSize 每次查询的待发送消息数量
+	relayBatchSize = 200
+	// maxAttempts 最大发送次数
+	maxAttempts = 20
+	// maxRetryDelay 重试间隔上限，重试间隔从 1 秒开始逐次翻倍
+	maxRetryDelay = 10 * time.Minute
+	// maxErrorLength 保存的失败原因的最大长度
+	maxErrorLength = 1024
+	// sentRetention 已发送消息的保留时间，保留期内的消息可以重放
+	sentRetention = 7 * 24 * time.Hour
+	// cleanupInterval 清理已发送消息的间隔
+	cleanupInterval = time.Hour
+)
+
+// Message 发件箱中的消息
+type Message struct {
+	ID          int64      `gorm:"column:id;type:bigint(20);primaryKey;comment:唯一id，雪花算法，同一 key 的消息按 id 顺序发送" json:"id"`         // 唯一id，雪花算法，同一 key 的消息按 id 顺序发送
+	Topic       string     `gorm:"column:topic;type:varchar(255);not null;comment:消息主题" json:"topic"`                            // 消息主题
+	Key         string     `gorm:"column:msg_key;type:varchar(255);not null;comment:保序 key，通常是实体id" json:"key"`                  // 保序 key，通常是实体id
+	Payload     string     `gorm:"column:payload;type:longtext;not null;comment:消息内容" json:"payload"`                            // 消息内容
+	Status      int        `gorm:"column:status;type:tinyint(4);not null;default:0;comment:状态 0 待发送 1 已发送 2 放弃发送" json:"status"` // 状态 0 待发送 1 已发送 2 放弃发送
+	Attempts    int        `gorm:"column:attempts;type:int(11);not null;default:0;comment:发送次数" json:"attempts"`                 // 发送次数
+	NextRetryAt time.Time  `gorm:"column:next_retry_at;type:datetime(3);not null;comment:下次发送时间" json:"next_retry_at"`           // 下次发送时间
+	LastError   string     `gorm:"column:last_error;type:varchar(1024);comment:最近一次发送失败的原因" json:"last_error"`                   // 最近一次发送失败的原因
+	CreatedAt   time.Time  `gorm:"column:created_at;type:datetime(3);not null;comment:创建时间" json:"created_at"`                   // 创建时间
+	SentAt      *time.Time `gorm:"column:sent_at;type:datetime(3);comment:发送时间" json:"sent_at"`                                  // 发送时间
+}
+
+// TableName Message's table name
+func (*Message) TableName() string {
+	return TableNameMessage
+}
+
+// Publisher 发送一条消息，返回错误时按退避时间重试
+type Publisher func(ctx context.Context, msg *Message) error
+
+// Publishers 各消息主题的发送方式
+type Publishers map[string]Publisher
+
+// Outbox 事务发件箱
+type Outbox interface {
+	// Enqueue 在 tx 所在的事务中写入消息，payload 为 []byte 时原样保存，否则序列化为 JSON。
+	// tx 为 nil 时直接写入，tx 所在的事务已结束时返回错误
+	Enqueue(ctx context.Context, tx *gorm.DB, topic, key string, payload any) error
+	// Relay 发送待发送的消息直到 ctx 结束。同一 key 的消息按写入顺序发送，前一条发送失败时后面的消息等待重试。
+	// 多副本部署时只能有一个实例运行
+	Relay(ctx context.Context)
+	// Replay 把创建时间在 [start, end) 内已发送或放弃发送的消息重新置为待发送，topic 为空时不限主题，返回消息数量
+	Replay(ctx context.Context, topic string, start, end time.Time) (int64, error)
+}
+
+func NewOutbox(db *gorm.DB, publishers Publishers) Outbox {
+	return &outbox{db: db, publishers: publishers}
+}
+
+type outbox struct {
+	db         *gorm.DB
+	publishers Publishers
+}
+
+func (o *outbox) Enqueue(ctx context.Context, tx *gorm.DB, topic, key string, payload any) error {
+	data, ok := payload.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+
+	id, err := utils.GetUniqueID()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	msg := &Message{
+		ID:          int64(id),
+		Topic:       topic,
+		Key:         key,
+		Payload:     string(data),
+		Status:      StatusPending,
+		NextRetryAt: now,
+		CreatedAt:   now,
+	}
+	db := o.db
+	if tx != nil {
+		db = tx.Session(&gorm.Session{NewDB: true})
+	}
+	// 事务已结束时返回错误，不能在事务外写入消息
+	if err = db.WithContext(ctx).Create(msg).Error; err != nil {
+		log.WithContext(ctx).Error("Outbox Enqueue", zap.String("topic", topic), zap.String("key", key), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (o *outbox) Relay(ctx context.Context) {
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		// 积压较多时连续发送，直到一批中有消息未发送
+		for {
+			sent, err := o.relayOnce(ctx)
+			if err != nil || sent < relayBatchSize {
+				break
+			}
+		}
+		if time.Since(lastCleanup) > cleanupInterval {
+			o.cleanup(ctx)
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayOnce 发送一批待发送的消息，返回发送成功的数量。
+// 某个 key 最早的待发送消息还没到重试时间时，这个 key 后面的消息都不发送
+func (o *outbox) relayOnce(ctx context.Context) (sent int, err error) {
+	now := time.Now()
+	waiting := o.db.Model(&Message{}).Select("msg_key").
+		Where("status = ? AND next_retry_at > ?", StatusPending, now)
+	var msgs []*Message
+	err = o.db.WithContext(ctx).
+		Where("status = ?", StatusPending).
+		Where("msg_key NOT IN (?)", waiting).
+		Order("id asc").
+		Limit(relayBatchSize).
+		Find(&msgs).Error
+	if err != nil {
+		log.WithContext(ctx).Error("Outbox relayOnce", zap.Error(err))
+		return 0, err
+	}
+
+	blocked := make(map[string]bool)
+	for _, msg := range msgs {
+		if blocked[msg.Key] {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return sent, err
+		}
+		if err := o.publish(ctx, msg); err != nil {
+			blocked[msg.Key] = true
+			o.markFailed(ctx, msg, err)
+			continue
+		}
+		o.markSent(ctx, msg)
+		sent++
+	}
+	return sent, nil
+}
+
+func (o *outbox) publish(ctx context.Context, msg *Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("publish panic: %v", r)
+		}
+	}()
+	p, ok := o.publishers[msg.Topic]
+	if !ok {
+		return fmt.Errorf("no publisher for topic %q", msg.Topic)
+	}
+	return p(ctx, msg)
+}
+
+func (o *outbox) markSent(ctx context.Context, msg *Message) {
+	now := time.Now()
+	err := o.db.WithContext(ctx).Model(&Message{}).Where("id = ?", msg.ID).
+		Updates(map[string]any{"status": StatusSent, "attempts": msg.Attempts + 1, "sent_at": now, "last_error": ""}).Error
+	if err != nil {
+		// 状态没有更新时消息会被再次发送，消费方需要能够处理重复消息
+		log.WithContext(ctx).Error("Outbox markSent", zap.Int64("id", msg.ID), zap.Error(err))
+	}
+}
+
+func (o *outbox) markFailed(ctx context.Context, msg *Message, cause error) {
+	attempts := msg.Attempts + 1
+	status := StatusPending
+	if attempts >= maxAttempts {
+		status = StatusDead
+		log.WithContext(ctx).Error("Outbox 消息超过最大发送次数，放弃发送",
+			zap.Int64("id", msg.ID), zap.String("topic", msg.Topic), zap.String("key", msg.Key), zap.Error(cause))
+	} else {
+		log.WithContext(ctx).Warn("Outbox 消息发送失败，等待重试",
+			zap.Int64("id", msg.ID), zap.String("topic", msg.Topic), zap.Int("attempts", attempts), zap.Error(cause))
+	}
+	err := o.db.WithContext(ctx).Model(&Message{}).Where("id = ?", msg.ID).
+		Updates(map[string]any{
+			"status":        status,
+			"attempts":      attempts,
+			"next_retry_at": time.Now().Add(retryDelay(attempts)),
+			"last_error":    truncate(cause.Error(), maxErrorLength),
+		}).Error
+	if err != nil {
+		log.WithContext(ctx).Error("Outbox markFailed", zap.Int64("id", msg.ID), zap.Error(err))
+	}
+}
+
+// cleanup 删除超过保留时间的已发送消息
+func (o *outbox) cleanup(ctx context.Context) {
+	res := o.db.WithContext(ctx).
+		Where("status = ? AND sent_at < ?", StatusSent, time.Now().Add(-sentRetention)).
+		Delete(&Message{})
+	if res.Error != nil {
+		log.WithContext(ctx).Error("Outbox cleanup", zap.Error(res.Error))
+		return
+	}
+	if res.RowsAffected > 0 {
+		log.WithContext(ctx).Info("Outbox 清理已发送消息", zap.Int64("count", res.RowsAffected))
+	}
+}
+
+func (o *outbox) Replay(ctx context.Context, topic string, start, end time.Time) (int64, error) {
+	tx := o.db.WithContext(ctx).Model(&Message{}).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Where("status IN ?", []int{StatusSent, StatusDead})
+	if topic != "" {
+		tx = tx.Where("topic = ?", topic)
+	}
+	tx = tx.Updates(map[string]any{"status": StatusPending, "attempts": 0, "next_retry_at": time.Now(), "last_error": ""})
+	if tx.Error != nil {
+		log.WithContext(ctx).Error("Outbox Replay", zap.Error(tx.Error))
+		return 0, tx.Error
+	}
+	return tx.RowsAffected, nil
+}
+
+// retryDelay 第 attempts 次发送失败后的重试间隔
+func retryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	if attempts > 20 {
+		return maxRetryDelay
+	}
+	d := time.Second << (attempts - 1)
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
+
+// truncate 按字符截断，避免截断后出现不完整的 UTF-8 字符
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := 0
+	for i := range s {
+		if i > n {
+			break
+		}
+		cut = i
+	}
+	return s[:cut]
+}
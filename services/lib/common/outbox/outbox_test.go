@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func Test_retryDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), retryDelay(0))
+	assert.Equal(t, time.Second, retryDelay(1))
+	assert.Equal(t, 8*time.Second, retryDelay(4))
+	assert.Equal(t, 512*time.Second, retryDelay(10))
+	assert.Equal(t, maxRetryDelay, retryDelay(11))
+	assert.Equal(t, maxRetryDelay, retryDelay(64))
+}
+
+func Test_truncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	// 中文每个字符 3 个字节
+	assert.Equal(t, "发", truncate("发件箱", 4))
+	assert.Equal(t, "发件", truncate("发件箱", 6))
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sqlite 驱动只按 DATETIME 类型解析时间，不能使用模型中的 datetime(3)
+	for _, ddl := range []string{
+		`CREATE TABLE outbox_message (id BIGINT PRIMARY KEY, topic VARCHAR(255) NOT NULL, msg_key VARCHAR(255) NOT NULL,
+			payload TEXT NOT NULL, status TINYINT NOT NULL DEFAULT 0, attempts INT NOT NULL DEFAULT 0, next_retry_at DATETIME NOT NULL,
+			last_error VARCHAR(1024), created_at DATETIME NOT NULL, sent_at DATETIME)`,
+		`CREATE TABLE outbox_relay_lease (name VARCHAR(255) PRIMARY KEY, holder VARCHAR(255) NOT NULL, expires_at DATETIME NOT NULL)`,
+	} {
+		if err = db.Exec(ddl).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+// 事务已结束时返回错误，不在事务外写入消息
+func Test_outbox_Enqueue_TxDone(t *testing.T) {
+	db := newTestDB(t)
+	o := NewOutbox(db, nil)
+
+	tx := db.Begin()
+	assert.NoError(t, o.Enqueue(context.Background(), tx, "t", "k", map[string]string{"a": "1"}))
+	assert.NoError(t, tx.Commit().Error)
+	assert.ErrorIs(t, o.Enqueue(context.Background(), tx, "t", "k", []byte("2")), sql.ErrTxDone)
+
+	var msgs []*Message
+	assert.NoError(t, db.Find(&msgs).Error)
+	if assert.Len(t, msgs, 1) {
+		assert.Equal(t, `{"a":"1"}`, msgs[0].Payload)
+	}
+}
+
+// 同一 key 的消息发送失败时后面的消息等待重试，其他 key 的消息不受影响
+func Test_outbox_relayOnce(t *testing.T) {
+	db := newTestDB(t)
+	var sent []string
+	failed := false
+	o := NewOutbox(db, Publishers{
+		"t": func(ctx context.Context, msg *Message) error {
+			if msg.Payload == "a1" && !failed {
+				failed = true
+				return errors.New("kafka unavailable")
+			}
+			sent = append(sent, msg.Payload)
+			return nil
+		},
+	}).(*outbox)
+
+	ctx := context.Background()
+	for _, m := range []struct{ key, payload string }{{"a", "a1"}, {"a", "a2"}, {"b", "b1"}} {
+		assert.NoError(t, o.Enqueue(ctx, nil, "t", m.key, []byte(m.payload)))
+	}
+
+	n, err := o.relayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"b1"}, sent)
+
+	// 还没到重试时间
+	n, err = o.relayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	assert.NoError(t, db.Model(&Message{}).Where("status = ?", StatusPending).Update("next_retry_at", time.Now().Add(-time.Second)).Error)
+	n, err = o.relayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"b1", "a1", "a2"}, sent)
+}
+
+// 租约由其他实例持有时不能获得，过期或释放后由其他实例接替，原实例续约失败
+func Test_RelayServer_acquire(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	s1 := NewRelayServer(nil, db, "svc")
+	s2 := NewRelayServer(nil, db, "svc")
+	assert.NotEqual(t, s1.holder, s2.holder)
+
+	assert.True(t, s1.acquire(ctx))
+	assert.True(t, s1.acquire(ctx))
+	assert.False(t, s2.acquire(ctx))
+
+	assert.NoError(t, db.Model(&Lease{}).Where("name = ?", "svc").Update("expires_at", time.Now().Add(-time.Second)).Error)
+	assert.True(t, s2.acquire(ctx))
+	assert.False(t, s1.acquire(ctx))
+
+	s2.release()
+	assert.True(t, s1.acquire(ctx))
+	// 不同名称的租约互不影响
+	assert.True(t, NewRelayServer(nil, db, "other").acquire(ctx))
+}
@@ -0,0 +1,168 @@
+package outbox
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/kweaver-ai/idrm-go-frame/core/telemetry/log"
+	"github.com/kweaver-ai/idrm-go-frame/core/utils"
+)
+
+const TableNameLease = "outbox_relay_lease"
+
+const (
+	// leaseDuration 租约有效期，持有租约的实例异常退出后其他实例最迟在租约过期后接替
+	leaseDuration = 30 * time.Second
+	// leaseRenewInterval 续约和竞选的间隔，需要明显小于 leaseDuration
+	leaseRenewInterval = 10 * time.Second
+)
+
+// Lease 运行 Relay 的租约，各实例使用本地时间判断租约是否过期，实例之间的时钟偏差需要明显小于 leaseDuration
+type Lease struct {
+	Name      string    `gorm:"column:name;type:varchar(255);primaryKey;comment:租约名称" json:"name"`            // 租约名称
+	Holder    string    `gorm:"column:holder;type:varchar(255);not null;comment:持有租约的实例" json:"holder"`       // 持有租约的实例
+	ExpiresAt time.Time `gorm:"column:expires_at;type:datetime(3);not null;comment:租约过期时间" json:"expires_at"` // 租约过期时间
+}
+
+// TableName Lease's table name
+func (*Lease) TableName() string {
+	return TableNameLease
+}
+
+// RelayServer 以服务的方式运行 Relay，实现 transport.Server。
+// 多副本部署时通过数据库租约选出一个实例运行 Relay，续约失败时停止发送，由其他实例接替
+type RelayServer struct {
+	outbox Outbox
+	db     *gorm.DB
+	// name 租约名称，同一个发件箱的各实例使用相同的名称
+	name string
+	// holder 当前实例的标识
+	holder string
+
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+}
+
+// NewRelayServer name 为租约名称，通常是服务名称
+func NewRelayServer(outbox Outbox, db *gorm.DB, name string) *RelayServer {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	// 同一主机上可能运行多个实例
+	id, _ := utils.GetUniqueID()
+	return &RelayServer{
+		outbox: outbox,
+		db:     db,
+		name:   name,
+		holder: hostname + "-" + strconv.FormatUint(id, 10),
+	}
+}
+
+func (s *RelayServer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mtx.Lock()
+	s.cancel = cancel
+	s.mtx.Unlock()
+
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		if s.acquire(ctx) {
+			log.Info("RelayServer 获得租约，开始发送发件箱消息", zap.String("name", s.name), zap.String("holder", s.holder))
+			s.lead(ctx, ticker)
+			log.Info("RelayServer 停止发送发件箱消息", zap.String("name", s.name), zap.String("holder", s.holder))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RelayServer) Stop(_ context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
+// lead 持有租约期间运行 Relay，直到续约失败或 ctx 结束
+func (s *RelayServer) lead(ctx context.Context, ticker *time.Ticker) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("RelayServer panic recovered", zap.Any("panic", r))
+			}
+		}()
+		s.outbox.Relay(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			<-done
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			if !s.acquire(ctx) {
+				cancel()
+				<-done
+				return
+			}
+		}
+	}
+}
+
+// acquire 获得或续约租约，租约由其他实例持有且没有过期时返回 false
+func (s *RelayServer) acquire(ctx context.Context) bool {
+	now := time.Now()
+	res := s.db.WithContext(ctx).Model(&Lease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", s.name, s.holder, now).
+		Updates(map[string]any{"holder": s.holder, "expires_at": now.Add(leaseDuration)})
+	if res.Error != nil {
+		log.WithContext(ctx).Error("RelayServer acquire", zap.String("name", s.name), zap.Error(res.Error))
+		return false
+	}
+	if res.RowsAffected > 0 {
+		return true
+	}
+	// 第一次竞选时租约不存在，多个实例同时写入时只有一个成功
+	res = s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Lease{Name: s.name, Holder: s.holder, ExpiresAt: now.Add(leaseDuration)})
+	if res.Error != nil {
+		log.WithContext(ctx).Error("RelayServer acquire", zap.String("name", s.name), zap.Error(res.Error))
+		return false
+	}
+	return res.RowsAffected > 0
+}
+
+// release 退出时释放租约，其他实例不需要等到租约过期
+func (s *RelayServer) release() {
+	err := s.db.Model(&Lease{}).
+		Where("name = ? AND holder = ?", s.name, s.holder).
+		Update("expires_at", time.Unix(0, 0)).Error
+	if err != nil {
+		log.Error("RelayServer release", zap.String("name", s.name), zap.Error(err))
+	}
+}
@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+const txCallbackName = "outbox:tx"
+
+type txContextKey struct{}
+
+// RegisterTxCallbacks 注册 gorm 回调，在新增、更新、删除时把当前事务放到 Statement.Context 中，
+// 数据库回调的 Transport 可以通过 TxFromContext 取得事务，在同一个事务中写入发件箱。需要在注册其他数据库回调之前调用
+func RegisterTxCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:begin_transaction").Before("gorm:create").Register(txCallbackName, withTx); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:begin_transaction").Before("gorm:update").Register(txCallbackName, withTx); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:begin_transaction").Before("gorm:delete").Register(txCallbackName, withTx)
+}
+
+func withTx(db *gorm.DB) {
+	// 只有在事务中执行时才需要
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); !ok {
+		return
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	db.Statement.Context = context.WithValue(ctx, txContextKey{}, db.Session(&gorm.Session{NewDB: true}))
+}
+
+// TxFromContext 获取 RegisterTxCallbacks 放到 Statement.Context 中的事务，不在事务中时返回 nil
+func TxFromContext(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx
+}